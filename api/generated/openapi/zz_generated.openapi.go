@@ -32,104 +32,129 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		porch.PackageRevision{}.OpenAPIModelName():                   schema_kptdev_porch_api_porch_PackageRevision(ref),
-		porch.PackageRevisionResources{}.OpenAPIModelName():          schema_kptdev_porch_api_porch_PackageRevisionResources(ref),
-		porch.PorchPackage{}.OpenAPIModelName():                      schema_kptdev_porch_api_porch_PorchPackage(ref),
-		v1alpha1.Condition{}.OpenAPIModelName():                      schema_porch_api_porch_v1alpha1_Condition(ref),
-		v1alpha1.Field{}.OpenAPIModelName():                          schema_porch_api_porch_v1alpha1_Field(ref),
-		v1alpha1.File{}.OpenAPIModelName():                           schema_porch_api_porch_v1alpha1_File(ref),
-		v1alpha1.GitLock{}.OpenAPIModelName():                        schema_porch_api_porch_v1alpha1_GitLock(ref),
-		v1alpha1.GitPackage{}.OpenAPIModelName():                     schema_porch_api_porch_v1alpha1_GitPackage(ref),
-		v1alpha1.Locator{}.OpenAPIModelName():                        schema_porch_api_porch_v1alpha1_Locator(ref),
-		v1alpha1.NameMeta{}.OpenAPIModelName():                       schema_porch_api_porch_v1alpha1_NameMeta(ref),
-		v1alpha1.OciPackage{}.OpenAPIModelName():                     schema_porch_api_porch_v1alpha1_OciPackage(ref),
-		v1alpha1.PackageCloneTaskSpec{}.OpenAPIModelName():           schema_porch_api_porch_v1alpha1_PackageCloneTaskSpec(ref),
-		v1alpha1.PackageEditTaskSpec{}.OpenAPIModelName():            schema_porch_api_porch_v1alpha1_PackageEditTaskSpec(ref),
-		v1alpha1.PackageInitTaskSpec{}.OpenAPIModelName():            schema_porch_api_porch_v1alpha1_PackageInitTaskSpec(ref),
-		v1alpha1.PackageMetadata{}.OpenAPIModelName():                schema_porch_api_porch_v1alpha1_PackageMetadata(ref),
-		v1alpha1.PackageRevision{}.OpenAPIModelName():                schema_porch_api_porch_v1alpha1_PackageRevision(ref),
-		v1alpha1.PackageRevisionList{}.OpenAPIModelName():            schema_porch_api_porch_v1alpha1_PackageRevisionList(ref),
-		v1alpha1.PackageRevisionRef{}.OpenAPIModelName():             schema_porch_api_porch_v1alpha1_PackageRevisionRef(ref),
-		v1alpha1.PackageRevisionResources{}.OpenAPIModelName():       schema_porch_api_porch_v1alpha1_PackageRevisionResources(ref),
-		v1alpha1.PackageRevisionResourcesList{}.OpenAPIModelName():   schema_porch_api_porch_v1alpha1_PackageRevisionResourcesList(ref),
-		v1alpha1.PackageRevisionResourcesSpec{}.OpenAPIModelName():   schema_porch_api_porch_v1alpha1_PackageRevisionResourcesSpec(ref),
-		v1alpha1.PackageRevisionResourcesStatus{}.OpenAPIModelName(): schema_porch_api_porch_v1alpha1_PackageRevisionResourcesStatus(ref),
-		v1alpha1.PackageRevisionSpec{}.OpenAPIModelName():            schema_porch_api_porch_v1alpha1_PackageRevisionSpec(ref),
-		v1alpha1.PackageRevisionStatus{}.OpenAPIModelName():          schema_porch_api_porch_v1alpha1_PackageRevisionStatus(ref),
-		v1alpha1.PackageSpec{}.OpenAPIModelName():                    schema_porch_api_porch_v1alpha1_PackageSpec(ref),
-		v1alpha1.PackageStatus{}.OpenAPIModelName():                  schema_porch_api_porch_v1alpha1_PackageStatus(ref),
-		v1alpha1.PackageUpgradeTaskSpec{}.OpenAPIModelName():         schema_porch_api_porch_v1alpha1_PackageUpgradeTaskSpec(ref),
-		v1alpha1.ParentReference{}.OpenAPIModelName():                schema_porch_api_porch_v1alpha1_ParentReference(ref),
-		v1alpha1.PorchPackage{}.OpenAPIModelName():                   schema_porch_api_porch_v1alpha1_PorchPackage(ref),
-		v1alpha1.PorchPackageList{}.OpenAPIModelName():               schema_porch_api_porch_v1alpha1_PorchPackageList(ref),
-		v1alpha1.ReadinessGate{}.OpenAPIModelName():                  schema_porch_api_porch_v1alpha1_ReadinessGate(ref),
-		v1alpha1.RenderStatus{}.OpenAPIModelName():                   schema_porch_api_porch_v1alpha1_RenderStatus(ref),
-		v1alpha1.RepositoryRef{}.OpenAPIModelName():                  schema_porch_api_porch_v1alpha1_RepositoryRef(ref),
-		v1alpha1.ResourceIdentifier{}.OpenAPIModelName():             schema_porch_api_porch_v1alpha1_ResourceIdentifier(ref),
-		v1alpha1.Result{}.OpenAPIModelName():                         schema_porch_api_porch_v1alpha1_Result(ref),
-		v1alpha1.ResultItem{}.OpenAPIModelName():                     schema_porch_api_porch_v1alpha1_ResultItem(ref),
-		v1alpha1.ResultList{}.OpenAPIModelName():                     schema_porch_api_porch_v1alpha1_ResultList(ref),
-		v1alpha1.SecretRef{}.OpenAPIModelName():                      schema_porch_api_porch_v1alpha1_SecretRef(ref),
-		v1alpha1.Selector{}.OpenAPIModelName():                       schema_porch_api_porch_v1alpha1_Selector(ref),
-		v1alpha1.Task{}.OpenAPIModelName():                           schema_porch_api_porch_v1alpha1_Task(ref),
-		v1alpha1.TaskResult{}.OpenAPIModelName():                     schema_porch_api_porch_v1alpha1_TaskResult(ref),
-		v1alpha1.UpstreamPackage{}.OpenAPIModelName():                schema_porch_api_porch_v1alpha1_UpstreamPackage(ref),
-		"github.com/kptdev/porch/api/porch/v1alpha2.PackageRevision": schema_porch_api_porch_v1alpha2_PackageRevision(ref),
-		resource.Quantity{}.OpenAPIModelName():                       schema_apimachinery_pkg_api_resource_Quantity(ref),
-		v1.APIGroup{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_APIGroup(ref),
-		v1.APIGroupList{}.OpenAPIModelName():                         schema_pkg_apis_meta_v1_APIGroupList(ref),
-		v1.APIResource{}.OpenAPIModelName():                          schema_pkg_apis_meta_v1_APIResource(ref),
-		v1.APIResourceList{}.OpenAPIModelName():                      schema_pkg_apis_meta_v1_APIResourceList(ref),
-		v1.APIVersions{}.OpenAPIModelName():                          schema_pkg_apis_meta_v1_APIVersions(ref),
-		v1.ApplyOptions{}.OpenAPIModelName():                         schema_pkg_apis_meta_v1_ApplyOptions(ref),
-		v1.Condition{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_Condition(ref),
-		v1.CreateOptions{}.OpenAPIModelName():                        schema_pkg_apis_meta_v1_CreateOptions(ref),
-		v1.DeleteOptions{}.OpenAPIModelName():                        schema_pkg_apis_meta_v1_DeleteOptions(ref),
-		v1.Duration{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_Duration(ref),
-		v1.FieldSelectorRequirement{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_FieldSelectorRequirement(ref),
-		v1.FieldsV1{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_FieldsV1(ref),
-		v1.GetOptions{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_GetOptions(ref),
-		v1.GroupKind{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_GroupKind(ref),
-		v1.GroupResource{}.OpenAPIModelName():                        schema_pkg_apis_meta_v1_GroupResource(ref),
-		v1.GroupVersion{}.OpenAPIModelName():                         schema_pkg_apis_meta_v1_GroupVersion(ref),
-		v1.GroupVersionForDiscovery{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
-		v1.GroupVersionKind{}.OpenAPIModelName():                     schema_pkg_apis_meta_v1_GroupVersionKind(ref),
-		v1.GroupVersionResource{}.OpenAPIModelName():                 schema_pkg_apis_meta_v1_GroupVersionResource(ref),
-		v1.InternalEvent{}.OpenAPIModelName():                        schema_pkg_apis_meta_v1_InternalEvent(ref),
-		v1.LabelSelector{}.OpenAPIModelName():                        schema_pkg_apis_meta_v1_LabelSelector(ref),
-		v1.LabelSelectorRequirement{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
-		v1.List{}.OpenAPIModelName():                                 schema_pkg_apis_meta_v1_List(ref),
-		v1.ListMeta{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_ListMeta(ref),
-		v1.ListOptions{}.OpenAPIModelName():                          schema_pkg_apis_meta_v1_ListOptions(ref),
-		v1.ManagedFieldsEntry{}.OpenAPIModelName():                   schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
-		v1.MicroTime{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_MicroTime(ref),
-		v1.ObjectMeta{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_ObjectMeta(ref),
-		v1.OwnerReference{}.OpenAPIModelName():                       schema_pkg_apis_meta_v1_OwnerReference(ref),
-		v1.PartialObjectMetadata{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
-		v1.PartialObjectMetadataList{}.OpenAPIModelName():            schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
-		v1.Patch{}.OpenAPIModelName():                                schema_pkg_apis_meta_v1_Patch(ref),
-		v1.PatchOptions{}.OpenAPIModelName():                         schema_pkg_apis_meta_v1_PatchOptions(ref),
-		v1.Preconditions{}.OpenAPIModelName():                        schema_pkg_apis_meta_v1_Preconditions(ref),
-		v1.RootPaths{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_RootPaths(ref),
-		v1.ServerAddressByClientCIDR{}.OpenAPIModelName():            schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
-		v1.ShardInfo{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_ShardInfo(ref),
-		v1.Status{}.OpenAPIModelName():                               schema_pkg_apis_meta_v1_Status(ref),
-		v1.StatusCause{}.OpenAPIModelName():                          schema_pkg_apis_meta_v1_StatusCause(ref),
-		v1.StatusDetails{}.OpenAPIModelName():                        schema_pkg_apis_meta_v1_StatusDetails(ref),
-		v1.Table{}.OpenAPIModelName():                                schema_pkg_apis_meta_v1_Table(ref),
-		v1.TableColumnDefinition{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
-		v1.TableOptions{}.OpenAPIModelName():                         schema_pkg_apis_meta_v1_TableOptions(ref),
-		v1.TableRow{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_TableRow(ref),
-		v1.TableRowCondition{}.OpenAPIModelName():                    schema_pkg_apis_meta_v1_TableRowCondition(ref),
-		v1.Time{}.OpenAPIModelName():                                 schema_pkg_apis_meta_v1_Time(ref),
-		v1.Timestamp{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_Timestamp(ref),
-		v1.TypeMeta{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_TypeMeta(ref),
-		v1.UpdateOptions{}.OpenAPIModelName():                        schema_pkg_apis_meta_v1_UpdateOptions(ref),
-		v1.WatchEvent{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_WatchEvent(ref),
-		runtime.RawExtension{}.OpenAPIModelName():                    schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
-		runtime.TypeMeta{}.OpenAPIModelName():                        schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
-		runtime.Unknown{}.OpenAPIModelName():                         schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
-		version.Info{}.OpenAPIModelName():                            schema_k8sio_apimachinery_pkg_version_Info(ref),
+		porch.PackageRevision{}.OpenAPIModelName():                      schema_kptdev_porch_api_porch_PackageRevision(ref),
+		porch.PackageRevisionResources{}.OpenAPIModelName():             schema_kptdev_porch_api_porch_PackageRevisionResources(ref),
+		porch.PorchPackage{}.OpenAPIModelName():                         schema_kptdev_porch_api_porch_PorchPackage(ref),
+		v1alpha1.BundledPackageRevision{}.OpenAPIModelName():            schema_porch_api_porch_v1alpha1_BundledPackageRevision(ref),
+		v1alpha1.Condition{}.OpenAPIModelName():                         schema_porch_api_porch_v1alpha1_Condition(ref),
+		v1alpha1.DeploymentStatus{}.OpenAPIModelName():                  schema_porch_api_porch_v1alpha1_DeploymentStatus(ref),
+		v1alpha1.DeploymentTargetStatus{}.OpenAPIModelName():            schema_porch_api_porch_v1alpha1_DeploymentTargetStatus(ref),
+		v1alpha1.Field{}.OpenAPIModelName():                             schema_porch_api_porch_v1alpha1_Field(ref),
+		v1alpha1.File{}.OpenAPIModelName():                              schema_porch_api_porch_v1alpha1_File(ref),
+		v1alpha1.GitLock{}.OpenAPIModelName():                           schema_porch_api_porch_v1alpha1_GitLock(ref),
+		v1alpha1.GitPackage{}.OpenAPIModelName():                        schema_porch_api_porch_v1alpha1_GitPackage(ref),
+		v1alpha1.Locator{}.OpenAPIModelName():                           schema_porch_api_porch_v1alpha1_Locator(ref),
+		v1alpha1.NameMeta{}.OpenAPIModelName():                          schema_porch_api_porch_v1alpha1_NameMeta(ref),
+		v1alpha1.OciPackage{}.OpenAPIModelName():                        schema_porch_api_porch_v1alpha1_OciPackage(ref),
+		v1alpha1.PackageCloneTaskSpec{}.OpenAPIModelName():              schema_porch_api_porch_v1alpha1_PackageCloneTaskSpec(ref),
+		v1alpha1.PackageEditTaskSpec{}.OpenAPIModelName():               schema_porch_api_porch_v1alpha1_PackageEditTaskSpec(ref),
+		v1alpha1.PackageInitTaskSpec{}.OpenAPIModelName():               schema_porch_api_porch_v1alpha1_PackageInitTaskSpec(ref),
+		v1alpha1.PackageMetadata{}.OpenAPIModelName():                   schema_porch_api_porch_v1alpha1_PackageMetadata(ref),
+		v1alpha1.PackageRevision{}.OpenAPIModelName():                   schema_porch_api_porch_v1alpha1_PackageRevision(ref),
+		v1alpha1.PackageRevisionBatch{}.OpenAPIModelName():              schema_porch_api_porch_v1alpha1_PackageRevisionBatch(ref),
+		v1alpha1.PackageRevisionBatchResult{}.OpenAPIModelName():        schema_porch_api_porch_v1alpha1_PackageRevisionBatchResult(ref),
+		v1alpha1.PackageRevisionBatchSpec{}.OpenAPIModelName():          schema_porch_api_porch_v1alpha1_PackageRevisionBatchSpec(ref),
+		v1alpha1.PackageRevisionBatchStatus{}.OpenAPIModelName():        schema_porch_api_porch_v1alpha1_PackageRevisionBatchStatus(ref),
+		v1alpha1.PackageRevisionConditions{}.OpenAPIModelName():         schema_porch_api_porch_v1alpha1_PackageRevisionConditions(ref),
+		v1alpha1.PackageRevisionConditionsSpec{}.OpenAPIModelName():     schema_porch_api_porch_v1alpha1_PackageRevisionConditionsSpec(ref),
+		v1alpha1.PackageRevisionConditionsStatus{}.OpenAPIModelName():   schema_porch_api_porch_v1alpha1_PackageRevisionConditionsStatus(ref),
+		v1alpha1.PackageRevisionDependencies{}.OpenAPIModelName():       schema_porch_api_porch_v1alpha1_PackageRevisionDependencies(ref),
+		v1alpha1.PackageRevisionDependenciesStatus{}.OpenAPIModelName(): schema_porch_api_porch_v1alpha1_PackageRevisionDependenciesStatus(ref),
+		v1alpha1.PackageRevisionDependency{}.OpenAPIModelName():         schema_porch_api_porch_v1alpha1_PackageRevisionDependency(ref),
+		v1alpha1.PackageRevisionList{}.OpenAPIModelName():               schema_porch_api_porch_v1alpha1_PackageRevisionList(ref),
+		v1alpha1.PackageRevisionPipeline{}.OpenAPIModelName():           schema_porch_api_porch_v1alpha1_PackageRevisionPipeline(ref),
+		v1alpha1.PackageRevisionPipelineSpec{}.OpenAPIModelName():       schema_porch_api_porch_v1alpha1_PackageRevisionPipelineSpec(ref),
+		v1alpha1.PackageRevisionPipelineStatus{}.OpenAPIModelName():     schema_porch_api_porch_v1alpha1_PackageRevisionPipelineStatus(ref),
+		v1alpha1.PackageRevisionRef{}.OpenAPIModelName():                schema_porch_api_porch_v1alpha1_PackageRevisionRef(ref),
+		v1alpha1.PackageRevisionResources{}.OpenAPIModelName():          schema_porch_api_porch_v1alpha1_PackageRevisionResources(ref),
+		v1alpha1.PackageRevisionResourcesList{}.OpenAPIModelName():      schema_porch_api_porch_v1alpha1_PackageRevisionResourcesList(ref),
+		v1alpha1.PackageRevisionResourcesSpec{}.OpenAPIModelName():      schema_porch_api_porch_v1alpha1_PackageRevisionResourcesSpec(ref),
+		v1alpha1.PackageRevisionResourcesStatus{}.OpenAPIModelName():    schema_porch_api_porch_v1alpha1_PackageRevisionResourcesStatus(ref),
+		v1alpha1.PackageRevisionSpec{}.OpenAPIModelName():               schema_porch_api_porch_v1alpha1_PackageRevisionSpec(ref),
+		v1alpha1.PackageRevisionStatus{}.OpenAPIModelName():             schema_porch_api_porch_v1alpha1_PackageRevisionStatus(ref),
+		v1alpha1.PackageSpec{}.OpenAPIModelName():                       schema_porch_api_porch_v1alpha1_PackageSpec(ref),
+		v1alpha1.PackageStatus{}.OpenAPIModelName():                     schema_porch_api_porch_v1alpha1_PackageStatus(ref),
+		v1alpha1.PackageTemplate{}.OpenAPIModelName():                   schema_porch_api_porch_v1alpha1_PackageTemplate(ref),
+		v1alpha1.PackageUpgradePreview{}.OpenAPIModelName():             schema_porch_api_porch_v1alpha1_PackageUpgradePreview(ref),
+		v1alpha1.PackageUpgradePreviewStatus{}.OpenAPIModelName():       schema_porch_api_porch_v1alpha1_PackageUpgradePreviewStatus(ref),
+		v1alpha1.PackageUpgradeTaskSpec{}.OpenAPIModelName():            schema_porch_api_porch_v1alpha1_PackageUpgradeTaskSpec(ref),
+		v1alpha1.ParentReference{}.OpenAPIModelName():                   schema_porch_api_porch_v1alpha1_ParentReference(ref),
+		v1alpha1.PipelineFunction{}.OpenAPIModelName():                  schema_porch_api_porch_v1alpha1_PipelineFunction(ref),
+		v1alpha1.PorchPackage{}.OpenAPIModelName():                      schema_porch_api_porch_v1alpha1_PorchPackage(ref),
+		v1alpha1.PorchPackageList{}.OpenAPIModelName():                  schema_porch_api_porch_v1alpha1_PorchPackageList(ref),
+		v1alpha1.ReadinessGate{}.OpenAPIModelName():                     schema_porch_api_porch_v1alpha1_ReadinessGate(ref),
+		v1alpha1.RenderStatus{}.OpenAPIModelName():                      schema_porch_api_porch_v1alpha1_RenderStatus(ref),
+		v1alpha1.RepositoryBundle{}.OpenAPIModelName():                  schema_porch_api_porch_v1alpha1_RepositoryBundle(ref),
+		v1alpha1.RepositoryBundleSpec{}.OpenAPIModelName():              schema_porch_api_porch_v1alpha1_RepositoryBundleSpec(ref),
+		v1alpha1.RepositoryBundleStatus{}.OpenAPIModelName():            schema_porch_api_porch_v1alpha1_RepositoryBundleStatus(ref),
+		v1alpha1.RepositoryRef{}.OpenAPIModelName():                     schema_porch_api_porch_v1alpha1_RepositoryRef(ref),
+		v1alpha1.ResourceIdentifier{}.OpenAPIModelName():                schema_porch_api_porch_v1alpha1_ResourceIdentifier(ref),
+		v1alpha1.Result{}.OpenAPIModelName():                            schema_porch_api_porch_v1alpha1_Result(ref),
+		v1alpha1.ResultItem{}.OpenAPIModelName():                        schema_porch_api_porch_v1alpha1_ResultItem(ref),
+		v1alpha1.ResultList{}.OpenAPIModelName():                        schema_porch_api_porch_v1alpha1_ResultList(ref),
+		v1alpha1.SecretRef{}.OpenAPIModelName():                         schema_porch_api_porch_v1alpha1_SecretRef(ref),
+		v1alpha1.Selector{}.OpenAPIModelName():                          schema_porch_api_porch_v1alpha1_Selector(ref),
+		v1alpha1.Subpackage{}.OpenAPIModelName():                        schema_porch_api_porch_v1alpha1_Subpackage(ref),
+		v1alpha1.Task{}.OpenAPIModelName():                              schema_porch_api_porch_v1alpha1_Task(ref),
+		v1alpha1.TaskResult{}.OpenAPIModelName():                        schema_porch_api_porch_v1alpha1_TaskResult(ref),
+		v1alpha1.UpgradePreviewResource{}.OpenAPIModelName():            schema_porch_api_porch_v1alpha1_UpgradePreviewResource(ref),
+		v1alpha1.UpstreamPackage{}.OpenAPIModelName():                   schema_porch_api_porch_v1alpha1_UpstreamPackage(ref),
+		"github.com/kptdev/porch/api/porch/v1alpha2.PackageRevision":    schema_porch_api_porch_v1alpha2_PackageRevision(ref),
+		resource.Quantity{}.OpenAPIModelName():                          schema_apimachinery_pkg_api_resource_Quantity(ref),
+		v1.APIGroup{}.OpenAPIModelName():                                schema_pkg_apis_meta_v1_APIGroup(ref),
+		v1.APIGroupList{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_APIGroupList(ref),
+		v1.APIResource{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_APIResource(ref),
+		v1.APIResourceList{}.OpenAPIModelName():                         schema_pkg_apis_meta_v1_APIResourceList(ref),
+		v1.APIVersions{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_APIVersions(ref),
+		v1.ApplyOptions{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_ApplyOptions(ref),
+		v1.Condition{}.OpenAPIModelName():                               schema_pkg_apis_meta_v1_Condition(ref),
+		v1.CreateOptions{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_CreateOptions(ref),
+		v1.DeleteOptions{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_DeleteOptions(ref),
+		v1.Duration{}.OpenAPIModelName():                                schema_pkg_apis_meta_v1_Duration(ref),
+		v1.FieldSelectorRequirement{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_FieldSelectorRequirement(ref),
+		v1.FieldsV1{}.OpenAPIModelName():                                schema_pkg_apis_meta_v1_FieldsV1(ref),
+		v1.GetOptions{}.OpenAPIModelName():                              schema_pkg_apis_meta_v1_GetOptions(ref),
+		v1.GroupKind{}.OpenAPIModelName():                               schema_pkg_apis_meta_v1_GroupKind(ref),
+		v1.GroupResource{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_GroupResource(ref),
+		v1.GroupVersion{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_GroupVersion(ref),
+		v1.GroupVersionForDiscovery{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
+		v1.GroupVersionKind{}.OpenAPIModelName():                        schema_pkg_apis_meta_v1_GroupVersionKind(ref),
+		v1.GroupVersionResource{}.OpenAPIModelName():                    schema_pkg_apis_meta_v1_GroupVersionResource(ref),
+		v1.InternalEvent{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_InternalEvent(ref),
+		v1.LabelSelector{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_LabelSelector(ref),
+		v1.LabelSelectorRequirement{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
+		v1.List{}.OpenAPIModelName():                                    schema_pkg_apis_meta_v1_List(ref),
+		v1.ListMeta{}.OpenAPIModelName():                                schema_pkg_apis_meta_v1_ListMeta(ref),
+		v1.ListOptions{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_ListOptions(ref),
+		v1.ManagedFieldsEntry{}.OpenAPIModelName():                      schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
+		v1.MicroTime{}.OpenAPIModelName():                               schema_pkg_apis_meta_v1_MicroTime(ref),
+		v1.ObjectMeta{}.OpenAPIModelName():                              schema_pkg_apis_meta_v1_ObjectMeta(ref),
+		v1.OwnerReference{}.OpenAPIModelName():                          schema_pkg_apis_meta_v1_OwnerReference(ref),
+		v1.PartialObjectMetadata{}.OpenAPIModelName():                   schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
+		v1.PartialObjectMetadataList{}.OpenAPIModelName():               schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
+		v1.Patch{}.OpenAPIModelName():                                   schema_pkg_apis_meta_v1_Patch(ref),
+		v1.PatchOptions{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_PatchOptions(ref),
+		v1.Preconditions{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_Preconditions(ref),
+		v1.RootPaths{}.OpenAPIModelName():                               schema_pkg_apis_meta_v1_RootPaths(ref),
+		v1.ServerAddressByClientCIDR{}.OpenAPIModelName():               schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
+		v1.ShardInfo{}.OpenAPIModelName():                               schema_pkg_apis_meta_v1_ShardInfo(ref),
+		v1.Status{}.OpenAPIModelName():                                  schema_pkg_apis_meta_v1_Status(ref),
+		v1.StatusCause{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_StatusCause(ref),
+		v1.StatusDetails{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_StatusDetails(ref),
+		v1.Table{}.OpenAPIModelName():                                   schema_pkg_apis_meta_v1_Table(ref),
+		v1.TableColumnDefinition{}.OpenAPIModelName():                   schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
+		v1.TableOptions{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_TableOptions(ref),
+		v1.TableRow{}.OpenAPIModelName():                                schema_pkg_apis_meta_v1_TableRow(ref),
+		v1.TableRowCondition{}.OpenAPIModelName():                       schema_pkg_apis_meta_v1_TableRowCondition(ref),
+		v1.Time{}.OpenAPIModelName():                                    schema_pkg_apis_meta_v1_Time(ref),
+		v1.Timestamp{}.OpenAPIModelName():                               schema_pkg_apis_meta_v1_Timestamp(ref),
+		v1.TypeMeta{}.OpenAPIModelName():                                schema_pkg_apis_meta_v1_TypeMeta(ref),
+		v1.UpdateOptions{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_UpdateOptions(ref),
+		v1.WatchEvent{}.OpenAPIModelName():                              schema_pkg_apis_meta_v1_WatchEvent(ref),
+		runtime.RawExtension{}.OpenAPIModelName():                       schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
+		runtime.TypeMeta{}.OpenAPIModelName():                           schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
+		runtime.Unknown{}.OpenAPIModelName():                            schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
+		version.Info{}.OpenAPIModelName():                               schema_k8sio_apimachinery_pkg_version_Info(ref),
 	}
 }
 
@@ -274,6 +299,96 @@ func schema_kptdev_porch_api_porch_PorchPackage(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_porch_api_porch_v1alpha1_BundledPackageRevision(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BundledPackageRevision is a package revision in a RepositoryBundle.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"packageName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PackageName identifies the package in the repository.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"workspaceName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WorkspaceName identifies the package revision within its package.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"revision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Revision is the revision number of the package revision; 0 if it is not published.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"lifecycle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Lifecycle is the lifecycle of the package revision.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tasks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tasks record how the package revision was created, and thereby its upstream.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.Task{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"publishedBy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PublishedBy is the identity of the user who approved the package revision.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"publishTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PublishedAt is the time when the package revision was approved.",
+							Ref:         ref(v1.Time{}.OpenAPIModelName()),
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources are the files of the package revision, keyed by path.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"packageName", "workspaceName", "lifecycle"},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.Task{}.OpenAPIModelName(), v1.Time{}.OpenAPIModelName()},
+	}
+}
+
 func schema_porch_api_porch_v1alpha1_Condition(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -313,6 +428,107 @@ func schema_porch_api_porch_v1alpha1_Condition(ref common.ReferenceCallback) com
 	}
 }
 
+func schema_porch_api_porch_v1alpha1_DeploymentStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeploymentStatus is the deployment feedback for a package revision.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State is the worst state reported across all targets.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"targets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Targets holds the last report for each deployment target.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.DeploymentTargetStatus{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.DeploymentTargetStatus{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_DeploymentTargetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeploymentTargetStatus is the deployment feedback from a single target.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Target identifies where the package revision is deployed, such as a cluster.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State is one of Applied, Failed, Drifted or Progressing.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"syncedCommit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SyncedCommit is the commit the GitOps agent last synced.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastSyncTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSyncTime is the time of the last sync.",
+							Ref:         ref(v1.Time{}.OpenAPIModelName()),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable summary from the GitOps agent.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources is the number of resources the GitOps agent reported on.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"healthyResources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HealthyResources is the number of those resources that are healthy.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"target"},
+			},
+		},
+		Dependencies: []string{
+			v1.Time{}.OpenAPIModelName()},
+	}
+}
+
 func schema_porch_api_porch_v1alpha1_Field(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -621,9 +837,17 @@ func schema_porch_api_porch_v1alpha1_PackageInitTaskSpec(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "`Template` is a starter template package that the package is created from. The package is not tracked as a clone of the template.",
+							Ref:         ref(v1alpha1.PackageTemplate{}.OpenAPIModelName()),
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			v1alpha1.PackageTemplate{}.OpenAPIModelName()},
 	}
 }
 
@@ -716,11 +940,11 @@ func schema_porch_api_porch_v1alpha1_PackageRevision(ref common.ReferenceCallbac
 	}
 }
 
-func schema_porch_api_porch_v1alpha1_PackageRevisionList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_porch_api_porch_v1alpha1_PackageRevisionBatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PackageRevisionList",
+				Description: "PackageRevisionBatch applies a lifecycle operation to many package revisions in one request. The changes to package revisions in the same repository are pushed together in a single push, and either all of them are applied or none of them is. PackageRevisionBatch can only be created; the created object reports the outcome for each package revision in its status.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -740,135 +964,681 @@ func schema_porch_api_porch_v1alpha1_PackageRevisionList(ref common.ReferenceCal
 					"metadata": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref(v1.ListMeta{}.OpenAPIModelName()),
+							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
 						},
 					},
-					"items": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref(v1alpha1.PackageRevision{}.OpenAPIModelName()),
-									},
-								},
-							},
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.PackageRevisionBatchSpec{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.PackageRevisionBatchStatus{}.OpenAPIModelName()),
 						},
 					},
 				},
-				Required: []string{"items"},
 			},
 		},
 		Dependencies: []string{
-			v1alpha1.PackageRevision{}.OpenAPIModelName(), v1.ListMeta{}.OpenAPIModelName()},
+			v1alpha1.PackageRevisionBatchSpec{}.OpenAPIModelName(), v1alpha1.PackageRevisionBatchStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
 	}
 }
 
-func schema_porch_api_porch_v1alpha1_PackageRevisionRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_porch_api_porch_v1alpha1_PackageRevisionBatchResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PackageRevisionRef is a reference to a package revision.",
+				Description: "PackageRevisionBatchResult is the outcome of a PackageRevisionBatch for a package revision.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "`Name` is the name of the referenced PackageRevision resource.",
+							Description: "Name is the name of the package revision.",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-				},
-				Required: []string{"name"},
-			},
-		},
-	}
-}
-
-func schema_porch_api_porch_v1alpha1_PackageRevisionResources(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "PackageRevisionResources",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"kind": {
+					"repository": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Description: "Repository is the name of the repository of the package revision.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"apiVersion": {
+					"outcome": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Description: "Outcome is the outcome of the operation for the package revision.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"metadata": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
-						},
-					},
-					"spec": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref(v1alpha1.PackageRevisionResourcesSpec{}.OpenAPIModelName()),
-						},
-					},
-					"status": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref(v1alpha1.PackageRevisionResourcesStatus{}.OpenAPIModelName()),
+							Description: "Message explains why the operation failed or was aborted.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"name", "outcome"},
 			},
 		},
-		Dependencies: []string{
-			v1alpha1.PackageRevisionResourcesSpec{}.OpenAPIModelName(), v1alpha1.PackageRevisionResourcesStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
 	}
 }
 
-func schema_porch_api_porch_v1alpha1_PackageRevisionResourcesList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_porch_api_porch_v1alpha1_PackageRevisionBatchSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PackageRevisionResourcesList",
+				Description: "PackageRevisionBatchSpec selects the package revisions of a PackageRevisionBatch and the operation to apply to them. At least one of PackageRevisions and Selector must be set; if both are set, the batch applies to the package revisions matched by either.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"apiVersion": {
+					"operation": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Description: "Operation is the lifecycle operation to apply.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"metadata": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref(v1.ListMeta{}.OpenAPIModelName()),
-						},
-					},
-					"items": {
+					"packageRevisions": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "PackageRevisions lists the names of the package revisions to apply the operation to.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref(v1alpha1.PackageRevisionResources{}.OpenAPIModelName()),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector selects the package revisions to apply the operation to by their labels.",
+							Ref:         ref(v1.LabelSelector{}.OpenAPIModelName()),
+						},
+					},
+				},
+				Required: []string{"operation"},
+			},
+		},
+		Dependencies: []string{
+			v1.LabelSelector{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionBatchStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionBatchStatus reports the outcome of a PackageRevisionBatch.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"results": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Results holds the outcome for each package revision, ordered by repository and name.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.PackageRevisionBatchResult{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.PackageRevisionBatchResult{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionConditions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionConditions holds the conditions in the Kptfile status of a package revision. It is served by the packagerevisions/conditions subresource, so that actors outside the package, such as CI systems, security scanners or reviewers, can satisfy its readiness gates. Updating it sets, changes or clears conditions in the Kptfile of a draft or proposed package revision without rendering the package. The caller must be allowed to \"set\" the packageconditions resource named after the type of every condition it changes.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.PackageRevisionConditionsSpec{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.PackageRevisionConditionsStatus{}.OpenAPIModelName()),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.PackageRevisionConditionsSpec{}.OpenAPIModelName(), v1alpha1.PackageRevisionConditionsStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionConditionsSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionConditionsSpec lists the conditions of a package revision.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions are the conditions in the Kptfile status. On update, conditions that are not listed are cleared.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.Condition{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.Condition{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionConditionsStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionConditionsStatus reports the readiness gates of a package revision.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"readinessGates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadinessGates are the readiness gates in the Kptfile.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.ReadinessGate{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"ready": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ready is true if the condition of every readiness gate is True.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"ready"},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.ReadinessGate{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionDependencies(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionDependencies describes the lineage of a package revision: the chain of upstream package revisions it was cloned or upgraded from, and the package revisions in the namespace that were cloned or upgraded from it. It is served read-only by the packagerevisions/dependencies subresource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.PackageRevisionDependenciesStatus{}.OpenAPIModelName()),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.PackageRevisionDependenciesStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionDependenciesStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionDependenciesStatus lists the upstream and downstream package revisions of a package revision.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"upstream": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Upstream is the chain of upstream package revisions, starting with the package revision that this package revision was cloned or upgraded from.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.PackageRevisionDependency{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"downstream": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Downstream lists the package revisions that were cloned or upgraded from this package revision, directly or transitively, in breadth-first order.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.PackageRevisionDependency{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.PackageRevisionDependency{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionDependency(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionDependency identifies a package revision in the lineage of another package revision.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the package revision.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"repository": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RepositoryName is the name of the Repository object containing the package revision.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"packageName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PackageName identifies the package in the repository.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"revision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Revision identifies the version of the package.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"lifecycle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Lifecycle is the lifecycle of the package revision.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"upstreamName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpstreamName is the name of the package revision this package revision was cloned or upgraded from.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"latestUpstreamName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LatestUpstreamName is the name of the latest published revision of the upstream package.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"behindUpstream": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BehindUpstream is true if a newer published revision of the upstream package exists.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionList",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1.ListMeta{}.OpenAPIModelName()),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.PackageRevision{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.PackageRevision{}.OpenAPIModelName(), v1.ListMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionPipeline(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionPipeline is the kpt function pipeline in the Kptfile of a package revision. It is served by the packagerevisions/pipeline subresource. Updating it rewrites the pipeline in the Kptfile of a draft package revision and renders the package; comments in the Kptfile and the fields of functions that are not part of PipelineFunction are kept. A dry-run update renders the package with the new pipeline without saving it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.PackageRevisionPipelineSpec{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.PackageRevisionPipelineStatus{}.OpenAPIModelName()),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.PackageRevisionPipelineSpec{}.OpenAPIModelName(), v1alpha1.PackageRevisionPipelineStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionPipelineSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionPipelineSpec lists the functions of a pipeline in the order in which they run.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"mutators": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mutators are the functions that transform the resources of the package.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.PipelineFunction{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"validators": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Validators are the functions that validate the resources of the package.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.PipelineFunction{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.PipelineFunction{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionPipelineStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionPipelineStatus reports the result of rendering the package with the pipeline.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"renderStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RenderStatus is the result of the render done by an update or a dry-run update.",
+							Default:     map[string]interface{}{},
+							Ref:         ref(v1alpha1.RenderStatus{}.OpenAPIModelName()),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.RenderStatus{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionRef is a reference to a package revision.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "`Name` is the name of the referenced PackageRevision resource.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionResources(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionResources",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.PackageRevisionResourcesSpec{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.PackageRevisionResourcesStatus{}.OpenAPIModelName()),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.PackageRevisionResourcesSpec{}.OpenAPIModelName(), v1alpha1.PackageRevisionResourcesStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageRevisionResourcesList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageRevisionResourcesList",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1.ListMeta{}.OpenAPIModelName()),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.PackageRevisionResources{}.OpenAPIModelName()),
 									},
 								},
 							},
@@ -954,11 +1724,25 @@ func schema_porch_api_porch_v1alpha1_PackageRevisionResourcesStatus(ref common.R
 							Ref:         ref(v1alpha1.RenderStatus{}.OpenAPIModelName()),
 						},
 					},
+					"subpackages": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subpackages lists the independent subpackages embedded in the package revision, ordered by path.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.Subpackage{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			v1alpha1.RenderStatus{}.OpenAPIModelName()},
+			v1alpha1.RenderStatus{}.OpenAPIModelName(), v1alpha1.Subpackage{}.OpenAPIModelName()},
 	}
 }
 
@@ -1108,11 +1892,31 @@ func schema_porch_api_porch_v1alpha1_PackageRevisionStatus(ref common.ReferenceC
 							Format:      "int64",
 						},
 					},
+					"deploymentStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeploymentStatus aggregates the PackageRevisionDeploymentStatus reports of GitOps agents for a package revision in a deployment repository.",
+							Ref:         ref(v1alpha1.DeploymentStatus{}.OpenAPIModelName()),
+						},
+					},
+					"subpackages": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subpackages lists the independent subpackages embedded in the package revision, ordered by path.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.Subpackage{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			v1alpha1.Condition{}.OpenAPIModelName(), v1alpha1.Locator{}.OpenAPIModelName(), v1.Time{}.OpenAPIModelName()},
+			v1alpha1.Condition{}.OpenAPIModelName(), v1alpha1.DeploymentStatus{}.OpenAPIModelName(), v1alpha1.Locator{}.OpenAPIModelName(), v1alpha1.Subpackage{}.OpenAPIModelName(), v1.Time{}.OpenAPIModelName()},
 	}
 }
 
@@ -1143,16 +1947,141 @@ func schema_porch_api_porch_v1alpha1_PackageSpec(ref common.ReferenceCallback) c
 	}
 }
 
-func schema_porch_api_porch_v1alpha1_PackageStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_porch_api_porch_v1alpha1_PackageStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageStatus defines the observed state of Package",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"latestRevision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LatestRevision identifies the package revision that is the latest published package revision belonging to this package. Latest is determined by comparing packages that have valid semantic version as their revision. In case of git backend, branch tracking revisions like \"main\" and in case of oci backend, revisions tracking \"latest\" are not considered during selection of the latest revision.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageTemplate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageTemplate references a starter template package and the values of its parameters. Exactly one of `UpstreamRef` or `Git` must be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"upstreamRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "`UpstreamRef` is the reference to a published template package revision in a registered repository.",
+							Ref:         ref(v1alpha1.PackageRevisionRef{}.OpenAPIModelName()),
+						},
+					},
+					"git": {
+						SchemaProps: spec.SchemaProps{
+							Description: "`Git` is a template package stored in a Git repository.",
+							Ref:         ref(v1alpha1.GitPackage{}.OpenAPIModelName()),
+						},
+					},
+					"parameters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "`Parameters` are the values of the parameters declared by the template. They are validated against the TemplateParameters resource of the template.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.GitPackage{}.OpenAPIModelName(), v1alpha1.PackageRevisionRef{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageUpgradePreview(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PackageUpgradePreview previews the upgrade of a package revision, or of an independent subpackage in a draft package revision, to a new upstream package revision, without creating or changing any package revision. PackageUpgradePreview can only be created; the created object reports the outcome of the upgrade for each resource of the package in its status.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec is the upgrade to preview, as it would be given in the upgrade task of a package revision.",
+							Default:     map[string]interface{}{},
+							Ref:         ref(v1alpha1.PackageUpgradeTaskSpec{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.PackageUpgradePreviewStatus{}.OpenAPIModelName()),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.PackageUpgradePreviewStatus{}.OpenAPIModelName(), v1alpha1.PackageUpgradeTaskSpec{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_PackageUpgradePreviewStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PackageStatus defines the observed state of Package",
+				Description: "PackageUpgradePreviewStatus reports the outcome of a PackageUpgradePreview.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"latestRevision": {
+					"resources": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LatestRevision identifies the package revision that is the latest published package revision belonging to this package. Latest is determined by comparing packages that have valid semantic version as their revision. In case of git backend, branch tracking revisions like \"main\" and in case of oci backend, revisions tracking \"latest\" are not considered during selection of the latest revision.",
+							Description: "Resources holds the outcome for each resource of the local package, the new upstream and the upgraded package, ordered by file and by position in the file.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.UpgradePreviewResource{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"conflicts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conflicts is the number of resources with the Conflict outcome.",
 							Type:        []string{"integer"},
 							Format:      "int32",
 						},
@@ -1160,6 +2089,8 @@ func schema_porch_api_porch_v1alpha1_PackageStatus(ref common.ReferenceCallback)
 				},
 			},
 		},
+		Dependencies: []string{
+			v1alpha1.UpgradePreviewResource{}.OpenAPIModelName()},
 	}
 }
 
@@ -1199,7 +2130,7 @@ func schema_porch_api_porch_v1alpha1_PackageUpgradeTaskSpec(ref common.Reference
 					},
 					"strategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Defines which strategy should be used to update the package. It defaults to 'resource-merge'.\n * resource-merge: Perform a structural comparison of the original /\n   updated resources, and merge the changes into the local package.\n * fast-forward: Fail without updating if the local package was modified\n   since it was fetched.\n * force-delete-replace: Wipe all the local changes to the package and replace\n   it with the remote version.\n * copy-merge: Copy all the remote changes to the local package.",
+							Description: "Defines which strategy should be used to update the package. It defaults to 'resource-merge'.\n * resource-merge: Perform a structural comparison of the original /\n   updated resources, and merge the changes into the local package.\n * fast-forward: Fail without updating if the local package was modified\n   since it was fetched.\n * force-delete-replace: Wipe all the local changes to the package and replace\n   it with the remote version.\n * copy-merge: Copy all the remote changes to the local package.\n * manual-merge: Merge like resource-merge, but keep the local value of every\n   conflicting change and record the conflict for manual resolution. The\n   MergeConflicts condition lists the conflicts, and the package revision cannot\n   be proposed until they are resolved.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -1234,6 +2165,58 @@ func schema_porch_api_porch_v1alpha1_ParentReference(ref common.ReferenceCallbac
 	}
 }
 
+func schema_porch_api_porch_v1alpha1_PipelineFunction(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PipelineFunction is a function in a pipeline.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name identifies the function in the pipeline. Functions without a name are identified by their image.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image is the container image of the function.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"configPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigPath is the path, relative to the Kptfile, of the file holding the function config.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"configMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigMap is the function config as key-value pairs.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"image"},
+			},
+		},
+	}
+}
+
 func schema_porch_api_porch_v1alpha1_PorchPackage(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1377,6 +2360,117 @@ func schema_porch_api_porch_v1alpha1_RenderStatus(ref common.ReferenceCallback)
 	}
 }
 
+func schema_porch_api_porch_v1alpha1_RepositoryBundle(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RepositoryBundle is a portable snapshot of the package revisions of a repository. Getting the RepositoryBundle named after a repository exports the repository, and creating a RepositoryBundle named after a repository imports the bundle into it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.RepositoryBundleSpec{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1alpha1.RepositoryBundleStatus{}.OpenAPIModelName()),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.RepositoryBundleSpec{}.OpenAPIModelName(), v1alpha1.RepositoryBundleStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_RepositoryBundleSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RepositoryBundleSpec holds the package revisions of a RepositoryBundle.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sourceRepository": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SourceRepository is the name of the repository the bundle was exported from.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"packageRevisions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PackageRevisions are the package revisions in the bundle, ordered by package name and, within a package, with published revisions in ascending revision order followed by unpublished revisions.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(v1alpha1.BundledPackageRevision{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.BundledPackageRevision{}.OpenAPIModelName()},
+	}
+}
+
+func schema_porch_api_porch_v1alpha1_RepositoryBundleStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RepositoryBundleStatus reports the outcome of importing a RepositoryBundle.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"imported": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Imported lists the names of the package revisions created by the import. For a dry-run import, it lists the package revisions that would be created.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_porch_api_porch_v1alpha1_RepositoryRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1681,6 +2775,50 @@ func schema_porch_api_porch_v1alpha1_Selector(ref common.ReferenceCallback) comm
 	}
 }
 
+func schema_porch_api_porch_v1alpha1_Subpackage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Subpackage describes an independent subpackage embedded in a package revision.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the directory of the subpackage, relative to the root of the package.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"parent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Parent is the path of the subpackage that contains this subpackage. It is empty if the subpackage is embedded directly in the package revision.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the subpackage in its Kptfile.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"upstreamLock": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpstreamLock identifies the upstream data for this subpackage. It is unset if the subpackage was not cloned from an upstream package.",
+							Ref:         ref(v1alpha1.Locator{}.OpenAPIModelName()),
+						},
+					},
+				},
+				Required: []string{"path"},
+			},
+		},
+		Dependencies: []string{
+			v1alpha1.Locator{}.OpenAPIModelName()},
+	}
+}
+
 func schema_porch_api_porch_v1alpha1_Task(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1748,6 +2886,75 @@ func schema_porch_api_porch_v1alpha1_TaskResult(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_porch_api_porch_v1alpha1_UpgradePreviewResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "UpgradePreviewResource is the outcome of an upgrade for a resource of a package. Files which do not hold KRM resources are reported as a whole, with only File set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"file": {
+						SchemaProps: spec.SchemaProps{
+							Description: "File is the path of the file holding the resource, relative to the package revision.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"outcome": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Outcome is the outcome of the upgrade for the resource.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"fields": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Fields lists the paths of the fields that the local package and the new upstream changed differently if the outcome is Conflict, and otherwise the paths of the fields that the upgrade changes in the resource of the local package.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"file", "outcome"},
+			},
+		},
+	}
+}
+
 func schema_porch_api_porch_v1alpha1_UpstreamPackage(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -42,8 +42,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PorchPackageList{},
 		&PackageRevision{},
 		&PackageRevisionList{},
+		&PackageRevisionConditions{},
+		&PackageRevisionDependencies{},
+		&PackageRevisionPipeline{},
 		&PackageRevisionResources{},
 		&PackageRevisionResourcesList{},
+		&RepositoryBundle{},
+		&PackageRevisionBatch{},
+		&PackageUpgradePreview{},
 	)
 	return nil
 }
@@ -46,8 +46,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PorchPackageList{},
 		&PackageRevision{},
 		&PackageRevisionList{},
+		&PackageRevisionConditions{},
+		&PackageRevisionDependencies{},
+		&PackageRevisionPipeline{},
 		&PackageRevisionResources{},
 		&PackageRevisionResourcesList{},
+		&RepositoryBundle{},
+		&PackageRevisionBatch{},
+		&PackageUpgradePreview{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -165,6 +165,47 @@ type PackageRevisionStatus struct {
 
 	// ResourcesSizeBytes is the total file size, in bytes, of the package revision's resources.
 	ResourcesSizeBytes int64 `json:"resourcesSizeBytes,omitempty"`
+
+	// DeploymentStatus aggregates the PackageRevisionDeploymentStatus reports of
+	// GitOps agents for a package revision in a deployment repository.
+	DeploymentStatus *DeploymentStatus `json:"deploymentStatus,omitempty"`
+
+	// Subpackages lists the independent subpackages embedded in the package revision,
+	// ordered by path.
+	Subpackages []Subpackage `json:"subpackages,omitempty"`
+}
+
+// DeploymentStatus is the deployment feedback for a package revision.
+type DeploymentStatus struct {
+	// State is the worst state reported across all targets.
+	State string `json:"state,omitempty"`
+
+	// Targets holds the last report for each deployment target.
+	Targets []DeploymentTargetStatus `json:"targets,omitempty"`
+}
+
+// DeploymentTargetStatus is the deployment feedback from a single target.
+type DeploymentTargetStatus struct {
+	// Target identifies where the package revision is deployed, such as a cluster.
+	Target string `json:"target"`
+
+	// State is one of Applied, Failed, Drifted or Progressing.
+	State string `json:"state,omitempty"`
+
+	// SyncedCommit is the commit the GitOps agent last synced.
+	SyncedCommit string `json:"syncedCommit,omitempty"`
+
+	// LastSyncTime is the time of the last sync.
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Message is a human readable summary from the GitOps agent.
+	Message string `json:"message,omitempty"`
+
+	// Resources is the number of resources the GitOps agent reported on.
+	Resources int `json:"resources,omitempty"`
+
+	// HealthyResources is the number of those resources that are healthy.
+	HealthyResources int `json:"healthyResources,omitempty"`
 }
 
 type TaskType string
@@ -207,6 +248,23 @@ type PackageInitTaskSpec struct {
 	Keywords []string `json:"keywords,omitempty"`
 	// `Site` is a link to page with information about the package.
 	Site string `json:"site,omitempty"`
+	// `Template` is a starter template package that the package is created from.
+	// The package is not tracked as a clone of the template.
+	Template *PackageTemplate `json:"template,omitempty"`
+}
+
+// PackageTemplate references a starter template package and the values of its parameters.
+// Exactly one of `UpstreamRef` or `Git` must be set.
+type PackageTemplate struct {
+	// `UpstreamRef` is the reference to a published template package revision in a registered repository.
+	UpstreamRef *PackageRevisionRef `json:"upstreamRef,omitempty"`
+
+	// `Git` is a template package stored in a Git repository.
+	Git *GitPackage `json:"git,omitempty"`
+
+	// `Parameters` are the values of the parameters declared by the template. They are
+	// validated against the TemplateParameters resource of the template.
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 type PackageCloneTaskSpec struct {
@@ -253,6 +311,10 @@ type PackageUpgradeTaskSpec struct {
 	//  * force-delete-replace: Wipe all the local changes to the package and replace
 	//    it with the remote version.
 	//  * copy-merge: Copy all the remote changes to the local package.
+	//  * manual-merge: Merge like resource-merge, but keep the local value of every
+	//    conflicting change and record the conflict for manual resolution. The
+	//    MergeConflicts condition lists the conflicts, and the package revision cannot
+	//    be proposed until they are resolved.
 	Strategy PackageMergeStrategy `json:"strategy,omitempty"`
 }
 
@@ -261,6 +323,7 @@ const (
 	FastForward        PackageMergeStrategy = "fast-forward"
 	ForceDeleteReplace PackageMergeStrategy = "force-delete-replace"
 	CopyMerge          PackageMergeStrategy = "copy-merge"
+	ManualMerge        PackageMergeStrategy = "manual-merge"
 )
 
 type PackageEditTaskSpec struct {
@@ -534,6 +597,353 @@ type PackageRevisionResourcesSpec struct {
 type PackageRevisionResourcesStatus struct {
 	// RenderStatus contains the result of rendering the package resources.
 	RenderStatus RenderStatus `json:"renderStatus,omitempty"`
+
+	// Subpackages lists the independent subpackages embedded in the package revision,
+	// ordered by path.
+	Subpackages []Subpackage `json:"subpackages,omitempty"`
+}
+
+// Subpackage describes an independent subpackage embedded in a package revision.
+type Subpackage struct {
+	// Path is the directory of the subpackage, relative to the root of the package.
+	Path string `json:"path"`
+
+	// Parent is the path of the subpackage that contains this subpackage. It is empty
+	// if the subpackage is embedded directly in the package revision.
+	Parent string `json:"parent,omitempty"`
+
+	// Name is the name of the subpackage in its Kptfile.
+	Name string `json:"name,omitempty"`
+
+	// UpstreamLock identifies the upstream data for this subpackage. It is unset if the
+	// subpackage was not cloned from an upstream package.
+	UpstreamLock *Locator `json:"upstreamLock,omitempty"`
+}
+
+// PackageRevisionDependencies describes the lineage of a package revision: the chain of
+// upstream package revisions it was cloned or upgraded from, and the package revisions
+// in the namespace that were cloned or upgraded from it.
+// It is served read-only by the packagerevisions/dependencies subresource.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PackageRevisionDependencies struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status PackageRevisionDependenciesStatus `json:"status,omitempty"`
+}
+
+// PackageRevisionDependenciesStatus lists the upstream and downstream package revisions
+// of a package revision.
+type PackageRevisionDependenciesStatus struct {
+	// Upstream is the chain of upstream package revisions, starting with the package
+	// revision that this package revision was cloned or upgraded from.
+	Upstream []PackageRevisionDependency `json:"upstream,omitempty"`
+
+	// Downstream lists the package revisions that were cloned or upgraded from this
+	// package revision, directly or transitively, in breadth-first order.
+	Downstream []PackageRevisionDependency `json:"downstream,omitempty"`
+}
+
+// PackageRevisionDependency identifies a package revision in the lineage of another package revision.
+type PackageRevisionDependency struct {
+	// Name is the name of the package revision.
+	Name string `json:"name"`
+
+	// RepositoryName is the name of the Repository object containing the package revision.
+	RepositoryName string `json:"repository,omitempty"`
+
+	// PackageName identifies the package in the repository.
+	PackageName string `json:"packageName,omitempty"`
+
+	// Revision identifies the version of the package.
+	Revision int `json:"revision,omitempty"`
+
+	// Lifecycle is the lifecycle of the package revision.
+	Lifecycle PackageRevisionLifecycle `json:"lifecycle,omitempty"`
+
+	// UpstreamName is the name of the package revision this package revision was
+	// cloned or upgraded from.
+	UpstreamName string `json:"upstreamName,omitempty"`
+
+	// LatestUpstreamName is the name of the latest published revision of the upstream package.
+	LatestUpstreamName string `json:"latestUpstreamName,omitempty"`
+
+	// BehindUpstream is true if a newer published revision of the upstream package exists.
+	BehindUpstream bool `json:"behindUpstream,omitempty"`
+}
+
+// PackageRevisionConditions holds the conditions in the Kptfile status of a package revision.
+// It is served by the packagerevisions/conditions subresource, so that actors outside the
+// package, such as CI systems, security scanners or reviewers, can satisfy its readiness gates.
+// Updating it sets, changes or clears conditions in the Kptfile of a draft or proposed package
+// revision without rendering the package. The caller must be allowed to "set" the
+// packageconditions resource named after the type of every condition it changes.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PackageRevisionConditions struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PackageRevisionConditionsSpec   `json:"spec,omitempty"`
+	Status PackageRevisionConditionsStatus `json:"status,omitempty"`
+}
+
+// PackageRevisionConditionsSpec lists the conditions of a package revision.
+type PackageRevisionConditionsSpec struct {
+	// Conditions are the conditions in the Kptfile status. On update, conditions that are
+	// not listed are cleared.
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// PackageRevisionConditionsStatus reports the readiness gates of a package revision.
+type PackageRevisionConditionsStatus struct {
+	// ReadinessGates are the readiness gates in the Kptfile.
+	ReadinessGates []ReadinessGate `json:"readinessGates,omitempty"`
+
+	// Ready is true if the condition of every readiness gate is True.
+	Ready bool `json:"ready"`
+}
+
+// PackageRevisionPipeline is the kpt function pipeline in the Kptfile of a package revision.
+// It is served by the packagerevisions/pipeline subresource. Updating it rewrites the pipeline
+// in the Kptfile of a draft package revision and renders the package; comments in the Kptfile
+// and the fields of functions that are not part of PipelineFunction are kept. A dry-run update
+// renders the package with the new pipeline without saving it.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PackageRevisionPipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PackageRevisionPipelineSpec   `json:"spec,omitempty"`
+	Status PackageRevisionPipelineStatus `json:"status,omitempty"`
+}
+
+// PackageRevisionPipelineSpec lists the functions of a pipeline in the order in which they run.
+type PackageRevisionPipelineSpec struct {
+	// Mutators are the functions that transform the resources of the package.
+	Mutators []PipelineFunction `json:"mutators,omitempty"`
+
+	// Validators are the functions that validate the resources of the package.
+	Validators []PipelineFunction `json:"validators,omitempty"`
+}
+
+// PipelineFunction is a function in a pipeline.
+type PipelineFunction struct {
+	// Name identifies the function in the pipeline. Functions without a name are
+	// identified by their image.
+	Name string `json:"name,omitempty"`
+
+	// Image is the container image of the function.
+	Image string `json:"image"`
+
+	// ConfigPath is the path, relative to the Kptfile, of the file holding the function config.
+	ConfigPath string `json:"configPath,omitempty"`
+
+	// ConfigMap is the function config as key-value pairs.
+	ConfigMap map[string]string `json:"configMap,omitempty"`
+}
+
+// PackageRevisionPipelineStatus reports the result of rendering the package with the pipeline.
+type PackageRevisionPipelineStatus struct {
+	// RenderStatus is the result of the render done by an update or a dry-run update.
+	RenderStatus RenderStatus `json:"renderStatus,omitempty"`
+}
+
+// RepositoryBundle is a portable snapshot of the package revisions of a repository.
+// Getting the RepositoryBundle named after a repository exports the repository, and
+// creating a RepositoryBundle named after a repository imports the bundle into it.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RepositoryBundle struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositoryBundleSpec   `json:"spec,omitempty"`
+	Status RepositoryBundleStatus `json:"status,omitempty"`
+}
+
+// RepositoryBundleSpec holds the package revisions of a RepositoryBundle.
+type RepositoryBundleSpec struct {
+	// SourceRepository is the name of the repository the bundle was exported from.
+	SourceRepository string `json:"sourceRepository,omitempty"`
+
+	// PackageRevisions are the package revisions in the bundle, ordered by package name
+	// and, within a package, with published revisions in ascending revision order
+	// followed by unpublished revisions.
+	PackageRevisions []BundledPackageRevision `json:"packageRevisions,omitempty"`
+}
+
+// BundledPackageRevision is a package revision in a RepositoryBundle.
+type BundledPackageRevision struct {
+	// PackageName identifies the package in the repository.
+	PackageName string `json:"packageName"`
+
+	// WorkspaceName identifies the package revision within its package.
+	WorkspaceName string `json:"workspaceName"`
+
+	// Revision is the revision number of the package revision; 0 if it is not published.
+	Revision int `json:"revision,omitempty"`
+
+	// Lifecycle is the lifecycle of the package revision.
+	Lifecycle PackageRevisionLifecycle `json:"lifecycle"`
+
+	// Tasks record how the package revision was created, and thereby its upstream.
+	Tasks []Task `json:"tasks,omitempty"`
+
+	// PublishedBy is the identity of the user who approved the package revision.
+	PublishedBy string `json:"publishedBy,omitempty"`
+
+	// PublishedAt is the time when the package revision was approved.
+	PublishedAt metav1.Time `json:"publishTimestamp,omitempty"`
+
+	// Resources are the files of the package revision, keyed by path.
+	Resources map[string]string `json:"resources,omitempty"`
+}
+
+// RepositoryBundleStatus reports the outcome of importing a RepositoryBundle.
+type RepositoryBundleStatus struct {
+	// Imported lists the names of the package revisions created by the import.
+	// For a dry-run import, it lists the package revisions that would be created.
+	Imported []string `json:"imported,omitempty"`
+}
+
+// PackageRevisionBatch applies a lifecycle operation to many package revisions in one request.
+// The changes to package revisions in the same repository are pushed together in a single push,
+// and either all of them are applied or none of them is. PackageRevisionBatch can only be created;
+// the created object reports the outcome for each package revision in its status.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PackageRevisionBatch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PackageRevisionBatchSpec   `json:"spec,omitempty"`
+	Status PackageRevisionBatchStatus `json:"status,omitempty"`
+}
+
+// PackageRevisionBatchOperation is the lifecycle operation applied by a PackageRevisionBatch.
+type PackageRevisionBatchOperation string
+
+const (
+	// BatchOperationPropose proposes Draft package revisions.
+	BatchOperationPropose PackageRevisionBatchOperation = "Propose"
+	// BatchOperationApprove publishes Proposed package revisions.
+	BatchOperationApprove PackageRevisionBatchOperation = "Approve"
+	// BatchOperationReject returns Proposed package revisions to Draft, and DeletionProposed
+	// package revisions to Published.
+	BatchOperationReject PackageRevisionBatchOperation = "Reject"
+	// BatchOperationProposeDelete proposes Published package revisions for deletion.
+	BatchOperationProposeDelete PackageRevisionBatchOperation = "ProposeDelete"
+	// BatchOperationDelete deletes package revisions that are not Published.
+	BatchOperationDelete PackageRevisionBatchOperation = "Delete"
+)
+
+// PackageRevisionBatchSpec selects the package revisions of a PackageRevisionBatch and the
+// operation to apply to them. At least one of PackageRevisions and Selector must be set; if
+// both are set, the batch applies to the package revisions matched by either.
+type PackageRevisionBatchSpec struct {
+	// Operation is the lifecycle operation to apply.
+	Operation PackageRevisionBatchOperation `json:"operation"`
+
+	// PackageRevisions lists the names of the package revisions to apply the operation to.
+	PackageRevisions []string `json:"packageRevisions,omitempty"`
+
+	// Selector selects the package revisions to apply the operation to by their labels.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// PackageRevisionBatchOutcome is the outcome of a PackageRevisionBatch for a package revision.
+type PackageRevisionBatchOutcome string
+
+const (
+	// BatchOutcomeSucceeded means the operation was applied to the package revision.
+	BatchOutcomeSucceeded PackageRevisionBatchOutcome = "Succeeded"
+	// BatchOutcomeFailed means the operation could not be applied to the package revision.
+	BatchOutcomeFailed PackageRevisionBatchOutcome = "Failed"
+	// BatchOutcomeAborted means the operation was not applied to the package revision because
+	// it failed for another package revision in the same repository.
+	BatchOutcomeAborted PackageRevisionBatchOutcome = "Aborted"
+)
+
+// PackageRevisionBatchStatus reports the outcome of a PackageRevisionBatch.
+type PackageRevisionBatchStatus struct {
+	// Results holds the outcome for each package revision, ordered by repository and name.
+	Results []PackageRevisionBatchResult `json:"results,omitempty"`
+}
+
+// PackageRevisionBatchResult is the outcome of a PackageRevisionBatch for a package revision.
+type PackageRevisionBatchResult struct {
+	// Name is the name of the package revision.
+	Name string `json:"name"`
+
+	// Repository is the name of the repository of the package revision.
+	Repository string `json:"repository,omitempty"`
+
+	// Outcome is the outcome of the operation for the package revision.
+	Outcome PackageRevisionBatchOutcome `json:"outcome"`
+
+	// Message explains why the operation failed or was aborted.
+	Message string `json:"message,omitempty"`
+}
+
+// PackageUpgradePreview previews the upgrade of a package revision, or of an independent subpackage
+// in a draft package revision, to a new upstream package revision, without creating or changing any
+// package revision. PackageUpgradePreview can only be created; the created object reports the
+// outcome of the upgrade for each resource of the package in its status.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PackageUpgradePreview struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the upgrade to preview, as it would be given in the upgrade task of a package revision.
+	Spec   PackageUpgradeTaskSpec      `json:"spec,omitempty"`
+	Status PackageUpgradePreviewStatus `json:"status,omitempty"`
+}
+
+// UpgradeOutcome is the outcome of an upgrade for a resource of a package.
+type UpgradeOutcome string
+
+const (
+	// UpgradeOutcomeUnchanged means the resource is the same in the local package, the new upstream
+	// and the upgraded package.
+	UpgradeOutcomeUnchanged UpgradeOutcome = "Unchanged"
+	// UpgradeOutcomeUpstream means the upgraded package takes the resource from the new upstream.
+	UpgradeOutcomeUpstream UpgradeOutcome = "Upstream"
+	// UpgradeOutcomeLocal means the upgraded package keeps the resource of the local package.
+	UpgradeOutcomeLocal UpgradeOutcome = "Local"
+	// UpgradeOutcomeMerged means the upgraded package merges the changes of the local package and of
+	// the new upstream to the resource.
+	UpgradeOutcomeMerged UpgradeOutcome = "Merged"
+	// UpgradeOutcomeConflict means the local package and the new upstream changed the same fields of
+	// the resource differently, or one of them deleted the resource and the other changed it.
+	UpgradeOutcomeConflict UpgradeOutcome = "Conflict"
+)
+
+// PackageUpgradePreviewStatus reports the outcome of a PackageUpgradePreview.
+type PackageUpgradePreviewStatus struct {
+	// Resources holds the outcome for each resource of the local package, the new upstream and the
+	// upgraded package, ordered by file and by position in the file.
+	Resources []UpgradePreviewResource `json:"resources,omitempty"`
+
+	// Conflicts is the number of resources with the Conflict outcome.
+	Conflicts int `json:"conflicts,omitempty"`
+}
+
+// UpgradePreviewResource is the outcome of an upgrade for a resource of a package. Files which do
+// not hold KRM resources are reported as a whole, with only File set.
+type UpgradePreviewResource struct {
+	// File is the path of the file holding the resource, relative to the package revision.
+	File string `json:"file"`
+
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name,omitempty"`
+
+	// Outcome is the outcome of the upgrade for the resource.
+	Outcome UpgradeOutcome `json:"outcome"`
+
+	// Fields lists the paths of the fields that the local package and the new upstream changed
+	// differently if the outcome is Conflict, and otherwise the paths of the fields that the
+	// upgrade changes in the resource of the local package.
+	Fields []string `json:"fields,omitempty"`
 }
 
 // Package
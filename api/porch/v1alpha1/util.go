@@ -50,23 +50,25 @@ func (l *PackageRevisionLifecycle) IsValid() bool {
 
 // Check ReadinessGates checks if the package has met all readiness gates
 func PackageRevisionIsReady(readinessGates []ReadinessGate, conditions []Condition) bool {
+	return len(UnmetReadinessGates(readinessGates, conditions)) == 0
+}
+
+// UnmetReadinessGates returns the condition types of the readiness gates whose condition
+// is missing or not True.
+func UnmetReadinessGates(readinessGates []ReadinessGate, conditions []Condition) []string {
 	// Index our conditions
 	conds := make(map[string]Condition)
 	for _, c := range conditions {
 		conds[c.Type] = c
 	}
 
-	// Check if the readiness gates are met
+	var unmet []string
 	for _, g := range readinessGates {
-		if _, ok := conds[g.ConditionType]; !ok {
-			return false
-		}
-		if conds[g.ConditionType].Status != "True" {
-			return false
+		if c, ok := conds[g.ConditionType]; !ok || c.Status != ConditionTrue {
+			unmet = append(unmet, g.ConditionType)
 		}
 	}
-
-	return true
+	return unmet
 }
 
 var validFirstTaskTypes = []TaskType{TaskTypeInit, TaskTypeEdit, TaskTypeClone, TaskTypeUpgrade}
@@ -23,6 +23,7 @@ import (
 	unsafe "unsafe"
 
 	porch "github.com/kptdev/porch/api/porch"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -34,6 +35,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*BundledPackageRevision)(nil), (*porch.BundledPackageRevision)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_BundledPackageRevision_To_porch_BundledPackageRevision(a.(*BundledPackageRevision), b.(*porch.BundledPackageRevision), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.BundledPackageRevision)(nil), (*BundledPackageRevision)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_BundledPackageRevision_To_v1alpha1_BundledPackageRevision(a.(*porch.BundledPackageRevision), b.(*BundledPackageRevision), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Condition)(nil), (*porch.Condition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Condition_To_porch_Condition(a.(*Condition), b.(*porch.Condition), scope)
 	}); err != nil {
@@ -44,6 +55,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DeploymentStatus)(nil), (*porch.DeploymentStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DeploymentStatus_To_porch_DeploymentStatus(a.(*DeploymentStatus), b.(*porch.DeploymentStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.DeploymentStatus)(nil), (*DeploymentStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_DeploymentStatus_To_v1alpha1_DeploymentStatus(a.(*porch.DeploymentStatus), b.(*DeploymentStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DeploymentTargetStatus)(nil), (*porch.DeploymentTargetStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DeploymentTargetStatus_To_porch_DeploymentTargetStatus(a.(*DeploymentTargetStatus), b.(*porch.DeploymentTargetStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.DeploymentTargetStatus)(nil), (*DeploymentTargetStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_DeploymentTargetStatus_To_v1alpha1_DeploymentTargetStatus(a.(*porch.DeploymentTargetStatus), b.(*DeploymentTargetStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Field)(nil), (*porch.Field)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Field_To_porch_Field(a.(*Field), b.(*porch.Field), scope)
 	}); err != nil {
@@ -164,6 +195,106 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionBatch)(nil), (*porch.PackageRevisionBatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionBatch_To_porch_PackageRevisionBatch(a.(*PackageRevisionBatch), b.(*porch.PackageRevisionBatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionBatch)(nil), (*PackageRevisionBatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionBatch_To_v1alpha1_PackageRevisionBatch(a.(*porch.PackageRevisionBatch), b.(*PackageRevisionBatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionBatchResult)(nil), (*porch.PackageRevisionBatchResult)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionBatchResult_To_porch_PackageRevisionBatchResult(a.(*PackageRevisionBatchResult), b.(*porch.PackageRevisionBatchResult), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionBatchResult)(nil), (*PackageRevisionBatchResult)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionBatchResult_To_v1alpha1_PackageRevisionBatchResult(a.(*porch.PackageRevisionBatchResult), b.(*PackageRevisionBatchResult), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionBatchSpec)(nil), (*porch.PackageRevisionBatchSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionBatchSpec_To_porch_PackageRevisionBatchSpec(a.(*PackageRevisionBatchSpec), b.(*porch.PackageRevisionBatchSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionBatchSpec)(nil), (*PackageRevisionBatchSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionBatchSpec_To_v1alpha1_PackageRevisionBatchSpec(a.(*porch.PackageRevisionBatchSpec), b.(*PackageRevisionBatchSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionBatchStatus)(nil), (*porch.PackageRevisionBatchStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionBatchStatus_To_porch_PackageRevisionBatchStatus(a.(*PackageRevisionBatchStatus), b.(*porch.PackageRevisionBatchStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionBatchStatus)(nil), (*PackageRevisionBatchStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionBatchStatus_To_v1alpha1_PackageRevisionBatchStatus(a.(*porch.PackageRevisionBatchStatus), b.(*PackageRevisionBatchStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionConditions)(nil), (*porch.PackageRevisionConditions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionConditions_To_porch_PackageRevisionConditions(a.(*PackageRevisionConditions), b.(*porch.PackageRevisionConditions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionConditions)(nil), (*PackageRevisionConditions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionConditions_To_v1alpha1_PackageRevisionConditions(a.(*porch.PackageRevisionConditions), b.(*PackageRevisionConditions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionConditionsSpec)(nil), (*porch.PackageRevisionConditionsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionConditionsSpec_To_porch_PackageRevisionConditionsSpec(a.(*PackageRevisionConditionsSpec), b.(*porch.PackageRevisionConditionsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionConditionsSpec)(nil), (*PackageRevisionConditionsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionConditionsSpec_To_v1alpha1_PackageRevisionConditionsSpec(a.(*porch.PackageRevisionConditionsSpec), b.(*PackageRevisionConditionsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionConditionsStatus)(nil), (*porch.PackageRevisionConditionsStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionConditionsStatus_To_porch_PackageRevisionConditionsStatus(a.(*PackageRevisionConditionsStatus), b.(*porch.PackageRevisionConditionsStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionConditionsStatus)(nil), (*PackageRevisionConditionsStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionConditionsStatus_To_v1alpha1_PackageRevisionConditionsStatus(a.(*porch.PackageRevisionConditionsStatus), b.(*PackageRevisionConditionsStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionDependencies)(nil), (*porch.PackageRevisionDependencies)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionDependencies_To_porch_PackageRevisionDependencies(a.(*PackageRevisionDependencies), b.(*porch.PackageRevisionDependencies), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionDependencies)(nil), (*PackageRevisionDependencies)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionDependencies_To_v1alpha1_PackageRevisionDependencies(a.(*porch.PackageRevisionDependencies), b.(*PackageRevisionDependencies), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionDependenciesStatus)(nil), (*porch.PackageRevisionDependenciesStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionDependenciesStatus_To_porch_PackageRevisionDependenciesStatus(a.(*PackageRevisionDependenciesStatus), b.(*porch.PackageRevisionDependenciesStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionDependenciesStatus)(nil), (*PackageRevisionDependenciesStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionDependenciesStatus_To_v1alpha1_PackageRevisionDependenciesStatus(a.(*porch.PackageRevisionDependenciesStatus), b.(*PackageRevisionDependenciesStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionDependency)(nil), (*porch.PackageRevisionDependency)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionDependency_To_porch_PackageRevisionDependency(a.(*PackageRevisionDependency), b.(*porch.PackageRevisionDependency), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionDependency)(nil), (*PackageRevisionDependency)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionDependency_To_v1alpha1_PackageRevisionDependency(a.(*porch.PackageRevisionDependency), b.(*PackageRevisionDependency), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PackageRevisionList)(nil), (*porch.PackageRevisionList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_PackageRevisionList_To_porch_PackageRevisionList(a.(*PackageRevisionList), b.(*porch.PackageRevisionList), scope)
 	}); err != nil {
@@ -174,6 +305,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionPipeline)(nil), (*porch.PackageRevisionPipeline)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionPipeline_To_porch_PackageRevisionPipeline(a.(*PackageRevisionPipeline), b.(*porch.PackageRevisionPipeline), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionPipeline)(nil), (*PackageRevisionPipeline)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionPipeline_To_v1alpha1_PackageRevisionPipeline(a.(*porch.PackageRevisionPipeline), b.(*PackageRevisionPipeline), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionPipelineSpec)(nil), (*porch.PackageRevisionPipelineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionPipelineSpec_To_porch_PackageRevisionPipelineSpec(a.(*PackageRevisionPipelineSpec), b.(*porch.PackageRevisionPipelineSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionPipelineSpec)(nil), (*PackageRevisionPipelineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionPipelineSpec_To_v1alpha1_PackageRevisionPipelineSpec(a.(*porch.PackageRevisionPipelineSpec), b.(*PackageRevisionPipelineSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageRevisionPipelineStatus)(nil), (*porch.PackageRevisionPipelineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageRevisionPipelineStatus_To_porch_PackageRevisionPipelineStatus(a.(*PackageRevisionPipelineStatus), b.(*porch.PackageRevisionPipelineStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageRevisionPipelineStatus)(nil), (*PackageRevisionPipelineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageRevisionPipelineStatus_To_v1alpha1_PackageRevisionPipelineStatus(a.(*porch.PackageRevisionPipelineStatus), b.(*PackageRevisionPipelineStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PackageRevisionRef)(nil), (*porch.PackageRevisionRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_PackageRevisionRef_To_porch_PackageRevisionRef(a.(*PackageRevisionRef), b.(*porch.PackageRevisionRef), scope)
 	}); err != nil {
@@ -264,6 +425,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PackageTemplate)(nil), (*porch.PackageTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageTemplate_To_porch_PackageTemplate(a.(*PackageTemplate), b.(*porch.PackageTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageTemplate)(nil), (*PackageTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageTemplate_To_v1alpha1_PackageTemplate(a.(*porch.PackageTemplate), b.(*PackageTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageUpgradePreview)(nil), (*porch.PackageUpgradePreview)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageUpgradePreview_To_porch_PackageUpgradePreview(a.(*PackageUpgradePreview), b.(*porch.PackageUpgradePreview), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageUpgradePreview)(nil), (*PackageUpgradePreview)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageUpgradePreview_To_v1alpha1_PackageUpgradePreview(a.(*porch.PackageUpgradePreview), b.(*PackageUpgradePreview), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PackageUpgradePreviewStatus)(nil), (*porch.PackageUpgradePreviewStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PackageUpgradePreviewStatus_To_porch_PackageUpgradePreviewStatus(a.(*PackageUpgradePreviewStatus), b.(*porch.PackageUpgradePreviewStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PackageUpgradePreviewStatus)(nil), (*PackageUpgradePreviewStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PackageUpgradePreviewStatus_To_v1alpha1_PackageUpgradePreviewStatus(a.(*porch.PackageUpgradePreviewStatus), b.(*PackageUpgradePreviewStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PackageUpgradeTaskSpec)(nil), (*porch.PackageUpgradeTaskSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_PackageUpgradeTaskSpec_To_porch_PackageUpgradeTaskSpec(a.(*PackageUpgradeTaskSpec), b.(*porch.PackageUpgradeTaskSpec), scope)
 	}); err != nil {
@@ -284,6 +475,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PipelineFunction)(nil), (*porch.PipelineFunction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PipelineFunction_To_porch_PipelineFunction(a.(*PipelineFunction), b.(*porch.PipelineFunction), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.PipelineFunction)(nil), (*PipelineFunction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_PipelineFunction_To_v1alpha1_PipelineFunction(a.(*porch.PipelineFunction), b.(*PipelineFunction), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PorchPackage)(nil), (*porch.PorchPackage)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_PorchPackage_To_porch_PorchPackage(a.(*PorchPackage), b.(*porch.PorchPackage), scope)
 	}); err != nil {
@@ -324,6 +525,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RepositoryBundle)(nil), (*porch.RepositoryBundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RepositoryBundle_To_porch_RepositoryBundle(a.(*RepositoryBundle), b.(*porch.RepositoryBundle), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.RepositoryBundle)(nil), (*RepositoryBundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_RepositoryBundle_To_v1alpha1_RepositoryBundle(a.(*porch.RepositoryBundle), b.(*RepositoryBundle), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RepositoryBundleSpec)(nil), (*porch.RepositoryBundleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RepositoryBundleSpec_To_porch_RepositoryBundleSpec(a.(*RepositoryBundleSpec), b.(*porch.RepositoryBundleSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.RepositoryBundleSpec)(nil), (*RepositoryBundleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_RepositoryBundleSpec_To_v1alpha1_RepositoryBundleSpec(a.(*porch.RepositoryBundleSpec), b.(*RepositoryBundleSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RepositoryBundleStatus)(nil), (*porch.RepositoryBundleStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RepositoryBundleStatus_To_porch_RepositoryBundleStatus(a.(*RepositoryBundleStatus), b.(*porch.RepositoryBundleStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.RepositoryBundleStatus)(nil), (*RepositoryBundleStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_RepositoryBundleStatus_To_v1alpha1_RepositoryBundleStatus(a.(*porch.RepositoryBundleStatus), b.(*RepositoryBundleStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RepositoryRef)(nil), (*porch.RepositoryRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RepositoryRef_To_porch_RepositoryRef(a.(*RepositoryRef), b.(*porch.RepositoryRef), scope)
 	}); err != nil {
@@ -394,6 +625,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Subpackage)(nil), (*porch.Subpackage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Subpackage_To_porch_Subpackage(a.(*Subpackage), b.(*porch.Subpackage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.Subpackage)(nil), (*Subpackage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_Subpackage_To_v1alpha1_Subpackage(a.(*porch.Subpackage), b.(*Subpackage), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Task)(nil), (*porch.Task)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Task_To_porch_Task(a.(*Task), b.(*porch.Task), scope)
 	}); err != nil {
@@ -414,6 +655,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*UpgradePreviewResource)(nil), (*porch.UpgradePreviewResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_UpgradePreviewResource_To_porch_UpgradePreviewResource(a.(*UpgradePreviewResource), b.(*porch.UpgradePreviewResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*porch.UpgradePreviewResource)(nil), (*UpgradePreviewResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_porch_UpgradePreviewResource_To_v1alpha1_UpgradePreviewResource(a.(*porch.UpgradePreviewResource), b.(*UpgradePreviewResource), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*UpstreamPackage)(nil), (*porch.UpstreamPackage)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_UpstreamPackage_To_porch_UpstreamPackage(a.(*UpstreamPackage), b.(*porch.UpstreamPackage), scope)
 	}); err != nil {
@@ -427,6 +678,40 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_BundledPackageRevision_To_porch_BundledPackageRevision(in *BundledPackageRevision, out *porch.BundledPackageRevision, s conversion.Scope) error {
+	out.PackageName = in.PackageName
+	out.WorkspaceName = in.WorkspaceName
+	out.Revision = in.Revision
+	out.Lifecycle = porch.PackageRevisionLifecycle(in.Lifecycle)
+	out.Tasks = *(*[]porch.Task)(unsafe.Pointer(&in.Tasks))
+	out.PublishedBy = in.PublishedBy
+	out.PublishedAt = in.PublishedAt
+	out.Resources = *(*map[string]string)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_v1alpha1_BundledPackageRevision_To_porch_BundledPackageRevision is an autogenerated conversion function.
+func Convert_v1alpha1_BundledPackageRevision_To_porch_BundledPackageRevision(in *BundledPackageRevision, out *porch.BundledPackageRevision, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BundledPackageRevision_To_porch_BundledPackageRevision(in, out, s)
+}
+
+func autoConvert_porch_BundledPackageRevision_To_v1alpha1_BundledPackageRevision(in *porch.BundledPackageRevision, out *BundledPackageRevision, s conversion.Scope) error {
+	out.PackageName = in.PackageName
+	out.WorkspaceName = in.WorkspaceName
+	out.Revision = in.Revision
+	out.Lifecycle = PackageRevisionLifecycle(in.Lifecycle)
+	out.Tasks = *(*[]Task)(unsafe.Pointer(&in.Tasks))
+	out.PublishedBy = in.PublishedBy
+	out.PublishedAt = in.PublishedAt
+	out.Resources = *(*map[string]string)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_porch_BundledPackageRevision_To_v1alpha1_BundledPackageRevision is an autogenerated conversion function.
+func Convert_porch_BundledPackageRevision_To_v1alpha1_BundledPackageRevision(in *porch.BundledPackageRevision, out *BundledPackageRevision, s conversion.Scope) error {
+	return autoConvert_porch_BundledPackageRevision_To_v1alpha1_BundledPackageRevision(in, out, s)
+}
+
 func autoConvert_v1alpha1_Condition_To_porch_Condition(in *Condition, out *porch.Condition, s conversion.Scope) error {
 	out.Type = in.Type
 	out.Status = porch.ConditionStatus(in.Status)
@@ -453,6 +738,60 @@ func Convert_porch_Condition_To_v1alpha1_Condition(in *porch.Condition, out *Con
 	return autoConvert_porch_Condition_To_v1alpha1_Condition(in, out, s)
 }
 
+func autoConvert_v1alpha1_DeploymentStatus_To_porch_DeploymentStatus(in *DeploymentStatus, out *porch.DeploymentStatus, s conversion.Scope) error {
+	out.State = in.State
+	out.Targets = *(*[]porch.DeploymentTargetStatus)(unsafe.Pointer(&in.Targets))
+	return nil
+}
+
+// Convert_v1alpha1_DeploymentStatus_To_porch_DeploymentStatus is an autogenerated conversion function.
+func Convert_v1alpha1_DeploymentStatus_To_porch_DeploymentStatus(in *DeploymentStatus, out *porch.DeploymentStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DeploymentStatus_To_porch_DeploymentStatus(in, out, s)
+}
+
+func autoConvert_porch_DeploymentStatus_To_v1alpha1_DeploymentStatus(in *porch.DeploymentStatus, out *DeploymentStatus, s conversion.Scope) error {
+	out.State = in.State
+	out.Targets = *(*[]DeploymentTargetStatus)(unsafe.Pointer(&in.Targets))
+	return nil
+}
+
+// Convert_porch_DeploymentStatus_To_v1alpha1_DeploymentStatus is an autogenerated conversion function.
+func Convert_porch_DeploymentStatus_To_v1alpha1_DeploymentStatus(in *porch.DeploymentStatus, out *DeploymentStatus, s conversion.Scope) error {
+	return autoConvert_porch_DeploymentStatus_To_v1alpha1_DeploymentStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_DeploymentTargetStatus_To_porch_DeploymentTargetStatus(in *DeploymentTargetStatus, out *porch.DeploymentTargetStatus, s conversion.Scope) error {
+	out.Target = in.Target
+	out.State = in.State
+	out.SyncedCommit = in.SyncedCommit
+	out.LastSyncTime = (*v1.Time)(unsafe.Pointer(in.LastSyncTime))
+	out.Message = in.Message
+	out.Resources = in.Resources
+	out.HealthyResources = in.HealthyResources
+	return nil
+}
+
+// Convert_v1alpha1_DeploymentTargetStatus_To_porch_DeploymentTargetStatus is an autogenerated conversion function.
+func Convert_v1alpha1_DeploymentTargetStatus_To_porch_DeploymentTargetStatus(in *DeploymentTargetStatus, out *porch.DeploymentTargetStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DeploymentTargetStatus_To_porch_DeploymentTargetStatus(in, out, s)
+}
+
+func autoConvert_porch_DeploymentTargetStatus_To_v1alpha1_DeploymentTargetStatus(in *porch.DeploymentTargetStatus, out *DeploymentTargetStatus, s conversion.Scope) error {
+	out.Target = in.Target
+	out.State = in.State
+	out.SyncedCommit = in.SyncedCommit
+	out.LastSyncTime = (*v1.Time)(unsafe.Pointer(in.LastSyncTime))
+	out.Message = in.Message
+	out.Resources = in.Resources
+	out.HealthyResources = in.HealthyResources
+	return nil
+}
+
+// Convert_porch_DeploymentTargetStatus_To_v1alpha1_DeploymentTargetStatus is an autogenerated conversion function.
+func Convert_porch_DeploymentTargetStatus_To_v1alpha1_DeploymentTargetStatus(in *porch.DeploymentTargetStatus, out *DeploymentTargetStatus, s conversion.Scope) error {
+	return autoConvert_porch_DeploymentTargetStatus_To_v1alpha1_DeploymentTargetStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_Field_To_porch_Field(in *Field, out *porch.Field, s conversion.Scope) error {
 	out.Path = in.Path
 	out.CurrentValue = in.CurrentValue
@@ -669,6 +1008,7 @@ func autoConvert_v1alpha1_PackageInitTaskSpec_To_porch_PackageInitTaskSpec(in *P
 	out.Description = in.Description
 	out.Keywords = *(*[]string)(unsafe.Pointer(&in.Keywords))
 	out.Site = in.Site
+	out.Template = (*porch.PackageTemplate)(unsafe.Pointer(in.Template))
 	return nil
 }
 
@@ -681,6 +1021,7 @@ func autoConvert_porch_PackageInitTaskSpec_To_v1alpha1_PackageInitTaskSpec(in *p
 	out.Description = in.Description
 	out.Keywords = *(*[]string)(unsafe.Pointer(&in.Keywords))
 	out.Site = in.Site
+	out.Template = (*PackageTemplate)(unsafe.Pointer(in.Template))
 	return nil
 }
 
@@ -743,87 +1084,423 @@ func Convert_porch_PackageRevision_To_v1alpha1_PackageRevision(in *porch.Package
 	return autoConvert_porch_PackageRevision_To_v1alpha1_PackageRevision(in, out, s)
 }
 
-func autoConvert_v1alpha1_PackageRevisionList_To_porch_PackageRevisionList(in *PackageRevisionList, out *porch.PackageRevisionList, s conversion.Scope) error {
-	out.ListMeta = in.ListMeta
-	out.Items = *(*[]porch.PackageRevision)(unsafe.Pointer(&in.Items))
+func autoConvert_v1alpha1_PackageRevisionBatch_To_porch_PackageRevisionBatch(in *PackageRevisionBatch, out *porch.PackageRevisionBatch, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_PackageRevisionBatchSpec_To_porch_PackageRevisionBatchSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_PackageRevisionBatchStatus_To_porch_PackageRevisionBatchStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
 	return nil
 }
 
-// Convert_v1alpha1_PackageRevisionList_To_porch_PackageRevisionList is an autogenerated conversion function.
-func Convert_v1alpha1_PackageRevisionList_To_porch_PackageRevisionList(in *PackageRevisionList, out *porch.PackageRevisionList, s conversion.Scope) error {
-	return autoConvert_v1alpha1_PackageRevisionList_To_porch_PackageRevisionList(in, out, s)
+// Convert_v1alpha1_PackageRevisionBatch_To_porch_PackageRevisionBatch is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionBatch_To_porch_PackageRevisionBatch(in *PackageRevisionBatch, out *porch.PackageRevisionBatch, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionBatch_To_porch_PackageRevisionBatch(in, out, s)
 }
 
-func autoConvert_porch_PackageRevisionList_To_v1alpha1_PackageRevisionList(in *porch.PackageRevisionList, out *PackageRevisionList, s conversion.Scope) error {
-	out.ListMeta = in.ListMeta
-	out.Items = *(*[]PackageRevision)(unsafe.Pointer(&in.Items))
+func autoConvert_porch_PackageRevisionBatch_To_v1alpha1_PackageRevisionBatch(in *porch.PackageRevisionBatch, out *PackageRevisionBatch, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_porch_PackageRevisionBatchSpec_To_v1alpha1_PackageRevisionBatchSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_porch_PackageRevisionBatchStatus_To_v1alpha1_PackageRevisionBatchStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
 	return nil
 }
 
-// Convert_porch_PackageRevisionList_To_v1alpha1_PackageRevisionList is an autogenerated conversion function.
-func Convert_porch_PackageRevisionList_To_v1alpha1_PackageRevisionList(in *porch.PackageRevisionList, out *PackageRevisionList, s conversion.Scope) error {
-	return autoConvert_porch_PackageRevisionList_To_v1alpha1_PackageRevisionList(in, out, s)
+// Convert_porch_PackageRevisionBatch_To_v1alpha1_PackageRevisionBatch is an autogenerated conversion function.
+func Convert_porch_PackageRevisionBatch_To_v1alpha1_PackageRevisionBatch(in *porch.PackageRevisionBatch, out *PackageRevisionBatch, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionBatch_To_v1alpha1_PackageRevisionBatch(in, out, s)
 }
 
-func autoConvert_v1alpha1_PackageRevisionRef_To_porch_PackageRevisionRef(in *PackageRevisionRef, out *porch.PackageRevisionRef, s conversion.Scope) error {
+func autoConvert_v1alpha1_PackageRevisionBatchResult_To_porch_PackageRevisionBatchResult(in *PackageRevisionBatchResult, out *porch.PackageRevisionBatchResult, s conversion.Scope) error {
 	out.Name = in.Name
+	out.Repository = in.Repository
+	out.Outcome = porch.PackageRevisionBatchOutcome(in.Outcome)
+	out.Message = in.Message
 	return nil
 }
 
-// Convert_v1alpha1_PackageRevisionRef_To_porch_PackageRevisionRef is an autogenerated conversion function.
-func Convert_v1alpha1_PackageRevisionRef_To_porch_PackageRevisionRef(in *PackageRevisionRef, out *porch.PackageRevisionRef, s conversion.Scope) error {
-	return autoConvert_v1alpha1_PackageRevisionRef_To_porch_PackageRevisionRef(in, out, s)
+// Convert_v1alpha1_PackageRevisionBatchResult_To_porch_PackageRevisionBatchResult is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionBatchResult_To_porch_PackageRevisionBatchResult(in *PackageRevisionBatchResult, out *porch.PackageRevisionBatchResult, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionBatchResult_To_porch_PackageRevisionBatchResult(in, out, s)
 }
 
-func autoConvert_porch_PackageRevisionRef_To_v1alpha1_PackageRevisionRef(in *porch.PackageRevisionRef, out *PackageRevisionRef, s conversion.Scope) error {
+func autoConvert_porch_PackageRevisionBatchResult_To_v1alpha1_PackageRevisionBatchResult(in *porch.PackageRevisionBatchResult, out *PackageRevisionBatchResult, s conversion.Scope) error {
 	out.Name = in.Name
+	out.Repository = in.Repository
+	out.Outcome = PackageRevisionBatchOutcome(in.Outcome)
+	out.Message = in.Message
 	return nil
 }
 
-// Convert_porch_PackageRevisionRef_To_v1alpha1_PackageRevisionRef is an autogenerated conversion function.
-func Convert_porch_PackageRevisionRef_To_v1alpha1_PackageRevisionRef(in *porch.PackageRevisionRef, out *PackageRevisionRef, s conversion.Scope) error {
-	return autoConvert_porch_PackageRevisionRef_To_v1alpha1_PackageRevisionRef(in, out, s)
+// Convert_porch_PackageRevisionBatchResult_To_v1alpha1_PackageRevisionBatchResult is an autogenerated conversion function.
+func Convert_porch_PackageRevisionBatchResult_To_v1alpha1_PackageRevisionBatchResult(in *porch.PackageRevisionBatchResult, out *PackageRevisionBatchResult, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionBatchResult_To_v1alpha1_PackageRevisionBatchResult(in, out, s)
 }
 
-func autoConvert_v1alpha1_PackageRevisionResources_To_porch_PackageRevisionResources(in *PackageRevisionResources, out *porch.PackageRevisionResources, s conversion.Scope) error {
-	out.ObjectMeta = in.ObjectMeta
-	if err := Convert_v1alpha1_PackageRevisionResourcesSpec_To_porch_PackageRevisionResourcesSpec(&in.Spec, &out.Spec, s); err != nil {
-		return err
-	}
-	if err := Convert_v1alpha1_PackageRevisionResourcesStatus_To_porch_PackageRevisionResourcesStatus(&in.Status, &out.Status, s); err != nil {
-		return err
-	}
+func autoConvert_v1alpha1_PackageRevisionBatchSpec_To_porch_PackageRevisionBatchSpec(in *PackageRevisionBatchSpec, out *porch.PackageRevisionBatchSpec, s conversion.Scope) error {
+	out.Operation = porch.PackageRevisionBatchOperation(in.Operation)
+	out.PackageRevisions = *(*[]string)(unsafe.Pointer(&in.PackageRevisions))
+	out.Selector = (*v1.LabelSelector)(unsafe.Pointer(in.Selector))
 	return nil
 }
 
-// Convert_v1alpha1_PackageRevisionResources_To_porch_PackageRevisionResources is an autogenerated conversion function.
-func Convert_v1alpha1_PackageRevisionResources_To_porch_PackageRevisionResources(in *PackageRevisionResources, out *porch.PackageRevisionResources, s conversion.Scope) error {
-	return autoConvert_v1alpha1_PackageRevisionResources_To_porch_PackageRevisionResources(in, out, s)
+// Convert_v1alpha1_PackageRevisionBatchSpec_To_porch_PackageRevisionBatchSpec is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionBatchSpec_To_porch_PackageRevisionBatchSpec(in *PackageRevisionBatchSpec, out *porch.PackageRevisionBatchSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionBatchSpec_To_porch_PackageRevisionBatchSpec(in, out, s)
 }
 
-func autoConvert_porch_PackageRevisionResources_To_v1alpha1_PackageRevisionResources(in *porch.PackageRevisionResources, out *PackageRevisionResources, s conversion.Scope) error {
-	out.ObjectMeta = in.ObjectMeta
-	if err := Convert_porch_PackageRevisionResourcesSpec_To_v1alpha1_PackageRevisionResourcesSpec(&in.Spec, &out.Spec, s); err != nil {
-		return err
-	}
-	if err := Convert_porch_PackageRevisionResourcesStatus_To_v1alpha1_PackageRevisionResourcesStatus(&in.Status, &out.Status, s); err != nil {
-		return err
-	}
+func autoConvert_porch_PackageRevisionBatchSpec_To_v1alpha1_PackageRevisionBatchSpec(in *porch.PackageRevisionBatchSpec, out *PackageRevisionBatchSpec, s conversion.Scope) error {
+	out.Operation = PackageRevisionBatchOperation(in.Operation)
+	out.PackageRevisions = *(*[]string)(unsafe.Pointer(&in.PackageRevisions))
+	out.Selector = (*v1.LabelSelector)(unsafe.Pointer(in.Selector))
 	return nil
 }
 
-// Convert_porch_PackageRevisionResources_To_v1alpha1_PackageRevisionResources is an autogenerated conversion function.
-func Convert_porch_PackageRevisionResources_To_v1alpha1_PackageRevisionResources(in *porch.PackageRevisionResources, out *PackageRevisionResources, s conversion.Scope) error {
-	return autoConvert_porch_PackageRevisionResources_To_v1alpha1_PackageRevisionResources(in, out, s)
+// Convert_porch_PackageRevisionBatchSpec_To_v1alpha1_PackageRevisionBatchSpec is an autogenerated conversion function.
+func Convert_porch_PackageRevisionBatchSpec_To_v1alpha1_PackageRevisionBatchSpec(in *porch.PackageRevisionBatchSpec, out *PackageRevisionBatchSpec, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionBatchSpec_To_v1alpha1_PackageRevisionBatchSpec(in, out, s)
 }
 
-func autoConvert_v1alpha1_PackageRevisionResourcesList_To_porch_PackageRevisionResourcesList(in *PackageRevisionResourcesList, out *porch.PackageRevisionResourcesList, s conversion.Scope) error {
-	out.ListMeta = in.ListMeta
-	out.Items = *(*[]porch.PackageRevisionResources)(unsafe.Pointer(&in.Items))
+func autoConvert_v1alpha1_PackageRevisionBatchStatus_To_porch_PackageRevisionBatchStatus(in *PackageRevisionBatchStatus, out *porch.PackageRevisionBatchStatus, s conversion.Scope) error {
+	out.Results = *(*[]porch.PackageRevisionBatchResult)(unsafe.Pointer(&in.Results))
 	return nil
 }
 
-// Convert_v1alpha1_PackageRevisionResourcesList_To_porch_PackageRevisionResourcesList is an autogenerated conversion function.
+// Convert_v1alpha1_PackageRevisionBatchStatus_To_porch_PackageRevisionBatchStatus is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionBatchStatus_To_porch_PackageRevisionBatchStatus(in *PackageRevisionBatchStatus, out *porch.PackageRevisionBatchStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionBatchStatus_To_porch_PackageRevisionBatchStatus(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionBatchStatus_To_v1alpha1_PackageRevisionBatchStatus(in *porch.PackageRevisionBatchStatus, out *PackageRevisionBatchStatus, s conversion.Scope) error {
+	out.Results = *(*[]PackageRevisionBatchResult)(unsafe.Pointer(&in.Results))
+	return nil
+}
+
+// Convert_porch_PackageRevisionBatchStatus_To_v1alpha1_PackageRevisionBatchStatus is an autogenerated conversion function.
+func Convert_porch_PackageRevisionBatchStatus_To_v1alpha1_PackageRevisionBatchStatus(in *porch.PackageRevisionBatchStatus, out *PackageRevisionBatchStatus, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionBatchStatus_To_v1alpha1_PackageRevisionBatchStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionConditions_To_porch_PackageRevisionConditions(in *PackageRevisionConditions, out *porch.PackageRevisionConditions, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_PackageRevisionConditionsSpec_To_porch_PackageRevisionConditionsSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_PackageRevisionConditionsStatus_To_porch_PackageRevisionConditionsStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionConditions_To_porch_PackageRevisionConditions is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionConditions_To_porch_PackageRevisionConditions(in *PackageRevisionConditions, out *porch.PackageRevisionConditions, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionConditions_To_porch_PackageRevisionConditions(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionConditions_To_v1alpha1_PackageRevisionConditions(in *porch.PackageRevisionConditions, out *PackageRevisionConditions, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_porch_PackageRevisionConditionsSpec_To_v1alpha1_PackageRevisionConditionsSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_porch_PackageRevisionConditionsStatus_To_v1alpha1_PackageRevisionConditionsStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_porch_PackageRevisionConditions_To_v1alpha1_PackageRevisionConditions is an autogenerated conversion function.
+func Convert_porch_PackageRevisionConditions_To_v1alpha1_PackageRevisionConditions(in *porch.PackageRevisionConditions, out *PackageRevisionConditions, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionConditions_To_v1alpha1_PackageRevisionConditions(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionConditionsSpec_To_porch_PackageRevisionConditionsSpec(in *PackageRevisionConditionsSpec, out *porch.PackageRevisionConditionsSpec, s conversion.Scope) error {
+	out.Conditions = *(*[]porch.Condition)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionConditionsSpec_To_porch_PackageRevisionConditionsSpec is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionConditionsSpec_To_porch_PackageRevisionConditionsSpec(in *PackageRevisionConditionsSpec, out *porch.PackageRevisionConditionsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionConditionsSpec_To_porch_PackageRevisionConditionsSpec(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionConditionsSpec_To_v1alpha1_PackageRevisionConditionsSpec(in *porch.PackageRevisionConditionsSpec, out *PackageRevisionConditionsSpec, s conversion.Scope) error {
+	out.Conditions = *(*[]Condition)(unsafe.Pointer(&in.Conditions))
+	return nil
+}
+
+// Convert_porch_PackageRevisionConditionsSpec_To_v1alpha1_PackageRevisionConditionsSpec is an autogenerated conversion function.
+func Convert_porch_PackageRevisionConditionsSpec_To_v1alpha1_PackageRevisionConditionsSpec(in *porch.PackageRevisionConditionsSpec, out *PackageRevisionConditionsSpec, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionConditionsSpec_To_v1alpha1_PackageRevisionConditionsSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionConditionsStatus_To_porch_PackageRevisionConditionsStatus(in *PackageRevisionConditionsStatus, out *porch.PackageRevisionConditionsStatus, s conversion.Scope) error {
+	out.ReadinessGates = *(*[]porch.ReadinessGate)(unsafe.Pointer(&in.ReadinessGates))
+	out.Ready = in.Ready
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionConditionsStatus_To_porch_PackageRevisionConditionsStatus is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionConditionsStatus_To_porch_PackageRevisionConditionsStatus(in *PackageRevisionConditionsStatus, out *porch.PackageRevisionConditionsStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionConditionsStatus_To_porch_PackageRevisionConditionsStatus(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionConditionsStatus_To_v1alpha1_PackageRevisionConditionsStatus(in *porch.PackageRevisionConditionsStatus, out *PackageRevisionConditionsStatus, s conversion.Scope) error {
+	out.ReadinessGates = *(*[]ReadinessGate)(unsafe.Pointer(&in.ReadinessGates))
+	out.Ready = in.Ready
+	return nil
+}
+
+// Convert_porch_PackageRevisionConditionsStatus_To_v1alpha1_PackageRevisionConditionsStatus is an autogenerated conversion function.
+func Convert_porch_PackageRevisionConditionsStatus_To_v1alpha1_PackageRevisionConditionsStatus(in *porch.PackageRevisionConditionsStatus, out *PackageRevisionConditionsStatus, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionConditionsStatus_To_v1alpha1_PackageRevisionConditionsStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionDependencies_To_porch_PackageRevisionDependencies(in *PackageRevisionDependencies, out *porch.PackageRevisionDependencies, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_PackageRevisionDependenciesStatus_To_porch_PackageRevisionDependenciesStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionDependencies_To_porch_PackageRevisionDependencies is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionDependencies_To_porch_PackageRevisionDependencies(in *PackageRevisionDependencies, out *porch.PackageRevisionDependencies, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionDependencies_To_porch_PackageRevisionDependencies(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionDependencies_To_v1alpha1_PackageRevisionDependencies(in *porch.PackageRevisionDependencies, out *PackageRevisionDependencies, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_porch_PackageRevisionDependenciesStatus_To_v1alpha1_PackageRevisionDependenciesStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_porch_PackageRevisionDependencies_To_v1alpha1_PackageRevisionDependencies is an autogenerated conversion function.
+func Convert_porch_PackageRevisionDependencies_To_v1alpha1_PackageRevisionDependencies(in *porch.PackageRevisionDependencies, out *PackageRevisionDependencies, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionDependencies_To_v1alpha1_PackageRevisionDependencies(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionDependenciesStatus_To_porch_PackageRevisionDependenciesStatus(in *PackageRevisionDependenciesStatus, out *porch.PackageRevisionDependenciesStatus, s conversion.Scope) error {
+	out.Upstream = *(*[]porch.PackageRevisionDependency)(unsafe.Pointer(&in.Upstream))
+	out.Downstream = *(*[]porch.PackageRevisionDependency)(unsafe.Pointer(&in.Downstream))
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionDependenciesStatus_To_porch_PackageRevisionDependenciesStatus is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionDependenciesStatus_To_porch_PackageRevisionDependenciesStatus(in *PackageRevisionDependenciesStatus, out *porch.PackageRevisionDependenciesStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionDependenciesStatus_To_porch_PackageRevisionDependenciesStatus(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionDependenciesStatus_To_v1alpha1_PackageRevisionDependenciesStatus(in *porch.PackageRevisionDependenciesStatus, out *PackageRevisionDependenciesStatus, s conversion.Scope) error {
+	out.Upstream = *(*[]PackageRevisionDependency)(unsafe.Pointer(&in.Upstream))
+	out.Downstream = *(*[]PackageRevisionDependency)(unsafe.Pointer(&in.Downstream))
+	return nil
+}
+
+// Convert_porch_PackageRevisionDependenciesStatus_To_v1alpha1_PackageRevisionDependenciesStatus is an autogenerated conversion function.
+func Convert_porch_PackageRevisionDependenciesStatus_To_v1alpha1_PackageRevisionDependenciesStatus(in *porch.PackageRevisionDependenciesStatus, out *PackageRevisionDependenciesStatus, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionDependenciesStatus_To_v1alpha1_PackageRevisionDependenciesStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionDependency_To_porch_PackageRevisionDependency(in *PackageRevisionDependency, out *porch.PackageRevisionDependency, s conversion.Scope) error {
+	out.Name = in.Name
+	out.RepositoryName = in.RepositoryName
+	out.PackageName = in.PackageName
+	out.Revision = in.Revision
+	out.Lifecycle = porch.PackageRevisionLifecycle(in.Lifecycle)
+	out.UpstreamName = in.UpstreamName
+	out.LatestUpstreamName = in.LatestUpstreamName
+	out.BehindUpstream = in.BehindUpstream
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionDependency_To_porch_PackageRevisionDependency is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionDependency_To_porch_PackageRevisionDependency(in *PackageRevisionDependency, out *porch.PackageRevisionDependency, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionDependency_To_porch_PackageRevisionDependency(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionDependency_To_v1alpha1_PackageRevisionDependency(in *porch.PackageRevisionDependency, out *PackageRevisionDependency, s conversion.Scope) error {
+	out.Name = in.Name
+	out.RepositoryName = in.RepositoryName
+	out.PackageName = in.PackageName
+	out.Revision = in.Revision
+	out.Lifecycle = PackageRevisionLifecycle(in.Lifecycle)
+	out.UpstreamName = in.UpstreamName
+	out.LatestUpstreamName = in.LatestUpstreamName
+	out.BehindUpstream = in.BehindUpstream
+	return nil
+}
+
+// Convert_porch_PackageRevisionDependency_To_v1alpha1_PackageRevisionDependency is an autogenerated conversion function.
+func Convert_porch_PackageRevisionDependency_To_v1alpha1_PackageRevisionDependency(in *porch.PackageRevisionDependency, out *PackageRevisionDependency, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionDependency_To_v1alpha1_PackageRevisionDependency(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionList_To_porch_PackageRevisionList(in *PackageRevisionList, out *porch.PackageRevisionList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]porch.PackageRevision)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionList_To_porch_PackageRevisionList is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionList_To_porch_PackageRevisionList(in *PackageRevisionList, out *porch.PackageRevisionList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionList_To_porch_PackageRevisionList(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionList_To_v1alpha1_PackageRevisionList(in *porch.PackageRevisionList, out *PackageRevisionList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]PackageRevision)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_porch_PackageRevisionList_To_v1alpha1_PackageRevisionList is an autogenerated conversion function.
+func Convert_porch_PackageRevisionList_To_v1alpha1_PackageRevisionList(in *porch.PackageRevisionList, out *PackageRevisionList, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionList_To_v1alpha1_PackageRevisionList(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionPipeline_To_porch_PackageRevisionPipeline(in *PackageRevisionPipeline, out *porch.PackageRevisionPipeline, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_PackageRevisionPipelineSpec_To_porch_PackageRevisionPipelineSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_PackageRevisionPipelineStatus_To_porch_PackageRevisionPipelineStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionPipeline_To_porch_PackageRevisionPipeline is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionPipeline_To_porch_PackageRevisionPipeline(in *PackageRevisionPipeline, out *porch.PackageRevisionPipeline, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionPipeline_To_porch_PackageRevisionPipeline(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionPipeline_To_v1alpha1_PackageRevisionPipeline(in *porch.PackageRevisionPipeline, out *PackageRevisionPipeline, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_porch_PackageRevisionPipelineSpec_To_v1alpha1_PackageRevisionPipelineSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_porch_PackageRevisionPipelineStatus_To_v1alpha1_PackageRevisionPipelineStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_porch_PackageRevisionPipeline_To_v1alpha1_PackageRevisionPipeline is an autogenerated conversion function.
+func Convert_porch_PackageRevisionPipeline_To_v1alpha1_PackageRevisionPipeline(in *porch.PackageRevisionPipeline, out *PackageRevisionPipeline, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionPipeline_To_v1alpha1_PackageRevisionPipeline(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionPipelineSpec_To_porch_PackageRevisionPipelineSpec(in *PackageRevisionPipelineSpec, out *porch.PackageRevisionPipelineSpec, s conversion.Scope) error {
+	out.Mutators = *(*[]porch.PipelineFunction)(unsafe.Pointer(&in.Mutators))
+	out.Validators = *(*[]porch.PipelineFunction)(unsafe.Pointer(&in.Validators))
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionPipelineSpec_To_porch_PackageRevisionPipelineSpec is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionPipelineSpec_To_porch_PackageRevisionPipelineSpec(in *PackageRevisionPipelineSpec, out *porch.PackageRevisionPipelineSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionPipelineSpec_To_porch_PackageRevisionPipelineSpec(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionPipelineSpec_To_v1alpha1_PackageRevisionPipelineSpec(in *porch.PackageRevisionPipelineSpec, out *PackageRevisionPipelineSpec, s conversion.Scope) error {
+	out.Mutators = *(*[]PipelineFunction)(unsafe.Pointer(&in.Mutators))
+	out.Validators = *(*[]PipelineFunction)(unsafe.Pointer(&in.Validators))
+	return nil
+}
+
+// Convert_porch_PackageRevisionPipelineSpec_To_v1alpha1_PackageRevisionPipelineSpec is an autogenerated conversion function.
+func Convert_porch_PackageRevisionPipelineSpec_To_v1alpha1_PackageRevisionPipelineSpec(in *porch.PackageRevisionPipelineSpec, out *PackageRevisionPipelineSpec, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionPipelineSpec_To_v1alpha1_PackageRevisionPipelineSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionPipelineStatus_To_porch_PackageRevisionPipelineStatus(in *PackageRevisionPipelineStatus, out *porch.PackageRevisionPipelineStatus, s conversion.Scope) error {
+	if err := Convert_v1alpha1_RenderStatus_To_porch_RenderStatus(&in.RenderStatus, &out.RenderStatus, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionPipelineStatus_To_porch_PackageRevisionPipelineStatus is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionPipelineStatus_To_porch_PackageRevisionPipelineStatus(in *PackageRevisionPipelineStatus, out *porch.PackageRevisionPipelineStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionPipelineStatus_To_porch_PackageRevisionPipelineStatus(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionPipelineStatus_To_v1alpha1_PackageRevisionPipelineStatus(in *porch.PackageRevisionPipelineStatus, out *PackageRevisionPipelineStatus, s conversion.Scope) error {
+	if err := Convert_porch_RenderStatus_To_v1alpha1_RenderStatus(&in.RenderStatus, &out.RenderStatus, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_porch_PackageRevisionPipelineStatus_To_v1alpha1_PackageRevisionPipelineStatus is an autogenerated conversion function.
+func Convert_porch_PackageRevisionPipelineStatus_To_v1alpha1_PackageRevisionPipelineStatus(in *porch.PackageRevisionPipelineStatus, out *PackageRevisionPipelineStatus, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionPipelineStatus_To_v1alpha1_PackageRevisionPipelineStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionRef_To_porch_PackageRevisionRef(in *PackageRevisionRef, out *porch.PackageRevisionRef, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionRef_To_porch_PackageRevisionRef is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionRef_To_porch_PackageRevisionRef(in *PackageRevisionRef, out *porch.PackageRevisionRef, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionRef_To_porch_PackageRevisionRef(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionRef_To_v1alpha1_PackageRevisionRef(in *porch.PackageRevisionRef, out *PackageRevisionRef, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_porch_PackageRevisionRef_To_v1alpha1_PackageRevisionRef is an autogenerated conversion function.
+func Convert_porch_PackageRevisionRef_To_v1alpha1_PackageRevisionRef(in *porch.PackageRevisionRef, out *PackageRevisionRef, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionRef_To_v1alpha1_PackageRevisionRef(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionResources_To_porch_PackageRevisionResources(in *PackageRevisionResources, out *porch.PackageRevisionResources, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_PackageRevisionResourcesSpec_To_porch_PackageRevisionResourcesSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_PackageRevisionResourcesStatus_To_porch_PackageRevisionResourcesStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionResources_To_porch_PackageRevisionResources is an autogenerated conversion function.
+func Convert_v1alpha1_PackageRevisionResources_To_porch_PackageRevisionResources(in *PackageRevisionResources, out *porch.PackageRevisionResources, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageRevisionResources_To_porch_PackageRevisionResources(in, out, s)
+}
+
+func autoConvert_porch_PackageRevisionResources_To_v1alpha1_PackageRevisionResources(in *porch.PackageRevisionResources, out *PackageRevisionResources, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_porch_PackageRevisionResourcesSpec_To_v1alpha1_PackageRevisionResourcesSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_porch_PackageRevisionResourcesStatus_To_v1alpha1_PackageRevisionResourcesStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_porch_PackageRevisionResources_To_v1alpha1_PackageRevisionResources is an autogenerated conversion function.
+func Convert_porch_PackageRevisionResources_To_v1alpha1_PackageRevisionResources(in *porch.PackageRevisionResources, out *PackageRevisionResources, s conversion.Scope) error {
+	return autoConvert_porch_PackageRevisionResources_To_v1alpha1_PackageRevisionResources(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageRevisionResourcesList_To_porch_PackageRevisionResourcesList(in *PackageRevisionResourcesList, out *porch.PackageRevisionResourcesList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]porch.PackageRevisionResources)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_PackageRevisionResourcesList_To_porch_PackageRevisionResourcesList is an autogenerated conversion function.
 func Convert_v1alpha1_PackageRevisionResourcesList_To_porch_PackageRevisionResourcesList(in *PackageRevisionResourcesList, out *porch.PackageRevisionResourcesList, s conversion.Scope) error {
 	return autoConvert_v1alpha1_PackageRevisionResourcesList_To_porch_PackageRevisionResourcesList(in, out, s)
 }
@@ -871,6 +1548,7 @@ func autoConvert_v1alpha1_PackageRevisionResourcesStatus_To_porch_PackageRevisio
 	if err := Convert_v1alpha1_RenderStatus_To_porch_RenderStatus(&in.RenderStatus, &out.RenderStatus, s); err != nil {
 		return err
 	}
+	out.Subpackages = *(*[]porch.Subpackage)(unsafe.Pointer(&in.Subpackages))
 	return nil
 }
 
@@ -883,6 +1561,7 @@ func autoConvert_porch_PackageRevisionResourcesStatus_To_v1alpha1_PackageRevisio
 	if err := Convert_porch_RenderStatus_To_v1alpha1_RenderStatus(&in.RenderStatus, &out.RenderStatus, s); err != nil {
 		return err
 	}
+	out.Subpackages = *(*[]Subpackage)(unsafe.Pointer(&in.Subpackages))
 	return nil
 }
 
@@ -935,6 +1614,8 @@ func autoConvert_v1alpha1_PackageRevisionStatus_To_porch_PackageRevisionStatus(i
 	out.Deployment = in.Deployment
 	out.Conditions = *(*[]porch.Condition)(unsafe.Pointer(&in.Conditions))
 	out.ResourcesSizeBytes = in.ResourcesSizeBytes
+	out.DeploymentStatus = (*porch.DeploymentStatus)(unsafe.Pointer(in.DeploymentStatus))
+	out.Subpackages = *(*[]porch.Subpackage)(unsafe.Pointer(&in.Subpackages))
 	return nil
 }
 
@@ -951,6 +1632,8 @@ func autoConvert_porch_PackageRevisionStatus_To_v1alpha1_PackageRevisionStatus(i
 	out.Deployment = in.Deployment
 	out.Conditions = *(*[]Condition)(unsafe.Pointer(&in.Conditions))
 	out.ResourcesSizeBytes = in.ResourcesSizeBytes
+	out.DeploymentStatus = (*DeploymentStatus)(unsafe.Pointer(in.DeploymentStatus))
+	out.Subpackages = *(*[]Subpackage)(unsafe.Pointer(&in.Subpackages))
 	return nil
 }
 
@@ -1001,6 +1684,84 @@ func Convert_porch_PackageStatus_To_v1alpha1_PackageStatus(in *porch.PackageStat
 	return autoConvert_porch_PackageStatus_To_v1alpha1_PackageStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_PackageTemplate_To_porch_PackageTemplate(in *PackageTemplate, out *porch.PackageTemplate, s conversion.Scope) error {
+	out.UpstreamRef = (*porch.PackageRevisionRef)(unsafe.Pointer(in.UpstreamRef))
+	out.Git = (*porch.GitPackage)(unsafe.Pointer(in.Git))
+	out.Parameters = *(*map[string]string)(unsafe.Pointer(&in.Parameters))
+	return nil
+}
+
+// Convert_v1alpha1_PackageTemplate_To_porch_PackageTemplate is an autogenerated conversion function.
+func Convert_v1alpha1_PackageTemplate_To_porch_PackageTemplate(in *PackageTemplate, out *porch.PackageTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageTemplate_To_porch_PackageTemplate(in, out, s)
+}
+
+func autoConvert_porch_PackageTemplate_To_v1alpha1_PackageTemplate(in *porch.PackageTemplate, out *PackageTemplate, s conversion.Scope) error {
+	out.UpstreamRef = (*PackageRevisionRef)(unsafe.Pointer(in.UpstreamRef))
+	out.Git = (*GitPackage)(unsafe.Pointer(in.Git))
+	out.Parameters = *(*map[string]string)(unsafe.Pointer(&in.Parameters))
+	return nil
+}
+
+// Convert_porch_PackageTemplate_To_v1alpha1_PackageTemplate is an autogenerated conversion function.
+func Convert_porch_PackageTemplate_To_v1alpha1_PackageTemplate(in *porch.PackageTemplate, out *PackageTemplate, s conversion.Scope) error {
+	return autoConvert_porch_PackageTemplate_To_v1alpha1_PackageTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageUpgradePreview_To_porch_PackageUpgradePreview(in *PackageUpgradePreview, out *porch.PackageUpgradePreview, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_PackageUpgradeTaskSpec_To_porch_PackageUpgradeTaskSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_PackageUpgradePreviewStatus_To_porch_PackageUpgradePreviewStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_PackageUpgradePreview_To_porch_PackageUpgradePreview is an autogenerated conversion function.
+func Convert_v1alpha1_PackageUpgradePreview_To_porch_PackageUpgradePreview(in *PackageUpgradePreview, out *porch.PackageUpgradePreview, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageUpgradePreview_To_porch_PackageUpgradePreview(in, out, s)
+}
+
+func autoConvert_porch_PackageUpgradePreview_To_v1alpha1_PackageUpgradePreview(in *porch.PackageUpgradePreview, out *PackageUpgradePreview, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_porch_PackageUpgradeTaskSpec_To_v1alpha1_PackageUpgradeTaskSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_porch_PackageUpgradePreviewStatus_To_v1alpha1_PackageUpgradePreviewStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_porch_PackageUpgradePreview_To_v1alpha1_PackageUpgradePreview is an autogenerated conversion function.
+func Convert_porch_PackageUpgradePreview_To_v1alpha1_PackageUpgradePreview(in *porch.PackageUpgradePreview, out *PackageUpgradePreview, s conversion.Scope) error {
+	return autoConvert_porch_PackageUpgradePreview_To_v1alpha1_PackageUpgradePreview(in, out, s)
+}
+
+func autoConvert_v1alpha1_PackageUpgradePreviewStatus_To_porch_PackageUpgradePreviewStatus(in *PackageUpgradePreviewStatus, out *porch.PackageUpgradePreviewStatus, s conversion.Scope) error {
+	out.Resources = *(*[]porch.UpgradePreviewResource)(unsafe.Pointer(&in.Resources))
+	out.Conflicts = in.Conflicts
+	return nil
+}
+
+// Convert_v1alpha1_PackageUpgradePreviewStatus_To_porch_PackageUpgradePreviewStatus is an autogenerated conversion function.
+func Convert_v1alpha1_PackageUpgradePreviewStatus_To_porch_PackageUpgradePreviewStatus(in *PackageUpgradePreviewStatus, out *porch.PackageUpgradePreviewStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PackageUpgradePreviewStatus_To_porch_PackageUpgradePreviewStatus(in, out, s)
+}
+
+func autoConvert_porch_PackageUpgradePreviewStatus_To_v1alpha1_PackageUpgradePreviewStatus(in *porch.PackageUpgradePreviewStatus, out *PackageUpgradePreviewStatus, s conversion.Scope) error {
+	out.Resources = *(*[]UpgradePreviewResource)(unsafe.Pointer(&in.Resources))
+	out.Conflicts = in.Conflicts
+	return nil
+}
+
+// Convert_porch_PackageUpgradePreviewStatus_To_v1alpha1_PackageUpgradePreviewStatus is an autogenerated conversion function.
+func Convert_porch_PackageUpgradePreviewStatus_To_v1alpha1_PackageUpgradePreviewStatus(in *porch.PackageUpgradePreviewStatus, out *PackageUpgradePreviewStatus, s conversion.Scope) error {
+	return autoConvert_porch_PackageUpgradePreviewStatus_To_v1alpha1_PackageUpgradePreviewStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_PackageUpgradeTaskSpec_To_porch_PackageUpgradeTaskSpec(in *PackageUpgradeTaskSpec, out *porch.PackageUpgradeTaskSpec, s conversion.Scope) error {
 	if err := Convert_v1alpha1_PackageRevisionRef_To_porch_PackageRevisionRef(&in.OldUpstream, &out.OldUpstream, s); err != nil {
 		return err
@@ -1061,6 +1822,32 @@ func Convert_porch_ParentReference_To_v1alpha1_ParentReference(in *porch.ParentR
 	return autoConvert_porch_ParentReference_To_v1alpha1_ParentReference(in, out, s)
 }
 
+func autoConvert_v1alpha1_PipelineFunction_To_porch_PipelineFunction(in *PipelineFunction, out *porch.PipelineFunction, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Image = in.Image
+	out.ConfigPath = in.ConfigPath
+	out.ConfigMap = *(*map[string]string)(unsafe.Pointer(&in.ConfigMap))
+	return nil
+}
+
+// Convert_v1alpha1_PipelineFunction_To_porch_PipelineFunction is an autogenerated conversion function.
+func Convert_v1alpha1_PipelineFunction_To_porch_PipelineFunction(in *PipelineFunction, out *porch.PipelineFunction, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PipelineFunction_To_porch_PipelineFunction(in, out, s)
+}
+
+func autoConvert_porch_PipelineFunction_To_v1alpha1_PipelineFunction(in *porch.PipelineFunction, out *PipelineFunction, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Image = in.Image
+	out.ConfigPath = in.ConfigPath
+	out.ConfigMap = *(*map[string]string)(unsafe.Pointer(&in.ConfigMap))
+	return nil
+}
+
+// Convert_porch_PipelineFunction_To_v1alpha1_PipelineFunction is an autogenerated conversion function.
+func Convert_porch_PipelineFunction_To_v1alpha1_PipelineFunction(in *porch.PipelineFunction, out *PipelineFunction, s conversion.Scope) error {
+	return autoConvert_porch_PipelineFunction_To_v1alpha1_PipelineFunction(in, out, s)
+}
+
 func autoConvert_v1alpha1_PorchPackage_To_porch_PorchPackage(in *PorchPackage, out *porch.PorchPackage, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha1_PackageSpec_To_porch_PackageSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1161,6 +1948,80 @@ func Convert_porch_RenderStatus_To_v1alpha1_RenderStatus(in *porch.RenderStatus,
 	return autoConvert_porch_RenderStatus_To_v1alpha1_RenderStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_RepositoryBundle_To_porch_RepositoryBundle(in *RepositoryBundle, out *porch.RepositoryBundle, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_RepositoryBundleSpec_To_porch_RepositoryBundleSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_RepositoryBundleStatus_To_porch_RepositoryBundleStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_RepositoryBundle_To_porch_RepositoryBundle is an autogenerated conversion function.
+func Convert_v1alpha1_RepositoryBundle_To_porch_RepositoryBundle(in *RepositoryBundle, out *porch.RepositoryBundle, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RepositoryBundle_To_porch_RepositoryBundle(in, out, s)
+}
+
+func autoConvert_porch_RepositoryBundle_To_v1alpha1_RepositoryBundle(in *porch.RepositoryBundle, out *RepositoryBundle, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_porch_RepositoryBundleSpec_To_v1alpha1_RepositoryBundleSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_porch_RepositoryBundleStatus_To_v1alpha1_RepositoryBundleStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_porch_RepositoryBundle_To_v1alpha1_RepositoryBundle is an autogenerated conversion function.
+func Convert_porch_RepositoryBundle_To_v1alpha1_RepositoryBundle(in *porch.RepositoryBundle, out *RepositoryBundle, s conversion.Scope) error {
+	return autoConvert_porch_RepositoryBundle_To_v1alpha1_RepositoryBundle(in, out, s)
+}
+
+func autoConvert_v1alpha1_RepositoryBundleSpec_To_porch_RepositoryBundleSpec(in *RepositoryBundleSpec, out *porch.RepositoryBundleSpec, s conversion.Scope) error {
+	out.SourceRepository = in.SourceRepository
+	out.PackageRevisions = *(*[]porch.BundledPackageRevision)(unsafe.Pointer(&in.PackageRevisions))
+	return nil
+}
+
+// Convert_v1alpha1_RepositoryBundleSpec_To_porch_RepositoryBundleSpec is an autogenerated conversion function.
+func Convert_v1alpha1_RepositoryBundleSpec_To_porch_RepositoryBundleSpec(in *RepositoryBundleSpec, out *porch.RepositoryBundleSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RepositoryBundleSpec_To_porch_RepositoryBundleSpec(in, out, s)
+}
+
+func autoConvert_porch_RepositoryBundleSpec_To_v1alpha1_RepositoryBundleSpec(in *porch.RepositoryBundleSpec, out *RepositoryBundleSpec, s conversion.Scope) error {
+	out.SourceRepository = in.SourceRepository
+	out.PackageRevisions = *(*[]BundledPackageRevision)(unsafe.Pointer(&in.PackageRevisions))
+	return nil
+}
+
+// Convert_porch_RepositoryBundleSpec_To_v1alpha1_RepositoryBundleSpec is an autogenerated conversion function.
+func Convert_porch_RepositoryBundleSpec_To_v1alpha1_RepositoryBundleSpec(in *porch.RepositoryBundleSpec, out *RepositoryBundleSpec, s conversion.Scope) error {
+	return autoConvert_porch_RepositoryBundleSpec_To_v1alpha1_RepositoryBundleSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_RepositoryBundleStatus_To_porch_RepositoryBundleStatus(in *RepositoryBundleStatus, out *porch.RepositoryBundleStatus, s conversion.Scope) error {
+	out.Imported = *(*[]string)(unsafe.Pointer(&in.Imported))
+	return nil
+}
+
+// Convert_v1alpha1_RepositoryBundleStatus_To_porch_RepositoryBundleStatus is an autogenerated conversion function.
+func Convert_v1alpha1_RepositoryBundleStatus_To_porch_RepositoryBundleStatus(in *RepositoryBundleStatus, out *porch.RepositoryBundleStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RepositoryBundleStatus_To_porch_RepositoryBundleStatus(in, out, s)
+}
+
+func autoConvert_porch_RepositoryBundleStatus_To_v1alpha1_RepositoryBundleStatus(in *porch.RepositoryBundleStatus, out *RepositoryBundleStatus, s conversion.Scope) error {
+	out.Imported = *(*[]string)(unsafe.Pointer(&in.Imported))
+	return nil
+}
+
+// Convert_porch_RepositoryBundleStatus_To_v1alpha1_RepositoryBundleStatus is an autogenerated conversion function.
+func Convert_porch_RepositoryBundleStatus_To_v1alpha1_RepositoryBundleStatus(in *porch.RepositoryBundleStatus, out *RepositoryBundleStatus, s conversion.Scope) error {
+	return autoConvert_porch_RepositoryBundleStatus_To_v1alpha1_RepositoryBundleStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_RepositoryRef_To_porch_RepositoryRef(in *RepositoryRef, out *porch.RepositoryRef, s conversion.Scope) error {
 	out.Name = in.Name
 	return nil
@@ -1333,6 +2194,32 @@ func Convert_porch_Selector_To_v1alpha1_Selector(in *porch.Selector, out *Select
 	return autoConvert_porch_Selector_To_v1alpha1_Selector(in, out, s)
 }
 
+func autoConvert_v1alpha1_Subpackage_To_porch_Subpackage(in *Subpackage, out *porch.Subpackage, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Parent = in.Parent
+	out.Name = in.Name
+	out.UpstreamLock = (*porch.Locator)(unsafe.Pointer(in.UpstreamLock))
+	return nil
+}
+
+// Convert_v1alpha1_Subpackage_To_porch_Subpackage is an autogenerated conversion function.
+func Convert_v1alpha1_Subpackage_To_porch_Subpackage(in *Subpackage, out *porch.Subpackage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Subpackage_To_porch_Subpackage(in, out, s)
+}
+
+func autoConvert_porch_Subpackage_To_v1alpha1_Subpackage(in *porch.Subpackage, out *Subpackage, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Parent = in.Parent
+	out.Name = in.Name
+	out.UpstreamLock = (*Locator)(unsafe.Pointer(in.UpstreamLock))
+	return nil
+}
+
+// Convert_porch_Subpackage_To_v1alpha1_Subpackage is an autogenerated conversion function.
+func Convert_porch_Subpackage_To_v1alpha1_Subpackage(in *porch.Subpackage, out *Subpackage, s conversion.Scope) error {
+	return autoConvert_porch_Subpackage_To_v1alpha1_Subpackage(in, out, s)
+}
+
 func autoConvert_v1alpha1_Task_To_porch_Task(in *Task, out *porch.Task, s conversion.Scope) error {
 	out.Type = porch.TaskType(in.Type)
 	out.Init = (*porch.PackageInitTaskSpec)(unsafe.Pointer(in.Init))
@@ -1383,6 +2270,38 @@ func Convert_porch_TaskResult_To_v1alpha1_TaskResult(in *porch.TaskResult, out *
 	return autoConvert_porch_TaskResult_To_v1alpha1_TaskResult(in, out, s)
 }
 
+func autoConvert_v1alpha1_UpgradePreviewResource_To_porch_UpgradePreviewResource(in *UpgradePreviewResource, out *porch.UpgradePreviewResource, s conversion.Scope) error {
+	out.File = in.File
+	out.APIVersion = in.APIVersion
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Outcome = porch.UpgradeOutcome(in.Outcome)
+	out.Fields = *(*[]string)(unsafe.Pointer(&in.Fields))
+	return nil
+}
+
+// Convert_v1alpha1_UpgradePreviewResource_To_porch_UpgradePreviewResource is an autogenerated conversion function.
+func Convert_v1alpha1_UpgradePreviewResource_To_porch_UpgradePreviewResource(in *UpgradePreviewResource, out *porch.UpgradePreviewResource, s conversion.Scope) error {
+	return autoConvert_v1alpha1_UpgradePreviewResource_To_porch_UpgradePreviewResource(in, out, s)
+}
+
+func autoConvert_porch_UpgradePreviewResource_To_v1alpha1_UpgradePreviewResource(in *porch.UpgradePreviewResource, out *UpgradePreviewResource, s conversion.Scope) error {
+	out.File = in.File
+	out.APIVersion = in.APIVersion
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Outcome = UpgradeOutcome(in.Outcome)
+	out.Fields = *(*[]string)(unsafe.Pointer(&in.Fields))
+	return nil
+}
+
+// Convert_porch_UpgradePreviewResource_To_v1alpha1_UpgradePreviewResource is an autogenerated conversion function.
+func Convert_porch_UpgradePreviewResource_To_v1alpha1_UpgradePreviewResource(in *porch.UpgradePreviewResource, out *UpgradePreviewResource, s conversion.Scope) error {
+	return autoConvert_porch_UpgradePreviewResource_To_v1alpha1_UpgradePreviewResource(in, out, s)
+}
+
 func autoConvert_v1alpha1_UpstreamPackage_To_porch_UpstreamPackage(in *UpstreamPackage, out *porch.UpstreamPackage, s conversion.Scope) error {
 	out.Type = porch.RepositoryType(in.Type)
 	out.Git = (*porch.GitPackage)(unsafe.Pointer(in.Git))
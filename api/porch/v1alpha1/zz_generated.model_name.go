@@ -19,11 +19,26 @@
 
 package v1alpha1
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in BundledPackageRevision) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.BundledPackageRevision"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in Condition) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.Condition"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in DeploymentStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.DeploymentStatus"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in DeploymentTargetStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.DeploymentTargetStatus"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in Field) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.Field"
@@ -84,11 +99,76 @@ func (in PackageRevision) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevision"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionBatch) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionBatch"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionBatchResult) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionBatchResult"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionBatchSpec) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionBatchSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionBatchStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionBatchStatus"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionConditions) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionConditions"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionConditionsSpec) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionConditionsSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionConditionsStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionConditionsStatus"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionDependencies) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionDependencies"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionDependenciesStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionDependenciesStatus"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionDependency) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionDependency"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in PackageRevisionList) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionList"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionPipeline) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionPipeline"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionPipelineSpec) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionPipelineSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionPipelineStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionPipelineStatus"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in PackageRevisionRef) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageRevisionRef"
@@ -134,6 +214,21 @@ func (in PackageStatus) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageTemplate) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageTemplate"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageUpgradePreview) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageUpgradePreview"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageUpgradePreviewStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageUpgradePreviewStatus"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in PackageUpgradeTaskSpec) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.PackageUpgradeTaskSpec"
@@ -144,6 +239,11 @@ func (in ParentReference) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.ParentReference"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PipelineFunction) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.PipelineFunction"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in PorchPackage) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.PorchPackage"
@@ -164,6 +264,21 @@ func (in RenderStatus) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.RenderStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in RepositoryBundle) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.RepositoryBundle"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in RepositoryBundleSpec) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.RepositoryBundleSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in RepositoryBundleStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.RepositoryBundleStatus"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in RepositoryRef) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.RepositoryRef"
@@ -199,6 +314,11 @@ func (in Selector) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.Selector"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in Subpackage) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.Subpackage"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in Task) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.Task"
@@ -209,6 +329,11 @@ func (in TaskResult) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.TaskResult"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in UpgradePreviewResource) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.v1alpha1.UpgradePreviewResource"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in UpstreamPackage) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.v1alpha1.UpstreamPackage"
@@ -14,6 +14,10 @@
 
 package v1alpha2
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // This file contains types used in PackageRevision status fields.
 // These types are intentionally duplicated from the kpt library to maintain API independence.
 
@@ -47,3 +51,39 @@ type GitLock struct {
 	// This is set by kpt for bookkeeping purposes.
 	Commit string `json:"commit,omitempty"`
 }
+
+// DeploymentStatus is the deployment feedback for a package revision.
+type DeploymentStatus struct {
+	// State is the worst state reported across all targets.
+	State string `json:"state,omitempty"`
+
+	// Targets holds the last report for each deployment target.
+	// +listType=map
+	// +listMapKey=target
+	Targets []DeploymentTargetStatus `json:"targets,omitempty"`
+}
+
+// DeploymentTargetStatus is the deployment feedback from a single target.
+type DeploymentTargetStatus struct {
+	// Target identifies where the package revision is deployed, such as a cluster.
+	Target string `json:"target"`
+
+	// State is one of Applied, Failed, Drifted or Progressing.
+	State string `json:"state,omitempty"`
+
+	// SyncedCommit is the commit the GitOps agent last synced.
+	SyncedCommit string `json:"syncedCommit,omitempty"`
+
+	// LastSyncTime is the time of the last sync.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Message is a human readable summary from the GitOps agent.
+	Message string `json:"message,omitempty"`
+
+	// Resources is the number of resources the GitOps agent reported on.
+	Resources int `json:"resources,omitempty"`
+
+	// HealthyResources is the number of those resources that are healthy.
+	HealthyResources int `json:"healthyResources,omitempty"`
+}
@@ -35,6 +35,7 @@ import (
 // +kubebuilder:printcolumn:name="Lifecycle",type=string,JSONPath=`.spec.lifecycle`
 // +kubebuilder:printcolumn:name="Repository",type=string,JSONPath=`.spec.repository`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:printcolumn:name="Deployment",type=string,JSONPath=`.status.deploymentStatus.state`,priority=1
 // +kubebuilder:selectablefield:JSONPath=`.spec.lifecycle`
 // +kubebuilder:selectablefield:JSONPath=`.spec.repository`
 // +kubebuilder:selectablefield:JSONPath=`.spec.packageName`
@@ -198,7 +199,7 @@ type PackageRevisionStatus struct {
 	RenderingPrrResourceVersion string `json:"renderingPrrResourceVersion,omitempty"`
 
 	// CreationSource indicates how this package was created (for debugging/history).
-	// Possible values: "init", "clone", "copy", "upgrade".
+	// Possible values: "init", "clone", "copy", "upgrade", "promote".
 	// This is a read-only field populated by the system.
 	// +optional
 	CreationSource string `json:"creationSource,omitempty"`
@@ -217,11 +218,21 @@ type PackageRevisionStatus struct {
 
 	// ResourcesSizeBytes is the total file size, in bytes, of the package revision's resources.
 	ResourcesSizeBytes int64 `json:"resourcesSizeBytes,omitempty"`
+
+	// Subpackages lists the independent subpackages embedded in the package revision,
+	// ordered by path.
+	// +optional
+	Subpackages []Subpackage `json:"subpackages,omitempty"`
+
+	// DeploymentStatus aggregates the PackageRevisionDeploymentStatus reports of
+	// GitOps agents for a package revision in a deployment repository.
+	// +optional
+	DeploymentStatus *DeploymentStatus `json:"deploymentStatus,omitempty"`
 }
 
 // PackageSource specifies how a package was created.
 // Exactly one field must be set.
-// +kubebuilder:validation:XValidation:rule="[has(self.init), has(self.cloneFrom), has(self.copyFrom), has(self.upgrade)].filter(x, x).size() == 1",message="exactly one of init, cloneFrom, copyFrom, or upgrade must be set"
+// +kubebuilder:validation:XValidation:rule="[has(self.init), has(self.cloneFrom), has(self.copyFrom), has(self.upgrade), has(self.promoteFrom)].filter(x, x).size() == 1",message="exactly one of init, cloneFrom, copyFrom, upgrade, or promoteFrom must be set"
 type PackageSource struct {
 	// Init creates a brand new package from scratch.
 	Init *PackageInitSpec `json:"init,omitempty"`
@@ -234,6 +245,29 @@ type PackageSource struct {
 
 	// Upgrade merges changes from a new upstream version into a local package.
 	Upgrade *PackageUpgradeSpec `json:"upgrade,omitempty"`
+
+	// PromoteFrom creates a new revision of the package from a published revision of
+	// the same package in another repository, typically one registered on another branch
+	// of the same git repository (for example dev -> staging -> prod). The promoted
+	// revision records the source revision as its upstream.
+	PromoteFrom *PackageRevisionRef `json:"promoteFrom,omitempty"`
+}
+
+// Subpackage describes an independent subpackage embedded in a package revision.
+type Subpackage struct {
+	// Path is the directory of the subpackage, relative to the root of the package.
+	Path string `json:"path"`
+
+	// Parent is the path of the subpackage that contains this subpackage. It is empty
+	// if the subpackage is embedded directly in the package revision.
+	Parent string `json:"parent,omitempty"`
+
+	// Name is the name of the subpackage in its Kptfile.
+	Name string `json:"name,omitempty"`
+
+	// UpstreamLock identifies the upstream data for this subpackage. It is unset if the
+	// subpackage was not cloned from an upstream package.
+	UpstreamLock *Locator `json:"upstreamLock,omitempty"`
 }
 
 // SubpackageOperation specifies an operation on an independent subpackage of a package.
@@ -301,9 +335,12 @@ const (
 
 // Condition reasons for PackageRevision.Conditions
 const (
-	ReasonReady        = "Ready"
-	ReasonPending      = "Pending"
-	ReasonFailed       = "Failed"
-	ReasonRendered     = "Rendered"
-	ReasonRenderFailed = "RenderFailed"
+	ReasonReady                = "Ready"
+	ReasonPending              = "Pending"
+	ReasonFailed               = "Failed"
+	ReasonRendered             = "Rendered"
+	ReasonRenderFailed         = "RenderFailed"
+	ReasonSourceDenied         = "SourceDenied"
+	ReasonReadinessGatesNotMet = "ReadinessGatesNotMet"
+	ReasonMergeConflicts       = "MergeConflicts"
 )
@@ -16,7 +16,7 @@ package v1alpha2
 
 // Package creation source specifications.
 // In v1alpha2, the creation source is specified directly via PackageSource fields.
-// Exactly one of Init, CloneFrom, CopyFrom, or Upgrade must be set when creating a PackageRevision.
+// Exactly one of Init, CloneFrom, CopyFrom, Upgrade, or PromoteFrom must be set when creating a PackageRevision.
 // These fields are immutable after creation.
 
 // PackageInitSpec defines the package initialization parameters.
@@ -28,6 +28,24 @@ type PackageInitSpec struct {
 	Keywords []string `json:"keywords,omitempty"`
 	// Site is a link to page with information about the package.
 	Site string `json:"site,omitempty"`
+	// Template is an optional starter template the new package is instantiated from.
+	// If unset, an empty package containing only a Kptfile is created.
+	Template *PackageTemplate `json:"template,omitempty"`
+}
+
+// PackageTemplate specifies a starter template package and the values for the
+// parameters it declares in its TemplateParameters resource.
+// Exactly one of UpstreamRef or Git must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.upstreamRef), has(self.git)].filter(x, x).size() == 1",message="exactly one of upstreamRef or git must be set"
+type PackageTemplate struct {
+	// UpstreamRef is the reference to a published template package revision in a registered repository.
+	UpstreamRef *PackageRevisionRef `json:"upstreamRef,omitempty"`
+
+	// Git is the template package stored in a Git repository.
+	Git *GitPackage `json:"git,omitempty"`
+
+	// Parameters are the values for the parameters declared by the template.
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 // PackageUpgradeSpec defines the package upgrade parameters.
@@ -53,11 +71,15 @@ type PackageUpgradeSpec struct {
 	//  * force-delete-replace: Wipe all the local changes to the package and replace
 	//    it with the remote version.
 	//  * copy-merge: Copy all the remote changes to the local package.
+	//  * manual-merge: Merge like resource-merge, but keep the local value of every
+	//    conflicting change and record the conflict for manual resolution. The
+	//    MergeConflicts condition lists the conflicts, and the package revision cannot
+	//    be proposed until they are resolved.
 	Strategy PackageMergeStrategy `json:"strategy,omitempty"`
 }
 
 // PackageMergeStrategy defines the strategy for merging package changes
-// +kubebuilder:validation:Enum=resource-merge;fast-forward;force-delete-replace;copy-merge
+// +kubebuilder:validation:Enum=resource-merge;fast-forward;force-delete-replace;copy-merge;manual-merge
 type PackageMergeStrategy string
 
 const (
@@ -65,4 +87,5 @@ const (
 	FastForward        PackageMergeStrategy = "fast-forward"
 	ForceDeleteReplace PackageMergeStrategy = "force-delete-replace"
 	CopyMerge          PackageMergeStrategy = "copy-merge"
+	ManualMerge        PackageMergeStrategy = "manual-merge"
 )
@@ -24,23 +24,25 @@ func LifecycleIsPublished(lifecycle PackageRevisionLifecycle) bool {
 
 // PackageRevisionIsReady checks if the package has met all readiness gates
 func PackageRevisionIsReady(readinessGates []ReadinessGate, packageConditions []PackageCondition) bool {
-	// Index our package conditions
+	return len(UnmetReadinessGates(readinessGates, packageConditions)) == 0
+}
+
+// UnmetReadinessGates returns the condition types of the readiness gates whose condition
+// is missing or not True.
+func UnmetReadinessGates(readinessGates []ReadinessGate, packageConditions []PackageCondition) []string {
+	// Index our conditions
 	conds := make(map[string]PackageCondition)
 	for _, c := range packageConditions {
 		conds[c.Type] = c
 	}
 
-	// Check if the readiness gates are met
+	var unmet []string
 	for _, g := range readinessGates {
-		if _, ok := conds[g.ConditionType]; !ok {
-			return false
-		}
-		if conds[g.ConditionType].Status != PackageConditionTrue {
-			return false
+		if c, ok := conds[g.ConditionType]; !ok || c.Status != PackageConditionTrue {
+			unmet = append(unmet, g.ConditionType)
 		}
 	}
-
-	return true
+	return unmet
 }
 
 // IsPackageCreation checks if the package revision is an init or clone operation
@@ -112,6 +112,20 @@ func TestPackageRevisionIsReady(t *testing.T) {
 	}
 }
 
+func TestUnmetReadinessGates(t *testing.T) {
+	gates := []ReadinessGate{
+		{ConditionType: "Ready"},
+		{ConditionType: "Scanned"},
+		{ConditionType: "Approved"},
+	}
+	conditions := []PackageCondition{
+		{Type: "Ready", Status: PackageConditionTrue},
+		{Type: "Scanned", Status: PackageConditionFalse},
+	}
+	assert.Equal(t, []string{"Scanned", "Approved"}, UnmetReadinessGates(gates, conditions))
+	assert.Empty(t, UnmetReadinessGates(nil, conditions))
+}
+
 func TestIsPackageCreation(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -20,9 +20,41 @@
 package porch
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundledPackageRevision) DeepCopyInto(out *BundledPackageRevision) {
+	*out = *in
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]Task, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PublishedAt.DeepCopyInto(&out.PublishedAt)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundledPackageRevision.
+func (in *BundledPackageRevision) DeepCopy() *BundledPackageRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(BundledPackageRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -39,6 +71,49 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]DeploymentTargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatus.
+func (in *DeploymentStatus) DeepCopy() *DeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentTargetStatus) DeepCopyInto(out *DeploymentTargetStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentTargetStatus.
+func (in *DeploymentTargetStatus) DeepCopy() *DeploymentTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Field) DeepCopyInto(out *Field) {
 	*out = *in
@@ -203,6 +278,11 @@ func (in *PackageInitTaskSpec) DeepCopyInto(out *PackageInitTaskSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(PackageTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -274,6 +354,236 @@ func (in *PackageRevision) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionBatch) DeepCopyInto(out *PackageRevisionBatch) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionBatch.
+func (in *PackageRevisionBatch) DeepCopy() *PackageRevisionBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageRevisionBatch) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionBatchResult) DeepCopyInto(out *PackageRevisionBatchResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionBatchResult.
+func (in *PackageRevisionBatchResult) DeepCopy() *PackageRevisionBatchResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionBatchResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionBatchSpec) DeepCopyInto(out *PackageRevisionBatchSpec) {
+	*out = *in
+	if in.PackageRevisions != nil {
+		in, out := &in.PackageRevisions, &out.PackageRevisions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionBatchSpec.
+func (in *PackageRevisionBatchSpec) DeepCopy() *PackageRevisionBatchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionBatchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionBatchStatus) DeepCopyInto(out *PackageRevisionBatchStatus) {
+	*out = *in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]PackageRevisionBatchResult, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionBatchStatus.
+func (in *PackageRevisionBatchStatus) DeepCopy() *PackageRevisionBatchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionBatchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionConditions) DeepCopyInto(out *PackageRevisionConditions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionConditions.
+func (in *PackageRevisionConditions) DeepCopy() *PackageRevisionConditions {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionConditions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageRevisionConditions) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionConditionsSpec) DeepCopyInto(out *PackageRevisionConditionsSpec) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionConditionsSpec.
+func (in *PackageRevisionConditionsSpec) DeepCopy() *PackageRevisionConditionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionConditionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionConditionsStatus) DeepCopyInto(out *PackageRevisionConditionsStatus) {
+	*out = *in
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]ReadinessGate, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionConditionsStatus.
+func (in *PackageRevisionConditionsStatus) DeepCopy() *PackageRevisionConditionsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionConditionsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionDependencies) DeepCopyInto(out *PackageRevisionDependencies) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionDependencies.
+func (in *PackageRevisionDependencies) DeepCopy() *PackageRevisionDependencies {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionDependencies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageRevisionDependencies) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionDependenciesStatus) DeepCopyInto(out *PackageRevisionDependenciesStatus) {
+	*out = *in
+	if in.Upstream != nil {
+		in, out := &in.Upstream, &out.Upstream
+		*out = make([]PackageRevisionDependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.Downstream != nil {
+		in, out := &in.Downstream, &out.Downstream
+		*out = make([]PackageRevisionDependency, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionDependenciesStatus.
+func (in *PackageRevisionDependenciesStatus) DeepCopy() *PackageRevisionDependenciesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionDependenciesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionDependency) DeepCopyInto(out *PackageRevisionDependency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionDependency.
+func (in *PackageRevisionDependency) DeepCopy() *PackageRevisionDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageRevisionList) DeepCopyInto(out *PackageRevisionList) {
 	*out = *in
@@ -307,6 +617,81 @@ func (in *PackageRevisionList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionPipeline) DeepCopyInto(out *PackageRevisionPipeline) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionPipeline.
+func (in *PackageRevisionPipeline) DeepCopy() *PackageRevisionPipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionPipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageRevisionPipeline) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionPipelineSpec) DeepCopyInto(out *PackageRevisionPipelineSpec) {
+	*out = *in
+	if in.Mutators != nil {
+		in, out := &in.Mutators, &out.Mutators
+		*out = make([]PipelineFunction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Validators != nil {
+		in, out := &in.Validators, &out.Validators
+		*out = make([]PipelineFunction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionPipelineSpec.
+func (in *PackageRevisionPipelineSpec) DeepCopy() *PackageRevisionPipelineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionPipelineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRevisionPipelineStatus) DeepCopyInto(out *PackageRevisionPipelineStatus) {
+	*out = *in
+	in.RenderStatus.DeepCopyInto(&out.RenderStatus)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionPipelineStatus.
+func (in *PackageRevisionPipelineStatus) DeepCopy() *PackageRevisionPipelineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRevisionPipelineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageRevisionRef) DeepCopyInto(out *PackageRevisionRef) {
 	*out = *in
@@ -411,6 +796,13 @@ func (in *PackageRevisionResourcesSpec) DeepCopy() *PackageRevisionResourcesSpec
 func (in *PackageRevisionResourcesStatus) DeepCopyInto(out *PackageRevisionResourcesStatus) {
 	*out = *in
 	in.RenderStatus.DeepCopyInto(&out.RenderStatus)
+	if in.Subpackages != nil {
+		in, out := &in.Subpackages, &out.Subpackages
+		*out = make([]Subpackage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -481,6 +873,18 @@ func (in *PackageRevisionStatus) DeepCopyInto(out *PackageRevisionStatus) {
 		*out = make([]Condition, len(*in))
 		copy(*out, *in)
 	}
+	if in.DeploymentStatus != nil {
+		in, out := &in.DeploymentStatus, &out.DeploymentStatus
+		*out = new(DeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Subpackages != nil {
+		in, out := &in.Subpackages, &out.Subpackages
+		*out = make([]Subpackage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -526,6 +930,90 @@ func (in *PackageStatus) DeepCopy() *PackageStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageTemplate) DeepCopyInto(out *PackageTemplate) {
+	*out = *in
+	if in.UpstreamRef != nil {
+		in, out := &in.UpstreamRef, &out.UpstreamRef
+		*out = new(PackageRevisionRef)
+		**out = **in
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitPackage)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageTemplate.
+func (in *PackageTemplate) DeepCopy() *PackageTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageUpgradePreview) DeepCopyInto(out *PackageUpgradePreview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageUpgradePreview.
+func (in *PackageUpgradePreview) DeepCopy() *PackageUpgradePreview {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageUpgradePreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageUpgradePreview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageUpgradePreviewStatus) DeepCopyInto(out *PackageUpgradePreviewStatus) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]UpgradePreviewResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageUpgradePreviewStatus.
+func (in *PackageUpgradePreviewStatus) DeepCopy() *PackageUpgradePreviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageUpgradePreviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageUpgradeTaskSpec) DeepCopyInto(out *PackageUpgradeTaskSpec) {
 	*out = *in
@@ -561,6 +1049,29 @@ func (in *ParentReference) DeepCopy() *ParentReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineFunction) DeepCopyInto(out *PipelineFunction) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineFunction.
+func (in *PipelineFunction) DeepCopy() *PipelineFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PorchPackage) DeepCopyInto(out *PorchPackage) {
 	*out = *in
@@ -655,6 +1166,78 @@ func (in *RenderStatus) DeepCopy() *RenderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryBundle) DeepCopyInto(out *RepositoryBundle) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryBundle.
+func (in *RepositoryBundle) DeepCopy() *RepositoryBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RepositoryBundle) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryBundleSpec) DeepCopyInto(out *RepositoryBundleSpec) {
+	*out = *in
+	if in.PackageRevisions != nil {
+		in, out := &in.PackageRevisions, &out.PackageRevisions
+		*out = make([]BundledPackageRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryBundleSpec.
+func (in *RepositoryBundleSpec) DeepCopy() *RepositoryBundleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryBundleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryBundleStatus) DeepCopyInto(out *RepositoryBundleStatus) {
+	*out = *in
+	if in.Imported != nil {
+		in, out := &in.Imported, &out.Imported
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryBundleStatus.
+func (in *RepositoryBundleStatus) DeepCopy() *RepositoryBundleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryBundleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepositoryRef) DeepCopyInto(out *RepositoryRef) {
 	*out = *in
@@ -811,6 +1394,27 @@ func (in *Selector) DeepCopy() *Selector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Subpackage) DeepCopyInto(out *Subpackage) {
+	*out = *in
+	if in.UpstreamLock != nil {
+		in, out := &in.UpstreamLock, &out.UpstreamLock
+		*out = new(Locator)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subpackage.
+func (in *Subpackage) DeepCopy() *Subpackage {
+	if in == nil {
+		return nil
+	}
+	out := new(Subpackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Task) DeepCopyInto(out *Task) {
 	*out = *in
@@ -873,6 +1477,27 @@ func (in *TaskResult) DeepCopy() *TaskResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePreviewResource) DeepCopyInto(out *UpgradePreviewResource) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePreviewResource.
+func (in *UpgradePreviewResource) DeepCopy() *UpgradePreviewResource {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePreviewResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpstreamPackage) DeepCopyInto(out *UpstreamPackage) {
 	*out = *in
@@ -19,11 +19,26 @@
 
 package porch
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in BundledPackageRevision) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.BundledPackageRevision"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in Condition) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.Condition"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in DeploymentStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.DeploymentStatus"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in DeploymentTargetStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.DeploymentTargetStatus"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in Field) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.Field"
@@ -84,11 +99,76 @@ func (in PackageRevision) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.PackageRevision"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionBatch) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionBatch"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionBatchResult) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionBatchResult"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionBatchSpec) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionBatchSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionBatchStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionBatchStatus"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionConditions) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionConditions"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionConditionsSpec) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionConditionsSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionConditionsStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionConditionsStatus"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionDependencies) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionDependencies"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionDependenciesStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionDependenciesStatus"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionDependency) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionDependency"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in PackageRevisionList) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.PackageRevisionList"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionPipeline) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionPipeline"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionPipelineSpec) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionPipelineSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageRevisionPipelineStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageRevisionPipelineStatus"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in PackageRevisionRef) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.PackageRevisionRef"
@@ -134,6 +214,21 @@ func (in PackageStatus) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.PackageStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageTemplate) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageTemplate"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageUpgradePreview) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageUpgradePreview"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PackageUpgradePreviewStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PackageUpgradePreviewStatus"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in PackageUpgradeTaskSpec) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.PackageUpgradeTaskSpec"
@@ -144,6 +239,11 @@ func (in ParentReference) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.ParentReference"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in PipelineFunction) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.PipelineFunction"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in PorchPackage) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.PorchPackage"
@@ -164,6 +264,21 @@ func (in RenderStatus) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.RenderStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in RepositoryBundle) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.RepositoryBundle"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in RepositoryBundleSpec) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.RepositoryBundleSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in RepositoryBundleStatus) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.RepositoryBundleStatus"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in RepositoryRef) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.RepositoryRef"
@@ -199,6 +314,11 @@ func (in Selector) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.Selector"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in Subpackage) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.Subpackage"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in Task) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.Task"
@@ -209,6 +329,11 @@ func (in TaskResult) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.TaskResult"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in UpgradePreviewResource) OpenAPIModelName() string {
+	return "com.github.kptdev.porch.api.porch.UpgradePreviewResource"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in UpstreamPackage) OpenAPIModelName() string {
 	return "com.github.kptdev.porch.api.porch.UpstreamPackage"
@@ -0,0 +1,185 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=packagerevisiondeploymentstatuses,singular=packagerevisiondeploymentstatus,shortName=prds
+// +kubebuilder:printcolumn:name="PackageRevision",type=string,JSONPath=`.spec.packageRevision`
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+// +kubebuilder:printcolumn:name="Commit",type=string,JSONPath=`.spec.syncedCommit`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:selectablefield:JSONPath=`.spec.packageRevision`
+
+// PackageRevisionDeploymentStatus is reported by a GitOps agent after it syncs a
+// package revision of a deployment repository to a target, such as a cluster.
+// There is one object per package revision and target, in the namespace of the
+// package revision. Porch aggregates the reports on the status of the
+// PackageRevision and of its Repository.
+type PackageRevisionDeploymentStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PackageRevisionDeploymentStatusSpec `json:"spec,omitempty"`
+}
+
+// PackageRevisionDeploymentStatusSpec is the status of a package revision on a
+// deployment target, as last reported by the GitOps agent.
+type PackageRevisionDeploymentStatusSpec struct {
+	// PackageRevision is the name of the deployed PackageRevision.
+	PackageRevision string `json:"packageRevision"`
+
+	// Target identifies where the package revision is deployed, for example
+	// the name of a cluster.
+	Target string `json:"target"`
+
+	// SyncedCommit is the commit of the deployment repository that the agent
+	// last synced to the target.
+	// +optional
+	SyncedCommit string `json:"syncedCommit,omitempty"`
+
+	// State is the outcome of the last sync.
+	// +kubebuilder:validation:Enum=Applied;Failed;Drifted;Progressing
+	State DeploymentState `json:"state"`
+
+	// Message gives details of the state, such as the reason a sync failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastSyncTime is the time the agent last synced the package revision.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Resources reports the health of the resources of the package revision
+	// on the target.
+	// +optional
+	Resources []DeployedResourceStatus `json:"resources,omitempty"`
+}
+
+// DeploymentState is the outcome of syncing a package revision to a target.
+type DeploymentState string
+
+const (
+	// DeploymentStateApplied means that the package revision was applied and
+	// the target matches it.
+	DeploymentStateApplied DeploymentState = "Applied"
+	// DeploymentStateFailed means that the package revision could not be applied.
+	DeploymentStateFailed DeploymentState = "Failed"
+	// DeploymentStateDrifted means that the package revision was applied, but
+	// the target has since been changed outside of the deployment repository.
+	DeploymentStateDrifted DeploymentState = "Drifted"
+	// DeploymentStateProgressing means that the package revision is being applied.
+	DeploymentStateProgressing DeploymentState = "Progressing"
+)
+
+// DeployedResourceStatus is the health of one resource of a package revision
+// on a deployment target.
+type DeployedResourceStatus struct {
+	// Group is the API group of the resource; empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the resource; empty for cluster-scoped resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Health is the health of the resource.
+	// +kubebuilder:validation:Enum=Healthy;Progressing;Degraded;Missing;Unknown
+	Health ResourceHealth `json:"health"`
+
+	// Message gives details of the health of the resource.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ResourceHealth is the health of a deployed resource.
+type ResourceHealth string
+
+const (
+	ResourceHealthHealthy     ResourceHealth = "Healthy"
+	ResourceHealthProgressing ResourceHealth = "Progressing"
+	ResourceHealthDegraded    ResourceHealth = "Degraded"
+	ResourceHealthMissing     ResourceHealth = "Missing"
+	ResourceHealthUnknown     ResourceHealth = "Unknown"
+)
+
+// +kubebuilder:object:root=true
+
+// PackageRevisionDeploymentStatusList contains a list of PackageRevisionDeploymentStatus
+type PackageRevisionDeploymentStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PackageRevisionDeploymentStatus `json:"items"`
+}
+
+// RepositoryDeploymentStatus summarises the deployment statuses reported for
+// the package revisions of a deployment repository.
+type RepositoryDeploymentStatus struct {
+	// PackageRevisions is the number of package revisions with at least one
+	// reported deployment status.
+	PackageRevisions int `json:"packageRevisions,omitempty"`
+	// Applied is the number of package revisions that are applied on all their targets.
+	Applied int `json:"applied,omitempty"`
+	// Failed is the number of package revisions that failed on at least one target.
+	Failed int `json:"failed,omitempty"`
+	// Drifted is the number of package revisions that drifted on at least one
+	// target and failed on none.
+	Drifted int `json:"drifted,omitempty"`
+	// Progressing is the number of the remaining package revisions that are
+	// still being applied on at least one target.
+	Progressing int `json:"progressing,omitempty"`
+}
+
+// deploymentStateSeverity orders the deployment states from the least to the
+// most severe.
+var deploymentStateSeverity = map[DeploymentState]int{
+	DeploymentStateApplied:     1,
+	DeploymentStateProgressing: 2,
+	DeploymentStateDrifted:     3,
+	DeploymentStateFailed:      4,
+}
+
+// AggregateDeploymentState returns the most severe of the given states: Failed,
+// then Drifted, then Progressing, then Applied. It returns "" if no states are given.
+func AggregateDeploymentState(states ...DeploymentState) DeploymentState {
+	var aggregate DeploymentState
+	for _, state := range states {
+		if deploymentStateSeverity[state] > deploymentStateSeverity[aggregate] {
+			aggregate = state
+		}
+	}
+	return aggregate
+}
+
+// HealthyResources returns the number of reported resources that are healthy.
+func (s *PackageRevisionDeploymentStatusSpec) HealthyResources() int {
+	healthy := 0
+	for _, resource := range s.Resources {
+		if resource.Health == ResourceHealthHealthy {
+			healthy++
+		}
+	}
+	return healthy
+}
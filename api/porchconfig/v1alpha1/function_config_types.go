@@ -41,7 +41,7 @@ type FunctionConfigList struct {
 	Items []FunctionConfig `json:"items"`
 }
 
-// +kubebuilder:validation:XValidation:message="At least one configuration must be specified",rule="has(self.podExecutor) || has(self.binaryExecutor) || has(self.goExecutor)"
+// +kubebuilder:validation:XValidation:message="At least one configuration must be specified",rule="has(self.podExecutor) || has(self.binaryExecutor) || has(self.goExecutor) || has(self.scriptExecutor)"
 type FunctionConfigSpec struct {
 	// +kubebuilder:validation:MinLength=1
 	Image          string                `json:"image"`
@@ -49,6 +49,11 @@ type FunctionConfigSpec struct {
 	PodExecutor    *PodExecutorConfig    `json:"podExecutor,omitempty"`
 	BinaryExecutor *BinaryExecutorConfig `json:"binaryExecutor,omitempty"`
 	GoExecutor     *GoExecutorConfig     `json:"goExecutor,omitempty"`
+	ScriptExecutor *ScriptExecutorConfig `json:"scriptExecutor,omitempty"`
+	// DisableResultCache excludes the function from the function runner's result cache.
+	// Set it for functions whose output is not fully determined by their input, e.g.
+	// functions that generate names or fetch data from external systems.
+	DisableResultCache bool `json:"disableResultCache,omitempty"`
 }
 
 type FunctionConfigStatus struct {
@@ -109,3 +114,33 @@ type GoExecutorConfig struct {
 	// If empty, `.spec.image` will be used instead.
 	ID *string `json:"id,omitempty"`
 }
+
+type ScriptLanguage string
+
+const (
+	ScriptLanguageStarlark ScriptLanguage = "starlark"
+	ScriptLanguageCEL      ScriptLanguage = "cel"
+)
+
+// ScriptExecutorConfig defines the function inline as a script, which Porch
+// runs in-process instead of the image.
+type ScriptExecutorConfig struct {
+	// Image tags which can be substituted with the script.
+	// +kubebuilder:validation:MinItems=1
+	Tags []string `json:"tags"`
+	// Language is the language of the script.
+	// +kubebuilder:validation:Enum=starlark;cel
+	Language ScriptLanguage `json:"language"`
+	// Source is the script. A Starlark script modifies `ctx.resource_list` in place.
+	// A CEL expression is evaluated with the ResourceList as `resourceList` and returns
+	// either the new list of items, or a bool that fails the function if it is false.
+	// +kubebuilder:validation:MinLength=1
+	Source string `json:"source"`
+	// MaxSteps limits the Starlark execution steps or the CEL evaluation cost of one run.
+	// Defaults to 1000000.
+	// +kubebuilder:validation:Minimum=1
+	MaxSteps int64 `json:"maxSteps,omitempty"`
+	// Timeout limits the duration of one run. Defaults to 10s.
+	// +kubebuilder:validation:Format=duration
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=functionimagepolicies,singular=functionimagepolicy
+
+// FunctionImagePolicy restricts the KRM function images that the function
+// runner evaluates for package revisions in its namespace. If a namespace has
+// no FunctionImagePolicy, any image is allowed. If it has one or more, an
+// image is allowed only if none of them rejects it.
+type FunctionImagePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec FunctionImagePolicySpec `json:"spec,omitempty"`
+}
+
+// FunctionImagePolicySpec lists the allowed and denied function images.
+//
+// Patterns use the syntax of path.Match, so "*" matches any sequence of
+// characters except "/". A pattern matches an image if it matches the full
+// image name, the image name without its tag or digest, or any of its parent
+// paths. For example "ghcr.io/kptdev/krm-functions-catalog" matches every
+// image of the function catalog.
+type FunctionImagePolicySpec struct {
+	// Allow lists patterns of the images that functions may use. If it is
+	// empty, every image that is not denied is allowed.
+	Allow []string `json:"allow,omitempty"`
+
+	// Deny lists patterns of the images that functions must not use. Deny
+	// takes precedence over Allow.
+	Deny []string `json:"deny,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FunctionImagePolicyList contains a list of FunctionImagePolicy
+type FunctionImagePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FunctionImagePolicy `json:"items"`
+}
+
+// AllowsImage reports whether the policy allows evaluating functions with
+// the given image.
+func (p *FunctionImagePolicy) AllowsImage(image string) bool {
+	for _, pattern := range p.Spec.Deny {
+		if matchImagePattern(pattern, image) {
+			return false
+		}
+	}
+	if len(p.Spec.Allow) == 0 {
+		return true
+	}
+	for _, pattern := range p.Spec.Allow {
+		if matchImagePattern(pattern, image) {
+			return true
+		}
+	}
+	return false
+}
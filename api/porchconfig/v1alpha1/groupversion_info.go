@@ -65,6 +65,36 @@ var (
 		objects:  []runtime.Object{&PackageVariantSet{}, &PackageVariantSetList{}},
 	}
 
+	TypeInjectionGrant = TypeInfo{
+		Kind:     "InjectionGrant",
+		Resource: GroupVersion.WithResource("injectiongrants"),
+		objects:  []runtime.Object{&InjectionGrant{}, &InjectionGrantList{}},
+	}
+
+	TypeSourcePolicy = TypeInfo{
+		Kind:     "SourcePolicy",
+		Resource: GroupVersion.WithResource("sourcepolicies"),
+		objects:  []runtime.Object{&SourcePolicy{}, &SourcePolicyList{}},
+	}
+
+	TypeFunctionImagePolicy = TypeInfo{
+		Kind:     "FunctionImagePolicy",
+		Resource: GroupVersion.WithResource("functionimagepolicies"),
+		objects:  []runtime.Object{&FunctionImagePolicy{}, &FunctionImagePolicyList{}},
+	}
+
+	TypePackageRevisionDeploymentStatus = TypeInfo{
+		Kind:     "PackageRevisionDeploymentStatus",
+		Resource: GroupVersion.WithResource("packagerevisiondeploymentstatuses"),
+		objects:  []runtime.Object{&PackageRevisionDeploymentStatus{}, &PackageRevisionDeploymentStatusList{}},
+	}
+
+	TypeUpgradePolicy = TypeInfo{
+		Kind:     "UpgradePolicy",
+		Resource: GroupVersion.WithResource("upgradepolicies"),
+		objects:  []runtime.Object{&UpgradePolicy{}, &UpgradePolicyList{}},
+	}
+
 	AllKinds = []TypeInfo{
 		TypePackageRev,
 		TypeRepository,
@@ -72,6 +102,11 @@ var (
 		TypeServiceTemplate,
 		TypePackageVariant,
 		TypePackageVariantSet,
+		TypeInjectionGrant,
+		TypeSourcePolicy,
+		TypeFunctionImagePolicy,
+		TypePackageRevisionDeploymentStatus,
+		TypeUpgradePolicy,
 	}
 )
 
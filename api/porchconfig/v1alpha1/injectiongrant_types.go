@@ -0,0 +1,92 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=injectiongrants,singular=injectiongrant
+
+// InjectionGrant allows PackageVariants in other namespaces to inject
+// resources from the namespace of the InjectionGrant.
+type InjectionGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec InjectionGrantSpec `json:"spec,omitempty"`
+}
+
+// InjectionGrantSpec defines which namespaces may inject which resources.
+type InjectionGrantSpec struct {
+	// From lists the namespaces whose PackageVariants may inject resources
+	// from this namespace.
+	From []InjectionGrantFrom `json:"from"`
+
+	// To lists the resources that may be injected. If empty, any resource in
+	// this namespace may be injected.
+	To []InjectionGrantTo `json:"to,omitempty"`
+}
+
+// InjectionGrantFrom identifies a namespace that is granted access.
+type InjectionGrantFrom struct {
+	Namespace string `json:"namespace"`
+}
+
+// InjectionGrantTo identifies resources that may be injected. Resources
+// inside package revisions are matched by their own group and kind.
+type InjectionGrantTo struct {
+	// Group of the resource. The core group is specified as "".
+	Group string `json:"group"`
+	Kind  string `json:"kind"`
+
+	// Name of the resource. If omitted, all resources of the group and kind
+	// may be injected.
+	Name *string `json:"name,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InjectionGrantList contains a list of InjectionGrant
+type InjectionGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InjectionGrant `json:"items"`
+}
+
+// Allows reports whether the grant allows PackageVariants in the namespace to
+// inject the named resource of the given group and kind.
+func (g *InjectionGrant) Allows(namespace, group, kind, name string) bool {
+	fromAllowed := false
+	for _, from := range g.Spec.From {
+		if from.Namespace == namespace {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+	if len(g.Spec.To) == 0 {
+		return true
+	}
+	for _, to := range g.Spec.To {
+		if to.Group == group && to.Kind == kind && (to.Name == nil || *to.Name == name) {
+			return true
+		}
+	}
+	return false
+}
@@ -90,12 +90,38 @@ type PackageContext struct {
 }
 
 // InjectionSelector specifies how to select in-cluster objects for
-// resolving injection points.
+// resolving injection points. At least one of Name and Selector must be
+// specified; when both are, a resource must match both.
 type InjectionSelector struct {
 	Group   *string `json:"group,omitempty"`
 	Version *string `json:"version,omitempty"`
 	Kind    *string `json:"kind,omitempty"`
-	Name    string  `json:"name"`
+	Name    string  `json:"name,omitempty"`
+
+	// Selector selects resources by label. If more than one resource
+	// matches, the first one in name order is injected.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Namespace is the namespace to select resources from. Defaults to the
+	// namespace of the PackageVariant. Selecting from another namespace
+	// requires an InjectionGrant in that namespace that allows it.
+	Namespace string `json:"namespace,omitempty"`
+
+	// FromPackage selects the resource from the contents of a Published
+	// package revision, such as a shared values package, instead of from
+	// the cluster.
+	FromPackage *InjectionPackageRef `json:"fromPackage,omitempty"`
+}
+
+// InjectionPackageRef identifies a Published package revision whose resources
+// can be injected.
+type InjectionPackageRef struct {
+	Repo    string `json:"repo"`
+	Package string `json:"package"`
+
+	// Revision is the revision of the package to inject from. If omitted,
+	// the latest Published revision is used.
+	Revision int `json:"revision,omitempty"`
 }
 
 // PackageVariantStatus defines the observed state of PackageVariant
@@ -28,6 +28,7 @@ import (
 //+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=='Ready')].status`
 //+kubebuilder:printcolumn:name="Address",type=string,JSONPath=`.spec['git','oci']['repo','registry']`
 //+kubebuilder:printcolumn:name="Branch",type=string,JSONPath=`.spec.git.branch`
+//+kubebuilder:printcolumn:name="Shard",type=string,JSONPath=`.status.shard`,priority=1
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:validation:XValidation:rule="self.metadata.name.matches('^[a-z0-9]([-a-z0-9]*[a-z0-9])?$')",message="metadata.name must conform to the RFC1123 DNS label standard"
 // +kubebuilder:validation:XValidation:rule="size(self.metadata.name) <= 63",message="metadata.name must be no more than 63 characters"
@@ -81,6 +82,8 @@ type RepositorySpec struct {
 	Git *GitRepository `json:"git,omitempty"`
 	// OCI repository details. Required if `type` is `oci`. Ignored if `type` is not `oci`.
 	Oci *OciRepository `json:"oci,omitempty"`
+	// Retention policy for package revisions in the repository. If unspecified, package revisions are never garbage-collected.
+	Retention *RetentionPolicy `json:"retention,omitempty"`
 }
 
 type RepositorySync struct {
@@ -90,6 +93,21 @@ type RepositorySync struct {
 	Schedule string `json:"schedule,omitempty"`
 }
 
+// RetentionPolicy defines which package revisions the repository controller garbage-collects
+// during a full sync. Package revisions referenced as the upstream of another package revision
+// in the namespace are never removed.
+type RetentionPolicy struct {
+	// KeepPublished is the number of most recent Published revisions to keep for each package.
+	// Older Published revisions are removed. If unspecified, Published revisions are never removed.
+	// +kubebuilder:validation:Minimum=1
+	KeepPublished *int `json:"keepPublished,omitempty"`
+	// DraftTTL is how long a Draft may remain unmodified before it is removed. If unspecified, Drafts are never removed.
+	// +kubebuilder:validation:Format=duration
+	DraftTTL *metav1.Duration `json:"draftTTL,omitempty"`
+	// DryRun reports the package revisions selected by the policy in the status without removing them.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
 // GitRepository describes a Git repository.
 // TODO: authentication methods
 type GitRepository struct {
@@ -194,6 +212,31 @@ type RepositoryStatus struct {
 	// NextFullSyncTime is the timestamp when the next full sync is scheduled to occur.
 	// +optional
 	NextFullSyncTime *metav1.Time `json:"nextFullSyncTime,omitempty"`
+	// Retention reports the outcome of the last retention policy enforcement.
+	// +optional
+	Retention *RetentionStatus `json:"retention,omitempty"`
+	// Deployment summarises the deployment statuses reported by GitOps agents for the
+	// package revisions of a deployment repository.
+	// +optional
+	Deployment *RepositoryDeploymentStatus `json:"deployment,omitempty"`
+	// Shard is the identity of the repository controller replica that owns this
+	// repository when repository reconciliation is sharded across replicas.
+	// +optional
+	Shard string `json:"shard,omitempty"`
+}
+
+// RetentionStatus reports the outcome of a retention policy enforcement.
+type RetentionStatus struct {
+	// LastEnforcedTime is the time the retention policy was last enforced.
+	LastEnforcedTime *metav1.Time `json:"lastEnforcedTime,omitempty"`
+	// DryRun is true if the policy was enforced in dry-run mode and nothing was removed.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Removed lists the package revisions removed by the last enforcement, or, in dry-run mode,
+	// the package revisions that would have been removed.
+	Removed []string `json:"removed,omitempty"`
+	// Protected lists the package revisions selected by the policy that were kept because
+	// another package revision references them as its upstream.
+	Protected []string `json:"protected,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,131 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"path"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=sourcepolicies,singular=sourcepolicy
+
+// SourcePolicy restricts the upstream sources that package revisions in its
+// namespace may be cloned or upgraded from. If a namespace has no
+// SourcePolicy, any source is allowed. If it has one or more, a source is
+// allowed only if at least one of them allows it.
+type SourcePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SourcePolicySpec `json:"spec,omitempty"`
+}
+
+// SourcePolicySpec lists the allowed upstream sources.
+//
+// Patterns use the syntax of path.Match, so "*" matches any sequence of
+// characters except "/". For example "https://github.com/example-org/*"
+// allows every repository of the example-org organisation.
+type SourcePolicySpec struct {
+	// GitURLs lists patterns of git repository URLs that packages may be
+	// cloned from directly. A trailing ".git" and "/" are ignored when
+	// matching.
+	GitURLs []string `json:"gitURLs,omitempty"`
+
+	// Repositories lists patterns of the names of registered Repositories in
+	// this namespace that packages may be cloned, upgraded or promoted from.
+	Repositories []string `json:"repositories,omitempty"`
+
+	// OCIRegistries lists patterns of OCI registries, for example
+	// "gcr.io/example-project", that packages may be cloned from. An image
+	// is allowed if it is in a matching registry or in a path below it.
+	OCIRegistries []string `json:"ociRegistries,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SourcePolicyList contains a list of SourcePolicy
+type SourcePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SourcePolicy `json:"items"`
+}
+
+// AllowsGitURL reports whether the policy allows cloning from the git
+// repository with the given URL.
+func (p *SourcePolicy) AllowsGitURL(url string) bool {
+	url = normalizeGitURL(url)
+	for _, pattern := range p.Spec.GitURLs {
+		if matchSourcePattern(normalizeGitURL(pattern), url) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRepository reports whether the policy allows cloning, upgrading or
+// promoting from the registered Repository with the given name.
+func (p *SourcePolicy) AllowsRepository(name string) bool {
+	for _, pattern := range p.Spec.Repositories {
+		if matchSourcePattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsOCIImage reports whether the policy allows cloning from the given
+// OCI image. Tags and digests are ignored.
+func (p *SourcePolicy) AllowsOCIImage(image string) bool {
+	for _, pattern := range p.Spec.OCIRegistries {
+		if matchImagePattern(pattern, image) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeGitURL(url string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+}
+
+func matchSourcePattern(pattern, value string) bool {
+	matched, err := path.Match(pattern, value)
+	return err == nil && matched
+}
+
+// matchImagePattern reports whether pattern matches the OCI image, the image
+// without its tag or digest, or one of the parent paths of the image.
+func matchImagePattern(pattern, image string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+	if matchSourcePattern(pattern, image) {
+		return true
+	}
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	// path.Dir ends at "." for relative paths and at "/" for absolute ones
+	for prefix := image; prefix != "." && prefix != "/"; prefix = path.Dir(prefix) {
+		if matchSourcePattern(pattern, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowsOCIImage(t *testing.T) {
+	policy := &SourcePolicy{
+		Spec: SourcePolicySpec{
+			OCIRegistries: []string{"gcr.io/example-project/", "ghcr.io/*/packages"},
+		},
+	}
+
+	tests := []struct {
+		image   string
+		allowed bool
+	}{
+		{image: "gcr.io/example-project", allowed: true},
+		{image: "gcr.io/example-project/pkg", allowed: true},
+		{image: "gcr.io/example-project/team/pkg:v1", allowed: true},
+		{image: "gcr.io/example-project/pkg@sha256:0123", allowed: true},
+		{image: "ghcr.io/example-org/packages/pkg:v1", allowed: true},
+		{image: "gcr.io/other-project/pkg", allowed: false},
+		{image: "gcr.io/example-project-evil/pkg", allowed: false},
+		{image: "gcr.io", allowed: false},
+		{image: "", allowed: false},
+		// Absolute paths must not loop forever looking for a parent
+		{image: "/evil", allowed: false},
+		{image: "/gcr.io/example-project/pkg", allowed: false},
+		{image: "/", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, policy.AllowsOCIImage(tt.image))
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=upgradepolicies,singular=upgradepolicy
+// +kubebuilder:printcolumn:name="Strategy",type=string,JSONPath=`.spec.strategy`
+// +kubebuilder:printcolumn:name="Constraint",type=string,JSONPath=`.spec.constraint`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// UpgradePolicy creates upgrade drafts for downstream package revisions in its
+// namespace when a newer revision of their upstream package is published.
+// It covers packages that were cloned by hand; packages managed by a
+// PackageVariant are upgraded by the PackageVariant controller and are skipped.
+type UpgradePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UpgradePolicySpec   `json:"spec,omitempty"`
+	Status UpgradePolicyStatus `json:"status,omitempty"`
+}
+
+const (
+	// UpgradePolicyLabel is set on the upgrade drafts created by an
+	// UpgradePolicy to the name of the policy.
+	UpgradePolicyLabel = "config.porch.kpt.dev/upgrade-policy"
+)
+
+// UpgradePolicySpec defines which downstream packages are upgraded, to which
+// upstream revisions and how.
+type UpgradePolicySpec struct {
+	// Downstream selects the downstream packages to upgrade. Only the latest
+	// Published revision of each package is considered.
+	// +optional
+	Downstream PackageRevisionSelector `json:"downstream,omitempty"`
+
+	// Upstream selects the upstream packages whose new Published revisions
+	// trigger upgrades.
+	// +optional
+	Upstream PackageRevisionSelector `json:"upstream,omitempty"`
+
+	// Constraint restricts the upstream revisions to upgrade to, using the
+	// syntax of semantic version constraints, for example ">= 3, < 5". A
+	// revision number N is compared as version N.0.0, so "^3" allows only
+	// revision 3 and "~3" is the same. If omitted, the latest Published
+	// revision is used.
+	// +optional
+	Constraint string `json:"constraint,omitempty"`
+
+	// Strategy is the merge strategy of the upgrade drafts. Defaults to
+	// resource-merge.
+	// +optional
+	// +kubebuilder:validation:Enum=resource-merge;fast-forward;force-delete-replace;copy-merge;manual-merge
+	Strategy porchapi.PackageMergeStrategy `json:"strategy,omitempty"`
+
+	// Labels and Annotations are added to the upgrade drafts.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// PackageRevisionSelector selects package revisions by repository, package
+// name and labels. An empty selector selects every package revision.
+//
+// Patterns use the syntax of path.Match, so "*" matches any sequence of
+// characters except "/".
+type PackageRevisionSelector struct {
+	// Repositories lists patterns of repository names. If empty, every
+	// repository matches.
+	// +optional
+	Repositories []string `json:"repositories,omitempty"`
+
+	// Packages lists patterns of package names. If empty, every package
+	// matches.
+	// +optional
+	Packages []string `json:"packages,omitempty"`
+
+	// Selector selects package revisions by their labels.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// UpgradePolicyStatus defines the observed state of UpgradePolicy
+type UpgradePolicyStatus struct {
+	// Conditions describes the reconciliation state of the object.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// PendingUpgrades lists the downstream package revisions that have an
+	// upgrade available, with the draft that upgrades them.
+	PendingUpgrades []PendingUpgrade `json:"pendingUpgrades,omitempty"`
+}
+
+// PendingUpgrade is an available upgrade of a downstream package revision.
+type PendingUpgrade struct {
+	// Downstream is the name of the downstream PackageRevision.
+	Downstream string `json:"downstream"`
+
+	// CurrentUpstream is the name of the upstream PackageRevision that the
+	// downstream is based on.
+	CurrentUpstream string `json:"currentUpstream"`
+
+	// TargetUpstream is the name of the upstream PackageRevision to upgrade to.
+	TargetUpstream string `json:"targetUpstream"`
+
+	// Draft is the name of the PackageRevision that performs the upgrade.
+	// It is empty if the draft could not be created.
+	// +optional
+	Draft string `json:"draft,omitempty"`
+
+	// DraftResourceVersion is the resource version of the draft when the
+	// policy created it. The policy only replaces its drafts if they are
+	// unchanged since.
+	// +optional
+	DraftResourceVersion string `json:"draftResourceVersion,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// UpgradePolicyList contains a list of UpgradePolicy
+type UpgradePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UpgradePolicy `json:"items"`
+}
+
+// Matches reports whether the selector selects a package revision with the
+// given repository, package name and labels.
+func (s *PackageRevisionSelector) Matches(repository, packageName string, prLabels map[string]string) (bool, error) {
+	if !matchesAnyPattern(s.Repositories, repository) || !matchesAnyPattern(s.Packages, packageName) {
+		return false, nil
+	}
+	if s.Selector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(s.Selector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(prLabels)), nil
+}
+
+func matchesAnyPattern(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matchSourcePattern(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The kpt Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+-- Move the content of resource files into the content-addressed resource_blobs table,
+-- so that files with the same content, for example the unchanged files of consecutive
+-- revisions of a package, are stored only once. The resources table keeps the files
+-- of each package revision and refers to their content by its SHA-256 hash.
+--
+-- The migration rewrites every row of the resources table. Run it while the Porch
+-- server is stopped.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS resource_blobs (
+    hash      TEXT NOT NULL CHECK (hash != ''),
+    content   TEXT NOT NULL,
+    ref_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (hash)
+);
+
+ALTER TABLE resources
+    ADD COLUMN IF NOT EXISTS resource_hash TEXT;
+
+UPDATE resources
+SET resource_hash = encode(sha256(convert_to(resource_value, 'UTF8')), 'hex')
+WHERE resource_hash IS NULL;
+
+INSERT INTO resource_blobs (hash, content)
+SELECT DISTINCT ON (resource_hash) resource_hash, resource_value
+FROM resources
+ON CONFLICT (hash) DO NOTHING;
+
+-- Every row of the resources table holds one reference to its blob.
+UPDATE resource_blobs b
+SET ref_count = r.ref_count
+FROM (
+    SELECT resource_hash, COUNT(*) AS ref_count
+    FROM resources
+    GROUP BY resource_hash
+) r
+WHERE b.hash = r.resource_hash;
+
+ALTER TABLE resources
+    ALTER COLUMN resource_hash SET NOT NULL,
+    DROP COLUMN resource_value;
+
+CREATE OR REPLACE FUNCTION release_resource_blob() RETURNS trigger
+    LANGUAGE plpgsql AS
+$BODY$
+BEGIN
+    UPDATE resource_blobs SET ref_count = ref_count - 1 WHERE hash = OLD.resource_hash;
+    DELETE FROM resource_blobs WHERE hash = OLD.resource_hash AND ref_count <= 0;
+    RETURN NULL;
+END;
+$BODY$;
+
+CREATE OR REPLACE TRIGGER resources_release_blob
+   AFTER DELETE OR UPDATE OF resource_hash ON resources FOR EACH ROW
+   EXECUTE PROCEDURE release_resource_blob();
+
+COMMIT;
@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The kpt Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+-- Copy the content of resource files back into the resources table and remove the
+-- content-addressed resource_blobs table.
+BEGIN;
+
+DROP TRIGGER IF EXISTS resources_release_blob ON resources;
+DROP FUNCTION IF EXISTS release_resource_blob;
+
+ALTER TABLE resources
+    ADD COLUMN IF NOT EXISTS resource_value TEXT;
+
+UPDATE resources r
+SET resource_value = b.content
+FROM resource_blobs b
+WHERE r.resource_hash = b.hash;
+
+ALTER TABLE resources
+    ALTER COLUMN resource_value SET NOT NULL,
+    DROP COLUMN resource_hash;
+
+DROP TABLE IF EXISTS resource_blobs;
+
+COMMIT;
@@ -14,6 +14,8 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 DROP TABLE IF EXISTS resources;
+DROP FUNCTION IF EXISTS release_resource_blob;
+DROP TABLE IF EXISTS resource_blobs;
 
 DROP TABLE IF EXISTS package_revisions;
 DROP FUNCTION IF EXISTS check_package_revisions_columns;
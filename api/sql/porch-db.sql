@@ -219,15 +219,39 @@ CREATE OR REPLACE TRIGGER package_revisions_delete
    AFTER DELETE ON package_revisions FOR EACH ROW
    EXECUTE PROCEDURE check_package_revisions_delete();
 
+-- The content of resource files is stored once in resource_blobs, addressed by its SHA-256 hash, and the
+-- resources table maps the files of each package revision to their blobs. Porch takes a reference to a blob
+-- when it writes a file, and the resources_release_blob trigger releases it when the file is deleted or replaced.
+CREATE TABLE IF NOT EXISTS resource_blobs (
+    hash      TEXT NOT NULL CHECK (hash != ''),
+    content   TEXT NOT NULL,
+    ref_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (hash)
+);
+
 CREATE TABLE IF NOT EXISTS resources (
     k8s_name_space TEXT NOT NULL CHECK (k8s_name_space != ''),
     k8s_name       TEXT NOT NULL CHECK (k8s_name != ''),
     revision       INTEGER NOT NULL,
     resource_key   TEXT NOT NULL CHECK (resource_key != ''),
-    resource_value TEXT NOT NULL,
+    resource_hash  TEXT NOT NULL,
     PRIMARY KEY (k8s_name_space, k8s_name, resource_key),
     CONSTRAINT fk_package_rev
         FOREIGN KEY (k8s_name_space, k8s_name)
         REFERENCES package_revisions (k8s_name_space, k8s_name)
         ON DELETE CASCADE
 );
+
+CREATE OR REPLACE FUNCTION release_resource_blob() RETURNS trigger
+    LANGUAGE plpgsql AS
+$BODY$
+BEGIN
+    UPDATE resource_blobs SET ref_count = ref_count - 1 WHERE hash = OLD.resource_hash;
+    DELETE FROM resource_blobs WHERE hash = OLD.resource_hash AND ref_count <= 0;
+    RETURN NULL;
+END;
+$BODY$;
+
+CREATE OR REPLACE TRIGGER resources_release_blob
+   AFTER DELETE OR UPDATE OF resource_hash ON resources FOR EACH ROW
+   EXECUTE PROCEDURE release_resource_blob();
@@ -28,6 +28,7 @@ import (
 	"github.com/kptdev/krm-functions-catalog/functions/go/starlark/starlark"
 	fnsdk "github.com/kptdev/krm-functions-sdk/go/fn"
 	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/controllers/functionconfigs/script"
 	"github.com/kptdev/porch/pkg/util"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -59,6 +60,9 @@ type FunctionConfigStore struct {
 	functionConfigurations map[string]*configapi.FunctionConfig
 	binaryExecutorCache    map[string]BinaryCacheEntry
 	builtInExecutorCache   map[string]BuiltInCacheEntry
+	scriptExecutorCache    map[string]BuiltInCacheEntry
+	// scriptOwners maps each image in the script cache to the FunctionConfig whose script it holds
+	scriptOwners map[string]string
 
 	defaultImagePrefix string
 	defaultBinaryDir   string
@@ -69,6 +73,8 @@ func NewFunctionConfigStore(defaultImagePrefix, defaultBinaryDir string) *Functi
 		functionConfigurations: make(map[string]*configapi.FunctionConfig),
 		binaryExecutorCache:    make(map[string]BinaryCacheEntry),
 		builtInExecutorCache:   make(map[string]BuiltInCacheEntry),
+		scriptExecutorCache:    make(map[string]BuiltInCacheEntry),
+		scriptOwners:           make(map[string]string),
 		defaultImagePrefix:     strings.TrimRight(defaultImagePrefix, "/"),
 		defaultBinaryDir:       strings.TrimRight(defaultBinaryDir, "/"),
 	}
@@ -164,9 +170,49 @@ func (s *FunctionConfigStore) UpdateExecCache(name string, functionConfig *confi
 	}
 }
 
+// UpdateScriptCache compiles the script of the FunctionConfig, so that it runs
+// in place of the image. It removes the script the FunctionConfig cached
+// before, if the FunctionConfig no longer defines one or its image changed.
+func (s *FunctionConfigStore) UpdateScriptCache(name string, obj *configapi.FunctionConfig) error {
+	if obj.Spec.ScriptExecutor == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.removeScript(name)
+		return nil
+	}
+
+	processor, err := script.NewProcessor(obj.Spec.Image, obj.Spec.ScriptExecutor)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeScript(name)
+	s.scriptExecutorCache[obj.Spec.Image] = BuiltInCacheEntry{
+		Process:     processor,
+		Tags:        obj.Spec.ScriptExecutor.Tags,
+		PrefixRegex: s.generateRegexPattern(obj.Spec.Prefixes, obj.Spec.Image),
+	}
+	s.scriptOwners[obj.Spec.Image] = name
+	return nil
+}
+
+// removeScript removes the scripts the named FunctionConfig cached. Scripts
+// cached by other FunctionConfigs for the same image are kept.
+func (s *FunctionConfigStore) removeScript(name string) {
+	for image, owner := range s.scriptOwners {
+		if owner == name {
+			delete(s.scriptExecutorCache, image)
+			delete(s.scriptOwners, image)
+		}
+	}
+}
+
 func (s *FunctionConfigStore) DeleteFunctionConfig(key types.NamespacedName) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.removeScript(key.Name)
 	delete(s.functionConfigurations, key.Name)
 }
 
@@ -236,9 +282,37 @@ func (s *FunctionConfigStore) GetExecCache() map[string]BuiltInCacheEntry {
 func (s *FunctionConfigStore) GetProcessorFromCache(image string) (fnsdk.ResourceListProcessor, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	return s.lookupProcessor(s.builtInExecutorCache, image)
+}
+
+// GetScriptFromCache looks up the script that runs in place of the image.
+func (s *FunctionConfigStore) GetScriptFromCache(image string) (fnsdk.ResourceListProcessor, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lookupProcessor(s.scriptExecutorCache, image)
+}
+
+// GetScriptFromCacheByConstraint looks up the script that runs in place of
+// the image, selecting the highest tag that satisfies the constraint.
+func (s *FunctionConfigStore) GetScriptFromCacheByConstraint(image, tag string) (fnsdk.ResourceListProcessor, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, found := s.scriptExecutorCache[util.GetImageName(image)]
+	if !found {
+		return nil, false
+	}
+	selectedTag, err := util.FindBestSemverMatch(tag, image, entry.Tags)
+	if err != nil {
+		return nil, false
+	}
+	return s.lookupProcessor(s.scriptExecutorCache, image+":"+selectedTag)
+}
+
+func (s *FunctionConfigStore) lookupProcessor(cache map[string]BuiltInCacheEntry, image string) (fnsdk.ResourceListProcessor, bool) {
 	baseName := util.GetImageName(image)
 	tag := util.GetImageTag(image)
-	entry, found := s.builtInExecutorCache[baseName]
+	entry, found := cache[baseName]
 	prefixToCheck := util.GetImageRepository(image)
 	if prefixToCheck == "" {
 		prefixToCheck = s.defaultImagePrefix
@@ -345,6 +419,10 @@ func (r *FunctionConfigReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		r.FunctionConfigStore.UpdateExecCache(obj.Name, obj)
 	}
 
+	if err := r.FunctionConfigStore.UpdateScriptCache(obj.Name, obj); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -314,6 +314,80 @@ func TestGetProcessorFromCache(t *testing.T) {
 	assert.False(t, found)
 }
 
+func TestGetScriptFromCache(t *testing.T) {
+	store := NewFunctionConfigStore(defaultImagePrefix, functionCacheDir)
+
+	obj := &configapi.FunctionConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "require-labels", Namespace: testNamespace},
+		Spec: configapi.FunctionConfigSpec{
+			Image:    "require-labels",
+			Prefixes: []string{""},
+			ScriptExecutor: &configapi.ScriptExecutorConfig{
+				Tags:     []string{"v1.0.0", "v1.1.0"},
+				Language: configapi.ScriptLanguageCEL,
+				Source:   "resourceList.items.all(r, has(r.metadata.labels))",
+			},
+		},
+	}
+	require.NoError(t, store.UpdateScriptCache(obj.Name, obj))
+
+	// Found with full prefix
+	processor, found := store.GetScriptFromCache("ghcr.io/kptdev/krm-functions-catalog/require-labels:v1.0.0")
+	assert.True(t, found)
+	assert.NotNil(t, processor)
+
+	// Found by constraint
+	processor, found = store.GetScriptFromCacheByConstraint("require-labels", "~1.1")
+	assert.True(t, found)
+	assert.NotNil(t, processor)
+
+	// Not found for unknown tag or unmatched constraint
+	_, found = store.GetScriptFromCache("require-labels:v9.9.9")
+	assert.False(t, found)
+	_, found = store.GetScriptFromCacheByConstraint("require-labels", "^2")
+	assert.False(t, found)
+
+	// Not found for unknown prefix
+	_, found = store.GetScriptFromCache("example.com/require-labels:v1.0.0")
+	assert.False(t, found)
+
+	// Invalid scripts are rejected
+	invalid := obj.DeepCopy()
+	invalid.Spec.ScriptExecutor.Source = "resourceList.items.("
+	assert.Error(t, store.UpdateScriptCache(invalid.Name, invalid))
+
+	// Removed when the FunctionConfig no longer defines a script
+	removed := obj.DeepCopy()
+	removed.Spec.ScriptExecutor = nil
+	require.NoError(t, store.UpdateScriptCache(removed.Name, removed))
+	_, found = store.GetScriptFromCache("require-labels:v1.0.0")
+	assert.False(t, found)
+
+	// Removed from the old image when the image changes
+	require.NoError(t, store.UpdateScriptCache(obj.Name, obj))
+	renamed := obj.DeepCopy()
+	renamed.Spec.Image = "require-annotations"
+	require.NoError(t, store.UpdateScriptCache(renamed.Name, renamed))
+	_, found = store.GetScriptFromCache("require-labels:v1.0.0")
+	assert.False(t, found)
+	_, found = store.GetScriptFromCache("require-annotations:v1.0.0")
+	assert.True(t, found)
+
+	// Kept when another FunctionConfig of the same image has no script
+	other := obj.DeepCopy()
+	other.Name = "require-annotations-binary"
+	other.Spec.Image = "require-annotations"
+	other.Spec.ScriptExecutor = nil
+	require.NoError(t, store.UpdateScriptCache(other.Name, other))
+	store.DeleteFunctionConfig(types.NamespacedName{Namespace: testNamespace, Name: other.Name})
+	_, found = store.GetScriptFromCache("require-annotations:v1.0.0")
+	assert.True(t, found)
+
+	store.DeleteFunctionConfig(types.NamespacedName{Namespace: testNamespace, Name: renamed.Name})
+	_, found = store.GetScriptFromCache("require-annotations:v1.0.0")
+	assert.False(t, found)
+}
+
 func TestPrePopulationPattern(t *testing.T) {
 	// Simulates what setupFunctionConfigReconciler does on cold start:
 	// list all FunctionConfigs and populate the store synchronously
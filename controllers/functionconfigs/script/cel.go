@@ -0,0 +1,123 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package script
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/ext"
+	fnsdk "github.com/kptdev/krm-functions-sdk/go/fn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// celVariable is the name of the ResourceList in CEL expressions.
+const celVariable = "resourceList"
+
+// celProcessor evaluates a CEL expression over the ResourceList. The
+// expression returns either the new list of items, or a bool that validates
+// the ResourceList: if it is false, the function fails.
+type celProcessor struct {
+	name    string
+	program cel.Program
+	limits  limits
+}
+
+var _ fnsdk.ResourceListProcessor = &celProcessor{}
+
+func newCELProcessor(name, source string, limits limits) (*celProcessor, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(celVariable, cel.MapType(cel.StringType, cel.DynType)),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(source)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("invalid CEL script: %w", issues.Err())
+	}
+	program, err := env.Program(ast,
+		cel.CostLimit(limits.maxSteps),
+		cel.InterruptCheckFrequency(100),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CEL script: %w", err)
+	}
+	return &celProcessor{
+		name:    name,
+		program: program,
+		limits:  limits,
+	}, nil
+}
+
+func (p *celProcessor) Process(rl *fnsdk.ResourceList) (bool, error) {
+	in, err := toJSON(rl, p.limits)
+	if err != nil {
+		return false, err
+	}
+	var resourceList map[string]any
+	if err := json.Unmarshal(in, &resourceList); err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.limits.timeout)
+	defer cancel()
+	out, _, err := p.program.ContextEval(ctx, map[string]any{celVariable: resourceList})
+	if err != nil {
+		return false, fmt.Errorf("CEL script %q failed: %w", p.name, err)
+	}
+
+	switch out := out.(type) {
+	case types.Bool:
+		if !out {
+			rl.Results = append(rl.Results, fnsdk.GeneralResult(
+				fmt.Sprintf("the resources do not satisfy CEL script %q", p.name), fnsdk.Error))
+			return false, nil
+		}
+		return true, nil
+	case traits.Lister:
+		items, err := celToJSON(out)
+		if err != nil {
+			return false, fmt.Errorf("CEL script %q returned invalid items: %w", p.name, err)
+		}
+		resourceList["items"] = json.RawMessage(items)
+		data, err := json.Marshal(resourceList)
+		if err != nil {
+			return false, err
+		}
+		if err := fromJSON(rl, data, p.limits); err != nil {
+			return false, fmt.Errorf("CEL script %q returned invalid items: %w", p.name, err)
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("CEL script %q must return a list of items or a bool, not %s", p.name, out.Type().TypeName())
+	}
+}
+
+func celToJSON(val ref.Val) ([]byte, error) {
+	native, err := val.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(native.(*structpb.Value))
+}
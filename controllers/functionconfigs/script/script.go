@@ -0,0 +1,118 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package script runs KRM functions that are defined inline in a
+// FunctionConfig as a Starlark or CEL script, instead of as a container image.
+package script
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	fnsdk "github.com/kptdev/krm-functions-sdk/go/fn"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultMaxSteps is the default limit of Starlark execution steps or of
+	// the CEL evaluation cost of one run of a script.
+	DefaultMaxSteps = 1000000
+	// DefaultTimeout is the default limit of the duration of one run of a script.
+	DefaultTimeout = 10 * time.Second
+	// MaxBytes limits the size of the ResourceList that a script gets and
+	// returns, and of each string or list that a Starlark script builds.
+	MaxBytes = 32 << 20
+)
+
+// NewProcessor compiles the script of a FunctionConfig into a KRM function.
+// The name identifies the function in errors and logs.
+func NewProcessor(name string, config *configapi.ScriptExecutorConfig) (fnsdk.ResourceListProcessor, error) {
+	limits := limits{
+		maxSteps: DefaultMaxSteps,
+		timeout:  DefaultTimeout,
+		maxBytes: MaxBytes,
+	}
+	if config.MaxSteps > 0 {
+		limits.maxSteps = uint64(config.MaxSteps)
+	}
+	if config.Timeout != nil && config.Timeout.Duration > 0 {
+		limits.timeout = config.Timeout.Duration
+	}
+
+	switch config.Language {
+	case configapi.ScriptLanguageStarlark:
+		return newStarlarkProcessor(name, config.Source, limits)
+	case configapi.ScriptLanguageCEL:
+		return newCELProcessor(name, config.Source, limits)
+	default:
+		return nil, fmt.Errorf("unsupported script language %q", config.Language)
+	}
+}
+
+// limits sandbox one run of a script.
+type limits struct {
+	maxSteps uint64
+	timeout  time.Duration
+	maxBytes int
+}
+
+// checkSize returns an error if what is larger than the size limit.
+func (l limits) checkSize(what string, size int) error {
+	if size > l.maxBytes {
+		return fmt.Errorf("%s of %d bytes exceeds the limit of %d bytes", what, size, l.maxBytes)
+	}
+	return nil
+}
+
+// toJSON returns the ResourceList as JSON. The items are always present, so
+// that scripts do not need to handle a missing list.
+func toJSON(rl *fnsdk.ResourceList, limits limits) ([]byte, error) {
+	data, err := rl.ToYAML()
+	if err != nil {
+		return nil, err
+	}
+	var resourceList map[string]any
+	if err := yaml.Unmarshal(data, &resourceList); err != nil {
+		return nil, err
+	}
+	if _, found := resourceList["items"]; !found {
+		resourceList["items"] = []any{}
+	}
+	data, err = json.Marshal(resourceList)
+	if err != nil {
+		return nil, err
+	}
+	return data, limits.checkSize("resource list", len(data))
+}
+
+// fromJSON replaces the items and results of the ResourceList with the ones
+// of the ResourceList in JSON that a script returned.
+func fromJSON(rl *fnsdk.ResourceList, data []byte, limits limits) error {
+	if err := limits.checkSize("resource list", len(data)); err != nil {
+		return err
+	}
+	data, err := yaml.JSONToYAML(data)
+	if err != nil {
+		return err
+	}
+	out, err := fnsdk.ParseResourceList(data)
+	if err != nil {
+		return err
+	}
+	rl.Items = out.Items
+	rl.Results = out.Results
+	return nil
+}
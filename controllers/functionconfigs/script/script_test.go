@@ -0,0 +1,245 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package script
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	fnsdk "github.com/kptdev/krm-functions-sdk/go/fn"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const resourceList = `apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: first
+  data:
+    key: value
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: second
+`
+
+func process(t *testing.T, config *configapi.ScriptExecutorConfig) (*fnsdk.ResourceList, bool, error) {
+	t.Helper()
+	processor, err := NewProcessor("test", config)
+	require.NoError(t, err)
+	rl, err := fnsdk.ParseResourceList([]byte(resourceList))
+	require.NoError(t, err)
+	ok, err := processor.Process(rl)
+	return rl, ok, err
+}
+
+func TestStarlarkMutatesResources(t *testing.T) {
+	rl, ok, err := process(t, &configapi.ScriptExecutorConfig{
+		Language: configapi.ScriptLanguageStarlark,
+		Source: `
+for r in ctx.resource_list["items"]:
+  r["metadata"]["namespace"] = "example"
+`,
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.Len(t, rl.Items, 2)
+	for _, item := range rl.Items {
+		assert.Equal(t, "example", item.GetNamespace())
+	}
+	value, _, err := rl.Items[0].NestedString("data", "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
+
+func TestStarlarkStepLimit(t *testing.T) {
+	_, _, err := process(t, &configapi.ScriptExecutorConfig{
+		Language: configapi.ScriptLanguageStarlark,
+		MaxSteps: 1000,
+		Source: `
+while True:
+  pass
+`,
+	})
+	assert.ErrorContains(t, err, "too many steps")
+}
+
+func TestStarlarkTimeout(t *testing.T) {
+	_, _, err := process(t, &configapi.ScriptExecutorConfig{
+		Language: configapi.ScriptLanguageStarlark,
+		MaxSteps: 1 << 40,
+		Timeout:  &metav1.Duration{Duration: 100 * time.Millisecond},
+		Source: `
+while True:
+  pass
+`,
+	})
+	assert.ErrorContains(t, err, "timed out")
+}
+
+func TestStarlarkSizeLimit(t *testing.T) {
+	for name, source := range map[string]string{
+		"string repeat": `s = "a" * (64 << 20)`,
+		"list repeat":   `l = [0] * (64 << 20)`,
+		"repeat first":  `s = (64 << 20) * "a"`,
+		"doubling": `
+s = "a"
+for i in range(40):
+  s += s
+`,
+		"concatenation": `
+s = "a" * (1 << 20)
+for i in range(40):
+  s = s + s
+`,
+		"output": `
+ctx.resource_list["items"][0]["data"]["key"] = "a" * (20 << 20) + "a"
+ctx.resource_list["items"][1]["metadata"]["labels"] = {"key": "a" * (20 << 20)}
+`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := process(t, &configapi.ScriptExecutorConfig{
+				Language: configapi.ScriptLanguageStarlark,
+				Source:   source,
+			})
+			assert.ErrorContains(t, err, "exceeds the limit")
+		})
+	}
+}
+
+func TestStarlarkSizeCheckedOperators(t *testing.T) {
+	rl, _, err := process(t, &configapi.ScriptExecutorConfig{
+		Language: configapi.ScriptLanguageStarlark,
+		Source: `
+items = ctx.resource_list["items"]
+alias = items
+items += [{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "third"}}]
+data = items[0]["data"]
+data["key"] += "-" + "x" * 3
+data["count"] = str(2 * 3 + 1)
+names = [r["metadata"]["name"] for r in alias]
+data["names"] = ",".join(names * 1)
+`,
+	})
+	require.NoError(t, err)
+	require.Len(t, rl.Items, 3)
+	data, _, err := rl.Items[0].NestedStringMap("data")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"key":   "value-xxx",
+		"count": "7",
+		"names": "first,second,third",
+	}, data)
+}
+
+func TestInputSizeLimit(t *testing.T) {
+	processor, err := NewProcessor("test", &configapi.ScriptExecutorConfig{
+		Language: configapi.ScriptLanguageStarlark,
+		Source:   `pass`,
+	})
+	require.NoError(t, err)
+	rl, err := fnsdk.ParseResourceList([]byte(resourceList))
+	require.NoError(t, err)
+	require.NoError(t, rl.Items[0].SetNestedString(strings.Repeat("a", MaxBytes), "data", "key"))
+	_, err = processor.Process(rl)
+	assert.ErrorContains(t, err, "exceeds the limit")
+}
+
+func TestCELFiltersResources(t *testing.T) {
+	rl, ok, err := process(t, &configapi.ScriptExecutorConfig{
+		Language: configapi.ScriptLanguageCEL,
+		Source:   `resourceList.items.filter(r, r.kind == "ConfigMap")`,
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.Len(t, rl.Items, 1)
+	assert.Equal(t, "first", rl.Items[0].GetName())
+}
+
+func TestCELValidatesResources(t *testing.T) {
+	testCases := map[string]struct {
+		source string
+		ok     bool
+	}{
+		"satisfied": {
+			source: `resourceList.items.all(r, has(r.metadata.name))`,
+			ok:     true,
+		},
+		"not satisfied": {
+			source: `resourceList.items.all(r, r.kind == "ConfigMap")`,
+			ok:     false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rl, ok, err := process(t, &configapi.ScriptExecutorConfig{
+				Language: configapi.ScriptLanguageCEL,
+				Source:   tc.source,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.ok, ok)
+			assert.Len(t, rl.Items, 2)
+			if tc.ok {
+				assert.Empty(t, rl.Results)
+			} else {
+				require.Len(t, rl.Results, 1)
+				assert.Equal(t, fnsdk.Error, rl.Results[0].Severity)
+			}
+		})
+	}
+}
+
+func TestCELCostLimit(t *testing.T) {
+	_, _, err := process(t, &configapi.ScriptExecutorConfig{
+		Language: configapi.ScriptLanguageCEL,
+		MaxSteps: 1,
+		Source:   `resourceList.items.all(r, r.kind.startsWith("C"))`,
+	})
+	assert.ErrorContains(t, err, "cost limit")
+}
+
+func TestNewProcessorErrors(t *testing.T) {
+	testCases := map[string]struct {
+		config configapi.ScriptExecutorConfig
+		err    string
+	}{
+		"invalid starlark": {
+			config: configapi.ScriptExecutorConfig{Language: configapi.ScriptLanguageStarlark, Source: "def ("},
+			err:    "invalid starlark script",
+		},
+		"invalid CEL": {
+			config: configapi.ScriptExecutorConfig{Language: configapi.ScriptLanguageCEL, Source: "resourceList.items.("},
+			err:    "invalid CEL script",
+		},
+		"unsupported language": {
+			config: configapi.ScriptExecutorConfig{Language: "lua", Source: "return"},
+			err:    `unsupported script language "lua"`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewProcessor("test", &tc.config)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
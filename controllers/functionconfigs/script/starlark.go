@@ -0,0 +1,339 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package script
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	fnsdk "github.com/kptdev/krm-functions-sdk/go/fn"
+	"go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+	"k8s.io/klog/v2"
+)
+
+// starlarkProcessor runs a Starlark script. Like the starlark function of the
+// KRM functions catalog, the script modifies ctx.resource_list in place.
+// It cannot load modules or access the file system or the network.
+//
+// Execution steps do not account for memory: a single step such as "x" * n
+// or s + s can allocate any amount of it. So the script is compiled with its
+// + and * operators replaced by calls of builtins that first check the size
+// of the string or list they would build.
+type starlarkProcessor struct {
+	name    string
+	program *starlark.Program
+	limits  limits
+}
+
+var _ fnsdk.ResourceListProcessor = &starlarkProcessor{}
+
+var starlarkFileOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+	Recursion:       true,
+}
+
+func newStarlarkProcessor(name, source string, limits limits) (*starlarkProcessor, error) {
+	file, err := starlarkFileOptions.Parse(name, source, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid starlark script: %w", err)
+	}
+	syntax.Walk(file, checkSizesOf)
+	program, err := starlark.FileProgram(file, func(name string) bool {
+		_, sizeChecked := sizeCheckedOps[name]
+		return name == "ctx" || name == "json" || sizeChecked
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid starlark script: %w", err)
+	}
+	return &starlarkProcessor{
+		name:    name,
+		program: program,
+		limits:  limits,
+	}, nil
+}
+
+func (p *starlarkProcessor) Process(rl *fnsdk.ResourceList) (bool, error) {
+	in, err := toJSON(rl, p.limits)
+	if err != nil {
+		return false, err
+	}
+	// The conversions run on their own thread, so that the limits apply to
+	// the script only.
+	convert := &starlark.Thread{Name: p.name}
+	resourceList, err := starlark.Call(convert, json.Module.Members["decode"], starlark.Tuple{starlark.String(in)}, nil)
+	if err != nil {
+		return false, err
+	}
+	predeclared := starlark.StringDict{
+		"ctx": starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+			"resource_list": resourceList,
+		}),
+		"json": json.Module,
+	}
+	for name, op := range sizeCheckedOps {
+		predeclared[name] = op.builtin(name, p.limits)
+	}
+
+	thread := &starlark.Thread{
+		Name: p.name,
+		Print: func(_ *starlark.Thread, msg string) {
+			klog.Infof("%s: %s", p.name, msg)
+		},
+	}
+	thread.SetMaxExecutionSteps(p.limits.maxSteps)
+	timer := time.AfterFunc(p.limits.timeout, func() {
+		thread.Cancel(fmt.Sprintf("timed out after %s", p.limits.timeout))
+	})
+	_, err = p.program.Init(thread, predeclared)
+	timer.Stop()
+	if err != nil {
+		return false, fmt.Errorf("starlark script %q failed: %w", p.name, err)
+	}
+
+	out, err := starlark.Call(convert, json.Module.Members["encode"], starlark.Tuple{resourceList}, nil)
+	if err != nil {
+		return false, fmt.Errorf("starlark script %q produced an invalid resource list: %w", p.name, err)
+	}
+	if err := fromJSON(rl, []byte(out.(starlark.String)), p.limits); err != nil {
+		return false, fmt.Errorf("starlark script %q produced an invalid resource list: %w", p.name, err)
+	}
+	return true, nil
+}
+
+// sizeCheckedOp is an operator of a script that is replaced by a builtin that
+// checks the size of its result. The builtin either applies the operator, or,
+// for augmented assignments such as x += y, returns its right operand so that
+// the assignment applies the operator itself.
+type sizeCheckedOp struct {
+	op      syntax.Token
+	operand bool
+}
+
+var sizeCheckedOps = map[string]sizeCheckedOp{
+	"_porch_add":         {op: syntax.PLUS},
+	"_porch_mul":         {op: syntax.STAR},
+	"_porch_add_operand": {op: syntax.PLUS, operand: true},
+	"_porch_mul_operand": {op: syntax.STAR, operand: true},
+}
+
+func (o sizeCheckedOp) builtin(name string, limits limits) *starlark.Builtin {
+	return starlark.NewBuiltin(name, func(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, _ []starlark.Tuple) (starlark.Value, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("%s: got %d arguments, want 2", name, len(args))
+		}
+		x, y := args[0], args[1]
+		if size, sized := resultSize(o.op, x, y); sized {
+			if err := limits.checkSize(fmt.Sprintf("result of %s %s %s", x.Type(), o.op, y.Type()), size); err != nil {
+				return nil, err
+			}
+		}
+		if o.operand {
+			return y, nil
+		}
+		return starlark.Binary(o.op, x, y)
+	})
+}
+
+// resultSize returns the size in bytes of the string or list that applying op
+// to x and y builds, if it builds one.
+func resultSize(op syntax.Token, x, y starlark.Value) (int, bool) {
+	switch op {
+	case syntax.PLUS:
+		xSize, xSized := valueSize(x)
+		ySize, ySized := valueSize(y)
+		return xSize + ySize, xSized && ySized
+	case syntax.STAR:
+		size, sized := valueSize(x)
+		n, isInt := y.(starlark.Int)
+		if !sized {
+			size, sized = valueSize(y)
+			n, isInt = x.(starlark.Int)
+		}
+		if !sized || !isInt {
+			return 0, false
+		}
+		times, ok := n.Int64()
+		if !ok || times > math.MaxInt32 {
+			return math.MaxInt, true
+		}
+		if times <= 0 {
+			return 0, true
+		}
+		if size > 0 && int(times) > math.MaxInt/size {
+			return math.MaxInt, true
+		}
+		return size * int(times), true
+	}
+	return 0, false
+}
+
+// listElementSize is the size in bytes of an element of a list or tuple.
+const listElementSize = 16
+
+// valueSize returns the size in bytes of a string or of the elements of a list.
+func valueSize(v starlark.Value) (int, bool) {
+	switch v := v.(type) {
+	case starlark.String:
+		return len(v), true
+	case starlark.Bytes:
+		return len(v), true
+	case *starlark.List:
+		return v.Len() * listElementSize, true
+	case starlark.Tuple:
+		return v.Len() * listElementSize, true
+	}
+	return 0, false
+}
+
+// checkSizesOf replaces the + and * operators in the children of a node of a
+// script by calls of the size checked builtins.
+func checkSizesOf(n syntax.Node) bool {
+	switch n := n.(type) {
+	case *syntax.ExprStmt:
+		checkSizeOf(&n.X)
+	case *syntax.IfStmt:
+		checkSizeOf(&n.Cond)
+	case *syntax.AssignStmt:
+		checkSizeOf(&n.RHS)
+		// x += y becomes x += _porch_add_operand(x, y). An index in x is
+		// evaluated twice.
+		var name string
+		switch n.Op {
+		case syntax.PLUS_EQ:
+			name = "_porch_add_operand"
+		case syntax.STAR_EQ:
+			name = "_porch_mul_operand"
+		}
+		if name != "" {
+			n.RHS = sizeCheckedCall(name, n.OpPos, copyOperand(n.LHS), n.RHS)
+		}
+	case *syntax.DefStmt:
+		for _, param := range n.Params {
+			if binary, ok := param.(*syntax.BinaryExpr); ok && binary.Op == syntax.EQ {
+				checkSizeOf(&binary.Y)
+			}
+		}
+	case *syntax.ForStmt:
+		checkSizeOf(&n.X)
+	case *syntax.WhileStmt:
+		checkSizeOf(&n.Cond)
+	case *syntax.ReturnStmt:
+		if n.Result != nil {
+			checkSizeOf(&n.Result)
+		}
+	case *syntax.ListExpr:
+		for i := range n.List {
+			checkSizeOf(&n.List[i])
+		}
+	case *syntax.TupleExpr:
+		for i := range n.List {
+			checkSizeOf(&n.List[i])
+		}
+	case *syntax.ParenExpr:
+		checkSizeOf(&n.X)
+	case *syntax.CondExpr:
+		checkSizeOf(&n.Cond)
+		checkSizeOf(&n.True)
+		checkSizeOf(&n.False)
+	case *syntax.IndexExpr:
+		checkSizeOf(&n.X)
+		checkSizeOf(&n.Y)
+	case *syntax.DictEntry:
+		checkSizeOf(&n.Key)
+		checkSizeOf(&n.Value)
+	case *syntax.SliceExpr:
+		checkSizeOf(&n.X)
+		checkSizeOf(&n.Lo)
+		checkSizeOf(&n.Hi)
+		checkSizeOf(&n.Step)
+	case *syntax.Comprehension:
+		checkSizeOf(&n.Body)
+	case *syntax.IfClause:
+		checkSizeOf(&n.Cond)
+	case *syntax.ForClause:
+		checkSizeOf(&n.X)
+	case *syntax.UnaryExpr:
+		checkSizeOf(&n.X)
+	case *syntax.BinaryExpr:
+		checkSizeOf(&n.X)
+		checkSizeOf(&n.Y)
+	case *syntax.DotExpr:
+		checkSizeOf(&n.X)
+	case *syntax.CallExpr:
+		checkSizeOf(&n.Fn)
+		for i := range n.Args {
+			// Keyword arguments are name=value binary expressions
+			if binary, ok := n.Args[i].(*syntax.BinaryExpr); ok && binary.Op == syntax.EQ {
+				checkSizeOf(&binary.Y)
+			} else {
+				checkSizeOf(&n.Args[i])
+			}
+		}
+	case *syntax.LambdaExpr:
+		checkSizeOf(&n.Body)
+	}
+	return true
+}
+
+// checkSizeOf replaces the expression by a call of a size checked builtin if
+// it is a + or * operation.
+func checkSizeOf(expr *syntax.Expr) {
+	binary, ok := (*expr).(*syntax.BinaryExpr)
+	if !ok {
+		return
+	}
+	switch binary.Op {
+	case syntax.PLUS:
+		*expr = sizeCheckedCall("_porch_add", binary.OpPos, binary.X, binary.Y)
+	case syntax.STAR:
+		*expr = sizeCheckedCall("_porch_mul", binary.OpPos, binary.X, binary.Y)
+	}
+}
+
+func sizeCheckedCall(name string, pos syntax.Position, x, y syntax.Expr) *syntax.CallExpr {
+	return &syntax.CallExpr{
+		Fn:     &syntax.Ident{NamePos: pos, Name: name},
+		Lparen: pos,
+		Args:   []syntax.Expr{x, y},
+		Rparen: pos,
+	}
+}
+
+// copyOperand copies the target of an augmented assignment, so that it can
+// also be read as an operand.
+func copyOperand(target syntax.Expr) syntax.Expr {
+	switch target := target.(type) {
+	case *syntax.Ident:
+		return &syntax.Ident{NamePos: target.NamePos, Name: target.Name}
+	case *syntax.IndexExpr:
+		copied := *target
+		return &copied
+	case *syntax.DotExpr:
+		copied := *target
+		return &copied
+	case *syntax.ParenExpr:
+		copied := *target
+		copied.X = copyOperand(target.X)
+		return &copied
+	}
+	return target
+}
@@ -45,6 +45,7 @@ import (
 	"github.com/kptdev/porch/controllers/packagevariants/pkg/controllers/packagevariant"
 	"github.com/kptdev/porch/controllers/packagevariantsets/pkg/controllers/packagevariantset"
 	"github.com/kptdev/porch/controllers/repositories/pkg/controllers/repository"
+	"github.com/kptdev/porch/controllers/upgradepolicies/pkg/controllers/upgradepolicy"
 	"github.com/kptdev/porch/pkg/cache/contentcache"
 	"github.com/kptdev/porch/pkg/controllerrestmapper"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -76,6 +77,7 @@ var (
 		prReconciler,
 		&packagevariant.PackageVariantReconciler{},
 		&packagevariantset.PackageVariantSetReconciler{},
+		&upgradepolicy.UpgradePolicyReconciler{},
 	)
 )
 
@@ -349,6 +351,9 @@ func prePopulateFunctionConfigStore(reader client.Reader, store *reconciler.Func
 		if obj.Spec.BinaryExecutor != nil {
 			store.UpdateBinaryCache(obj.Name, obj)
 		}
+		if err := store.UpdateScriptCache(obj.Name, obj); err != nil {
+			klog.Warningf("Failed to compile script of FunctionConfig %q: %v", obj.Name, err)
+		}
 	}
 	klog.Infof("FunctionConfig store pre-populated with %d configs", len(fcList.Items))
 }
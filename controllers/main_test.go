@@ -130,7 +130,7 @@ func TestInitScheme(t *testing.T) {
 // --- reconcilers map ---
 
 func TestReconcilersMapContainsAllReconcilers(t *testing.T) {
-	expected := []string{"repositories", "packagerevisions", "packagevariants", "packagevariantsets"}
+	expected := []string{"repositories", "packagerevisions", "packagevariants", "packagevariantsets", "upgradepolicies"}
 	for _, name := range expected {
 		_, ok := reconcilers[name]
 		assert.True(t, ok, "reconcilers map should contain %q", name)
@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
 	"github.com/kptdev/porch/controllers/packagerevisions/pkg/controllers/packagerevision"
 	mockrepository "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/repository"
 )
@@ -45,7 +46,10 @@ var _ = BeforeSuite(func() {
 	By("bootstrapping test environment")
 	testEnv = &envtest.Environment{
 		CRDInstallOptions: envtest.CRDInstallOptions{
-			Paths: []string{filepath.Join("..", "..", "..", "api", "porch", "v1alpha2", "porch.kpt.dev_packagerevisions.yaml")},
+			Paths: []string{
+				filepath.Join("..", "..", "..", "api", "porch", "v1alpha2", "porch.kpt.dev_packagerevisions.yaml"),
+				filepath.Join("..", "..", "..", "api", "generated", "crds", "config.porch.kpt.dev_packagerevisiondeploymentstatuses.yaml"),
+			},
 		},
 	}
 
@@ -57,6 +61,8 @@ var _ = BeforeSuite(func() {
 	scheme := runtime.NewScheme()
 	err = porchv1alpha2.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = configapi.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
 
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme})
 	Expect(err).NotTo(HaveOccurred())
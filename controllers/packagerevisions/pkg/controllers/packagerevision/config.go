@@ -74,6 +74,7 @@ func (r *PackageRevisionReconciler) Init(mgr ctrl.Manager) error {
 	r.ExternalPackageFetcher = contentcache.NewExternalPackageFetcher(
 		credResolver, caBundleResolver, r.RepoOperationRetryAttempts,
 	)
+	r.SourcePolicyChecker = porch.NewSourcePolicyChecker(coreClient)
 
 	fnRunnerAddr := os.Getenv("FUNCTION_RUNNER_ADDRESS")
 	functionRuntime, err := engine.NewMultiFunctionRuntime(fnRunnerAddr, r.MaxGRPCMessageSize, r.FunctionConfigStore)
@@ -0,0 +1,127 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packagerevision
+
+import (
+	"context"
+	"fmt"
+
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/util/deploymentstatus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+//+kubebuilder:rbac:groups=config.porch.kpt.dev,resources=packagerevisiondeploymentstatuses,verbs=get;list;watch
+
+// DeploymentStatusReconciler aggregates the PackageRevisionDeploymentStatus objects
+// reported by GitOps agents on status.deploymentStatus of v1alpha2 package revisions
+// in deployment repositories. It runs as a separate controller so that agent reports
+// never trigger the lifecycle and render reconciliation.
+type DeploymentStatusReconciler struct {
+	client.Client
+}
+
+// Reconcile recomputes status.deploymentStatus of a package revision from its reports.
+func (r *DeploymentStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var pr porchv1alpha2.PackageRevision
+	if err := r.Get(ctx, req.NamespacedName, &pr); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !pr.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	status, err := r.deploymentStatus(ctx, &pr)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if equality.Semantic.DeepEqual(status, pr.Status.DeploymentStatus) {
+		return ctrl.Result{}, nil
+	}
+	log.FromContext(ctx).V(1).Info("Updating deployment status", "name", pr.Name)
+
+	applyObj := &porchv1alpha2.PackageRevision{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageRevision",
+			APIVersion: porchv1alpha2.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pr.Name,
+			Namespace: pr.Namespace,
+		},
+		Status: porchv1alpha2.PackageRevisionStatus{
+			DeploymentStatus: status,
+		},
+	}
+	return ctrl.Result{}, r.Status().Patch(ctx, applyObj, client.Apply, client.FieldOwner(fieldManagerPRControllerDeployment), client.ForceOwnership)
+}
+
+// deploymentStatus returns the deployment status of the package revision, or nil if it
+// is not in a deployment repository or has no reports.
+func (r *DeploymentStatusReconciler) deploymentStatus(ctx context.Context, pr *porchv1alpha2.PackageRevision) (*porchv1alpha2.DeploymentStatus, error) {
+	var repo configapi.Repository
+	if err := r.Get(ctx, types.NamespacedName{Namespace: pr.Namespace, Name: pr.Spec.RepositoryName}, &repo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !repo.Spec.Deployment {
+		return nil, nil
+	}
+
+	var reports configapi.PackageRevisionDeploymentStatusList
+	if err := r.List(ctx, &reports, client.InNamespace(pr.Namespace),
+		client.MatchingFields{deploymentstatus.PackageRevisionField: pr.Name}); err != nil {
+		return nil, err
+	}
+	return deploymentstatus.ForV1Alpha2PackageRevision(reports.Items), nil
+}
+
+// SetupWithManager registers the controller. It is triggered by the creation and spec
+// changes of package revisions and by any change to a PackageRevisionDeploymentStatus.
+func (r *DeploymentStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &configapi.PackageRevisionDeploymentStatus{}, deploymentstatus.PackageRevisionField,
+		func(obj client.Object) []string {
+			return []string{obj.(*configapi.PackageRevisionDeploymentStatus).Spec.PackageRevision}
+		}); err != nil {
+		return fmt.Errorf("failed to index field %s: %w", deploymentstatus.PackageRevisionField, err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&porchv1alpha2.PackageRevision{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&configapi.PackageRevisionDeploymentStatus{}, handler.EnqueueRequestsFromMapFunc(mapDeploymentStatusToPackageRevision)).
+		Named("packagerevision-deployment").
+		Complete(r)
+}
+
+func mapDeploymentStatusToPackageRevision(_ context.Context, obj client.Object) []reconcile.Request {
+	report, ok := obj.(*configapi.PackageRevisionDeploymentStatus)
+	if !ok || report.Spec.PackageRevision == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: report.Namespace, Name: report.Spec.PackageRevision}}}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packagerevision
+
+import (
+	"context"
+	"testing"
+
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	mockclient "github.com/kptdev/porch/test/mockery/mocks/external/sigs.k8s.io/controller-runtime/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func deploymentPR() *porchv1alpha2.PackageRevision {
+	pr := basePR()
+	pr.Name = "deploy.app.v1"
+	pr.Spec.RepositoryName = "deploy"
+	return pr
+}
+
+// expectDeploymentGets sets up the Get calls for the package revision and its repository.
+func expectDeploymentGets(mockClient *mockclient.MockClient, pr *porchv1alpha2.PackageRevision, deployment bool) {
+	mockClient.EXPECT().Get(mock.Anything, types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name}, mock.AnythingOfType("*v1alpha2.PackageRevision")).
+		RunAndReturn(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			pr.DeepCopyInto(obj.(*porchv1alpha2.PackageRevision))
+			return nil
+		})
+	mockClient.EXPECT().Get(mock.Anything, types.NamespacedName{Namespace: pr.Namespace, Name: pr.Spec.RepositoryName}, mock.AnythingOfType("*v1alpha1.Repository")).
+		RunAndReturn(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			obj.(*configapi.Repository).Spec.Deployment = deployment
+			return nil
+		})
+}
+
+func TestDeploymentStatusReconcile(t *testing.T) {
+	mockClient := mockclient.NewMockClient(t)
+	pr := deploymentPR()
+	expectDeploymentGets(mockClient, pr, true)
+	mockClient.EXPECT().List(mock.Anything, mock.AnythingOfType("*v1alpha1.PackageRevisionDeploymentStatusList"), mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			list.(*configapi.PackageRevisionDeploymentStatusList).Items = []configapi.PackageRevisionDeploymentStatus{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: pr.Namespace, Name: "edge-1"},
+				Spec: configapi.PackageRevisionDeploymentStatusSpec{
+					PackageRevision: pr.Name,
+					Target:          "edge-1",
+					State:           configapi.DeploymentStateApplied,
+					SyncedCommit:    "abc123",
+				},
+			}}
+			return nil
+		})
+	captured := captureStatusPatch(t, mockClient)
+
+	r := &DeploymentStatusReconciler{Client: mockClient}
+	_, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name}})
+	require.NoError(t, err)
+
+	require.NotNil(t, captured.DeploymentStatus)
+	assert.Equal(t, "Applied", captured.DeploymentStatus.State)
+	assert.Equal(t, []porchv1alpha2.DeploymentTargetStatus{{Target: "edge-1", State: "Applied", SyncedCommit: "abc123"}}, captured.DeploymentStatus.Targets)
+	assert.Empty(t, captured.Conditions)
+}
+
+func TestDeploymentStatusReconcileNotDeployment(t *testing.T) {
+	mockClient := mockclient.NewMockClient(t)
+	pr := deploymentPR()
+	// Nothing to report and nothing reported before, so no patch is expected.
+	expectDeploymentGets(mockClient, pr, false)
+
+	r := &DeploymentStatusReconciler{Client: mockClient}
+	_, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name}})
+	require.NoError(t, err)
+}
+
+func TestDeploymentStatusReconcileRepositoryGone(t *testing.T) {
+	mockClient := mockclient.NewMockClient(t)
+	pr := deploymentPR()
+	pr.Status.DeploymentStatus = &porchv1alpha2.DeploymentStatus{State: "Applied"}
+	mockClient.EXPECT().Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha2.PackageRevision")).
+		RunAndReturn(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			pr.DeepCopyInto(obj.(*porchv1alpha2.PackageRevision))
+			return nil
+		})
+	mockClient.EXPECT().Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Repository")).
+		Return(apierrors.NewNotFound(schema.GroupResource{Resource: "repositories"}, "deploy"))
+	captured := captureStatusPatch(t, mockClient)
+
+	r := &DeploymentStatusReconciler{Client: mockClient}
+	_, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name}})
+	require.NoError(t, err)
+	assert.Nil(t, captured.DeploymentStatus)
+}
+
+func TestMapDeploymentStatusToPackageRevision(t *testing.T) {
+	report := &configapi.PackageRevisionDeploymentStatus{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "edge-1"},
+		Spec:       configapi.PackageRevisionDeploymentStatusSpec{PackageRevision: "deploy.app.v1"},
+	}
+	assert.Equal(t, []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "deploy.app.v1"}}},
+		mapDeploymentStatusToPackageRevision(t.Context(), report))
+
+	report.Spec.PackageRevision = ""
+	assert.Empty(t, mapDeploymentStatusToPackageRevision(t.Context(), report))
+}
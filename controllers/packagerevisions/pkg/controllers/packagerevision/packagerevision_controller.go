@@ -17,11 +17,14 @@ package packagerevision
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	kptfilev1 "github.com/kptdev/kpt/api/kptfile/v1"
 	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
 	"github.com/kptdev/porch/controllers/functionconfigs/reconciler"
 	"github.com/kptdev/porch/pkg/repository"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -37,6 +40,7 @@ import (
 //+kubebuilder:rbac:groups=porch.kpt.dev,resources=packagerevisions/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=porch.kpt.dev,resources=packagerevisions/finalizers,verbs=update
 //+kubebuilder:rbac:groups=config.porch.kpt.dev,resources=repositories,verbs=get
+//+kubebuilder:rbac:groups=config.porch.kpt.dev,resources=sourcepolicies,verbs=get;list;watch
 //+kubebuilder:rbac:groups=config.porch.kpt.dev,resources=functionconfigs,verbs=get;list;watch;patch
 //+kubebuilder:rbac:groups=config.porch.kpt.dev,resources=functionconfigs/status,verbs=get;update;patch
 
@@ -50,6 +54,7 @@ type PackageRevisionReconciler struct {
 	Scheme                 *runtime.Scheme
 	ContentCache           repository.ContentCache
 	ExternalPackageFetcher repository.ExternalPackageFetcher
+	SourcePolicyChecker    repository.SourcePolicyChecker
 	FunctionConfigStore    *reconciler.FunctionConfigStore
 	Renderer               renderer // nil = skip rendering
 
@@ -142,6 +147,36 @@ func (r *PackageRevisionReconciler) reconcileLifecycle(ctx context.Context, pr *
 		return ctrl.Result{}, nil
 	}
 
+	if current == string(porchv1alpha2.PackageRevisionLifecycleProposed) && porchv1alpha2.LifecycleIsPublished(porchv1alpha2.PackageRevisionLifecycle(desired)) {
+		unmet, err := r.unmetReadinessGates(ctx, pr, content)
+		if err != nil {
+			log.Error(err, "failed to check readiness gates")
+			r.updateStatus(ctx, pr, nil, "", readyCondition(pr.Generation, metav1.ConditionFalse, porchv1alpha2.ReasonFailed, err.Error()))
+			return ctrl.Result{}, nil
+		}
+		if len(unmet) > 0 {
+			// Setting a condition through the conditions subresource triggers a new reconcile.
+			r.updateStatus(ctx, pr, content, "", readyCondition(pr.Generation, metav1.ConditionFalse, porchv1alpha2.ReasonReadinessGatesNotMet,
+				"readiness conditions not met: "+strings.Join(unmet, ", ")))
+			return ctrl.Result{}, nil
+		}
+	}
+
+	if current == string(porchv1alpha2.PackageRevisionLifecycleDraft) && desired == string(porchv1alpha2.PackageRevisionLifecycleProposed) {
+		conflicts, err := unresolvedMergeConflicts(ctx, content)
+		if err != nil {
+			log.Error(err, "failed to check merge conflicts")
+			r.updateStatus(ctx, pr, nil, "", readyCondition(pr.Generation, metav1.ConditionFalse, porchv1alpha2.ReasonFailed, err.Error()))
+			return ctrl.Result{}, nil
+		}
+		if conflicts != "" {
+			// Resolving the conflicts updates the package resources, which triggers a new reconcile.
+			r.updateStatus(ctx, pr, content, "", readyCondition(pr.Generation, metav1.ConditionFalse, porchv1alpha2.ReasonMergeConflicts,
+				"cannot propose package; "+conflicts))
+			return ctrl.Result{}, nil
+		}
+	}
+
 	log.Info("lifecycle transition", "name", pr.Name, "current", current, "desired", desired)
 
 	updated, err := r.ContentCache.UpdateLifecycle(ctx, repoKey, pr.Spec.PackageName, pr.Spec.WorkspaceName, desired)
@@ -162,6 +197,44 @@ func (r *PackageRevisionReconciler) reconcileLifecycle(ctx context.Context, pr *
 	return ctrl.Result{}, nil
 }
 
+// unmetReadinessGates reads the Kptfile of a package revision being approved, syncs its
+// conditions into the CRD, and returns the readiness gates whose conditions are not True.
+// Proposed packages are not rendered, so conditions set by external actors are picked up here.
+func (r *PackageRevisionReconciler) unmetReadinessGates(ctx context.Context, pr *porchv1alpha2.PackageRevision, content repository.PackageContent) ([]string, error) {
+	resources, err := content.GetResourceContents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get resources: %w", err)
+	}
+	kf, err := kptfileFromResources(resources)
+	if err != nil {
+		return nil, err
+	}
+	r.updateKptfileFields(ctx, pr, kf)
+	return porchv1alpha2.UnmetReadinessGates(porchv1alpha2.KptfileToReadinessGates(kf), porchv1alpha2.KptfileToPackageConditions(kf)), nil
+}
+
+// unresolvedMergeConflicts returns the message of the MergeConflicts condition in the Kptfile
+// of the package if it reports unresolved conflicts left by a manual-merge upgrade.
+func unresolvedMergeConflicts(ctx context.Context, content repository.PackageContent) (string, error) {
+	resources, err := content.GetResourceContents(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get resources: %w", err)
+	}
+	kf, err := kptfileFromResources(resources)
+	if err != nil {
+		return "", err
+	}
+	if kf.Status == nil {
+		return "", nil
+	}
+	for _, c := range kf.Status.Conditions {
+		if c.Type == mergeconflict.ConditionType && c.Status == kptfilev1.ConditionTrue {
+			return c.Message, nil
+		}
+	}
+	return "", nil
+}
+
 func resultOrDefault(result *ctrl.Result) ctrl.Result {
 	if result != nil {
 		return *result
@@ -287,8 +360,10 @@ func (r *PackageRevisionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Named("packagerevision").
 		Complete(r)
 
-	if err == nil {
-		log.V(1).Info("PackageRevision controller successfully registered")
+	if err != nil {
+		return err
 	}
-	return err
+	log.V(1).Info("PackageRevision controller successfully registered")
+
+	return (&DeploymentStatusReconciler{Client: r.Client}).SetupWithManager(mgr)
 }
@@ -1037,6 +1037,7 @@ func TestReconcileLifecycleTransition(t *testing.T) {
 
 			mockContent := mockrepository.NewMockPackageContent(t)
 			mockContent.EXPECT().Lifecycle(mock.Anything).Return(tt.current)
+			mockContent.EXPECT().GetResourceContents(mock.Anything).Return(map[string]string{}, nil).Maybe()
 
 			updatedContent := mockrepository.NewMockPackageContent(t)
 			updatedContent.EXPECT().Lifecycle(mock.Anything).Return(string(tt.desired)).Maybe()
@@ -1063,6 +1064,138 @@ func TestReconcileLifecycleTransition(t *testing.T) {
 	}
 }
 
+func TestReconcileLifecycleReadinessGatesNotMet(t *testing.T) {
+	ctx := t.Context()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-pr", Namespace: "default"}}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: readyObjectMeta("test-pr", "default", "my-repo"),
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    "my-pkg",
+			RepositoryName: "my-repo",
+			WorkspaceName:  "ws-1",
+			Lifecycle:      porchv1alpha2.PackageRevisionLifecyclePublished,
+		},
+	}
+
+	mockClient := mockclient.NewMockClient(t)
+	mockClient.EXPECT().Get(mock.Anything, req.NamespacedName, mock.AnythingOfType("*v1alpha2.PackageRevision")).
+		Run(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) {
+			*obj.(*porchv1alpha2.PackageRevision) = *pr
+		}).Return(nil)
+	// Kptfile-derived readiness gates are synced into the spec
+	mockClient.EXPECT().Patch(mock.Anything, mock.AnythingOfType("*v1alpha2.PackageRevision"), mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	kptfile := `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: my-pkg
+info:
+  readinessGates:
+  - conditionType: scan.example.com/passed
+  - conditionType: review.example.com/approved
+status:
+  conditions:
+  - type: scan.example.com/passed
+    status: "True"
+  - type: review.example.com/approved
+    status: "False"
+`
+	mockContent := mockrepository.NewMockPackageContent(t)
+	mockContent.EXPECT().Lifecycle(mock.Anything).Return("Proposed")
+	mockContent.EXPECT().GetResourceContents(mock.Anything).Return(map[string]string{"Kptfile": kptfile}, nil)
+	setupMockContentDefaults(mockContent)
+
+	mockCache := mockrepository.NewMockContentCache(t)
+	mockCache.EXPECT().GetPackageContent(mock.Anything, mock.Anything, "my-pkg", "ws-1").Return(mockContent, nil)
+
+	var readyCond *metav1.Condition
+	mockStatusWriter := mockclient.NewMockSubResourceWriter(t)
+	mockStatusWriter.EXPECT().Patch(mock.Anything, mock.AnythingOfType("*v1alpha2.PackageRevision"), mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) {
+			for _, c := range obj.(*porchv1alpha2.PackageRevision).Status.Conditions {
+				if c.Type == porchv1alpha2.ConditionReady {
+					readyCond = c.DeepCopy()
+				}
+			}
+		}).Return(nil)
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+
+	r := newTestReconciler(mockClient, mockCache)
+	result, err := r.Reconcile(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	mockCache.AssertNotCalled(t, "UpdateLifecycle", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	require.NotNil(t, readyCond)
+	assert.Equal(t, metav1.ConditionFalse, readyCond.Status)
+	assert.Equal(t, porchv1alpha2.ReasonReadinessGatesNotMet, readyCond.Reason)
+	assert.Equal(t, "readiness conditions not met: review.example.com/approved", readyCond.Message)
+}
+
+func TestReconcileLifecycleMergeConflicts(t *testing.T) {
+	ctx := t.Context()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-pr", Namespace: "default"}}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: readyObjectMeta("test-pr", "default", "my-repo"),
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    "my-pkg",
+			RepositoryName: "my-repo",
+			WorkspaceName:  "ws-1",
+			Lifecycle:      porchv1alpha2.PackageRevisionLifecycleProposed,
+		},
+	}
+
+	mockClient := mockclient.NewMockClient(t)
+	mockClient.EXPECT().Get(mock.Anything, req.NamespacedName, mock.AnythingOfType("*v1alpha2.PackageRevision")).
+		Run(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) {
+			*obj.(*porchv1alpha2.PackageRevision) = *pr
+		}).Return(nil)
+
+	kptfile := `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: my-pkg
+status:
+  conditions:
+  - type: MergeConflicts
+    status: "True"
+    reason: Unresolved
+    message: '1 unresolved merge conflicts: cm.yaml ConfigMap cm: data.key'
+`
+	mockContent := mockrepository.NewMockPackageContent(t)
+	mockContent.EXPECT().Lifecycle(mock.Anything).Return("Draft")
+	mockContent.EXPECT().GetResourceContents(mock.Anything).Return(map[string]string{"Kptfile": kptfile}, nil)
+	setupMockContentDefaults(mockContent)
+
+	mockCache := mockrepository.NewMockContentCache(t)
+	mockCache.EXPECT().GetPackageContent(mock.Anything, mock.Anything, "my-pkg", "ws-1").Return(mockContent, nil)
+
+	var readyCond *metav1.Condition
+	mockStatusWriter := mockclient.NewMockSubResourceWriter(t)
+	mockStatusWriter.EXPECT().Patch(mock.Anything, mock.AnythingOfType("*v1alpha2.PackageRevision"), mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) {
+			for _, c := range obj.(*porchv1alpha2.PackageRevision).Status.Conditions {
+				if c.Type == porchv1alpha2.ConditionReady {
+					readyCond = c.DeepCopy()
+				}
+			}
+		}).Return(nil)
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+
+	r := newTestReconciler(mockClient, mockCache)
+	result, err := r.Reconcile(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	mockCache.AssertNotCalled(t, "UpdateLifecycle", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	require.NotNil(t, readyCond)
+	assert.Equal(t, metav1.ConditionFalse, readyCond.Status)
+	assert.Equal(t, porchv1alpha2.ReasonMergeConflicts, readyCond.Reason)
+	assert.Equal(t, "cannot propose package; 1 unresolved merge conflicts: cm.yaml ConfigMap cm: data.key", readyCond.Message)
+}
+
 func TestReconcileLifecycleTransitionFailure(t *testing.T) {
 	ctx := t.Context()
 	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-pr", Namespace: "default"}}
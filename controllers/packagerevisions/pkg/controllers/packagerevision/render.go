@@ -27,7 +27,9 @@ import (
 	"github.com/kptdev/kpt/pkg/lib/kptops"
 	"github.com/kptdev/kpt/pkg/lib/runneroptions"
 	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	"github.com/kptdev/porch/func/evaluator"
 	"github.com/kptdev/porch/pkg/repository"
+	pctx "github.com/kptdev/porch/pkg/util/context"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -180,7 +182,13 @@ func (r *PackageRevisionReconciler) executeRender(ctx context.Context, pr *porch
 	}
 	log.V(1).Info("read package resources", "count", len(resources))
 
-	result, requeueResult, err := r.renderWithConcurrencyLimit(ctx, resources)
+	// The namespace selects the function image policies the function runner applies
+	renderCtx := pctx.WithNamespace(ctx, pr.Namespace)
+	if _, annotation, _ := renderTrigger(pr); annotation {
+		// Renders requested by an update of the package resources are waited for by a user
+		renderCtx = pctx.WithPriority(renderCtx, evaluator.PriorityInteractive)
+	}
+	result, requeueResult, err := r.renderWithConcurrencyLimit(renderCtx, resources)
 	if requeueResult != nil {
 		log.Info("render concurrency limit reached, requeuing")
 		return requeueResult, nil
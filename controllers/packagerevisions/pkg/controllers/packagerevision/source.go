@@ -29,6 +29,9 @@ import (
 	"github.com/kptdev/kpt/pkg/printer/fake"
 	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
 	"github.com/kptdev/porch/pkg/repository"
+	"github.com/kptdev/porch/pkg/task"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
+	"github.com/kptdev/porch/pkg/util/template"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
@@ -46,6 +49,9 @@ func (r *PackageRevisionReconciler) applySource(ctx context.Context, pr *porchv1
 	}
 
 	switch {
+	case pr.Spec.Source.Init != nil && pr.Spec.Source.Init.Template != nil:
+		resources, err := r.initFromTemplate(ctx, pr)
+		return resources, "init", err
 	case pr.Spec.Source.Init != nil:
 		resources, err := initPackage(ctx, pr.Spec.PackageName, pr.Spec.Source.Init)
 		return resources, "init", err
@@ -58,6 +64,9 @@ func (r *PackageRevisionReconciler) applySource(ctx context.Context, pr *porchv1
 	case pr.Spec.Source.Upgrade != nil:
 		resources, err := r.upgradePackage(ctx, pr)
 		return resources, "upgrade", err
+	case pr.Spec.Source.PromoteFrom != nil:
+		resources, err := r.promotePackage(ctx, pr)
+		return resources, "promote", err
 	default:
 		return nil, "", fmt.Errorf("source has no fields set")
 	}
@@ -85,6 +94,69 @@ func initPackage(ctx context.Context, pkgName string, spec *porchv1alpha2.Packag
 	return readFsToMap(fs)
 }
 
+// initFromTemplate instantiates the starter template referenced by Init.Template
+// with the supplied parameter values. The resulting package does not track the
+// template as its upstream; its pipeline is rendered by the caller as usual.
+func (r *PackageRevisionReconciler) initFromTemplate(ctx context.Context, pr *porchv1alpha2.PackageRevision) (map[string]string, error) {
+	spec := pr.Spec.Source.Init
+
+	resources, err := r.fetchTemplate(ctx, pr.Namespace, spec.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err = template.Instantiate(resources, template.Options{
+		PackageName: pr.Spec.PackageName,
+		Description: spec.Description,
+		Keywords:    spec.Keywords,
+		Site:        spec.Site,
+		Values:      spec.Template.Parameters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate template for pkg %q: %w", pr.Spec.PackageName, err)
+	}
+	return resources, nil
+}
+
+// fetchTemplate reads the resources of a starter template from a published package
+// revision in a registered repository or from a git repository.
+func (r *PackageRevisionReconciler) fetchTemplate(ctx context.Context, namespace string, spec *porchv1alpha2.PackageTemplate) (map[string]string, error) {
+	log := log.FromContext(ctx)
+
+	switch {
+	case spec.UpstreamRef != nil:
+		templatePR, err := r.getPublishedPackageRevision(ctx, namespace, spec.UpstreamRef.Name)
+		if err != nil {
+			return nil, fmt.Errorf("template: %w", err)
+		}
+		if err := r.checkRepositorySource(ctx, namespace, templatePR.Spec.RepositoryName); err != nil {
+			return nil, err
+		}
+
+		log.V(1).Info("initializing from template", "template", spec.UpstreamRef.Name)
+		resources, err := r.getPackageResources(ctx, templatePR)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template resources: %w", err)
+		}
+		return resources, nil
+	case spec.Git != nil:
+		if r.SourcePolicyChecker != nil {
+			if err := r.SourcePolicyChecker.CheckGitSource(ctx, namespace, spec.Git.Repo); err != nil {
+				return nil, err
+			}
+		}
+
+		log.V(1).Info("initializing from git template", "repo", spec.Git.Repo, "ref", spec.Git.Ref, "directory", spec.Git.Directory)
+		resources, _, err := r.ExternalPackageFetcher.FetchExternalGitPackage(ctx, spec.Git, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch template from git: %w", err)
+		}
+		return resources, nil
+	default:
+		return nil, fmt.Errorf("template must specify either upstreamRef or git")
+	}
+}
+
 // copyPackage reads the source package referenced by CopyFrom and returns its resources.
 // Validates the source is from the same repository and is published.
 func (r *PackageRevisionReconciler) copyPackage(ctx context.Context, pr *porchv1alpha2.PackageRevision) (map[string]string, error) {
@@ -141,6 +213,9 @@ func (r *PackageRevisionReconciler) cloneFromUpstreamRef(ctx context.Context, pr
 	if !porchv1alpha2.LifecycleIsPublished(sourcePR.Spec.Lifecycle) {
 		return nil, fmt.Errorf("upstream package %q must be published", ref.Name)
 	}
+	if err := r.checkRepositorySource(ctx, pr.Namespace, sourcePR.Spec.RepositoryName); err != nil {
+		return nil, err
+	}
 
 	log.V(1).Info("cloning from upstream ref", "upstream", ref.Name)
 
@@ -168,6 +243,12 @@ func (r *PackageRevisionReconciler) cloneFromUpstreamRef(ctx context.Context, pr
 }
 
 func (r *PackageRevisionReconciler) cloneFromGit(ctx context.Context, pr *porchv1alpha2.PackageRevision, gitSpec *porchv1alpha2.GitPackage) (map[string]string, error) {
+	if r.SourcePolicyChecker != nil {
+		if err := r.SourcePolicyChecker.CheckGitSource(ctx, pr.Namespace, gitSpec.Repo); err != nil {
+			return nil, err
+		}
+	}
+
 	log.FromContext(ctx).V(1).Info("cloning from git", "repo", gitSpec.Repo, "ref", gitSpec.Ref, "directory", gitSpec.Directory)
 	resources, lock, err := r.ExternalPackageFetcher.FetchExternalGitPackage(ctx, gitSpec, pr.Namespace)
 	if err != nil {
@@ -204,6 +285,11 @@ func (r *PackageRevisionReconciler) upgradePackage(ctx context.Context, pr *porc
 	if strategy == "" {
 		strategy = string(porchv1alpha2.ResourceMerge)
 	}
+	// The manual-merge strategy merges like resource-merge and then records the conflicts.
+	manual := strategy == string(porchv1alpha2.ManualMerge)
+	if manual {
+		strategy = string(porchv1alpha2.ResourceMerge)
+	}
 
 	// Look up all three package revisions.
 	oldUpstreamPR, err := r.getPublishedPackageRevision(ctx, pr.Namespace, upgrade.OldUpstream.Name)
@@ -214,6 +300,9 @@ func (r *PackageRevisionReconciler) upgradePackage(ctx context.Context, pr *porc
 	if err != nil {
 		return nil, fmt.Errorf("new upstream: %w", err)
 	}
+	if err := r.checkRepositorySource(ctx, pr.Namespace, newUpstreamPR.Spec.RepositoryName); err != nil {
+		return nil, err
+	}
 	currentPR, err := r.getPublishedPackageRevision(ctx, pr.Namespace, upgrade.CurrentPackage.Name)
 	if err != nil {
 		return nil, fmt.Errorf("current package: %w", err)
@@ -252,6 +341,11 @@ func (r *PackageRevisionReconciler) upgradePackage(ctx context.Context, pr *porc
 	if err != nil {
 		return nil, fmt.Errorf("3-way merge failed: %w", err)
 	}
+	if manual {
+		if err := task.MarkConflicts(currentResources, oldUpstreamResources, newUpstreamResources, updated.Contents); err != nil {
+			return nil, fmt.Errorf("failed to record merge conflicts: %w", err)
+		}
+	}
 
 	// Update Kptfile upstream/upstreamLock to point at new upstream.
 	newUpstream, newUpstreamLock, err := newUpstreamContent.GetLock(ctx)
@@ -261,6 +355,11 @@ func (r *PackageRevisionReconciler) upgradePackage(ctx context.Context, pr *porc
 	if err := kptops.UpdateKptfileUpstream(pr.Spec.PackageName, updated.Contents, newUpstream, newUpstreamLock); err != nil {
 		return nil, fmt.Errorf("failed to update Kptfile upstream: %w", err)
 	}
+	if manual {
+		if err := mergeconflict.Refresh(updated.Contents, true); err != nil {
+			return nil, fmt.Errorf("failed to set the %s condition: %w", mergeconflict.ConditionType, err)
+		}
+	}
 
 	// Add merge-key comments to newly added resources.
 	result, err := ensureMergeKey(updated.Contents)
@@ -273,6 +372,47 @@ func (r *PackageRevisionReconciler) upgradePackage(ctx context.Context, pr *porc
 	return result, nil
 }
 
+// promotePackage reads the source package referenced by PromoteFrom and returns its
+// resources with Kptfile upstream/upstreamLock pointing at the source revision, so the
+// promoted revision records which environment it was promoted from.
+// Validates the source is the same package in a different repository and is published.
+func (r *PackageRevisionReconciler) promotePackage(ctx context.Context, pr *porchv1alpha2.PackageRevision) (map[string]string, error) {
+	log := log.FromContext(ctx)
+	sourceRef := pr.Spec.Source.PromoteFrom
+
+	sourcePR, err := r.getPublishedPackageRevision(ctx, pr.Namespace, sourceRef.Name)
+	if err != nil {
+		return nil, fmt.Errorf("promotion source: %w", err)
+	}
+	if sourcePR.Spec.RepositoryName == pr.Spec.RepositoryName {
+		return nil, fmt.Errorf("promotion source must be from a different repository than %q, use copyFrom to create a new revision in the same repository", pr.Spec.RepositoryName)
+	}
+	if sourcePR.Spec.PackageName != pr.Spec.PackageName {
+		return nil, fmt.Errorf("promotion source must be same package %q, got %q", pr.Spec.PackageName, sourcePR.Spec.PackageName)
+	}
+	if err := r.checkRepositorySource(ctx, pr.Namespace, sourcePR.Spec.RepositoryName); err != nil {
+		return nil, err
+	}
+
+	log.V(1).Info("promoting from source", "source", sourceRef.Name, "sourceRepository", sourcePR.Spec.RepositoryName)
+	content, resources, err := r.getPackageContentAndResources(ctx, sourcePR)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read promotion source resources: %w", err)
+	}
+
+	// The self lock of the source revision becomes the upstream lock of the promoted
+	// revision, recording the promotion lineage (repository, branch, tag and commit).
+	upstream, lock, err := content.GetLock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion source lock for %q: %w", sourceRef.Name, err)
+	}
+	if err := kptops.UpdateKptfileUpstream(pr.Spec.PackageName, resources, upstream, lock); err != nil {
+		return nil, fmt.Errorf("failed to update Kptfile upstream: %w", err)
+	}
+
+	return resources, nil
+}
+
 // getPublishedPackageRevision looks up a PackageRevision CRD and validates it is published.
 func (r *PackageRevisionReconciler) getPublishedPackageRevision(ctx context.Context, namespace, name string) (*porchv1alpha2.PackageRevision, error) {
 	var pr porchv1alpha2.PackageRevision
@@ -353,3 +493,12 @@ func readFsToMap(fs filesys.FileSystem) (map[string]string, error) {
 	}
 	return contents, nil
 }
+
+// checkRepositorySource checks a registered repository that a package revision is cloned, upgraded
+// or promoted from against the SourcePolicies of the namespace.
+func (r *PackageRevisionReconciler) checkRepositorySource(ctx context.Context, namespace, repositoryName string) error {
+	if r.SourcePolicyChecker == nil {
+		return nil
+	}
+	return r.SourcePolicyChecker.CheckRepositorySource(ctx, namespace, repositoryName)
+}
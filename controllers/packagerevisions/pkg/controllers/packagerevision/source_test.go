@@ -25,6 +25,7 @@ import (
 	kptfilev1 "github.com/kptdev/kpt/api/kptfile/v1"
 	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
 	"github.com/kptdev/porch/pkg/repository"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
 	mockclient "github.com/kptdev/porch/test/mockery/mocks/external/sigs.k8s.io/controller-runtime/pkg/client"
 	mockrepository "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/repository"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -108,6 +109,155 @@ func TestInitPackageEmpty(t *testing.T) {
 	assert.True(t, ok, "Kptfile should exist even with empty spec")
 }
 
+const testTemplateKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: starter
+info:
+  description: starter template
+pipeline:
+  mutators:
+  - image: apply-setters:v0.2
+    configPath: template-values.yaml
+`
+
+const testTemplateParameters = `apiVersion: config.porch.kpt.dev/v1alpha1
+kind: TemplateParameters
+metadata:
+  name: parameters
+  annotations:
+    config.kubernetes.io/local-config: "true"
+spec:
+  parameters:
+  - name: replicas
+    type: integer
+    default: "1"
+`
+
+func TestApplySourceInitFromTemplateUpstreamRef(t *testing.T) {
+	ctx := context.Background()
+
+	mc := mockclient.NewMockClient(t)
+	mc.EXPECT().Get(mock.Anything, client.ObjectKey{Namespace: "default", Name: "templates.starter.v1"}, &porchv1alpha2.PackageRevision{}).
+		RunAndReturn(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			src := obj.(*porchv1alpha2.PackageRevision)
+			src.Spec.PackageName = "starter"
+			src.Spec.RepositoryName = "templates"
+			src.Spec.WorkspaceName = "v1"
+			src.Spec.Lifecycle = porchv1alpha2.PackageRevisionLifecyclePublished
+			return nil
+		})
+
+	mockContent := mockrepository.NewMockPackageContent(t)
+	mockContent.EXPECT().GetResourceContents(ctx).Return(map[string]string{
+		"Kptfile":         testTemplateKptfile,
+		"parameters.yaml": testTemplateParameters,
+	}, nil)
+
+	mockCache := mockrepository.NewMockContentCache(t)
+	mockCache.EXPECT().GetPackageContent(ctx,
+		repository.RepositoryKey{Namespace: "default", Name: "templates"}, "starter", "v1",
+	).Return(mockContent, nil)
+
+	r := &PackageRevisionReconciler{Client: mc, ContentCache: mockCache}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-repo.my-pkg.v1", Namespace: "default"},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    "my-pkg",
+			RepositoryName: "my-repo",
+			WorkspaceName:  "v1",
+			Source: &porchv1alpha2.PackageSource{
+				Init: &porchv1alpha2.PackageInitSpec{
+					Description: "my package",
+					Template: &porchv1alpha2.PackageTemplate{
+						UpstreamRef: &porchv1alpha2.PackageRevisionRef{Name: "templates.starter.v1"},
+						Parameters:  map[string]string{"replicas": "3"},
+					},
+				},
+			},
+		},
+	}
+
+	resources, source, err := r.applySource(ctx, pr)
+	require.NoError(t, err)
+	assert.Equal(t, "init", source)
+	assert.NotContains(t, resources, "parameters.yaml")
+	assert.Contains(t, resources["Kptfile"], "name: my-pkg")
+	assert.Contains(t, resources["Kptfile"], "my package")
+	assert.NotContains(t, resources["Kptfile"], "upstream")
+	assert.Contains(t, resources["template-values.yaml"], `replicas: "3"`)
+}
+
+func TestApplySourceInitFromTemplateGitInvalidParameters(t *testing.T) {
+	ctx := context.Background()
+
+	gitSpec := &porchv1alpha2.GitPackage{
+		Repo:      "https://example.com/templates.git",
+		Ref:       "main",
+		Directory: "/starter",
+	}
+
+	mockFetcher := mockrepository.NewMockExternalPackageFetcher(t)
+	mockFetcher.EXPECT().FetchExternalGitPackage(ctx, gitSpec, "default").Return(
+		map[string]string{
+			"Kptfile":         testTemplateKptfile,
+			"parameters.yaml": testTemplateParameters,
+		},
+		kptfilev1.GitLock{Repo: "https://example.com/templates.git", Directory: "/starter", Ref: "main", Commit: "abc123"},
+		nil,
+	)
+
+	r := &PackageRevisionReconciler{ExternalPackageFetcher: mockFetcher}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-repo.my-pkg.v1", Namespace: "default"},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    "my-pkg",
+			RepositoryName: "my-repo",
+			WorkspaceName:  "v1",
+			Source: &porchv1alpha2.PackageSource{
+				Init: &porchv1alpha2.PackageInitSpec{
+					Template: &porchv1alpha2.PackageTemplate{
+						Git:        gitSpec,
+						Parameters: map[string]string{"replicas": "three", "colour": "blue"},
+					},
+				},
+			},
+		},
+	}
+
+	_, _, err := r.applySource(ctx, pr)
+	assert.ErrorContains(t, err, "invalid template parameters")
+	assert.ErrorContains(t, err, "replicas")
+	assert.ErrorContains(t, err, `template declares no parameter "colour"`)
+}
+
+func TestApplySourceInitFromTemplateSourceDenied(t *testing.T) {
+	r := &PackageRevisionReconciler{
+		ExternalPackageFetcher: mockrepository.NewMockExternalPackageFetcher(t),
+		SourcePolicyChecker:    denyingSourcePolicyChecker{},
+	}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-repo.my-pkg.v1", Namespace: "default"},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName: "my-pkg",
+			Source: &porchv1alpha2.PackageSource{
+				Init: &porchv1alpha2.PackageInitSpec{
+					Template: &porchv1alpha2.PackageTemplate{
+						Git: &porchv1alpha2.GitPackage{Repo: "https://example.com/templates.git"},
+					},
+				},
+			},
+		},
+	}
+
+	_, _, err := r.applySource(context.Background(), pr)
+	assert.True(t, repository.IsSourceDenied(err))
+	assert.ErrorContains(t, err, "https://example.com/templates.git")
+}
+
 func TestApplySourceEmptySourceStruct(t *testing.T) {
 	r := &PackageRevisionReconciler{}
 	pr := &porchv1alpha2.PackageRevision{
@@ -421,6 +571,83 @@ func TestApplySourceCloneGit(t *testing.T) {
 	assert.Contains(t, resources["Kptfile"], "my-pkg")
 }
 
+// denyingSourcePolicyChecker denies every source.
+type denyingSourcePolicyChecker struct{}
+
+func (denyingSourcePolicyChecker) CheckGitSource(_ context.Context, namespace, url string) error {
+	return &repository.SourceDeniedError{Namespace: namespace, Kind: repository.SourceKindGit, Source: url}
+}
+
+func (denyingSourcePolicyChecker) CheckRepositorySource(_ context.Context, namespace, repositoryName string) error {
+	return &repository.SourceDeniedError{Namespace: namespace, Kind: repository.SourceKindRepository, Source: repositoryName}
+}
+
+func (denyingSourcePolicyChecker) CheckOCISource(_ context.Context, namespace, image string) error {
+	return &repository.SourceDeniedError{Namespace: namespace, Kind: repository.SourceKindOCI, Source: image}
+}
+
+func TestApplySourceCloneGitSourceDenied(t *testing.T) {
+	// The fetcher mock has no expectations, so fetching a denied source fails the test
+	r := &PackageRevisionReconciler{
+		ExternalPackageFetcher: mockrepository.NewMockExternalPackageFetcher(t),
+		SourcePolicyChecker:    denyingSourcePolicyChecker{},
+	}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-repo.my-pkg.v1", Namespace: "default"},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    "my-pkg",
+			RepositoryName: "my-repo",
+			WorkspaceName:  "v1",
+			Source: &porchv1alpha2.PackageSource{
+				CloneFrom: &porchv1alpha2.UpstreamPackage{
+					Git: &porchv1alpha2.GitPackage{Repo: "https://example.com/repo.git", Ref: "v1"},
+				},
+			},
+		},
+	}
+
+	_, _, err := r.applySource(context.Background(), pr)
+	assert.True(t, repository.IsSourceDenied(err))
+	assert.ErrorContains(t, err, "https://example.com/repo.git")
+}
+
+func TestApplySourceCloneUpstreamRefSourceDenied(t *testing.T) {
+	mc := mockclient.NewMockClient(t)
+	mc.EXPECT().Get(mock.Anything, client.ObjectKey{Namespace: "default", Name: "upstream-repo.upstream-pkg.v1"}, &porchv1alpha2.PackageRevision{}).
+		RunAndReturn(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			src := obj.(*porchv1alpha2.PackageRevision)
+			src.Spec.PackageName = "upstream-pkg"
+			src.Spec.RepositoryName = "upstream-repo"
+			src.Spec.Lifecycle = porchv1alpha2.PackageRevisionLifecyclePublished
+			return nil
+		})
+
+	r := &PackageRevisionReconciler{
+		Client:              mc,
+		ContentCache:        mockrepository.NewMockContentCache(t),
+		SourcePolicyChecker: denyingSourcePolicyChecker{},
+	}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-repo.my-pkg.v1", Namespace: "default"},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    "my-pkg",
+			RepositoryName: "my-repo",
+			WorkspaceName:  "v1",
+			Source: &porchv1alpha2.PackageSource{
+				CloneFrom: &porchv1alpha2.UpstreamPackage{
+					UpstreamRef: &porchv1alpha2.PackageRevisionRef{Name: "upstream-repo.upstream-pkg.v1"},
+				},
+			},
+		},
+	}
+
+	_, _, err := r.applySource(context.Background(), pr)
+	assert.True(t, repository.IsSourceDenied(err))
+	assert.ErrorContains(t, err, "upstream-repo")
+}
+
 func TestApplySourceCloneNoSourceSpecified(t *testing.T) {
 	r := &PackageRevisionReconciler{}
 
@@ -722,6 +949,84 @@ func TestApplySourceUpgrade(t *testing.T) {
 	assert.Contains(t, resources["Kptfile"], "v2")
 }
 
+func TestApplySourceUpgradeManualMerge(t *testing.T) {
+	ctx := context.Background()
+
+	mc := mockclient.NewMockClient(t)
+	for name, spec := range map[string]porchv1alpha2.PackageRevisionSpec{
+		"upstream.pkg.v1":   {PackageName: "pkg", RepositoryName: "upstream", WorkspaceName: "v1"},
+		"upstream.pkg.v2":   {PackageName: "pkg", RepositoryName: "upstream", WorkspaceName: "v2"},
+		"downstream.pkg.v1": {PackageName: "pkg", RepositoryName: "downstream", WorkspaceName: "v1"},
+	} {
+		mc.EXPECT().Get(mock.Anything, client.ObjectKey{Namespace: "default", Name: name}, &porchv1alpha2.PackageRevision{}).
+			RunAndReturn(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				pr := obj.(*porchv1alpha2.PackageRevision)
+				pr.Namespace = "default"
+				pr.Spec = spec
+				pr.Spec.Lifecycle = porchv1alpha2.PackageRevisionLifecyclePublished
+				return nil
+			})
+	}
+
+	kptfileContent := "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: pkg\n"
+	configMap := func(value string) string {
+		return "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  key: " + value + "\n"
+	}
+
+	oldContent := mockrepository.NewMockPackageContent(t)
+	oldContent.EXPECT().GetResourceContents(ctx).Return(map[string]string{"Kptfile": kptfileContent, "cm.yaml": configMap("base")}, nil)
+	newContent := mockrepository.NewMockPackageContent(t)
+	newContent.EXPECT().GetResourceContents(ctx).Return(map[string]string{"Kptfile": kptfileContent, "cm.yaml": configMap("upstream")}, nil)
+	newContent.EXPECT().GetLock(ctx).Return(
+		kptfilev1.Upstream{Type: kptfilev1.GitOrigin, Git: &kptfilev1.Git{Repo: "https://example.com/upstream.git", Directory: "/pkg", Ref: "v2"}},
+		kptfilev1.Locator{Type: kptfilev1.GitOrigin, Git: &kptfilev1.GitLock{Repo: "https://example.com/upstream.git", Directory: "/pkg", Ref: "v2", Commit: "def456"}},
+		nil,
+	)
+	currentContent := mockrepository.NewMockPackageContent(t)
+	currentContent.EXPECT().GetResourceContents(ctx).Return(map[string]string{"Kptfile": kptfileContent, "cm.yaml": configMap("local")}, nil)
+
+	mockCache := mockrepository.NewMockContentCache(t)
+	mockCache.EXPECT().GetPackageContent(ctx, repository.RepositoryKey{Namespace: "default", Name: "upstream"}, "pkg", "v1").Return(oldContent, nil)
+	mockCache.EXPECT().GetPackageContent(ctx, repository.RepositoryKey{Namespace: "default", Name: "upstream"}, "pkg", "v2").Return(newContent, nil)
+	mockCache.EXPECT().GetPackageContent(ctx, repository.RepositoryKey{Namespace: "default", Name: "downstream"}, "pkg", "v1").Return(currentContent, nil)
+
+	r := &PackageRevisionReconciler{Client: mc, ContentCache: mockCache}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "downstream.pkg.v2", Namespace: "default"},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    "pkg",
+			RepositoryName: "downstream",
+			WorkspaceName:  "v2",
+			Source: &porchv1alpha2.PackageSource{
+				Upgrade: &porchv1alpha2.PackageUpgradeSpec{
+					OldUpstream:    porchv1alpha2.PackageRevisionRef{Name: "upstream.pkg.v1"},
+					NewUpstream:    porchv1alpha2.PackageRevisionRef{Name: "upstream.pkg.v2"},
+					CurrentPackage: porchv1alpha2.PackageRevisionRef{Name: "downstream.pkg.v1"},
+					Strategy:       porchv1alpha2.ManualMerge,
+				},
+			},
+		},
+	}
+
+	resources, _, err := r.applySource(ctx, pr)
+	require.NoError(t, err)
+
+	// The conflicting field keeps the local value and the conflict is recorded.
+	items, err := mergeconflict.List(resources)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Contains(t, resources["cm.yaml"], "key: local")
+	assert.Contains(t, resources["cm.yaml"], mergeconflict.Annotation)
+
+	// The MergeConflicts condition blocks proposing the package revision.
+	mergedContent := mockrepository.NewMockPackageContent(t)
+	mergedContent.EXPECT().GetResourceContents(ctx).Return(resources, nil)
+	conflicts, err := unresolvedMergeConflicts(ctx, mergedContent)
+	require.NoError(t, err)
+	assert.Contains(t, conflicts, "1 unresolved merge conflicts")
+}
+
 func TestApplySourceUpgradeOldUpstreamNotFound(t *testing.T) {
 	mc := mockclient.NewMockClient(t)
 	mc.EXPECT().Get(mock.Anything, client.ObjectKey{Namespace: "default", Name: "upstream.pkg.v1"}, &porchv1alpha2.PackageRevision{}).
@@ -969,6 +1274,144 @@ func TestApplySourceUpgradeIdempotent(t *testing.T) {
 	assert.Empty(t, source)
 }
 
+func TestApplySourcePromote(t *testing.T) {
+	ctx := context.Background()
+
+	mc := mockclient.NewMockClient(t)
+	mc.EXPECT().Get(mock.Anything, client.ObjectKey{Namespace: "default", Name: "dev.pkg.v1"}, &porchv1alpha2.PackageRevision{}).
+		RunAndReturn(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			src := obj.(*porchv1alpha2.PackageRevision)
+			src.Spec.PackageName = "pkg"
+			src.Spec.RepositoryName = "dev"
+			src.Spec.WorkspaceName = "v1"
+			src.Spec.Lifecycle = porchv1alpha2.PackageRevisionLifecyclePublished
+			return nil
+		})
+
+	mockContent := mockrepository.NewMockPackageContent(t)
+	mockContent.EXPECT().GetResourceContents(ctx).Return(map[string]string{
+		"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: pkg\n",
+	}, nil)
+	mockContent.EXPECT().GetLock(ctx).Return(
+		kptfilev1.Upstream{Type: kptfilev1.GitOrigin, Git: &kptfilev1.Git{Repo: "https://example.com/repo.git", Directory: "pkg", Ref: "pkg/v1"}},
+		kptfilev1.Locator{Type: kptfilev1.GitOrigin, Git: &kptfilev1.GitLock{Repo: "https://example.com/repo.git", Directory: "pkg", Ref: "pkg/v1", Commit: "abc123"}},
+		nil,
+	)
+
+	mockCache := mockrepository.NewMockContentCache(t)
+	mockCache.EXPECT().GetPackageContent(ctx,
+		repository.RepositoryKey{Namespace: "default", Name: "dev"}, "pkg", "v1",
+	).Return(mockContent, nil)
+
+	r := &PackageRevisionReconciler{Client: mc, ContentCache: mockCache}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging.pkg.v1", Namespace: "default"},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    "pkg",
+			RepositoryName: "staging",
+			WorkspaceName:  "v1",
+			Source: &porchv1alpha2.PackageSource{
+				PromoteFrom: &porchv1alpha2.PackageRevisionRef{Name: "dev.pkg.v1"},
+			},
+		},
+	}
+
+	resources, source, err := r.applySource(ctx, pr)
+	require.NoError(t, err)
+	assert.Equal(t, "promote", source)
+	// Kptfile upstreamLock should record the promotion source revision
+	assert.Contains(t, resources["Kptfile"], "ref: pkg/v1")
+	assert.Contains(t, resources["Kptfile"], "commit: abc123")
+}
+
+func TestApplySourcePromoteSameRepo(t *testing.T) {
+	mc := mockclient.NewMockClient(t)
+	mc.EXPECT().Get(mock.Anything, client.ObjectKey{Namespace: "default", Name: "dev.pkg.v1"}, &porchv1alpha2.PackageRevision{}).
+		RunAndReturn(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			src := obj.(*porchv1alpha2.PackageRevision)
+			src.Spec.PackageName = "pkg"
+			src.Spec.RepositoryName = "dev"
+			src.Spec.Lifecycle = porchv1alpha2.PackageRevisionLifecyclePublished
+			return nil
+		})
+
+	r := &PackageRevisionReconciler{Client: mc}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev.pkg.v2", Namespace: "default"},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    "pkg",
+			RepositoryName: "dev",
+			WorkspaceName:  "v2",
+			Source: &porchv1alpha2.PackageSource{
+				PromoteFrom: &porchv1alpha2.PackageRevisionRef{Name: "dev.pkg.v1"},
+			},
+		},
+	}
+
+	_, _, err := r.applySource(context.Background(), pr)
+	assert.ErrorContains(t, err, "different repository")
+}
+
+func TestApplySourcePromoteDifferentPackageName(t *testing.T) {
+	mc := mockclient.NewMockClient(t)
+	mc.EXPECT().Get(mock.Anything, client.ObjectKey{Namespace: "default", Name: "dev.other-pkg.v1"}, &porchv1alpha2.PackageRevision{}).
+		RunAndReturn(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			src := obj.(*porchv1alpha2.PackageRevision)
+			src.Spec.PackageName = "other-pkg"
+			src.Spec.RepositoryName = "dev"
+			src.Spec.Lifecycle = porchv1alpha2.PackageRevisionLifecyclePublished
+			return nil
+		})
+
+	r := &PackageRevisionReconciler{Client: mc}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging.pkg.v1", Namespace: "default"},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    "pkg",
+			RepositoryName: "staging",
+			WorkspaceName:  "v1",
+			Source: &porchv1alpha2.PackageSource{
+				PromoteFrom: &porchv1alpha2.PackageRevisionRef{Name: "dev.other-pkg.v1"},
+			},
+		},
+	}
+
+	_, _, err := r.applySource(context.Background(), pr)
+	assert.ErrorContains(t, err, "same package")
+}
+
+func TestApplySourcePromoteNotPublished(t *testing.T) {
+	mc := mockclient.NewMockClient(t)
+	mc.EXPECT().Get(mock.Anything, client.ObjectKey{Namespace: "default", Name: "dev.pkg.v1"}, &porchv1alpha2.PackageRevision{}).
+		RunAndReturn(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			src := obj.(*porchv1alpha2.PackageRevision)
+			src.Spec.PackageName = "pkg"
+			src.Spec.RepositoryName = "dev"
+			src.Spec.Lifecycle = porchv1alpha2.PackageRevisionLifecycleProposed
+			return nil
+		})
+
+	r := &PackageRevisionReconciler{Client: mc}
+
+	pr := &porchv1alpha2.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "staging.pkg.v1", Namespace: "default"},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    "pkg",
+			RepositoryName: "staging",
+			WorkspaceName:  "v1",
+			Source: &porchv1alpha2.PackageSource{
+				PromoteFrom: &porchv1alpha2.PackageRevisionRef{Name: "dev.pkg.v1"},
+			},
+		},
+	}
+
+	_, _, err := r.applySource(context.Background(), pr)
+	assert.ErrorContains(t, err, "must be published")
+}
+
 func TestStripKptfileStatus(t *testing.T) {
 	kfWithStatus := `apiVersion: kpt.dev/v1
 kind: Kptfile
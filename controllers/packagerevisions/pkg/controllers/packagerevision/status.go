@@ -26,9 +26,10 @@ import (
 )
 
 const (
-	fieldManagerPRController        = "packagerev-controller"
-	fieldManagerPRControllerRender  = "packagerev-controller-render"
-	fieldManagerPRControllerKptfile = "packagerev-controller-kptfile"
+	fieldManagerPRController           = "packagerev-controller"
+	fieldManagerPRControllerRender     = "packagerev-controller-render"
+	fieldManagerPRControllerKptfile    = "packagerev-controller-kptfile"
+	fieldManagerPRControllerDeployment = "packagerev-controller-deployment"
 )
 
 // updateStatus applies the PR-controller-owned status fields via SSA.
@@ -63,6 +64,7 @@ func (r *PackageRevisionReconciler) updateStatus(ctx context.Context, pr *porchv
 		}
 		if resources, err := content.GetResourceContents(ctx); err == nil {
 			status.ResourcesSizeBytes = repository.CalculateResourcesSize(resources)
+			status.Subpackages = repository.ToV1Alpha2Subpackages(repository.FindSubpackages(resources))
 		}
 	}
 
@@ -135,9 +137,13 @@ func (r *PackageRevisionReconciler) refreshRenderedGeneration(ctx context.Contex
 // content didn't land successfully, so "not rendered" is accurate.
 func (r *PackageRevisionReconciler) setSourceFailed(ctx context.Context, pr *porchv1alpha2.PackageRevision, err error) error {
 	log.FromContext(ctx).Error(err, "source execution failed")
+	reason := porchv1alpha2.ReasonFailed
+	if repository.IsSourceDenied(err) {
+		reason = porchv1alpha2.ReasonSourceDenied
+	}
 	r.updateStatus(ctx, pr, nil, "",
-		readyCondition(pr.Generation, metav1.ConditionFalse, porchv1alpha2.ReasonFailed, err.Error()),
-		renderedCondition(pr.Generation, metav1.ConditionFalse, porchv1alpha2.ReasonFailed, err.Error()),
+		readyCondition(pr.Generation, metav1.ConditionFalse, reason, err.Error()),
+		renderedCondition(pr.Generation, metav1.ConditionFalse, reason, err.Error()),
 	)
 	return err
 }
@@ -124,6 +124,31 @@ func TestUpdateStatusWithDraftContent(t *testing.T) {
 	assert.Equal(t, int64(13), captured.ResourcesSizeBytes)
 }
 
+func TestUpdateStatusWithSubpackages(t *testing.T) {
+	mockClient := mockclient.NewMockClient(t)
+	captured := captureStatusPatch(t, mockClient)
+
+	content := mockrepository.NewMockPackageContent(t)
+	content.EXPECT().Lifecycle(mock.Anything).Return("Draft")
+	content.EXPECT().GetLock(mock.Anything).Return(kptfilev1.Upstream{}, kptfilev1.Locator{}, nil)
+	content.EXPECT().GetUpstreamLock(mock.Anything).Return(kptfilev1.Upstream{}, kptfilev1.Locator{}, nil)
+	content.EXPECT().GetResourceContents(mock.Anything).Return(map[string]string{
+		"Kptfile":        "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: app\n",
+		"db/Kptfile":     "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: db\n",
+		"db/tls/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: tls\n",
+	}, nil)
+
+	r := &PackageRevisionReconciler{Client: mockClient}
+	pr := basePR()
+
+	r.updateStatus(t.Context(), pr, content, "")
+
+	assert.Equal(t, []porchv1alpha2.Subpackage{
+		{Path: "db", Name: "db"},
+		{Path: "db/tls", Parent: "db", Name: "tls"},
+	}, captured.Subpackages)
+}
+
 func TestUpdateRenderStatusInProgress(t *testing.T) {
 	mockClient := mockclient.NewMockClient(t)
 	captured := captureStatusPatch(t, mockClient)
@@ -246,6 +271,25 @@ func TestSetRenderFailed(t *testing.T) {
 	assert.Equal(t, porchv1alpha2.ReasonRenderFailed, renderPatch.Conditions[0].Reason)
 }
 
+func TestSetSourceFailedSourceDenied(t *testing.T) {
+	mockClient := mockclient.NewMockClient(t)
+	captured := captureStatusPatch(t, mockClient)
+
+	r := &PackageRevisionReconciler{Client: mockClient}
+	pr := basePR()
+	denied := &repository.SourceDeniedError{Namespace: "default", Kind: repository.SourceKindGit, Source: "https://example.com/repo.git"}
+
+	err := r.setSourceFailed(t.Context(), pr, denied)
+
+	assert.ErrorIs(t, err, denied)
+	assert.Len(t, captured.Conditions, 2)
+	for _, condition := range captured.Conditions {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, porchv1alpha2.ReasonSourceDenied, condition.Reason)
+		assert.Contains(t, condition.Message, "https://example.com/repo.git")
+	}
+}
+
 func TestUpdateKptfileFields(t *testing.T) {
 	mockClient := mockclient.NewMockClient(t)
 
@@ -30,7 +30,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -56,14 +55,16 @@ var allowedInjectionFields = []injectableField{
 }
 
 type injectionPoint struct {
-	file               string
-	object             *fn.KubeObject
-	conditionType      string
-	required           bool
-	errors             []string
-	injected           bool
-	injectedName       string
-	inClusterResources []*unstructured.Unstructured
+	file          string
+	object        *fn.KubeObject
+	conditionType string
+	required      bool
+	errors        []string
+	injected      bool
+	injectedName  string
+	// injectedFrom describes where the injected resource came from; empty
+	// means the namespace of the PackageVariant.
+	injectedFrom string
 }
 
 func newInjectionPoint(file string, object *fn.KubeObject) *injectionPoint {
@@ -192,39 +193,55 @@ func validateInjectionPoints(injectionPoints []*injectionPoint) error {
 }
 
 func injectResources(ctx context.Context, c client.Client, namespace string, injectors []api.InjectionSelector, injectionPoints []*injectionPoint) {
+	sources := newInjectionSources(c, namespace)
 	for _, ip := range injectionPoints {
 		if len(injectors) == 0 {
 			ip.errors = append(ip.errors, "no injectors defined")
 			continue
 		}
-		if err := ip.loadInClusterResources(ctx, c, namespace); err != nil {
-			ip.errors = append(ip.errors, err.Error())
-			continue
-		}
-		ip.inject(injectors)
+		ip.inject(ctx, sources, injectors)
 	}
 }
 
-func (ip *injectionPoint) inject(injectors []api.InjectionSelector) {
-	if len(ip.inClusterResources) == 0 {
-		ip.errors = append(ip.errors, fmt.Sprintf("no in-cluster resources of type %s.%s", ip.object.GetAPIVersion(), ip.object.GetKind()))
-		return
-	}
-
+func (ip *injectionPoint) inject(ctx context.Context, sources *injectionSources, injectors []api.InjectionSelector) {
+	matched, candidates := false, 0
 	for _, injector := range injectors {
-		u := ip.matchSelector(injector)
+		if !ip.matchesInjector(injector) {
+			continue
+		}
+
+		resources, from, err := sources.load(ctx, injector, ip.object)
+		if err != nil {
+			ip.errors = append(ip.errors, err.Error())
+			continue
+		}
+		matched = true
+		candidates += len(resources)
+
+		u, err := sources.selectResource(ctx, injector, resources)
+		if err != nil {
+			ip.errors = append(ip.errors, err.Error())
+			continue
+		}
 		if u == nil {
 			continue
 		}
 
-		ip.injectResource(u)
-		break
+		ip.injectResource(u, from)
+		return
+	}
+
+	// A required injection point whose injectors found nothing to choose from
+	// gets a more specific message than the default "no resource matched".
+	if ip.required && matched && candidates == 0 && len(ip.errors) == 0 {
+		ip.errors = append(ip.errors, fmt.Sprintf("no in-cluster resources of type %s.%s", ip.object.GetAPIVersion(), ip.object.GetKind()))
 	}
 }
 
-func (ip *injectionPoint) injectResource(u *unstructured.Unstructured) {
+func (ip *injectionPoint) injectResource(u *unstructured.Unstructured, from string) {
 	ip.injected = true
 	ip.injectedName = u.GetName()
+	ip.injectedFrom = from
 
 	g, _ := fn.ParseGroupVersion(u.GetAPIVersion())
 
@@ -259,31 +276,19 @@ func (ip *injectionPoint) injectResource(u *unstructured.Unstructured) {
 	}
 }
 
-func (ip *injectionPoint) matchSelector(injector api.InjectionSelector) *unstructured.Unstructured {
-	// Check if this selector matches this in-package object
+// matchesInjector checks if the selector applies to this in-package object
+func (ip *injectionPoint) matchesInjector(injector api.InjectionSelector) bool {
 	g, v := fn.ParseGroupVersion(ip.object.GetAPIVersion())
 	if injector.Group != nil && *injector.Group != g {
-		return nil
+		return false
 	}
 	if injector.Version != nil && *injector.Version != v {
-		return nil
+		return false
 	}
 	if injector.Kind != nil && *injector.Kind != ip.object.GetKind() {
-		return nil
+		return false
 	}
-
-	// This injector applies to this in-package object
-	// So, check the in-cluster objects for a match
-	// We already know the GVK matches, we just need to check
-	// the names
-
-	for _, u := range ip.inClusterResources {
-		if u.GetName() == injector.Name {
-			return u
-		}
-	}
-
-	return nil
+	return true
 }
 
 func setInjectionPointConditionsAndGates(kptfileKubeObject *fn.KubeObject, injectionPoints []*injectionPoint) error {
@@ -323,7 +328,11 @@ func setInjectionPointConditionsAndGates(kptfileKubeObject *fn.KubeObject, injec
 		if ip.injected {
 			condStatus = "True"
 			condReason = "ConfigInjected"
-			condMessage = fmt.Sprintf("injected resource %q from cluster", ip.injectedName)
+			from := ip.injectedFrom
+			if from == "" {
+				from = "cluster"
+			}
+			condMessage = fmt.Sprintf("injected resource %q from %s", ip.injectedName, from)
 		}
 
 		meta.SetStatusCondition(&conditions, metav1.Condition{
@@ -405,24 +414,3 @@ func includeFile(path string) bool {
 	}
 	return false
 }
-
-func (ip *injectionPoint) loadInClusterResources(ctx context.Context, c client.Client, namespace string) error {
-	uList := &unstructured.UnstructuredList{}
-	group, version := fn.ParseGroupVersion(ip.object.GetAPIVersion())
-	uList.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   group,
-		Version: version,
-		Kind:    ip.object.GetKind(),
-	})
-
-	opts := []client.ListOption{client.InNamespace(namespace)}
-	if err := c.List(ctx, uList, opts...); err != nil {
-		return err
-	}
-
-	for _, u := range uList.Items {
-		ip.inClusterResources = append(ip.inClusterResources, u.DeepCopy())
-	}
-
-	return nil
-}
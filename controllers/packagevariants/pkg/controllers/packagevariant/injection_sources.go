@@ -0,0 +1,260 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packagevariant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kptdev/krm-functions-sdk/go/fn"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	api "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// injectionSources loads the resources that injectors select from: in-cluster
+// resources in the PackageVariant's namespace or, when granted, in another
+// namespace, and resources inside Published package revisions. Each source is
+// read at most once per reconciliation.
+type injectionSources struct {
+	c         client.Client
+	namespace string
+
+	cluster  map[clusterSourceKey][]*unstructured.Unstructured
+	packages map[string]*packageSource
+	prLists  map[string]*porchapi.PackageRevisionList
+	grants   map[string][]api.InjectionGrant
+}
+
+type clusterSourceKey struct {
+	namespace string
+	gvk       schema.GroupVersionKind
+}
+
+// packageSource holds the parsed resources of a package revision.
+type packageSource struct {
+	name      string
+	resources []*unstructured.Unstructured
+}
+
+func newInjectionSources(c client.Client, namespace string) *injectionSources {
+	return &injectionSources{
+		c:         c,
+		namespace: namespace,
+		cluster:   make(map[clusterSourceKey][]*unstructured.Unstructured),
+		packages:  make(map[string]*packageSource),
+		prLists:   make(map[string]*porchapi.PackageRevisionList),
+		grants:    make(map[string][]api.InjectionGrant),
+	}
+}
+
+func (s *injectionSources) sourceNamespace(injector api.InjectionSelector) string {
+	if injector.Namespace != "" {
+		return injector.Namespace
+	}
+	return s.namespace
+}
+
+// load returns the resources of the same kind as the injection point object that
+// the injector can select from, and a description of their source for the
+// injection condition.
+func (s *injectionSources) load(ctx context.Context, injector api.InjectionSelector, object *fn.KubeObject) ([]*unstructured.Unstructured, string, error) {
+	group, version := fn.ParseGroupVersion(object.GetAPIVersion())
+	gvk := schema.GroupVersionKind{Group: group, Version: version, Kind: object.GetKind()}
+	namespace := s.sourceNamespace(injector)
+
+	if injector.FromPackage != nil {
+		pkg, err := s.loadPackage(ctx, namespace, injector.FromPackage)
+		if err != nil {
+			return nil, "", err
+		}
+		var resources []*unstructured.Unstructured
+		for _, u := range pkg.resources {
+			if u.GroupVersionKind() == gvk {
+				resources = append(resources, u)
+			}
+		}
+		from := fmt.Sprintf("package revision %q", pkg.name)
+		if namespace != s.namespace {
+			from += fmt.Sprintf(" in namespace %q", namespace)
+		}
+		return resources, from, nil
+	}
+
+	resources, err := s.loadCluster(ctx, namespace, gvk)
+	if err != nil {
+		return nil, "", err
+	}
+	from := ""
+	if namespace != s.namespace {
+		from = fmt.Sprintf("namespace %q", namespace)
+	}
+	return resources, from, nil
+}
+
+func (s *injectionSources) loadCluster(ctx context.Context, namespace string, gvk schema.GroupVersionKind) ([]*unstructured.Unstructured, error) {
+	key := clusterSourceKey{namespace: namespace, gvk: gvk}
+	if resources, ok := s.cluster[key]; ok {
+		return resources, nil
+	}
+
+	uList := &unstructured.UnstructuredList{}
+	uList.SetGroupVersionKind(gvk)
+	if err := s.c.List(ctx, uList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	var resources []*unstructured.Unstructured
+	for _, u := range uList.Items {
+		resources = append(resources, u.DeepCopy())
+	}
+	s.cluster[key] = resources
+	return resources, nil
+}
+
+func (s *injectionSources) loadPackage(ctx context.Context, namespace string, ref *api.InjectionPackageRef) (*packageSource, error) {
+	key := fmt.Sprintf("%s/%s/%s/%d", namespace, ref.Repo, ref.Package, ref.Revision)
+	if pkg, ok := s.packages[key]; ok {
+		return pkg, nil
+	}
+
+	prList, ok := s.prLists[namespace]
+	if !ok {
+		prList = &porchapi.PackageRevisionList{}
+		if err := s.c.List(ctx, prList, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		s.prLists[namespace] = prList
+	}
+
+	var source *porchapi.PackageRevision
+	for i := range prList.Items {
+		pr := &prList.Items[i]
+		if pr.Spec.RepositoryName != ref.Repo || pr.Spec.PackageName != ref.Package || !pr.IsPublished() {
+			continue
+		}
+		if ref.Revision != 0 {
+			if pr.Spec.Revision == ref.Revision {
+				source = pr
+				break
+			}
+			continue
+		}
+		if source == nil || pr.Spec.Revision > source.Spec.Revision {
+			source = pr
+		}
+	}
+	if source == nil {
+		return nil, fmt.Errorf("could not find published package revision of package %q in repository %q (revision %d)",
+			ref.Package, ref.Repo, ref.Revision)
+	}
+
+	var prr porchapi.PackageRevisionResources
+	if err := s.c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: source.Name}, &prr); err != nil {
+		return nil, err
+	}
+	files, err := parseFiles(&prr)
+	if err != nil {
+		return nil, fmt.Errorf("package revision %q: %w", source.Name, err)
+	}
+
+	pkg := &packageSource{name: source.Name}
+	for _, kos := range files {
+		for _, ko := range kos {
+			u := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal([]byte(ko.String()), &u.Object); err != nil {
+				return nil, fmt.Errorf("package revision %q: %w", source.Name, err)
+			}
+			pkg.resources = append(pkg.resources, u)
+		}
+	}
+	s.packages[key] = pkg
+	return pkg, nil
+}
+
+// selectResource returns the first resource, in name order, that matches the
+// name and label selector of the injector and that the PackageVariant is
+// allowed to inject.
+func (s *injectionSources) selectResource(ctx context.Context, injector api.InjectionSelector, resources []*unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	selector := labels.Everything()
+	if injector.Selector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(injector.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid injector selector: %w", err)
+		}
+	}
+
+	var matches []*unstructured.Unstructured
+	for _, u := range resources {
+		if injector.Name != "" && u.GetName() != injector.Name {
+			continue
+		}
+		if !selector.Matches(labels.Set(u.GetLabels())) {
+			continue
+		}
+		matches = append(matches, u)
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].GetName() < matches[j].GetName() })
+
+	namespace := s.sourceNamespace(injector)
+	for _, u := range matches {
+		if namespace == s.namespace {
+			return u, nil
+		}
+		allowed, err := s.granted(ctx, namespace, u)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			return u, nil
+		}
+	}
+
+	if len(matches) > 0 {
+		return nil, fmt.Errorf("no InjectionGrant in namespace %q allows injecting %s %q into namespace %q",
+			namespace, matches[0].GetKind(), matches[0].GetName(), s.namespace)
+	}
+	return nil, nil
+}
+
+// granted checks if an InjectionGrant in the namespace allows the PackageVariant's
+// namespace to inject the resource.
+func (s *injectionSources) granted(ctx context.Context, namespace string, u *unstructured.Unstructured) (bool, error) {
+	grants, ok := s.grants[namespace]
+	if !ok {
+		var grantList api.InjectionGrantList
+		if err := s.c.List(ctx, &grantList, client.InNamespace(namespace)); err != nil {
+			return false, err
+		}
+		grants = grantList.Items
+		s.grants[namespace] = grants
+	}
+
+	group, _ := fn.ParseGroupVersion(u.GetAPIVersion())
+	for i := range grants {
+		if grants[i].Allows(s.namespace, group, u.GetKind(), u.GetName()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
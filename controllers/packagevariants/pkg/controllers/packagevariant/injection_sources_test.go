@@ -0,0 +1,258 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packagevariant
+
+import (
+	"testing"
+
+	"github.com/kptdev/krm-functions-sdk/go/fn"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	api "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const injectionPointYAML = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: endpoints
+  annotations:
+    kpt.dev/config-injection: required
+data:
+  db: example
+`
+
+func newConfigMap(namespace, name, db string, labels map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Data:       map[string]string{"db": db},
+	}
+}
+
+func newSourcesClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, porchapi.AddToScheme(scheme))
+	require.NoError(t, api.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newTestInjectionPoint(t *testing.T) *injectionPoint {
+	ko, err := fn.ParseKubeObject([]byte(injectionPointYAML))
+	require.NoError(t, err)
+	return newInjectionPoint("endpoints.yaml", ko)
+}
+
+func TestInjectResourcesFromSources(t *testing.T) {
+	valuesPR := func(name string, revision int, lifecycle porchapi.PackageRevisionLifecycle) *porchapi.PackageRevision {
+		return &porchapi.PackageRevision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: porchapi.PackageRevisionSpec{
+				RepositoryName: "values",
+				PackageName:    "shared",
+				Revision:       revision,
+				Lifecycle:      lifecycle,
+			},
+		}
+	}
+	valuesPRR := func(name, db string) *porchapi.PackageRevisionResources {
+		return &porchapi.PackageRevisionResources{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: porchapi.PackageRevisionResourcesSpec{
+				Resources: map[string]string{
+					"endpoints.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: shared-endpoints\ndata:\n  db: " + db + "\n",
+				},
+			},
+		}
+	}
+	grant := &api.InjectionGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "allow-default"},
+		Spec: api.InjectionGrantSpec{
+			From: []api.InjectionGrantFrom{{Namespace: "default"}},
+			To:   []api.InjectionGrantTo{{Group: "", Kind: "ConfigMap", Name: ptr.To("global-endpoints")}},
+		},
+	}
+
+	testCases := map[string]struct {
+		objects      []client.Object
+		injector     api.InjectionSelector
+		expectedDB   string
+		expectedFrom string
+		expectedErr  string
+	}{
+		"label selector picks first match by name": {
+			objects: []client.Object{
+				newConfigMap("default", "west", "db.west", map[string]string{"region": "west"}),
+				newConfigMap("default", "east-b", "db.east-b", map[string]string{"region": "east"}),
+				newConfigMap("default", "east-a", "db.east-a", map[string]string{"region": "east"}),
+			},
+			injector: api.InjectionSelector{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "east"}},
+			},
+			expectedDB: "db.east-a",
+		},
+		"other namespace with grant": {
+			objects: []client.Object{
+				newConfigMap("shared", "global-endpoints", "db.global", nil),
+				grant,
+			},
+			injector: api.InjectionSelector{
+				Name:      "global-endpoints",
+				Namespace: "shared",
+			},
+			expectedDB:   "db.global",
+			expectedFrom: `namespace "shared"`,
+		},
+		"other namespace without grant": {
+			objects: []client.Object{
+				newConfigMap("shared", "other-endpoints", "db.other", nil),
+				grant,
+			},
+			injector: api.InjectionSelector{
+				Name:      "other-endpoints",
+				Namespace: "shared",
+			},
+			expectedErr: `no InjectionGrant in namespace "shared" allows injecting ConfigMap "other-endpoints" into namespace "default"`,
+		},
+		"latest published package revision": {
+			objects: []client.Object{
+				valuesPR("values.shared.v1", 1, porchapi.PackageRevisionLifecyclePublished),
+				valuesPR("values.shared.v2", 2, porchapi.PackageRevisionLifecyclePublished),
+				valuesPR("values.shared.draft", 0, porchapi.PackageRevisionLifecycleDraft),
+				valuesPRR("values.shared.v1", "db.v1"),
+				valuesPRR("values.shared.v2", "db.v2"),
+			},
+			injector: api.InjectionSelector{
+				Name:        "shared-endpoints",
+				FromPackage: &api.InjectionPackageRef{Repo: "values", Package: "shared"},
+			},
+			expectedDB:   "db.v2",
+			expectedFrom: `package revision "values.shared.v2"`,
+		},
+		"specific package revision": {
+			objects: []client.Object{
+				valuesPR("values.shared.v1", 1, porchapi.PackageRevisionLifecyclePublished),
+				valuesPR("values.shared.v2", 2, porchapi.PackageRevisionLifecyclePublished),
+				valuesPRR("values.shared.v1", "db.v1"),
+				valuesPRR("values.shared.v2", "db.v2"),
+			},
+			injector: api.InjectionSelector{
+				Name:        "shared-endpoints",
+				FromPackage: &api.InjectionPackageRef{Repo: "values", Package: "shared", Revision: 1},
+			},
+			expectedDB:   "db.v1",
+			expectedFrom: `package revision "values.shared.v1"`,
+		},
+		"package revision not published": {
+			objects: []client.Object{
+				valuesPR("values.shared.draft", 0, porchapi.PackageRevisionLifecycleDraft),
+			},
+			injector: api.InjectionSelector{
+				Name:        "shared-endpoints",
+				FromPackage: &api.InjectionPackageRef{Repo: "values", Package: "shared"},
+			},
+			expectedErr: `could not find published package revision of package "shared" in repository "values" (revision 0)`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			c := newSourcesClient(t, tc.objects...)
+			ip := newTestInjectionPoint(t)
+
+			injectResources(t.Context(), c, "default", []api.InjectionSelector{tc.injector}, []*injectionPoint{ip})
+
+			if tc.expectedErr != "" {
+				assert.False(t, ip.injected)
+				assert.Contains(t, ip.errors, tc.expectedErr)
+				return
+			}
+			require.Empty(t, ip.errors)
+			require.True(t, ip.injected)
+			assert.Equal(t, tc.expectedFrom, ip.injectedFrom)
+			db, _, err := ip.object.NestedString("data", "db")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDB, db)
+		})
+	}
+}
+
+func TestInjectNoCandidates(t *testing.T) {
+	optionalIP := func(t *testing.T) *injectionPoint {
+		ip := newTestInjectionPoint(t)
+		ip.required = false
+		return ip
+	}
+	otherKind := api.InjectionSelector{Kind: ptr.To("Secret"), Name: "endpoints"}
+	configMap := api.InjectionSelector{Name: "endpoints"}
+
+	testCases := map[string]struct {
+		injectionPoint func(t *testing.T) *injectionPoint
+		injector       api.InjectionSelector
+		expectedErrors []string
+	}{
+		"required, no resources of the type": {
+			injectionPoint: newTestInjectionPoint,
+			injector:       configMap,
+			expectedErrors: []string{"no in-cluster resources of type v1.ConfigMap"},
+		},
+		"required, no injector for the type": {
+			injectionPoint: newTestInjectionPoint,
+			injector:       otherKind,
+		},
+		"optional, no resources of the type": {
+			injectionPoint: optionalIP,
+			injector:       configMap,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			c := newSourcesClient(t)
+			ip := tc.injectionPoint(t)
+
+			injectResources(t.Context(), c, "default", []api.InjectionSelector{tc.injector}, []*injectionPoint{ip})
+
+			assert.False(t, ip.injected)
+			assert.Equal(t, tc.expectedErrors, ip.errors)
+		})
+	}
+}
+
+func TestInjectionGrantAllows(t *testing.T) {
+	grant := &api.InjectionGrant{
+		Spec: api.InjectionGrantSpec{
+			From: []api.InjectionGrantFrom{{Namespace: "team-a"}},
+			To: []api.InjectionGrantTo{
+				{Group: "", Kind: "ConfigMap", Name: ptr.To("endpoints")},
+				{Group: "hr.example.com", Kind: "Team"},
+			},
+		},
+	}
+
+	assert.True(t, grant.Allows("team-a", "", "ConfigMap", "endpoints"))
+	assert.False(t, grant.Allows("team-a", "", "ConfigMap", "secrets"))
+	assert.True(t, grant.Allows("team-a", "hr.example.com", "Team", "any"))
+	assert.False(t, grant.Allows("team-b", "", "ConfigMap", "endpoints"))
+
+	grant.Spec.To = nil
+	assert.True(t, grant.Allows("team-a", "apps", "Deployment", "any"))
+}
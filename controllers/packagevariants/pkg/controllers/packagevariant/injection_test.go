@@ -467,6 +467,31 @@ func TestSetInjectionPointConditionsAndGates(t *testing.T) {
 				},
 			},
 		},
+		"optional, injected from package revision": {
+			initialKptfile: &kptfilev1.KptFile{},
+			injectionPoints: []*injectionPoint{
+				{
+					file:          "file.yaml",
+					required:      false,
+					conditionType: "config.injection.ConfigMap.foo",
+					injected:      true,
+					injectedName:  "my-injected-resource",
+					injectedFrom:  `package revision "values.shared.v1"`,
+				},
+			},
+			expectedKptfile: &kptfilev1.KptFile{
+				Status: &kptfilev1.Status{
+					Conditions: []kptfilev1.Condition{
+						{
+							Type:    "config.injection.ConfigMap.foo",
+							Status:  "True",
+							Reason:  "ConfigInjected",
+							Message: "injected resource \"my-injected-resource\" from package revision \"values.shared.v1\"",
+						},
+					},
+				},
+			},
+		},
 		"multiple optional": {
 			initialKptfile: &kptfilev1.KptFile{},
 			injectionPoints: []*injectionPoint{
@@ -72,6 +72,7 @@ func (r *PackageVariantReconciler) Name() string { return reconcilerName }
 //+kubebuilder:rbac:groups=porch.kpt.dev,resources=packagerevisions,verbs=create;delete;get;list;patch;update;watch
 //+kubebuilder:rbac:groups=porch.kpt.dev,resources=packagerevisionresources,verbs=create;delete;get;list;patch;update;watch
 //+kubebuilder:rbac:groups=config.porch.kpt.dev,resources=repositories,verbs=get;list;watch
+//+kubebuilder:rbac:groups=config.porch.kpt.dev,resources=injectiongrants,verbs=get;list;watch
 
 // Reconcile implements the main kubernetes reconciliation loop.
 func (r *PackageVariantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -227,9 +228,22 @@ func validatePackageVariant(pv *configapi.PackageVariant) []string {
 	}
 	if len(pv.Spec.Injectors) > 0 {
 		for i, injector := range pv.Spec.Injectors {
-			if injector.Name == "" {
+			if injector.Name == "" && injector.Selector == nil {
 				allErrs = append(allErrs, fmt.Sprintf("spec.injectors[%d].name must not be empty", i))
 			}
+			if injector.Selector != nil {
+				if _, err := metav1.LabelSelectorAsSelector(injector.Selector); err != nil {
+					allErrs = append(allErrs, fmt.Sprintf("spec.injectors[%d].selector is invalid: %s", i, err.Error()))
+				}
+			}
+			if fp := injector.FromPackage; fp != nil {
+				if fp.Repo == "" || fp.Package == "" {
+					allErrs = append(allErrs, fmt.Sprintf("spec.injectors[%d].fromPackage must specify repo and package", i))
+				}
+				if fp.Revision < 0 {
+					allErrs = append(allErrs, fmt.Sprintf("spec.injectors[%d].fromPackage.revision must not be negative", i))
+				}
+			}
 		}
 	}
 	return allErrs
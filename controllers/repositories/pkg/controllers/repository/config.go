@@ -31,6 +31,9 @@ const (
 	defaultCacheDirectory             = "/cache"
 	defaultGoGitRepoCacheSize         = 8              // MiB
 	defaultGoGitCacheMaxFileSize      = 1 * 1024 * 512 // bytes (512 KiB)
+	defaultShardNamespace             = "porch-system"
+	defaultShardLeaseDuration         = 30 * time.Second
+	minShardLeaseDuration             = 3 * time.Second
 )
 
 // InitDefaults initializes default values for standalone controller
@@ -45,6 +48,8 @@ func (r *RepositoryReconciler) InitDefaults() {
 	r.cacheDirectory = defaultCacheDirectory
 	r.GoGitRepoCacheSize = defaultGoGitRepoCacheSize
 	r.GoGitCacheMaxFileSize = defaultGoGitCacheMaxFileSize
+	r.ShardNamespace = defaultShardNamespace
+	r.ShardLeaseDuration = defaultShardLeaseDuration
 	r.validateConfig()
 }
 
@@ -63,6 +68,10 @@ func (r *RepositoryReconciler) BindFlags(prefix string, flags *flag.FlagSet) {
 	flags.BoolVar(&r.PushDraftsToGit, prefix+"push-drafts-to-git", false, "Push draft and proposed branches to git when using DB cache")
 	flags.IntVar(&r.GoGitRepoCacheSize, prefix+"gogit-repo-cache-size", defaultGoGitRepoCacheSize, "Size of the in-memory cache for git repositories when using gogit (in MiB)")
 	flags.Int64Var(&r.GoGitCacheMaxFileSize, prefix+"gogit-cache-max-file-size", defaultGoGitCacheMaxFileSize, "Maximum file size (in bytes) that will be read into the in-memory cache for git repositories when using gogit; files larger than this will be streamed from disk")
+	flags.BoolVar(&r.ShardingEnabled, prefix+"sharding", false, "Partition repositories across controller replicas using lease-based membership (requires the DB cache)")
+	flags.StringVar(&r.ShardIdentity, prefix+"shard-identity", "", "Identity of this replica in the repository shard membership (defaults to the hostname)")
+	flags.StringVar(&r.ShardNamespace, prefix+"shard-namespace", defaultShardNamespace, "Namespace of the repository shard membership leases")
+	flags.DurationVar(&r.ShardLeaseDuration, prefix+"shard-lease-duration", defaultShardLeaseDuration, "Duration after which an unrenewed repository shard lease expires and its repositories are rebalanced")
 }
 
 // validateConfig ensures configuration values are valid
@@ -85,6 +94,12 @@ func (r *RepositoryReconciler) validateConfig() {
 	if r.RepoOperationRetryAttempts <= 0 {
 		r.RepoOperationRetryAttempts = defaultRepoOperationRetryAttempts
 	}
+	if r.ShardNamespace == "" {
+		r.ShardNamespace = defaultShardNamespace
+	}
+	if r.ShardLeaseDuration <= 0 {
+		r.ShardLeaseDuration = defaultShardLeaseDuration
+	}
 }
 
 // LogConfig logs the controller configuration
@@ -103,7 +118,11 @@ func (r *RepositoryReconciler) LogConfig(log interface {
 		"createV1Alpha2Rpkg", r.CreateV1Alpha2Rpkg,
 		"pushDraftsToGit", r.PushDraftsToGit,
 		"goGitRepoCacheSize", r.GoGitRepoCacheSize,
-		"goGitCacheMaxFileSize", r.GoGitCacheMaxFileSize)
+		"goGitCacheMaxFileSize", r.GoGitCacheMaxFileSize,
+		"shardingEnabled", r.ShardingEnabled,
+		"shardIdentity", r.ShardIdentity,
+		"shardNamespace", r.ShardNamespace,
+		"shardLeaseDuration", r.ShardLeaseDuration)
 
 	if r.HealthCheckFrequency < defaultHealthCheckFrequency {
 		log.Info("Health check frequency is lower than recommended default",
@@ -46,6 +46,29 @@ func TestBindFlags(t *testing.T) {
 	assert.Equal(t, 100, r.MaxConcurrentReconciles)
 }
 
+func TestBindShardingFlags(t *testing.T) {
+	r := &RepositoryReconciler{}
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	r.BindFlags("repo-", flags)
+	assert.False(t, r.ShardingEnabled)
+	assert.Equal(t, "porch-system", r.ShardNamespace)
+	assert.Equal(t, 30*time.Second, r.ShardLeaseDuration)
+
+	err := flags.Parse([]string{
+		"--repo-sharding",
+		"--repo-shard-identity=replica-1",
+		"--repo-shard-namespace=porch",
+		"--repo-shard-lease-duration=1m",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, r.ShardingEnabled)
+	assert.Equal(t, "replica-1", r.ShardIdentity)
+	assert.Equal(t, "porch", r.ShardNamespace)
+	assert.Equal(t, time.Minute, r.ShardLeaseDuration)
+}
+
 type mockLogger struct {
 	infoCalls [][]interface{}
 }
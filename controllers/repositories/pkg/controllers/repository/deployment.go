@@ -0,0 +1,129 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/util/deploymentstatus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const fieldManagerDeploymentStatus = "repository-controller-deployment"
+
+// DeploymentStatusReconciler aggregates the PackageRevisionDeploymentStatus objects
+// reported by GitOps agents on the status of deployment repositories. It runs as a
+// separate controller so that agent reports never trigger a repository sync.
+type DeploymentStatusReconciler struct {
+	client.Client
+
+	// owns reports whether this replica owns a repository when sharding is
+	// enabled; nil means it owns all repositories.
+	owns func(namespace, name string) bool
+}
+
+//+kubebuilder:rbac:groups=config.porch.kpt.dev,resources=packagerevisiondeploymentstatuses,verbs=get;list;watch
+
+// Reconcile recomputes status.deployment of a repository from the reports for its
+// package revisions.
+func (r *DeploymentStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// The replica that syncs the repository also summarises its reports
+	if r.owns != nil && !r.owns(req.Namespace, req.Name) {
+		return ctrl.Result{}, nil
+	}
+
+	var repo api.Repository
+	if err := r.Get(ctx, req.NamespacedName, &repo); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !repo.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	var status *api.RepositoryDeploymentStatus
+	if repo.Spec.Deployment {
+		var reports api.PackageRevisionDeploymentStatusList
+		if err := r.List(ctx, &reports, client.InNamespace(repo.Namespace),
+			client.MatchingFields{deploymentstatus.RepositoryIndex: repo.Name}); err != nil {
+			return ctrl.Result{}, err
+		}
+		status = deploymentstatus.ForRepository(reports.Items)
+	}
+
+	if equality.Semantic.DeepEqual(status, repo.Status.Deployment) {
+		return ctrl.Result{}, nil
+	}
+	log.FromContext(ctx).V(1).Info("Updating repository deployment status", "repository", repo.Name)
+
+	// The field is applied with its own field manager, so the repository status
+	// written by the sync loop is left alone and a nil status removes the field.
+	patch := &api.Repository{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: api.TypeRepository.APIVersion(),
+			Kind:       api.TypeRepository.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      repo.Name,
+			Namespace: repo.Namespace,
+		},
+		Status: api.RepositoryStatus{
+			Deployment: status,
+		},
+	}
+	return ctrl.Result{}, r.Status().Patch(ctx, patch, client.Apply, client.FieldOwner(fieldManagerDeploymentStatus), client.ForceOwnership)
+}
+
+// SetupWithManager registers the controller. It is triggered by spec changes of
+// repositories and by any change to a PackageRevisionDeploymentStatus.
+func (r *DeploymentStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &api.PackageRevisionDeploymentStatus{}, deploymentstatus.RepositoryIndex,
+		func(obj client.Object) []string {
+			if repoName := deploymentstatus.RepositoryOf(obj.(*api.PackageRevisionDeploymentStatus)); repoName != "" {
+				return []string{repoName}
+			}
+			return nil
+		}); err != nil {
+		return fmt.Errorf("failed to index field %s: %w", deploymentstatus.RepositoryIndex, err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&api.Repository{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&api.PackageRevisionDeploymentStatus{}, handler.EnqueueRequestsFromMapFunc(mapDeploymentStatusToRepository)).
+		Named("repository-deployment").
+		Complete(r)
+}
+
+func mapDeploymentStatusToRepository(_ context.Context, obj client.Object) []reconcile.Request {
+	report, ok := obj.(*api.PackageRevisionDeploymentStatus)
+	if !ok {
+		return nil
+	}
+	repoName := deploymentstatus.RepositoryOf(report)
+	if repoName == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: report.Namespace, Name: repoName}}}
+}
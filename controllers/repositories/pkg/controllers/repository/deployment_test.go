@@ -0,0 +1,146 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/util/deploymentstatus"
+	mockclient "github.com/kptdev/porch/test/mockery/mocks/external/sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func deploymentReport(prName, target string, state configapi.DeploymentState) configapi.PackageRevisionDeploymentStatus {
+	return configapi.PackageRevisionDeploymentStatus{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: prName + "-" + target},
+		Spec: configapi.PackageRevisionDeploymentStatusSpec{
+			PackageRevision: prName,
+			Target:          target,
+			State:           state,
+		},
+	}
+}
+
+func setupDeploymentStatusTest(t *testing.T, repo *configapi.Repository, reports ...configapi.PackageRevisionDeploymentStatus) (*DeploymentStatusReconciler, *mockclient.MockClient) {
+	mockClient := mockclient.NewMockClient(t)
+	mockClient.EXPECT().Get(mock.Anything, types.NamespacedName{Namespace: repo.Namespace, Name: repo.Name}, mock.AnythingOfType("*v1alpha1.Repository")).
+		RunAndReturn(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			repo.DeepCopyInto(obj.(*configapi.Repository))
+			return nil
+		})
+	mockClient.EXPECT().List(mock.Anything, mock.AnythingOfType("*v1alpha1.PackageRevisionDeploymentStatusList"), mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			// Emulate the repository index of the cache
+			var listOpts client.ListOptions
+			listOpts.ApplyOptions(opts)
+			require.Equal(t, deploymentstatus.RepositoryIndex+"="+repo.Name, listOpts.FieldSelector.String())
+			var items []configapi.PackageRevisionDeploymentStatus
+			for i := range reports {
+				if deploymentstatus.RepositoryOf(&reports[i]) == repo.Name {
+					items = append(items, reports[i])
+				}
+			}
+			list.(*configapi.PackageRevisionDeploymentStatusList).Items = items
+			return nil
+		}).Maybe()
+	return &DeploymentStatusReconciler{Client: mockClient}, mockClient
+}
+
+func TestDeploymentStatusReconcile(t *testing.T) {
+	repo := createTestRepo("deploy", "test-ns")
+	repo.Spec.Deployment = true
+
+	r, mockClient := setupDeploymentStatusTest(t, repo,
+		deploymentReport("deploy.app.v1", "edge-1", configapi.DeploymentStateApplied),
+		deploymentReport("deploy.app.v1", "edge-2", configapi.DeploymentStateDrifted),
+		deploymentReport("deploy.db.v1", "edge-1", configapi.DeploymentStateApplied),
+		deploymentReport("other.app.v1", "edge-1", configapi.DeploymentStateFailed),
+	)
+	mockStatusWriter := mockclient.NewMockSubResourceWriter(t)
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(mock.Anything, mock.Anything, client.Apply, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+			patch := obj.(*configapi.Repository)
+			assert.Equal(t, &configapi.RepositoryDeploymentStatus{
+				PackageRevisions: 2,
+				Applied:          1,
+				Drifted:          1,
+			}, patch.Status.Deployment)
+			assert.Empty(t, patch.Status.Conditions)
+			return nil
+		}).Once()
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "deploy"}})
+	require.NoError(t, err)
+}
+
+func TestDeploymentStatusReconcileUnchanged(t *testing.T) {
+	repo := createTestRepo("deploy", "test-ns")
+	repo.Spec.Deployment = true
+	repo.Status.Deployment = &configapi.RepositoryDeploymentStatus{PackageRevisions: 1, Applied: 1}
+
+	// No patch is expected, since the status is already up to date.
+	r, _ := setupDeploymentStatusTest(t, repo,
+		deploymentReport("deploy.app.v1", "edge-1", configapi.DeploymentStateApplied),
+	)
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "deploy"}})
+	require.NoError(t, err)
+}
+
+func TestDeploymentStatusReconcileNotDeployment(t *testing.T) {
+	repo := createTestRepo("blueprints", "test-ns")
+	repo.Status.Deployment = &configapi.RepositoryDeploymentStatus{PackageRevisions: 1, Applied: 1}
+
+	// The repository is no longer a deployment repository, so the summary is removed.
+	r, mockClient := setupDeploymentStatusTest(t, repo)
+	mockStatusWriter := mockclient.NewMockSubResourceWriter(t)
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(mock.Anything, mock.Anything, client.Apply, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+			assert.Nil(t, obj.(*configapi.Repository).Status.Deployment)
+			return nil
+		}).Once()
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "blueprints"}})
+	require.NoError(t, err)
+}
+
+func TestDeploymentStatusReconcileOtherShard(t *testing.T) {
+	// The repository is owned by another replica, so the client is never called.
+	r := &DeploymentStatusReconciler{
+		Client: mockclient.NewMockClient(t),
+		owns:   func(_, _ string) bool { return false },
+	}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "deploy"}})
+	require.NoError(t, err)
+}
+
+func TestMapDeploymentStatusToRepository(t *testing.T) {
+	report := deploymentReport("deploy.app.v1", "edge-1", configapi.DeploymentStateApplied)
+	requests := mapDeploymentStatusToRepository(context.Background(), &report)
+	assert.Equal(t, []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "deploy"}}}, requests)
+
+	report.Spec.PackageRevision = ""
+	assert.Empty(t, mapDeploymentStatusToRepository(context.Background(), &report))
+}
@@ -203,6 +203,7 @@ func packageRevisionUpToDate(existing, desired *porchv1alpha2.PackageRevision) b
 	return repoOwnedLabelsMatch(existing.Labels, desired.Labels) &&
 		existing.Status.Deployment == desired.Status.Deployment &&
 		resourcesSizeBytesUpToDate(existing.Status.ResourcesSizeBytes, desired.Status.ResourcesSizeBytes) &&
+		subpackagesUpToDate(existing, desired) &&
 		equality.Semantic.DeepEqual(existing.Status.UpstreamLock, desired.Status.UpstreamLock) &&
 		equality.Semantic.DeepEqual(existing.Status.SelfLock, desired.Status.SelfLock)
 }
@@ -227,6 +228,16 @@ func resourcesSizeBytesUpToDate(existing, desired int64) bool {
 	return existing == desired
 }
 
+// subpackagesUpToDate returns true if the subpackages field doesn't need updating.
+// Subpackages are computed from the same resources as the size, so when the size
+// couldn't be computed we treat the existing value as up-to-date as well.
+func subpackagesUpToDate(existing, desired *porchv1alpha2.PackageRevision) bool {
+	if desired.Status.ResourcesSizeBytes == 0 {
+		return true
+	}
+	return equality.Semantic.DeepEqual(existing.Status.Subpackages, desired.Status.Subpackages)
+}
+
 // buildPackageRevision constructs a PackageRevision resource containing only
 // repo-controller-owned fields: identity, labels, ownerRef, locks, deployment.
 // Seed fields (lifecycle, publish metadata, Kptfile-derived) are applied
@@ -243,9 +254,10 @@ func buildPackageRevision(ctx context.Context, repo *configapi.Repository, pkgRe
 		// PackageConditions omitted — PR controller owns after first render.
 	}
 
-	// Calculate resource size for status.resourcesSizeBytes.
+	// Calculate resource size for status.resourcesSizeBytes and list embedded subpackages.
 	if prr, err := pkgRev.GetResources(ctx); err == nil && prr != nil && prr.Spec.Resources != nil {
 		status.ResourcesSizeBytes = repository.CalculateResourcesSize(prr.Spec.Resources)
+		status.Subpackages = repository.ToV1Alpha2Subpackages(repository.FindSubpackages(prr.Spec.Resources))
 	}
 
 	crd := &porchv1alpha2.PackageRevision{
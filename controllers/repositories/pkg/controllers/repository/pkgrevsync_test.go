@@ -253,6 +253,18 @@ func TestBuildPackageRevision(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, int64(0), crd.Status.ResourcesSizeBytes)
 	})
+
+	t.Run("Subpackages listed from resources", func(t *testing.T) {
+		pkgRev := newFakePkgRev("parent-pkg", "ws1", porchv1alpha2.PackageRevisionLifecycleDraft)
+		pkgRev.resources = map[string]string{
+			"Kptfile":       "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: parent-pkg\n",
+			"child/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: child\n",
+		}
+
+		crd, err := buildPackageRevision(ctx, repo, pkgRev, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []porchv1alpha2.Subpackage{{Path: "child", Name: "child"}}, crd.Status.Subpackages)
+	})
 }
 
 // --- Tests: packageRevisionUpToDate ---
@@ -332,6 +344,34 @@ func TestResourcesSizeBytesUpToDate(t *testing.T) {
 	}
 }
 
+func TestSubpackagesUpToDate(t *testing.T) {
+	withSubpackages := func(size int64, paths ...string) *porchv1alpha2.PackageRevision {
+		pr := &porchv1alpha2.PackageRevision{Status: porchv1alpha2.PackageRevisionStatus{ResourcesSizeBytes: size}}
+		for _, p := range paths {
+			pr.Status.Subpackages = append(pr.Status.Subpackages, porchv1alpha2.Subpackage{Path: p})
+		}
+		return pr
+	}
+
+	tests := []struct {
+		name     string
+		existing *porchv1alpha2.PackageRevision
+		desired  *porchv1alpha2.PackageRevision
+		expected bool
+	}{
+		{name: "both empty", existing: withSubpackages(10), desired: withSubpackages(10), expected: true},
+		{name: "equal", existing: withSubpackages(10, "a", "a/b"), desired: withSubpackages(10, "a", "a/b"), expected: true},
+		{name: "subpackage added", existing: withSubpackages(10, "a"), desired: withSubpackages(20, "a", "b"), expected: false},
+		{name: "subpackage removed", existing: withSubpackages(10, "a"), desired: withSubpackages(10), expected: false},
+		{name: "resources unknown - skip comparison", existing: withSubpackages(10, "a"), desired: withSubpackages(0), expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, subpackagesUpToDate(tt.existing, tt.desired))
+		})
+	}
+}
+
 // --- Tests: packageRevisionLabels ---
 
 func TestPackageRevisionLabels(t *testing.T) {
@@ -30,8 +30,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	api "github.com/kptdev/porch/api/porchconfig/v1alpha1"
 	cachetypes "github.com/kptdev/porch/pkg/cache/types"
@@ -64,14 +66,23 @@ type RepositoryReconciler struct {
 	GoGitRepoCacheSize    int   // In-memory cache size for git repositories (MiB)
 	GoGitCacheMaxFileSize int64 // Max file size (bytes) to read into the in-memory git cache
 
+	// Sharding configuration
+	ShardingEnabled    bool          // Partition repositories across controller replicas
+	ShardIdentity      string        // Identity of this replica (defaults to the hostname)
+	ShardNamespace     string        // Namespace of the shard membership Leases
+	ShardLeaseDuration time.Duration // How long a shard Lease stays valid without renewal
+
 	// Configuration (set via flags or defaults)
 	cacheType              string // Cache type (DB or CR)
 	cacheDirectory         string // Directory for git repository cache
 	useUserDefinedCaBundle bool   // Whether to use custom CA bundles from secrets
 
 	// Private implementation details
-	syncLimiter    chan struct{} // Semaphore for sync concurrency
-	coldStartRepos sync.Map      // Tracks repos that have synced since startup
+	syncLimiter    chan struct{}           // Semaphore for sync concurrency
+	coldStartRepos sync.Map                // Tracks repos that have synced since startup
+	shards         *shardMembership        // Shard membership, nil when sharding is disabled
+	syncLocks      *syncLocks              // Per-repository sync locks, nil when sharding is disabled
+	shardEvents    chan event.GenericEvent // Re-triggers repositories after a rebalance
 }
 
 //go:generate go run sigs.k8s.io/controller-tools/cmd/controller-gen@v0.21.0 rbac:headerFile=../../../../../scripts/boilerplate.yaml.txt,roleName=porch-controllers-repositories,year=$YEAR_GEN webhook paths="." output:rbac:artifacts:config=../../../config/rbac
@@ -98,6 +109,14 @@ func (r *RepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, fmt.Errorf("cache not available - controller not properly initialized")
 	}
 
+	// Leave repositories assigned to other replicas alone. Requeue anyway so the
+	// repository is picked up again if a rebalance notification is missed.
+	if !r.ownsRepository(req.Namespace, req.Name) {
+		log.V(2).Info("Repository owned by another shard, skipping")
+		r.coldStartRepos.Delete(req.Namespace + "/" + req.Name)
+		return ctrl.Result{RequeueAfter: r.HealthCheckFrequency}, nil
+	}
+
 	// Get Repository
 	repo := &api.Repository{}
 	if err := r.Get(ctx, req.NamespacedName, repo); err != nil {
@@ -208,8 +227,20 @@ func (r *RepositoryReconciler) performFullSync(ctx context.Context, repo *api.Re
 	log := log.FromContext(ctx)
 	log.V(2).Info("Starting repository full sync")
 
+	// Replicas can briefly disagree on who owns a repository, so the sync
+	// itself is locked. The replica holding the lock reports the status.
+	unlock, locked, err := r.lockRepositorySync(ctx, repo)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !locked {
+		log.V(1).Info("Repository sync locked by another replica, skipping")
+		return ctrl.Result{RequeueAfter: r.HealthCheckFrequency}, nil
+	}
+
 	// Set sync in progress status
 	if err := r.updateRepoStatusWithBackoff(ctx, repo, RepositoryStatusSyncInProgress, nil, nil); err != nil {
+		unlock()
 		return ctrl.Result{}, err
 	}
 
@@ -217,6 +248,7 @@ func (r *RepositoryReconciler) performFullSync(ctx context.Context, repo *api.Re
 	select {
 	case r.syncLimiter <- struct{}{}:
 		go func() {
+			defer unlock()
 			defer func() {
 				<-r.syncLimiter
 				// Recover from panics to prevent goroutine death
@@ -246,6 +278,7 @@ func (r *RepositoryReconciler) performFullSync(ctx context.Context, repo *api.Re
 		}()
 	default:
 		// Too many syncs running
+		unlock()
 		retryAfter := 30 * time.Second
 		log.V(0).Info("Sync capacity exceeded, will retry",
 			"retryAfter", retryAfter,
@@ -316,9 +349,16 @@ func (r *RepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Client = mgr.GetClient()
 	log.Info("Client injected", "reconcilerPtr", fmt.Sprintf("%p", r))
 
-	// Watch Repository CRs only
+	if r.ShardingEnabled {
+		if err := r.setupSharding(mgr); err != nil {
+			return fmt.Errorf("failed to set up repository sharding: %w", err)
+		}
+		log.Info("Repository sharding enabled", "shard", r.ShardIdentity, "namespace", r.ShardNamespace)
+	}
+
+	// Watch Repository CRs, plus the rebalance notifications when sharded
 	// Note: Predicates only filter watch events, not requeues from RequeueAfter
-	err := ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&api.Repository{}, builder.WithPredicates(predicate.Funcs{
 			UpdateFunc: func(e event.UpdateEvent) bool {
 				// Only trigger on user-initiated spec changes (Generation increment)
@@ -332,15 +372,21 @@ func (r *RepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			DeleteFunc: func(e event.DeleteEvent) bool {
 				return true
 			},
-		})).
+		}))
+	if r.shardEvents != nil {
+		b = b.WatchesRawSource(source.Channel(r.shardEvents, &handler.EnqueueRequestForObject{}))
+	}
+	err := b.
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
 		}).
 		Named("repository").
 		Complete(r)
 
-	if err == nil {
-		log.V(1).Info("Repository controller successfully registered")
+	if err != nil {
+		return err
 	}
-	return err
+	log.V(1).Info("Repository controller successfully registered")
+
+	return (&DeploymentStatusReconciler{Client: r.Client, owns: r.ownsRepository}).SetupWithManager(mgr)
 }
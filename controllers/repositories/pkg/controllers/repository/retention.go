@@ -0,0 +1,142 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	api "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/repository"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// enforceRetentionPolicy removes the package revisions selected by the repository's
+// retention policy and returns the package revisions that remain, together with the
+// retention status to report. Revisions referenced as an upstream by another package
+// revision in the namespace are always kept. In dry-run mode nothing is removed.
+func (r *RepositoryReconciler) enforceRetentionPolicy(ctx context.Context, repo *api.Repository, repoHandle repository.Repository, pkgRevs []repository.PackageRevision) ([]repository.PackageRevision, *api.RetentionStatus) {
+	log := log.FromContext(ctx)
+	policy := repo.Spec.Retention
+
+	now := metav1.Now()
+	status := &api.RetentionStatus{
+		LastEnforcedTime: &now,
+		DryRun:           policy.DryRun,
+	}
+
+	removed := make(map[string]bool)
+	for _, pkgRev := range selectRetentionCandidates(ctx, policy, pkgRevs, now.Time) {
+		name := pkgRev.KubeObjectName()
+
+		downstream, err := r.Cache.FindAllUpstreamReferencesInRepositories(ctx, repo.Namespace, name)
+		if err != nil {
+			log.Error(err, "Failed to look up upstream references, keeping package revision", "name", name)
+			continue
+		}
+		if downstream != "" {
+			log.V(2).Info("Package revision is referenced as upstream, keeping it", "name", name, "downstream", downstream)
+			status.Protected = append(status.Protected, name)
+			continue
+		}
+
+		if !policy.DryRun {
+			if err := repoHandle.DeletePackageRevision(ctx, pkgRev); err != nil {
+				log.Error(err, "Failed to remove package revision selected by retention policy", "name", name)
+				continue
+			}
+			log.Info("Removed package revision selected by retention policy", "name", name)
+			removed[name] = true
+		}
+		status.Removed = append(status.Removed, name)
+	}
+
+	if len(removed) == 0 {
+		return pkgRevs, status
+	}
+	remaining := make([]repository.PackageRevision, 0, len(pkgRevs)-len(removed))
+	for _, pkgRev := range pkgRevs {
+		if !removed[pkgRev.KubeObjectName()] {
+			remaining = append(remaining, pkgRev)
+		}
+	}
+	return remaining, status
+}
+
+// selectRetentionCandidates returns the package revisions that the retention policy
+// selects for removal, without checking upstream references:
+//   - Published revisions older than the KeepPublished most recent revisions of their package
+//   - Drafts that have not been modified for longer than DraftTTL
+//
+// Branch-tracking revisions (revision -1) and revisions in other lifecycles are never selected.
+// The result is sorted by name.
+func selectRetentionCandidates(ctx context.Context, policy *api.RetentionPolicy, pkgRevs []repository.PackageRevision, now time.Time) []repository.PackageRevision {
+	if policy == nil {
+		return nil
+	}
+
+	var candidates []repository.PackageRevision
+	publishedByPkg := make(map[string][]repository.PackageRevision)
+
+	for _, pkgRev := range pkgRevs {
+		switch pkgRev.Lifecycle(ctx) {
+		case porchapi.PackageRevisionLifecyclePublished:
+			if pkgRev.Key().Revision <= 0 {
+				continue
+			}
+			pkgPath := pkgRev.Key().PkgKey.ToPkgPathname()
+			publishedByPkg[pkgPath] = append(publishedByPkg[pkgPath], pkgRev)
+
+		case porchapi.PackageRevisionLifecycleDraft:
+			if policy.DraftTTL == nil || policy.DraftTTL.Duration <= 0 {
+				continue
+			}
+			lastModified := lastModifiedTime(pkgRev)
+			if !lastModified.IsZero() && now.Sub(lastModified) > policy.DraftTTL.Duration {
+				candidates = append(candidates, pkgRev)
+			}
+		}
+	}
+
+	if policy.KeepPublished != nil {
+		keep := max(*policy.KeepPublished, 1)
+		for _, published := range publishedByPkg {
+			if len(published) <= keep {
+				continue
+			}
+			sort.Slice(published, func(i, j int) bool {
+				return published[i].Key().Revision > published[j].Key().Revision
+			})
+			candidates = append(candidates, published[keep:]...)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].KubeObjectName() < candidates[j].KubeObjectName()
+	})
+	return candidates
+}
+
+// lastModifiedTime returns the time the package revision was last modified, falling back
+// to its creation timestamp for backends that don't track commits.
+func lastModifiedTime(pkgRev repository.PackageRevision) time.Time {
+	if commitTime, _ := pkgRev.GetCommitInfo(); !commitTime.IsZero() {
+		return commitTime
+	}
+	return pkgRev.GetMeta().CreationTimestamp.Time
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	api "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	cachetypes "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/cache/types"
+	mockrepo "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/repository"
+)
+
+func newPublishedPkgRev(pkg string, revision int) *fakePackageRevision {
+	pr := newFakePkgRev(pkg, fmt.Sprintf("v%d", revision), porchv1alpha2.PackageRevisionLifecyclePublished)
+	pr.key.Revision = revision
+	return pr
+}
+
+func newDraftPkgRev(pkg, workspace string, lastModified time.Time) *fakePackageRevision {
+	pr := newFakePkgRev(pkg, workspace, porchv1alpha2.PackageRevisionLifecycleDraft)
+	pr.commitTime = lastModified
+	return pr
+}
+
+func names(pkgRevs []repository.PackageRevision) []string {
+	var result []string
+	for _, pr := range pkgRevs {
+		result = append(result, pr.KubeObjectName())
+	}
+	return result
+}
+
+func TestSelectRetentionCandidates(t *testing.T) {
+	ctx := t.Context()
+	now := time.Now()
+
+	pkgA1 := newPublishedPkgRev("pkg-a", 1)
+	pkgA2 := newPublishedPkgRev("pkg-a", 2)
+	pkgA3 := newPublishedPkgRev("pkg-a", 3)
+	pkgB1 := newPublishedPkgRev("pkg-b", 1)
+	pkgAMain := newFakePkgRev("pkg-a", "main", porchv1alpha2.PackageRevisionLifecyclePublished)
+	pkgAMain.key.Revision = -1
+	staleDraft := newDraftPkgRev("pkg-a", "stale", now.Add(-48*time.Hour))
+	freshDraft := newDraftPkgRev("pkg-a", "fresh", now.Add(-time.Hour))
+	proposed := newFakePkgRev("pkg-b", "proposed", porchv1alpha2.PackageRevisionLifecycleProposed)
+
+	all := []repository.PackageRevision{pkgA1, pkgA2, pkgA3, pkgB1, pkgAMain, staleDraft, freshDraft, proposed}
+
+	tests := []struct {
+		name     string
+		policy   *api.RetentionPolicy
+		expected []string
+	}{
+		{
+			name:     "nil policy selects nothing",
+			policy:   nil,
+			expected: nil,
+		},
+		{
+			name:     "empty policy selects nothing",
+			policy:   &api.RetentionPolicy{},
+			expected: nil,
+		},
+		{
+			name:     "keep last published revision per package",
+			policy:   &api.RetentionPolicy{KeepPublished: ptr.To(1)},
+			expected: []string{pkgA1.KubeObjectName(), pkgA2.KubeObjectName()},
+		},
+		{
+			name:     "keep last two published revisions per package",
+			policy:   &api.RetentionPolicy{KeepPublished: ptr.To(2)},
+			expected: []string{pkgA1.KubeObjectName()},
+		},
+		{
+			name:     "keep count below one is treated as one",
+			policy:   &api.RetentionPolicy{KeepPublished: ptr.To(0)},
+			expected: []string{pkgA1.KubeObjectName(), pkgA2.KubeObjectName()},
+		},
+		{
+			name:     "stale drafts",
+			policy:   &api.RetentionPolicy{DraftTTL: &metav1.Duration{Duration: 24 * time.Hour}},
+			expected: []string{staleDraft.KubeObjectName()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectRetentionCandidates(ctx, tt.policy, all, now)
+			assert.ElementsMatch(t, tt.expected, names(got))
+		})
+	}
+}
+
+func TestEnforceRetentionPolicy(t *testing.T) {
+	ctx := t.Context()
+
+	pkgA1 := newPublishedPkgRev("pkg-a", 1)
+	pkgA2 := newPublishedPkgRev("pkg-a", 2)
+	pkgA3 := newPublishedPkgRev("pkg-a", 3)
+	all := []repository.PackageRevision{pkgA1, pkgA2, pkgA3}
+
+	newRepo := func(dryRun bool) *api.Repository {
+		repo := newTestRepo()
+		repo.Spec.Retention = &api.RetentionPolicy{KeepPublished: ptr.To(1), DryRun: dryRun}
+		return repo
+	}
+
+	t.Run("removes unreferenced revisions and keeps referenced ones", func(t *testing.T) {
+		mockCache := cachetypes.NewMockCache(t)
+		mockRepository := mockrepo.NewMockRepository(t)
+		mockCache.EXPECT().FindAllUpstreamReferencesInRepositories(ctx, "default", pkgA1.KubeObjectName()).Return("downstream.pkg.v1", nil)
+		mockCache.EXPECT().FindAllUpstreamReferencesInRepositories(ctx, "default", pkgA2.KubeObjectName()).Return("", nil)
+		mockRepository.EXPECT().DeletePackageRevision(ctx, repository.PackageRevision(pkgA2)).Return(nil)
+
+		r := &RepositoryReconciler{Cache: mockCache}
+		remaining, status := r.enforceRetentionPolicy(ctx, newRepo(false), mockRepository, all)
+
+		assert.Equal(t, []string{pkgA1.KubeObjectName(), pkgA3.KubeObjectName()}, names(remaining))
+		assert.False(t, status.DryRun)
+		assert.NotNil(t, status.LastEnforcedTime)
+		assert.Equal(t, []string{pkgA2.KubeObjectName()}, status.Removed)
+		assert.Equal(t, []string{pkgA1.KubeObjectName()}, status.Protected)
+	})
+
+	t.Run("dry run reports without removing", func(t *testing.T) {
+		mockCache := cachetypes.NewMockCache(t)
+		mockRepository := mockrepo.NewMockRepository(t)
+		mockCache.EXPECT().FindAllUpstreamReferencesInRepositories(ctx, "default", mock.Anything).Return("", nil).Twice()
+
+		r := &RepositoryReconciler{Cache: mockCache}
+		remaining, status := r.enforceRetentionPolicy(ctx, newRepo(true), mockRepository, all)
+
+		assert.Len(t, remaining, 3)
+		assert.True(t, status.DryRun)
+		assert.Equal(t, []string{pkgA1.KubeObjectName(), pkgA2.KubeObjectName()}, status.Removed)
+		assert.Empty(t, status.Protected)
+	})
+
+	t.Run("lookup and delete failures keep the revision", func(t *testing.T) {
+		mockCache := cachetypes.NewMockCache(t)
+		mockRepository := mockrepo.NewMockRepository(t)
+		mockCache.EXPECT().FindAllUpstreamReferencesInRepositories(ctx, "default", pkgA1.KubeObjectName()).Return("", errors.New("db down"))
+		mockCache.EXPECT().FindAllUpstreamReferencesInRepositories(ctx, "default", pkgA2.KubeObjectName()).Return("", nil)
+		mockRepository.EXPECT().DeletePackageRevision(ctx, repository.PackageRevision(pkgA2)).Return(errors.New("push rejected"))
+
+		r := &RepositoryReconciler{Cache: mockCache}
+		remaining, status := r.enforceRetentionPolicy(ctx, newRepo(false), mockRepository, all)
+
+		assert.Len(t, remaining, 3)
+		assert.Empty(t, status.Removed)
+		assert.Empty(t, status.Protected)
+	})
+}
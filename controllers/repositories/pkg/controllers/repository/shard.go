@@ -0,0 +1,345 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cachetypes "github.com/kptdev/porch/pkg/cache/types"
+)
+
+const (
+	// shardLeaseLabel marks the Leases used for repository shard membership
+	shardLeaseLabel = "config.porch.kpt.dev/repository-shard"
+	// shardLeasePrefix is prepended to the shard identity to name its Lease
+	shardLeasePrefix = "porch-repository-shard-"
+	// shardVirtualNodes is the number of points each shard gets on the hash ring
+	shardVirtualNodes = 128
+	// shardReleaseTimeout bounds the Lease deletion on shutdown
+	shardReleaseTimeout = 5 * time.Second
+)
+
+// setupSharding creates the shard membership of this replica and registers it
+// with the manager
+func (r *RepositoryReconciler) setupSharding(mgr ctrl.Manager) error {
+	// Every replica must see the package revisions of all repositories, which
+	// only holds when the cache is shared through the database
+	if strings.ToUpper(r.cacheType) != string(cachetypes.DBCacheType) {
+		return fmt.Errorf("repository sharding requires the %s cache, got %q", cachetypes.DBCacheType, r.cacheType)
+	}
+	if r.ShardLeaseDuration < minShardLeaseDuration {
+		return fmt.Errorf("shard lease duration %v is shorter than the minimum of %v", r.ShardLeaseDuration, minShardLeaseDuration)
+	}
+	if r.ShardIdentity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("failed to determine shard identity: %w", err)
+		}
+		r.ShardIdentity = hostname
+	}
+
+	r.shardEvents = make(chan event.GenericEvent)
+	r.shards = newShardMembership(mgr.GetClient(), mgr.GetAPIReader(), r.ShardNamespace, r.ShardIdentity, r.ShardLeaseDuration, r.enqueueAllRepositories)
+	r.syncLocks = newSyncLocks(mgr.GetClient(), mgr.GetAPIReader(), r.ShardNamespace, r.ShardIdentity, r.ShardLeaseDuration)
+	return mgr.Add(r.shards)
+}
+
+// ownsRepository reports whether this replica should reconcile the repository.
+// Without sharding every repository is owned.
+func (r *RepositoryReconciler) ownsRepository(namespace, name string) bool {
+	return r.shards == nil || r.shards.Owns(namespace, name)
+}
+
+// shardIdentity returns the shard recorded in the Repository status, or "" if
+// sharding is disabled
+func (r *RepositoryReconciler) shardIdentity() string {
+	if r.shards == nil {
+		return ""
+	}
+	return r.ShardIdentity
+}
+
+// enqueueAllRepositories re-triggers every Repository after a membership
+// change, so that the new owners pick up the repositories that moved to them
+func (r *RepositoryReconciler) enqueueAllRepositories(ctx context.Context) {
+	repos, err := r.getAllRepositories(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list repositories for shard rebalance")
+		return
+	}
+	for i := range repos {
+		select {
+		case r.shardEvents <- event.GenericEvent{Object: &repos[i]}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// shardMembership tracks the live repository controller replicas and assigns
+// repositories to them.
+//
+// Each replica holds its own coordination.k8s.io Lease and renews it every
+// third of the lease duration. The members are the holders of all unexpired
+// shard Leases, and repositories are mapped onto them with a consistent-hash
+// ring, so a replica joining or leaving only moves the repositories it gains
+// or loses. A replica that cannot refresh the membership for a whole lease
+// duration stops owning anything, because the other replicas will already
+// have taken its repositories over.
+type shardMembership struct {
+	client        client.Client
+	reader        client.Reader
+	namespace     string
+	identity      string
+	leaseDuration time.Duration
+
+	// onChange is called in its own goroutine after the membership changes
+	onChange func(ctx context.Context)
+	now      func() time.Time
+
+	mutex       sync.RWMutex
+	members     []string
+	ring        *hashRing
+	lastRefresh time.Time
+}
+
+func newShardMembership(c client.Client, reader client.Reader, namespace, identity string, leaseDuration time.Duration, onChange func(ctx context.Context)) *shardMembership {
+	return &shardMembership{
+		client:        c,
+		reader:        reader,
+		namespace:     namespace,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+		onChange:      onChange,
+		now:           time.Now,
+	}
+}
+
+// Start renews the Lease of this replica and refreshes the membership until
+// the context is cancelled, then releases the Lease so that the remaining
+// replicas rebalance straight away.
+func (m *shardMembership) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		m.heartbeat(ctx)
+		select {
+		case <-ctx.Done():
+			m.release()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection returns false because every replica must hold a shard Lease
+func (m *shardMembership) NeedLeaderElection() bool {
+	return false
+}
+
+// Owns reports whether the repository is assigned to this replica
+func (m *shardMembership) Owns(namespace, name string) bool {
+	owner, ok := m.Owner(namespace, name)
+	return ok && owner == m.identity
+}
+
+// Owner returns the shard the repository is assigned to. It returns false
+// while the membership is unknown or stale.
+func (m *shardMembership) Owner(namespace, name string) (string, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if m.ring == nil || m.now().Sub(m.lastRefresh) > m.leaseDuration {
+		return "", false
+	}
+	return m.ring.owner(namespace + "/" + name), true
+}
+
+// Members returns the live shards seen by the last refresh
+func (m *shardMembership) Members() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return slices.Clone(m.members)
+}
+
+func (m *shardMembership) heartbeat(ctx context.Context) {
+	if err := m.renew(ctx); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to renew repository shard lease", "shard", m.identity)
+	}
+	if err := m.refresh(ctx); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to refresh repository shard membership", "shard", m.identity)
+	}
+}
+
+func (m *shardMembership) leaseName() string {
+	return shardLeasePrefix + m.identity
+}
+
+// renew creates or renews the Lease of this replica
+func (m *shardMembership) renew(ctx context.Context) error {
+	now := metav1.NewMicroTime(m.now())
+	key := client.ObjectKey{Namespace: m.namespace, Name: m.leaseName()}
+
+	lease := &coordinationv1.Lease{}
+	if err := m.reader.Get(ctx, key, lease); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get shard lease %s: %w", key, err)
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels:    map[string]string{shardLeaseLabel: "true"},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(m.identity),
+				LeaseDurationSeconds: ptr.To(int32(m.leaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		if err := m.client.Create(ctx, lease); err != nil {
+			return fmt.Errorf("failed to create shard lease %s: %w", key, err)
+		}
+		return nil
+	}
+
+	lease.Spec.HolderIdentity = ptr.To(m.identity)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(m.leaseDuration.Seconds()))
+	lease.Spec.RenewTime = &now
+	if err := m.client.Update(ctx, lease); err != nil {
+		return fmt.Errorf("failed to renew shard lease %s: %w", key, err)
+	}
+	return nil
+}
+
+// refresh lists the shard Leases and rebuilds the hash ring if the set of live
+// members changed
+func (m *shardMembership) refresh(ctx context.Context) error {
+	leases := &coordinationv1.LeaseList{}
+	if err := m.reader.List(ctx, leases, client.InNamespace(m.namespace), client.MatchingLabels{shardLeaseLabel: "true"}); err != nil {
+		return fmt.Errorf("failed to list shard leases: %w", err)
+	}
+
+	now := m.now()
+	members := liveShardMembers(leases.Items, now)
+
+	m.mutex.Lock()
+	m.lastRefresh = now
+	changed := m.ring == nil || !slices.Equal(members, m.members)
+	if changed {
+		m.members = members
+		m.ring = newHashRing(members, shardVirtualNodes)
+	}
+	m.mutex.Unlock()
+
+	if changed {
+		log.FromContext(ctx).Info("Repository shard membership changed", "shard", m.identity, "members", members)
+		if m.onChange != nil {
+			go m.onChange(ctx)
+		}
+	}
+	return nil
+}
+
+// release deletes the Lease of this replica
+func (m *shardMembership) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), shardReleaseTimeout)
+	defer cancel()
+
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: m.leaseName(), Namespace: m.namespace},
+	}
+	if err := m.client.Delete(ctx, lease); client.IgnoreNotFound(err) != nil {
+		log.FromContext(ctx).Error(err, "Failed to release repository shard lease", "shard", m.identity)
+	}
+}
+
+// liveShardMembers returns the sorted holders of the unexpired Leases
+func liveShardMembers(leases []coordinationv1.Lease, now time.Time) []string {
+	var members []string
+	for _, lease := range leases {
+		spec := lease.Spec
+		if spec.HolderIdentity == nil || *spec.HolderIdentity == "" || spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+			continue
+		}
+		expiry := spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second)
+		if !now.Before(expiry) {
+			continue
+		}
+		members = append(members, *spec.HolderIdentity)
+	}
+	slices.Sort(members)
+	return slices.Compact(members)
+}
+
+// hashRing is a consistent-hash ring with virtual nodes
+type hashRing struct {
+	points []uint64
+	owners map[uint64]string
+}
+
+func newHashRing(members []string, virtualNodes int) *hashRing {
+	ring := &hashRing{owners: make(map[uint64]string, len(members)*virtualNodes)}
+	for _, member := range members {
+		for i := range virtualNodes {
+			point := hashRingPoint(fmt.Sprintf("%s#%d", member, i))
+			if _, taken := ring.owners[point]; taken {
+				continue
+			}
+			ring.owners[point] = member
+			ring.points = append(ring.points, point)
+		}
+	}
+	slices.Sort(ring.points)
+	return ring
+}
+
+// owner returns the member owning the first point at or after the hash of the key
+func (h *hashRing) owner(key string) string {
+	if len(h.points) == 0 {
+		return ""
+	}
+	point := hashRingPoint(key)
+	i := sort.Search(len(h.points), func(i int) bool { return h.points[i] >= point })
+	if i == len(h.points) {
+		i = 0
+	}
+	return h.owners[h.points[i]]
+}
+
+func hashRingPoint(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
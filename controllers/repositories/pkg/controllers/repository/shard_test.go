@@ -0,0 +1,247 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	mockclient "github.com/kptdev/porch/test/mockery/mocks/external/sigs.k8s.io/controller-runtime/pkg/client"
+	cachetypes "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/cache/types"
+)
+
+func newShardTestClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, coordinationv1.AddToScheme(scheme))
+	require.NoError(t, configapi.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func createShardLease(identity string, renewTime time.Time, duration time.Duration) *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      shardLeasePrefix + identity,
+			Namespace: defaultShardNamespace,
+			Labels:    map[string]string{shardLeaseLabel: "true"},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To(identity),
+			LeaseDurationSeconds: ptr.To(int32(duration.Seconds())),
+			RenewTime:            &metav1.MicroTime{Time: renewTime},
+		},
+	}
+}
+
+func ringAssignments(ring *hashRing, keys int) map[string]string {
+	owners := make(map[string]string, keys)
+	for i := range keys {
+		key := fmt.Sprintf("ns-%d/repo-%d", i%7, i)
+		owners[key] = ring.owner(key)
+	}
+	return owners
+}
+
+func TestHashRingOwner(t *testing.T) {
+	assert.Empty(t, newHashRing(nil, shardVirtualNodes).owner("ns/repo"))
+
+	ring := newHashRing([]string{"a", "b", "c"}, shardVirtualNodes)
+	owners := ringAssignments(ring, 3000)
+
+	counts := map[string]int{}
+	for key, owner := range owners {
+		assert.Equal(t, owner, ring.owner(key), "assignment must be deterministic")
+		counts[owner]++
+	}
+	for _, member := range []string{"a", "b", "c"} {
+		assert.Greater(t, counts[member], 600, "member %s is underloaded: %v", member, counts)
+	}
+}
+
+func TestHashRingRebalance(t *testing.T) {
+	before := ringAssignments(newHashRing([]string{"a", "b", "c"}, shardVirtualNodes), 3000)
+
+	// A joining member only takes repositories over, never shuffles the others
+	joined := ringAssignments(newHashRing([]string{"a", "b", "c", "d"}, shardVirtualNodes), 3000)
+	moved := 0
+	for key, owner := range joined {
+		if owner != before[key] {
+			assert.Equal(t, "d", owner, "key %s moved between existing members", key)
+			moved++
+		}
+	}
+	assert.Greater(t, moved, 0)
+
+	// A leaving member only hands its own repositories over
+	left := ringAssignments(newHashRing([]string{"a", "c"}, shardVirtualNodes), 3000)
+	for key, owner := range left {
+		if before[key] != "b" {
+			assert.Equal(t, before[key], owner, "key %s moved although its owner stayed", key)
+		}
+		assert.NotEqual(t, "b", owner)
+	}
+}
+
+func TestLiveShardMembers(t *testing.T) {
+	now := time.Now()
+	noHolder := createShardLease("x", now, time.Minute)
+	noHolder.Spec.HolderIdentity = nil
+	noRenewTime := createShardLease("y", now, time.Minute)
+	noRenewTime.Spec.RenewTime = nil
+
+	members := liveShardMembers([]coordinationv1.Lease{
+		*createShardLease("c", now, time.Minute),
+		*createShardLease("a", now.Add(-30*time.Second), time.Minute),
+		*createShardLease("expired", now.Add(-2*time.Minute), time.Minute),
+		*createShardLease("a", now, time.Minute),
+		*noHolder,
+		*noRenewTime,
+	}, now)
+
+	assert.Equal(t, []string{"a", "c"}, members)
+}
+
+func TestShardMembershipRenew(t *testing.T) {
+	ctx := t.Context()
+	c := newShardTestClient(t)
+	m := newShardMembership(c, c, defaultShardNamespace, "self", 30*time.Second, nil)
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return created }
+	require.NoError(t, m.renew(ctx))
+
+	lease := &coordinationv1.Lease{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: defaultShardNamespace, Name: "porch-repository-shard-self"}, lease))
+	assert.Equal(t, "self", *lease.Spec.HolderIdentity)
+	assert.Equal(t, int32(30), *lease.Spec.LeaseDurationSeconds)
+	assert.Equal(t, "true", lease.Labels[shardLeaseLabel])
+	assert.True(t, lease.Spec.RenewTime.Time.Equal(created))
+
+	renewed := created.Add(10 * time.Second)
+	m.now = func() time.Time { return renewed }
+	require.NoError(t, m.renew(ctx))
+
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: defaultShardNamespace, Name: "porch-repository-shard-self"}, lease))
+	assert.True(t, lease.Spec.RenewTime.Time.Equal(renewed))
+	assert.True(t, lease.Spec.AcquireTime.Time.Equal(created))
+
+	m.release()
+	err := c.Get(ctx, client.ObjectKey{Namespace: defaultShardNamespace, Name: "porch-repository-shard-self"}, lease)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestShardMembershipRefresh(t *testing.T) {
+	ctx := t.Context()
+	now := time.Now()
+	c := newShardTestClient(t,
+		createShardLease("other", now, 30*time.Second),
+		createShardLease("gone", now.Add(-time.Minute), 30*time.Second),
+	)
+
+	changes := make(chan struct{}, 2)
+	m := newShardMembership(c, c, defaultShardNamespace, "self", 30*time.Second, func(context.Context) {
+		changes <- struct{}{}
+	})
+	m.now = func() time.Time { return now }
+
+	_, known := m.Owner("ns", "repo")
+	assert.False(t, known, "ownership is unknown before the first refresh")
+
+	m.heartbeat(ctx)
+	assert.Equal(t, []string{"other", "self"}, m.Members())
+	<-changes
+
+	owned := 0
+	for i := range 100 {
+		name := fmt.Sprintf("repo-%d", i)
+		owner, known := m.Owner("ns", name)
+		require.True(t, known)
+		assert.Contains(t, []string{"other", "self"}, owner)
+		if m.Owns("ns", name) {
+			assert.Equal(t, "self", owner)
+			owned++
+		}
+	}
+	assert.Greater(t, owned, 0)
+	assert.Less(t, owned, 100)
+
+	// An unchanged membership does not trigger a rebalance
+	require.NoError(t, m.refresh(ctx))
+	assert.Empty(t, changes)
+
+	// A membership that could not be refreshed for a whole lease duration is not trusted
+	m.now = func() time.Time { return now.Add(time.Minute) }
+	_, known = m.Owner("ns", "repo")
+	assert.False(t, known)
+	assert.False(t, m.Owns("ns", "repo-0"))
+}
+
+func TestReconcileSkipsRepositoryOfOtherShard(t *testing.T) {
+	mockClient := mockclient.NewMockClient(t)
+	mockCache := cachetypes.NewMockCache(t)
+	r := newTestReconciler(mockClient, mockCache)
+	r.ShardIdentity = "self"
+	r.shards = newShardMembership(mockClient, mockClient, defaultShardNamespace, "self", 30*time.Second, nil)
+	r.shards.members = []string{"other"}
+	r.shards.ring = newHashRing([]string{"other"}, shardVirtualNodes)
+	r.shards.lastRefresh = time.Now()
+	r.coldStartRepos.Store("test-ns/test-repo", true)
+
+	result, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "test-repo"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, r.HealthCheckFrequency, result.RequeueAfter)
+	_, warm := r.coldStartRepos.Load("test-ns/test-repo")
+	assert.False(t, warm, "a released repository must be fully synced again if it comes back")
+}
+
+func TestShardIdentity(t *testing.T) {
+	r := &RepositoryReconciler{ShardIdentity: "self"}
+	assert.True(t, r.ownsRepository("ns", "repo"))
+	assert.Empty(t, r.shardIdentity())
+
+	r.shards = newShardMembership(nil, nil, defaultShardNamespace, "self", 30*time.Second, nil)
+	assert.False(t, r.ownsRepository("ns", "repo"))
+	assert.Equal(t, "self", r.shardIdentity())
+}
+
+func TestEnqueueAllRepositories(t *testing.T) {
+	c := newShardTestClient(t, createTestRepo("repo-1", "ns"), createTestRepo("repo-2", "other-ns"))
+	r := &RepositoryReconciler{Client: c, shardEvents: make(chan event.GenericEvent, 2)}
+
+	r.enqueueAllRepositories(t.Context())
+
+	var enqueued []string
+	for range 2 {
+		e := <-r.shardEvents
+		enqueued = append(enqueued, e.Object.GetNamespace()+"/"+e.Object.GetName())
+	}
+	assert.ElementsMatch(t, []string{"ns/repo-1", "other-ns/repo-2"}, enqueued)
+}
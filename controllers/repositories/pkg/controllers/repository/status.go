@@ -106,6 +106,8 @@ func (r *RepositoryReconciler) updateRepoStatusWithBackoff(ctx context.Context,
 			PackageCount:       repo.Status.PackageCount,
 			GitCommitHash:      repo.Status.GitCommitHash,
 			NextFullSyncTime:   repo.Status.NextFullSyncTime,
+			Retention:          repo.Status.Retention,
+			Shard:              r.shardIdentity(),
 		},
 	}
 
@@ -148,6 +148,12 @@ func (r *RepositoryReconciler) syncRepository(ctx context.Context, repo *api.Rep
 		return 0, "", err
 	}
 
+	if repo.Spec.Retention != nil {
+		pkgRevs, repo.Status.Retention = r.enforceRetentionPolicy(ctx, repo, repoHandle, pkgRevs)
+	} else {
+		repo.Status.Retention = nil
+	}
+
 	if r.CreateV1Alpha2Rpkg && repo.Annotations[api.AnnotationKeyV1Alpha2Migration] == api.AnnotationValueMigrationEnabled {
 		if err := r.syncPackageRevisions(ctx, repo, pkgRevs); err != nil {
 			log.Error(err, "Failed to sync v1alpha2 PackageRevisions", "repo", repo.Name)
@@ -0,0 +1,209 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+)
+
+const (
+	// syncLockLabel marks the Leases used to lock the sync of a repository
+	syncLockLabel = "config.porch.kpt.dev/repository-sync-lock"
+	// syncLockRepositoryAnnotation records the repository a sync lock Lease is for
+	syncLockRepositoryAnnotation = "config.porch.kpt.dev/repository"
+	// syncLockPrefix is prepended to the hash of the repository key to name its Lease
+	syncLockPrefix = "porch-repository-sync-"
+)
+
+// syncLocks hands out per-repository exclusive locks, so that at most one
+// replica syncs a repository at a time even while the replicas briefly
+// disagree on the shard membership, for instance during a rebalance.
+//
+// A lock is a coordination.k8s.io Lease named after the repository. It is
+// taken with optimistic concurrency, renewed every third of the lease
+// duration while the sync runs and deleted when the sync ends. A Lease that
+// was not renewed for a whole lease duration is taken over, so a replica
+// that dies mid-sync does not block the repository.
+type syncLocks struct {
+	client        client.Client
+	reader        client.Reader
+	namespace     string
+	identity      string
+	leaseDuration time.Duration
+	now           func() time.Time
+}
+
+func newSyncLocks(c client.Client, reader client.Reader, namespace, identity string, leaseDuration time.Duration) *syncLocks {
+	return &syncLocks{
+		client:        c,
+		reader:        reader,
+		namespace:     namespace,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+		now:           time.Now,
+	}
+}
+
+// lockRepositorySync takes the sync lock of the repository. Without sharding
+// only this replica syncs repositories, so there is nothing to lock.
+func (r *RepositoryReconciler) lockRepositorySync(ctx context.Context, repo *configapi.Repository) (unlock func(), locked bool, err error) {
+	if r.syncLocks == nil {
+		return func() {}, true, nil
+	}
+	return r.syncLocks.TryLock(ctx, repo.Namespace, repo.Name)
+}
+
+// syncLockName returns the name of the Lease locking the sync of a repository.
+// Repository keys can be longer than a Lease name, so the key is hashed.
+func syncLockName(namespace, name string) string {
+	sum := sha256.Sum256([]byte(namespace + "/" + name))
+	return syncLockPrefix + hex.EncodeToString(sum[:16])
+}
+
+// TryLock takes the sync lock of the repository. It returns false, without an
+// error, if another replica holds the lock. The returned unlock function stops
+// the renewal of the lock and releases it.
+func (l *syncLocks) TryLock(ctx context.Context, namespace, name string) (unlock func(), locked bool, err error) {
+	key := client.ObjectKey{Namespace: l.namespace, Name: syncLockName(namespace, name)}
+	now := metav1.NewMicroTime(l.now())
+
+	lease := &coordinationv1.Lease{}
+	if err := l.reader.Get(ctx, key, lease); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, false, fmt.Errorf("failed to get sync lock %s: %w", key, err)
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        key.Name,
+				Namespace:   key.Namespace,
+				Labels:      map[string]string{syncLockLabel: "true"},
+				Annotations: map[string]string{syncLockRepositoryAnnotation: namespace + "/" + name},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(l.identity),
+				LeaseDurationSeconds: ptr.To(int32(l.leaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		if err := l.client.Create(ctx, lease); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return nil, false, nil
+			}
+			return nil, false, fmt.Errorf("failed to create sync lock %s: %w", key, err)
+		}
+	} else {
+		if l.heldByOther(lease) {
+			return nil, false, nil
+		}
+		lease.Spec.HolderIdentity = ptr.To(l.identity)
+		lease.Spec.LeaseDurationSeconds = ptr.To(int32(l.leaseDuration.Seconds()))
+		lease.Spec.AcquireTime = &now
+		lease.Spec.RenewTime = &now
+		// The update fails with a conflict if another replica took the lock since the Get
+		if err := l.client.Update(ctx, lease); err != nil {
+			if apierrors.IsConflict(err) {
+				return nil, false, nil
+			}
+			return nil, false, fmt.Errorf("failed to take sync lock %s: %w", key, err)
+		}
+	}
+
+	renewCtx, stopRenewal := context.WithCancel(context.WithoutCancel(ctx))
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		l.renew(renewCtx, key)
+	}()
+	return func() {
+		stopRenewal()
+		<-renewed
+		l.release(renewCtx, key)
+	}, true, nil
+}
+
+// heldByOther reports whether another replica holds an unexpired lock
+func (l *syncLocks) heldByOther(lease *coordinationv1.Lease) bool {
+	spec := lease.Spec
+	if spec.HolderIdentity == nil || *spec.HolderIdentity == "" || *spec.HolderIdentity == l.identity {
+		return false
+	}
+	if spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	expiry := spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second)
+	return l.now().Before(expiry)
+}
+
+// renew keeps the lock alive until the context is cancelled
+func (l *syncLocks) renew(ctx context.Context, key client.ObjectKey) {
+	ticker := time.NewTicker(l.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		lease := &coordinationv1.Lease{}
+		if err := l.reader.Get(ctx, key, lease); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to get repository sync lock for renewal", "lock", key)
+			continue
+		}
+		if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != l.identity {
+			log.FromContext(ctx).Info("Repository sync lock was taken over by another replica", "lock", key)
+			return
+		}
+		lease.Spec.RenewTime = ptr.To(metav1.NewMicroTime(l.now()))
+		if err := l.client.Update(ctx, lease); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to renew repository sync lock", "lock", key)
+		}
+	}
+}
+
+// release deletes the lock if this replica still holds it
+func (l *syncLocks) release(ctx context.Context, key client.ObjectKey) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shardReleaseTimeout)
+	defer cancel()
+
+	lease := &coordinationv1.Lease{}
+	if err := l.reader.Get(ctx, key, lease); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "Failed to get repository sync lock for release", "lock", key)
+		}
+		return
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != l.identity {
+		return
+	}
+	// The precondition keeps a lock that another replica took over in the meantime
+	if err := l.client.Delete(ctx, lease, client.Preconditions{ResourceVersion: ptr.To(lease.ResourceVersion)}); client.IgnoreNotFound(err) != nil {
+		log.FromContext(ctx).Error(err, "Failed to release repository sync lock", "lock", key)
+	}
+}
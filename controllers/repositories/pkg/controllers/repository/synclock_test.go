@@ -0,0 +1,108 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mockclient "github.com/kptdev/porch/test/mockery/mocks/external/sigs.k8s.io/controller-runtime/pkg/client"
+	cachetypes "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/cache/types"
+)
+
+func TestSyncLocks(t *testing.T) {
+	ctx := t.Context()
+	c := newShardTestClient(t)
+	first := newSyncLocks(c, c, defaultShardNamespace, "first", 30*time.Second)
+	second := newSyncLocks(c, c, defaultShardNamespace, "second", 30*time.Second)
+
+	unlock, locked, err := first.TryLock(ctx, "ns", "repo")
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	_, locked, err = second.TryLock(ctx, "ns", "repo")
+	require.NoError(t, err)
+	assert.False(t, locked, "the lock is held by the first replica")
+
+	// Other repositories are locked independently
+	unlockOther, locked, err := second.TryLock(ctx, "ns", "other-repo")
+	require.NoError(t, err)
+	assert.True(t, locked)
+	unlockOther()
+
+	unlock()
+	lease := &coordinationv1.Lease{}
+	err = c.Get(ctx, client.ObjectKey{Namespace: defaultShardNamespace, Name: syncLockName("ns", "repo")}, lease)
+	assert.True(t, apierrors.IsNotFound(err), "unlock releases the lock")
+
+	unlock, locked, err = second.TryLock(ctx, "ns", "repo")
+	require.NoError(t, err)
+	require.True(t, locked)
+	defer unlock()
+
+	// A lock that was not renewed for a whole lease duration is taken over
+	now := time.Now()
+	first.now = func() time.Time { return now.Add(time.Minute) }
+	unlockExpired, locked, err := first.TryLock(ctx, "ns", "repo")
+	require.NoError(t, err)
+	assert.True(t, locked)
+	unlockExpired()
+}
+
+func TestPerformFullSyncWithDisagreeingShardMemberships(t *testing.T) {
+	ctx := t.Context()
+	repo := createTestRepo("test-repo", "test-ns")
+	leases := newShardTestClient(t)
+
+	// Each replica has only seen its own shard Lease so far, so both believe
+	// they own every repository
+	newShardedReconciler := func(identity string) *RepositoryReconciler {
+		r := newTestReconciler(mockclient.NewMockClient(t), cachetypes.NewMockCache(t))
+		r.ShardIdentity = identity
+		r.shards = newShardMembership(leases, leases, defaultShardNamespace, identity, 30*time.Second, nil)
+		r.shards.members = []string{identity}
+		r.shards.ring = newHashRing([]string{identity}, shardVirtualNodes)
+		r.shards.lastRefresh = time.Now()
+		r.syncLocks = newSyncLocks(leases, leases, defaultShardNamespace, identity, 30*time.Second)
+		r.InitializeSyncLimiter()
+		return r
+	}
+	first := newShardedReconciler("first")
+	second := newShardedReconciler("second")
+	require.True(t, first.ownsRepository(repo.Namespace, repo.Name))
+	require.True(t, second.ownsRepository(repo.Namespace, repo.Name))
+
+	unlock, locked, err := first.lockRepositorySync(ctx, repo)
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	// The second replica neither syncs nor touches the status while the
+	// first one syncs; its mock client expects no calls
+	result, err := second.performFullSync(ctx, repo)
+	require.NoError(t, err)
+	assert.Equal(t, second.HealthCheckFrequency, result.RequeueAfter)
+
+	unlock()
+	unlock, locked, err = second.lockRepositorySync(ctx, repo)
+	require.NoError(t, err)
+	assert.True(t, locked, "the repository can be synced again once the first sync ends")
+	unlock()
+}
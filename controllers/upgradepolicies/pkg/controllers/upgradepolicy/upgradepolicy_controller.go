@@ -0,0 +1,340 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgradepolicy
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	semver "github.com/Masterminds/semver/v3"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type Options struct{}
+
+func (o *Options) InitDefaults()                       {}
+func (o *Options) BindFlags(_ string, _ *flag.FlagSet) {}
+
+// UpgradePolicyReconciler reconciles an UpgradePolicy object
+type UpgradePolicyReconciler struct {
+	client.Client
+	Options
+}
+
+const (
+	reconcilerName      = "upgradepolicies"
+	workspaceNamePrefix = "upgrade-"
+
+	ConditionTypeStalled        = "Stalled"        // whether or not the upgradepolicy object is making progress or not
+	ConditionTypeReady          = "Ready"          // whether or not the reconciliation succeeded
+	ConditionTypeModifiedDrafts = "ModifiedDrafts" // whether drafts of the policy are kept since they were changed
+)
+
+func (r *UpgradePolicyReconciler) Name() string { return reconcilerName }
+
+//go:generate go run sigs.k8s.io/controller-tools/cmd/controller-gen@v0.21.0 rbac:headerFile=../../../../../scripts/boilerplate.yaml.txt,roleName=porch-controllers-upgradepolicies,year=$YEAR_GEN webhook paths="." output:rbac:artifacts:config=../../../config/rbac
+
+//+kubebuilder:rbac:groups=config.porch.kpt.dev,resources=upgradepolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=config.porch.kpt.dev,resources=upgradepolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=porch.kpt.dev,resources=packagerevisions,verbs=create;delete;get;list;watch
+//+kubebuilder:rbac:groups=config.porch.kpt.dev,resources=repositories,verbs=get;list;watch
+
+// Reconcile implements the main kubernetes reconciliation loop.
+func (r *UpgradePolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var policy configapi.UpgradePolicy
+	if err := r.Get(ctx, req.NamespacedName, &policy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	var prList porchapi.PackageRevisionList
+	if err := r.List(ctx, &prList, client.InNamespace(policy.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+	var repoList configapi.RepositoryList
+	if err := r.List(ctx, &repoList, client.InNamespace(policy.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		if err := r.Status().Update(ctx, &policy); err != nil {
+			klog.Errorf("could not update status: %s\n", err.Error())
+		}
+	}()
+
+	constraint, err := parseConstraint(policy.Spec.Constraint)
+	if err != nil {
+		setStalledConditionsToTrue(&policy, err.Error())
+		// do not requeue; an invalid constraint requires a policy change
+		return ctrl.Result{}, nil
+	}
+	upgrades, err := findUpgrades(&policy, constraint, prList.Items, repoList.Items)
+	if err != nil {
+		setStalledConditionsToTrue(&policy, err.Error())
+		return ctrl.Result{}, nil
+	}
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:    ConditionTypeStalled,
+		Status:  "False",
+		Reason:  "Valid",
+		Message: "all validation checks passed",
+	})
+
+	var errs []string
+	for _, u := range upgrades {
+		if u.draft != "" {
+			continue
+		}
+		if u.staleDraft != nil {
+			klog.Infof("upgrade policy %q is replacing upgrade package revision %q", policy.Name, u.staleDraft.Name)
+			if err := r.Delete(ctx, u.staleDraft); client.IgnoreNotFound(err) != nil {
+				errs = append(errs, fmt.Sprintf("could not replace upgrade draft %q: %s", u.staleDraft.Name, err.Error()))
+				continue
+			}
+		}
+		draft, err := r.createUpgradeDraft(ctx, &policy, u, &prList)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("could not create upgrade draft for %q: %s", u.downstream.Name, err.Error()))
+			continue
+		}
+		u.draft, u.draftResourceVersion = draft.Name, draft.ResourceVersion
+		prList.Items = append(prList.Items, *draft)
+	}
+	setPendingUpgrades(&policy, upgrades)
+	setModifiedDraftsCondition(&policy, upgrades)
+
+	if len(errs) > 0 {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:    ConditionTypeReady,
+			Status:  "False",
+			Reason:  "Error",
+			Message: strings.Join(errs, "; "),
+		})
+		return ctrl.Result{}, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:    ConditionTypeReady,
+		Status:  "True",
+		Reason:  "NoErrors",
+		Message: fmt.Sprintf("%d pending upgrades", len(upgrades)),
+	})
+	return ctrl.Result{}, nil
+}
+
+// parseConstraint parses the revision constraint of a policy. An empty
+// constraint allows every revision.
+func parseConstraint(constraint string) (*semver.Constraints, error) {
+	if constraint == "" {
+		return nil, nil
+	}
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint %q: %w", constraint, err)
+	}
+	return c, nil
+}
+
+// allowsRevision reports whether the revision satisfies the constraint.
+func allowsRevision(constraint *semver.Constraints, revision int) bool {
+	if constraint == nil {
+		return true
+	}
+	return constraint.Check(semver.New(uint64(revision), 0, 0, "", ""))
+}
+
+func setStalledConditionsToTrue(policy *configapi.UpgradePolicy, message string) {
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:    ConditionTypeStalled,
+		Status:  "True",
+		Reason:  "ValidationError",
+		Message: message,
+	})
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:    ConditionTypeReady,
+		Status:  "False",
+		Reason:  "Error",
+		Message: "invalid upgradepolicy object",
+	})
+}
+
+func setPendingUpgrades(policy *configapi.UpgradePolicy, upgrades []*upgrade) {
+	pending := []configapi.PendingUpgrade{}
+	for _, u := range upgrades {
+		pending = append(pending, configapi.PendingUpgrade{
+			Downstream:           u.downstream.Name,
+			CurrentUpstream:      u.oldUpstream.Name,
+			TargetUpstream:       u.newUpstream.Name,
+			Draft:                u.draft,
+			DraftResourceVersion: u.draftResourceVersion,
+		})
+	}
+	policy.Status.PendingUpgrades = pending
+}
+
+// setModifiedDraftsCondition reports the drafts that the policy created for an
+// older upstream revision but does not replace, since they were changed since.
+func setModifiedDraftsCondition(policy *configapi.UpgradePolicy, upgrades []*upgrade) {
+	var modified []string
+	for _, u := range upgrades {
+		if u.modifiedDraft {
+			modified = append(modified, u.draft)
+		}
+	}
+	if len(modified) == 0 {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:    ConditionTypeModifiedDrafts,
+			Status:  "False",
+			Reason:  "NoModifiedDrafts",
+			Message: "no outdated upgrade drafts were changed",
+		})
+		return
+	}
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:   ConditionTypeModifiedDrafts,
+		Status: "True",
+		Reason: "DraftsChanged",
+		Message: fmt.Sprintf("outdated upgrade drafts were changed since the policy created them and are not replaced: %s",
+			strings.Join(modified, ", ")),
+	})
+}
+
+// createUpgradeDraft creates a draft of the downstream package that upgrades
+// it to the new upstream revision.
+func (r *UpgradePolicyReconciler) createUpgradeDraft(ctx context.Context,
+	policy *configapi.UpgradePolicy,
+	u *upgrade,
+	prList *porchapi.PackageRevisionList) (*porchapi.PackageRevision, error) {
+
+	labels := map[string]string{}
+	for k, v := range policy.Spec.Labels {
+		labels[k] = v
+	}
+	labels[configapi.UpgradePolicyLabel] = policy.Name
+
+	strategy := policy.Spec.Strategy
+	if strategy == "" {
+		strategy = porchapi.ResourceMerge
+	}
+
+	newPr := &porchapi.PackageRevision{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageRevision",
+			APIVersion: porchapi.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   u.downstream.Namespace,
+			Labels:      labels,
+			Annotations: policy.Spec.Annotations,
+		},
+		Spec: porchapi.PackageRevisionSpec{
+			PackageName:    u.downstream.Spec.PackageName,
+			RepositoryName: u.downstream.Spec.RepositoryName,
+			WorkspaceName:  newWorkspaceName(prList, u.downstream, u.newUpstream.Spec.Revision),
+			Lifecycle:      porchapi.PackageRevisionLifecycleDraft,
+			ReadinessGates: u.downstream.Spec.ReadinessGates,
+			Tasks: []porchapi.Task{
+				{
+					Type: porchapi.TaskTypeUpgrade,
+					Upgrade: &porchapi.PackageUpgradeTaskSpec{
+						OldUpstream: porchapi.PackageRevisionRef{
+							Name: u.oldUpstream.Name,
+						},
+						NewUpstream: porchapi.PackageRevisionRef{
+							Name: u.newUpstream.Name,
+						},
+						LocalPackageRevisionRef: porchapi.PackageRevisionRef{
+							Name: u.downstream.Name,
+						},
+						Strategy: strategy,
+					},
+				},
+			},
+		},
+	}
+
+	klog.Infoln(fmt.Sprintf("upgrade policy %q is creating upgrade package revision from {old: %q, new: %q, local: %q}",
+		policy.Name, u.oldUpstream.Name, u.newUpstream.Name, u.downstream.Name))
+	if err := r.Create(ctx, newPr); err != nil {
+		return nil, err
+	}
+	return newPr, nil
+}
+
+// newWorkspaceName returns a workspace name for an upgrade of the downstream
+// package to the given upstream revision that is not used by another revision
+// of the package.
+func newWorkspaceName(prList *porchapi.PackageRevisionList, downstream *porchapi.PackageRevision, revision int) string {
+	used := map[string]bool{}
+	for _, pr := range prList.Items {
+		if pr.Spec.PackageName == downstream.Spec.PackageName && pr.Spec.RepositoryName == downstream.Spec.RepositoryName {
+			used[pr.Spec.WorkspaceName] = true
+		}
+	}
+	base := fmt.Sprintf("%sv%d", workspaceNamePrefix, revision)
+	name := base
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	return name
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *UpgradePolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := configapi.AddToScheme(mgr.GetScheme()); err != nil {
+		return err
+	}
+	if err := porchapi.AddToScheme(mgr.GetScheme()); err != nil {
+		return err
+	}
+
+	r.Client = mgr.GetClient()
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&configapi.UpgradePolicy{}).
+		Watches(&porchapi.PackageRevision{}, handler.EnqueueRequestsFromMapFunc(mapObjectsToRequests(r.Client))).
+		Complete(r)
+}
+
+// mapObjectsToRequests requests a reconcile of every UpgradePolicy in the
+// namespace of a changed PackageRevision, since a new upstream revision may
+// match any of them.
+func mapObjectsToRequests(mgrClient client.Reader) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		policies := &configapi.UpgradePolicyList{}
+		if err := mgrClient.List(ctx, policies, client.InNamespace(obj.GetNamespace())); err != nil {
+			return []reconcile.Request{}
+		}
+		requests := make([]reconcile.Request, len(policies.Items))
+		for i, item := range policies.Items {
+			requests[i] = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      item.GetName(),
+					Namespace: item.GetNamespace(),
+				},
+			}
+		}
+		return requests
+	}
+}
@@ -0,0 +1,420 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgradepolicy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testNamespace = "test-ns"
+	blueprintsURL = "https://github.com/example/blueprints.git"
+)
+
+// namingClient names package revisions on creation, as the porch server does.
+type namingClient struct {
+	client.Client
+}
+
+func (c *namingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if pr, ok := obj.(*porchapi.PackageRevision); ok && pr.Name == "" {
+		pr.Name = fmt.Sprintf("%s.%s.%s", pr.Spec.RepositoryName, pr.Spec.PackageName, pr.Spec.WorkspaceName)
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func gitRepo(name, url, directory string) *configapi.Repository {
+	return &configapi.Repository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name},
+		Spec: configapi.RepositorySpec{
+			Type: configapi.RepositoryTypeGit,
+			Git:  &configapi.GitRepository{Repo: url, Directory: directory},
+		},
+	}
+}
+
+func publishedPR(repo, pkg string, revision int) *porchapi.PackageRevision {
+	return &porchapi.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      fmt.Sprintf("%s.%s.v%d", repo, pkg, revision),
+		},
+		Spec: porchapi.PackageRevisionSpec{
+			RepositoryName: repo,
+			PackageName:    pkg,
+			WorkspaceName:  fmt.Sprintf("v%d", revision),
+			Revision:       revision,
+			Lifecycle:      porchapi.PackageRevisionLifecyclePublished,
+		},
+	}
+}
+
+// clonedPR returns a Published downstream package revision that was cloned
+// from the given revision of the blueprints package.
+func clonedPR(repo, pkg string, revision int, upstreamPkg string, upstreamRevision int) *porchapi.PackageRevision {
+	pr := publishedPR(repo, pkg, revision)
+	pr.Status.UpstreamLock = &porchapi.Locator{
+		Type: "git",
+		Git: &porchapi.GitLock{
+			Repo:      blueprintsURL,
+			Directory: upstreamPkg,
+			Ref:       fmt.Sprintf("%s/v%d", upstreamPkg, upstreamRevision),
+		},
+	}
+	return pr
+}
+
+func upgradeDraft(repo, pkg, workspace, newUpstream string) *porchapi.PackageRevision {
+	return &porchapi.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      fmt.Sprintf("%s.%s.%s", repo, pkg, workspace),
+		},
+		Spec: porchapi.PackageRevisionSpec{
+			RepositoryName: repo,
+			PackageName:    pkg,
+			WorkspaceName:  workspace,
+			Lifecycle:      porchapi.PackageRevisionLifecycleDraft,
+			Tasks: []porchapi.Task{{
+				Type: porchapi.TaskTypeUpgrade,
+				Upgrade: &porchapi.PackageUpgradeTaskSpec{
+					NewUpstream: porchapi.PackageRevisionRef{Name: newUpstream},
+				},
+			}},
+		},
+	}
+}
+
+func newPolicy(spec configapi.UpgradePolicySpec) *configapi.UpgradePolicy {
+	return &configapi.UpgradePolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "my-policy"},
+		Spec:       spec,
+	}
+}
+
+func setupReconciler(t *testing.T, objs ...client.Object) (*UpgradePolicyReconciler, client.Client) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, porchapi.AddToScheme(scheme))
+	require.NoError(t, configapi.AddToScheme(scheme))
+	c := &namingClient{fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&configapi.UpgradePolicy{}).
+		Build()}
+	return &UpgradePolicyReconciler{Client: c}, c
+}
+
+func reconcilePolicy(t *testing.T, r *UpgradePolicyReconciler, c client.Client) *configapi.UpgradePolicy {
+	_, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "my-policy"},
+	})
+	require.NoError(t, err)
+	var policy configapi.UpgradePolicy
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: "my-policy"}, &policy))
+	return &policy
+}
+
+func TestReconcileCreatesUpgradeDraft(t *testing.T) {
+	policy := newPolicy(configapi.UpgradePolicySpec{
+		Downstream: configapi.PackageRevisionSelector{Repositories: []string{"deployments"}},
+		Labels:     map[string]string{"team": "edge"},
+	})
+	r, c := setupReconciler(t,
+		policy,
+		gitRepo("blueprints", blueprintsURL, ""),
+		publishedPR("blueprints", "basens", 1),
+		publishedPR("blueprints", "basens", 2),
+		clonedPR("deployments", "edge-ns", 1, "basens", 1),
+		clonedPR("other", "edge-ns", 1, "basens", 1),
+	)
+
+	policy = reconcilePolicy(t, r, c)
+
+	assert.Equal(t, []configapi.PendingUpgrade{{
+		Downstream:           "deployments.edge-ns.v1",
+		CurrentUpstream:      "blueprints.basens.v1",
+		TargetUpstream:       "blueprints.basens.v2",
+		Draft:                "deployments.edge-ns.upgrade-v2",
+		DraftResourceVersion: "1",
+	}}, policy.Status.PendingUpgrades)
+	assert.True(t, meta.IsStatusConditionTrue(policy.Status.Conditions, ConditionTypeReady))
+	assert.True(t, meta.IsStatusConditionFalse(policy.Status.Conditions, ConditionTypeModifiedDrafts))
+
+	var draft porchapi.PackageRevision
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: "deployments.edge-ns.upgrade-v2"}, &draft))
+	assert.Equal(t, porchapi.PackageRevisionLifecycleDraft, draft.Spec.Lifecycle)
+	assert.Equal(t, map[string]string{"team": "edge", configapi.UpgradePolicyLabel: "my-policy"}, draft.Labels)
+	require.Len(t, draft.Spec.Tasks, 1)
+	assert.Equal(t, &porchapi.PackageUpgradeTaskSpec{
+		OldUpstream:             porchapi.PackageRevisionRef{Name: "blueprints.basens.v1"},
+		NewUpstream:             porchapi.PackageRevisionRef{Name: "blueprints.basens.v2"},
+		LocalPackageRevisionRef: porchapi.PackageRevisionRef{Name: "deployments.edge-ns.v1"},
+		Strategy:                porchapi.ResourceMerge,
+	}, draft.Spec.Tasks[0].Upgrade)
+
+	// A second reconcile finds the draft instead of creating another one.
+	policy = reconcilePolicy(t, r, c)
+	assert.Equal(t, "deployments.edge-ns.upgrade-v2", policy.Status.PendingUpgrades[0].Draft)
+	assert.Equal(t, "1", policy.Status.PendingUpgrades[0].DraftResourceVersion)
+	var prs porchapi.PackageRevisionList
+	require.NoError(t, c.List(context.Background(), &prs))
+	assert.Len(t, prs.Items, 5)
+}
+
+func TestReconcileDedupesExistingUpgradeDraft(t *testing.T) {
+	r, c := setupReconciler(t,
+		newPolicy(configapi.UpgradePolicySpec{Strategy: porchapi.ManualMerge}),
+		gitRepo("blueprints", blueprintsURL, ""),
+		publishedPR("blueprints", "basens", 1),
+		publishedPR("blueprints", "basens", 2),
+		clonedPR("deployments", "edge-ns", 1, "basens", 1),
+		upgradeDraft("deployments", "edge-ns", "by-hand", "blueprints.basens.v2"),
+	)
+
+	policy := reconcilePolicy(t, r, c)
+
+	require.Len(t, policy.Status.PendingUpgrades, 1)
+	assert.Equal(t, "deployments.edge-ns.by-hand", policy.Status.PendingUpgrades[0].Draft)
+	var prs porchapi.PackageRevisionList
+	require.NoError(t, c.List(context.Background(), &prs))
+	assert.Len(t, prs.Items, 4)
+}
+
+// stalePolicyDraft returns a draft that the policy created for an older upstream
+// revision, with the policy recording its resource version at creation.
+func stalePolicyDraft(resourceVersion string) (*configapi.UpgradePolicy, *porchapi.PackageRevision) {
+	stale := upgradeDraft("deployments", "edge-ns", "upgrade-v2", "blueprints.basens.v2")
+	stale.Labels = map[string]string{configapi.UpgradePolicyLabel: "my-policy"}
+	stale.ResourceVersion = resourceVersion
+	policy := newPolicy(configapi.UpgradePolicySpec{})
+	policy.Status.PendingUpgrades = []configapi.PendingUpgrade{{
+		Downstream:           "deployments.edge-ns.v1",
+		CurrentUpstream:      "blueprints.basens.v1",
+		TargetUpstream:       "blueprints.basens.v2",
+		Draft:                stale.Name,
+		DraftResourceVersion: "5",
+	}}
+	return policy, stale
+}
+
+func TestReconcileReplacesStaleUpgradeDraft(t *testing.T) {
+	policy, stale := stalePolicyDraft("5")
+	r, c := setupReconciler(t,
+		policy,
+		gitRepo("blueprints", blueprintsURL, ""),
+		publishedPR("blueprints", "basens", 1),
+		publishedPR("blueprints", "basens", 2),
+		publishedPR("blueprints", "basens", 3),
+		clonedPR("deployments", "edge-ns", 1, "basens", 1),
+		stale,
+		clonedPR("deployments", "other-ns", 1, "basens", 1),
+		upgradeDraft("deployments", "other-ns", "by-hand", "blueprints.basens.v2"),
+	)
+
+	policy = reconcilePolicy(t, r, c)
+
+	assert.Equal(t, []configapi.PendingUpgrade{{
+		Downstream:           "deployments.edge-ns.v1",
+		CurrentUpstream:      "blueprints.basens.v1",
+		TargetUpstream:       "blueprints.basens.v3",
+		Draft:                "deployments.edge-ns.upgrade-v3",
+		DraftResourceVersion: "1",
+	}, {
+		Downstream:      "deployments.other-ns.v1",
+		CurrentUpstream: "blueprints.basens.v1",
+		TargetUpstream:  "blueprints.basens.v3",
+		Draft:           "deployments.other-ns.by-hand",
+	}}, policy.Status.PendingUpgrades)
+
+	// The draft of the policy is replaced, the draft made by hand is kept
+	var draft porchapi.PackageRevision
+	err := c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: stale.Name}, &draft)
+	assert.True(t, apierrors.IsNotFound(err))
+	var prs porchapi.PackageRevisionList
+	require.NoError(t, c.List(context.Background(), &prs))
+	assert.Len(t, prs.Items, 7)
+}
+
+func TestReconcileKeepsModifiedUpgradeDraft(t *testing.T) {
+	// The draft was changed after the policy created it
+	policy, stale := stalePolicyDraft("6")
+	r, c := setupReconciler(t,
+		policy,
+		gitRepo("blueprints", blueprintsURL, ""),
+		publishedPR("blueprints", "basens", 1),
+		publishedPR("blueprints", "basens", 2),
+		publishedPR("blueprints", "basens", 3),
+		clonedPR("deployments", "edge-ns", 1, "basens", 1),
+		stale,
+	)
+
+	policy = reconcilePolicy(t, r, c)
+
+	assert.Equal(t, []configapi.PendingUpgrade{{
+		Downstream:           "deployments.edge-ns.v1",
+		CurrentUpstream:      "blueprints.basens.v1",
+		TargetUpstream:       "blueprints.basens.v3",
+		Draft:                stale.Name,
+		DraftResourceVersion: "5",
+	}}, policy.Status.PendingUpgrades)
+	cond := meta.FindStatusCondition(policy.Status.Conditions, ConditionTypeModifiedDrafts)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Contains(t, cond.Message, stale.Name)
+
+	var draft porchapi.PackageRevision
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: stale.Name}, &draft))
+	var prs porchapi.PackageRevisionList
+	require.NoError(t, c.List(context.Background(), &prs))
+	assert.Len(t, prs.Items, 5)
+}
+
+func TestReconcileInvalidConstraint(t *testing.T) {
+	r, c := setupReconciler(t,
+		newPolicy(configapi.UpgradePolicySpec{Constraint: "not a constraint"}),
+	)
+
+	policy := reconcilePolicy(t, r, c)
+
+	assert.True(t, meta.IsStatusConditionTrue(policy.Status.Conditions, ConditionTypeStalled))
+	assert.True(t, meta.IsStatusConditionFalse(policy.Status.Conditions, ConditionTypeReady))
+	assert.Empty(t, policy.Status.PendingUpgrades)
+}
+
+func TestFindUpgrades(t *testing.T) {
+	pvOwned := clonedPR("deployments", "pv-ns", 1, "basens", 1)
+	pvOwned.OwnerReferences = []metav1.OwnerReference{{Kind: "PackageVariant", Name: "pv"}}
+	labelled := publishedPR("blueprints", "basens", 3)
+	labelled.Labels = map[string]string{"channel": "stable"}
+
+	prs := []porchapi.PackageRevision{
+		*publishedPR("blueprints", "basens", 1),
+		*publishedPR("blueprints", "basens", 2),
+		*labelled,
+		*publishedPR("blueprints", "basens", 4),
+		*clonedPR("deployments", "a-ns", 1, "basens", 1),
+		*clonedPR("deployments", "b-ns", 1, "basens", 1),
+		*clonedPR("deployments", "b-ns", 2, "basens", 4),
+		*clonedPR("deployments", "c-ns", 1, "basens", 2),
+		*clonedPR("deployments", "d-ns", 1, "unknown", 1),
+		*pvOwned,
+	}
+	repos := []configapi.Repository{*gitRepo("blueprints", "https://github.com/example/blueprints", "")}
+
+	testCases := map[string]struct {
+		spec     configapi.UpgradePolicySpec
+		expected map[string]string
+	}{
+		"latest": {
+			expected: map[string]string{
+				"deployments.a-ns.v1": "blueprints.basens.v4",
+				"deployments.c-ns.v1": "blueprints.basens.v4",
+			},
+		},
+		"constraint": {
+			spec: configapi.UpgradePolicySpec{Constraint: "< 3"},
+			expected: map[string]string{
+				"deployments.a-ns.v1": "blueprints.basens.v2",
+			},
+		},
+		"upstream selector": {
+			spec: configapi.UpgradePolicySpec{
+				Upstream: configapi.PackageRevisionSelector{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"channel": "stable"}},
+				},
+			},
+			expected: map[string]string{
+				"deployments.a-ns.v1": "blueprints.basens.v3",
+				"deployments.c-ns.v1": "blueprints.basens.v3",
+			},
+		},
+		"downstream selector": {
+			spec: configapi.UpgradePolicySpec{
+				Downstream: configapi.PackageRevisionSelector{Packages: []string{"c-*"}},
+			},
+			expected: map[string]string{
+				"deployments.c-ns.v1": "blueprints.basens.v4",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			constraint, err := parseConstraint(tc.spec.Constraint)
+			require.NoError(t, err)
+			upgrades, err := findUpgrades(newPolicy(tc.spec), constraint, prs, repos)
+			require.NoError(t, err)
+
+			actual := map[string]string{}
+			for _, u := range upgrades {
+				actual[u.downstream.Name] = u.newUpstream.Name
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestUpstreamPackage(t *testing.T) {
+	repos := []configapi.Repository{
+		*gitRepo("oci", "", ""),
+		*gitRepo("catalog", "https://github.com/example/catalog.git/", "packages"),
+	}
+	repos[0].Spec.Type = configapi.RepositoryTypeOCI
+	repos[0].Spec.Git = nil
+
+	key, ok := upstreamPackage(&porchapi.Locator{Git: &porchapi.GitLock{
+		Repo: "https://github.com/example/catalog",
+		Ref:  "packages/network/v3",
+	}}, repos)
+	assert.True(t, ok)
+	assert.Equal(t, packageKey{repository: "catalog", pkg: "network"}, key)
+
+	_, ok = upstreamPackage(&porchapi.Locator{Git: &porchapi.GitLock{
+		Repo: "https://github.com/example/catalog",
+		Ref:  "drafts/packages/network/my-ws",
+	}}, repos)
+	assert.False(t, ok)
+
+	_, ok = upstreamPackage(nil, repos)
+	assert.False(t, ok)
+}
+
+func TestNewWorkspaceName(t *testing.T) {
+	downstream := publishedPR("deployments", "edge-ns", 1)
+	prList := &porchapi.PackageRevisionList{Items: []porchapi.PackageRevision{
+		*downstream,
+		*upgradeDraft("deployments", "edge-ns", "upgrade-v2", "blueprints.basens.v2"),
+		*upgradeDraft("deployments", "edge-ns", "upgrade-v2-2", "blueprints.basens.v2"),
+		*upgradeDraft("deployments", "other-ns", "upgrade-v3", "blueprints.basens.v3"),
+	}}
+
+	assert.Equal(t, "upgrade-v2-3", newWorkspaceName(prList, downstream, 2))
+	assert.Equal(t, "upgrade-v3", newWorkspaceName(prList, downstream, 3))
+}
@@ -0,0 +1,270 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgradepolicy
+
+import (
+	"sort"
+	"strings"
+
+	semver "github.com/Masterminds/semver/v3"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/repository"
+	"k8s.io/klog/v2"
+)
+
+// upgrade is an available upgrade of a downstream package revision.
+type upgrade struct {
+	downstream  *porchapi.PackageRevision
+	oldUpstream *porchapi.PackageRevision
+	newUpstream *porchapi.PackageRevision
+
+	// draft is the name of the draft that performs the upgrade, if it exists.
+	draft string
+	// draftResourceVersion is the resource version of the draft when the
+	// policy created it, if it did.
+	draftResourceVersion string
+	// staleDraft is a draft that the policy created for an older upstream
+	// revision. It is replaced by a draft that performs the upgrade.
+	staleDraft *porchapi.PackageRevision
+	// modifiedDraft reports that the draft was created by the policy for an
+	// older upstream revision, but is kept since it was changed after that.
+	modifiedDraft bool
+}
+
+// packageKey identifies a package in a repository.
+type packageKey struct {
+	repository string
+	pkg        string
+}
+
+func keyOf(pr *porchapi.PackageRevision) packageKey {
+	return packageKey{repository: pr.Spec.RepositoryName, pkg: pr.Spec.PackageName}
+}
+
+// findUpgrades returns the upgrades that the policy selects, ordered by the
+// name of the downstream package revision. Each downstream package is
+// upgraded from its latest Published revision to the latest Published
+// revision of its upstream package that satisfies the constraint.
+func findUpgrades(policy *configapi.UpgradePolicy,
+	constraint *semver.Constraints,
+	prs []porchapi.PackageRevision,
+	repos []configapi.Repository) ([]*upgrade, error) {
+
+	published := map[packageKey]map[int]*porchapi.PackageRevision{}
+	latest := map[packageKey]*porchapi.PackageRevision{}
+	for i := range prs {
+		pr := &prs[i]
+		if !pr.IsPublished() {
+			continue
+		}
+		key := keyOf(pr)
+		if published[key] == nil {
+			published[key] = map[int]*porchapi.PackageRevision{}
+		}
+		published[key][pr.Spec.Revision] = pr
+		if l, ok := latest[key]; !ok || pr.Spec.Revision > l.Spec.Revision {
+			latest[key] = pr
+		}
+	}
+
+	var upgrades []*upgrade
+	for _, downstream := range latest {
+		if managedByPackageVariant(downstream) {
+			continue
+		}
+		matches, err := policy.Spec.Downstream.Matches(downstream.Spec.RepositoryName, downstream.Spec.PackageName, downstream.Labels)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			continue
+		}
+		upstreamKey, ok := upstreamPackage(downstream.Status.UpstreamLock, repos)
+		if !ok {
+			continue
+		}
+		oldUpstream := published[upstreamKey][revisionFromLocator(downstream.Status.UpstreamLock)]
+		if oldUpstream == nil {
+			klog.V(4).Infof("upstream revision of package revision %q was not found", downstream.Name)
+			continue
+		}
+		newUpstream, err := targetUpstream(policy, constraint, oldUpstream, published[upstreamKey])
+		if err != nil {
+			return nil, err
+		}
+		if newUpstream == nil {
+			continue
+		}
+		u := &upgrade{
+			downstream:  downstream,
+			oldUpstream: oldUpstream,
+			newUpstream: newUpstream,
+		}
+		if draft := existingDraft(prs, downstream, newUpstream); draft != nil {
+			createdVersion := createdResourceVersion(policy, draft.Name)
+			target, _ := upgradeTarget(draft)
+			switch {
+			case target == newUpstream.Name || !replaceable(policy, draft):
+				u.draft, u.draftResourceVersion = draft.Name, createdVersion
+			case createdVersion == "" || createdVersion != draft.ResourceVersion:
+				u.draft, u.draftResourceVersion = draft.Name, createdVersion
+				u.modifiedDraft = true
+			default:
+				u.staleDraft = draft
+			}
+		}
+		upgrades = append(upgrades, u)
+	}
+	sort.Slice(upgrades, func(i, j int) bool {
+		return upgrades[i].downstream.Name < upgrades[j].downstream.Name
+	})
+	return upgrades, nil
+}
+
+// targetUpstream returns the latest revision of the upstream package that is
+// newer than the current one and is allowed by the policy, or nil if there is
+// none.
+func targetUpstream(policy *configapi.UpgradePolicy,
+	constraint *semver.Constraints,
+	current *porchapi.PackageRevision,
+	revisions map[int]*porchapi.PackageRevision) (*porchapi.PackageRevision, error) {
+
+	var target *porchapi.PackageRevision
+	for revision, pr := range revisions {
+		if revision <= current.Spec.Revision || !allowsRevision(constraint, revision) {
+			continue
+		}
+		if target != nil && target.Spec.Revision > revision {
+			continue
+		}
+		matches, err := policy.Spec.Upstream.Matches(pr.Spec.RepositoryName, pr.Spec.PackageName, pr.Labels)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			target = pr
+		}
+	}
+	return target, nil
+}
+
+// upstreamPackage finds the registered repository and package that the
+// upstream lock of a package revision points at. Only Published upstream
+// revisions in git repositories are supported.
+func upstreamPackage(lock *porchapi.Locator, repos []configapi.Repository) (packageKey, bool) {
+	if lock == nil || lock.Git == nil {
+		return packageKey{}, false
+	}
+	lastIndex := strings.LastIndex(lock.Git.Ref, "/")
+	if lastIndex < 0 || strings.HasPrefix(lock.Git.Ref, "drafts") {
+		return packageKey{}, false
+	}
+	upstreamPackageName := lock.Git.Ref[:lastIndex]
+
+	for _, repo := range repos {
+		if repo.Spec.Type != configapi.RepositoryTypeGit || repo.Spec.Git == nil {
+			continue
+		}
+		if normalizeGitURL(repo.Spec.Git.Repo) != normalizeGitURL(lock.Git.Repo) {
+			continue
+		}
+		// If the repo has a directory configured, strip it from the upstream package name
+		pkgName := upstreamPackageName
+		if dir := strings.Trim(repo.Spec.Git.Directory, "/"); dir != "" {
+			if !strings.HasPrefix(pkgName, dir+"/") {
+				continue
+			}
+			pkgName = strings.TrimPrefix(pkgName, dir+"/")
+		}
+		return packageKey{repository: repo.Name, pkg: pkgName}, true
+	}
+	return packageKey{}, false
+}
+
+// existingDraft returns an unpublished revision of the downstream package
+// that upgrades it, preferring one that upgrades it to the target upstream
+// revision, or nil if there is none.
+func existingDraft(prs []porchapi.PackageRevision, downstream, target *porchapi.PackageRevision) *porchapi.PackageRevision {
+	var found *porchapi.PackageRevision
+	for i := range prs {
+		pr := &prs[i]
+		if pr.IsPublished() || keyOf(pr) != keyOf(downstream) {
+			continue
+		}
+		newUpstream, ok := upgradeTarget(pr)
+		if !ok {
+			continue
+		}
+		if newUpstream == target.Name {
+			return pr
+		}
+		if found == nil {
+			found = pr
+		}
+	}
+	return found
+}
+
+// upgradeTarget returns the name of the upstream revision that the package
+// revision upgrades to, if it has an upgrade task.
+func upgradeTarget(pr *porchapi.PackageRevision) (string, bool) {
+	for _, task := range pr.Spec.Tasks {
+		if task.Type == porchapi.TaskTypeUpgrade && task.Upgrade != nil {
+			return task.Upgrade.NewUpstream.Name, true
+		}
+	}
+	return "", false
+}
+
+// replaceable reports whether the policy may replace the draft: it created
+// the draft and the draft has not been proposed yet. The caller also checks
+// that the draft is unchanged since the policy created it.
+func replaceable(policy *configapi.UpgradePolicy, draft *porchapi.PackageRevision) bool {
+	return draft.Spec.Lifecycle == porchapi.PackageRevisionLifecycleDraft &&
+		draft.Labels[configapi.UpgradePolicyLabel] == policy.Name
+}
+
+// createdResourceVersion returns the resource version that the policy recorded
+// for the draft when it created it, or "" if it did not record one.
+func createdResourceVersion(policy *configapi.UpgradePolicy, draft string) string {
+	for _, pending := range policy.Status.PendingUpgrades {
+		if pending.Draft == draft {
+			return pending.DraftResourceVersion
+		}
+	}
+	return ""
+}
+
+// managedByPackageVariant reports whether the package revision is owned by a
+// PackageVariant, which upgrades it itself.
+func managedByPackageVariant(pr *porchapi.PackageRevision) bool {
+	for _, owner := range pr.OwnerReferences {
+		if owner.Kind == configapi.TypePackageVariant.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeGitURL(url string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+}
+
+// revisionFromLocator extracts the revision number from a Locator's Git ref.
+func revisionFromLocator(lock *porchapi.Locator) int {
+	lastIndex := strings.LastIndex(lock.Git.Ref, "/")
+	return repository.Revision2Int(lock.Git.Ref[lastIndex+1:])
+}
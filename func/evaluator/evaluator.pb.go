@@ -44,7 +44,15 @@ type EvaluateFunctionRequest struct {
 	// kpt image identifying the function to evaluate
 	Image string `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
 	// optional field for function description.
-	Tag           string `protobuf:"bytes,3,opt,name=Tag,proto3" json:"Tag,omitempty"`
+	Tag string `protobuf:"bytes,3,opt,name=Tag,proto3" json:"Tag,omitempty"`
+	// namespace of the package revision the function is evaluated for, used to
+	// apply the function image policies of the namespace and to share the
+	// function runner fairly between namespaces.
+	Namespace string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// priority of the evaluation. Evaluations a user waits for, such as the
+	// render after an update of PackageRevisionResources, have a higher priority
+	// than background evaluations, such as the renders of controllers.
+	Priority      int32 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -100,6 +108,20 @@ func (x *EvaluateFunctionRequest) GetTag() string {
 	return ""
 }
 
+func (x *EvaluateFunctionRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *EvaluateFunctionRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
 // ConfigMap wraps a map<string, string> for use in oneof clause.
 type ConfigMap struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -204,11 +226,13 @@ var File_evaluator_proto protoreflect.FileDescriptor
 
 const file_evaluator_proto_rawDesc = "" +
 	"\n" +
-	"\x0fevaluator.proto\x12\tevaluator\x1a\fstruct.proto\"f\n" +
+	"\x0fevaluator.proto\x12\tevaluator\x1a\fstruct.proto\"\xa0\x01\n" +
 	"\x17EvaluateFunctionRequest\x12#\n" +
 	"\rresource_list\x18\x01 \x01(\fR\fresourceList\x12\x14\n" +
 	"\x05image\x18\x02 \x01(\tR\x05image\x12\x10\n" +
-	"\x03Tag\x18\x03 \x01(\tR\x03Tag\"x\n" +
+	"\x03Tag\x18\x03 \x01(\tR\x03Tag\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\"x\n" +
 	"\tConfigMap\x122\n" +
 	"\x04data\x18\x01 \x03(\v2\x1e.evaluator.ConfigMap.DataEntryR\x04data\x1a7\n" +
 	"\tDataEntry\x12\x10\n" +
@@ -0,0 +1,25 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+// Priorities of function evaluations, see EvaluateFunctionRequest.Priority.
+const (
+	// PriorityBackground is the priority of evaluations no user is waiting for, such as the
+	// renders of controllers. It is the priority of requests that do not set one.
+	PriorityBackground int32 = 0
+	// PriorityInteractive is the priority of evaluations a user is waiting for, such as the
+	// render after an update of PackageRevisionResources.
+	PriorityInteractive int32 = 1
+)
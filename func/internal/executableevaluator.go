@@ -19,9 +19,11 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"strings"
 
 	kptfilev1 "github.com/kptdev/kpt/api/kptfile/v1"
 	"github.com/kptdev/kpt/pkg/fn"
+	fnsdk "github.com/kptdev/krm-functions-sdk/go/fn"
 	"github.com/kptdev/porch/controllers/functionconfigs/reconciler"
 	pb "github.com/kptdev/porch/func/evaluator"
 	regclientref "github.com/regclient/regclient/types/ref"
@@ -48,6 +50,10 @@ func NewExecutableEvaluator(FunctionConfigStore *reconciler.FunctionConfigStore)
 }
 
 func (e *executableEvaluator) EvaluateFunction(ctx context.Context, req *pb.EvaluateFunctionRequest) (*pb.EvaluateFunctionResponse, error) {
+	if processor, found := e.findScript(req); found {
+		return evaluateScript(req, processor)
+	}
+
 	var selectedBinary string
 	if req.Tag != "" {
 		ref, err := regclientref.New(req.Image)
@@ -98,3 +104,42 @@ func (e *executableEvaluator) EvaluateFunction(ctx context.Context, req *pb.Eval
 		Log:          stderr.Bytes(),
 	}, nil
 }
+
+// findScript returns the script that runs in place of the image of the
+// request, if its FunctionConfig defines one.
+func (e *executableEvaluator) findScript(req *pb.EvaluateFunctionRequest) (fnsdk.ResourceListProcessor, bool) {
+	if req.Tag == "" {
+		return e.FunctionConfigStore.GetScriptFromCache(req.Image)
+	}
+	ref, err := regclientref.New(req.Image)
+	if err != nil {
+		return nil, false
+	}
+	image := req.Image
+	if ref.Tag != "" {
+		image = strings.TrimSuffix(image, ":"+ref.Tag)
+	}
+	return e.FunctionConfigStore.GetScriptFromCacheByConstraint(image, req.Tag)
+}
+
+// evaluateScript runs a script in-process. The script is sandboxed by the
+// limits of its FunctionConfig.
+func evaluateScript(req *pb.EvaluateFunctionRequest, processor fnsdk.ResourceListProcessor) (resp *pb.EvaluateFunctionResponse, err error) {
+	// KRM functions often panic on input validation errors, so we need to convert panics to errors
+	defer func() {
+		if p := recover(); p != nil {
+			err = status.Errorf(codes.Internal, "Failed to execute function %q: script panicked with: %v", req.Image, p)
+		}
+	}()
+
+	klog.Infof("Evaluating %q in script mode", req.Image)
+	var stdout bytes.Buffer
+	if err := fnsdk.Execute(processor, bytes.NewReader(req.ResourceList), &stdout); err != nil {
+		klog.V(4).Infof("Resource List: %s", req.ResourceList)
+		return nil, status.Errorf(codes.Internal, "Failed to execute function %q: %s", req.Image, err)
+	}
+	klog.Infof("Evaluated %q: stdout %d bytes", req.Image, stdout.Len())
+	return &pb.EvaluateFunctionResponse{
+		ResourceList: stdout.Bytes(),
+	}, nil
+}
@@ -345,4 +345,49 @@ items: []
 		assert.Contains(t, logOutput, `(version "0.1.3")`)
 		assert.Contains(t, logOutput, `for request "ghcr.io/kptdev/krm-functions-catalog/set-image"`)
 	})
+	t.Run("script runs in place of the function", func(t *testing.T) {
+		ctx := t.Context()
+
+		const resourceList = `apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: example
+`
+
+		fStore := getFunctionConfigStore(tempCacheDir)
+		require.NoError(t, fStore.UpdateScriptCache("add-label", &configapi.FunctionConfig{
+			Spec: configapi.FunctionConfigSpec{
+				Image:    "add-label",
+				Prefixes: []string{""},
+				ScriptExecutor: &configapi.ScriptExecutorConfig{
+					Tags:     []string{"v0.1.0"},
+					Language: configapi.ScriptLanguageStarlark,
+					Source: `
+for r in ctx.resource_list["items"]:
+  r["metadata"]["labels"] = {"team": "example"}
+`,
+				},
+			},
+		}))
+		evaluator, err := NewExecutableEvaluator(fStore)
+		require.NoError(t, err)
+
+		resp, err := evaluator.EvaluateFunction(ctx, &pb.EvaluateFunctionRequest{
+			ResourceList: []byte(resourceList),
+			Image:        util.ImageJoin(defaultKRMImagePrefix, "add-label"),
+			Tag:          "~0.1",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, string(resp.ResourceList), "team: example")
+
+		resp, err = evaluator.EvaluateFunction(ctx, &pb.EvaluateFunctionRequest{
+			ResourceList: []byte("req-rl"),
+			Image:        util.ImageJoin(defaultKRMImagePrefix, "add-label") + ":v0.1.0",
+		})
+		assert.Nil(t, resp)
+		assert.ErrorContains(t, err, `Failed to execute function "ghcr.io/kptdev/krm-functions-catalog/add-label:v0.1.0"`)
+	})
 }
@@ -0,0 +1,183 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kptdev/porch/func/evaluator"
+	"github.com/kptdev/porch/internal/telemetry"
+)
+
+// interactiveWeight is the share of the function runner an interactive evaluation gets
+// relative to a background evaluation.
+const interactiveWeight = 8
+
+// fairScheduler limits the number of function evaluations that run in pods at the same time
+// and shares them between the namespaces and priorities of the requests with start-time fair
+// queueing: every (namespace, priority) pair has its own queue, and the waiting evaluation with
+// the smallest virtual start time runs next. An evaluation advances the virtual time of its
+// queue by the inverse of the weight of its priority, so a namespace that submits thousands of
+// background renders gets the same share as a namespace that submits a few, and interactive
+// evaluations overtake background ones.
+type fairScheduler struct {
+	capacity int
+
+	mutex   sync.Mutex
+	running int
+	// virtualTime is the start time of the evaluation that was started last
+	virtualTime float64
+	queues      map[fairQueueKey]*fairQueue
+	waiting     int
+}
+
+type fairQueueKey struct {
+	namespace string
+	priority  int32
+}
+
+type fairQueue struct {
+	// finish is the virtual time at which the last evaluation of the queue finishes
+	finish  float64
+	waiters []*fairWaiter
+}
+
+type fairWaiter struct {
+	start float64
+	// ready is closed when the evaluation may start
+	ready   chan struct{}
+	started bool
+}
+
+// newFairScheduler returns a scheduler that runs at most capacity evaluations at the same
+// time, or nil if capacity is not positive, which disables the scheduling.
+func newFairScheduler(capacity int) *fairScheduler {
+	if capacity <= 0 {
+		return nil
+	}
+	return &fairScheduler{
+		capacity: capacity,
+		queues:   map[fairQueueKey]*fairQueue{},
+	}
+}
+
+// acquire waits until an evaluation for the namespace with the given priority may start. The
+// returned function must be called when the evaluation is done. A nil scheduler does not wait.
+func (s *fairScheduler) acquire(ctx context.Context, namespace string, priority int32) (func(), error) {
+	if s == nil {
+		return func() {}, nil
+	}
+
+	s.mutex.Lock()
+	if s.running < s.capacity && s.waiting == 0 {
+		s.running++
+		s.mutex.Unlock()
+		return s.release, nil
+	}
+
+	key := fairQueueKey{namespace: namespace, priority: priority}
+	queue, found := s.queues[key]
+	if !found {
+		queue = &fairQueue{}
+		s.queues[key] = queue
+	}
+	waiter := &fairWaiter{
+		start: max(s.virtualTime, queue.finish),
+		ready: make(chan struct{}),
+	}
+	queue.finish = waiter.start + 1/priorityWeight(priority)
+	queue.waiters = append(queue.waiters, waiter)
+	s.waiting++
+	s.mutex.Unlock()
+	telemetry.RecordFunctionEvaluationQueueDepth(ctx, namespace, priority, 1)
+
+	select {
+	case <-waiter.ready:
+		return s.release, nil
+	case <-ctx.Done():
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if waiter.started {
+			// The evaluation was started while the context was cancelled, pass its slot on
+			s.running--
+			s.startWaiting()
+			return nil, ctx.Err()
+		}
+		for i, w := range queue.waiters {
+			if w == waiter {
+				queue.waiters = append(queue.waiters[:i], queue.waiters[i+1:]...)
+				break
+			}
+		}
+		s.waiting--
+		telemetry.RecordFunctionEvaluationQueueDepth(context.Background(), namespace, priority, -1)
+		return nil, ctx.Err()
+	}
+}
+
+func (s *fairScheduler) release() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.running--
+	s.startWaiting()
+}
+
+// startWaiting starts waiting evaluations in the order of their virtual start times until
+// the capacity is used up. It must be called with the mutex held.
+func (s *fairScheduler) startWaiting() {
+	for s.running < s.capacity && s.waiting > 0 {
+		var nextKey fairQueueKey
+		var next *fairQueue
+		for key, queue := range s.queues {
+			if len(queue.waiters) == 0 {
+				if queue.finish <= s.virtualTime {
+					// The queue has no advantage or debt left, forget it
+					delete(s.queues, key)
+				}
+				continue
+			}
+			if next == nil || queue.waiters[0].start < next.waiters[0].start ||
+				(queue.waiters[0].start == next.waiters[0].start && fairQueueKeyLess(key, nextKey)) {
+				nextKey, next = key, queue
+			}
+		}
+
+		waiter := next.waiters[0]
+		next.waiters = next.waiters[1:]
+		s.waiting--
+		s.running++
+		s.virtualTime = waiter.start
+		waiter.started = true
+		close(waiter.ready)
+		telemetry.RecordFunctionEvaluationQueueDepth(context.Background(), nextKey.namespace, nextKey.priority, -1)
+	}
+}
+
+// fairQueueKeyLess orders queues whose next evaluations have the same virtual start time,
+// higher priorities first.
+func fairQueueKeyLess(a, b fairQueueKey) bool {
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	return a.namespace < b.namespace
+}
+
+func priorityWeight(priority int32) float64 {
+	if priority >= evaluator.PriorityInteractive {
+		return interactiveWeight
+	}
+	return 1
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kptdev/porch/func/evaluator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitingEvaluations(s *fairScheduler) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.waiting
+}
+
+func TestNewFairSchedulerDisabled(t *testing.T) {
+	assert.Nil(t, newFairScheduler(0))
+
+	var s *fairScheduler
+	release, err := s.acquire(context.Background(), "ns", evaluator.PriorityBackground)
+	require.NoError(t, err)
+	release()
+}
+
+func TestFairSchedulerOrder(t *testing.T) {
+	ctx := context.Background()
+	s := newFairScheduler(1)
+
+	release, err := s.acquire(ctx, "busy", evaluator.PriorityBackground)
+	require.NoError(t, err)
+
+	started := make(chan string)
+	enqueue := func(name, namespace string, priority int32) {
+		waiting := waitingEvaluations(s)
+		go func() {
+			release, err := s.acquire(ctx, namespace, priority)
+			if !assert.NoError(t, err) {
+				return
+			}
+			started <- name
+			release()
+		}()
+		require.Eventually(t, func() bool { return waitingEvaluations(s) == waiting+1 }, time.Second, time.Millisecond)
+	}
+
+	// A namespace mass-rendering packages does not delay the other namespaces behind all of its renders
+	enqueue("busy-1", "busy", evaluator.PriorityBackground)
+	enqueue("busy-2", "busy", evaluator.PriorityBackground)
+	enqueue("busy-3", "busy", evaluator.PriorityBackground)
+	enqueue("quiet-1", "quiet", evaluator.PriorityBackground)
+	enqueue("interactive-1", "quiet", evaluator.PriorityInteractive)
+	enqueue("quiet-2", "quiet", evaluator.PriorityBackground)
+
+	release()
+
+	var order []string
+	for range 6 {
+		select {
+		case name := <-started:
+			order = append(order, name)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("evaluations did not start, started so far: %v", order)
+		}
+	}
+	assert.Equal(t, []string{"interactive-1", "busy-1", "quiet-1", "busy-2", "quiet-2", "busy-3"}, order)
+}
+
+func TestFairSchedulerCapacity(t *testing.T) {
+	ctx := context.Background()
+	s := newFairScheduler(2)
+
+	release1, err := s.acquire(ctx, "ns", evaluator.PriorityBackground)
+	require.NoError(t, err)
+	release2, err := s.acquire(ctx, "ns", evaluator.PriorityBackground)
+	require.NoError(t, err)
+
+	acquired := make(chan func())
+	go func() {
+		release, err := s.acquire(ctx, "ns", evaluator.PriorityBackground)
+		if assert.NoError(t, err) {
+			acquired <- release
+		}
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("evaluation started while the scheduler was at capacity")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release1()
+	select {
+	case release3 := <-acquired:
+		release3()
+	case <-time.After(5 * time.Second):
+		t.Fatal("evaluation did not start after capacity was released")
+	}
+	release2()
+}
+
+func TestFairSchedulerCancel(t *testing.T) {
+	s := newFairScheduler(1)
+	release, err := s.acquire(context.Background(), "ns", evaluator.PriorityBackground)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() {
+		_, err := s.acquire(ctx, "other", evaluator.PriorityBackground)
+		errCh <- err
+	}()
+	require.Eventually(t, func() bool { return waitingEvaluations(s) == 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-errCh, context.Canceled)
+	assert.Equal(t, 0, waitingEvaluations(s))
+
+	release()
+	release, err = s.acquire(context.Background(), "ns", evaluator.PriorityBackground)
+	require.NoError(t, err)
+	release()
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// imageRejectedError reports a function image that must not be evaluated.
+type imageRejectedError struct {
+	image  string
+	reason string
+}
+
+func (e *imageRejectedError) Error() string {
+	return fmt.Sprintf("function image %q rejected: %s", e.image, e.reason)
+}
+
+// checkFunctionImagePolicies returns an *imageRejectedError if a FunctionImagePolicy in the
+// namespace rejects the image. Requests that do not carry a namespace are not subject to
+// FunctionImagePolicies.
+func checkFunctionImagePolicies(ctx context.Context, reader client.Reader, namespace, image string) error {
+	if reader == nil || namespace == "" {
+		return nil
+	}
+
+	var policies configapi.FunctionImagePolicyList
+	if err := reader.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			// The FunctionImagePolicy CRD is not installed, so no policy restricts the image.
+			return nil
+		}
+		return fmt.Errorf("unable to list the FunctionImagePolicies of namespace %q: %w", namespace, err)
+	}
+
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if !policy.AllowsImage(image) {
+			return &imageRejectedError{
+				image:  image,
+				reason: fmt.Sprintf("not allowed by FunctionImagePolicy %s/%s", policy.Namespace, policy.Name),
+			}
+		}
+	}
+	return nil
+}
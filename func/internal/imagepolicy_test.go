@@ -0,0 +1,61 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckFunctionImagePolicies(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, configapi.AddToScheme(scheme))
+
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&configapi.FunctionImagePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "approved", Namespace: "restricted"},
+			Spec: configapi.FunctionImagePolicySpec{
+				Allow: []string{"ghcr.io/kptdev/krm-functions-catalog"},
+				Deny:  []string{"ghcr.io/kptdev/krm-functions-catalog/starlark"},
+			},
+		},
+	).Build()
+
+	assert.NoError(t, checkFunctionImagePolicies(ctx, reader, "restricted", "ghcr.io/kptdev/krm-functions-catalog/set-namespace:v0.4.1"))
+	assert.NoError(t, checkFunctionImagePolicies(ctx, reader, "restricted", "ghcr.io/kptdev/krm-functions-catalog/set-labels@sha256:0123"))
+
+	err := checkFunctionImagePolicies(ctx, reader, "restricted", "docker.io/someone/set-namespace:v1")
+	var rejected *imageRejectedError
+	require.True(t, errors.As(err, &rejected))
+	assert.Equal(t, "docker.io/someone/set-namespace:v1", rejected.image)
+	assert.Contains(t, err.Error(), "restricted/approved")
+
+	err = checkFunctionImagePolicies(ctx, reader, "restricted", "ghcr.io/kptdev/krm-functions-catalog/starlark:v0.5")
+	assert.True(t, errors.As(err, &rejected))
+
+	// Namespaces without FunctionImagePolicies and requests without a namespace allow any image
+	assert.NoError(t, checkFunctionImagePolicies(ctx, reader, "open", "docker.io/someone/set-namespace:v1"))
+	assert.NoError(t, checkFunctionImagePolicies(ctx, reader, "", "docker.io/someone/set-namespace:v1"))
+	assert.NoError(t, checkFunctionImagePolicies(ctx, nil, "restricted", "docker.io/someone/set-namespace:v1"))
+}
@@ -327,7 +327,7 @@ func (pcm *podCacheManager) retrieveFunctionPods(ctx context.Context) error {
 						continue
 					}
 
-					image := pod.Spec.Containers[0].Image
+					image := functionImage(&pod)
 					fn := pcm.FunctionInfo(image)
 					if len(fn.pods) < pcm.maxParallelPodsPerFunction && pod.Status.Phase == corev1.PodRunning {
 						pData, err := pcm.podManager.createPodData(ctx, serviceKey, podKey, image)
@@ -16,6 +16,8 @@ package internal
 
 import (
 	"context"
+	"crypto"
+	"errors"
 	"fmt"
 	"sync/atomic"
 	"time"
@@ -24,6 +26,7 @@ import (
 	"github.com/kptdev/kpt/pkg/lib/runneroptions"
 	fnconf "github.com/kptdev/porch/controllers/functionconfigs/reconciler"
 	"github.com/kptdev/porch/func/evaluator"
+	"github.com/kptdev/porch/internal/telemetry"
 	"github.com/kptdev/porch/pkg/util"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -40,6 +43,7 @@ const (
 	gRPCProbeBin                      = "grpc-health-probe"
 	krmFunctionImageLabel             = "fn.kpt.dev/image"
 	templateVersionAnnotation         = "fn.kpt.dev/template-version"
+	functionImageAnnotation           = "fn.kpt.dev/function-image"
 	fieldManagerName                  = "krm-function-runner"
 	functionContainerName             = "function"
 	defaultManagerNamespace           = "porch-system"
@@ -57,6 +61,11 @@ type podEvaluator struct {
 
 	podCacheManager *podCacheManager
 	maxGrpcRetries  int
+	resultCache     *resultCache
+	// imagePolicyReader reads the FunctionImagePolicies of namespaces, nil disables them
+	imagePolicyReader client.Reader
+	// scheduler shares the pod evaluations fairly between namespaces, nil disables it
+	scheduler *fairScheduler
 }
 
 type PodEvaluatorOptions struct {
@@ -75,6 +84,11 @@ type PodEvaluatorOptions struct {
 	MaxWaitlistLength          int           // Maximum waitlist length per pod
 	MaxParallelPodsPerFunction int           // Maximum parallel pods per function
 	MaxGrpcRetries             int           // Maximum number of retries on gRPC Unavailable errors
+	ResultCacheSize            int           // Maximum number of cached function results, 0 disables the result cache
+	ResultCacheTTL             time.Duration // Time-to-live of cached function results
+	VerifyImageSignatures      bool          // If true, pods are only created for images with a cosign signature made with one of the public keys
+	ImageSignatureKeysPath     string        // Path of a PEM file or a directory of PEM files holding the public keys of image signatures
+	MaxConcurrentEvaluations   int           // Maximum number of concurrent pod evaluations, shared fairly between namespaces, 0 means unlimited
 }
 
 var _ Evaluator = &podEvaluator{}
@@ -132,14 +146,26 @@ func NewPodEvaluator(ctx context.Context, o PodEvaluatorOptions, cl client.Clien
 		managerNs = defaultManagerNamespace
 	}
 
+	var publicKeys []crypto.PublicKey
+	if o.VerifyImageSignatures {
+		publicKeys, err = loadSignaturePublicKeys(o.ImageSignatureKeysPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the public keys of image signatures: %w", err)
+		}
+		klog.Infof("verifying function image signatures with %d public keys", len(publicKeys))
+	}
+
 	reqCh := make(chan *connectionRequest, channelBufferSize)
 	readyCh := make(chan *podReadyResponse, channelBufferSize)
 	evictCh := make(chan *podEvictionRequest, channelBufferSize)
 
 	pe := &podEvaluator{
-		requestCh:      reqCh,
-		evictionCh:     evictCh,
-		maxGrpcRetries: maxRetries,
+		requestCh:         reqCh,
+		evictionCh:        evictCh,
+		maxGrpcRetries:    maxRetries,
+		resultCache:       newResultCache(o.ResultCacheSize, o.ResultCacheTTL),
+		imagePolicyReader: cl,
+		scheduler:         newFairScheduler(o.MaxConcurrentEvaluations),
 		podCacheManager: &podCacheManager{
 			gcScanInterval:             o.GcScanInterval,
 			podTTL:                     o.PodTTL,
@@ -170,6 +196,10 @@ func NewPodEvaluator(ctx context.Context, o PodEvaluatorOptions, cl client.Clien
 			},
 		},
 	}
+	if o.VerifyImageSignatures {
+		pm := pe.podCacheManager.podManager
+		pm.signatureVerifier = newSignatureVerifier(publicKeys, pm.imageSignatures)
+	}
 	go pe.podCacheManager.podCacheManager(ctx)
 
 	err = pe.podCacheManager.retrieveFunctionPods(context.Background())
@@ -203,6 +233,31 @@ func (pe *podEvaluator) EvaluateFunction(ctx context.Context, req *evaluator.Eva
 	}
 	req.Image = image
 
+	if err := pe.checkImage(ctx, req.Namespace, req.Image); err != nil {
+		var rejected *imageRejectedError
+		if errors.As(err, &rejected) {
+			klog.Warningf("rejected evaluating %v for namespace %q: %v", req.Image, req.Namespace, err)
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, fmt.Errorf("unable to check function image %v: %w", req.Image, err)
+	}
+
+	cacheKey := pe.resultCacheKey(ctx, req)
+	if cacheKey != "" {
+		if resp, found := pe.resultCache.get(cacheKey); found {
+			telemetry.RecordFunctionResultCacheLookup(ctx, req.Image, true)
+			klog.V(2).Infof("using cached result of evaluating %v", req.Image)
+			return resp, nil
+		}
+		telemetry.RecordFunctionResultCacheLookup(ctx, req.Image, false)
+	}
+
+	release, err := pe.scheduler.acquire(ctx, req.Namespace, req.Priority)
+	if err != nil {
+		return nil, fmt.Errorf("function evaluation timed out for %v while waiting in the queue: %w", req.Image, err)
+	}
+	defer release()
+
 	maxRetries := pe.maxGrpcRetries
 	var lastErr error
 
@@ -277,6 +332,9 @@ func (pe *podEvaluator) EvaluateFunction(ctx context.Context, req *evaluator.Eva
 			if len(resp.Log) > 0 {
 				klog.Warningf("evaluating %q succeeded, but stderr is: %v", req.Image, string(resp.Log))
 			}
+			if cacheKey != "" {
+				pe.resultCache.add(cacheKey, resp)
+			}
 			return resp, nil
 		case <-ctx.Done():
 			return nil, fmt.Errorf("function evaluation timed out for %v: %w", req.Image, ctx.Err())
@@ -285,3 +343,43 @@ func (pe *podEvaluator) EvaluateFunction(ctx context.Context, req *evaluator.Eva
 
 	return nil, fmt.Errorf("unable to evaluate %v with pod evaluator after retries: %w", req.Image, lastErr)
 }
+
+// checkImage applies the FunctionImagePolicies of the namespace to the image and verifies its
+// signature if signature verification is enabled. It runs before a cached result is returned or
+// a pod is requested, so a rejected image never runs.
+func (pe *podEvaluator) checkImage(ctx context.Context, namespace, image string) error {
+	pm := pe.podCacheManager.podManager
+	if pm.imageResolver != nil {
+		image = pm.imageResolver(image)
+	}
+	if err := checkFunctionImagePolicies(ctx, pe.imagePolicyReader, namespace, image); err != nil {
+		return err
+	}
+	return pm.verifyImageSignature(ctx, image)
+}
+
+// resultCacheKey returns the result cache key of an evaluation request whose image has
+// already been resolved, or "" if the result must not be cached: the result cache is
+// disabled, the FunctionConfig of the image opts out of it, or the image digest is unknown.
+func (pe *podEvaluator) resultCacheKey(ctx context.Context, req *evaluator.EvaluateFunctionRequest) string {
+	if pe.resultCache == nil {
+		return ""
+	}
+
+	var generation int64
+	if pe.podCacheManager.functionConfigMap != nil {
+		if fc, exists := pe.podCacheManager.functionConfigMap.GetFunctionConfig(util.GetImageName(req.Image)); exists {
+			if fc.Spec.DisableResultCache {
+				return ""
+			}
+			generation = fc.Generation
+		}
+	}
+
+	de, err := pe.podCacheManager.podManager.imageDigestAndEntrypoint(ctx, req.Image)
+	if err != nil {
+		klog.Warningf("unable to get the digest of %v, its result will not be cached: %v", req.Image, err)
+		return ""
+	}
+	return resultCacheKey(de.digest, generation, req.ResourceList)
+}
@@ -18,8 +18,11 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
@@ -33,6 +36,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	containerregistry "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	"github.com/kptdev/kpt/pkg/fn/runtime"
 	"github.com/kptdev/kpt/pkg/lib/runneroptions"
 	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
@@ -222,6 +226,8 @@ type podManager struct {
 	tagResolver runtime.TagResolver
 	// skipGrpcReadyCheck disables the gRPC readiness verification during pod creation (for testing)
 	skipGrpcReadyCheck bool
+	// signatureVerifier verifies image signatures before pods are created, nil if signature verification is disabled
+	signatureVerifier *signatureVerifier
 }
 
 type digestAndEntrypoint struct {
@@ -409,25 +415,95 @@ func (pm *podManager) imageDigestAndEntrypoint(ctx context.Context, image string
 		return nil, err
 	}
 
-	var auth authn.Authenticator
+	auth, err := pm.imageAuth(ctx, ref, image)
+	if err != nil {
+		return nil, err
+	}
+
+	return pm.getImageMetadata(ctx, ref, auth, image)
+}
+
+// imageAuth returns the authenticator used to read the image from its registry.
+func (pm *podManager) imageAuth(ctx context.Context, ref name.Reference, image string) (authn.Authenticator, error) {
 	if pm.enablePrivateRegistries && !strings.HasPrefix(image, defaultRegistry) {
 		if err := pm.ensureCustomAuthSecret(ctx, pm.registryAuthSecretPath, pm.registryAuthSecretName); err != nil {
 			return nil, err
 		}
+		return pm.getCustomAuth(ref, pm.registryAuthSecretPath)
+	}
 
-		auth, err = pm.getCustomAuth(ref, pm.registryAuthSecretPath)
+	auth, err := authn.DefaultKeychain.Resolve(ref.Context())
+	if err != nil {
+		klog.Errorf("error resolving default keychain: %v", err)
+		return nil, err
+	}
+	return auth, nil
+}
+
+// verifyImageSignature verifies the signature of the image if signature verification is enabled.
+func (pm *podManager) verifyImageSignature(ctx context.Context, image string) error {
+	if pm.signatureVerifier == nil {
+		return nil
+	}
+	de, err := pm.imageDigestAndEntrypoint(ctx, image)
+	if err != nil {
+		return fmt.Errorf("unable to get the digest of %v: %w", image, err)
+	}
+	return pm.signatureVerifier.verify(ctx, image, "sha256:"+de.digest)
+}
+
+// imageSignatures reads the cosign signatures of the image with the given digest from the
+// registry of the image. An image without signatures has no signature tag.
+func (pm *podManager) imageSignatures(ctx context.Context, image, digest string) ([]cosignSignature, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	signatureRef := ref.Context().Tag(cosignSignatureTag(digest))
+
+	auth, err := pm.imageAuth(ctx, ref, image)
+	if err != nil {
+		return nil, err
+	}
+	img, err := pm.getImage(ctx, signatureRef, auth, image)
+	if err != nil {
+		var transportErr *transport.Error
+		if errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	var signatures []cosignSignature
+	for _, layer := range manifest.Layers {
+		encoded, found := layer.Annotations[cosignSignatureAnnotation]
+		if !found || layer.Size > maxSignaturePayloadSize {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		blob, err := img.LayerByDigest(layer.Digest)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		auth, err = authn.DefaultKeychain.Resolve(ref.Context())
+		rc, err := blob.Compressed()
+		if err != nil {
+			return nil, err
+		}
+		payload, err := io.ReadAll(io.LimitReader(rc, maxSignaturePayloadSize))
+		rc.Close()
 		if err != nil {
-			klog.Errorf("error resolving default keychain: %v", err)
 			return nil, err
 		}
+		signatures = append(signatures, cosignSignature{payload: payload, signature: signature})
 	}
-
-	return pm.getImageMetadata(ctx, ref, auth, image)
+	return signatures, nil
 }
 
 // ensureCustomAuthSecret ensures that, if an image from a custom registry is requested, the appropriate credentials are passed into a secret for function pods to use when pulling. If the secret does not already exist, it is created.
@@ -557,6 +633,9 @@ func (pm *podManager) CreatePod(ctx context.Context, image string, postFix int,
 	if err != nil {
 		return nil, fmt.Errorf("unable to get the entrypoint for %v: %w", image, err)
 	}
+	if err := pm.verifyImageSignature(ctx, image); err != nil {
+		return nil, err
+	}
 
 	podId, err := podID(image, de.digest, strconv.Itoa(postFix))
 	if err != nil {
@@ -812,6 +891,15 @@ func (pm *podManager) patchNewPodContainer(pod *corev1.PodTemplateSpec, de diges
 			)
 			container.Args = append(container.Args, de.entrypoint...)
 			container.Image = image
+			if pm.signatureVerifier != nil {
+				// Run the digest whose signature was verified, so that moving the tag to another
+				// image between the verification and the pull cannot run an unsigned image.
+				container.Image = imageWithDigest(image, de.digest)
+				if pod.Annotations == nil {
+					pod.Annotations = map[string]string{}
+				}
+				pod.Annotations[functionImageAnnotation] = image
+			}
 			patchedContainer = true
 		}
 	}
@@ -821,6 +909,25 @@ func (pm *podManager) patchNewPodContainer(pod *corev1.PodTemplateSpec, de diges
 	return nil
 }
 
+// imageWithDigest returns the image pinned to the given sha256 digest. An image that is
+// already referenced by digest is returned unchanged.
+func imageWithDigest(image, digest string) string {
+	if strings.Contains(image, "@") {
+		return image
+	}
+	return image + "@sha256:" + digest
+}
+
+// functionImage returns the image of the function that a function pod runs. The function
+// container of a pod whose image signature was verified runs the image by digest, so the
+// image requested for the function is recorded in an annotation.
+func functionImage(pod *corev1.Pod) string {
+	if image, found := pod.Annotations[functionImageAnnotation]; found {
+		return image
+	}
+	return pod.Spec.Containers[0].Image
+}
+
 // Patch labels and annotations so the cache manager can keep track of the pod
 func (pm *podManager) patchNewPodMetadata(pod *corev1.PodTemplateSpec, podId string, templateVersion string) {
 	pod.Namespace = pm.namespace
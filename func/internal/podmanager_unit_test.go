@@ -290,6 +290,37 @@ func TestPatchNewPodContainer(t *testing.T) {
 		assert.Equal(t, "sidecar-image", podTemplateSpec.Spec.Containers[0].Image, "sidecar should be unchanged")
 		assert.Equal(t, "my-image", podTemplateSpec.Spec.Containers[1].Image, "function container should be patched")
 	})
+
+	t.Run("pins the verified digest when signatures are verified", func(t *testing.T) {
+		pm := &podManager{
+			maxGrpcMessageSize: 4 * 1024 * 1024,
+			signatureVerifier:  newSignatureVerifier(nil, nil),
+		}
+		podTemplateSpec := &corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: functionContainerName, Image: "to-be-replaced"},
+				},
+			},
+		}
+		de := digestAndEntrypoint{
+			digest:     "abc123",
+			entrypoint: []string{"/fn"},
+		}
+
+		err := pm.patchNewPodContainer(podTemplateSpec, de, "example.com/fn:v1")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com/fn:v1@sha256:abc123", podTemplateSpec.Spec.Containers[0].Image)
+		assert.Equal(t, "example.com/fn:v1", podTemplateSpec.Annotations[functionImageAnnotation])
+
+		pod := &corev1.Pod{ObjectMeta: podTemplateSpec.ObjectMeta, Spec: podTemplateSpec.Spec}
+		assert.Equal(t, "example.com/fn:v1", functionImage(pod))
+	})
+}
+
+func TestImageWithDigest(t *testing.T) {
+	assert.Equal(t, "example.com/fn:v1@sha256:abc123", imageWithDigest("example.com/fn:v1", "abc123"))
+	assert.Equal(t, "example.com/fn@sha256:def456", imageWithDigest("example.com/fn@sha256:def456", "abc123"))
 }
 
 func TestPatchNewPodMetadata(t *testing.T) {
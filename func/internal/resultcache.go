@@ -0,0 +1,87 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+
+	"github.com/kptdev/porch/func/evaluator"
+	"k8s.io/utils/lru"
+)
+
+// resultCache is a bounded, content-addressed cache of function evaluation results.
+// Entries are keyed by the digest of the function image, the generation of the
+// FunctionConfig that applies to the image and the input ResourceList, which
+// carries the functionConfig of the function. Entries expire after ttl.
+type resultCache struct {
+	ttl   time.Duration
+	cache *lru.Cache
+	// now is replaced in tests
+	now func() time.Time
+}
+
+type resultCacheEntry struct {
+	response *evaluator.EvaluateFunctionResponse
+	expires  time.Time
+}
+
+// newResultCache returns a cache holding at most maxEntries results, or nil if
+// maxEntries is not positive, which disables result caching.
+func newResultCache(maxEntries int, ttl time.Duration) *resultCache {
+	if maxEntries <= 0 {
+		return nil
+	}
+	return &resultCache{
+		ttl:   ttl,
+		cache: lru.New(maxEntries),
+		now:   time.Now,
+	}
+}
+
+// resultCacheKey returns the key of the result of evaluating the function image
+// with the given digest on a ResourceList.
+func resultCacheKey(imageDigest string, functionConfigGeneration int64, resourceList []byte) string {
+	h := sha256.New()
+	h.Write([]byte(imageDigest))
+	h.Write([]byte{0})
+	_ = binary.Write(h, binary.BigEndian, functionConfigGeneration)
+	h.Write(resourceList)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns the cached result for key, if one exists and has not expired.
+func (c *resultCache) get(key string) (*evaluator.EvaluateFunctionResponse, bool) {
+	val, found := c.cache.Get(key)
+	if !found {
+		return nil, false
+	}
+	entry := val.(*resultCacheEntry)
+	if c.ttl > 0 && c.now().After(entry.expires) {
+		c.cache.Remove(key)
+		return nil, false
+	}
+	return entry.response, true
+}
+
+// add stores the result for key, evicting the least recently used result if the cache is full.
+func (c *resultCache) add(key string, response *evaluator.EvaluateFunctionResponse) {
+	c.cache.Add(key, &resultCacheEntry{
+		response: response,
+		expires:  c.now().Add(c.ttl),
+	})
+}
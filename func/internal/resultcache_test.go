@@ -0,0 +1,159 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kptdev/kpt/pkg/fn/runtime"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	fnconf "github.com/kptdev/porch/controllers/functionconfigs/reconciler"
+	pb "github.com/kptdev/porch/func/evaluator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewResultCacheDisabled(t *testing.T) {
+	assert.Nil(t, newResultCache(0, time.Minute))
+	assert.Nil(t, newResultCache(-1, time.Minute))
+}
+
+func TestResultCacheKey(t *testing.T) {
+	key := resultCacheKey("sha256:aaaa", 1, []byte("resource-list"))
+
+	assert.Equal(t, key, resultCacheKey("sha256:aaaa", 1, []byte("resource-list")))
+	assert.NotEqual(t, key, resultCacheKey("sha256:bbbb", 1, []byte("resource-list")))
+	assert.NotEqual(t, key, resultCacheKey("sha256:aaaa", 2, []byte("resource-list")))
+	assert.NotEqual(t, key, resultCacheKey("sha256:aaaa", 1, []byte("other-resource-list")))
+}
+
+func TestResultCacheGetAndAdd(t *testing.T) {
+	now := time.Now()
+	c := newResultCache(2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	resp1 := &pb.EvaluateFunctionResponse{ResourceList: []byte("one")}
+	resp2 := &pb.EvaluateFunctionResponse{ResourceList: []byte("two")}
+	resp3 := &pb.EvaluateFunctionResponse{ResourceList: []byte("three")}
+
+	_, found := c.get("one")
+	assert.False(t, found)
+
+	c.add("one", resp1)
+	c.add("two", resp2)
+	got, found := c.get("one")
+	require.True(t, found)
+	assert.Equal(t, resp1, got)
+
+	// "two" is the least recently used entry and is evicted
+	c.add("three", resp3)
+	_, found = c.get("two")
+	assert.False(t, found)
+	_, found = c.get("one")
+	assert.True(t, found)
+	_, found = c.get("three")
+	assert.True(t, found)
+
+	// entries expire after the TTL
+	now = now.Add(2 * time.Minute)
+	_, found = c.get("one")
+	assert.False(t, found)
+	assert.Equal(t, 1, c.cache.Len())
+}
+
+func TestEvaluateFunction_ResultCache(t *testing.T) {
+	var calls atomic.Int32
+	addr, cleanup := startFakeEvalServer(t, func(_ context.Context, req *pb.EvaluateFunctionRequest) (*pb.EvaluateFunctionResponse, error) {
+		calls.Add(1)
+		return &pb.EvaluateFunctionResponse{ResourceList: append([]byte("evaluated-"), req.ResourceList...)}, nil
+	})
+	defer cleanup()
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	newEvaluator := func(store *fnconf.FunctionConfigStore) *podEvaluator {
+		reqCh := make(chan *connectionRequest, 1)
+		go func() {
+			for req := range reqCh {
+				counter := &atomic.Int32{}
+				counter.Store(1)
+				req.responseCh <- &connectionResponse{
+					podData:               podData{image: req.image, grpcConnection: conn},
+					concurrentEvaluations: counter,
+				}
+			}
+		}()
+		t.Cleanup(func() { close(reqCh) })
+
+		pe := &podEvaluator{
+			requestCh:   reqCh,
+			resultCache: newResultCache(10, time.Minute),
+			podCacheManager: &podCacheManager{
+				functionConfigMap: store,
+				podManager: &podManager{
+					tagResolver: runtime.TagResolver{},
+				},
+			},
+		}
+		pe.podCacheManager.podManager.imageMetadataCache.Store("test-image", &digestAndEntrypoint{digest: "sha256:aaaa"})
+		return pe
+	}
+
+	evaluate := func(pe *podEvaluator, input string) string {
+		resp, err := pe.EvaluateFunction(t.Context(), &pb.EvaluateFunctionRequest{
+			Image:        "test-image",
+			ResourceList: []byte(input),
+		})
+		require.NoError(t, err)
+		return string(resp.ResourceList)
+	}
+
+	t.Run("identical requests are evaluated once", func(t *testing.T) {
+		calls.Store(0)
+		pe := newEvaluator(fnconf.NewFunctionConfigStore("", ""))
+
+		assert.Equal(t, "evaluated-input", evaluate(pe, "input"))
+		assert.Equal(t, "evaluated-input", evaluate(pe, "input"))
+		assert.Equal(t, int32(1), calls.Load())
+
+		assert.Equal(t, "evaluated-other", evaluate(pe, "other"))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("function config opts out", func(t *testing.T) {
+		calls.Store(0)
+		store := fnconf.NewFunctionConfigStore("", "")
+		store.UpsertFunctionConfig("test-image", &configapi.FunctionConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-image"},
+			Spec: configapi.FunctionConfigSpec{
+				Image:              "test-image",
+				DisableResultCache: true,
+			},
+		})
+		pe := newEvaluator(store)
+
+		evaluate(pe, "input")
+		evaluate(pe, "input")
+		assert.Equal(t, int32(2), calls.Load())
+	})
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// cosignSignatureAnnotation holds the base64 encoded signature of a cosign signature layer
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// maxSignaturePayloadSize limits the size of the signature payloads read from registries
+	maxSignaturePayloadSize = 1 << 20
+	// defaultSignatureFailureTTL is how long a failed verification is cached
+	defaultSignatureFailureTTL = time.Minute
+)
+
+// cosignSignature is a signature of a container image in cosign's "simple signing" format.
+type cosignSignature struct {
+	payload   []byte
+	signature []byte
+}
+
+// cosignPayload is the part of a simple signing payload that binds the signature to an image.
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// signatureVerifier verifies the cosign signatures of function images against a set of public
+// keys. Signatures are read from the registry of the image and no transparency log is consulted,
+// so verification does not depend on the public Sigstore infrastructure.
+//
+// Results are cached by image digest: successful verifications for the lifetime of the verifier,
+// failed ones for failureTTL so that signatures pushed later are picked up.
+type signatureVerifier struct {
+	publicKeys []crypto.PublicKey
+	// fetchSignatures reads the cosign signatures of the image with the given digest
+	fetchSignatures func(ctx context.Context, image, digest string) ([]cosignSignature, error)
+	failureTTL      time.Duration
+	// now is replaced in tests
+	now func() time.Time
+
+	mutex   sync.Mutex
+	results map[string]signatureVerification
+}
+
+type signatureVerification struct {
+	err     error
+	expires time.Time
+}
+
+func newSignatureVerifier(publicKeys []crypto.PublicKey, fetchSignatures func(ctx context.Context, image, digest string) ([]cosignSignature, error)) *signatureVerifier {
+	return &signatureVerifier{
+		publicKeys:      publicKeys,
+		fetchSignatures: fetchSignatures,
+		failureTTL:      defaultSignatureFailureTTL,
+		now:             time.Now,
+		results:         map[string]signatureVerification{},
+	}
+}
+
+// verify returns an *imageRejectedError if the image with the given digest, e.g. "sha256:abc...",
+// has no signature that can be verified with one of the public keys.
+func (v *signatureVerifier) verify(ctx context.Context, image, digest string) error {
+	v.mutex.Lock()
+	cached, found := v.results[digest]
+	v.mutex.Unlock()
+	if found && (cached.err == nil || v.now().Before(cached.expires)) {
+		return cached.err
+	}
+
+	signatures, err := v.fetchSignatures(ctx, image, digest)
+	if err != nil {
+		// Registry errors are not cached, the next evaluation tries again
+		return fmt.Errorf("unable to read the signatures of %v: %w", image, err)
+	}
+
+	err = v.verifySignatures(image, digest, signatures)
+	v.mutex.Lock()
+	v.results[digest] = signatureVerification{err: err, expires: v.now().Add(v.failureTTL)}
+	v.mutex.Unlock()
+	return err
+}
+
+func (v *signatureVerifier) verifySignatures(image, digest string, signatures []cosignSignature) error {
+	if len(signatures) == 0 {
+		return &imageRejectedError{image: image, reason: fmt.Sprintf("no signature found for digest %s", digest)}
+	}
+	for _, signature := range signatures {
+		var payload cosignPayload
+		if err := json.Unmarshal(signature.payload, &payload); err != nil || payload.Critical.Image.DockerManifestDigest != digest {
+			// The signature is for another image
+			continue
+		}
+		for _, key := range v.publicKeys {
+			if verifySignature(key, signature.payload, signature.signature) == nil {
+				return nil
+			}
+		}
+	}
+	return &imageRejectedError{
+		image:  image,
+		reason: fmt.Sprintf("no signature of digest %s could be verified with the configured public keys", digest),
+	}
+}
+
+// verifySignature verifies a signature of payload made with the private key of key.
+func verifySignature(key crypto.PublicKey, payload, signature []byte) error {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		if !ecdsa.VerifyASN1(k, digest[:], signature) {
+			return errors.New("invalid ECDSA signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature)
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, payload, signature) {
+			return errors.New("invalid Ed25519 signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// cosignSignatureTag returns the tag under which cosign stores the signatures of the image
+// with the given digest, e.g. "sha256-abc....sig".
+func cosignSignatureTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1) + ".sig"
+}
+
+// loadSignaturePublicKeys reads the PEM encoded public keys in the file or directory at path.
+// Hidden files in a directory, such as the "..data" entries of a mounted Secret, are skipped.
+func loadSignaturePublicKeys(path string) ([]crypto.PublicKey, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files = nil
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			file := filepath.Join(path, entry.Name())
+			if info, err := os.Stat(file); err != nil || info.IsDir() {
+				continue
+			}
+			files = append(files, file)
+		}
+	}
+
+	var keys []crypto.PublicKey
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			var block *pem.Block
+			block, data = pem.Decode(data)
+			if block == nil {
+				break
+			}
+			if block.Type != "PUBLIC KEY" {
+				continue
+			}
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse public key in %s: %w", file, err)
+			}
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no PEM encoded public keys found in %s", path)
+	}
+	return keys, nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testImageDigest = "sha256:4c5d4e1cb5b0c7a9e3c0d4c3c1f0a8c7e2c1d7f3b0a9e8d7c6b5a4f3e2d1c0b9"
+
+func simpleSigningPayload(digest string) []byte {
+	return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"example.com/fn"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, digest))
+}
+
+func signECDSA(t *testing.T, key *ecdsa.PrivateKey, payload []byte) []byte {
+	digest := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	return signature
+}
+
+func TestSignatureVerifierVerify(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	payload := simpleSigningPayload(testImageDigest)
+
+	testCases := map[string]struct {
+		signatures []cosignSignature
+		rejected   bool
+	}{
+		"valid signature": {
+			signatures: []cosignSignature{{payload: payload, signature: signECDSA(t, key, payload)}},
+		},
+		"one of several signatures valid": {
+			signatures: []cosignSignature{
+				{payload: payload, signature: signECDSA(t, otherKey, payload)},
+				{payload: payload, signature: signECDSA(t, key, payload)},
+			},
+		},
+		"no signatures": {
+			rejected: true,
+		},
+		"signed with unknown key": {
+			signatures: []cosignSignature{{payload: payload, signature: signECDSA(t, otherKey, payload)}},
+			rejected:   true,
+		},
+		"signature of another digest": {
+			signatures: []cosignSignature{{
+				payload:   simpleSigningPayload("sha256:0000"),
+				signature: signECDSA(t, key, simpleSigningPayload("sha256:0000")),
+			}},
+			rejected: true,
+		},
+		"malformed payload": {
+			signatures: []cosignSignature{{payload: []byte("{"), signature: signECDSA(t, key, []byte("{"))}},
+			rejected:   true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			verifier := newSignatureVerifier([]crypto.PublicKey{&key.PublicKey}, func(context.Context, string, string) ([]cosignSignature, error) {
+				return tc.signatures, nil
+			})
+			err := verifier.verify(ctx, "example.com/fn:v1", testImageDigest)
+			if !tc.rejected {
+				assert.NoError(t, err)
+				return
+			}
+			var rejected *imageRejectedError
+			assert.True(t, errors.As(err, &rejected), "expected an imageRejectedError, got %v", err)
+		})
+	}
+}
+
+func TestSignatureVerifierEd25519(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	payload := simpleSigningPayload(testImageDigest)
+	verifier := newSignatureVerifier([]crypto.PublicKey{publicKey}, func(context.Context, string, string) ([]cosignSignature, error) {
+		return []cosignSignature{{payload: payload, signature: ed25519.Sign(privateKey, payload)}}, nil
+	})
+	assert.NoError(t, verifier.verify(context.Background(), "example.com/fn:v1", testImageDigest))
+}
+
+func TestSignatureVerifierCache(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	payload := simpleSigningPayload(testImageDigest)
+
+	fetches := 0
+	var signatures []cosignSignature
+	var fetchErr error
+	verifier := newSignatureVerifier([]crypto.PublicKey{&key.PublicKey}, func(context.Context, string, string) ([]cosignSignature, error) {
+		fetches++
+		return signatures, fetchErr
+	})
+	now := time.Now()
+	verifier.now = func() time.Time { return now }
+
+	// Registry errors are not cached
+	fetchErr = errors.New("registry unavailable")
+	assert.ErrorContains(t, verifier.verify(ctx, "example.com/fn:v1", testImageDigest), "registry unavailable")
+	fetchErr = nil
+
+	// Failures are cached until the failure TTL expires
+	assert.Error(t, verifier.verify(ctx, "example.com/fn:v1", testImageDigest))
+	assert.Error(t, verifier.verify(ctx, "example.com/fn:v1", testImageDigest))
+	assert.Equal(t, 2, fetches)
+
+	signatures = []cosignSignature{{payload: payload, signature: signECDSA(t, key, payload)}}
+	now = now.Add(defaultSignatureFailureTTL + time.Second)
+	assert.NoError(t, verifier.verify(ctx, "example.com/fn:v1", testImageDigest))
+	assert.Equal(t, 3, fetches)
+
+	// Successes are cached without expiry
+	now = now.Add(24 * time.Hour)
+	assert.NoError(t, verifier.verify(ctx, "example.com/fn:v1", testImageDigest))
+	assert.Equal(t, 3, fetches)
+}
+
+func TestCosignSignatureTag(t *testing.T) {
+	assert.Equal(t, "sha256-abcd.sig", cosignSignatureTag("sha256:abcd"))
+}
+
+func TestLoadSignaturePublicKeys(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ed25519Key, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	encode := func(key crypto.PublicKey) []byte {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cosign.pub"), encode(&ecdsaKey.PublicKey), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "release.pub"), encode(ed25519Key), 0o600))
+	// Hidden entries, such as the ones of a mounted Secret, are skipped
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden.pub"), []byte("not a key"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..data"), 0o700))
+
+	keys, err := loadSignaturePublicKeys(dir)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	keys, err = loadSignaturePublicKeys(filepath.Join(dir, "cosign.pub"))
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, ecdsaKey.PublicKey.Equal(keys[0]))
+
+	empty := filepath.Join(t.TempDir(), "empty.pub")
+	require.NoError(t, os.WriteFile(empty, []byte("no keys here"), 0o600))
+	_, err = loadSignaturePublicKeys(empty)
+	assert.Error(t, err)
+
+	_, err = loadSignaturePublicKeys(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
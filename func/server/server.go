@@ -42,6 +42,7 @@ import (
 	"k8s.io/klog/v2/textlogger"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -93,6 +94,11 @@ func main() {
 	flag.IntVar(&o.pod.MaxWaitlistLength, "max-waitlist-length", 2, "Maximum waitlist length per pod")
 	flag.IntVar(&o.pod.MaxParallelPodsPerFunction, "max-parallel-pods-per-function", 1, "Maximum parallel pods per function")
 	flag.IntVar(&o.pod.MaxGrpcRetries, "max-grpc-retries", 2, "Maximum number of retries on gRPC Unavailable errors")
+	flag.IntVar(&o.pod.ResultCacheSize, "result-cache-size", 0, "Maximum number of cached function evaluation results. 0 disables the result cache.")
+	flag.DurationVar(&o.pod.ResultCacheTTL, "result-cache-ttl", 10*time.Minute, "Time-to-live of cached function evaluation results.")
+	flag.IntVar(&o.pod.MaxConcurrentEvaluations, "max-concurrent-evaluations", 0, "Maximum number of function evaluations running in pods at the same time, shared fairly between namespaces. 0 means unlimited")
+	flag.BoolVar(&o.pod.VerifyImageSignatures, "verify-image-signatures", false, "if true, function images must have a cosign signature that can be verified with one of the public keys in --image-signature-keys")
+	flag.StringVar(&o.pod.ImageSignatureKeysPath, "image-signature-keys", "/var/tmp/image-signature-keys", "Path of a PEM file, or a directory of PEM files, holding the public keys used to verify function image signatures")
 
 	flag.Parse()
 
@@ -240,6 +246,12 @@ func buildFnConfigReconciler(o *options, scheme *runtime.Scheme) (*reconciler.Fu
 	cacheOpts.DefaultNamespaces = map[string]cache.Config{
 		o.pod.PodNamespace: {},
 	}
+	// FunctionImagePolicies live in the namespaces of the packages the functions are evaluated for
+	cacheOpts.ByObject = map[client.Object]cache.ByObject{
+		&configapi.FunctionImagePolicy{}: {
+			Namespaces: map[string]cache.Config{cache.AllNamespaces: {}},
+		},
+	}
 
 	mgr, err := ctrl.NewManager(restCfg, ctrl.Options{
 		Scheme: scheme,
@@ -132,6 +132,9 @@ func createRESTMapper() meta.RESTMapper {
 		{kind: configapi.GroupVersion.WithKind("Repository"), plural: "repositories", singular: "repository"},
 		{kind: porchapi.SchemeGroupVersion.WithKind("PackageRevision"), plural: "packagerevisions", singular: "packagerevision"},
 		{kind: porchapi.SchemeGroupVersion.WithKind("PackageRevisionResources"), plural: "packagerevisionresources", singular: "packagerevisionresources"},
+		{kind: porchapi.SchemeGroupVersion.WithKind("RepositoryBundle"), plural: "repositorybundles", singular: "repositorybundle"},
+		{kind: porchapi.SchemeGroupVersion.WithKind("PackageRevisionBatch"), plural: "packagerevisionbatches", singular: "packagerevisionbatch"},
+		{kind: porchapi.SchemeGroupVersion.WithKind("PackageUpgradePreview"), plural: "packageupgradepreviews", singular: "packageupgradepreview"},
 		{kind: porchapi.SchemeGroupVersion.WithKind("Function"), plural: "functions", singular: "function"},
 		{kind: coreapi.SchemeGroupVersion.WithKind("Secret"), plural: "secrets", singular: "secret"},
 		{kind: metav1.SchemeGroupVersion.WithKind("Table"), plural: "tables", singular: "table"},
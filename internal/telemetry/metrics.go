@@ -17,6 +17,7 @@ package telemetry
 import (
 	"context"
 
+	"github.com/kptdev/porch/func/evaluator"
 	"github.com/kptdev/porch/pkg/repository"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -29,6 +30,8 @@ const meterName = "github.com/kptdev/porch"
 var (
 	prResourceSizeHistogram metric.Int64Histogram
 	prResourceSizeGauge     metric.Int64Gauge
+	fnResultCacheCounter    metric.Int64Counter
+	fnQueueDepthCounter     metric.Int64UpDownCounter
 )
 
 func InitMetrics() (err error) {
@@ -55,6 +58,24 @@ func InitMetrics() (err error) {
 		return
 	}
 
+	fnResultCacheCounter, err = m.Int64Counter(
+		"porch_function_result_cache_lookups_total",
+		metric.WithDescription("Number of function result cache lookups in the function runner, by result (hit or miss)"),
+	)
+	if err != nil {
+		klog.Errorf("failed to create porch_function_result_cache_lookups_total counter: %v", err)
+		return
+	}
+
+	fnQueueDepthCounter, err = m.Int64UpDownCounter(
+		"porch_function_evaluation_queue_depth",
+		metric.WithDescription("Number of function evaluations waiting in the function runner, by namespace and priority"),
+	)
+	if err != nil {
+		klog.Errorf("failed to create porch_function_evaluation_queue_depth counter: %v", err)
+		return
+	}
+
 	return nil
 }
 
@@ -92,3 +113,40 @@ func RecordPackageRevisionResourcesSize(ctx context.Context, prKey repository.Pa
 	}
 	prResourceSizeGauge.Record(ctx, resourcesSize, metric.WithAttributeSet(attributes))
 }
+
+// RecordFunctionResultCacheLookup records a hit or a miss of the function runner's result cache for an image.
+func RecordFunctionResultCacheLookup(ctx context.Context, image string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	attributes := attribute.NewSet(
+		attribute.String("image", image),
+		attribute.String("result", result),
+	)
+
+	if fnResultCacheCounter == nil {
+		klog.Warning("fnResultCacheCounter is nil - was InitMetrics() called?")
+		return
+	}
+	fnResultCacheCounter.Add(ctx, 1, metric.WithAttributeSet(attributes))
+}
+
+// RecordFunctionEvaluationQueueDepth adds delta to the number of function evaluations of a namespace and
+// priority that wait in the function runner.
+func RecordFunctionEvaluationQueueDepth(ctx context.Context, namespace string, priority int32, delta int64) {
+	priorityName := "background"
+	if priority >= evaluator.PriorityInteractive {
+		priorityName = "interactive"
+	}
+	attributes := attribute.NewSet(
+		attribute.String("namespace", namespace),
+		attribute.String("priority", priorityName),
+	)
+
+	if fnQueueDepthCounter == nil {
+		klog.Warning("fnQueueDepthCounter is nil - was InitMetrics() called?")
+		return
+	}
+	fnQueueDepthCounter.Add(ctx, delta, metric.WithAttributeSet(attributes))
+}
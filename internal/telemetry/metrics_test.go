@@ -99,3 +99,45 @@ func TestRecordPackageRevisionResourcesSize_RecordsMetrics(t *testing.T) {
 	assert.True(t, foundHistogram, "expected porch_package_size_bytes histogram to be recorded")
 	assert.True(t, foundGauge, "expected porch_package_size_bytes_total gauge to be recorded")
 }
+
+func TestRecordFunctionEvaluationQueueDepth(t *testing.T) {
+	previousMp := otel.GetMeterProvider()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(mp)
+	defer func() {
+		otel.SetMeterProvider(previousMp)
+		mp.Shutdown(context.Background())
+	}()
+
+	require.NoError(t, InitMetrics())
+
+	RecordFunctionEvaluationQueueDepth(context.Background(), "tenant-a", 0, 1)
+	RecordFunctionEvaluationQueueDepth(context.Background(), "tenant-a", 0, 1)
+	RecordFunctionEvaluationQueueDepth(context.Background(), "tenant-a", 0, -1)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	var depth int64
+	var found bool
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "porch_function_evaluation_queue_depth" {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			for _, dp := range sum.DataPoints {
+				namespace, _ := dp.Attributes.Value("namespace")
+				priority, _ := dp.Attributes.Value("priority")
+				assert.Equal(t, "tenant-a", namespace.AsString())
+				assert.Equal(t, "background", priority.AsString())
+				depth += dp.Value
+				found = true
+			}
+		}
+	}
+	assert.True(t, found, "expected porch_function_evaluation_queue_depth to be recorded")
+	assert.Equal(t, int64(1), depth)
+}
@@ -30,7 +30,7 @@ export FN_RUNNER_WARM_UP_POD_CACHE ?= true
 export CREATE_V1ALPHA2_RPKG ?= false
 
 # Reconciler configuration
-ALL_RECONCILERS=packagevariants,packagevariantsets,repositories
+ALL_RECONCILERS=packagevariants,packagevariantsets,repositories,upgradepolicies
 ifndef RECONCILERS
   ENABLED_RECONCILERS=$(ALL_RECONCILERS)
 else
@@ -94,6 +94,9 @@ type ExtraConfig struct {
 
 	PodNameSpace  string
 	FunctionStore *reconciler.FunctionConfigStore
+
+	// WatchHistorySize is the number of package revision changes kept for resuming watches.
+	WatchHistorySize int
 }
 
 // Config defines the config for the apiserver
@@ -382,9 +385,10 @@ func (c completedConfig) New(ctx context.Context) (*PorchServer, error) {
 	credentialResolver := porch.NewCredentialResolver(coreClient, resolverChain)
 	caBundleResolver := porch.NewCredentialResolver(coreClient, []porch.Resolver{porch.NewCaBundleResolver()})
 	referenceResolver := porch.NewReferenceResolver(coreClient)
+	sourcePolicyChecker := porch.NewSourcePolicyChecker(coreClient)
 	userInfoProvider := &porch.ApiserverUserInfoProvider{}
 
-	watcherMgr := engine.NewWatcherManager()
+	watcherMgr := engine.NewWatcherManager(c.ExtraConfig.WatchHistorySize)
 
 	c.ExtraConfig.CacheOptions.CoreClient = coreClient
 	c.ExtraConfig.CacheOptions.RepoPRChangeNotifier = watcherMgr
@@ -426,6 +430,7 @@ func (c completedConfig) New(ctx context.Context) (*PorchServer, error) {
 		engine.WithCredentialResolver(credentialResolver),
 		engine.WithRunnerOptionsResolver(runnerOptionsResolver),
 		engine.WithReferenceResolver(referenceResolver),
+		engine.WithSourcePolicyChecker(sourcePolicyChecker),
 		engine.WithUserInfoProvider(userInfoProvider),
 		engine.WithWatcherManager(watcherMgr),
 		engine.WithRepoOperationRetryAttempts(c.ExtraConfig.CacheOptions.RepoOperationRetryAttempts),
@@ -439,6 +444,7 @@ func (c completedConfig) New(ctx context.Context) (*PorchServer, error) {
 		Codecs:     Codecs,
 		CaD:        cad,
 		CoreClient: coreClient,
+		Authorizer: c.GenericConfig.Authorization.Authorizer,
 	}
 	porchGroup, err := restStorageOptions.NewRESTStorage()
 	if err != nil {
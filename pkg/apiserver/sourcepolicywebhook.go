@@ -0,0 +1,121 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	"github.com/kptdev/porch/pkg/registry/porch"
+	"github.com/kptdev/porch/pkg/repository"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// validatePackageRevisionSource rejects v1alpha2 PackageRevisions whose upstream source is not
+// allowed by the SourcePolicies of their namespace.
+func validatePackageRevisionSource(w http.ResponseWriter, r *http.Request, clientReader client.Reader) {
+	ctx, span := tracer.Start(r.Context(), "validatePackageRevisionSource")
+	defer span.End()
+
+	admissionReviewRequest, err := decodeAdmissionReview(r)
+	if err != nil {
+		writeErr(fmt.Sprintf("error decoding admission review: %v", err), &w)
+		return
+	}
+
+	if admissionReviewRequest.Request.Resource.Resource != "packagerevisions" {
+		writeErr(fmt.Sprintf("unexpected resource: %s", admissionReviewRequest.Request.Resource.Resource), &w)
+		return
+	}
+
+	var attempted porchv1alpha2.PackageRevision
+	if err := json.Unmarshal(admissionReviewRequest.Request.Object.Raw, &attempted); err != nil {
+		writeErr(fmt.Sprintf("could not unmarshal package revision: %v", err), &w)
+		return
+	}
+	if attempted.Namespace == "" {
+		attempted.Namespace = admissionReviewRequest.Request.Namespace
+	}
+
+	if err := checkPackageRevisionSource(ctx, clientReader, porch.NewSourcePolicyChecker(clientReader), &attempted); err != nil {
+		if !repository.IsSourceDenied(err) {
+			writeErr(fmt.Sprintf("could not check package revision source: %v", err), &w)
+			return
+		}
+		klog.Infof("package revision %s/%s rejected: %v", attempted.Namespace, attempted.Name, err)
+		writeConflictResponse(err.Error(), porchv1alpha2.ReasonSourceDenied, admissionReviewRequest, &w)
+		return
+	}
+
+	resp := &admissionv1.AdmissionResponse{
+		Allowed: true,
+		Result: &metav1.Status{
+			Status:  "Success",
+			Message: "PackageRevision source validated successfully",
+		},
+	}
+	responseBytes, err := constructResponse(resp, admissionReviewRequest)
+	if err != nil {
+		writeErr(fmt.Sprintf("error constructing response: %v", err), &w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(responseBytes); err != nil { // #nosec G705
+		klog.Errorf("error writing response: %v", err)
+	}
+}
+
+// checkPackageRevisionSource checks the clone, upgrade and promotion source of a package revision.
+// References to package revisions that do not exist are allowed here; the controller reports them.
+func checkPackageRevisionSource(ctx context.Context, clientReader client.Reader, checker repository.SourcePolicyChecker, pr *porchv1alpha2.PackageRevision) error {
+	source := pr.Spec.Source
+	if source == nil {
+		return nil
+	}
+
+	checkRef := func(ref *porchv1alpha2.PackageRevisionRef) error {
+		if ref == nil || ref.Name == "" {
+			return nil
+		}
+		var upstream porchv1alpha2.PackageRevision
+		if err := clientReader.Get(ctx, client.ObjectKey{Namespace: pr.Namespace, Name: ref.Name}, &upstream); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		return checker.CheckRepositorySource(ctx, pr.Namespace, upstream.Spec.RepositoryName)
+	}
+
+	switch {
+	case source.CloneFrom != nil && source.CloneFrom.Git != nil:
+		return checker.CheckGitSource(ctx, pr.Namespace, source.CloneFrom.Git.Repo)
+	case source.CloneFrom != nil:
+		return checkRef(source.CloneFrom.UpstreamRef)
+	case source.Upgrade != nil:
+		return checkRef(&source.Upgrade.NewUpstream)
+	case source.PromoteFrom != nil:
+		return checkRef(source.PromoteFrom)
+	}
+	return nil
+}
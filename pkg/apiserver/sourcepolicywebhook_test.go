@@ -0,0 +1,138 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidatePackageRevisionSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, configapi.AddToScheme(scheme))
+	require.NoError(t, porchv1alpha2.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&configapi.SourcePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "approved", Namespace: "restricted"},
+			Spec: configapi.SourcePolicySpec{
+				GitURLs:      []string{"https://github.com/approved/*"},
+				Repositories: []string{"blueprints"},
+			},
+		},
+		&porchv1alpha2.PackageRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "blueprints.base.v1", Namespace: "restricted"},
+			Spec:       porchv1alpha2.PackageRevisionSpec{RepositoryName: "blueprints"},
+		},
+		&porchv1alpha2.PackageRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "scratch.base.v1", Namespace: "restricted"},
+			Spec:       porchv1alpha2.PackageRevisionSpec{RepositoryName: "scratch"},
+		},
+	).Build()
+
+	review := func(namespace string, source *porchv1alpha2.PackageSource) *admissionv1.AdmissionResponse {
+		pr := porchv1alpha2.PackageRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployments.app.ws", Namespace: namespace},
+			Spec:       porchv1alpha2.PackageRevisionSpec{Source: source},
+		}
+		raw, err := json.Marshal(pr)
+		require.NoError(t, err)
+
+		body, err := json.Marshal(admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{Kind: "AdmissionReview", APIVersion: "admission.k8s.io/v1"},
+			Request: &admissionv1.AdmissionRequest{
+				UID:       "12345",
+				Resource:  metav1.GroupVersionResource{Group: "porch.kpt.dev", Version: "v1alpha2", Resource: "packagerevisions"},
+				Object:    runtime.RawExtension{Raw: raw},
+				Name:      pr.Name,
+				Namespace: namespace,
+			},
+		})
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, packageRevisionSourceValidationEndpoint, bytes.NewReader(body))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+
+		validatePackageRevisionSource(response, request, fakeClient)
+
+		var result admissionv1.AdmissionReview
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &result), response.Body.String())
+		require.NotNil(t, result.Response)
+		return result.Response
+	}
+
+	gitSource := func(repo string) *porchv1alpha2.PackageSource {
+		return &porchv1alpha2.PackageSource{CloneFrom: &porchv1alpha2.UpstreamPackage{
+			Git: &porchv1alpha2.GitPackage{Repo: repo, Ref: "main"},
+		}}
+	}
+
+	t.Run("allowed git url", func(t *testing.T) {
+		assert.True(t, review("restricted", gitSource("https://github.com/approved/blueprints.git")).Allowed)
+	})
+
+	t.Run("denied git url", func(t *testing.T) {
+		resp := review("restricted", gitSource("https://github.com/someone-else/blueprints.git"))
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, metav1.StatusReason(porchv1alpha2.ReasonSourceDenied), resp.Result.Reason)
+		assert.Contains(t, resp.Result.Message, "someone-else")
+	})
+
+	t.Run("allowed upstream repository", func(t *testing.T) {
+		assert.True(t, review("restricted", &porchv1alpha2.PackageSource{CloneFrom: &porchv1alpha2.UpstreamPackage{
+			UpstreamRef: &porchv1alpha2.PackageRevisionRef{Name: "blueprints.base.v1"},
+		}}).Allowed)
+	})
+
+	t.Run("denied upgrade repository", func(t *testing.T) {
+		assert.False(t, review("restricted", &porchv1alpha2.PackageSource{Upgrade: &porchv1alpha2.PackageUpgradeSpec{
+			NewUpstream: porchv1alpha2.PackageRevisionRef{Name: "scratch.base.v1"},
+		}}).Allowed)
+	})
+
+	t.Run("denied promotion repository", func(t *testing.T) {
+		assert.False(t, review("restricted", &porchv1alpha2.PackageSource{
+			PromoteFrom: &porchv1alpha2.PackageRevisionRef{Name: "scratch.base.v1"},
+		}).Allowed)
+	})
+
+	t.Run("unknown upstream left to the controller", func(t *testing.T) {
+		assert.True(t, review("restricted", &porchv1alpha2.PackageSource{CloneFrom: &porchv1alpha2.UpstreamPackage{
+			UpstreamRef: &porchv1alpha2.PackageRevisionRef{Name: "missing.base.v1"},
+		}}).Allowed)
+	})
+
+	t.Run("namespace without policies", func(t *testing.T) {
+		assert.True(t, review("open", gitSource("https://github.com/someone-else/blueprints.git")).Allowed)
+	})
+
+	t.Run("init source", func(t *testing.T) {
+		assert.True(t, review("restricted", &porchv1alpha2.PackageSource{Init: &porchv1alpha2.PackageInitSpec{}}).Allowed)
+	})
+}
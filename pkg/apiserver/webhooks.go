@@ -37,6 +37,7 @@ import (
 	"github.com/fsnotify/fsnotify"
 	"go.opentelemetry.io/otel"
 
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
 	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
 	"github.com/kptdev/porch/pkg/util"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -50,7 +51,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const repositoryValidationEndpoint = "/validate-repository"
+const (
+	repositoryValidationEndpoint            = "/validate-repository"
+	packageRevisionSourceValidationEndpoint = "/validate-packagerevision-source"
+)
 
 var (
 	cert        tls.Certificate
@@ -63,6 +67,7 @@ var tracer = otel.Tracer("repository-webhook")
 type WebhookConfig struct {
 	Port                 int32
 	RepositoryPath       string
+	PackageRevisionPath  string
 	RepoServiceName      string
 	RepoServiceNamespace string
 	RepoHost             string
@@ -75,6 +80,7 @@ type WebhookConfig struct {
 func newWebhookConfig(ctx context.Context) *WebhookConfig {
 	var cfg WebhookConfig
 	cfg.RepositoryPath = repositoryValidationEndpoint
+	cfg.PackageRevisionPath = packageRevisionSourceValidationEndpoint
 	cfg.RepoServiceName, cfg.RepoServiceNamespace = webhookServiceName(ctx)
 	cfg.RepoHost = fmt.Sprintf("%s.%s.svc", cfg.RepoServiceName, cfg.RepoServiceNamespace)
 
@@ -274,6 +280,8 @@ func createValidatingWebhook(ctx context.Context, cfg *WebhookConfig, caCert []b
 	var (
 		repositoryCfgName = "repository-validating-webhook"
 		fail              = admissionregistrationv1.Fail
+		ignore            = admissionregistrationv1.Ignore
+		exact             = admissionregistrationv1.Exact
 		none              = admissionregistrationv1.SideEffectClassNone
 	)
 
@@ -313,6 +321,36 @@ func createValidatingWebhook(ctx context.Context, cfg *WebhookConfig, caCert []b
 		Port:      &cfg.Port,
 	}
 
+	// Webhook for checking the upstream sources of v1alpha2 PackageRevisions against SourcePolicies.
+	// Failures are ignored because the PackageRevision controller checks the sources again.
+	repositoryWebhook.Webhooks = append(repositoryWebhook.Webhooks, admissionregistrationv1.ValidatingWebhook{
+		Name: "porchpackagerevisionsourcewebhook.kpt.dev",
+		ClientConfig: admissionregistrationv1.WebhookClientConfig{
+			CABundle: caCert,
+			Service: &admissionregistrationv1.ServiceReference{
+				Name:      cfg.RepoServiceName,
+				Namespace: cfg.RepoServiceNamespace,
+				Path:      &cfg.PackageRevisionPath,
+				Port:      &cfg.Port,
+			},
+		},
+		Rules: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{
+				admissionregistrationv1.Create,
+			},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{porchv1alpha2.SchemeGroupVersion.Group},
+				APIVersions: []string{porchv1alpha2.SchemeGroupVersion.Version},
+				Resources:   []string{"packagerevisions"},
+			},
+		}},
+		AdmissionReviewVersions: []string{"v1"},
+		SideEffects:             &none,
+		FailurePolicy:           &ignore,
+		MatchPolicy:             &exact,
+		TimeoutSeconds:          &cfg.timeout,
+	})
+
 	// Delete and recreate repository webhook to allow updates in webhook configurations
 	_ = kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(ctx, repositoryCfgName, metav1.DeleteOptions{})
 
@@ -406,6 +444,9 @@ func runWebhookServer(ctx context.Context, cfg *WebhookConfig, clientReader clie
 	http.HandleFunc(cfg.RepositoryPath, func(w http.ResponseWriter, r *http.Request) {
 		validateRepository(w, r, clientReader)
 	})
+	http.HandleFunc(cfg.PackageRevisionPath, func(w http.ResponseWriter, r *http.Request) {
+		validatePackageRevisionSource(w, r, clientReader)
+	})
 	server := http.Server{
 		Addr: fmt.Sprintf(":%d", cfg.Port),
 		TLSConfig: &tls.Config{
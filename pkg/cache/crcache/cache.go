@@ -17,6 +17,7 @@ package crcache
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
@@ -125,13 +126,30 @@ func (c *Cache) CheckRepositoryConnectivity(ctx context.Context, repositorySpec
 }
 
 func (c *Cache) FindAllUpstreamReferencesInRepositories(ctx context.Context, namespace, prName string) (string, error) {
-	var downstreamName string
+	if downstreamNames := c.findDownstreamPackageRevisions(ctx, namespace, prName, true); len(downstreamNames) > 0 {
+		return downstreamNames[0], nil
+	}
+	return "", nil
+}
+
+func (c *Cache) FindAllDownstreamPackageRevisions(ctx context.Context, namespace, prName string) ([]string, error) {
+	downstreamNames := c.findDownstreamPackageRevisions(ctx, namespace, prName, false)
+	sort.Strings(downstreamNames)
+	return downstreamNames, nil
+}
+
+// findDownstreamPackageRevisions returns the names of the package revisions in the namespace
+// whose clone or upgrade task references prName as upstream. If firstOnly is set, it stops
+// at the first match.
+func (c *Cache) findDownstreamPackageRevisions(ctx context.Context, namespace, prName string, firstOnly bool) []string {
+	var downstreamNames []string
 	c.repositories.Range(func(key, value any) bool {
 		cachedRepo := value.(*cachedRepository)
 		if cachedRepo.Key().Namespace != namespace {
 			return true
 		}
 		cachedRepo.mutex.RLock()
+		defer cachedRepo.mutex.RUnlock()
 		for _, pr := range cachedRepo.cachedPackageRevisions {
 			// Skip main branch packages (revision = -1) as they are auto-managed
 			if pr.Key().Revision == -1 {
@@ -150,16 +168,17 @@ func (c *Cache) FindAllUpstreamReferencesInRepositories(ctx context.Context, nam
 					matched = task.Upgrade != nil && task.Upgrade.NewUpstream.Name == prName
 				}
 				if matched {
-					downstreamName = pr.KubeObjectName()
-					cachedRepo.mutex.RUnlock()
-					return false
+					downstreamNames = append(downstreamNames, pr.KubeObjectName())
+					if firstOnly {
+						return false
+					}
+					break
 				}
 			}
 		}
-		cachedRepo.mutex.RUnlock()
 		return true
 	})
-	return downstreamName, nil
+	return downstreamNames
 }
 
 func (c *Cache) ListPackageRevisions(ctx context.Context, filter repository.ListPackageRevisionFilter) ([]repository.PackageRevision, error) {
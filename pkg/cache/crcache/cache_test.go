@@ -20,6 +20,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
@@ -138,6 +139,44 @@ func TestPublishedLatest(t *testing.T) {
 	}
 }
 
+func TestPublishWithPublishInfo(t *testing.T) {
+	ctx := context.Background()
+	testPath := filepath.Join("..", "..", "externalrepo", "git", "testdata")
+	cachedRepo := openRepositoryFromArchive(t, ctx, testPath, "nested")
+
+	revisions, err := cachedRepo.ListPackageRevisions(ctx, repository.ListPackageRevisionFilter{
+		Key: repository.PackageRevisionKey{
+			PkgKey: repository.PackageKey{
+				Path:    "catalog/gcp",
+				Package: "bucket",
+			},
+			WorkspaceName: "v2",
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+
+	update, err := cachedRepo.UpdatePackageRevision(ctx, revisions[0])
+	require.NoError(t, err)
+	require.NoError(t, update.UpdateLifecycle(ctx, porchapi.PackageRevisionLifecyclePublished))
+
+	publishedAt := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	ctx = repository.WithPublishInfo(ctx, repository.PublishInfo{
+		Revision:    7,
+		PublishedBy: "importer@example.com",
+		PublishedAt: publishedAt,
+	})
+	closed, err := cachedRepo.ClosePackageRevisionDraft(ctx, update, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 7, closed.Key().Revision)
+
+	resource, err := closed.GetPackageRevision(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 7, resource.Spec.Revision)
+	assert.Equal(t, "importer@example.com", resource.Status.PublishedBy)
+	assert.True(t, publishedAt.Equal(resource.Status.PublishedAt.Time))
+}
+
 func TestDeletePublishedMain(t *testing.T) {
 	ctx := context.Background()
 	testPath := filepath.Join("../..", "externalrepo", "git", "testdata")
@@ -443,3 +482,55 @@ func TestFindUpstreamReference(t *testing.T) {
 		})
 	}
 }
+
+func TestFindAllDownstreamPackageRevisions(t *testing.T) {
+	ctx := context.Background()
+	cache := &Cache{repositories: repomap.SafeRepoMap{}}
+
+	newRepo := func(name string) *cachedRepository {
+		repoKey := repository.RepositoryKey{Namespace: "test-ns", Name: name}
+		repo := &cachedRepository{
+			key:                    repoKey,
+			cachedPackageRevisions: make(map[repository.PackageRevisionKey]*cachedPackageRevision),
+		}
+		_, _ = cache.repositories.LoadOrCreate(repoKey, func() (repository.Repository, error) {
+			return repo, nil
+		})
+		return repo
+	}
+	addDownstream := func(repo *cachedRepository, pkgName string, task porchapi.Task) {
+		key := repository.PackageRevisionKey{
+			PkgKey:        repository.PackageKey{RepoKey: repo.key, Package: pkgName},
+			WorkspaceName: "v1",
+		}
+		repo.cachedPackageRevisions[key] = &cachedPackageRevision{
+			PackageRevision: &fake.FakePackageRevision{
+				PrKey:           key,
+				PackageRevision: &porchapi.PackageRevision{Spec: porchapi.PackageRevisionSpec{Tasks: []porchapi.Task{task}}},
+			},
+		}
+	}
+
+	repo1 := newRepo("test-repo")
+	repo2 := newRepo("test-repo2")
+	addDownstream(repo2, "zeta", porchapi.Task{
+		Type:  "clone",
+		Clone: &porchapi.PackageCloneTaskSpec{Upstream: porchapi.UpstreamPackage{UpstreamRef: &porchapi.PackageRevisionRef{Name: "test-repo.base.v1"}}},
+	})
+	addDownstream(repo1, "alpha", porchapi.Task{
+		Type:    "upgrade",
+		Upgrade: &porchapi.PackageUpgradeTaskSpec{NewUpstream: porchapi.PackageRevisionRef{Name: "test-repo.base.v1"}},
+	})
+	addDownstream(repo1, "other", porchapi.Task{
+		Type:  "clone",
+		Clone: &porchapi.PackageCloneTaskSpec{Upstream: porchapi.UpstreamPackage{UpstreamRef: &porchapi.PackageRevisionRef{Name: "test-repo.base.v2"}}},
+	})
+
+	downstream, err := cache.FindAllDownstreamPackageRevisions(ctx, "test-ns", "test-repo.base.v1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-repo.alpha.v1", "test-repo2.zeta.v1"}, downstream)
+
+	downstream, err = cache.FindAllDownstreamPackageRevisions(ctx, "other-ns", "test-repo.base.v1")
+	require.NoError(t, err)
+	assert.Empty(t, downstream)
+}
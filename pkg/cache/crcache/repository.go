@@ -15,6 +15,7 @@
 package crcache
 
 import (
+	"cmp"
 	"context"
 	"strings"
 	stdSync "sync"
@@ -55,6 +56,11 @@ type cachedRepository struct {
 	// but not reflected in the list of packageRevisions correctly.
 	lastVersion string
 
+	// batchedChanges is set when the cache was updated with changes that were added to a
+	// push batch rather than pushed, so that the next Refresh reloads the cache even if the
+	// version of the repository is unchanged because the batch was never pushed.
+	batchedChanges bool
+
 	mutex                  stdSync.RWMutex
 	refreshWg              stdSync.WaitGroup
 	cachedPackageRevisions map[repository.PackageRevisionKey]*cachedPackageRevision
@@ -90,12 +96,34 @@ func (r *cachedRepository) Key() repository.RepositoryKey {
 }
 
 func (r *cachedRepository) Refresh(ctx context.Context) error {
+	r.mutex.Lock()
+	batchedChanges := r.batchedChanges
+	if batchedChanges {
+		r.lastVersion = ""
+		r.batchedChanges = false
+	}
+	r.mutex.Unlock()
+
+	if batchedChanges {
+		if err := r.repo.Refresh(ctx); err != nil {
+			return err
+		}
+	}
 
 	_, _, err := r.refreshAllCachedPackages(ctx)
 
 	return err
 }
 
+// noteBatchedChanges makes the next Refresh reload the cache if a push batch is set on ctx.
+func (r *cachedRepository) noteBatchedChanges(ctx context.Context) {
+	if _, batched := repository.PushBatchFrom(ctx); batched {
+		r.mutex.Lock()
+		r.batchedChanges = true
+		r.mutex.Unlock()
+	}
+}
+
 func (r *cachedRepository) Version(ctx context.Context) (string, error) {
 	ctx, span := tracer.Start(ctx, "cachedRepository::Version", trace.WithAttributes())
 	defer span.End()
@@ -219,6 +247,8 @@ func (r *cachedRepository) ClosePackageRevisionDraft(ctx context.Context, prd re
 		klog.V(3).InfoS("[CR Cache] Draft closed and lifecycle change pushed to Git for PackageRevision", pctx.LogMetadataFrom(ctx)...)
 	}()
 
+	r.noteBatchedChanges(ctx)
+
 	v, err := r.Version(ctx)
 	if err != nil {
 		return nil, err
@@ -252,7 +282,11 @@ func (r *cachedRepository) ClosePackageRevisionDraft(ctx context.Context, prd re
 		}
 	}
 
-	closedPr, err := r.repo.ClosePackageRevisionDraft(ctx, prd, highestRevision+1)
+	// An explicit version, or the revision of a package revision published
+	// elsewhere, takes precedence over the next revision number
+	version = cmp.Or(version, publishedRevision(ctx), highestRevision+1)
+
+	closedPr, err := r.repo.ClosePackageRevisionDraft(ctx, prd, version)
 	if err != nil {
 		return nil, err
 	}
@@ -284,6 +318,15 @@ func (r *cachedRepository) ClosePackageRevisionDraft(ctx context.Context, prd re
 	return cachedPr, nil
 }
 
+// publishedRevision returns the revision number set on the context with
+// repository.WithPublishInfo, or 0 if there is none
+func publishedRevision(ctx context.Context) int {
+	if info, ok := repository.PublishInfoFrom(ctx); ok && info.Revision > 0 {
+		return info.Revision
+	}
+	return 0
+}
+
 func (r *cachedRepository) UpdatePackageRevision(ctx context.Context, old repository.PackageRevision) (repository.PackageRevisionDraft, error) {
 	klog.InfoS("[CR Cache] Loading draft for update from Git for PackageRevision", pctx.LogMetadataFrom(ctx)...)
 	defer func() {
@@ -437,6 +480,7 @@ func (r *cachedRepository) DeletePackageRevision(ctx context.Context, prToDelete
 	if err := r.repo.DeletePackageRevision(ctx, unwrapped); err != nil {
 		return err
 	}
+	r.noteBatchedChanges(ctx)
 
 	r.mutex.Lock()
 	if r.cachedPackages != nil {
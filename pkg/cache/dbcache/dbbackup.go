@@ -0,0 +1,387 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbcache
+
+import (
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+)
+
+// CacheBackupVersion is the version of the cache backup archive format written by BackupUnpublished.
+const CacheBackupVersion = "porch.kpt.dev/cache-backup/v1"
+
+// cacheBackup is the content of a cache backup archive: the draft and proposed package revisions
+// in the database, with their resources and the package and repository rows they belong to.
+// Published package revisions are not backed up, they are restored from git by repository sync.
+type cacheBackup struct {
+	Version          string                       `json:"version"`
+	Created          time.Time                    `json:"created"`
+	Repositories     []backupRepositoryRow        `json:"repositories"`
+	Packages         []backupPackageRow           `json:"packages"`
+	PackageRevisions []backupPackageRevisionRow   `json:"packageRevisions"`
+	Resources        map[string]map[string]string `json:"resources"`
+}
+
+type backupRepositoryRow struct {
+	Namespace     string     `json:"namespace"`
+	Name          string     `json:"name"`
+	Directory     string     `json:"directory"`
+	DefaultWSName string     `json:"defaultWSName"`
+	Meta          string     `json:"meta"`
+	Spec          string     `json:"spec"`
+	Updated       *time.Time `json:"updated,omitempty"`
+	UpdatedBy     *string    `json:"updatedBy,omitempty"`
+	Deployment    *bool      `json:"deployment,omitempty"`
+}
+
+type backupPackageRow struct {
+	Namespace   string    `json:"namespace"`
+	Name        string    `json:"name"`
+	RepoName    string    `json:"repoName"`
+	PackagePath string    `json:"packagePath"`
+	Meta        string    `json:"meta"`
+	Spec        string    `json:"spec"`
+	Updated     time.Time `json:"updated"`
+	UpdatedBy   string    `json:"updatedBy"`
+}
+
+type backupPackageRevisionRow struct {
+	Namespace       string    `json:"namespace"`
+	Name            string    `json:"name"`
+	PackageName     string    `json:"packageName"`
+	Revision        int       `json:"revision"`
+	Meta            string    `json:"meta"`
+	Spec            string    `json:"spec"`
+	Updated         time.Time `json:"updated"`
+	UpdatedBy       string    `json:"updatedBy"`
+	Lifecycle       string    `json:"lifecycle"`
+	ExtPRID         string    `json:"extPRID"`
+	Tasks           string    `json:"tasks"`
+	KptfileStatus   string    `json:"kptfileStatus"`
+	ResourcesSize   int64     `json:"resourcesSize"`
+	UpstreamRefName string    `json:"upstreamRefName"`
+}
+
+// CacheBackupSummary describes the content of a cache backup.
+type CacheBackupSummary struct {
+	Version          string
+	Created          time.Time
+	Repositories     int
+	Packages         int
+	PackageRevisions int
+}
+
+// CacheRestoreSummary describes the outcome of restoring a cache backup.
+type CacheRestoreSummary struct {
+	CacheBackupSummary
+	// Restored lists the namespaced names of the package revisions that were restored.
+	Restored []string
+	// Skipped lists the namespaced names of the package revisions that were not restored
+	// because a package revision with the same name is already in the database.
+	Skipped []string
+}
+
+func backupKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// BackupUnpublished writes a backup archive of the draft and proposed package revisions in the
+// database to w. The backup is read in a single repeatable-read transaction, so it is a consistent
+// snapshot even while the Porch server is running.
+func BackupUnpublished(ctx context.Context, w io.Writer) (*CacheBackupSummary, error) {
+	_, span := tracer.Start(ctx, "dbbackup::BackupUnpublished", trace.WithAttributes())
+	defer span.End()
+
+	tx, err := GetDB().db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot start backup transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	backup := cacheBackup{
+		Version:   CacheBackupVersion,
+		Created:   time.Now().UTC(),
+		Resources: map[string]map[string]string{},
+	}
+
+	if err := backupReadPackageRevisions(ctx, tx, &backup); err != nil {
+		return nil, err
+	}
+	if err := backupReadPackages(ctx, tx, &backup); err != nil {
+		return nil, err
+	}
+	if err := backupReadRepositories(ctx, tx, &backup); err != nil {
+		return nil, err
+	}
+	if err := backupReadResources(ctx, tx, &backup); err != nil {
+		return nil, err
+	}
+
+	gzw := gzip.NewWriter(w)
+	if err := json.NewEncoder(gzw).Encode(&backup); err != nil {
+		return nil, fmt.Errorf("cannot write backup archive: %w", err)
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, fmt.Errorf("cannot write backup archive: %w", err)
+	}
+
+	klog.Infof("BackupUnpublished: backed up %d package revisions in %d packages of %d repositories",
+		len(backup.PackageRevisions), len(backup.Packages), len(backup.Repositories))
+
+	return backup.summary(), nil
+}
+
+func backupReadPackageRevisions(ctx context.Context, tx *sql.Tx, backup *cacheBackup) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT k8s_name_space, k8s_name, package_k8s_name, revision, meta, spec, updated, updatedby,
+			lifecycle, ext_pr_id, tasks, kptfile_status, resources_size, upstream_ref_name
+		FROM package_revisions
+		WHERE lifecycle IN ('Draft', 'Proposed') AND revision = 0
+		ORDER BY k8s_name_space, k8s_name
+	`)
+	if err != nil {
+		return fmt.Errorf("cannot read package revisions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row backupPackageRevisionRow
+		if err := rows.Scan(&row.Namespace, &row.Name, &row.PackageName, &row.Revision, &row.Meta, &row.Spec, &row.Updated, &row.UpdatedBy,
+			&row.Lifecycle, &row.ExtPRID, &row.Tasks, &row.KptfileStatus, &row.ResourcesSize, &row.UpstreamRefName); err != nil {
+			return fmt.Errorf("cannot read package revisions: %w", err)
+		}
+		backup.PackageRevisions = append(backup.PackageRevisions, row)
+	}
+	return rows.Err()
+}
+
+func backupReadPackages(ctx context.Context, tx *sql.Tx, backup *cacheBackup) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT DISTINCT packages.k8s_name_space, packages.k8s_name, packages.repo_k8s_name, packages.package_path,
+			packages.meta, packages.spec, packages.updated, packages.updatedby
+		FROM packages INNER JOIN package_revisions
+			ON package_revisions.k8s_name_space=packages.k8s_name_space AND package_revisions.package_k8s_name=packages.k8s_name
+		WHERE package_revisions.lifecycle IN ('Draft', 'Proposed') AND package_revisions.revision = 0
+		ORDER BY packages.k8s_name_space, packages.k8s_name
+	`)
+	if err != nil {
+		return fmt.Errorf("cannot read packages: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row backupPackageRow
+		if err := rows.Scan(&row.Namespace, &row.Name, &row.RepoName, &row.PackagePath, &row.Meta, &row.Spec, &row.Updated, &row.UpdatedBy); err != nil {
+			return fmt.Errorf("cannot read packages: %w", err)
+		}
+		backup.Packages = append(backup.Packages, row)
+	}
+	return rows.Err()
+}
+
+func backupReadRepositories(ctx context.Context, tx *sql.Tx, backup *cacheBackup) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT DISTINCT repositories.k8s_name_space, repositories.k8s_name, repositories.directory, repositories.default_ws_name,
+			repositories.meta, repositories.spec, repositories.updated, repositories.updatedby, repositories.deployment
+		FROM repositories INNER JOIN packages
+			ON packages.k8s_name_space=repositories.k8s_name_space AND packages.repo_k8s_name=repositories.k8s_name
+		INNER JOIN package_revisions
+			ON package_revisions.k8s_name_space=packages.k8s_name_space AND package_revisions.package_k8s_name=packages.k8s_name
+		WHERE package_revisions.lifecycle IN ('Draft', 'Proposed') AND package_revisions.revision = 0
+		ORDER BY repositories.k8s_name_space, repositories.k8s_name
+	`)
+	if err != nil {
+		return fmt.Errorf("cannot read repositories: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row backupRepositoryRow
+		var updated sql.NullTime
+		var updatedBy sql.NullString
+		var deployment sql.NullBool
+		if err := rows.Scan(&row.Namespace, &row.Name, &row.Directory, &row.DefaultWSName, &row.Meta, &row.Spec, &updated, &updatedBy, &deployment); err != nil {
+			return fmt.Errorf("cannot read repositories: %w", err)
+		}
+		if updated.Valid {
+			row.Updated = &updated.Time
+		}
+		if updatedBy.Valid {
+			row.UpdatedBy = &updatedBy.String
+		}
+		if deployment.Valid {
+			row.Deployment = &deployment.Bool
+		}
+		backup.Repositories = append(backup.Repositories, row)
+	}
+	return rows.Err()
+}
+
+func backupReadResources(ctx context.Context, tx *sql.Tx, backup *cacheBackup) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT resources.k8s_name_space, resources.k8s_name, resources.resource_key, resource_blobs.content
+		FROM resources INNER JOIN package_revisions
+			ON resources.k8s_name_space=package_revisions.k8s_name_space AND resources.k8s_name=package_revisions.k8s_name
+		INNER JOIN resource_blobs ON resource_blobs.hash=resources.resource_hash
+		WHERE package_revisions.lifecycle IN ('Draft', 'Proposed') AND package_revisions.revision = 0
+	`)
+	if err != nil {
+		return fmt.Errorf("cannot read resources: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var namespace, name, key, value string
+		if err := rows.Scan(&namespace, &name, &key, &value); err != nil {
+			return fmt.Errorf("cannot read resources: %w", err)
+		}
+		prKey := backupKey(namespace, name)
+		if backup.Resources[prKey] == nil {
+			backup.Resources[prKey] = map[string]string{}
+		}
+		backup.Resources[prKey][key] = value
+	}
+	return rows.Err()
+}
+
+// ReadCacheBackup reads and validates a backup archive written by BackupUnpublished, without restoring it.
+func ReadCacheBackup(r io.Reader) (*CacheBackupSummary, error) {
+	backup, err := readCacheBackup(r)
+	if err != nil {
+		return nil, err
+	}
+	return backup.summary(), nil
+}
+
+func readCacheBackup(r io.Reader) (*cacheBackup, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a cache backup archive: %w", err)
+	}
+	defer gzr.Close()
+
+	var backup cacheBackup
+	if err := json.NewDecoder(gzr).Decode(&backup); err != nil {
+		return nil, fmt.Errorf("cannot read cache backup archive: %w", err)
+	}
+	if backup.Version != CacheBackupVersion {
+		return nil, fmt.Errorf("unsupported cache backup version %q, expected %q", backup.Version, CacheBackupVersion)
+	}
+	return &backup, nil
+}
+
+func (b *cacheBackup) summary() *CacheBackupSummary {
+	return &CacheBackupSummary{
+		Version:          b.Version,
+		Created:          b.Created,
+		Repositories:     len(b.Repositories),
+		Packages:         len(b.Packages),
+		PackageRevisions: len(b.PackageRevisions),
+	}
+}
+
+// RestoreUnpublished restores a backup archive written by BackupUnpublished into the database.
+// Repository and package rows that are missing are recreated. Package revisions are restored
+// unless a package revision with the same name is already in the database, for example because
+// it was published to git after the backup was taken and has since been synced.
+// The restore runs in a single transaction; if anything fails, nothing is restored.
+//
+// Published package revisions are not part of the backup. Repository sync rebuilds them from git
+// and replaces any restored draft that was published in git after the backup was taken.
+func RestoreUnpublished(ctx context.Context, r io.Reader) (*CacheRestoreSummary, error) {
+	_, span := tracer.Start(ctx, "dbbackup::RestoreUnpublished", trace.WithAttributes())
+	defer span.End()
+
+	backup, err := readCacheBackup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := GetDB().db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot start restore transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	for _, repo := range backup.Repositories {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO repositories (k8s_name_space, k8s_name, directory, default_ws_name, meta, spec, updated, updatedby, deployment)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (k8s_name_space, k8s_name) DO NOTHING`,
+			repo.Namespace, repo.Name, repo.Directory, repo.DefaultWSName, repo.Meta, repo.Spec, repo.Updated, repo.UpdatedBy, repo.Deployment); err != nil {
+			return nil, fmt.Errorf("cannot restore repository %s: %w", backupKey(repo.Namespace, repo.Name), err)
+		}
+	}
+
+	for _, pkg := range backup.Packages {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO packages (k8s_name_space, k8s_name, repo_k8s_name, package_path, meta, spec, updated, updatedby)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (k8s_name_space, k8s_name) DO NOTHING`,
+			pkg.Namespace, pkg.Name, pkg.RepoName, pkg.PackagePath, pkg.Meta, pkg.Spec, pkg.Updated, pkg.UpdatedBy); err != nil {
+			return nil, fmt.Errorf("cannot restore package %s: %w", backupKey(pkg.Namespace, pkg.Name), err)
+		}
+	}
+
+	summary := &CacheRestoreSummary{CacheBackupSummary: *backup.summary()}
+	for _, pr := range backup.PackageRevisions {
+		prKey := backupKey(pr.Namespace, pr.Name)
+
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO package_revisions (k8s_name_space, k8s_name, package_k8s_name, revision, meta, spec, updated, updatedby, lifecycle, ext_pr_id, tasks, kptfile_status, resources_size, upstream_ref_name)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			ON CONFLICT (k8s_name_space, k8s_name) DO NOTHING`,
+			pr.Namespace, pr.Name, pr.PackageName, pr.Revision, pr.Meta, pr.Spec, pr.Updated, pr.UpdatedBy, pr.Lifecycle, pr.ExtPRID, pr.Tasks, pr.KptfileStatus, pr.ResourcesSize, pr.UpstreamRefName)
+		if err != nil {
+			return nil, fmt.Errorf("cannot restore package revision %s: %w", prKey, err)
+		}
+		if inserted, err := result.RowsAffected(); err != nil {
+			return nil, fmt.Errorf("cannot restore package revision %s: %w", prKey, err)
+		} else if inserted == 0 {
+			klog.Infof("RestoreUnpublished: package revision %s is already in the database, skipping it", prKey)
+			summary.Skipped = append(summary.Skipped, prKey)
+			continue
+		}
+
+		for resKey, resVal := range backup.Resources[prKey] {
+			resHash := resourceHash(resVal)
+			if err := resourceBlobWriteToDB(ctx, tx, resHash, resVal); err != nil {
+				return nil, fmt.Errorf("cannot restore resource %q of package revision %s: %w", resKey, prKey, err)
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO resources (k8s_name_space, k8s_name, revision, resource_key, resource_hash)
+				VALUES ($1, $2, $3, $4, $5)`,
+				pr.Namespace, pr.Name, pr.Revision, resKey, resHash); err != nil {
+				return nil, fmt.Errorf("cannot restore resource %q of package revision %s: %w", resKey, prKey, err)
+			}
+		}
+		summary.Restored = append(summary.Restored, prKey)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("cannot commit restore transaction: %w", err)
+	}
+
+	klog.Infof("RestoreUnpublished: restored %d package revisions, skipped %d", len(summary.Restored), len(summary.Skipped))
+	return summary, nil
+}
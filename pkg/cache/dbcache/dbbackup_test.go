@@ -0,0 +1,115 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbcache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"time"
+
+	cachetypes "github.com/kptdev/porch/pkg/cache/types"
+	"github.com/kptdev/porch/pkg/repository"
+	mockcachetypes "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/cache/types"
+	"github.com/stretchr/testify/mock"
+)
+
+func (t *DbTestSuite) TestBackupRestoreUnpublished() {
+	mockCache := mockcachetypes.NewMockCache(t.T())
+	cachetypes.CacheInstance = mockCache
+	mockCache.EXPECT().GetRepository(mock.Anything).Return(&dbRepository{}).Maybe()
+
+	dbRepo := t.createTestRepo("backup-ns", "backup-repo")
+	dbPkg := dbPackage{
+		pkgKey: repository.PackageKey{
+			RepoKey: dbRepo.Key(),
+			Package: "backup-pkg",
+		},
+		updated: time.Now().UTC(),
+	}
+	t.NoError(pkgWriteToDB(t.Context(), &dbPkg))
+
+	draft := dbPackageRevision{
+		pkgRevKey: repository.PackageRevisionKey{
+			PkgKey:        dbPkg.Key(),
+			WorkspaceName: "draft",
+		},
+		updated:   time.Now().UTC(),
+		lifecycle: "Draft",
+		resources: map[string]string{"Kptfile": "kind: Kptfile\n", "cm.yaml": "kind: ConfigMap\n"},
+	}
+	t.NoError(pkgRevWriteToDB(t.Context(), &draft))
+
+	published := dbPackageRevision{
+		pkgRevKey: repository.PackageRevisionKey{
+			PkgKey:        dbPkg.Key(),
+			WorkspaceName: "v1",
+			Revision:      1,
+		},
+		updated:   time.Now().UTC(),
+		lifecycle: "Published",
+		resources: map[string]string{"Kptfile": "kind: Kptfile\n"},
+	}
+	t.NoError(pkgRevWriteToDB(t.Context(), &published))
+
+	var archive bytes.Buffer
+	summary, err := BackupUnpublished(t.Context(), &archive)
+	t.NoError(err)
+	t.Equal(CacheBackupVersion, summary.Version)
+	t.GreaterOrEqual(summary.Repositories, 1)
+	t.GreaterOrEqual(summary.Packages, 1)
+	t.GreaterOrEqual(summary.PackageRevisions, 1)
+
+	readSummary, err := ReadCacheBackup(bytes.NewReader(archive.Bytes()))
+	t.NoError(err)
+	t.Equal(summary.PackageRevisions, readSummary.PackageRevisions)
+
+	t.NoError(pkgRevDeleteFromDB(t.Context(), draft.Key()))
+	_, err = pkgRevReadFromDB(t.Context(), draft.Key(), false)
+	t.Error(err)
+
+	restoreSummary, err := RestoreUnpublished(t.Context(), bytes.NewReader(archive.Bytes()))
+	t.NoError(err)
+	t.Contains(restoreSummary.Restored, "backup-ns/"+draft.KubeObjectName())
+	t.NotContains(restoreSummary.Skipped, "backup-ns/"+draft.KubeObjectName())
+
+	restored, err := pkgRevReadFromDB(t.Context(), draft.Key(), true)
+	t.NoError(err)
+	t.Equal(draft.lifecycle, restored.lifecycle)
+	t.Equal(draft.resources, restored.resources)
+
+	restoreSummary, err = RestoreUnpublished(t.Context(), bytes.NewReader(archive.Bytes()))
+	t.NoError(err)
+	t.Empty(restoreSummary.Restored)
+	t.Contains(restoreSummary.Skipped, "backup-ns/"+draft.KubeObjectName())
+
+	t.NoError(pkgRevDeleteFromDB(t.Context(), draft.Key()))
+	t.NoError(pkgRevDeleteFromDB(t.Context(), published.Key()))
+	t.NoError(pkgDeleteFromDB(t.Context(), dbPkg.Key()))
+	t.deleteTestRepo(dbRepo.Key())
+}
+
+func (t *DbTestSuite) TestRestoreUnpublishedBadVersion() {
+	var archive bytes.Buffer
+	zw := gzip.NewWriter(&archive)
+	t.NoError(json.NewEncoder(zw).Encode(cacheBackup{Version: "porch.kpt.dev/cache-backup/v0"}))
+	t.NoError(zw.Close())
+
+	_, err := RestoreUnpublished(t.Context(), bytes.NewReader(archive.Bytes()))
+	t.ErrorContains(err, "porch.kpt.dev/cache-backup/v0")
+
+	_, err = ReadCacheBackup(bytes.NewReader([]byte("not a backup")))
+	t.Error(err)
+}
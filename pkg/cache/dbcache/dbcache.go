@@ -1,4 +1,4 @@
-// Copyright 2024-2025 The kpt Authors
+// Copyright 2024-2026 The kpt Authors
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -41,6 +41,12 @@ var _ cachetypes.Cache = &dbCache{}
 type dbCache struct {
 	repositories repomap.SafeRepoMap
 	options      cachetypes.CacheOptions
+
+	// origin identifies this replica on the change bus.
+	origin string
+	// localNotifier notifies the watchers on this replica; options.RepoPRChangeNotifier also publishes changes to
+	// the other replicas when there is a change bus.
+	localNotifier cachetypes.RepoPRChangeNotifier
 }
 
 func (c *dbCache) OpenRepository(ctx context.Context, repositorySpec *configapi.Repository) (repository.Repository, error) {
@@ -168,6 +174,10 @@ func (c *dbCache) FindAllUpstreamReferencesInRepositories(ctx context.Context, n
 	return findUpstreamRefsFromDB(ctx, namespace, prName)
 }
 
+func (c *dbCache) FindAllDownstreamPackageRevisions(ctx context.Context, namespace, prName string) ([]string, error) {
+	return findDownstreamPkgRevsFromDB(ctx, namespace, prName)
+}
+
 func (c *dbCache) ListPackageRevisions(ctx context.Context, filter repository.ListPackageRevisionFilter) ([]repository.PackageRevision, error) {
 	dbprs, err := pkgRevListPRsFromDB(ctx, filter)
 	if err != nil {
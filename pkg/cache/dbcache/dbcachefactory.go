@@ -1,4 +1,4 @@
-// Copyright 2025 The kpt Authors
+// Copyright 2025-2026 The kpt Authors
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -21,11 +21,16 @@ import (
 	"github.com/kptdev/porch/pkg/cache/repomap"
 	cachetypes "github.com/kptdev/porch/pkg/cache/types"
 	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/klog/v2"
 )
 
 var _ cachetypes.CacheFactory = &DBCacheFactory{}
 
 type DBCacheFactory struct {
+	// ChangeBus carries package revision changes between replicas. If nil, Postgres LISTEN/NOTIFY is used when
+	// cross-replica notification is enabled and the database driver is pgx.
+	ChangeBus ChangeBus
 }
 
 func (f *DBCacheFactory) NewCache(ctx context.Context, options cachetypes.CacheOptions) (cachetypes.Cache, error) {
@@ -40,12 +45,51 @@ func (f *DBCacheFactory) NewCache(ctx context.Context, options cachetypes.CacheO
 		return nil, fmt.Errorf("kptfile_status backfill failed: %w", err)
 	}
 
+	if err := backfillSubpackages(ctx); err != nil {
+		return nil, fmt.Errorf("subpackages backfill failed: %w", err)
+	}
+
 	if err := backfillUpstreamRefName(ctx); err != nil {
 		return nil, fmt.Errorf("upstream_ref_name backfill failed: %w", err)
 	}
 
-	return &dbCache{
-		repositories: repomap.SafeRepoMap{},
-		options:      options,
-	}, nil
+	c := &dbCache{
+		repositories:  repomap.SafeRepoMap{},
+		options:       options,
+		origin:        string(uuid.NewUUID()),
+		localNotifier: options.RepoPRChangeNotifier,
+	}
+
+	if bus := f.changeBus(options.DBCacheOptions); bus != nil {
+		if c.localNotifier == nil {
+			c.localNotifier = cachetypes.NewNoOpRepoPRChangeNotifier()
+		}
+		c.options.RepoPRChangeNotifier = newChangeNotifier(ctx, c.localNotifier, bus, c.origin)
+
+		go func() {
+			if err := bus.Subscribe(ctx, c.handleRemoteChange, c.handleMissedChanges); err != nil && ctx.Err() == nil {
+				klog.Errorf("DbCacheFactory: receiving changes from other replicas stopped: %q", err)
+			}
+		}()
+		klog.Infof("DbCacheFactory: notifying other replicas of package revision changes as %q", c.origin)
+	}
+
+	return c, nil
+}
+
+func (f *DBCacheFactory) changeBus(options cachetypes.DBCacheOptions) ChangeBus {
+	if f.ChangeBus != nil {
+		return f.ChangeBus
+	}
+
+	if !options.CrossReplicaNotification {
+		return nil
+	}
+
+	if options.Driver != cachetypes.DefaultDBCacheDriver {
+		klog.Warningf("DbCacheFactory: cross-replica notification is not supported with database driver %q", options.Driver)
+		return nil
+	}
+
+	return newPgChangeBus(options.DataSource)
 }
@@ -0,0 +1,180 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbcache
+
+import (
+	"context"
+	"time"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	cachetypes "github.com/kptdev/porch/pkg/cache/types"
+	"github.com/kptdev/porch/pkg/repository"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// changePublishTimeout bounds how long the publishing of a change waits for the change bus.
+	changePublishTimeout = 10 * time.Second
+	// changeQueueSize is the number of changes that can wait to be published to the other replicas.
+	changeQueueSize = 10000
+)
+
+// ChangeBus carries package revision changes between the porch-server replicas that share a database. A change
+// published by any replica, including the publishing one, is delivered to the subscribers of every replica.
+type ChangeBus interface {
+	// Publish sends a change to all replicas.
+	Publish(ctx context.Context, change PackageRevisionChange) error
+	// Subscribe calls the handler for each change published by any replica, until the context is done. It calls
+	// missed when changes may have been published without being passed to the handler, for instance while the
+	// bus was disconnected.
+	Subscribe(ctx context.Context, handler func(ctx context.Context, change PackageRevisionChange), missed func(ctx context.Context)) error
+}
+
+// PackageRevisionChange describes a change to a package revision made by a porch-server replica.
+type PackageRevisionChange struct {
+	// Origin identifies the replica that made the change.
+	Origin    string                            `json:"origin"`
+	EventType watch.EventType                   `json:"eventType"`
+	Key       repository.PackageRevisionKey     `json:"key"`
+	Lifecycle porchapi.PackageRevisionLifecycle `json:"lifecycle,omitempty"`
+	Updated   time.Time                         `json:"updated,omitempty"`
+}
+
+var _ cachetypes.RepoPRChangeNotifier = &changeNotifier{}
+
+// changeNotifier notifies the local watchers of changes made on this replica, and publishes the changes to the
+// other replicas. The changes are published in the background, in order, so that a slow change bus does not hold
+// up the requests that make the changes.
+type changeNotifier struct {
+	local  cachetypes.RepoPRChangeNotifier
+	bus    ChangeBus
+	origin string
+	queue  chan PackageRevisionChange
+}
+
+// newChangeNotifier returns a change notifier that publishes changes until the context is done.
+func newChangeNotifier(ctx context.Context, local cachetypes.RepoPRChangeNotifier, bus ChangeBus, origin string) *changeNotifier {
+	n := &changeNotifier{
+		local:  local,
+		bus:    bus,
+		origin: origin,
+		queue:  make(chan PackageRevisionChange, changeQueueSize),
+	}
+	go n.publishQueued(ctx)
+	return n
+}
+
+func (n *changeNotifier) NotifyPackageRevisionChange(eventType watch.EventType, obj repository.PackageRevision) int {
+	sent := n.local.NotifyPackageRevisionChange(eventType, obj)
+
+	change := PackageRevisionChange{
+		Origin:    n.origin,
+		EventType: eventType,
+		Key:       obj.Key(),
+		Lifecycle: obj.Lifecycle(context.Background()),
+	}
+	if dbPR, ok := obj.(*dbPackageRevision); ok {
+		change.Updated = dbPR.updated
+	}
+
+	select {
+	case n.queue <- change:
+	default:
+		klog.Warningf("dbcache: dropping change %q of package revision %+v, %d changes are already waiting to be published to other replicas", eventType, obj.Key(), changeQueueSize)
+	}
+
+	return sent
+}
+
+// publishQueued publishes the queued changes to the other replicas until the context is done.
+func (n *changeNotifier) publishQueued(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case change := <-n.queue:
+			publishCtx, cancel := context.WithTimeout(ctx, changePublishTimeout)
+			if err := n.bus.Publish(publishCtx, change); err != nil {
+				klog.Warningf("dbcache: publishing change %q of package revision %+v to other replicas failed: %q", change.EventType, change.Key, err)
+			}
+			cancel()
+		}
+	}
+}
+
+// handleRemoteChange applies a change made by another replica: it drops this replica's in-memory state for the
+// package revision, and notifies the local watchers.
+func (c *dbCache) handleRemoteChange(ctx context.Context, change PackageRevisionChange) {
+	ctx, span := tracer.Start(ctx, "dbCache::handleRemoteChange", trace.WithAttributes())
+	defer span.End()
+
+	if change.Origin == c.origin {
+		return
+	}
+
+	repo, ok := c.repositories.Load(change.Key.RKey())
+	if !ok {
+		klog.V(4).Infof("dbcache: ignoring change %q of package revision %+v, its repository is not open on this replica", change.EventType, change.Key)
+		return
+	}
+	dbRepo := repo.(*dbRepository)
+
+	if dbRepo.pushDraftsToGit {
+		dbRepo.deleteCachedGitPR(change.Key.PkgKey, change.Key.WorkspaceName)
+	}
+
+	var pr repository.PackageRevision
+	if change.EventType == watch.Deleted {
+		pr = &dbPackageRevision{
+			repo:      dbRepo,
+			pkgRevKey: change.Key,
+			lifecycle: change.Lifecycle,
+			updated:   change.Updated,
+		}
+	} else {
+		readPR, err := pkgRevReadFromDB(ctx, change.Key, false)
+		if err != nil {
+			klog.Warningf("dbcache: reading package revision %+v changed by another replica failed: %q", change.Key, err)
+			return
+		}
+		pr = readPR
+	}
+
+	sent := c.localNotifier.NotifyPackageRevisionChange(change.EventType, pr)
+	klog.Infof("DB cache %+v: sent %d notifications for package revision %+v changed by another replica", dbRepo.Key(), sent, change.Key)
+}
+
+// handleMissedChanges deals with changes of other replicas that this replica did not receive: it drops this
+// replica's in-memory state of all package revisions, and ends the local watches with 410 Gone, so that their
+// clients list the package revisions again from the database.
+func (c *dbCache) handleMissedChanges(ctx context.Context) {
+	_, span := tracer.Start(ctx, "dbCache::handleMissedChanges", trace.WithAttributes())
+	defer span.End()
+
+	c.repositories.Range(func(_, value any) bool {
+		if dbRepo, ok := value.(*dbRepository); ok && dbRepo.pushDraftsToGit {
+			dbRepo.clearCachedGitPRs()
+		}
+		return true
+	})
+
+	if expirer, ok := c.localNotifier.(cachetypes.WatchExpirer); ok {
+		expirer.ExpireWatches("package revision changes of other replicas may have been missed")
+		return
+	}
+	klog.Warningf("dbcache: package revision changes of other replicas may have been missed, and the watches on this replica cannot be ended")
+}
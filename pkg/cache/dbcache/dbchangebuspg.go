@@ -0,0 +1,113 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbcache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"k8s.io/klog/v2"
+)
+
+// pgChangeChannel is the Postgres notification channel that carries package revision changes.
+const pgChangeChannel = "porch_package_revision_changes"
+
+var _ ChangeBus = &pgChangeBus{}
+
+// pgChangeBus is a change bus that uses Postgres LISTEN/NOTIFY. Changes are published on the shared connection
+// pool, and received on a dedicated connection that is re-established if it fails. Changes published while the
+// listening connection is down are not received, so the subscriber is told that it missed changes once the
+// connection listens again.
+type pgChangeBus struct {
+	dataSource     string
+	reconnectDelay time.Duration
+}
+
+func newPgChangeBus(dataSource string) *pgChangeBus {
+	return &pgChangeBus{
+		dataSource:     dataSource,
+		reconnectDelay: 5 * time.Second,
+	}
+}
+
+func (b *pgChangeBus) Publish(ctx context.Context, change PackageRevisionChange) error {
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	_, err = GetDB().db.Exec(ctx, "SELECT pg_notify($1, $2)", pgChangeChannel, string(payload))
+	return err
+}
+
+func (b *pgChangeBus) Subscribe(ctx context.Context, handler func(ctx context.Context, change PackageRevisionChange), missed func(ctx context.Context)) error {
+	// Changes can only have been missed after the connection listened once
+	listened := false
+	listening := func(ctx context.Context) {
+		if listened {
+			missed(ctx)
+		}
+		listened = true
+	}
+
+	for {
+		err := b.listen(ctx, handler, listening)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		klog.Warningf("pgChangeBus: listening on channel %q failed, reconnecting in %v: %q", pgChangeChannel, b.reconnectDelay, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(b.reconnectDelay):
+		}
+	}
+}
+
+// listen receives changes on a new connection until it fails. It calls listening once the connection listens.
+func (b *pgChangeBus) listen(ctx context.Context, handler func(ctx context.Context, change PackageRevisionChange), listening func(ctx context.Context)) error {
+	conn, err := pgx.Connect(ctx, b.dataSource)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(context.Background()); err != nil {
+			klog.V(4).Infof("pgChangeBus: closing listening connection failed: %q", err)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgChangeChannel); err != nil {
+		return err
+	}
+	klog.Infof("pgChangeBus: listening for package revision changes on channel %q", pgChangeChannel)
+	listening(ctx)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var change PackageRevisionChange
+		if err := json.Unmarshal([]byte(notification.Payload), &change); err != nil {
+			klog.Warningf("pgChangeBus: ignoring malformed change %q: %q", notification.Payload, err)
+			continue
+		}
+		handler(ctx, change)
+	}
+}
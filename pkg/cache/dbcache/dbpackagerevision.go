@@ -46,6 +46,9 @@ var (
 type kptfileStatus struct {
 	Conditions   []porchapi.Condition `json:"conditions,omitempty"`
 	UpstreamLock *kptfile.Locator     `json:"upstreamLock,omitempty"`
+	// Subpackages are read from the Kptfiles of the subpackages, so that listing
+	// package revisions does not need to read their resources.
+	Subpackages []porchapi.Subpackage `json:"subpackages,omitempty"`
 }
 
 func extractKptfileStatus(resources map[string]string) kptfileStatus {
@@ -68,6 +71,7 @@ func extractFromKptfile(resources map[string]string) (kptfileStatus, []porchapi.
 		s.Conditions = repository.ToAPIConditions(*kf)
 	}
 	s.UpstreamLock = kf.UpstreamLock
+	s.Subpackages = repository.ToAPISubpackages(repository.FindSubpackages(resources))
 	return s, repository.ToAPIReadinessGates(*kf), &porchapi.PackageMetadata{
 		Labels:      kf.Labels,
 		Annotations: kf.Annotations,
@@ -242,6 +246,7 @@ func (pr *dbPackageRevision) GetPackageRevision(ctx context.Context) (*porchapi.
 		Deployment:         pr.deployment,
 		Conditions:         pr.kptfileStatus.Conditions,
 		ResourcesSizeBytes: pr.resourcesSizeBytes,
+		Subpackages:        pr.kptfileStatus.Subpackages,
 	}
 
 	if porchapi.LifecycleIsPublished(pr.Lifecycle(ctx)) {
@@ -516,6 +521,18 @@ func (pr *dbPackageRevision) publishPR(ctx context.Context, newLifecycle porchap
 	}
 
 	pr.pkgRevKey.Revision = latestRev + 1
+	// Package revisions published elsewhere keep their revision number, publisher and publish time
+	if info, ok := repository.PublishInfoFrom(ctx); ok {
+		if info.Revision > 0 {
+			pr.pkgRevKey.Revision = info.Revision
+		}
+		if info.PublishedBy != "" {
+			pr.updatedBy = info.PublishedBy
+		}
+		if !info.PublishedAt.IsZero() {
+			pr.updated = info.PublishedAt
+		}
+	}
 	pr.lifecycle = newLifecycle
 
 	var gitPR repository.PackageRevision
@@ -490,6 +490,37 @@ upstreamLock:
 		t.Equal("my-pkg", s.UpstreamLock.Git.Directory)
 		t.Equal("abc123", s.UpstreamLock.Git.Commit)
 	})
+
+	t.Run("WithSubpackages", func() {
+		resources := map[string]string{
+			"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: test-pkg\n",
+			"db/Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: db
+upstreamLock:
+  type: git
+  git:
+    repo: https://example.com/catalog.git
+    directory: postgres
+    ref: v1
+    commit: def456`,
+			"db/tls/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: tls\n",
+		}
+		s, _, _ := extractFromKptfile(resources)
+		t.Require().Len(s.Subpackages, 2)
+		t.Equal("db", s.Subpackages[0].Path)
+		t.Equal("db", s.Subpackages[0].Name)
+		t.Require().NotNil(s.Subpackages[0].UpstreamLock)
+		t.Equal("postgres", s.Subpackages[0].UpstreamLock.Git.Directory)
+		t.Equal("db/tls", s.Subpackages[1].Path)
+		t.Equal("db", s.Subpackages[1].Parent)
+
+		pr := &dbPackageRevision{kptfileStatus: s}
+		apiPR, err := pr.GetPackageRevision(context.Background())
+		t.Require().NoError(err)
+		t.Equal(s.Subpackages, apiPR.Status.Subpackages)
+	})
 }
 
 func (t *DbTestSuite) TestKptfileStatusRoundTrip() {
@@ -618,6 +649,71 @@ status:
 	t.deleteTestRepo(dbRepo.Key())
 }
 
+func (t *DbTestSuite) TestBackfillSubpackages() {
+	mockCache := mockcachetypes.NewMockCache(t.T())
+	cachetypes.CacheInstance = mockCache
+	mockCache.EXPECT().GetRepository(mock.Anything).Return(&dbRepository{})
+
+	dbRepo := t.createTestRepo("backfill-ns", "subpkg-backfill-repo")
+	dbPkg := t.createTestPkg(dbRepo.Key(), "backfill-pkg")
+
+	// Write a PR whose kptfile_status was stored before subpackages were (simulating an upgraded row)
+	pr := dbPackageRevision{
+		pkgRevKey: repository.PackageRevisionKey{
+			PkgKey:        dbPkg.Key(),
+			WorkspaceName: "ws-1",
+			Revision:      1,
+		},
+		lifecycle: "Published",
+		resources: map[string]string{
+			"Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: root`,
+			"db/Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: db`,
+			"db/tls/Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: tls`,
+		},
+		kptfileStatus: kptfileStatus{
+			Conditions: []porchapi.Condition{{Type: "Ready", Status: porchapi.ConditionTrue}},
+		},
+	}
+	t.Require().NoError(pkgRevWriteToDB(t.Context(), &pr))
+
+	filter := repository.ListPackageRevisionFilter{
+		Key: repository.PackageRevisionKey{PkgKey: repository.PackageKey{RepoKey: dbRepo.Key()}},
+	}
+	results, err := pkgRevListPRsFromDB(t.Context(), filter)
+	t.Require().NoError(err)
+	t.Require().Len(results, 1)
+	t.Empty(results[0].kptfileStatus.Subpackages)
+
+	err = backfillSubpackages(t.Context())
+	t.Require().NoError(err)
+
+	// Verify the subpackages are populated and the other status fields are kept
+	results, err = pkgRevListPRsFromDB(t.Context(), filter)
+	t.Require().NoError(err)
+	t.Require().Len(results, 1)
+	t.Require().Len(results[0].kptfileStatus.Subpackages, 2)
+	t.Equal("db", results[0].kptfileStatus.Subpackages[0].Path)
+	t.Equal("db", results[0].kptfileStatus.Subpackages[0].Name)
+	t.Equal("db/tls", results[0].kptfileStatus.Subpackages[1].Path)
+	t.Equal("db", results[0].kptfileStatus.Subpackages[1].Parent)
+	t.Len(results[0].kptfileStatus.Conditions, 1)
+
+	// Run backfill again — should be a no-op (kptfile_status now has subpackages)
+	err = backfillSubpackages(t.Context())
+	t.Require().NoError(err)
+
+	t.deleteTestRepo(dbRepo.Key())
+}
+
 func (t *DbTestSuite) TestBackfillUpstreamRefName() {
 	mockCache := mockcachetypes.NewMockCache(t.T())
 	cachetypes.CacheInstance = mockCache
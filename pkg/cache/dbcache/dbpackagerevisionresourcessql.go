@@ -16,8 +16,12 @@ package dbcache
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/kptdev/porch/internal/telemetry"
 	"github.com/kptdev/porch/pkg/repository"
@@ -31,7 +35,10 @@ func pkgRevResourceReadFromDB(ctx context.Context, prk repository.PackageRevisio
 
 	klog.V(5).Infof("pkgRevResourceReadFromDB: reading package revision resource %+v:%q", prk, resKey)
 
-	sqlStatement := `SELECT resource_value FROM resources WHERE k8s_name_space=$1 AND k8s_name=$2 AND resource_key=$3`
+	sqlStatement := `
+		SELECT resource_blobs.content FROM resources
+			JOIN resource_blobs ON resource_blobs.hash=resources.resource_hash
+			WHERE resources.k8s_name_space=$1 AND resources.k8s_name=$2 AND resources.resource_key=$3`
 
 	var resVal string
 
@@ -58,7 +65,10 @@ func pkgRevResourcesReadFromDB(ctx context.Context, prk repository.PackageRevisi
 
 	klog.V(5).Infof("pkgRevResourcesReadFromDB: reading package revision resource %+v", prk)
 
-	sqlStatement := `SELECT resource_key, resource_value FROM resources WHERE k8s_name_space=$1 AND k8s_name=$2`
+	sqlStatement := `
+		SELECT resources.resource_key, resource_blobs.content FROM resources
+			JOIN resource_blobs ON resource_blobs.hash=resources.resource_hash
+			WHERE resources.k8s_name_space=$1 AND resources.k8s_name=$2`
 
 	resources := make(map[string]string)
 
@@ -90,20 +100,28 @@ func pkgRevResourceWriteToDB(ctx context.Context, prk repository.PackageRevision
 
 	klog.V(5).Infof("pkgRevResourceWriteToDB: writing package revision resource %+v=%q for %q", resKey, resVal, prk)
 
-	sqlStatement := `
-		INSERT INTO resources (k8s_name_space, k8s_name, revision, resource_key, resource_value)
+	return runInTx(ctx, func(tx *sql.Tx) error {
+		resHash := resourceHash(resVal)
+		if err := resourceBlobWriteToDB(ctx, tx, resHash, resVal); err != nil {
+			klog.Warningf("pkgRevResourceWriteToDB: blob write failed on package revision %+v: %q", prk, err)
+			return err
+		}
+
+		sqlStatement := `
+		INSERT INTO resources (k8s_name_space, k8s_name, revision, resource_key, resource_hash)
 			VALUES ($1, $2, $3, $4, $5)
-			ON CONFLICT (k8s_name_space, k8s_name, resource_key) 
-			DO UPDATE SET resource_value = EXCLUDED.resource_value`
+			ON CONFLICT (k8s_name_space, k8s_name, resource_key)
+			DO UPDATE SET resource_hash = EXCLUDED.resource_hash`
+
+		klog.V(6).Infof("pkgRevResourceWriteToDB: running query %q on package revision %+v", sqlStatement, prk)
+		if _, err := tx.ExecContext(ctx, sqlStatement, prk.K8SNS(), prk.K8SName(), prk.Revision, resKey, resHash); err != nil {
+			klog.Warningf("pkgRevResourceWriteToDB: query failed on package revision %+v: %q", prk, err)
+			return err
+		}
 
-	klog.V(6).Infof("pkgRevResourceWriteToDB: running query %q on package revision %+v", sqlStatement, prk)
-	if _, err := GetDB().db.Exec(ctx, sqlStatement, prk.K8SNS(), prk.K8SName(), prk.Revision, resKey, resVal); err == nil {
 		klog.V(5).Infof("pkgRevResourceWriteToDB: query succeeded, row created/updated")
 		return nil
-	} else {
-		klog.Warningf("pkgRevResourceWriteToDB: query failed on package revision %+v: %q", prk, err)
-		return err
-	}
+	})
 }
 
 func pkgRevResourcesWriteToDB(ctx context.Context, pr *dbPackageRevision) error {
@@ -112,39 +130,46 @@ func pkgRevResourcesWriteToDB(ctx context.Context, pr *dbPackageRevision) error
 
 	prk := pr.Key()
 
-	tx, err := GetDB().db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("pkgRevResourcesWriteToDB: begin tx: %w", err)
-	}
-	defer tx.Rollback() //nolint:errcheck
+	return runInTx(ctx, func(tx *sql.Tx) error {
+		// Write the blobs before deleting the existing resources, so that the blobs of unchanged
+		// resources keep a reference and are not deleted and written again.
+		resourceHashes := make(map[string]string, len(pr.resources))
+		for resourceKey, resourceValue := range pr.resources {
+			resourceHashes[resourceKey] = resourceHash(resourceValue)
+		}
+		for _, resourceKey := range keysSortedByHash(resourceHashes) {
+			if err := resourceBlobWriteToDB(ctx, tx, resourceHashes[resourceKey], pr.resources[resourceKey]); err != nil {
+				klog.Warningf("pkgRevResourcesWriteToDB: blob write failed for %+v key %q: %q", prk, resourceKey, err)
+				return err
+			}
+		}
 
-	// Delete all existing resources within the transaction.
-	if _, err := tx.ExecContext(ctx, `DELETE FROM resources WHERE k8s_name_space=$1 AND k8s_name=$2`, prk.K8SNS(), prk.K8SName()); err != nil {
-		klog.Warningf("pkgRevResourcesWriteToDB: delete failed for %+v: %q", prk, err)
-		return err
-	}
+		// Delete all existing resources within the transaction.
+		if _, err := tx.ExecContext(ctx, `DELETE FROM resources WHERE k8s_name_space=$1 AND k8s_name=$2`, prk.K8SNS(), prk.K8SName()); err != nil {
+			klog.Warningf("pkgRevResourcesWriteToDB: delete failed for %+v: %q", prk, err)
+			return err
+		}
 
-	if len(pr.resources) == 0 {
-		klog.Warningf("pkgRevResourcesWriteToDB: pr %+v has no resources", prk)
-		return tx.Commit()
-	}
+		if len(pr.resources) == 0 {
+			klog.Warningf("pkgRevResourcesWriteToDB: pr %+v has no resources", prk)
+			return nil
+		}
 
-	klog.V(5).Infof("pkgRevResourcesWriteToDB: writing package revision resources for %+v", prk)
+		klog.V(5).Infof("pkgRevResourcesWriteToDB: writing package revision resources for %+v", prk)
 
-	for resourceKey, resourceValue := range pr.resources {
-		if _, err := tx.ExecContext(ctx,
-			`INSERT INTO resources (k8s_name_space, k8s_name, revision, resource_key, resource_value)
-				VALUES ($1, $2, $3, $4, $5)
-				ON CONFLICT (k8s_name_space, k8s_name, resource_key)
-				DO UPDATE SET resource_value = EXCLUDED.resource_value`,
-			prk.K8SNS(), prk.K8SName(), prk.Revision, resourceKey, resourceValue); err != nil {
-			klog.Warningf("pkgRevResourcesWriteToDB: insert failed for %+v key %q: %q", prk, resourceKey, err)
-			return err
+		for resourceKey, resHash := range resourceHashes {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO resources (k8s_name_space, k8s_name, revision, resource_key, resource_hash)
+				VALUES ($1, $2, $3, $4, $5)`,
+				prk.K8SNS(), prk.K8SName(), prk.Revision, resourceKey, resHash); err != nil {
+				klog.Warningf("pkgRevResourcesWriteToDB: insert failed for %+v key %q: %q", prk, resourceKey, err)
+				return err
+			}
 		}
-	}
 
-	klog.V(5).Infof("pkgRevResourcesWriteToDB: query succeeded, row created/updated")
-	return tx.Commit()
+		klog.V(5).Infof("pkgRevResourcesWriteToDB: query succeeded, row created/updated")
+		return nil
+	})
 }
 
 func pkgRevResourcesDeleteFromDB(ctx context.Context, prk repository.PackageRevisionKey) error {
@@ -186,3 +211,40 @@ func pkgRevResourceDeleteFromDB(ctx context.Context, prk repository.PackageRevis
 
 	return err
 }
+
+// resourceBlobWriteToDB stores the content of a resource in the resource_blobs table, unless a blob with the
+// same content is already stored, and takes a reference to the blob. The caller must refer to the blob from the
+// resources table in the same transaction; the resources_release_blob trigger releases the reference when the
+// resource is deleted or its content is replaced.
+func resourceBlobWriteToDB(ctx context.Context, tx *sql.Tx, resHash, resVal string) error {
+	sqlStatement := `
+		INSERT INTO resource_blobs (hash, content, ref_count)
+			VALUES ($1, $2, 1)
+			ON CONFLICT (hash)
+			DO UPDATE SET ref_count = resource_blobs.ref_count + 1`
+
+	klog.V(6).Infof("resourceBlobWriteToDB: running query %q for blob %q", sqlStatement, resHash)
+	if _, err := tx.ExecContext(ctx, sqlStatement, resHash, resVal); err != nil {
+		return fmt.Errorf("write of resource blob %q failed: %w", resHash, err)
+	}
+	return nil
+}
+
+// resourceHash returns the address of the content of a resource in the resource_blobs table.
+func resourceHash(resVal string) string {
+	hash := sha256.Sum256([]byte(resVal))
+	return hex.EncodeToString(hash[:])
+}
+
+// keysSortedByHash returns the resource keys in the order of the hashes of their content. Writing blobs in a
+// fixed order avoids deadlocks between transactions that write resources with the same content.
+func keysSortedByHash(resourceHashes map[string]string) []string {
+	keys := make([]string, 0, len(resourceHashes))
+	for key := range resourceHashes {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		return strings.Compare(resourceHashes[a], resourceHashes[b])
+	})
+	return keys
+}
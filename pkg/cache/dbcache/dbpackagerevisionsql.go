@@ -360,7 +360,7 @@ func pkgRevWriteToDB(ctx context.Context, pr *dbPackageRevision) error {
 	if _, err := GetDB().db.Exec(ctx,
 		sqlStatement,
 		prk.K8SNS(), prk.K8SName(),
-		prk.PKey().K8SName(), prk.Revision, valueAsJSON(pr.meta), valueAsJSON(pr.spec), pr.updated, pr.updatedBy, pr.lifecycle, valueAsJSON(pr.extPRID), valueAsJSON(pr.tasks), valueAsJSON(pr.kptfileStatus), pr.resourcesSizeBytes, extractUpstreamRefName(pr.tasks)); err == nil {
+		prk.PKey().K8SName(), prk.Revision, valueAsJSON(pr.meta), valueAsJSON(pr.spec), pr.updated, pr.updatedBy, pr.lifecycle, valueAsJSON(pr.extPRID), valueAsJSON(pr.tasks), valueAsJSON(pr.kptfileStatus), pr.resourcesSizeBytes, repository.UpstreamPackageRevisionName(pr.tasks)); err == nil {
 		klog.V(5).Infof("pkgRevWriteToDB: query succeeded, row created")
 	} else {
 		klog.Warningf("pkgRevWriteToDB: query failed for %+v %q", pr.Key(), err)
@@ -415,7 +415,7 @@ func pkgRevUpdateDB(ctx context.Context, pr *dbPackageRevision, updateResources
 	result, err := GetDB().db.Exec(ctx,
 		sqlStatement,
 		prk.K8SNS(), prk.K8SName(),
-		prk.PKey().K8SName(), prk.Revision, valueAsJSON(pr.meta), valueAsJSON(pr.spec), pr.updated, pr.updatedBy, pr.lifecycle, valueAsJSON(pr.extPRID), valueAsJSON(pr.tasks), valueAsJSON(pr.kptfileStatus), pr.resourcesSizeBytes, extractUpstreamRefName(pr.tasks))
+		prk.PKey().K8SName(), prk.Revision, valueAsJSON(pr.meta), valueAsJSON(pr.spec), pr.updated, pr.updatedBy, pr.lifecycle, valueAsJSON(pr.extPRID), valueAsJSON(pr.tasks), valueAsJSON(pr.kptfileStatus), pr.resourcesSizeBytes, repository.UpstreamPackageRevisionName(pr.tasks))
 
 	if err == nil {
 		if rowsAffected, _ := result.RowsAffected(); rowsAffected == 1 {
@@ -502,22 +502,42 @@ func findUpstreamRefsFromDB(ctx context.Context, namespace, prName string) (stri
 	return downstreamName, nil
 }
 
-// extractUpstreamRefName extracts the upstream package revision name from tasks.
-// It looks for clone tasks with an upstreamRef or upgrade tasks with a newUpstreamRef.
-func extractUpstreamRefName(tasks []porchapi.Task) string {
-	for _, task := range tasks {
-		switch task.Type {
-		case porchapi.TaskTypeClone:
-			if task.Clone != nil && task.Clone.Upstream.UpstreamRef != nil && task.Clone.Upstream.UpstreamRef.Name != "" {
-				return task.Clone.Upstream.UpstreamRef.Name
-			}
-		case porchapi.TaskTypeUpgrade:
-			if task.Upgrade != nil && task.Upgrade.NewUpstream.Name != "" {
-				return task.Upgrade.NewUpstream.Name
-			}
+// findDownstreamPkgRevsFromDB returns the names of all package revisions in the namespace
+// that were cloned or upgraded from the given package revision, sorted by name.
+func findDownstreamPkgRevsFromDB(ctx context.Context, namespace, prName string) ([]string, error) {
+	_, span := tracer.Start(ctx, "dbpackagerevisionsql::findDownstreamPkgRevsFromDB")
+	defer span.End()
+
+	if prName == "" {
+		return nil, nil
+	}
+
+	// Uses the same indexed upstream_ref_name lookup as findUpstreamRefsFromDB,
+	// without stopping at the first match.
+	sqlStatement := `
+		SELECT k8s_name FROM package_revisions
+		WHERE k8s_name_space=$1
+		  AND revision != -1
+		  AND upstream_ref_name != ''
+		  AND upstream_ref_name=$2
+		ORDER BY k8s_name
+	`
+
+	rows, err := GetDB().db.Query(ctx, sqlStatement, namespace, prName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var downstreamNames []string
+	for rows.Next() {
+		var downstreamName string
+		if err := rows.Scan(&downstreamName); err != nil {
+			return nil, err
 		}
+		downstreamNames = append(downstreamNames, downstreamName)
 	}
-	return ""
+	return downstreamNames, rows.Err()
 }
 
 // backfillBatchSize controls how many rows are selected and updated per
@@ -537,9 +557,10 @@ func backfillKptfileMeta(ctx context.Context) error {
 	type row struct{ ns, name, specJSON, kfYAML string }
 
 	sqlSelect := `
-		SELECT pr.k8s_name_space, pr.k8s_name, pr.spec, r.resource_value
+		SELECT pr.k8s_name_space, pr.k8s_name, pr.spec, b.content
 		FROM package_revisions pr
 		JOIN resources r ON pr.k8s_name_space = r.k8s_name_space AND pr.k8s_name = r.k8s_name
+		JOIN resource_blobs b ON b.hash = r.resource_hash
 		WHERE pr.kptfile_status = '{}' AND r.resource_key = 'Kptfile'
 		ORDER BY pr.k8s_name_space, pr.k8s_name
 		LIMIT $1
@@ -614,6 +635,118 @@ func backfillKptfileMeta(ctx context.Context) error {
 	return nil
 }
 
+// backfillSubpackages populates the subpackages in the kptfile_status column for
+// any package revisions whose status was written before subpackages were stored
+// there and that contain nested Kptfiles. The subpackages are read from the
+// Kptfile resources of each such row; the other status fields are kept.
+// This runs once on startup, after backfillKptfileMeta, to handle rows created
+// before subpackages were stored. It processes rows in batches using keyset
+// pagination for efficient seeking on large tables.
+func backfillSubpackages(ctx context.Context) error {
+	type update struct {
+		ns, name string
+		status   kptfileStatus
+	}
+
+	sqlSelect := `
+		WITH candidates AS (
+			SELECT pr.k8s_name_space, pr.k8s_name, pr.kptfile_status
+			FROM package_revisions pr
+			WHERE pr.kptfile_status NOT LIKE '%"subpackages"%'
+			  AND (pr.k8s_name_space, pr.k8s_name) > ($2, $3)
+			  AND EXISTS (
+				SELECT 1 FROM resources r
+				WHERE r.k8s_name_space = pr.k8s_name_space AND r.k8s_name = pr.k8s_name
+				  AND r.resource_key LIKE '%/Kptfile'
+			  )
+			ORDER BY pr.k8s_name_space, pr.k8s_name
+			LIMIT $1
+		)
+		SELECT c.k8s_name_space, c.k8s_name, c.kptfile_status, r.resource_key, b.content
+		FROM candidates c
+		JOIN resources r ON c.k8s_name_space = r.k8s_name_space AND c.k8s_name = r.k8s_name
+		JOIN resource_blobs b ON b.hash = r.resource_hash
+		WHERE r.resource_key LIKE '%/Kptfile'
+		ORDER BY c.k8s_name_space, c.k8s_name
+	`
+	sqlUpdate := `UPDATE package_revisions SET kptfile_status = $3 WHERE k8s_name_space = $1 AND k8s_name = $2`
+
+	totalUpdated := 0
+	lastNS, lastName := "", ""
+
+	for {
+		rows, err := GetDB().db.Query(ctx, sqlSelect, backfillBatchSize, lastNS, lastName)
+		if err != nil {
+			return fmt.Errorf("backfillSubpackages: query failed after (%s, %s): %w", lastNS, lastName, err)
+		}
+
+		var updates []update
+		kptfiles := map[string]string{}
+		var statusJSON string
+		flush := func() {
+			if len(kptfiles) == 0 {
+				return
+			}
+			var status kptfileStatus
+			setValueFromJSON(statusJSON, &status)
+			status.Subpackages = repository.ToAPISubpackages(repository.FindSubpackages(kptfiles))
+			updates = append(updates, update{lastNS, lastName, status})
+			kptfiles = map[string]string{}
+		}
+		for rows.Next() {
+			var ns, name, resKey, content string
+			var rowStatusJSON string
+			if err := rows.Scan(&ns, &name, &rowStatusJSON, &resKey, &content); err != nil {
+				rows.Close()
+				return fmt.Errorf("backfillSubpackages: scan failed: %w", err)
+			}
+			if ns != lastNS || name != lastName {
+				flush()
+				lastNS, lastName, statusJSON = ns, name, rowStatusJSON
+			}
+			kptfiles[resKey] = content
+		}
+		flush()
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("backfillSubpackages: row iteration failed: %w", err)
+		}
+
+		if len(updates) == 0 {
+			break
+		}
+
+		tx, err := GetDB().db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("backfillSubpackages: begin transaction failed: %w", err)
+		}
+
+		for _, u := range updates {
+			if _, err := tx.ExecContext(ctx, sqlUpdate, u.ns, u.name, valueAsJSON(u.status)); err != nil {
+				tx.Rollback() //nolint:errcheck
+				return fmt.Errorf("backfillSubpackages: update failed for %s/%s: %w", u.ns, u.name, err)
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("backfillSubpackages: commit failed: %w", err)
+		}
+
+		totalUpdated += len(updates)
+		klog.V(3).Infof("backfillSubpackages: committed batch of %d rows (total so far: %d)", len(updates), totalUpdated)
+
+		if len(updates) < backfillBatchSize {
+			break
+		}
+	}
+
+	if totalUpdated > 0 {
+		klog.Infof("backfillSubpackages: populated subpackages for %d package revisions", totalUpdated)
+	}
+	return nil
+}
+
 // backfillUpstreamRefName populates the upstream_ref_name column for any package
 // revisions that still have an empty value but have tasks containing upstream references.
 // It parses the tasks JSON, extracts the upstream ref name, and stores it.
@@ -656,7 +789,7 @@ func backfillUpstreamRefName(ctx context.Context) error {
 
 			var tasks []porchapi.Task
 			setValueFromJSON(tasksJSON, &tasks)
-			upstreamName := extractUpstreamRefName(tasks)
+			upstreamName := repository.UpstreamPackageRevisionName(tasks)
 			if upstreamName != "" {
 				updates = append(updates, update{ns, name, upstreamName})
 			}
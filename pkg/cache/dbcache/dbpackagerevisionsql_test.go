@@ -304,6 +304,63 @@ func (t *DbTestSuite) TestPackageRevisionResources() {
 	t.Require().NoError(err)
 }
 
+func (t *DbTestSuite) TestPackageRevisionResourceBlobs() {
+	const shared = "Shared between package revisions"
+	const first = "Written first"
+	const second = "Written second"
+
+	dbRepo := t.createTestRepo("my-ns", "my-blob-repo")
+	leftPkg := t.createTestPkg(dbRepo.Key(), "left-package")
+	rightPkg := t.createTestPkg(dbRepo.Key(), "right-package")
+
+	leftPR := t.createTestPR(leftPkg.Key(), "left_pr")
+	leftPR.resources = map[string]string{"Kptfile": shared, "file.txt": first}
+	t.Require().NoError(pkgRevResourcesWriteToDB(t.Context(), &leftPR))
+
+	rightPR := t.createTestPR(rightPkg.Key(), "right_pr")
+	rightPR.resources = map[string]string{"Kptfile": shared, "file.txt": second}
+	t.Require().NoError(pkgRevResourcesWriteToDB(t.Context(), &rightPR))
+
+	t.Equal(int64(2), t.resourceBlobRefCount(shared))
+	t.Equal(int64(1), t.resourceBlobRefCount(first))
+	t.Equal(int64(1), t.resourceBlobRefCount(second))
+
+	// Rewriting unchanged resources keeps the reference counts
+	t.Require().NoError(pkgRevResourcesWriteToDB(t.Context(), &leftPR))
+	t.Equal(int64(2), t.resourceBlobRefCount(shared))
+	t.Equal(int64(1), t.resourceBlobRefCount(first))
+
+	// Replacing the content of a resource releases the old blob
+	t.Require().NoError(pkgRevResourceWriteToDB(t.Context(), rightPR.Key(), "file.txt", first))
+	t.Equal(int64(2), t.resourceBlobRefCount(first))
+	t.Equal(int64(0), t.resourceBlobRefCount(second))
+
+	resources, err := pkgRevResourcesReadFromDB(t.Context(), rightPR.Key())
+	t.Require().NoError(err)
+	t.Equal(map[string]string{"Kptfile": shared, "file.txt": first}, resources)
+
+	t.Require().NoError(pkgRevResourceDeleteFromDB(t.Context(), leftPR.Key(), "file.txt"))
+	t.Equal(int64(1), t.resourceBlobRefCount(first))
+
+	t.Require().NoError(pkgRevDeleteFromDB(t.Context(), leftPR.Key()))
+	t.Equal(int64(1), t.resourceBlobRefCount(shared))
+
+	// Deleting the repository releases the blobs of its package revisions
+	t.Require().NoError(repoDeleteFromDB(t.Context(), dbRepo.Key()))
+	t.Equal(int64(0), t.resourceBlobRefCount(shared))
+	t.Equal(int64(0), t.resourceBlobRefCount(first))
+}
+
+func (t *DbTestSuite) resourceBlobRefCount(content string) int64 {
+	var refCount int64
+	err := GetDB().db.QueryRow(t.Context(), `SELECT ref_count FROM resource_blobs WHERE hash=$1`, resourceHash(content)).Scan(&refCount)
+	if err == sql.ErrNoRows {
+		return 0
+	}
+	t.Require().NoError(err)
+	return refCount
+}
+
 func (t *DbTestSuite) TestPackageRevisionDBSchema() {
 	dbPR := dbPackageRevision{}
 	err := pkgRevWriteToDB(t.Context(), &dbPR)
@@ -92,6 +92,14 @@ func (r *dbRepository) deleteCachedGitPR(pkgKey repository.PackageKey, workspace
 	klog.V(5).Infof("deleted cached gitPR for %s", cacheKey)
 }
 
+func (r *dbRepository) clearCachedGitPRs() {
+	r.gitPRCacheMutex.Lock()
+	defer r.gitPRCacheMutex.Unlock()
+
+	clear(r.gitPRCache)
+	klog.V(5).Infof("cleared cached gitPRs of repository %+v", r.Key())
+}
+
 func (r *dbRepository) KubeObjectName() string {
 	return r.Key().Name
 }
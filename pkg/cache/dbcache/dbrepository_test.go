@@ -15,6 +15,7 @@
 package dbcache
 
 import (
+	"context"
 	"errors"
 	"strings"
 
@@ -142,6 +143,76 @@ func (t *DbTestSuite) TestDBRepositoryPRCrud() {
 	t.Require().NoError(err)
 }
 
+type failingPendingPush struct {
+	err error
+}
+
+func (p *failingPendingPush) Push(context.Context) error {
+	return p.err
+}
+
+func (t *DbTestSuite) TestDBRepositoryPushBatch() {
+	mockCache := mockcachetypes.NewMockCache(t.T())
+	cachetypes.CacheInstance = mockCache
+
+	externalrepo.ExternalRepoInUnitTestMode = true
+
+	ctx := t.Context()
+
+	testRepo := t.createTestRepo("my-ns", "my-batch-repo")
+	mockCache.EXPECT().GetRepository(mock.Anything).Return(testRepo).Maybe()
+
+	err := testRepo.OpenRepository(ctx, externalrepotypes.ExternalRepoOptions{})
+	t.Require().NoError(err)
+
+	newPRDef := porchapi.PackageRevision{
+		Spec: porchapi.PackageRevisionSpec{
+			RepositoryName: "my-batch-repo",
+			PackageName:    "my-package",
+			WorkspaceName:  "my-workspace",
+		},
+	}
+	newPRDraft, err := testRepo.CreatePackageRevisionDraft(ctx, &newPRDef)
+	t.Require().NoError(err)
+	t.Require().NoError(newPRDraft.UpdateLifecycle(ctx, porchapi.PackageRevisionLifecycleProposed))
+	proposedPR, err := testRepo.ClosePackageRevisionDraft(ctx, newPRDraft, -1)
+	t.Require().NoError(err)
+
+	publish := func(ctx context.Context) {
+		draft, err := testRepo.UpdatePackageRevision(ctx, proposedPR)
+		t.Require().NoError(err)
+		t.Require().NoError(draft.UpdateLifecycle(ctx, porchapi.PackageRevisionLifecyclePublished))
+		_, err = testRepo.ClosePackageRevisionDraft(ctx, draft, 0)
+		t.Require().NoError(err)
+	}
+
+	// The database writes of a batch whose push fails are rolled back
+	pushErr := errors.New("push rejected")
+	batch := repository.NewPushBatch()
+	batchCtx := repository.WithPushBatch(ctx, batch)
+	publish(batchCtx)
+	batch.Pending(testRepo.Key(), func() repository.PendingPush { return &failingPendingPush{err: pushErr} })
+	t.ErrorIs(batch.Flush(ctx), pushErr)
+
+	readPR, err := pkgRevReadFromDB(ctx, proposedPR.Key(), false)
+	t.Require().NoError(err)
+	t.Equal(porchapi.PackageRevisionLifecycleProposed, readPR.lifecycle)
+	t.Equal(0, readPR.Key().Revision)
+
+	// The database writes of a batch whose push succeeds are committed
+	batch = repository.NewPushBatch()
+	publish(repository.WithPushBatch(ctx, batch))
+	t.Require().NoError(batch.Flush(ctx))
+
+	readPR, err = pkgRevReadFromDB(ctx, proposedPR.Key(), false)
+	t.Require().NoError(err)
+	t.Equal(porchapi.PackageRevisionLifecyclePublished, readPR.lifecycle)
+	t.Equal(1, readPR.Key().Revision)
+
+	err = testRepo.Close(ctx)
+	t.Require().NoError(err)
+}
+
 func (t *DbTestSuite) TestDBRepositorySync() {
 	mockCache := mockcachetypes.NewMockCache(t.T())
 	cachetypes.CacheInstance = mockCache
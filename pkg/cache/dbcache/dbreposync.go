@@ -222,6 +222,17 @@ func (s *repositorySync) cacheExternalPRs(ctx context.Context, externalPrMap map
 			extAPIPR.CreationTimestamp.Time = time.Now()
 		}
 
+		// A draft or proposed package revision with the same name as a published external package revision
+		// is a stale copy, for example one restored from a cache backup taken before the package revision
+		// was published in git. Unpublished package revisions cannot be updated to published ones, so the
+		// stale copy is replaced.
+		if cachedPR, err := pkgRevReadFromDB(ctx, extPRKey, false); err == nil && !porchapi.LifecycleIsPublished(cachedPR.lifecycle) && extPRKey.Revision != 0 {
+			klog.Warningf("repositorySync %+v: replacing cached %s package revision %+v with package revision published in external repository", s.repo.Key(), cachedPR.lifecycle, extPRKey)
+			if err := pkgRevDeleteFromDB(ctx, cachedPR.Key()); err != nil {
+				return err
+			}
+		}
+
 		_, extPRUpstreamLock, _ := extPR.GetLock(ctx)
 
 		dbPR := dbPackageRevision{
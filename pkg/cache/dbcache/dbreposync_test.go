@@ -692,6 +692,93 @@ func (t *DbTestSuite) TestCacheExternalPRs_AllTextFiles() {
 	t.True(hasReadme, "README.md should be cached")
 }
 
+// TestCacheExternalPRs_ReplacesRestoredProposed verifies a proposed package revision restored from a
+// cache backup is replaced by the package revision published in the external repository
+func (t *DbTestSuite) TestCacheExternalPRs_ReplacesRestoredProposed() {
+	ctx := t.Context()
+	externalrepo.ExternalRepoInUnitTestMode = true
+
+	testRepo := t.createTestRepo("restored-ns", "restored-repo")
+	defer t.deleteTestRepo(testRepo.Key())
+
+	mockCache := mockcachetypes.NewMockCache(t.T())
+	cachetypes.CacheInstance = mockCache
+	mockCache.EXPECT().GetRepository(mock.Anything).Return(testRepo).Maybe()
+
+	err := testRepo.OpenRepository(ctx, externalrepotypes.ExternalRepoOptions{})
+	t.Require().NoError(err)
+	defer func() {
+		if err := testRepo.Close(ctx); err != nil {
+			t.T().Logf("Failed to close test repo: %v", err)
+		}
+	}()
+
+	pkgKey := repository.PackageKey{
+		RepoKey: testRepo.Key(),
+		Package: "restored-pkg",
+	}
+	t.Require().NoError(pkgWriteToDB(ctx, &dbPackage{pkgKey: pkgKey, updated: time.Now().UTC()}))
+
+	// The backup was taken while the package revision was proposed
+	restored := dbPackageRevision{
+		pkgRevKey: repository.PackageRevisionKey{
+			PkgKey:        pkgKey,
+			WorkspaceName: "ws",
+		},
+		updated:   time.Now().UTC(),
+		lifecycle: porchapi.PackageRevisionLifecycleProposed,
+		resources: map[string]string{"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\n"},
+	}
+	t.Require().NoError(pkgRevWriteToDB(ctx, &restored))
+
+	prKey := repository.PackageRevisionKey{
+		PkgKey:        pkgKey,
+		Revision:      1,
+		WorkspaceName: "ws",
+	}
+	resources := map[string]string{
+		"Kptfile":         "apiVersion: kpt.dev/v1\nkind: Kptfile\n",
+		"deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\n",
+	}
+	fakeExtPR := &fake.FakePackageRevision{
+		PrKey: prKey,
+		PackageRevision: &porchapi.PackageRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              prKey.K8SName(),
+				Namespace:         "restored-ns",
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: porchapi.PackageRevisionSpec{
+				RepositoryName: "restored-repo",
+				PackageName:    "restored-pkg",
+				WorkspaceName:  "ws",
+				Revision:       1,
+				Lifecycle:      porchapi.PackageRevisionLifecyclePublished,
+			},
+		},
+		PackageLifecycle: porchapi.PackageRevisionLifecyclePublished,
+		Resources: &porchapi.PackageRevisionResources{
+			Spec: porchapi.PackageRevisionResourcesSpec{Resources: resources},
+		},
+		Kptfile: kptfilev1.KptFile{
+			Upstream:     &kptfilev1.Upstream{},
+			UpstreamLock: &kptfilev1.Locator{},
+		},
+	}
+
+	repoSync := &repositorySync{
+		repo: testRepo,
+	}
+	err = repoSync.cacheExternalPRs(ctx, map[repository.PackageRevisionKey]repository.PackageRevision{prKey: fakeExtPR}, []repository.PackageRevisionKey{prKey})
+	t.Require().NoError(err)
+
+	cachedPR, err := pkgRevReadFromDB(ctx, prKey, true)
+	t.Require().NoError(err)
+	t.Equal(porchapi.PackageRevisionLifecyclePublished, cachedPR.lifecycle)
+	t.Equal(1, cachedPR.Key().Revision)
+	t.Equal(resources, cachedPR.resources)
+}
+
 // TestCacheExternalPRs_AllBinaryFiles verifies all binary files are skipped without error
 func (t *DbTestSuite) TestCacheExternalPRs_AllBinaryFiles() {
 	ctx := t.Context()
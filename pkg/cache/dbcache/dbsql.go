@@ -18,6 +18,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+
+	"github.com/kptdev/porch/pkg/repository"
 )
 
 type dbSQLInterface interface {
@@ -50,7 +52,82 @@ func (ds *dbSQL) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, err
 	}
 }
 
+// dbBatchTx is the transaction in which the database writes made under a push batch are kept
+// until the pushes of the batch succeed.
+type dbBatchTx struct {
+	tx *sql.Tx
+}
+
+func (t *dbBatchTx) Commit(context.Context) error {
+	return t.tx.Commit()
+}
+
+func (t *dbBatchTx) Rollback(context.Context) error {
+	return t.tx.Rollback()
+}
+
+type dbBatchTxKey struct{}
+
+// batchTx returns the transaction of the push batch on ctx. If the batch has no transaction yet,
+// it is started if start is set, otherwise nil is returned.
+func (ds *dbSQL) batchTx(ctx context.Context, start bool) (*sql.Tx, error) {
+	batch, ok := repository.PushBatchFrom(ctx)
+	if !ok || ds.db == nil {
+		return nil, nil
+	}
+
+	var begin func() (repository.BatchTransaction, error)
+	if start {
+		begin = func() (repository.BatchTransaction, error) {
+			// The transaction outlives the request that starts it, it ends when the batch is flushed
+			tx, err := ds.db.BeginTx(context.WithoutCancel(ctx), nil)
+			if err != nil {
+				return nil, fmt.Errorf("cannot begin transaction for push batch: %w", err)
+			}
+			return &dbBatchTx{tx: tx}, nil
+		}
+	}
+
+	batchTx, err := batch.Transaction(dbBatchTxKey{}, begin)
+	if err != nil || batchTx == nil {
+		return nil, err
+	}
+	return batchTx.(*dbBatchTx).tx, nil
+}
+
+// runInTx calls f with the transaction of the push batch on ctx if there is one, or with a new
+// transaction that is committed if f succeeds.
+func runInTx(ctx context.Context, f func(tx *sql.Tx) error) error {
+	db := GetDB().db
+	if ds, ok := db.(*dbSQL); ok {
+		tx, err := ds.batchTx(ctx, true)
+		if err != nil {
+			return err
+		}
+		if tx != nil {
+			return f(tx)
+		}
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := f(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 func (ds *dbSQL) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if tx, err := ds.batchTx(ctx, true); err != nil {
+		return nil, err
+	} else if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+
 	if ds.db != nil {
 		return ds.db.ExecContext(ctx, query, args...)
 	} else {
@@ -58,7 +135,15 @@ func (ds *dbSQL) Exec(ctx context.Context, query string, args ...any) (sql.Resul
 	}
 }
 
+// Query and QueryRow read in the transaction of the push batch on ctx, if it has started, so that
+// they see the writes made in the batch so far.
 func (ds *dbSQL) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if tx, err := ds.batchTx(ctx, false); err != nil {
+		return nil, err
+	} else if tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+
 	if ds.db != nil {
 		return ds.db.QueryContext(ctx, query, args...)
 	} else {
@@ -67,6 +152,10 @@ func (ds *dbSQL) Query(ctx context.Context, query string, args ...any) (*sql.Row
 }
 
 func (ds *dbSQL) QueryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	if tx, _ := ds.batchTx(ctx, false); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+
 	if ds.db != nil {
 		return ds.db.QueryRowContext(ctx, query, args...)
 	} else {
@@ -1,4 +1,4 @@
-// Copyright 2025 The kpt Authors
+// Copyright 2025-2026 The kpt Authors
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -55,6 +55,8 @@ type DBCacheOptions struct {
 	MaxConnections     int
 	MaxIdleConnections int
 	MaxConnLifetime    time.Duration
+	// CrossReplicaNotification sends package revision changes to the other porch-server replicas sharing the database.
+	CrossReplicaNotification bool
 }
 
 type CRCacheOptions struct {
@@ -70,6 +72,7 @@ type Cache interface {
 	UpdateRepository(ctx context.Context, repositorySpec *configapi.Repository) error
 	CheckRepositoryConnectivity(ctx context.Context, repositorySpec *configapi.Repository) error
 	FindAllUpstreamReferencesInRepositories(ctx context.Context, namespace, prName string) (string, error)
+	FindAllDownstreamPackageRevisions(ctx context.Context, namespace, prName string) ([]string, error)
 	ListPackageRevisions(ctx context.Context, filter repository.ListPackageRevisionFilter) ([]repository.PackageRevision, error)
 }
 
@@ -85,6 +88,12 @@ type RepoPRChangeNotifier interface {
 	NotifyPackageRevisionChange(eventType watch.EventType, obj repository.PackageRevision) int
 }
 
+// WatchExpirer is implemented by change notifiers that can end their watches when changes were missed.
+type WatchExpirer interface {
+	// ExpireWatches ends all watches with a ResourceExpired error, so that their clients list again.
+	ExpireWatches(reason string)
+}
+
 // noOpRepoPRChangeNotifier is a no-op implementation
 type noOpRepoPRChangeNotifier struct{}
 
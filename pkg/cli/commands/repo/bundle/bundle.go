@@ -0,0 +1,155 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bundle reads and writes repository bundle archives.
+//
+// A bundle archive is a gzipped tarball holding a bundle.yaml file with the RepositoryBundle
+// without its resources, and the resources of each package revision as regular files under
+// packages/<package name>/<workspace name>/.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	manifestFile   = "bundle.yaml"
+	packagesPrefix = "packages/"
+)
+
+// Write writes a bundle archive of the RepositoryBundle to w.
+func Write(w io.Writer, bundle *porchapi.RepositoryBundle) error {
+	manifest := bundle.DeepCopy()
+	for i := range manifest.Spec.PackageRevisions {
+		manifest.Spec.PackageRevisions[i].Resources = nil
+	}
+	manifestBytes, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("cannot marshal bundle manifest: %w", err)
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	modTime := time.Now()
+
+	writeFile := func(name string, contents []byte) error {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(contents)),
+			ModTime: modTime,
+		}); err != nil {
+			return err
+		}
+		_, err := tw.Write(contents)
+		return err
+	}
+
+	if err := writeFile(manifestFile, manifestBytes); err != nil {
+		return err
+	}
+	for _, pr := range bundle.Spec.PackageRevisions {
+		prefix := packagePrefix(&pr)
+		for _, name := range slices.Sorted(maps.Keys(pr.Resources)) {
+			if err := writeFile(prefix+name, []byte(pr.Resources[name])); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
+
+// Read reads a bundle archive from r and returns the RepositoryBundle it holds, including
+// the resources of its package revisions.
+func Read(r io.Reader) (*porchapi.RepositoryBundle, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a bundle archive: %w", err)
+	}
+	defer gzr.Close()
+
+	var manifestBytes []byte
+	files := map[string]string{}
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read bundle archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read %q from bundle archive: %w", hdr.Name, err)
+		}
+		if hdr.Name == manifestFile {
+			manifestBytes = contents
+		} else {
+			files[hdr.Name] = string(contents)
+		}
+	}
+	if manifestBytes == nil {
+		return nil, fmt.Errorf("bundle archive has no %s", manifestFile)
+	}
+
+	var bundle porchapi.RepositoryBundle
+	if err := yaml.Unmarshal(manifestBytes, &bundle); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", manifestFile, err)
+	}
+
+	// Package names may contain slashes, so each file belongs to the package revision
+	// with the longest matching prefix.
+	prs := bundle.Spec.PackageRevisions
+	for name, contents := range files {
+		match := -1
+		for i := range prs {
+			prefix := packagePrefix(&prs[i])
+			if strings.HasPrefix(name, prefix) && (match < 0 || len(prefix) > len(packagePrefix(&prs[match]))) {
+				match = i
+			}
+		}
+		if match < 0 {
+			return nil, fmt.Errorf("file %q in bundle archive does not belong to any package revision", name)
+		}
+		if prs[match].Resources == nil {
+			prs[match].Resources = map[string]string{}
+		}
+		prs[match].Resources[strings.TrimPrefix(name, packagePrefix(&prs[match]))] = contents
+	}
+
+	return &bundle, nil
+}
+
+func packagePrefix(pr *porchapi.BundledPackageRevision) string {
+	return packagesPrefix + path.Join(pr.PackageName, pr.WorkspaceName) + "/"
+}
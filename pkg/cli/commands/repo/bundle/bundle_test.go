@@ -0,0 +1,88 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	published := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	bundle := &porchapi.RepositoryBundle{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RepositoryBundle",
+			APIVersion: porchapi.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: "blueprints"},
+		Spec: porchapi.RepositoryBundleSpec{
+			SourceRepository: "blueprints",
+			PackageRevisions: []porchapi.BundledPackageRevision{
+				{
+					PackageName:   "base",
+					WorkspaceName: "v1",
+					Revision:      1,
+					Lifecycle:     porchapi.PackageRevisionLifecyclePublished,
+					PublishedBy:   "alice",
+					PublishedAt:   published,
+					Resources: map[string]string{
+						"Kptfile":            "kind: Kptfile\n",
+						"config/config.yaml": "kind: ConfigMap\n",
+					},
+				},
+				{
+					PackageName:   "nested/app",
+					WorkspaceName: "ws",
+					Lifecycle:     porchapi.PackageRevisionLifecycleDraft,
+					Resources: map[string]string{
+						"Kptfile": "kind: Kptfile\nmetadata:\n  name: app\n",
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, bundle))
+
+	got, err := Read(&buf)
+	require.NoError(t, err)
+	// the time is read back in the local time zone
+	assert.True(t, published.Equal(&got.Spec.PackageRevisions[0].PublishedAt))
+	got.Spec.PackageRevisions[0].PublishedAt = published
+	assert.Equal(t, bundle, got)
+}
+
+func TestReadInvalid(t *testing.T) {
+	_, err := Read(strings.NewReader("not a tarball"))
+	assert.ErrorContains(t, err, "not a bundle archive")
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, &porchapi.RepositoryBundle{
+		Spec: porchapi.RepositoryBundleSpec{
+			PackageRevisions: []porchapi.BundledPackageRevision{{PackageName: "base", WorkspaceName: "v1"}},
+		},
+	}))
+	got, err := Read(&buf)
+	require.NoError(t, err)
+	assert.Empty(t, got.Spec.PackageRevisions[0].Resources)
+}
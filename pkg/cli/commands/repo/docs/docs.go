@@ -146,3 +146,62 @@ var SyncExamples = `
   # Schedule sync for repositories foo1 and foo2 in namespace bar at a specific time
   $ porchctl repo sync foo1 foo2 --namespace bar --run-once=2025-09-16T14:00:00Z
 `
+
+var ExportShort = `Export the package revisions of a repository to a bundle archive.`
+var ExportLong = `
+  porchctl repo export REPOSITORY_NAME --output=FILE [flags]
+
+Description:
+
+  This command writes all package revisions of a registered repository, with their
+  resources, lifecycles, tasks, revision numbers and publishing metadata, to a gzipped
+  tarball. The bundle can be imported into another repository with porchctl repo import.
+
+Args:
+
+  REPOSITORY_NAME:
+    The name of a registered repository.
+
+Flags:
+
+  --output, -o:
+    Path of the bundle archive to write. Use - to write to standard output.
+`
+var ExportExamples = `
+  # export all package revisions of the repository blueprints to blueprints.tgz
+  $ porchctl repo export blueprints -o blueprints.tgz --namespace=default
+`
+
+var ImportShort = `Import a bundle archive into a repository.`
+var ImportLong = `
+  porchctl repo import BUNDLE --into=REPOSITORY_NAME [flags]
+
+Description:
+
+  This command imports the package revisions of a bundle archive written by
+  porchctl repo export into a registered repository. Published package revisions keep
+  their revision numbers and publishing metadata. The bundle is checked against the
+  repository first; if any package revision conflicts with an existing one, the
+  conflicts are reported and nothing is imported.
+
+Args:
+
+  BUNDLE:
+    Path of the bundle archive to import.
+
+Flags:
+
+  --into:
+    The name of the registered repository to import the bundle into.
+
+  --dry-run:
+    Check the bundle against the repository and list the package revisions that
+    would be imported, without importing them.
+`
+var ImportExamples = `
+  # check whether blueprints.tgz can be imported into the repository blueprints-copy
+  $ porchctl repo import blueprints.tgz --into=blueprints-copy --dry-run --namespace=default
+
+  # import blueprints.tgz into the repository blueprints-copy
+  $ porchctl repo import blueprints.tgz --into=blueprints-copy --namespace=default
+`
@@ -0,0 +1,131 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	cliutils "github.com/kptdev/porch/internal/cliutils"
+	"github.com/kptdev/porch/pkg/cli/commands/repo/bundle"
+	"github.com/kptdev/porch/pkg/cli/commands/repo/docs"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	command = "cmdrepoexport"
+)
+
+func NewCommand(ctx context.Context, rcg *genericclioptions.ConfigFlags) *cobra.Command {
+	return newRunner(ctx, rcg).Command
+}
+
+func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner {
+	r := &runner{
+		ctx: ctx,
+		cfg: rcg,
+	}
+	c := &cobra.Command{
+		Use:     "export REPOSITORY [flags]",
+		Short:   docs.ExportShort,
+		Long:    docs.ExportShort + "\n" + docs.ExportLong,
+		Example: docs.ExportExamples,
+		PreRunE: r.preRunE,
+		RunE:    r.runE,
+		Hidden:  cliutils.HidePorchCommands,
+	}
+	r.Command = c
+
+	c.Flags().StringVarP(&r.output, "output", "o", "", "Path of the bundle archive to write. Use - to write to standard output.")
+
+	return r
+}
+
+type runner struct {
+	ctx     context.Context
+	cfg     *genericclioptions.ConfigFlags
+	client  client.Client
+	Command *cobra.Command
+
+	// Flags
+	output string
+}
+
+func (r *runner) preRunE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".preRunE"
+
+	if r.output == "" {
+		return errors.E(op, fmt.Errorf("--output is required"))
+	}
+
+	if *r.cfg.Namespace == "" {
+		// Get the namespace from kubeconfig
+		namespace, _, err := r.cfg.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return fmt.Errorf("error getting namespace: %w", err)
+		}
+		r.cfg.Namespace = &namespace
+	}
+
+	if r.client == nil {
+		client, err := cliutils.CreateClientWithFlags(r.cfg)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		r.client = client
+	}
+	return nil
+}
+
+func (r *runner) runE(cmd *cobra.Command, args []string) error {
+	const op errors.Op = command + ".runE"
+
+	if len(args) == 0 {
+		return errors.E(op, fmt.Errorf("REPOSITORY is a required positional argument"))
+	}
+
+	var repositoryBundle porchapi.RepositoryBundle
+	if err := r.client.Get(r.ctx, client.ObjectKey{
+		Namespace: *r.cfg.Namespace,
+		Name:      args[0],
+	}, &repositoryBundle); err != nil {
+		return errors.E(op, err)
+	}
+
+	var archive bytes.Buffer
+	if err := bundle.Write(&archive, &repositoryBundle); err != nil {
+		return errors.E(op, fmt.Errorf("cannot write bundle archive: %w", err))
+	}
+
+	if r.output == "-" {
+		if _, err := cmd.OutOrStdout().Write(archive.Bytes()); err != nil {
+			return errors.E(op, err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(r.output, archive.Bytes(), 0644); err != nil {
+		return errors.E(op, err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s exported %d package revisions to %s\n",
+		args[0], len(repositoryBundle.Spec.PackageRevisions), r.output)
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importbundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	cliutils "github.com/kptdev/porch/internal/cliutils"
+	"github.com/kptdev/porch/pkg/cli/commands/repo/bundle"
+	"github.com/kptdev/porch/pkg/cli/commands/repo/docs"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	command = "cmdrepoimport"
+)
+
+func NewCommand(ctx context.Context, rcg *genericclioptions.ConfigFlags) *cobra.Command {
+	return newRunner(ctx, rcg).Command
+}
+
+func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner {
+	r := &runner{
+		ctx: ctx,
+		cfg: rcg,
+	}
+	c := &cobra.Command{
+		Use:     "import BUNDLE [flags]",
+		Short:   docs.ImportShort,
+		Long:    docs.ImportShort + "\n" + docs.ImportLong,
+		Example: docs.ImportExamples,
+		PreRunE: r.preRunE,
+		RunE:    r.runE,
+		Hidden:  cliutils.HidePorchCommands,
+	}
+	r.Command = c
+
+	c.Flags().StringVar(&r.into, "into", "", "Name of the registered repository to import the bundle into.")
+	c.Flags().BoolVar(&r.dryRun, "dry-run", false, "Check the bundle against the repository and list the package revisions that would be imported, without importing them.")
+
+	return r
+}
+
+type runner struct {
+	ctx     context.Context
+	cfg     *genericclioptions.ConfigFlags
+	client  client.Client
+	Command *cobra.Command
+
+	// Flags
+	into   string
+	dryRun bool
+}
+
+func (r *runner) preRunE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".preRunE"
+
+	if r.into == "" {
+		return errors.E(op, fmt.Errorf("--into is required"))
+	}
+
+	if *r.cfg.Namespace == "" {
+		// Get the namespace from kubeconfig
+		namespace, _, err := r.cfg.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return fmt.Errorf("error getting namespace: %w", err)
+		}
+		r.cfg.Namespace = &namespace
+	}
+
+	if r.client == nil {
+		client, err := cliutils.CreateClientWithFlags(r.cfg)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		r.client = client
+	}
+	return nil
+}
+
+func (r *runner) runE(cmd *cobra.Command, args []string) error {
+	const op errors.Op = command + ".runE"
+
+	if len(args) == 0 {
+		return errors.E(op, fmt.Errorf("BUNDLE is a required positional argument"))
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer f.Close()
+
+	repositoryBundle, err := bundle.Read(f)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	repositoryBundle.TypeMeta = metav1.TypeMeta{
+		Kind:       "RepositoryBundle",
+		APIVersion: porchapi.SchemeGroupVersion.Identifier(),
+	}
+	repositoryBundle.ObjectMeta = metav1.ObjectMeta{
+		Name:      r.into,
+		Namespace: *r.cfg.Namespace,
+	}
+
+	var opts []client.CreateOption
+	if r.dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := r.client.Create(r.ctx, repositoryBundle, opts...); err != nil {
+		if apierrors.IsConflict(err) {
+			return errors.E(op, fmt.Errorf("nothing was imported: %w", err))
+		}
+		return errors.E(op, err)
+	}
+
+	verb := "imported"
+	if r.dryRun {
+		verb = "would be imported (dry run)"
+	}
+	for _, name := range repositoryBundle.Status.Imported {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", name, verb)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importbundle
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/kptdev/porch/pkg/cli/commands/repo/bundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestRunE(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tgz")
+	var archive bytes.Buffer
+	require.NoError(t, bundle.Write(&archive, &porchapi.RepositoryBundle{
+		Spec: porchapi.RepositoryBundleSpec{
+			SourceRepository: "blueprints",
+			PackageRevisions: []porchapi.BundledPackageRevision{{
+				PackageName:   "base",
+				WorkspaceName: "v1",
+				Revision:      1,
+				Lifecycle:     porchapi.PackageRevisionLifecyclePublished,
+				Resources:     map[string]string{"Kptfile": "kind: Kptfile\n"},
+			}},
+		},
+	}))
+	require.NoError(t, os.WriteFile(bundlePath, archive.Bytes(), 0644))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, porchapi.AddToScheme(scheme))
+
+	newRunnerWithClient := func(dryRun bool, create func(obj *porchapi.RepositoryBundle, dryRun bool) error) (*runner, *bytes.Buffer) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(_ context.Context, _ client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				createOpts := &client.CreateOptions{}
+				createOpts.ApplyOptions(opts)
+				return create(obj.(*porchapi.RepositoryBundle), len(createOpts.DryRun) > 0)
+			},
+		}).Build()
+
+		ns := "ns"
+		r := newRunner(context.Background(), &genericclioptions.ConfigFlags{Namespace: &ns})
+		r.client = c
+		r.into = "copy"
+		r.dryRun = dryRun
+		out := &bytes.Buffer{}
+		r.Command.SetOut(out)
+		return r, out
+	}
+
+	t.Run("import", func(t *testing.T) {
+		r, out := newRunnerWithClient(false, func(obj *porchapi.RepositoryBundle, dryRun bool) error {
+			assert.False(t, dryRun)
+			assert.Equal(t, "copy", obj.Name)
+			assert.Equal(t, "ns", obj.Namespace)
+			require.Len(t, obj.Spec.PackageRevisions, 1)
+			assert.Equal(t, "kind: Kptfile\n", obj.Spec.PackageRevisions[0].Resources["Kptfile"])
+			obj.Status.Imported = []string{"copy.base.v1"}
+			return nil
+		})
+		require.NoError(t, r.runE(r.Command, []string{bundlePath}))
+		assert.Equal(t, "copy.base.v1 imported\n", out.String())
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		r, out := newRunnerWithClient(true, func(obj *porchapi.RepositoryBundle, dryRun bool) error {
+			assert.True(t, dryRun)
+			obj.Status.Imported = []string{"copy.base.v1"}
+			return nil
+		})
+		require.NoError(t, r.runE(r.Command, []string{bundlePath}))
+		assert.Equal(t, "copy.base.v1 would be imported (dry run)\n", out.String())
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		r, _ := newRunnerWithClient(false, func(obj *porchapi.RepositoryBundle, _ bool) error {
+			return apierrors.NewConflict(porchapi.Resource("repositorybundles"), obj.Name, assert.AnError)
+		})
+		err := r.runE(r.Command, []string{bundlePath})
+		assert.ErrorContains(t, err, "nothing was imported")
+	})
+
+	t.Run("missing bundle", func(t *testing.T) {
+		r, _ := newRunnerWithClient(false, nil)
+		assert.Error(t, r.runE(r.Command, []string{filepath.Join(t.TempDir(), "missing.tgz")}))
+		assert.ErrorContains(t, r.runE(r.Command, nil), "BUNDLE is a required positional argument")
+	})
+}
@@ -21,7 +21,9 @@ import (
 
 	cliutils "github.com/kptdev/porch/internal/cliutils"
 	"github.com/kptdev/porch/pkg/cli/commands/repo/docs"
+	"github.com/kptdev/porch/pkg/cli/commands/repo/export"
 	"github.com/kptdev/porch/pkg/cli/commands/repo/get"
+	"github.com/kptdev/porch/pkg/cli/commands/repo/importbundle"
 	"github.com/kptdev/porch/pkg/cli/commands/repo/reg"
 	"github.com/kptdev/porch/pkg/cli/commands/repo/sync"
 	"github.com/kptdev/porch/pkg/cli/commands/repo/unreg"
@@ -66,6 +68,8 @@ func NewCommand(ctx context.Context, version string) *cobra.Command {
 		get.NewCommand(ctx, kubeflags),
 		unreg.NewCommand(ctx, kubeflags),
 		sync.NewCommand(ctx, kubeflags),
+		export.NewCommand(ctx, kubeflags),
+		importbundle.NewCommand(ctx, kubeflags),
 	)
 
 	return repo
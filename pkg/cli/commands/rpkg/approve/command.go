@@ -57,11 +57,15 @@ func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner
 	}
 	r.Command = c
 
+	c.Flags().StringVar(&r.selector, "selector", "", rpkgutil.SelectorFlagUsage)
+
 	return r
 }
 
 type runner struct {
 	rpkgutil.Runner
+
+	selector string
 }
 
 func approveAction(ctx context.Context, client client.Client, pr *porchapi.PackageRevision) (string, error) {
@@ -72,6 +76,10 @@ func approveAction(ctx context.Context, client client.Client, pr *porchapi.Packa
 }
 
 func (r *runner) runE(_ *cobra.Command, args []string) error {
+	if r.selector != "" {
+		return rpkgutil.RunBatch(r.Ctx, r.Client, r.Command, *r.Cfg.Namespace, args, r.selector,
+			porchapi.BatchOperationApprove, command, "approved")
+	}
 	return rpkgutil.RunForEachPackage(r.Ctx, r.Client, r.Command, *r.Cfg.Namespace, args,
 		rpkgutil.RunForEachOpts{CmdName: command, WithRetry: true, CheckReadiness: true},
 		approveAction)
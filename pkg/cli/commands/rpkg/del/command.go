@@ -52,6 +52,7 @@ func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner
 	r.Command = c
 
 	// Create flags
+	c.Flags().StringVar(&r.selector, "selector", "", rpkgutil.SelectorFlagUsage)
 
 	return r
 }
@@ -65,10 +66,16 @@ func NewCommand(ctx context.Context, rcg *genericclioptions.ConfigFlags) *cobra.
 
 type runner struct {
 	rpkgutil.Runner
+
+	selector string
 }
 
 func (r *runner) runE(_ *cobra.Command, args []string) error {
 	const op errors.Op = command + ".runE"
+	if r.selector != "" {
+		return rpkgutil.RunBatch(r.Ctx, r.Client, r.Command, *r.Cfg.Namespace, args, r.selector,
+			porchapi.BatchOperationDelete, command, "deleted")
+	}
 	if len(args) == 0 {
 		return errors.E(op, fmt.Errorf("PACKAGE is a required positional argument"))
 	}
@@ -0,0 +1,191 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	cliutils "github.com/kptdev/porch/internal/cliutils"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/docs"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	command = "cmdrpkgdeps"
+)
+
+func NewCommand(ctx context.Context, rcg *genericclioptions.ConfigFlags) *cobra.Command {
+	v1 := newRunner(ctx, rcg)
+	v2 := newV1Alpha2Runner(ctx, rcg)
+	cliutils.WrapVersionDispatch(v1.Command, v2.preRunE, v2.runE)
+	return v1.Command
+}
+
+func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner {
+	r := &runner{
+		ctx: ctx,
+		cfg: rcg,
+	}
+	r.Command = &cobra.Command{
+		Use:     "deps PACKAGE_REVISION",
+		Aliases: []string{"dependencies"},
+		Short:   docs.DepsShort,
+		Long:    docs.DepsShort + "\n" + docs.DepsLong,
+		Example: docs.DepsExamples,
+		PreRunE: r.preRunE,
+		RunE:    r.runE,
+		Hidden:  cliutils.HidePorchCommands,
+	}
+	return r
+}
+
+type runner struct {
+	ctx     context.Context
+	cfg     *genericclioptions.ConfigFlags
+	client  client.Client
+	Command *cobra.Command
+
+	name string
+}
+
+func (r *runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = command + ".preRunE"
+	if r.client == nil {
+		c, err := cliutils.CreateClientWithFlags(r.cfg)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		r.client = c
+	}
+
+	name, err := validateArgs(args)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.name = name
+	return nil
+}
+
+func (r *runner) runE(cmd *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".runE"
+
+	pr := &porchapi.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: *r.cfg.Namespace,
+			Name:      r.name,
+		},
+	}
+	var deps porchapi.PackageRevisionDependencies
+	if err := r.client.SubResource("dependencies").Get(r.ctx, pr, &deps); err != nil {
+		return errors.E(op, err)
+	}
+
+	printTree(cmd.OutOrStdout(), r.name, &deps.Status)
+	return nil
+}
+
+func validateArgs(args []string) (string, error) {
+	if len(args) < 1 {
+		return "", fmt.Errorf("PACKAGE_REVISION is a required positional argument")
+	}
+	if len(args) > 1 {
+		return "", fmt.Errorf("too many arguments; PACKAGE_REVISION is the only accepted positional argument")
+	}
+	return args[0], nil
+}
+
+// printTree prints the upstream chain of the named package revision, starting with the
+// root upstream, followed by the tree of its downstream package revisions.
+func printTree(out io.Writer, name string, status *porchapi.PackageRevisionDependenciesStatus) {
+	depth := 0
+	for i := len(status.Upstream) - 1; i >= 0; i-- {
+		fmt.Fprintln(out, treeLine(strings.Repeat("    ", max(depth-1, 0)), depth > 0, true, describe(&status.Upstream[i])))
+		depth++
+	}
+	fmt.Fprintln(out, treeLine(strings.Repeat("    ", max(depth-1, 0)), depth > 0, true, name))
+
+	// Attach each downstream to its recorded upstream, or to the package revision itself
+	// if the recorded upstream is not part of the tree.
+	inTree := map[string]bool{name: true}
+	for _, d := range status.Downstream {
+		inTree[d.Name] = true
+	}
+	children := map[string][]*porchapi.PackageRevisionDependency{}
+	for i := range status.Downstream {
+		d := &status.Downstream[i]
+		parent := d.UpstreamName
+		if !inTree[parent] || parent == d.Name {
+			parent = name
+		}
+		children[parent] = append(children[parent], d)
+	}
+
+	printed := map[string]bool{name: true}
+	var printChildren func(parent, prefix string)
+	printChildren = func(parent, prefix string) {
+		kids := children[parent]
+		for i, kid := range kids {
+			if printed[kid.Name] {
+				continue
+			}
+			printed[kid.Name] = true
+			last := i == len(kids)-1
+			fmt.Fprintln(out, treeLine(prefix, true, last, describe(kid)))
+			if last {
+				printChildren(kid.Name, prefix+"    ")
+			} else {
+				printChildren(kid.Name, prefix+"│   ")
+			}
+		}
+	}
+	printChildren(name, strings.Repeat("    ", depth))
+}
+
+func treeLine(prefix string, child, last bool, label string) string {
+	switch {
+	case !child:
+		return prefix + label
+	case last:
+		return prefix + "└── " + label
+	default:
+		return prefix + "├── " + label
+	}
+}
+
+func describe(d *porchapi.PackageRevisionDependency) string {
+	details := []string{string(d.Lifecycle)}
+	if d.Revision > 0 {
+		details = append(details, fmt.Sprintf("revision %d", d.Revision))
+	}
+	details = slices.DeleteFunc(details, func(s string) bool { return s == "" })
+
+	label := d.Name
+	if len(details) > 0 {
+		label += " (" + strings.Join(details, ", ") + ")"
+	}
+	if d.BehindUpstream {
+		label += fmt.Sprintf(" [behind upstream, latest is %s]", d.LatestUpstreamName)
+	}
+	return label
+}
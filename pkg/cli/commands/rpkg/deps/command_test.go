@@ -0,0 +1,78 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"bytes"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateArgs(t *testing.T) {
+	_, err := validateArgs(nil)
+	assert.ErrorContains(t, err, "PACKAGE_REVISION is a required positional argument")
+
+	_, err = validateArgs([]string{"a", "b"})
+	assert.ErrorContains(t, err, "too many arguments")
+
+	name, err := validateArgs([]string{"repo.pkg.v1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "repo.pkg.v1", name)
+}
+
+func TestPrintTree(t *testing.T) {
+	tests := []struct {
+		name   string
+		status porchapi.PackageRevisionDependenciesStatus
+		want   string
+	}{
+		{
+			name: "no dependencies",
+			want: "deployments.app.v1\n",
+		},
+		{
+			name: "upstream chain and downstream tree",
+			status: porchapi.PackageRevisionDependenciesStatus{
+				Upstream: []porchapi.PackageRevisionDependency{
+					{Name: "blueprints.app.v1", Lifecycle: porchapi.PackageRevisionLifecyclePublished, Revision: 1, UpstreamName: "catalog.base.v3"},
+					{Name: "catalog.base.v3", Lifecycle: porchapi.PackageRevisionLifecyclePublished, Revision: 3},
+				},
+				Downstream: []porchapi.PackageRevisionDependency{
+					{Name: "edge1.app.v1", Lifecycle: porchapi.PackageRevisionLifecyclePublished, Revision: 1, UpstreamName: "deployments.app.v1"},
+					{Name: "edge2.app.ws", Lifecycle: porchapi.PackageRevisionLifecycleDraft, UpstreamName: "deployments.app.v1"},
+					{Name: "site.app.v1", Lifecycle: porchapi.PackageRevisionLifecyclePublished, Revision: 1, UpstreamName: "edge1.app.v1",
+						BehindUpstream: true, LatestUpstreamName: "edge1.app.v2"},
+				},
+			},
+			want: `catalog.base.v3 (Published, revision 3)
+└── blueprints.app.v1 (Published, revision 1)
+    └── deployments.app.v1
+        ├── edge1.app.v1 (Published, revision 1)
+        │   └── site.app.v1 (Published, revision 1) [behind upstream, latest is edge1.app.v2]
+        └── edge2.app.ws (Draft)
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			printTree(&out, "deployments.app.v1", &tt.status)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
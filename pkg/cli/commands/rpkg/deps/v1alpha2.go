@@ -0,0 +1,170 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	cliutils "github.com/kptdev/porch/internal/cliutils"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// v1alpha2Runner computes the dependencies client-side from the list of package revisions,
+// since the v1alpha2 API has no dependencies subresource.
+type v1alpha2Runner struct {
+	ctx    context.Context
+	cfg    *genericclioptions.ConfigFlags
+	client client.Client
+
+	name string
+}
+
+func newV1Alpha2Runner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *v1alpha2Runner {
+	return &v1alpha2Runner{ctx: ctx, cfg: rcg}
+}
+
+func (r *v1alpha2Runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = command + ".preRunE"
+	if r.client == nil {
+		c, err := cliutils.CreateV1Alpha2ClientWithFlags(r.cfg)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		r.client = c
+	}
+
+	name, err := validateArgs(args)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.name = name
+	return nil
+}
+
+func (r *v1alpha2Runner) runE(cmd *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".runE"
+
+	var list porchv1alpha2.PackageRevisionList
+	if err := r.client.List(r.ctx, &list, client.InNamespace(*r.cfg.Namespace)); err != nil {
+		return errors.E(op, err)
+	}
+
+	status, err := computeDependencies(r.name, list.Items)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	printTree(cmd.OutOrStdout(), r.name, status)
+	return nil
+}
+
+// computeDependencies walks the upstream chain and the downstream package revisions of the
+// named package revision, following the upstream recorded in each package revision's source.
+func computeDependencies(name string, prs []porchv1alpha2.PackageRevision) (*porchapi.PackageRevisionDependenciesStatus, error) {
+	byName := make(map[string]*porchv1alpha2.PackageRevision, len(prs))
+	children := map[string][]string{}
+	latest := map[string]*porchv1alpha2.PackageRevision{}
+	for i := range prs {
+		pr := &prs[i]
+		byName[pr.Name] = pr
+		if upstream := upstreamName(pr); upstream != "" {
+			children[upstream] = append(children[upstream], pr.Name)
+		}
+		if pr.IsPublished() {
+			key := packageKey(pr)
+			if cur, ok := latest[key]; !ok || pr.Status.Revision > cur.Status.Revision {
+				latest[key] = pr
+			}
+		}
+	}
+
+	if _, ok := byName[name]; !ok {
+		return nil, fmt.Errorf("package revision %q not found", name)
+	}
+
+	describe := func(pr *porchv1alpha2.PackageRevision) porchapi.PackageRevisionDependency {
+		d := porchapi.PackageRevisionDependency{
+			Name:           pr.Name,
+			RepositoryName: pr.Spec.RepositoryName,
+			PackageName:    pr.Spec.PackageName,
+			Revision:       pr.Status.Revision,
+			Lifecycle:      porchapi.PackageRevisionLifecycle(pr.Spec.Lifecycle),
+			UpstreamName:   upstreamName(pr),
+		}
+		if upstream, ok := byName[d.UpstreamName]; ok {
+			if l, ok := latest[packageKey(upstream)]; ok {
+				d.LatestUpstreamName = l.Name
+				d.BehindUpstream = l.Status.Revision > upstream.Status.Revision
+			}
+		}
+		return d
+	}
+
+	status := &porchapi.PackageRevisionDependenciesStatus{}
+	visited := map[string]bool{name: true}
+
+	for upstream := upstreamName(byName[name]); upstream != "" && !visited[upstream]; {
+		visited[upstream] = true
+		pr, ok := byName[upstream]
+		if !ok {
+			break
+		}
+		status.Upstream = append(status.Upstream, describe(pr))
+		upstream = upstreamName(pr)
+	}
+
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range children[current] {
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			status.Downstream = append(status.Downstream, describe(byName[child]))
+			queue = append(queue, child)
+		}
+	}
+
+	return status, nil
+}
+
+// upstreamName returns the name of the package revision that the package revision was
+// cloned, upgraded or promoted from.
+func upstreamName(pr *porchv1alpha2.PackageRevision) string {
+	source := pr.Spec.Source
+	switch {
+	case source == nil:
+		return ""
+	case source.CloneFrom != nil && source.CloneFrom.UpstreamRef != nil:
+		return source.CloneFrom.UpstreamRef.Name
+	case source.Upgrade != nil:
+		return source.Upgrade.NewUpstream.Name
+	case source.PromoteFrom != nil:
+		return source.PromoteFrom.Name
+	}
+	return ""
+}
+
+func packageKey(pr *porchv1alpha2.PackageRevision) string {
+	return pr.Spec.RepositoryName + "/" + pr.Spec.PackageName
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newPackageRevision(repo, pkg, ws string, revision int, source *porchv1alpha2.PackageSource) *porchv1alpha2.PackageRevision {
+	lifecycle := porchv1alpha2.PackageRevisionLifecycleDraft
+	if revision > 0 {
+		lifecycle = porchv1alpha2.PackageRevisionLifecyclePublished
+	}
+	return &porchv1alpha2.PackageRevision{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageRevision",
+			APIVersion: porchv1alpha2.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      repo + "." + pkg + "." + ws,
+		},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			RepositoryName: repo,
+			PackageName:    pkg,
+			WorkspaceName:  ws,
+			Lifecycle:      lifecycle,
+			Source:         source,
+		},
+		Status: porchv1alpha2.PackageRevisionStatus{Revision: revision},
+	}
+}
+
+func cloneFrom(name string) *porchv1alpha2.PackageSource {
+	return &porchv1alpha2.PackageSource{
+		CloneFrom: &porchv1alpha2.UpstreamPackage{UpstreamRef: &porchv1alpha2.PackageRevisionRef{Name: name}},
+	}
+}
+
+func TestComputeDependencies(t *testing.T) {
+	prs := []porchv1alpha2.PackageRevision{
+		*newPackageRevision("blueprints", "base", "v1", 1, nil),
+		*newPackageRevision("blueprints", "base", "v2", 2, nil),
+		*newPackageRevision("deployments", "app", "v1", 1, cloneFrom("blueprints.base.v1")),
+		*newPackageRevision("deployments", "app", "v2", 0, &porchv1alpha2.PackageSource{
+			Upgrade: &porchv1alpha2.PackageUpgradeSpec{NewUpstream: porchv1alpha2.PackageRevisionRef{Name: "blueprints.base.v2"}},
+		}),
+		*newPackageRevision("prod", "app", "v1", 0, &porchv1alpha2.PackageSource{
+			PromoteFrom: &porchv1alpha2.PackageRevisionRef{Name: "deployments.app.v1"},
+		}),
+	}
+
+	status, err := computeDependencies("blueprints.base.v1", prs)
+	require.NoError(t, err)
+	assert.Empty(t, status.Upstream)
+	require.Len(t, status.Downstream, 2)
+	assert.Equal(t, "deployments.app.v1", status.Downstream[0].Name)
+	assert.True(t, status.Downstream[0].BehindUpstream)
+	assert.Equal(t, "blueprints.base.v2", status.Downstream[0].LatestUpstreamName)
+	assert.Equal(t, "prod.app.v1", status.Downstream[1].Name)
+	assert.False(t, status.Downstream[1].BehindUpstream)
+
+	status, err = computeDependencies("prod.app.v1", prs)
+	require.NoError(t, err)
+	require.Len(t, status.Upstream, 2)
+	assert.Equal(t, "deployments.app.v1", status.Upstream[0].Name)
+	assert.Equal(t, "blueprints.base.v1", status.Upstream[1].Name)
+	assert.Empty(t, status.Downstream)
+
+	_, err = computeDependencies("missing.pkg.v1", prs)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestV1Alpha2RunE(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, porchv1alpha2.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newPackageRevision("blueprints", "base", "v1", 1, nil),
+		newPackageRevision("deployments", "app", "v1", 1, cloneFrom("blueprints.base.v1")),
+	).Build()
+
+	ns := "ns"
+	r := &v1alpha2Runner{
+		ctx:    context.Background(),
+		cfg:    &genericclioptions.ConfigFlags{Namespace: &ns},
+		client: c,
+	}
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, r.preRunE(cmd, []string{"blueprints.base.v1"}))
+	require.NoError(t, r.runE(cmd, nil))
+	assert.Equal(t, "blueprints.base.v1\n└── deployments.app.v1 (Published, revision 1)\n", out.String())
+}
@@ -0,0 +1,385 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dev
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	"github.com/kptdev/kpt/pkg/lib/util/cmdutil"
+	"github.com/kptdev/kpt/pkg/printer"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	cliutils "github.com/kptdev/porch/internal/cliutils"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/docs"
+	rpkgutil "github.com/kptdev/porch/pkg/cli/commands/rpkg/util"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	command = "cmdrpkgdev"
+
+	defaultDebounce = 500 * time.Millisecond
+)
+
+func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner {
+	r := &runner{
+		Runner: rpkgutil.Runner{Ctx: ctx, Cfg: rcg},
+	}
+	c := &cobra.Command{
+		Use:     "dev PACKAGE DIR",
+		Short:   docs.DevShort,
+		Long:    docs.DevShort + "\n" + docs.DevLong,
+		Example: docs.DevExamples,
+		PreRunE: r.preRunE,
+		RunE:    r.runE,
+		Hidden:  cliutils.HidePorchCommands,
+	}
+	r.Command = c
+
+	c.Flags().DurationVar(&r.debounce, "debounce", defaultDebounce,
+		"How long to wait after the last local change before pushing.")
+	return r
+}
+
+// NewCommand returns the cobra command for `rpkg dev`, which pulls a draft
+// package revision into a local directory and keeps the two in sync while the
+// package is edited.
+func NewCommand(ctx context.Context, rcg *genericclioptions.ConfigFlags) *cobra.Command {
+	return newRunner(ctx, rcg).Command
+}
+
+type runner struct {
+	rpkgutil.Runner
+	printer printer.Printer
+
+	debounce time.Duration
+
+	name string
+	dir  string
+	// base is the content of the package revision last synced with the local
+	// directory, and baseVersion the resourceVersion it was read at. Local and
+	// remote changes are both merged relative to it.
+	base        map[string]string
+	baseVersion string
+}
+
+func (r *runner) preRunE(_ *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".preRunE"
+	config, err := r.Cfg.ToRESTConfig()
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	scheme, err := rpkgutil.CreateScheme()
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	r.Client = c
+	r.printer = printer.FromContextOrDie(r.Ctx)
+	return nil
+}
+
+func (r *runner) runE(_ *cobra.Command, args []string) error {
+	const op errors.Op = command + ".runE"
+
+	if len(args) < 2 {
+		return errors.E(op, "PACKAGE and DIR are required positional arguments")
+	}
+	r.name, r.dir = args[0], args[1]
+
+	if err := r.pull(); err != nil {
+		return errors.E(op, err)
+	}
+
+	ctx, stop := signal.NotifyContext(r.Ctx, os.Interrupt)
+	defer stop()
+	if err := r.watch(ctx); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// pull writes the draft package revision into the new local directory.
+func (r *runner) pull() error {
+	var pr porchapi.PackageRevision
+	if err := r.Client.Get(r.Ctx, r.key(), &pr); err != nil {
+		return err
+	}
+	if pr.Spec.Lifecycle != porchapi.PackageRevisionLifecycleDraft {
+		return fmt.Errorf("package revision %s is %s; only draft package revisions can be developed", r.name, pr.Spec.Lifecycle)
+	}
+
+	remote, err := r.getResources()
+	if err != nil {
+		return err
+	}
+	if err := cmdutil.CheckDirectoryNotPresent(r.dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(r.dir, 0750); err != nil {
+		return err
+	}
+	if err := writeChanges(r.dir, nil, remote.Spec.Resources); err != nil {
+		return err
+	}
+	r.setBase(remote)
+	r.printer.Printf("%s pulled into %s, watching for changes\n", r.name, r.dir)
+	return nil
+}
+
+// watch syncs the local directory with the package revision every time it changes,
+// until ctx is done.
+func (r *runner) watch(ctx context.Context) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	if err := addRecursive(w, r.dir); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(r.debounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			if rpkgutil.IsIgnoredFile(filepath.Base(event.Name)) {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				// New directories have to be watched as well; errors are ignored since
+				// the entry may be gone already.
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = addRecursive(w, event.Name)
+				}
+			}
+			timer.Reset(r.debounce)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			r.printer.Printf("Error watching %s: %s\n", r.dir, err)
+		case <-timer.C:
+			if err := r.sync(); err != nil {
+				r.printer.Printf("Error syncing %s: %s\n", r.name, err)
+			}
+		}
+	}
+}
+
+// sync pushes the local changes to the package revision, merging in the changes
+// made to it remotely since it was last synced, and pulls back the rendered
+// package.
+func (r *runner) sync() error {
+	local, err := rpkgutil.ReadFromDir(r.dir, rpkgutil.IsIgnoredFile)
+	if err != nil {
+		return err
+	}
+	if maps.Equal(local, r.base) {
+		return nil
+	}
+	if files := filesWithConflicts(local); len(files) > 0 {
+		r.printer.Printf("Not pushing %s: resolve the conflicts in %s first\n", r.name, strings.Join(files, ", "))
+		return nil
+	}
+
+	var (
+		remote *porchapi.PackageRevisionResources
+		merged mergeResult
+		pushed bool
+	)
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		if remote, err = r.getResources(); err != nil {
+			return err
+		}
+		if remote.ResourceVersion == r.baseVersion {
+			merged = mergeResult{resources: local}
+		} else {
+			merged = merge3(r.base, local, syncedFiles(remote.Spec.Resources))
+		}
+		if len(merged.conflicts) > 0 || maps.Equal(merged.resources, syncedFiles(remote.Spec.Resources)) {
+			return nil
+		}
+		// Files that are not read from the local directory are kept as they are
+		resources := maps.Clone(merged.resources)
+		for name, contents := range remote.Spec.Resources {
+			if rpkgutil.IsIgnoredFile(name) {
+				resources[name] = contents
+			}
+		}
+		remote.Spec.Resources = resources
+		if err := r.Client.Update(r.Ctx, remote); err != nil {
+			return err
+		}
+		pushed = true
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := writeChanges(r.dir, local, merged.resources); err != nil {
+		return err
+	}
+	if len(merged.conflicts) > 0 {
+		// The conflicting remote changes are now part of the local files, so the
+		// next push is relative to them.
+		r.setBase(remote)
+		r.printer.Printf("%s was changed remotely, conflicts in %s must be resolved before pushing\n",
+			r.name, strings.Join(merged.conflicts, ", "))
+		return nil
+	}
+	if !pushed {
+		// The remote package revision already has the local changes.
+		r.setBase(remote)
+		return nil
+	}
+
+	r.printRenderStatus(remote.Status.RenderStatus)
+	r.printer.Printf("%s pushed\n", r.name)
+	return r.pullRendered(merged.resources, remote)
+}
+
+// pullRendered writes the package revision as rendered after a push into the local
+// directory, keeping the local changes made while the push was in flight.
+func (r *runner) pullRendered(pushed map[string]string, remote *porchapi.PackageRevisionResources) error {
+	local, err := rpkgutil.ReadFromDir(r.dir, rpkgutil.IsIgnoredFile)
+	if err != nil {
+		return err
+	}
+	merged := merge3(pushed, local, syncedFiles(remote.Spec.Resources))
+	if err := writeChanges(r.dir, local, merged.resources); err != nil {
+		return err
+	}
+	r.setBase(remote)
+	if len(merged.conflicts) > 0 {
+		r.printer.Printf("Rendering %s conflicts with local changes in %s\n", r.name, strings.Join(merged.conflicts, ", "))
+	}
+	return nil
+}
+
+func (r *runner) printRenderStatus(rs porchapi.RenderStatus) {
+	if rs.Err != "" {
+		r.printer.Printf("Package is updated, but failed to render the package.\n")
+		r.printer.Printf("Error: %s\n", rs.Err)
+	}
+	rpkgutil.PrintRenderResults(r.printer, rs.Result.Items, true)
+}
+
+func (r *runner) getResources() (*porchapi.PackageRevisionResources, error) {
+	var resources porchapi.PackageRevisionResources
+	if err := r.Client.Get(r.Ctx, r.key(), &resources); err != nil {
+		return nil, err
+	}
+	return &resources, nil
+}
+
+func (r *runner) setBase(resources *porchapi.PackageRevisionResources) {
+	r.base = syncedFiles(resources.Spec.Resources)
+	r.baseVersion = resources.ResourceVersion
+}
+
+// syncedFiles returns the files of the package revision that are synced with
+// the local directory. Hidden and temporary files are not read from the local
+// directory, so they are left out of the merges as well.
+func syncedFiles(resources map[string]string) map[string]string {
+	synced := maps.Clone(resources)
+	maps.DeleteFunc(synced, func(name, _ string) bool {
+		return rpkgutil.IsIgnoredFile(name)
+	})
+	return synced
+}
+
+func (r *runner) key() client.ObjectKey {
+	return client.ObjectKey{
+		Namespace: *r.Cfg.Namespace,
+		Name:      r.name,
+	}
+}
+
+// filesWithConflicts returns the sorted names of the files which still contain
+// conflict markers.
+func filesWithConflicts(resources map[string]string) []string {
+	var files []string
+	for _, name := range fileNames(resources) {
+		if hasConflictMarkers(resources[name]) {
+			files = append(files, name)
+		}
+	}
+	return files
+}
+
+func addRecursive(w *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir && rpkgutil.IsIgnoredFile(d.Name()) {
+			return filepath.SkipDir
+		}
+		return w.Add(path)
+	})
+}
+
+// writeChanges updates the files in dir from their old to their new contents.
+func writeChanges(dir string, old, new map[string]string) error {
+	for name := range old {
+		if _, ok := new[name]; !ok {
+			if err := os.Remove(filepath.Join(dir, filepath.FromSlash(name))); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	for name, contents := range new {
+		if prev, ok := old[name]; ok && prev == contents {
+			continue
+		}
+		f := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(f), 0750); err != nil {
+			return err
+		}
+		if err := os.WriteFile(f, []byte(contents), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dev
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kptdev/kpt/pkg/printer"
+	fakeprint "github.com/kptdev/kpt/pkg/printer/fake"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	rpkgutil "github.com/kptdev/porch/pkg/cli/commands/rpkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const (
+	ns         = "ns"
+	pkgRevName = "repo.pkg.ws"
+)
+
+func newTestRunner(t *testing.T, lifecycle porchapi.PackageRevisionLifecycle, resources map[string]string, funcs interceptor.Funcs) (*runner, *bytes.Buffer) {
+	t.Helper()
+	scheme, err := rpkgutil.CreateScheme()
+	if err != nil {
+		t.Fatalf("error creating scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&porchapi.PackageRevision{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: pkgRevName},
+				Spec:       porchapi.PackageRevisionSpec{Lifecycle: lifecycle},
+			},
+			&porchapi.PackageRevisionResources{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: pkgRevName},
+				Spec:       porchapi.PackageRevisionResourcesSpec{Resources: resources},
+			},
+		).
+		WithInterceptorFuncs(funcs).
+		Build()
+
+	output := &bytes.Buffer{}
+	ctx := fakeprint.CtxWithPrinter(output, output)
+	r := &runner{
+		Runner:   rpkgutil.NewTestRunner(ns, c, nil),
+		printer:  printer.FromContextOrDie(ctx),
+		debounce: 10 * time.Millisecond,
+		name:     pkgRevName,
+		dir:      filepath.Join(t.TempDir(), "pkg"),
+	}
+	r.Ctx = ctx
+	return r, output
+}
+
+func getRemote(t *testing.T, r *runner) map[string]string {
+	t.Helper()
+	remote, err := r.getResources()
+	if err != nil {
+		t.Fatalf("error getting resources: %v", err)
+	}
+	return remote.Spec.Resources
+}
+
+func setRemote(t *testing.T, r *runner, resources map[string]string) {
+	t.Helper()
+	remote, err := r.getResources()
+	if err != nil {
+		t.Fatalf("error getting resources: %v", err)
+	}
+	remote.Spec.Resources = resources
+	if err := r.Client.Update(r.Ctx, remote); err != nil {
+		t.Fatalf("error updating resources: %v", err)
+	}
+}
+
+func writeLocal(t *testing.T, r *runner, name, contents string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(r.dir, name), []byte(contents), 0600); err != nil {
+		t.Fatalf("error writing %s: %v", name, err)
+	}
+}
+
+func readLocal(t *testing.T, r *runner) map[string]string {
+	t.Helper()
+	local, err := rpkgutil.ReadFromDir(r.dir, rpkgutil.IsIgnoredFile)
+	if err != nil {
+		t.Fatalf("error reading %s: %v", r.dir, err)
+	}
+	return local
+}
+
+func TestRunEArgs(t *testing.T) {
+	r, _ := newTestRunner(t, porchapi.PackageRevisionLifecycleDraft, nil, interceptor.Funcs{})
+	if err := r.runE(nil, []string{pkgRevName}); err == nil {
+		t.Error("expected an error when DIR is not provided")
+	}
+}
+
+func TestPull(t *testing.T) {
+	resources := map[string]string{
+		"Kptfile":     "kind: Kptfile\n",
+		"sub/cm.yaml": "kind: ConfigMap\n",
+	}
+	r, output := newTestRunner(t, porchapi.PackageRevisionLifecycleDraft, resources, interceptor.Funcs{})
+	if err := r.pull(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(resources, readLocal(t, r)); diff != "" {
+		t.Errorf("unexpected local files (-want, +got): %s", diff)
+	}
+	if diff := cmp.Diff(resources, r.base); diff != "" {
+		t.Errorf("unexpected base (-want, +got): %s", diff)
+	}
+	if !strings.Contains(output.String(), "watching for changes") {
+		t.Errorf("unexpected output %q", output.String())
+	}
+
+	if err := r.pull(); err == nil {
+		t.Error("expected an error pulling into an existing directory")
+	}
+}
+
+func TestPullPublished(t *testing.T) {
+	r, _ := newTestRunner(t, porchapi.PackageRevisionLifecyclePublished, nil, interceptor.Funcs{})
+	if err := r.pull(); err == nil || !strings.Contains(err.Error(), "only draft package revisions") {
+		t.Errorf("expected an error for a published package revision, got %v", err)
+	}
+}
+
+func TestSync(t *testing.T) {
+	// Rendering adds a file and reports the output of the function.
+	render := interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			prr := obj.(*porchapi.PackageRevisionResources)
+			prr.Spec.Resources["rendered.yaml"] = "rendered: true\n"
+			if err := c.Update(ctx, obj, opts...); err != nil {
+				return err
+			}
+			prr.Status.RenderStatus.Result.Items = []*porchapi.Result{{
+				Image:  "set-labels",
+				Stderr: "labels set\n",
+			}}
+			return nil
+		},
+	}
+	r, output := newTestRunner(t, porchapi.PackageRevisionLifecycleDraft, map[string]string{
+		"cm.yaml": "a: 1\nb: 2\n",
+	}, render)
+	if err := r.pull(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	writeLocal(t, r, "cm.yaml", "a: 10\nb: 2\n")
+	if err := r.sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"cm.yaml":       "a: 10\nb: 2\n",
+		"rendered.yaml": "rendered: true\n",
+	}
+	if diff := cmp.Diff(want, getRemote(t, r)); diff != "" {
+		t.Errorf("unexpected remote resources (-want, +got): %s", diff)
+	}
+	if diff := cmp.Diff(want, readLocal(t, r)); diff != "" {
+		t.Errorf("unexpected local files (-want, +got): %s", diff)
+	}
+	for _, s := range []string{`[PASS] "set-labels"`, "labels set", pkgRevName + " pushed"} {
+		if !strings.Contains(output.String(), s) {
+			t.Errorf("expected %q in output %q", s, output.String())
+		}
+	}
+
+	// Nothing changed locally, so nothing is pushed.
+	output.Reset()
+	if err := r.sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("unexpected output %q", output.String())
+	}
+}
+
+func TestSyncRemoteChanges(t *testing.T) {
+	r, _ := newTestRunner(t, porchapi.PackageRevisionLifecycleDraft, map[string]string{
+		"cm.yaml": "a: 1\nb: 2\nc: 3\n",
+	}, interceptor.Funcs{})
+	if err := r.pull(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	setRemote(t, r, map[string]string{"cm.yaml": "a: 1\nb: 2\nc: 30\n"})
+	writeLocal(t, r, "cm.yaml", "a: 10\nb: 2\nc: 3\n")
+	if err := r.sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{"cm.yaml": "a: 10\nb: 2\nc: 30\n"}
+	if diff := cmp.Diff(want, getRemote(t, r)); diff != "" {
+		t.Errorf("unexpected remote resources (-want, +got): %s", diff)
+	}
+	if diff := cmp.Diff(want, readLocal(t, r)); diff != "" {
+		t.Errorf("unexpected local files (-want, +got): %s", diff)
+	}
+}
+
+func TestSyncSkipsHiddenAndTemporaryFiles(t *testing.T) {
+	r, _ := newTestRunner(t, porchapi.PackageRevisionLifecycleDraft, map[string]string{
+		"cm.yaml":    "a: 1\n",
+		".krmignore": "tmp/\n",
+	}, interceptor.Funcs{})
+	if err := r.pull(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	writeLocal(t, r, "cm.yaml", "a: 10\n")
+	writeLocal(t, r, "cm.yaml~", "a: 1\n")
+	writeLocal(t, r, ".cm.yaml.swp", "swap")
+	if err := os.Remove(filepath.Join(r.dir, ".krmignore")); err != nil {
+		t.Fatalf("error removing .krmignore: %v", err)
+	}
+	if err := r.sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"cm.yaml":    "a: 10\n",
+		".krmignore": "tmp/\n",
+	}
+	if diff := cmp.Diff(want, getRemote(t, r)); diff != "" {
+		t.Errorf("unexpected remote resources (-want, +got): %s", diff)
+	}
+}
+
+func TestSyncConflict(t *testing.T) {
+	r, output := newTestRunner(t, porchapi.PackageRevisionLifecycleDraft, map[string]string{
+		"cm.yaml": "a: 1\n",
+	}, interceptor.Funcs{})
+	if err := r.pull(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remote := map[string]string{"cm.yaml": "a: remote\n"}
+	setRemote(t, r, remote)
+	writeLocal(t, r, "cm.yaml", "a: local\n")
+	if err := r.sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(remote, getRemote(t, r)); diff != "" {
+		t.Errorf("conflicting changes must not be pushed (-want, +got): %s", diff)
+	}
+	local := readLocal(t, r)["cm.yaml"]
+	if !hasConflictMarkers(local) {
+		t.Errorf("expected conflict markers in %q", local)
+	}
+	if !strings.Contains(output.String(), "conflicts in cm.yaml") {
+		t.Errorf("unexpected output %q", output.String())
+	}
+
+	// Changes are not pushed until the conflict is resolved.
+	writeLocal(t, r, "cm.yaml", local+"b: 2\n")
+	if err := r.sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(remote, getRemote(t, r)); diff != "" {
+		t.Errorf("unresolved conflicts must not be pushed (-want, +got): %s", diff)
+	}
+	if !strings.Contains(output.String(), "Not pushing") {
+		t.Errorf("unexpected output %q", output.String())
+	}
+
+	resolved := map[string]string{"cm.yaml": "a: local\n"}
+	writeLocal(t, r, "cm.yaml", resolved["cm.yaml"])
+	if err := r.sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(resolved, getRemote(t, r)); diff != "" {
+		t.Errorf("unexpected remote resources (-want, +got): %s", diff)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	r, _ := newTestRunner(t, porchapi.PackageRevisionLifecycleDraft, map[string]string{
+		"cm.yaml": "a: 1\n",
+	}, interceptor.Funcs{})
+	if err := r.pull(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- r.watch(ctx)
+	}()
+
+	// Give the watcher time to start before changing a new directory.
+	time.Sleep(100 * time.Millisecond)
+	if err := os.Mkdir(filepath.Join(r.dir, "sub"), 0750); err != nil {
+		t.Fatalf("error creating directory: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	writeLocal(t, r, "sub/cm.yaml", "b: 1\n")
+
+	want := map[string]string{
+		"cm.yaml":     "a: 1\n",
+		"sub/cm.yaml": "b: 1\n",
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !cmp.Equal(want, getRemote(t, r)) && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if diff := cmp.Diff(want, getRemote(t, r)); diff != "" {
+		t.Errorf("unexpected remote resources (-want, +got): %s", diff)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
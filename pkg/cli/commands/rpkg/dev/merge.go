@@ -0,0 +1,98 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dev
+
+import (
+	"sort"
+
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
+)
+
+// mergeResult is the outcome of a three-way merge of package resources.
+type mergeResult struct {
+	resources map[string]string
+	// conflicts holds the sorted names of the files which could not be merged
+	// cleanly.
+	conflicts []string
+}
+
+// merge3 merges the local and remote changes to the package resources, both made
+// relative to base. Files changed on one side only take that side's version, files
+// changed on both sides are merged line by line. Overlapping changes are written
+// with git style conflict markers, and a file deleted on one side and modified on
+// the other keeps the modified version; both are reported as conflicts.
+func merge3(base, local, remote map[string]string) mergeResult {
+	res := mergeResult{resources: map[string]string{}}
+	for _, name := range fileNames(base, local, remote) {
+		b, inBase := base[name]
+		l, inLocal := local[name]
+		r, inRemote := remote[name]
+
+		switch {
+		case inLocal == inRemote && l == r:
+			if inLocal {
+				res.resources[name] = l
+			}
+		case inLocal == inBase && l == b:
+			if inRemote {
+				res.resources[name] = r
+			}
+		case inRemote == inBase && r == b:
+			if inLocal {
+				res.resources[name] = l
+			}
+		case inLocal && inRemote:
+			merged, ok := mergeLines(b, l, r)
+			res.resources[name] = merged
+			if !ok {
+				res.conflicts = append(res.conflicts, name)
+			}
+		case inLocal:
+			res.resources[name] = l
+			res.conflicts = append(res.conflicts, name)
+		default:
+			res.resources[name] = r
+			res.conflicts = append(res.conflicts, name)
+		}
+	}
+	return res
+}
+
+// hasConflictMarkers reports whether the contents contain an unresolved conflict
+// written by merge3.
+func hasConflictMarkers(contents string) bool {
+	return mergeconflict.HasMarkers(contents)
+}
+
+func fileNames(resources ...map[string]string) []string {
+	set := map[string]struct{}{}
+	for _, r := range resources {
+		for name := range r {
+			set[name] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// mergeLines performs a diff3 merge of the lines of local and remote against base.
+// It returns false if the merge has conflicts.
+func mergeLines(base, local, remote string) (string, bool) {
+	return mergeconflict.MergeLines(base, local, remote, "local", "remote")
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dev
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMerge3(t *testing.T) {
+	base := map[string]string{
+		"Kptfile":         "kind: Kptfile\n",
+		"cm.yaml":         "a: 1\nb: 2\nc: 3\nd: 4\n",
+		"local-del.yaml":  "x: 1\n",
+		"remote-del.yaml": "y: 1\n",
+		"mod-del.yaml":    "z: 1\n",
+	}
+	testCases := map[string]struct {
+		local, remote map[string]string
+		want          map[string]string
+		conflicts     []string
+	}{
+		"no changes": {
+			local:  base,
+			remote: base,
+			want:   base,
+		},
+		"changes on different files and lines": {
+			local: map[string]string{
+				"Kptfile":         "kind: Kptfile\n",
+				"cm.yaml":         "a: 10\nb: 2\nc: 3\nd: 4\n",
+				"remote-del.yaml": "y: 1\n",
+				"mod-del.yaml":    "z: 1\n",
+				"local-new.yaml":  "n: 1\n",
+			},
+			remote: map[string]string{
+				"Kptfile":        "kind: Kptfile\nname: rendered\n",
+				"cm.yaml":        "a: 1\nb: 2\nc: 3\nd: 40\n",
+				"local-del.yaml": "x: 1\n",
+				"mod-del.yaml":   "z: 1\n",
+			},
+			want: map[string]string{
+				"Kptfile":        "kind: Kptfile\nname: rendered\n",
+				"cm.yaml":        "a: 10\nb: 2\nc: 3\nd: 40\n",
+				"mod-del.yaml":   "z: 1\n",
+				"local-new.yaml": "n: 1\n",
+			},
+		},
+		"same change on both sides": {
+			local:  map[string]string{"cm.yaml": "a: 1\n"},
+			remote: map[string]string{"cm.yaml": "a: 1\n"},
+			want:   map[string]string{"cm.yaml": "a: 1\n"},
+		},
+		"conflicting changes": {
+			local: map[string]string{
+				"Kptfile":         "kind: Kptfile\n",
+				"cm.yaml":         "a: 1\nb: local\nc: 3\nd: 4\n",
+				"local-del.yaml":  "x: 1\n",
+				"remote-del.yaml": "y: 1\n",
+				"mod-del.yaml":    "z: 2\n",
+			},
+			remote: map[string]string{
+				"Kptfile":         "kind: Kptfile\n",
+				"cm.yaml":         "a: 1\nb: remote\nc: 3\nd: 4\n",
+				"local-del.yaml":  "x: 1\n",
+				"remote-del.yaml": "y: 1\n",
+			},
+			want: map[string]string{
+				"Kptfile":         "kind: Kptfile\n",
+				"cm.yaml":         "a: 1\n<<<<<<< local\nb: local\n||||||| base\nb: 2\n=======\nb: remote\n>>>>>>> remote\nc: 3\nd: 4\n",
+				"local-del.yaml":  "x: 1\n",
+				"remote-del.yaml": "y: 1\n",
+				"mod-del.yaml":    "z: 2\n",
+			},
+			conflicts: []string{"cm.yaml", "mod-del.yaml"},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			got := merge3(base, tc.local, tc.remote)
+			if diff := cmp.Diff(tc.want, got.resources); diff != "" {
+				t.Errorf("unexpected resources (-want, +got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.conflicts, got.conflicts); diff != "" {
+				t.Errorf("unexpected conflicts (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeLines(t *testing.T) {
+	testCases := map[string]struct {
+		base, local, remote string
+		want                string
+		clean               bool
+	}{
+		"insertions at both ends": {
+			base:   "a\nb\nc\n",
+			local:  "first\na\nb\nc\n",
+			remote: "a\nb\nc\nlast\n",
+			want:   "first\na\nb\nc\nlast\n",
+			clean:  true,
+		},
+		"deletion and modification of different lines": {
+			base:   "a\nb\nc\nd\n",
+			local:  "a\nc\nd\n",
+			remote: "a\nb\nc\nD\n",
+			want:   "a\nc\nD\n",
+			clean:  true,
+		},
+		"missing trailing newline": {
+			base:   "a\nb",
+			local:  "a\nlocal",
+			remote: "a\nremote",
+			want:   "a\n<<<<<<< local\nlocal\n||||||| base\nb\n=======\nremote\n>>>>>>> remote\n",
+		},
+		"empty base": {
+			local:  "local\n",
+			remote: "remote\n",
+			want:   "<<<<<<< local\nlocal\n||||||| base\n=======\nremote\n>>>>>>> remote\n",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			got, clean := mergeLines(tc.base, tc.local, tc.remote)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected merge (-want, +got): %s", diff)
+			}
+			if clean != tc.clean {
+				t.Errorf("expected clean %t, got %t", tc.clean, clean)
+			}
+		})
+	}
+}
+
+func TestHasConflictMarkers(t *testing.T) {
+	merged, _ := mergeLines("a\n", "b\n", "c\n")
+	if !hasConflictMarkers(merged) {
+		t.Errorf("expected conflict markers in %q", merged)
+	}
+	if hasConflictMarkers("a: <<<<<<< local\n>>>>>>> remote\n") {
+		t.Error("expected no conflict markers when the markers do not start a line")
+	}
+	if hasConflictMarkers("<<<<<<< local\n") {
+		t.Error("expected no conflict markers without an end marker")
+	}
+}
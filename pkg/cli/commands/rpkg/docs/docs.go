@@ -135,6 +135,184 @@ var DelExamples = `
   $ porchctl rpkg del example-repo.example-package-name.example-workspace -n example-namespace
 `
 
+var DepsShort = `Show the upstream and downstream dependencies of a package revision.`
+var DepsLong = `
+  porchctl rpkg deps PACKAGE_REVISION [flags]
+
+Prints the lineage of a package revision as a tree: the chain of upstream
+package revisions it was cloned, upgraded or promoted from, followed by all
+package revisions in the namespace that were derived from it, across
+repositories. Downstream package revisions whose upstream has a newer
+published revision are marked as behind.
+
+Args:
+
+  PACKAGE_REVISION:
+    The kubernetes name of the package revision.
+`
+var DepsExamples = `
+  # show the dependencies of package revision 'blueprints.base.v1'
+  $ porchctl rpkg deps blueprints.base.v1 --namespace=example-namespace
+`
+
+var DevShort = `Develop a draft package revision in a local directory.`
+var DevLong = `
+  porchctl rpkg dev PACKAGE_REVISION DIR [flags]
+
+Pulls a draft package revision into a new local directory and watches the
+directory. Every time files in it change, the changes are pushed to the package
+revision, the result of rendering the package and the output of each function
+are printed, and the rendered package is pulled back into the directory.
+
+If the package revision was changed by someone else since it was last pulled,
+the changes are merged. Changes to the same lines of a file are written into it
+with conflict markers and are not pushed until the markers are removed.
+
+Hidden files and directories and editor backup (*~) and swap (*.swp) files in
+the directory are not pushed, and such files in the package revision are kept.
+
+Args:
+
+  PACKAGE_REVISION:
+    The kubernetes name of a draft package revision.
+
+  DIR:
+    The local directory to pull the package revision into. It must not exist.
+
+Flags:
+
+  --debounce
+    How long to wait after the last change to the directory before pushing.
+    Defaults to 500ms.
+`
+var DevExamples = `
+  # develop the draft package revision 'example-repo.example-package-name.example-workspace'
+  # in the './example-package-dir' directory
+  $ porchctl rpkg dev example-repo.example-package-name.example-workspace ./example-package-dir --namespace=example-namespace
+`
+
+var FnShort = `Edit the function pipeline of a package revision.`
+var FnLong = `
+  porchctl rpkg fn COMMAND PACKAGE_REVISION [flags]
+
+Lists, adds and removes the mutators and validators in the pipeline of the
+Kptfile of a package revision. Only draft package revisions can be edited.
+Comments and formatting in the Kptfile are kept. The package is rendered
+with the new pipeline and the result of each function is printed.
+
+Commands:
+
+  add:
+    Add a function to the pipeline, or replace the function with the same name.
+
+  rm:
+    Remove a function from the pipeline.
+
+  ls:
+    List the functions in the pipeline.
+`
+var FnExamples = `
+  # list the functions in the pipeline of package revision 'example-repo.example-package-name.example-workspace'
+  $ porchctl rpkg fn ls example-repo.example-package-name.example-workspace --namespace=example-namespace
+`
+
+var FnAddShort = `Add a function to the pipeline of a package revision.`
+var FnAddLong = `
+  porchctl rpkg fn add PACKAGE_REVISION [flags]
+
+Args:
+
+  PACKAGE_REVISION:
+    The kubernetes name of a draft package revision.
+
+Flags:
+
+  --image
+    Image of the function. Required.
+
+  --name
+    Name of the function. If the pipeline already has a function with this
+    name, it is replaced.
+
+  --type
+    Type of the function, mutator or validator. Defaults to mutator.
+
+  --config-path
+    Path of the function config file in the package.
+
+  --configmap
+    Key-value pairs of the function config. Cannot be used with --config-path.
+
+  --index
+    Position of the function in the list of functions of its type. Defaults
+    to the end of the list.
+
+  --dry-run
+    Render the package with the new pipeline and print the result without
+    saving it.
+`
+var FnAddExamples = `
+  # add a mutator that sets the namespace of all resources
+  $ porchctl rpkg fn add example-repo.example-package-name.example-workspace --namespace=example-namespace \
+      --image=ghcr.io/kptdev/krm-functions-catalog/set-namespace:v0.4 --name=set-namespace --configmap=namespace=prod
+
+  # add a validator as the first validator, without saving the package
+  $ porchctl rpkg fn add example-repo.example-package-name.example-workspace --namespace=example-namespace \
+      --image=ghcr.io/kptdev/krm-functions-catalog/kubeconform:v0.1 --type=validator --index=0 --dry-run
+`
+
+var FnRmShort = `Remove a function from the pipeline of a package revision.`
+var FnRmLong = `
+  porchctl rpkg fn rm PACKAGE_REVISION [flags]
+
+Args:
+
+  PACKAGE_REVISION:
+    The kubernetes name of a draft package revision.
+
+Flags:
+
+  --name
+    Name of the function to remove.
+
+  --image
+    Image of the function to remove. Fails if more than one function has
+    this image.
+
+  --index
+    Position of the function to remove in the list of functions of its type.
+
+  --type
+    Type of the function, mutator or validator. Defaults to mutator.
+
+  --dry-run
+    Render the package with the new pipeline and print the result without
+    saving it.
+
+Exactly one of --name, --image and --index must be given.
+`
+var FnRmExamples = `
+  # remove the mutator named 'set-namespace'
+  $ porchctl rpkg fn rm example-repo.example-package-name.example-workspace --name=set-namespace --namespace=example-namespace
+
+  # remove the first validator
+  $ porchctl rpkg fn rm example-repo.example-package-name.example-workspace --type=validator --index=0 --namespace=example-namespace
+`
+
+var FnLsShort = `List the functions in the pipeline of a package revision.`
+var FnLsLong = `
+  porchctl rpkg fn ls PACKAGE_REVISION [flags]
+
+Args:
+
+  PACKAGE_REVISION:
+    The kubernetes name of the package revision.
+`
+var FnLsExamples = `
+  # list the functions in the pipeline of package revision 'example-repo.example-package-name.example-workspace'
+  $ porchctl rpkg fn ls example-repo.example-package-name.example-workspace --namespace=example-namespace
+`
+
 var GetShort = `List package revisions in registered repositories.`
 var GetLong = `
   porchctl rpkg get [K8S_PACKAGE_REV_NAME] [flags]
@@ -203,10 +381,56 @@ Flags:
 
   --site
     Link to page with information about the package
+
+  --template
+    Starter template to initialize the package from. Either the name of a
+    published package revision, or a git repository in the form
+    REPO_URL[.git]/PACKAGE_PATH[@VERSION]. The new package does not track
+    the template as its upstream.
+
+  --param
+    Value of a parameter declared by the template, in the form NAME=VALUE.
+    May be repeated.
 `
 var InitExamples = `
   # create a new package named 'example-package-name' in the repository 'example-repository' which exists in the namespace 'example-namespace'.
   $ porchctl rpkg init example-package-name --repository=example-repository --workspace=example-workspace --namespace=example-namespace
+
+  # create a new package from the published starter template 'templates.web-app.v1'
+  $ porchctl rpkg init example-package-name --repository=example-repository --workspace=example-workspace \
+      --template=templates.web-app.v1 --param=team=web --param=replicas=3
+`
+
+var PromoteShort = `Promote a published package revision to another repository.`
+var PromoteLong = `
+  porchctl rpkg promote SOURCE_PACKAGE_REV_NAME [flags]
+
+Promotion creates a new draft package revision of the same package in the
+target repository, typically a repository registered on another branch of the
+same git repository (for example dev, staging and prod). The draft records the
+source package revision as its upstream, so the promotion lineage is kept.
+Promotion uses the v1alpha2 API.
+
+Args:
+
+  SOURCE_PACKAGE_REV_NAME:
+    The kubernetes name of the published package revision to promote.
+
+Flags:
+
+  --repository
+    The repository to which the package revision is promoted.
+
+  --workspace
+    Workspace for the promoted package revision. Defaults to the workspace of the
+    source package revision.
+`
+var PromoteExamples = `
+  # promote package revision 'dev.example-package-name.v3' to the 'staging' repository
+  $ porchctl rpkg promote dev.example-package-name.v3 --repository=staging --namespace=example-namespace
+
+  # promote to the 'prod' repository using workspace 'release-1'
+  $ porchctl rpkg promote staging.example-package-name.v3 --repository=prod --workspace=release-1 --namespace=example-namespace
 `
 
 var ProposeShort = `Propose that a package revision should be published.`
@@ -294,6 +518,78 @@ var RejectExamples = `
   $ porchctl rpkg reject example-repo.example-package-name.example-workspace --namespace=example-namespace
 `
 
+var ResolveShort = `List and resolve the merge conflicts left in a draft package revision by a manual-merge upgrade.`
+var ResolveLong = `
+  porchctl rpkg resolve K8S_PACKAGE_REV_NAME [FILE] [flags]
+
+Args:
+
+  K8S_PACKAGE_REV_NAME:
+    The kubernetes name of a draft package revision created or changed by an upgrade
+    with the manual-merge strategy.
+
+  FILE:
+    (Optional) Select the conflicts in this file of the package.
+
+Flags:
+
+  --take
+  (Optional) Resolve the selected conflicts by taking 'ours' (the local version) or
+  'theirs' (the upstream version). If not set, the selected conflicts are listed.
+
+  --resource
+  (Optional) Select the conflicts of the resource with this KIND/NAME.
+
+  --field
+  (Optional) Select the conflicts on this field path, for example spec.replicas or
+  spec.template.spec.containers[name=app].image, and on the fields it contains.
+
+A conflicting field of a KRM resource keeps the local value until it is resolved, and
+is recorded in the porch.kpt.dev/merge-conflicts annotation of the resource. Other
+files which both sides changed contain git style conflict markers, and are resolved
+as a whole. The MergeConflicts condition of the package revision lists the unresolved
+conflicts, and the package revision cannot be proposed until it is False. Conflicts can
+also be resolved by editing the package resources, for example with pull and push.
+`
+var ResolveExamples = `
+  # list the unresolved merge conflicts of package revision 'example-repo.example-package-name.example-workspace'
+  $ porchctl rpkg resolve example-repo.example-package-name.example-workspace --namespace=example-namespace
+
+  # take the upstream version of every conflicting field of the Deployment 'app'
+  $ porchctl rpkg resolve example-repo.example-package-name.example-workspace --resource=Deployment/app --take=theirs
+
+  # keep the local value of a single field
+  $ porchctl rpkg resolve example-repo.example-package-name.example-workspace deploy.yaml --field=spec.replicas --take=ours
+
+  # take the upstream version of every remaining conflict
+  $ porchctl rpkg resolve example-repo.example-package-name.example-workspace --take=theirs
+`
+
+var TreeShort = `Show the packages of repositories as a directory tree.`
+var TreeLong = `
+  porchctl rpkg tree [REPOSITORY] [flags]
+
+Prints the packages in each repository as a tree that follows the directories
+of their package paths. For each package the latest published revision is
+shown, or the newest draft or proposed revision if the package has not been
+published, with its lifecycle and the upstream it was cloned from. The
+independent subpackages embedded in the package, that is directories with their
+own Kptfile, are listed below it with their own upstream.
+
+Args:
+
+  REPOSITORY:
+    Optional. The name of a repository. If omitted, all repositories in the
+    namespace are shown.
+`
+var TreeExamples = `
+  # show the packages of all repositories in the namespace
+  $ porchctl rpkg tree --namespace=example-namespace
+
+  # show the packages of repository 'blueprints'
+  $ porchctl rpkg tree blueprints --namespace=example-namespace
+`
+
 var UpgradeShort = `Create a new revision which upgrades a published downstream to a more recent published revision of its upstream package.`
 var UpgradeLong = `
   porchctl rpkg upgrade SOURCE_PACKAGE_REVISION [flags]
@@ -318,7 +614,10 @@ Flags:
 
   --strategy
   (Optional) The strategy to use for the upgrade.
-  Options: resource-merge (default), fast-forward, force-delete-replace, copy-merge.
+  Options: resource-merge (default), fast-forward, force-delete-replace, copy-merge, manual-merge.
+  With manual-merge, conflicting changes keep the local version and are recorded in the
+  new Draft for resolution with 'porchctl rpkg resolve'; the Draft cannot be proposed
+  until every conflict is resolved.
 
   --discover
   If set, search for available updates instead of performing an update.
@@ -329,6 +628,12 @@ Flags:
   Directory path of an independent subpackage to upgrade within the parent package. When set, SOURCE_PACKAGE_REVISION refers
   to the parent Draft package revision (not a published downstream package revision)
   and --workspace must not be explicitly specified.
+
+  --dry-run
+  If set, report what the upgrade would do to each resource of the package instead of
+  performing it: whether the resource is unchanged, taken from the upstream, kept local,
+  merged or conflicting, and which fields change or conflict. No package revision is
+  created or changed, and --workspace is not required.
 `
 
 var UpgradeExamples = `
@@ -344,6 +649,12 @@ var UpgradeExamples = `
   # upgrade deployment.some-package.v1 package to v3 of its upstream, using copy-merge strategy
   $ porchctl rpkg upgrade deployment.some-package.v1 --revision=3 --workspace=v2 --strategy=copy-merge
 
+  # upgrade deployment.some-package.v1 package to v3 of its upstream, leaving conflicts for manual resolution
+  $ porchctl rpkg upgrade deployment.some-package.v1 --revision=3 --workspace=v2 --strategy=manual-merge
+
   # Upgrade an independent subpackage within a draft parent package
   $ porchctl rpkg upgrade deployment.parent-package.v2 --subpackage-dir=path/to/subpkg --revision=3
+
+  # preview the upgrade of deployment.some-package.v1 to v3 of its upstream
+  $ porchctl rpkg upgrade deployment.some-package.v1 --revision=3 --dry-run
 `
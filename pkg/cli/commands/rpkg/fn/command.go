@@ -0,0 +1,411 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fn
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	cliutils "github.com/kptdev/porch/internal/cliutils"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/docs"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	command = "cmdrpkgfn"
+
+	mutator   = "mutator"
+	validator = "validator"
+)
+
+// NewCommand returns the cobra command for `rpkg fn`, which edits the function
+// pipeline in the Kptfile of a draft package revision.
+func NewCommand(ctx context.Context, rcg *genericclioptions.ConfigFlags) *cobra.Command {
+	c := &cobra.Command{
+		Use:     "fn",
+		Aliases: []string{"function"},
+		Short:   docs.FnShort,
+		Long:    docs.FnShort + "\n" + docs.FnLong,
+		Example: docs.FnExamples,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			h, err := cmd.Flags().GetBool("help")
+			if err != nil {
+				return err
+			}
+			if h {
+				return cmd.Help()
+			}
+			return cmd.Usage()
+		},
+		Hidden: cliutils.HidePorchCommands,
+	}
+	c.AddCommand(
+		newAddRunner(ctx, rcg).Command,
+		newRmRunner(ctx, rcg).Command,
+		newLsRunner(ctx, rcg).Command,
+	)
+	return c
+}
+
+// pipelineClient reads and updates the pipeline of a package revision.
+type pipelineClient interface {
+	Get(ctx context.Context, namespace, name string) (*porchapi.PackageRevisionPipeline, error)
+	Update(ctx context.Context, p *porchapi.PackageRevisionPipeline, dryRun bool) error
+}
+
+// subresourceClient uses the pipeline subresource of the v1alpha1 API, which renders the
+// package with the new pipeline and reports the result.
+type subresourceClient struct {
+	client client.Client
+}
+
+func (c *subresourceClient) Get(ctx context.Context, namespace, name string) (*porchapi.PackageRevisionPipeline, error) {
+	pr := &porchapi.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	var p porchapi.PackageRevisionPipeline
+	if err := c.client.SubResource("pipeline").Get(ctx, pr, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (c *subresourceClient) Update(ctx context.Context, p *porchapi.PackageRevisionPipeline, dryRun bool) error {
+	pr := &porchapi.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: p.Namespace,
+			Name:      p.Name,
+		},
+	}
+	opts := []client.SubResourceUpdateOption{client.WithSubResourceBody(p)}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.client.SubResource("pipeline").Update(ctx, pr, opts...)
+}
+
+// runner holds the state shared by the fn subcommands.
+type runner struct {
+	ctx     context.Context
+	cfg     *genericclioptions.ConfigFlags
+	client  pipelineClient
+	Command *cobra.Command
+
+	name   string
+	fnType string
+}
+
+func (r *runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = command + ".preRunE"
+	if r.client == nil {
+		c, err := cliutils.CreateClientWithFlags(r.cfg)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		r.client = &subresourceClient{client: c}
+	}
+	return r.validate(args)
+}
+
+func (r *runner) validate(args []string) error {
+	const op errors.Op = command + ".preRunE"
+	name, err := validateArgs(args)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.name = name
+	if r.fnType != "" && r.fnType != mutator && r.fnType != validator {
+		return errors.E(op, fmt.Errorf("--type must be %q or %q", mutator, validator))
+	}
+	return nil
+}
+
+// wrap installs the version dispatch on the command, so that v1alpha2 edits the Kptfile
+// through PackageRevisionResources.
+func (r *runner) wrap(runE func(*cobra.Command, []string) error) {
+	r.Command.PreRunE = r.preRunE
+	r.Command.RunE = runE
+	cliutils.WrapVersionDispatch(r.Command, r.v1alpha2PreRunE, runE)
+}
+
+// functions returns the list of functions of the selected type.
+func (r *runner) functions(spec *porchapi.PackageRevisionPipelineSpec) *[]porchapi.PipelineFunction {
+	if r.fnType == validator {
+		return &spec.Validators
+	}
+	return &spec.Mutators
+}
+
+// update writes the pipeline back and reports the result of rendering the package.
+func (r *runner) update(cmd *cobra.Command, p *porchapi.PackageRevisionPipeline, dryRun bool) error {
+	if err := r.client.Update(r.ctx, p, dryRun); err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	printRenderStatus(out, &p.Status.RenderStatus, dryRun)
+	if dryRun {
+		printPipeline(out, &p.Spec)
+		fmt.Fprintf(out, "%s not updated (dry run)\n", r.name)
+		return nil
+	}
+	fmt.Fprintf(out, "%s updated\n", r.name)
+	return nil
+}
+
+type addRunner struct {
+	runner
+
+	function porchapi.PipelineFunction
+	index    int
+	dryRun   bool
+}
+
+func newAddRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *addRunner {
+	r := &addRunner{runner: runner{ctx: ctx, cfg: rcg}}
+	r.Command = &cobra.Command{
+		Use:     "add PACKAGE_REVISION",
+		Short:   docs.FnAddShort,
+		Long:    docs.FnAddShort + "\n" + docs.FnAddLong,
+		Example: docs.FnAddExamples,
+		Hidden:  cliutils.HidePorchCommands,
+	}
+	r.wrap(r.runE)
+
+	r.Command.Flags().StringVar(&r.function.Image, "image", "", "Image of the function.")
+	r.Command.Flags().StringVar(&r.function.Name, "name", "", "Name of the function. An existing function with the same name is replaced.")
+	r.Command.Flags().StringVar(&r.fnType, "type", mutator, "Type of the function, mutator or validator.")
+	r.Command.Flags().StringVar(&r.function.ConfigPath, "config-path", "", "Path of the function config file in the package.")
+	r.Command.Flags().StringToStringVar(&r.function.ConfigMap, "configmap", nil, "Key-value pairs of the function config, e.g. --configmap=app=nginx,tier=web.")
+	r.Command.Flags().IntVar(&r.index, "index", -1, "Position of the function in the list. Defaults to the end of the list.")
+	r.Command.Flags().BoolVar(&r.dryRun, "dry-run", false, "Render the package with the new pipeline without saving it.")
+	_ = r.Command.MarkFlagRequired("image")
+	return r
+}
+
+func (r *addRunner) runE(cmd *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".add.runE"
+
+	p, err := r.client.Get(r.ctx, *r.cfg.Namespace, r.name)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	functions := r.functions(&p.Spec)
+	if *functions, err = addFunction(*functions, r.function, r.index); err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.update(cmd, p, r.dryRun); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+type rmRunner struct {
+	runner
+
+	fnName string
+	image  string
+	index  int
+	dryRun bool
+}
+
+func newRmRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *rmRunner {
+	r := &rmRunner{runner: runner{ctx: ctx, cfg: rcg}}
+	r.Command = &cobra.Command{
+		Use:     "rm PACKAGE_REVISION",
+		Aliases: []string{"remove"},
+		Short:   docs.FnRmShort,
+		Long:    docs.FnRmShort + "\n" + docs.FnRmLong,
+		Example: docs.FnRmExamples,
+		Hidden:  cliutils.HidePorchCommands,
+	}
+	r.wrap(r.runE)
+
+	r.Command.Flags().StringVar(&r.fnName, "name", "", "Name of the function to remove.")
+	r.Command.Flags().StringVar(&r.image, "image", "", "Image of the function to remove.")
+	r.Command.Flags().IntVar(&r.index, "index", -1, "Position of the function to remove.")
+	r.Command.Flags().StringVar(&r.fnType, "type", mutator, "Type of the function, mutator or validator.")
+	r.Command.Flags().BoolVar(&r.dryRun, "dry-run", false, "Render the package with the new pipeline without saving it.")
+	r.Command.MarkFlagsOneRequired("name", "image", "index")
+	r.Command.MarkFlagsMutuallyExclusive("name", "image", "index")
+	return r
+}
+
+func (r *rmRunner) runE(cmd *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".rm.runE"
+
+	p, err := r.client.Get(r.ctx, *r.cfg.Namespace, r.name)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	functions := r.functions(&p.Spec)
+	if *functions, err = removeFunction(*functions, r.fnName, r.image, r.index); err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.update(cmd, p, r.dryRun); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+type lsRunner struct {
+	runner
+}
+
+func newLsRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *lsRunner {
+	r := &lsRunner{runner: runner{ctx: ctx, cfg: rcg}}
+	r.Command = &cobra.Command{
+		Use:     "ls PACKAGE_REVISION",
+		Aliases: []string{"list"},
+		Short:   docs.FnLsShort,
+		Long:    docs.FnLsShort + "\n" + docs.FnLsLong,
+		Example: docs.FnLsExamples,
+		Hidden:  cliutils.HidePorchCommands,
+	}
+	r.wrap(r.runE)
+	return r
+}
+
+func (r *lsRunner) runE(cmd *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".ls.runE"
+
+	p, err := r.client.Get(r.ctx, *r.cfg.Namespace, r.name)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	printPipeline(cmd.OutOrStdout(), &p.Spec)
+	return nil
+}
+
+func validateArgs(args []string) (string, error) {
+	if len(args) < 1 {
+		return "", fmt.Errorf("PACKAGE_REVISION is a required positional argument")
+	}
+	if len(args) > 1 {
+		return "", fmt.Errorf("too many arguments; PACKAGE_REVISION is the only accepted positional argument")
+	}
+	return args[0], nil
+}
+
+// addFunction inserts the function at the given index, or appends it if the index is negative.
+// A function with the same name is replaced; it keeps its position unless an index is given.
+func addFunction(functions []porchapi.PipelineFunction, function porchapi.PipelineFunction, index int) ([]porchapi.PipelineFunction, error) {
+	if function.Name != "" {
+		if i := slices.IndexFunc(functions, func(f porchapi.PipelineFunction) bool { return f.Name == function.Name }); i >= 0 {
+			if index < 0 || index == i {
+				functions[i] = function
+				return functions, nil
+			}
+			functions = slices.Delete(functions, i, i+1)
+		}
+	}
+	if index < 0 {
+		return append(functions, function), nil
+	}
+	if index > len(functions) {
+		return nil, fmt.Errorf("index %d is out of range; the pipeline has %d functions of this type", index, len(functions))
+	}
+	return slices.Insert(functions, index, function), nil
+}
+
+// removeFunction removes the function with the given name, the function with the given image,
+// or the function at the given index, in that order of precedence.
+func removeFunction(functions []porchapi.PipelineFunction, name, image string, index int) ([]porchapi.PipelineFunction, error) {
+	switch {
+	case name != "":
+		index = slices.IndexFunc(functions, func(f porchapi.PipelineFunction) bool { return f.Name == name })
+		if index < 0 {
+			return nil, fmt.Errorf("no function named %q in the pipeline", name)
+		}
+	case image != "":
+		index = -1
+		for i, f := range functions {
+			if f.Image != image {
+				continue
+			}
+			if index >= 0 {
+				return nil, fmt.Errorf("more than one function with image %q in the pipeline; use --name or --index", image)
+			}
+			index = i
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("no function with image %q in the pipeline", image)
+		}
+	case index < 0 || index >= len(functions):
+		return nil, fmt.Errorf("index %d is out of range; the pipeline has %d functions of this type", index, len(functions))
+	}
+	return slices.Delete(functions, index, index+1), nil
+}
+
+func printPipeline(out io.Writer, spec *porchapi.PackageRevisionPipelineSpec) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tINDEX\tNAME\tIMAGE\tCONFIG")
+	for _, list := range []struct {
+		fnType    string
+		functions []porchapi.PipelineFunction
+	}{
+		{mutator, spec.Mutators},
+		{validator, spec.Validators},
+	} {
+		for i, f := range list.functions {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", list.fnType, i, f.Name, f.Image, describeConfig(&f))
+		}
+	}
+	_ = w.Flush()
+}
+
+func describeConfig(f *porchapi.PipelineFunction) string {
+	if f.ConfigPath != "" {
+		return f.ConfigPath
+	}
+	var pairs []string
+	for _, k := range slices.Sorted(maps.Keys(f.ConfigMap)) {
+		pairs = append(pairs, k+"="+f.ConfigMap[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// printRenderStatus prints the functions that ran when the package was rendered, in the same
+// format as `rpkg push`. Unless it is a dry run, the package is saved even if rendering fails.
+func printRenderStatus(out io.Writer, rs *porchapi.RenderStatus, dryRun bool) {
+	if rs.Err != "" {
+		if dryRun {
+			fmt.Fprintf(out, "Failed to render the package.\n")
+		} else {
+			fmt.Fprintf(out, "Package is updated, but failed to render the package.\n")
+		}
+		fmt.Fprintf(out, "Error: %s\n", rs.Err)
+	}
+	for _, result := range rs.Result.Items {
+		fmt.Fprintf(out, "[RUNNING] %q\n", result.Image)
+		if result.ExitCode != 0 {
+			fmt.Fprintf(out, "[FAIL] %q\n", result.Image)
+		} else {
+			fmt.Fprintf(out, "[PASS] %q\n", result.Image)
+		}
+	}
+}
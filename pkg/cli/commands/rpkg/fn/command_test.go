@@ -0,0 +1,182 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fn
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestValidateArgs(t *testing.T) {
+	_, err := validateArgs(nil)
+	assert.ErrorContains(t, err, "PACKAGE_REVISION is a required positional argument")
+
+	_, err = validateArgs([]string{"a", "b"})
+	assert.ErrorContains(t, err, "too many arguments")
+
+	name, err := validateArgs([]string{"repo.pkg.ws"})
+	assert.NoError(t, err)
+	assert.Equal(t, "repo.pkg.ws", name)
+}
+
+func TestAddFunction(t *testing.T) {
+	existing := func() []porchapi.PipelineFunction {
+		return []porchapi.PipelineFunction{
+			{Name: "a", Image: "fn-a"},
+			{Name: "b", Image: "fn-b"},
+		}
+	}
+
+	functions, err := addFunction(existing(), porchapi.PipelineFunction{Image: "fn-c"}, -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fn-a", "fn-b", "fn-c"}, images(functions))
+
+	functions, err = addFunction(existing(), porchapi.PipelineFunction{Image: "fn-c"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fn-c", "fn-a", "fn-b"}, images(functions))
+
+	functions, err = addFunction(existing(), porchapi.PipelineFunction{Name: "a", Image: "fn-a2"}, -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fn-a2", "fn-b"}, images(functions))
+
+	functions, err = addFunction(existing(), porchapi.PipelineFunction{Name: "a", Image: "fn-a2"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fn-b", "fn-a2"}, images(functions))
+
+	_, err = addFunction(existing(), porchapi.PipelineFunction{Image: "fn-c"}, 3)
+	assert.ErrorContains(t, err, "out of range")
+}
+
+func TestRemoveFunction(t *testing.T) {
+	existing := func() []porchapi.PipelineFunction {
+		return []porchapi.PipelineFunction{
+			{Name: "a", Image: "fn-a"},
+			{Image: "fn-b"},
+			{Image: "fn-b", ConfigPath: "b.yaml"},
+		}
+	}
+
+	functions, err := removeFunction(existing(), "a", "", -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fn-b", "fn-b"}, images(functions))
+
+	functions, err = removeFunction(existing(), "", "fn-a", -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fn-b", "fn-b"}, images(functions))
+
+	functions, err = removeFunction(existing(), "", "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fn-a", "fn-b"}, images(functions))
+
+	_, err = removeFunction(existing(), "missing", "", -1)
+	assert.ErrorContains(t, err, `no function named "missing"`)
+
+	_, err = removeFunction(existing(), "", "fn-b", -1)
+	assert.ErrorContains(t, err, "more than one function")
+
+	_, err = removeFunction(existing(), "", "", 3)
+	assert.ErrorContains(t, err, "out of range")
+}
+
+func TestPrintPipeline(t *testing.T) {
+	var out bytes.Buffer
+	printPipeline(&out, &porchapi.PackageRevisionPipelineSpec{
+		Mutators: []porchapi.PipelineFunction{
+			{Name: "set-labels", Image: "set-labels:v1", ConfigMap: map[string]string{"tier": "web", "app": "nginx"}},
+		},
+		Validators: []porchapi.PipelineFunction{
+			{Image: "kubeconform:v1", ConfigPath: "kubeconform.yaml"},
+		},
+	})
+	assert.Equal(t, `TYPE        INDEX   NAME         IMAGE            CONFIG
+mutator     0       set-labels   set-labels:v1    app=nginx,tier=web
+validator   0                    kubeconform:v1   kubeconform.yaml
+`, out.String())
+}
+
+func TestAddRunE(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, porchapi.AddToScheme(scheme))
+
+	var updated *porchapi.PackageRevisionPipeline
+	var updateOpts client.SubResourceUpdateOptions
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		SubResourceGet: func(_ context.Context, _ client.Client, subResourceName string, obj client.Object, subResource client.Object, _ ...client.SubResourceGetOption) error {
+			assert.Equal(t, "pipeline", subResourceName)
+			assert.Equal(t, "repo.pkg.ws", obj.GetName())
+			p := subResource.(*porchapi.PackageRevisionPipeline)
+			p.Namespace = obj.GetNamespace()
+			p.Name = obj.GetName()
+			p.ResourceVersion = "3"
+			p.Spec.Validators = []porchapi.PipelineFunction{{Image: "kubeconform:v1"}}
+			return nil
+		},
+		SubResourceUpdate: func(_ context.Context, _ client.Client, subResourceName string, _ client.Object, opts ...client.SubResourceUpdateOption) error {
+			assert.Equal(t, "pipeline", subResourceName)
+			updateOpts = client.SubResourceUpdateOptions{}
+			updateOpts.ApplyOptions(opts)
+			updated = updateOpts.SubResourceBody.(*porchapi.PackageRevisionPipeline)
+			updated.Status.RenderStatus.Result.Items = []*porchapi.Result{{Image: "set-labels:v1"}}
+			return nil
+		},
+	}).Build()
+
+	ns := "ns"
+	r := newAddRunner(context.Background(), &genericclioptions.ConfigFlags{Namespace: &ns})
+	r.client = &subresourceClient{client: c}
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	r.function = porchapi.PipelineFunction{Name: "set-labels", Image: "set-labels:v1"}
+	r.fnType = mutator
+	require.NoError(t, r.preRunE(cmd, []string{"repo.pkg.ws"}))
+	require.NoError(t, r.runE(cmd, nil))
+
+	require.NotNil(t, updated)
+	assert.Empty(t, updateOpts.DryRun)
+	assert.Equal(t, "3", updated.ResourceVersion)
+	assert.Equal(t, []string{"set-labels:v1"}, images(updated.Spec.Mutators))
+	assert.Equal(t, []string{"kubeconform:v1"}, images(updated.Spec.Validators))
+	assert.Equal(t, "[RUNNING] \"set-labels:v1\"\n[PASS] \"set-labels:v1\"\nrepo.pkg.ws updated\n", out.String())
+
+	out.Reset()
+	r.dryRun = true
+	require.NoError(t, r.runE(cmd, nil))
+	assert.Equal(t, []string{"All"}, updateOpts.DryRun)
+	assert.Contains(t, out.String(), "repo.pkg.ws not updated (dry run)\n")
+
+	r.fnType = "generator"
+	assert.ErrorContains(t, r.preRunE(cmd, []string{"repo.pkg.ws"}), "--type must be")
+}
+
+func images(functions []porchapi.PipelineFunction) []string {
+	var images []string
+	for _, f := range functions {
+		images = append(images, f.Image)
+	}
+	return images
+}
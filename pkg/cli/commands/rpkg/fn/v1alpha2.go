@@ -0,0 +1,110 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	kptfileapi "github.com/kptdev/krm-functions-sdk/go/fn/kptfileapi"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	cliutils "github.com/kptdev/porch/internal/cliutils"
+	"github.com/kptdev/porch/pkg/util/pipeline"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// errV1Alpha2DryRun is returned for dry runs against the v1alpha2 API, which has no way to
+// render a package without saving it.
+var errV1Alpha2DryRun = fmt.Errorf("--dry-run is not supported with --api-version=v1alpha2, since the package cannot be rendered without saving it")
+
+func (r *runner) v1alpha2PreRunE(cmd *cobra.Command, args []string) error {
+	const op errors.Op = command + ".preRunE"
+	if dryRun, err := cmd.Flags().GetBool("dry-run"); err == nil && dryRun {
+		return errors.E(op, errV1Alpha2DryRun)
+	}
+	if r.client == nil {
+		c, err := cliutils.CreateV1Alpha2ClientWithFlags(r.cfg)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		r.client = &resourcesClient{client: c}
+	}
+	return r.validate(args)
+}
+
+// resourcesClient edits the Kptfile through PackageRevisionResources, since the v1alpha2 API
+// has no pipeline subresource. The package is rendered when the resources are updated; dry runs
+// are rejected, since the package cannot be rendered without saving it.
+type resourcesClient struct {
+	client client.Client
+}
+
+func (c *resourcesClient) Get(ctx context.Context, namespace, name string) (*porchapi.PackageRevisionPipeline, error) {
+	resources, kptfile, err := c.getResources(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := pipeline.Get(kptfile)
+	if err != nil {
+		return nil, err
+	}
+	return &porchapi.PackageRevisionPipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       namespace,
+			Name:            name,
+			ResourceVersion: resources.ResourceVersion,
+		},
+		Spec: spec,
+	}, nil
+}
+
+func (c *resourcesClient) Update(ctx context.Context, p *porchapi.PackageRevisionPipeline, dryRun bool) error {
+	if dryRun {
+		return errV1Alpha2DryRun
+	}
+	resources, kptfile, err := c.getResources(ctx, p.Namespace, p.Name)
+	if err != nil {
+		return err
+	}
+	newKptfile, err := pipeline.Set(kptfile, p.Spec)
+	if err != nil {
+		return err
+	}
+
+	resources.ResourceVersion = p.ResourceVersion
+	resources.Spec.Resources[kptfileapi.KptFileName] = newKptfile
+	if err := c.client.Update(ctx, resources); err != nil {
+		return err
+	}
+	p.ResourceVersion = resources.ResourceVersion
+	p.Status.RenderStatus = resources.Status.RenderStatus
+	return nil
+}
+
+func (c *resourcesClient) getResources(ctx context.Context, namespace, name string) (*porchapi.PackageRevisionResources, string, error) {
+	var resources porchapi.PackageRevisionResources
+	if err := c.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &resources); err != nil {
+		return nil, "", err
+	}
+	kptfile, found := resources.Spec.Resources[kptfileapi.KptFileName]
+	if !found {
+		return nil, "", fmt.Errorf("package revision %s has no %s", name, kptfileapi.KptFileName)
+	}
+	return &resources, kptfile, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fn
+
+import (
+	"context"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const kptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+pipeline:
+  mutators:
+    # keep this comment
+    - image: set-labels:v1
+      configMap:
+        app: nginx
+`
+
+func TestResourcesClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, porchapi.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&porchapi.PackageRevisionResources{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "repo.pkg.ws"},
+		Spec: porchapi.PackageRevisionResourcesSpec{
+			Resources: map[string]string{"Kptfile": kptfile},
+		},
+	}).Build()
+	rc := &resourcesClient{client: c}
+	ctx := context.Background()
+
+	p, err := rc.Get(ctx, "ns", "repo.pkg.ws")
+	require.NoError(t, err)
+	assert.NotEmpty(t, p.ResourceVersion)
+	assert.Equal(t, []porchapi.PipelineFunction{{Image: "set-labels:v1", ConfigMap: map[string]string{"app": "nginx"}}}, p.Spec.Mutators)
+
+	p.Spec.Validators = []porchapi.PipelineFunction{{Image: "kubeconform:v1"}}
+	assert.ErrorIs(t, rc.Update(ctx, p, true), errV1Alpha2DryRun)
+	var prr porchapi.PackageRevisionResources
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "repo.pkg.ws"}, &prr))
+	assert.Equal(t, kptfile, prr.Spec.Resources["Kptfile"])
+
+	require.NoError(t, rc.Update(ctx, p, false))
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "repo.pkg.ws"}, &prr))
+	assert.Equal(t, kptfile+`  validators:
+    - image: kubeconform:v1
+`, prr.Spec.Resources["Kptfile"])
+
+	stale := p.DeepCopy()
+	stale.ResourceVersion = "1"
+	assert.Error(t, rc.Update(ctx, stale, false))
+
+	_, err = rc.Get(ctx, "ns", "missing.pkg.ws")
+	assert.Error(t, err)
+}
+
+func TestV1Alpha2PreRunERejectsDryRun(t *testing.T) {
+	ns := "ns"
+	r := newAddRunner(context.Background(), &genericclioptions.ConfigFlags{Namespace: &ns})
+	r.client = &resourcesClient{}
+
+	require.NoError(t, r.v1alpha2PreRunE(r.Command, []string{"repo.pkg.ws"}))
+
+	require.NoError(t, r.Command.Flags().Set("dry-run", "true"))
+	assert.ErrorContains(t, r.v1alpha2PreRunE(r.Command, []string{"repo.pkg.ws"}), "--dry-run is not supported with --api-version=v1alpha2")
+}
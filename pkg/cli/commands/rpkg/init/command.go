@@ -17,8 +17,10 @@ package init
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/kptdev/kpt/pkg/lib/errors"
+	"github.com/kptdev/kpt/pkg/lib/util/parse"
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
 	cliutils "github.com/kptdev/porch/internal/cliutils"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/docs"
@@ -60,6 +62,8 @@ func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner
 	c.Flags().StringVar(&r.Site, "site", "", "link to page with information about the package.")
 	c.Flags().StringVar(&r.repository, "repository", "", "Repository to which package will be created.")
 	c.Flags().StringVar(&r.workspace, "workspace", "", "Workspace name of the package.")
+	c.Flags().StringVar(&r.template, "template", "", "Published package revision or git repository of a starter template to initialize the package from.")
+	c.Flags().StringToStringVar(&r.parameters, "param", map[string]string{}, "Value of a parameter declared by the template, in the form NAME=VALUE.")
 
 	return r
 }
@@ -77,6 +81,8 @@ type runner struct {
 	name        string // Target package name
 	repository  string // Target repository
 	workspace   string // Target workspace name
+	template    string // Starter template
+	parameters  map[string]string
 }
 
 func (r *runner) preRunE(_ *cobra.Command, args []string) error {
@@ -100,6 +106,10 @@ func (r *runner) preRunE(_ *cobra.Command, args []string) error {
 		return errors.E(op, fmt.Errorf("--workspace is required to specify workspace name"))
 	}
 
+	if r.template == "" && len(r.parameters) != 0 {
+		return errors.E(op, fmt.Errorf("--param may only be specified together with --template"))
+	}
+
 	r.name = args[0]
 	return nil
 }
@@ -107,6 +117,24 @@ func (r *runner) preRunE(_ *cobra.Command, args []string) error {
 func (r *runner) runE(cmd *cobra.Command, _ []string) error {
 	const op errors.Op = command + ".runE"
 
+	initSpec := &porchapi.PackageInitTaskSpec{
+		Description: r.Description,
+		Keywords:    r.Keywords,
+		Site:        r.Site,
+	}
+	if r.template != "" {
+		source, err := parseTemplateSource(r.template)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		initSpec.Template = &porchapi.PackageTemplate{Parameters: r.parameters}
+		if source.upstreamRef != "" {
+			initSpec.Template.UpstreamRef = &porchapi.PackageRevisionRef{Name: source.upstreamRef}
+		} else {
+			initSpec.Template.Git = &porchapi.GitPackage{Repo: source.repo, Ref: source.ref, Directory: source.directory}
+		}
+	}
+
 	pr := &porchapi.PackageRevision{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "PackageRevision",
@@ -122,11 +150,7 @@ func (r *runner) runE(cmd *cobra.Command, _ []string) error {
 			Tasks: []porchapi.Task{
 				{
 					Type: porchapi.TaskTypeInit,
-					Init: &porchapi.PackageInitTaskSpec{
-						Description: r.Description,
-						Keywords:    r.Keywords,
-						Site:        r.Site,
-					},
+					Init: initSpec,
 				},
 			},
 		},
@@ -139,3 +163,37 @@ func (r *runner) runE(cmd *cobra.Command, _ []string) error {
 	fmt.Fprintf(cmd.OutOrStdout(), "%s created\n", pr.Name)
 	return nil
 }
+
+// templateSource is the starter template selected with --template: either the
+// name of a published package revision or a git repository.
+type templateSource struct {
+	upstreamRef string
+	repo        string
+	directory   string
+	ref         string
+}
+
+// parseTemplateSource parses the --template flag. Values containing a "/" are
+// git repositories and may use the repo.git/directory@ref syntax of kpt.
+func parseTemplateSource(source string) (templateSource, error) {
+	if !strings.Contains(source, "/") {
+		return templateSource{upstreamRef: source}, nil
+	}
+
+	result := templateSource{repo: source}
+	if parse.HasGitSuffix(source) {
+		repo, dir, ref, err := parse.URL(source)
+		if err != nil {
+			return templateSource{}, err
+		}
+		// parse.URL removes the git suffix, we need to add it back
+		result = templateSource{repo: repo + ".git", directory: dir, ref: ref}
+	}
+	if result.ref == "" {
+		result.ref = "main"
+	}
+	if result.directory == "" {
+		result.directory = "/"
+	}
+	return result, nil
+}
@@ -1,4 +1,4 @@
-// Copyright 2024 The kpt Authors
+// Copyright 2024, 2026 The kpt Authors
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -18,6 +18,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -119,3 +120,50 @@ func TestNewCommand(t *testing.T) {
 		t.Fatal("NewCommand returned nil")
 	}
 }
+
+func TestParseTemplateSource(t *testing.T) {
+	testCases := map[string]struct {
+		source string
+		want   templateSource
+	}{
+		"package revision": {
+			source: "templates.web-app.v1",
+			want:   templateSource{upstreamRef: "templates.web-app.v1"},
+		},
+		"git repository": {
+			source: "https://example.com/templates",
+			want:   templateSource{repo: "https://example.com/templates", directory: "/", ref: "main"},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			got, err := parseTemplateSource(tc.source)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(templateSource{})); diff != "" {
+				t.Errorf("Unexpected result (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseTemplateSourceGitURL(t *testing.T) {
+	got, err := parseTemplateSource("https://example.com/templates.git/web-app@v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.repo != "https://example.com/templates.git" {
+		t.Errorf("expected repo 'https://example.com/templates.git', got %q", got.repo)
+	}
+	if got.ref != "v1" {
+		t.Errorf("expected ref 'v1', got %q", got.ref)
+	}
+	if !strings.Contains(got.directory, "web-app") {
+		t.Errorf("expected directory 'web-app', got %q", got.directory)
+	}
+	if got.upstreamRef != "" {
+		t.Errorf("expected no upstreamRef, got %q", got.upstreamRef)
+	}
+}
@@ -41,6 +41,8 @@ type v1alpha2Runner struct {
 	name        string
 	repository  string
 	workspace   string
+	template    string
+	parameters  map[string]string
 }
 
 func newV1Alpha2Runner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *v1alpha2Runner {
@@ -68,6 +70,8 @@ func (r *v1alpha2Runner) preRunE(cmd *cobra.Command, args []string) error {
 	r.Description, _ = cmd.Flags().GetString("description")
 	r.Site, _ = cmd.Flags().GetString("site")
 	r.Keywords, _ = cmd.Flags().GetStringSlice("keywords")
+	r.template, _ = cmd.Flags().GetString("template")
+	r.parameters, _ = cmd.Flags().GetStringToString("param")
 
 	if r.repository == "" {
 		return errors.E(op, fmt.Errorf("--repository is required to specify target repository"))
@@ -75,6 +79,9 @@ func (r *v1alpha2Runner) preRunE(cmd *cobra.Command, args []string) error {
 	if r.workspace == "" {
 		return errors.E(op, fmt.Errorf("--workspace is required to specify workspace name"))
 	}
+	if r.template == "" && len(r.parameters) != 0 {
+		return errors.E(op, fmt.Errorf("--param may only be specified together with --template"))
+	}
 
 	r.name = args[0]
 	pkgExists, err := util.PackageAlreadyExistsV1Alpha2(r.ctx, r.client, r.repository, r.name, *r.cfg.Namespace)
@@ -91,6 +98,24 @@ func (r *v1alpha2Runner) preRunE(cmd *cobra.Command, args []string) error {
 func (r *v1alpha2Runner) runE(cmd *cobra.Command, _ []string) error {
 	const op errors.Op = command + ".runE"
 
+	initSpec := &porchv1alpha2.PackageInitSpec{
+		Description: r.Description,
+		Keywords:    r.Keywords,
+		Site:        r.Site,
+	}
+	if r.template != "" {
+		source, err := parseTemplateSource(r.template)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		initSpec.Template = &porchv1alpha2.PackageTemplate{Parameters: r.parameters}
+		if source.upstreamRef != "" {
+			initSpec.Template.UpstreamRef = &porchv1alpha2.PackageRevisionRef{Name: source.upstreamRef}
+		} else {
+			initSpec.Template.Git = &porchv1alpha2.GitPackage{Repo: source.repo, Ref: source.ref, Directory: source.directory}
+		}
+	}
+
 	pr := &porchv1alpha2.PackageRevision{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "PackageRevision",
@@ -106,11 +131,7 @@ func (r *v1alpha2Runner) runE(cmd *cobra.Command, _ []string) error {
 			RepositoryName: r.repository,
 			Lifecycle:      porchv1alpha2.PackageRevisionLifecycleDraft,
 			Source: &porchv1alpha2.PackageSource{
-				Init: &porchv1alpha2.PackageInitSpec{
-					Description: r.Description,
-					Keywords:    r.Keywords,
-					Site:        r.Site,
-				},
+				Init: initSpec,
 			},
 		},
 	}
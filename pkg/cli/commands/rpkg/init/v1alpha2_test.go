@@ -500,3 +500,67 @@ func TestV1Alpha2InitPreRunEValidFlags(t *testing.T) {
 	assert.Equal(t, "https://example.com", r.Site)
 	assert.Equal(t, []string{"test", "pkg"}, r.Keywords)
 }
+
+func TestV1Alpha2InitFromTemplate(t *testing.T) {
+	ns := "ns"
+	scheme, err := createV1Alpha2Scheme()
+	if err != nil {
+		t.Fatalf("error creating scheme: %v", err)
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	r := &v1alpha2Runner{
+		ctx:        context.Background(),
+		cfg:        &genericclioptions.ConfigFlags{Namespace: &ns},
+		client:     c,
+		name:       "new-pkg",
+		repository: "repo",
+		workspace:  "v1",
+		template:   "templates.web-app.v1",
+		parameters: map[string]string{"replicas": "3"},
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	assert.NoError(t, r.runE(cmd, nil))
+
+	var pr porchv1alpha2.PackageRevision
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: ns, Name: "repo.new-pkg.v1"}, &pr); err != nil {
+		t.Fatalf("failed to get created PR: %v", err)
+	}
+	if pr.Spec.Source == nil || pr.Spec.Source.Init == nil || pr.Spec.Source.Init.Template == nil {
+		t.Fatal("expected source.init.template to be set")
+	}
+	assert.Equal(t, &porchv1alpha2.PackageTemplate{
+		UpstreamRef: &porchv1alpha2.PackageRevisionRef{Name: "templates.web-app.v1"},
+		Parameters:  map[string]string{"replicas": "3"},
+	}, pr.Spec.Source.Init.Template)
+}
+
+func TestV1Alpha2InitPreRunEParamWithoutTemplate(t *testing.T) {
+	ns := "ns"
+	ctx := context.Background()
+
+	scheme, err := createV1Alpha2Scheme()
+	if err != nil {
+		t.Fatalf("error creating scheme: %v", err)
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	r := &v1alpha2Runner{
+		ctx:    ctx,
+		cfg:    &genericclioptions.ConfigFlags{Namespace: &ns},
+		client: c,
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("repository", "repo", "")
+	cmd.Flags().String("workspace", "v1", "")
+	cmd.Flags().String("template", "", "")
+	cmd.Flags().StringToString("param", map[string]string{"replicas": "3"}, "")
+
+	err = r.preRunE(cmd, []string{"my-pkg"})
+	assert.ErrorContains(t, err, "--param may only be specified together with --template")
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promote
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	cliutils "github.com/kptdev/porch/internal/cliutils"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/docs"
+	pkgutil "github.com/kptdev/porch/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	command = "cmdrpkgpromote"
+)
+
+// NewCommand returns the promote command. Promotion is only available in the
+// v1alpha2 API, so the command always uses the v1alpha2 client.
+func NewCommand(ctx context.Context, rcg *genericclioptions.ConfigFlags) *cobra.Command {
+	return newRunner(ctx, rcg).Command
+}
+
+func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner {
+	r := &runner{
+		ctx: ctx,
+		cfg: rcg,
+	}
+	r.Command = &cobra.Command{
+		Use:     "promote SOURCE_PACKAGE_REV",
+		Short:   docs.PromoteShort,
+		Long:    docs.PromoteShort + "\n" + docs.PromoteLong,
+		Example: docs.PromoteExamples,
+		PreRunE: r.preRunE,
+		RunE:    r.runE,
+		Hidden:  cliutils.HidePorchCommands,
+	}
+	r.Command.Flags().StringVar(&r.repository, "repository", "", "Repository to which the package revision will be promoted.")
+	r.Command.Flags().StringVar(&r.workspace, "workspace", "", "Workspace name of the promoted package revision. Defaults to the workspace name of the source package revision.")
+	return r
+}
+
+type runner struct {
+	ctx     context.Context
+	cfg     *genericclioptions.ConfigFlags
+	client  client.Client
+	Command *cobra.Command
+
+	sourceName string
+	repository string // Target repository
+	workspace  string // Target package revision workspaceName
+}
+
+func (r *runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = command + ".preRunE"
+	if r.client == nil {
+		c, err := cliutils.CreateV1Alpha2ClientWithFlags(r.cfg)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		r.client = c
+	}
+
+	if len(args) < 1 {
+		return errors.E(op, fmt.Errorf("SOURCE_PACKAGE_REV is a required positional argument"))
+	}
+	if len(args) > 1 {
+		return errors.E(op, fmt.Errorf("too many arguments; SOURCE_PACKAGE_REV is the only accepted positional argument"))
+	}
+	if r.repository == "" {
+		return errors.E(op, fmt.Errorf("--repository is required to specify the target repository"))
+	}
+
+	r.sourceName = args[0]
+	return nil
+}
+
+func (r *runner) runE(cmd *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".runE"
+
+	var source porchv1alpha2.PackageRevision
+	if err := r.client.Get(r.ctx, types.NamespacedName{
+		Name:      r.sourceName,
+		Namespace: *r.cfg.Namespace,
+	}, &source); err != nil {
+		return errors.E(op, err)
+	}
+
+	if !source.IsPublished() {
+		return errors.E(op, fmt.Errorf("cannot promote %q: only published package revisions can be promoted", r.sourceName))
+	}
+	if source.Spec.RepositoryName == r.repository {
+		return errors.E(op, fmt.Errorf("cannot promote %q into its own repository %q; use `copy` to create a new revision in the same repository",
+			r.sourceName, r.repository))
+	}
+
+	workspace := r.workspace
+	if workspace == "" {
+		workspace = source.Spec.WorkspaceName
+	}
+
+	pr := &porchv1alpha2.PackageRevision{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageRevision",
+			APIVersion: porchv1alpha2.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: *r.cfg.Namespace,
+			Name:      pkgutil.ComposePkgRevObjName(r.repository, "", source.Spec.PackageName, workspace),
+		},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			PackageName:    source.Spec.PackageName,
+			WorkspaceName:  workspace,
+			RepositoryName: r.repository,
+			Lifecycle:      porchv1alpha2.PackageRevisionLifecycleDraft,
+			Source: &porchv1alpha2.PackageSource{
+				PromoteFrom: &porchv1alpha2.PackageRevisionRef{Name: r.sourceName},
+			},
+		},
+	}
+	if err := r.client.Create(r.ctx, pr); err != nil {
+		return errors.E(op, err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s created\n", pr.Name)
+	return nil
+}
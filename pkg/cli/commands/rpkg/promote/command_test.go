@@ -0,0 +1,202 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promote
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func createScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, porchv1alpha2.AddToScheme(scheme))
+	return scheme
+}
+
+func sourcePackageRevision(lifecycle porchv1alpha2.PackageRevisionLifecycle) *porchv1alpha2.PackageRevision {
+	return &porchv1alpha2.PackageRevision{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageRevision",
+			APIVersion: porchv1alpha2.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "dev.my-pkg.v1",
+		},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			RepositoryName: "dev",
+			PackageName:    "my-pkg",
+			WorkspaceName:  "v1",
+			Lifecycle:      lifecycle,
+		},
+	}
+}
+
+func TestPreRunE(t *testing.T) {
+	ns := "ns"
+	tests := []struct {
+		name       string
+		args       []string
+		repository string
+		wantErr    string
+	}{
+		{
+			name:    "missing source",
+			args:    []string{},
+			wantErr: "SOURCE_PACKAGE_REV is a required positional argument",
+		},
+		{
+			name:       "too many arguments",
+			args:       []string{"a", "b"},
+			repository: "staging",
+			wantErr:    "too many arguments",
+		},
+		{
+			name:    "missing repository",
+			args:    []string{"dev.my-pkg.v1"},
+			wantErr: "--repository is required",
+		},
+		{
+			name:       "valid",
+			args:       []string{"dev.my-pkg.v1"},
+			repository: "staging",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &runner{
+				ctx:        t.Context(),
+				cfg:        &genericclioptions.ConfigFlags{Namespace: &ns},
+				client:     fake.NewClientBuilder().WithScheme(createScheme(t)).Build(),
+				repository: tt.repository,
+			}
+			err := r.preRunE(&cobra.Command{}, tt.args)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "dev.my-pkg.v1", r.sourceName)
+		})
+	}
+}
+
+func TestRunE(t *testing.T) {
+	ns := "ns"
+	tests := []struct {
+		name          string
+		source        *porchv1alpha2.PackageRevision
+		repository    string
+		workspace     string
+		wantErr       string
+		wantName      string
+		wantWorkspace string
+	}{
+		{
+			name:          "promote keeps source workspace by default",
+			source:        sourcePackageRevision(porchv1alpha2.PackageRevisionLifecyclePublished),
+			repository:    "staging",
+			wantName:      "staging.my-pkg.v1",
+			wantWorkspace: "v1",
+		},
+		{
+			name:          "promote with explicit workspace",
+			source:        sourcePackageRevision(porchv1alpha2.PackageRevisionLifecyclePublished),
+			repository:    "prod",
+			workspace:     "release-1",
+			wantName:      "prod.my-pkg.release-1",
+			wantWorkspace: "release-1",
+		},
+		{
+			name:       "source not published",
+			source:     sourcePackageRevision(porchv1alpha2.PackageRevisionLifecycleProposed),
+			repository: "staging",
+			wantErr:    "only published package revisions can be promoted",
+		},
+		{
+			name:       "same repository",
+			source:     sourcePackageRevision(porchv1alpha2.PackageRevisionLifecyclePublished),
+			repository: "dev",
+			wantErr:    "into its own repository",
+		},
+		{
+			name:       "source not found",
+			repository: "staging",
+			wantErr:    "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(createScheme(t))
+			if tt.source != nil {
+				builder = builder.WithObjects(tt.source)
+			}
+			c := builder.Build()
+
+			r := &runner{
+				ctx:        t.Context(),
+				cfg:        &genericclioptions.ConfigFlags{Namespace: &ns},
+				client:     c,
+				sourceName: "dev.my-pkg.v1",
+				repository: tt.repository,
+				workspace:  tt.workspace,
+			}
+
+			output := &bytes.Buffer{}
+			cmd := &cobra.Command{}
+			cmd.SetOut(output)
+
+			err := r.runE(cmd, nil)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName+" created\n", output.String())
+
+			var created porchv1alpha2.PackageRevision
+			require.NoError(t, c.Get(t.Context(), types.NamespacedName{Namespace: ns, Name: tt.wantName}, &created))
+			assert.Equal(t, tt.repository, created.Spec.RepositoryName)
+			assert.Equal(t, "my-pkg", created.Spec.PackageName)
+			assert.Equal(t, tt.wantWorkspace, created.Spec.WorkspaceName)
+			assert.Equal(t, porchv1alpha2.PackageRevisionLifecycleDraft, created.Spec.Lifecycle)
+			require.NotNil(t, created.Spec.Source)
+			assert.Equal(t, &porchv1alpha2.PackageRevisionRef{Name: "dev.my-pkg.v1"}, created.Spec.Source.PromoteFrom)
+		})
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	ns := "default"
+	flags := genericclioptions.NewConfigFlags(true)
+	flags.Namespace = &ns
+	cmd := NewCommand(context.Background(), flags)
+	require.NotNil(t, cmd)
+	assert.NotNil(t, cmd.Flags().Lookup("repository"))
+	assert.NotNil(t, cmd.Flags().Lookup("workspace"))
+}
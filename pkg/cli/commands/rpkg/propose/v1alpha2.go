@@ -22,6 +22,7 @@ import (
 	"github.com/kptdev/kpt/pkg/lib/errors"
 	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
 	cliutils "github.com/kptdev/porch/internal/cliutils"
+	rpkgutil "github.com/kptdev/porch/pkg/cli/commands/rpkg/util"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/util/retry"
@@ -53,6 +54,9 @@ func (r *v1alpha2Runner) preRunE(_ *cobra.Command, _ []string) error {
 
 func (r *v1alpha2Runner) runE(cmd *cobra.Command, args []string) error {
 	const op errors.Op = command + ".runE"
+	if err := rpkgutil.CheckNoSelectorV1Alpha2(cmd); err != nil {
+		return errors.E(op, err)
+	}
 	r.cmd = cmd
 	var messages []string
 	namespace := *r.cfg.Namespace
@@ -49,6 +49,7 @@ func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner
 	r.Command = c
 
 	// Create flags
+	c.Flags().StringVar(&r.selector, "selector", "", rpkgutil.SelectorFlagUsage)
 
 	return r
 }
@@ -62,6 +63,8 @@ func NewCommand(ctx context.Context, rcg *genericclioptions.ConfigFlags) *cobra.
 
 type runner struct {
 	rpkgutil.Runner
+
+	selector string
 }
 
 func (r *runner) proposeDeleteAction(ctx context.Context, client client.Client, pr *porchapi.PackageRevision) (string, error) {
@@ -81,6 +84,10 @@ func (r *runner) proposeDeleteAction(ctx context.Context, client client.Client,
 }
 
 func (r *runner) runE(_ *cobra.Command, args []string) error {
+	if r.selector != "" {
+		return rpkgutil.RunBatch(r.Ctx, r.Client, r.Command, *r.Cfg.Namespace, args, r.selector,
+			porchapi.BatchOperationProposeDelete, command, "proposed for deletion")
+	}
 	return rpkgutil.RunForEachPackage(r.Ctx, r.Client, r.Command, *r.Cfg.Namespace, args,
 		rpkgutil.RunForEachOpts{CmdName: command, WithRetry: true, CheckReadiness: false},
 		r.proposeDeleteAction)
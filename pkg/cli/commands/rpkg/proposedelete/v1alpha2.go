@@ -23,6 +23,7 @@ import (
 	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
 	cliutils "github.com/kptdev/porch/internal/cliutils"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/docs"
+	rpkgutil "github.com/kptdev/porch/pkg/cli/commands/rpkg/util"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/util/retry"
@@ -66,6 +67,9 @@ func (r *v1alpha2Runner) preRunE(_ *cobra.Command, _ []string) error {
 
 func (r *v1alpha2Runner) runE(_ *cobra.Command, args []string) error {
 	const op errors.Op = command + ".runE"
+	if err := rpkgutil.CheckNoSelectorV1Alpha2(r.Command); err != nil {
+		return errors.E(op, err)
+	}
 	var messages []string
 	namespace := *r.cfg.Namespace
 
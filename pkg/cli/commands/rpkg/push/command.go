@@ -19,15 +19,9 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"io/fs"
-	"os"
 	"path"
-	"path/filepath"
-	"strings"
-	"unicode/utf8"
 
 	"github.com/kptdev/kpt/pkg/lib/errors"
-	"github.com/kptdev/kpt/pkg/lib/runneroptions"
 	"github.com/kptdev/kpt/pkg/printer"
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
 	cliutils "github.com/kptdev/porch/internal/cliutils"
@@ -111,7 +105,7 @@ func (r *runner) runE(cmd *cobra.Command, args []string) error {
 	var err error
 
 	if len(args) > 1 && args[1] != "-" {
-		resources, err = readFromDir(args[1])
+		resources, err = rpkgutil.ReadFromDir(args[1], nil)
 	} else if len(args) > 1 && args[1] == "-" {
 		resources, err = readFromReader(cmd.InOrStdin())
 	} else {
@@ -154,18 +148,7 @@ func (r *runner) runE(cmd *cobra.Command, args []string) error {
 		r.printer.Printf("Package is updated, but failed to render the package.\n")
 		r.printer.Printf("Error: %s\n", rs.Err)
 	}
-	if len(rs.Result.Items) > 0 {
-		for _, result := range rs.Result.Items {
-			r.printer.Printf("[RUNNING] %q \n", result.Image)
-			printOpt := printer.NewOpt()
-			if result.ExitCode != 0 {
-				r.printer.OptPrintf(printOpt, "[FAIL] %q\n", result.Image)
-			} else {
-				r.printer.OptPrintf(printOpt, "[PASS] %q\n", result.Image)
-			}
-			r.printFnResult(result, printOpt)
-		}
-	}
+	rpkgutil.PrintRenderResults(r.printer, rs.Result.Items, false)
 	fmt.Fprintf(cmd.OutOrStdout(), "%s pushed\n", packageName)
 	return nil
 }
@@ -173,91 +156,7 @@ func (r *runner) runE(cmd *cobra.Command, args []string) error {
 // printFnResult prints given function result in a user friendly
 // format on kpt CLI.
 func (r *runner) printFnResult(fnResult *porchapi.Result, opt *printer.Options) {
-	if len(fnResult.Results) > 0 {
-		// function returned structured results
-		var lines []string
-		for _, item := range fnResult.Results {
-			lines = append(lines, str(item))
-		}
-
-		ri := &runneroptions.SingleLineFormatter{
-			Title:     "[Results]",
-			Lines:     lines,
-			UseQuote:  false,
-			Separator: ", ",
-		}
-		r.printer.OptPrintf(opt, "%s", ri.String())
-	}
-}
-
-// String provides a human-readable message for the result item
-func str(i porchapi.ResultItem) string {
-	identifier := i.ResourceRef
-	var idStringList []string
-	if identifier != nil {
-		if identifier.APIVersion != "" {
-			idStringList = append(idStringList, identifier.APIVersion)
-		}
-		if identifier.Kind != "" {
-			idStringList = append(idStringList, identifier.Kind)
-		}
-		if identifier.Namespace != "" {
-			idStringList = append(idStringList, identifier.Namespace)
-		}
-		if identifier.Name != "" {
-			idStringList = append(idStringList, identifier.Name)
-		}
-	}
-	formatString := "[%s]"
-	severity := i.Severity
-	// We default Severity to Info when converting a result to a message.
-	if i.Severity == "" {
-		severity = "info"
-	}
-	list := []interface{}{severity}
-	if len(idStringList) > 0 {
-		formatString += " %s"
-		list = append(list, strings.Join(idStringList, "/"))
-	}
-	if i.Field != nil {
-		formatString += " %s"
-		list = append(list, i.Field.Path)
-	}
-	formatString += ": %s"
-	list = append(list, i.Message)
-	return fmt.Sprintf(formatString, list...)
-}
-
-func readFromDir(dir string) (map[string]string, error) {
-	resources := map[string]string{}
-	if err := filepath.Walk(dir, func(path string, info fs.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if !info.Mode().IsRegular() {
-			return nil
-		}
-		rel, err := filepath.Rel(dir, path)
-		if err != nil {
-			return err
-		}
-		contents, err := os.ReadFile(path)
-		if err != nil {
-			return err
-		}
-		if !utf8.Valid(contents) {
-			// Since PackageRevisionResources' spec.Resources is a map of strings, during JSON serialization all file contents
-			// will be converted to valid UTF-8 by changing invalid bytes to the Unicode replacement character.
-			// This of course corrupts the contents of binary files.
-			// Return an early error here to prevent pushing corrupt content.
-			return fmt.Errorf("file %s is not a valid UTF-8 text file: current porch API doesn't support binary files", path)
-		}
-		resources[filepath.ToSlash(rel)] = string(contents)
-		return nil
-	}); err != nil {
-		return nil, err
-	}
-	return resources, nil
+	rpkgutil.PrintFnResult(r.printer, fnResult, opt)
 }
 
 func readFromReader(in io.Reader) (map[string]string, error) {
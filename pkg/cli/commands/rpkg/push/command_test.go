@@ -18,10 +18,12 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	kptfilev1 "github.com/kptdev/kpt/api/kptfile/v1"
 	"github.com/kptdev/kpt/pkg/printer"
 	fakeprint "github.com/kptdev/kpt/pkg/printer/fake"
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
@@ -29,6 +31,7 @@ import (
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -136,6 +139,67 @@ func TestCmd(t *testing.T) {
 	}
 }
 
+func TestPushDirectoryWithHiddenFiles(t *testing.T) {
+	pkgRevName := "test-fjdos9u2nfe2f32"
+	ns := "ns"
+	scheme, err := rpkgutil.CreateScheme()
+	if err != nil {
+		t.Fatalf("error creating scheme: %v", err)
+	}
+
+	// A pulled directory with the revision metadata and other hidden files
+	dir := t.TempDir()
+	metadata, err := os.ReadFile(filepath.Join("testdata", pkgRevName, kptfilev1.RevisionMetaDataFileName))
+	if err != nil {
+		t.Fatalf("error reading revision metadata: %v", err)
+	}
+	for name, contents := range map[string]string{
+		kptfilev1.RevisionMetaDataFileName: string(metadata),
+		"deployment.yaml":                  "kind: Deployment\n",
+		".krmignore":                       "tmp/\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0600); err != nil {
+			t.Fatalf("error writing %s: %v", name, err)
+		}
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&porchapi.PackageRevisionResources{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pkgRevName,
+				Namespace: ns,
+			},
+		}).
+		Build()
+	ctx := fakeprint.CtxWithPrinter(&bytes.Buffer{}, &bytes.Buffer{})
+	r := &runner{
+		Runner: rpkgutil.Runner{
+			Ctx:    ctx,
+			Cfg:    &genericclioptions.ConfigFlags{Namespace: &ns},
+			Client: c,
+		},
+		printer: printer.FromContextOrDie(ctx),
+	}
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := r.runE(cmd, []string{pkgRevName, dir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var pushed porchapi.PackageRevisionResources
+	if err := c.Get(ctx, client.ObjectKey{Namespace: ns, Name: pkgRevName}, &pushed); err != nil {
+		t.Fatalf("error getting resources: %v", err)
+	}
+	want := map[string]string{
+		"deployment.yaml": "kind: Deployment\n",
+		".krmignore":      "tmp/\n",
+	}
+	if diff := cmp.Diff(want, pushed.Spec.Resources); diff != "" {
+		t.Errorf("unexpected pushed resources (-want, +got): %s", diff)
+	}
+}
+
 func TestPrintFnResult(t *testing.T) {
 	var buf bytes.Buffer
 	ctx := fakeprint.CtxWithPrinter(&buf, &buf)
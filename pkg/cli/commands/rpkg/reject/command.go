@@ -59,17 +59,21 @@ func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner
 	}
 	r.Command = c
 
+	c.Flags().StringVar(&r.selector, "selector", "", rpkgutil.SelectorFlagUsage)
+
 	return r
 }
 
 type runner struct {
 	rpkgutil.Runner
+
+	selector string
 }
 
 func (r *runner) preRunE(cmd *cobra.Command, args []string) error {
 	const op errors.Op = command + ".preRunE"
 
-	if len(args) < 1 {
+	if len(args) < 1 && r.selector == "" {
 		return errors.E(op, "PACKAGE is a required positional argument")
 	}
 
@@ -99,6 +103,10 @@ func rejectAction(ctx context.Context, client client.Client, pr *porchapi.Packag
 }
 
 func (r *runner) runE(_ *cobra.Command, args []string) error {
+	if r.selector != "" {
+		return rpkgutil.RunBatch(r.Ctx, r.Client, r.Command, *r.Cfg.Namespace, args, r.selector,
+			porchapi.BatchOperationReject, command, "rejected")
+	}
 	return rpkgutil.RunForEachPackage(r.Ctx, r.Client, r.Command, *r.Cfg.Namespace, args,
 		rpkgutil.RunForEachOpts{CmdName: command, WithRetry: true, CheckReadiness: false},
 		rejectAction)
@@ -0,0 +1,250 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolve
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	"github.com/kptdev/kpt/pkg/printer"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	cliutils "github.com/kptdev/porch/internal/cliutils"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/docs"
+	rpkgutil "github.com/kptdev/porch/pkg/cli/commands/rpkg/util"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	command = "cmdrpkgresolve"
+)
+
+func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner {
+	r := &runner{
+		Runner: rpkgutil.Runner{Ctx: ctx, Cfg: rcg},
+	}
+	c := &cobra.Command{
+		Use:     "resolve PACKAGE_REVISION [FILE]",
+		Short:   docs.ResolveShort,
+		Long:    docs.ResolveShort + "\n" + docs.ResolveLong,
+		Example: docs.ResolveExamples,
+		PreRunE: r.preRunE,
+		RunE:    r.runE,
+		Hidden:  cliutils.HidePorchCommands,
+	}
+	r.Command = c
+
+	c.Flags().StringVar(&r.take, "take", "",
+		"Side to take for the selected conflicts: ours (the local version) or theirs (the upstream version). If not set, the conflicts are listed.")
+	c.Flags().StringVar(&r.resource, "resource", "", "Select the conflicts of the resource with this KIND/NAME.")
+	c.Flags().StringVar(&r.field, "field", "", "Select the conflicts on this field path, and on the fields it contains.")
+	return r
+}
+
+// NewCommand returns the cobra command for `rpkg resolve`, which lists and
+// resolves the merge conflicts left in a draft package revision by an upgrade
+// with the manual-merge strategy.
+func NewCommand(ctx context.Context, rcg *genericclioptions.ConfigFlags) *cobra.Command {
+	return newRunner(ctx, rcg).Command
+}
+
+type runner struct {
+	rpkgutil.Runner
+	printer printer.Printer
+
+	take     string
+	resource string
+	field    string
+
+	name     string
+	selector mergeconflict.Selector
+}
+
+func (r *runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = command + ".preRunE"
+	if err := r.validate(args); err != nil {
+		return errors.E(op, err)
+	}
+
+	config, err := r.Cfg.ToRESTConfig()
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	scheme, err := rpkgutil.CreateScheme()
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	r.Client = c
+	r.printer = printer.FromContextOrDie(r.Ctx)
+	return nil
+}
+
+func (r *runner) validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("PACKAGE_REVISION is a required positional argument")
+	}
+	if len(args) > 2 {
+		return fmt.Errorf("too many arguments; only PACKAGE_REVISION and FILE are accepted")
+	}
+	r.name = args[0]
+
+	r.selector = mergeconflict.Selector{Field: r.field}
+	if len(args) == 2 {
+		r.selector.File = args[1]
+	}
+	if r.resource != "" {
+		kind, name, found := strings.Cut(r.resource, "/")
+		if !found || kind == "" || name == "" {
+			return fmt.Errorf("--resource must be KIND/NAME, got %q", r.resource)
+		}
+		r.selector.Kind, r.selector.Name = kind, name
+	}
+
+	switch side := mergeconflict.Side(r.take); side {
+	case "", mergeconflict.Ours, mergeconflict.Theirs:
+	default:
+		return fmt.Errorf("--take must be %q or %q", mergeconflict.Ours, mergeconflict.Theirs)
+	}
+	return nil
+}
+
+func (r *runner) runE(cmd *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".runE"
+
+	var pr porchapi.PackageRevision
+	if err := r.Client.Get(r.Ctx, r.key(), &pr); err != nil {
+		return errors.E(op, err)
+	}
+	if pr.Spec.Lifecycle != porchapi.PackageRevisionLifecycleDraft {
+		return errors.E(op, fmt.Errorf("package revision %s is %s; only the merge conflicts of draft package revisions can be resolved",
+			r.name, pr.Spec.Lifecycle))
+	}
+
+	if r.take == "" {
+		resources, err := r.getResources()
+		if err != nil {
+			return errors.E(op, err)
+		}
+		if err := r.printConflicts(cmd.OutOrStdout(), resources.Spec.Resources, r.selector); err != nil {
+			return errors.E(op, err)
+		}
+		return nil
+	}
+
+	var (
+		resources *porchapi.PackageRevisionResources
+		resolved  int
+	)
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		if resources, err = r.getResources(); err != nil {
+			return err
+		}
+		if resolved, err = mergeconflict.Resolve(resources.Spec.Resources, r.selector, mergeconflict.Side(r.take)); err != nil || resolved == 0 {
+			return err
+		}
+		if err := mergeconflict.Refresh(resources.Spec.Resources, false); err != nil {
+			return err
+		}
+		return r.Client.Update(r.Ctx, resources)
+	}); err != nil {
+		return errors.E(op, err)
+	}
+	if resolved == 0 {
+		return errors.E(op, fmt.Errorf("no unresolved merge conflicts in %s match the selection", r.name))
+	}
+
+	if rs := resources.Status.RenderStatus; rs.Err != "" {
+		r.printer.Printf("Package is updated, but failed to render the package.\n")
+		r.printer.Printf("Error: %s\n", rs.Err)
+	}
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "%s: resolved %d merge conflicts taking %s\n", r.name, resolved, r.take)
+	if err := r.printConflicts(out, resources.Spec.Resources, mergeconflict.Selector{}); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// printConflicts prints the selected unresolved conflicts in the resources of the package
+// revision.
+func (r *runner) printConflicts(w io.Writer, resources map[string]string, selector mergeconflict.Selector) error {
+	items, err := mergeconflict.List(resources)
+	if err != nil {
+		return err
+	}
+	var selected []mergeconflict.Item
+	for _, item := range items {
+		if selector.Matches(item) {
+			selected = append(selected, item)
+		}
+	}
+	if len(selected) == 0 {
+		if len(items) == 0 {
+			_, err = fmt.Fprintf(w, "%s has no unresolved merge conflicts\n", r.name)
+		} else {
+			_, err = fmt.Fprintf(w, "%s has %d unresolved merge conflicts, none of them selected\n", r.name, len(items))
+		}
+		return err
+	}
+
+	tw := printers.GetNewTabWriter(w)
+	if _, err := fmt.Fprintln(tw, "FILE\tKIND\tNAME\tFIELD"); err != nil {
+		return err
+	}
+	for _, item := range selected {
+		name := item.Name
+		if item.Namespace != "" {
+			name = item.Namespace + "/" + name
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", item.File, item.Kind, name, item.Field); err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "\n%s: %d unresolved merge conflicts\n", r.name, len(selected))
+	return err
+}
+
+func (r *runner) getResources() (*porchapi.PackageRevisionResources, error) {
+	var resources porchapi.PackageRevisionResources
+	if err := r.Client.Get(r.Ctx, r.key(), &resources); err != nil {
+		return nil, err
+	}
+	return &resources, nil
+}
+
+func (r *runner) key() client.ObjectKey {
+	return client.ObjectKey{
+		Namespace: *r.Cfg.Namespace,
+		Name:      r.name,
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolve
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kptdev/kpt/pkg/printer"
+	fakeprint "github.com/kptdev/kpt/pkg/printer/fake"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	rpkgutil "github.com/kptdev/porch/pkg/cli/commands/rpkg/util"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	ns         = "ns"
+	pkgRevName = "repo.pkg.ws"
+)
+
+const kptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+status:
+  conditions:
+  - type: MergeConflicts
+    status: "True"
+    reason: Unresolved
+    message: '2 unresolved merge conflicts'
+`
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  annotations:
+    porch.kpt.dev/merge-conflicts: '[{"field":"spec.replicas","ours":"3\n","theirs":"5\n"}]'
+spec:
+  replicas: 3
+`
+
+func conflictedResources() map[string]string {
+	return map[string]string{
+		"Kptfile":     kptfile,
+		"deploy.yaml": deployment,
+		"README.md":   "<<<<<<< local\nours\n=======\ntheirs\n>>>>>>> upstream\n",
+	}
+}
+
+func newTestRunner(t *testing.T, lifecycle porchapi.PackageRevisionLifecycle, resources map[string]string) (*runner, *cobra.Command, *bytes.Buffer) {
+	t.Helper()
+	scheme, err := rpkgutil.CreateScheme()
+	require.NoError(t, err)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&porchapi.PackageRevision{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: pkgRevName},
+				Spec:       porchapi.PackageRevisionSpec{Lifecycle: lifecycle},
+			},
+			&porchapi.PackageRevisionResources{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: pkgRevName},
+				Spec:       porchapi.PackageRevisionResourcesSpec{Resources: resources},
+			},
+		).
+		Build()
+
+	output := &bytes.Buffer{}
+	ctx := fakeprint.CtxWithPrinter(output, output)
+	r := &runner{
+		Runner:  rpkgutil.NewTestRunner(ns, c, nil),
+		printer: printer.FromContextOrDie(ctx),
+	}
+	r.Ctx = ctx
+	cmd := &cobra.Command{}
+	cmd.SetOut(output)
+	return r, cmd, output
+}
+
+func run(t *testing.T, r *runner, cmd *cobra.Command, args ...string) error {
+	t.Helper()
+	if err := r.validate(args); err != nil {
+		return err
+	}
+	return r.runE(cmd, args)
+}
+
+func remoteResources(t *testing.T, r *runner) map[string]string {
+	t.Helper()
+	resources, err := r.getResources()
+	require.NoError(t, err)
+	return resources.Spec.Resources
+}
+
+func TestValidate(t *testing.T) {
+	testCases := map[string]struct {
+		args     []string
+		take     string
+		resource string
+		want     mergeconflict.Selector
+		wantErr  string
+	}{
+		"no arguments": {
+			wantErr: "PACKAGE_REVISION is a required positional argument",
+		},
+		"too many arguments": {
+			args:    []string{pkgRevName, "a.yaml", "b.yaml"},
+			wantErr: "too many arguments",
+		},
+		"invalid side": {
+			args:    []string{pkgRevName},
+			take:    "both",
+			wantErr: "--take must be",
+		},
+		"invalid resource": {
+			args:     []string{pkgRevName},
+			resource: "app",
+			wantErr:  "--resource must be KIND/NAME",
+		},
+		"file and resource": {
+			args:     []string{pkgRevName, "deploy.yaml"},
+			take:     "theirs",
+			resource: "Deployment/app",
+			want:     mergeconflict.Selector{File: "deploy.yaml", Kind: "Deployment", Name: "app"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := &runner{take: tc.take, resource: tc.resource}
+			err := r.validate(tc.args)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, r.selector)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	r, cmd, output := newTestRunner(t, porchapi.PackageRevisionLifecycleDraft, conflictedResources())
+
+	require.NoError(t, run(t, r, cmd, pkgRevName))
+	out := output.String()
+	assert.Contains(t, out, "FILE")
+	assert.Contains(t, out, "README.md")
+	assert.Regexp(t, `deploy.yaml\s+Deployment\s+app\s+spec.replicas`, out)
+	assert.Contains(t, out, "2 unresolved merge conflicts")
+	assert.Equal(t, conflictedResources(), remoteResources(t, r), "listing must not change the package")
+}
+
+func TestResolve(t *testing.T) {
+	r, cmd, output := newTestRunner(t, porchapi.PackageRevisionLifecycleDraft, conflictedResources())
+	r.take = "theirs"
+	r.resource = "Deployment/app"
+
+	require.NoError(t, run(t, r, cmd, pkgRevName))
+	resources := remoteResources(t, r)
+	assert.Contains(t, resources["deploy.yaml"], "replicas: 5")
+	assert.NotContains(t, resources["deploy.yaml"], mergeconflict.Annotation)
+	assert.Equal(t, conflictedResources()["README.md"], resources["README.md"])
+	assert.Contains(t, resources["Kptfile"], "1 unresolved merge conflicts: README.md")
+	assert.Contains(t, output.String(), "resolved 1 merge conflicts taking theirs")
+
+	output.Reset()
+	r.take, r.resource = "ours", ""
+	require.NoError(t, run(t, r, cmd, pkgRevName, "README.md"))
+	resources = remoteResources(t, r)
+	assert.Equal(t, "ours\n", resources["README.md"])
+	assert.Contains(t, resources["Kptfile"], "reason: Resolved")
+	assert.Contains(t, output.String(), "has no unresolved merge conflicts")
+}
+
+func TestResolveNoMatch(t *testing.T) {
+	r, cmd, _ := newTestRunner(t, porchapi.PackageRevisionLifecycleDraft, conflictedResources())
+	r.take = "ours"
+	r.resource = "Service/app"
+
+	err := run(t, r, cmd, pkgRevName)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no unresolved merge conflicts")
+	assert.Equal(t, conflictedResources(), remoteResources(t, r))
+}
+
+func TestResolveNotDraft(t *testing.T) {
+	r, cmd, _ := newTestRunner(t, porchapi.PackageRevisionLifecycleProposed, conflictedResources())
+	r.take = "ours"
+
+	err := run(t, r, cmd, pkgRevName)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "only the merge conflicts of draft package revisions can be resolved"))
+}
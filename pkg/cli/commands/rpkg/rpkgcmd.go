@@ -24,14 +24,20 @@ import (
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/clone"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/copy"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/del"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/deps"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/dev"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/docs"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/fn"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/get"
 	initialization "github.com/kptdev/porch/pkg/cli/commands/rpkg/init"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/promote"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/propose"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/proposedelete"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/pull"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/push"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/reject"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/resolve"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/tree"
 	"github.com/kptdev/porch/pkg/cli/commands/rpkg/upgrade"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -84,7 +90,13 @@ func NewCommand(ctx context.Context, version string) *cobra.Command {
 		del.NewCommand(ctx, kubeflags),
 		copy.NewCommand(ctx, kubeflags),
 		upgrade.NewCommand(ctx, kubeflags),
+		resolve.NewCommand(ctx, kubeflags),
 		proposedelete.NewCommand(ctx, kubeflags),
+		promote.NewCommand(ctx, kubeflags),
+		deps.NewCommand(ctx, kubeflags),
+		tree.NewCommand(ctx, kubeflags),
+		fn.NewCommand(ctx, kubeflags),
+		dev.NewCommand(ctx, kubeflags),
 	)
 
 	return rpkg
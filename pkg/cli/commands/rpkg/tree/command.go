@@ -0,0 +1,345 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	cliutils "github.com/kptdev/porch/internal/cliutils"
+	"github.com/kptdev/porch/pkg/cli/commands/rpkg/docs"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	command = "cmdrpkgtree"
+)
+
+func NewCommand(ctx context.Context, rcg *genericclioptions.ConfigFlags) *cobra.Command {
+	v1 := newRunner(ctx, rcg)
+	v2 := newV1Alpha2Runner(ctx, rcg)
+	cliutils.WrapVersionDispatch(v1.Command, v2.preRunE, v2.runE)
+	return v1.Command
+}
+
+func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner {
+	r := &runner{
+		ctx: ctx,
+		cfg: rcg,
+	}
+	r.Command = &cobra.Command{
+		Use:     "tree [REPOSITORY]",
+		Short:   docs.TreeShort,
+		Long:    docs.TreeShort + "\n" + docs.TreeLong,
+		Example: docs.TreeExamples,
+		PreRunE: r.preRunE,
+		RunE:    r.runE,
+		Hidden:  cliutils.HidePorchCommands,
+	}
+	return r
+}
+
+type runner struct {
+	ctx     context.Context
+	cfg     *genericclioptions.ConfigFlags
+	client  client.Client
+	Command *cobra.Command
+
+	repository string
+}
+
+func (r *runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = command + ".preRunE"
+	if r.client == nil {
+		c, err := cliutils.CreateClientWithFlags(r.cfg)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		r.client = c
+	}
+
+	repository, err := validateArgs(args)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.repository = repository
+	return nil
+}
+
+func (r *runner) runE(cmd *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".runE"
+
+	var list porchapi.PackageRevisionList
+	if err := r.client.List(r.ctx, &list, listOptions(*r.cfg.Namespace, r.repository)...); err != nil {
+		return errors.E(op, err)
+	}
+
+	var candidates []treePackage
+	for i := range list.Items {
+		pr := &list.Items[i]
+		p := treePackage{
+			repository: pr.Spec.RepositoryName,
+			path:       pr.Spec.PackageName,
+			name:       pr.Name,
+			lifecycle:  string(pr.Spec.Lifecycle),
+			published:  pr.IsPublished(),
+			revision:   pr.Spec.Revision,
+			created:    pr.CreationTimestamp,
+			upstream:   describeUpstream(lockOf(pr.Status.UpstreamLock)),
+		}
+		for _, s := range pr.Status.Subpackages {
+			p.subpackages = append(p.subpackages, treeSubpackage{
+				path:     s.Path,
+				parent:   s.Parent,
+				upstream: describeUpstream(lockOf(s.UpstreamLock)),
+			})
+		}
+		candidates = append(candidates, p)
+	}
+
+	packages := selectRevisions(candidates)
+	printTree(cmd.OutOrStdout(), packages)
+	return nil
+}
+
+func validateArgs(args []string) (string, error) {
+	if len(args) > 1 {
+		return "", fmt.Errorf("too many arguments; REPOSITORY is the only accepted positional argument")
+	}
+	if len(args) == 1 {
+		return args[0], nil
+	}
+	return "", nil
+}
+
+// listOptions lists the package revisions of the namespace, or only those of the
+// repository if one is given.
+func listOptions(namespace, repository string) []client.ListOption {
+	opts := []client.ListOption{client.InNamespace(namespace)}
+	if repository != "" {
+		opts = append(opts, client.MatchingFields{"spec.repository": repository})
+	}
+	return opts
+}
+
+// treePackage is the package revision shown for a package in the tree.
+type treePackage struct {
+	repository  string
+	path        string
+	name        string
+	lifecycle   string
+	published   bool
+	revision    int
+	created     metav1.Time
+	upstream    string
+	subpackages []treeSubpackage
+}
+
+// treeSubpackage is an independent subpackage embedded in a package revision.
+type treeSubpackage struct {
+	path     string
+	parent   string
+	upstream string
+}
+
+// gitLock holds the fields of an upstream lock that are shown in the tree. It is
+// shared by the v1alpha1 and v1alpha2 locators, which have the same shape.
+type gitLock struct {
+	repo      string
+	directory string
+	ref       string
+}
+
+func lockOf(lock *porchapi.Locator) *gitLock {
+	if lock == nil || lock.Git == nil {
+		return nil
+	}
+	return &gitLock{repo: lock.Git.Repo, directory: lock.Git.Directory, ref: lock.Git.Ref}
+}
+
+// describeUpstream returns the upstream of a package as repo/directory@ref, or an
+// empty string if the package has no upstream.
+func describeUpstream(lock *gitLock) string {
+	if lock == nil || lock.repo == "" {
+		return ""
+	}
+	upstream := strings.TrimSuffix(lock.repo, "/")
+	if dir := strings.Trim(lock.directory, "/"); dir != "" {
+		upstream += "/" + dir
+	}
+	if lock.ref != "" {
+		upstream += "@" + lock.ref
+	}
+	return upstream
+}
+
+// selectRevisions picks the package revision to show for each package: the latest
+// published revision, or the most recently created draft or proposed revision if the
+// package has never been published. Placeholder revisions that track the branch of
+// the repository are skipped. The result is ordered by repository and package path.
+func selectRevisions(candidates []treePackage) []treePackage {
+	selected := map[string]treePackage{}
+	for _, c := range candidates {
+		if c.revision == -1 {
+			continue
+		}
+		key := c.repository + "/" + c.path
+		cur, ok := selected[key]
+		if !ok || newer(c, cur) {
+			selected[key] = c
+		}
+	}
+
+	result := make([]treePackage, 0, len(selected))
+	for _, p := range selected {
+		result = append(result, p)
+	}
+	slices.SortFunc(result, func(a, b treePackage) int {
+		if c := strings.Compare(a.repository, b.repository); c != 0 {
+			return c
+		}
+		return strings.Compare(a.path, b.path)
+	})
+	return result
+}
+
+// newer returns true if package revision a should be shown instead of b.
+func newer(a, b treePackage) bool {
+	switch {
+	case a.published != b.published:
+		return a.published
+	case a.published:
+		return a.revision > b.revision
+	case !a.created.Equal(&b.created):
+		return b.created.Before(&a.created)
+	default:
+		return a.name < b.name
+	}
+}
+
+// node is a directory in the tree of a repository. A directory that holds a package
+// has the package revision shown for it; other directories only group packages.
+type node struct {
+	name     string
+	pkg      *treePackage
+	children []*node
+}
+
+func (n *node) child(name string) *node {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	c := &node{name: name}
+	n.children = append(n.children, c)
+	return c
+}
+
+// printTree prints the packages of each repository as a directory hierarchy, with the
+// independent subpackages embedded in each package nested below it.
+func printTree(out io.Writer, packages []treePackage) {
+	var repositories []*node
+	for i := range packages {
+		p := &packages[i]
+		if len(repositories) == 0 || repositories[len(repositories)-1].name != p.repository {
+			repositories = append(repositories, &node{name: p.repository})
+		}
+		n := repositories[len(repositories)-1]
+		for _, segment := range strings.Split(p.path, "/") {
+			n = n.child(segment)
+		}
+		n.pkg = p
+	}
+
+	for _, repo := range repositories {
+		fmt.Fprintln(out, repo.name)
+		printChildren(out, repo, "")
+	}
+}
+
+func printChildren(out io.Writer, n *node, prefix string) {
+	for i, c := range n.children {
+		last := i == len(n.children)-1
+		fmt.Fprintln(out, prefix+branch(last)+describePackage(c))
+		childPrefix := prefix + indent(last)
+		if c.pkg != nil {
+			printSubpackages(out, c.pkg.subpackages, "", childPrefix, len(c.children) == 0)
+		}
+		printChildren(out, c, childPrefix)
+	}
+}
+
+// printSubpackages prints the subpackages whose closest enclosing subpackage is parent.
+// If more package directories follow, the branches of the last subpackage stay open.
+func printSubpackages(out io.Writer, subpackages []treeSubpackage, parent, prefix string, lastInDir bool) {
+	var kids []treeSubpackage
+	for _, s := range subpackages {
+		if s.parent == parent {
+			kids = append(kids, s)
+		}
+	}
+	for i, s := range kids {
+		last := i == len(kids)-1 && lastInDir
+		label := strings.TrimPrefix(s.path, parent+"/") + " (subpackage)"
+		if s.upstream != "" {
+			label += " [upstream: " + s.upstream + "]"
+		}
+		fmt.Fprintln(out, prefix+branch(last)+label)
+		printSubpackages(out, subpackages, s.path, prefix+indent(last), true)
+	}
+}
+
+func describePackage(n *node) string {
+	if n.pkg == nil {
+		return n.name + "/"
+	}
+	details := []string{n.pkg.lifecycle}
+	if n.pkg.published && n.pkg.revision > 0 {
+		details = append(details, fmt.Sprintf("revision %d", n.pkg.revision))
+	}
+	details = slices.DeleteFunc(details, func(s string) bool { return s == "" })
+
+	label := n.name
+	if len(details) > 0 {
+		label += " (" + strings.Join(details, ", ") + ")"
+	}
+	if n.pkg.upstream != "" {
+		label += " [upstream: " + n.pkg.upstream + "]"
+	}
+	return label
+}
+
+func branch(last bool) string {
+	if last {
+		return "└── "
+	}
+	return "├── "
+}
+
+func indent(last bool) string {
+	if last {
+		return "    "
+	}
+	return "│   "
+}
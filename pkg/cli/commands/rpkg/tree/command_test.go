@@ -0,0 +1,164 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateArgs(t *testing.T) {
+	repository, err := validateArgs(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, repository)
+
+	repository, err = validateArgs([]string{"blueprints"})
+	assert.NoError(t, err)
+	assert.Equal(t, "blueprints", repository)
+
+	_, err = validateArgs([]string{"a", "b"})
+	assert.ErrorContains(t, err, "too many arguments")
+}
+
+func TestDescribeUpstream(t *testing.T) {
+	assert.Empty(t, describeUpstream(nil))
+	assert.Empty(t, describeUpstream(&gitLock{}))
+	assert.Equal(t, "https://github.com/example/catalog.git/web@v3",
+		describeUpstream(&gitLock{repo: "https://github.com/example/catalog.git", directory: "/web/", ref: "v3"}))
+	assert.Equal(t, "https://github.com/example/catalog.git",
+		describeUpstream(&gitLock{repo: "https://github.com/example/catalog.git/"}))
+}
+
+func TestSelectRevisions(t *testing.T) {
+	now := time.Now()
+	candidates := []treePackage{
+		{repository: "blueprints", path: "base", name: "blueprints.base.main", published: true, revision: -1},
+		{repository: "blueprints", path: "base", name: "blueprints.base.v1", published: true, revision: 1},
+		{repository: "blueprints", path: "base", name: "blueprints.base.v2", published: true, revision: 2},
+		{repository: "blueprints", path: "base", name: "blueprints.base.ws", lifecycle: "Draft"},
+		{repository: "blueprints", path: "app", name: "blueprints.app.old", created: metav1.NewTime(now.Add(-time.Hour))},
+		{repository: "blueprints", path: "app", name: "blueprints.app.new", created: metav1.NewTime(now)},
+		{repository: "apps", path: "web", name: "apps.web.v1", published: true, revision: 1},
+	}
+
+	var names []string
+	for _, p := range selectRevisions(candidates) {
+		names = append(names, p.name)
+	}
+	assert.Equal(t, []string{"apps.web.v1", "blueprints.app.new", "blueprints.base.v2"}, names)
+}
+
+func TestPrintTree(t *testing.T) {
+	packages := []treePackage{
+		{
+			repository: "blueprints", path: "apps/web", lifecycle: "Published", published: true, revision: 3,
+			upstream: "https://github.com/example/catalog.git/web@v3",
+			subpackages: []treeSubpackage{
+				{path: "db", upstream: "https://github.com/example/catalog.git/postgres@v1"},
+				{path: "db/tls", parent: "db"},
+			},
+		},
+		{repository: "blueprints", path: "apps/worker", lifecycle: "Draft"},
+		{
+			repository: "blueprints", path: "base", lifecycle: "Published", published: true, revision: 1,
+			subpackages: []treeSubpackage{{path: "config/monitoring"}},
+		},
+		{repository: "blueprints", path: "base/extras", lifecycle: "Proposed"},
+		{repository: "deployments", path: "web", lifecycle: "Draft"},
+	}
+
+	var out bytes.Buffer
+	printTree(&out, packages)
+	assert.Equal(t, `blueprints
+├── apps/
+│   ├── web (Published, revision 3) [upstream: https://github.com/example/catalog.git/web@v3]
+│   │   └── db (subpackage) [upstream: https://github.com/example/catalog.git/postgres@v1]
+│   │       └── tls (subpackage)
+│   └── worker (Draft)
+└── base (Published, revision 1)
+    ├── config/monitoring (subpackage)
+    └── extras (Proposed)
+deployments
+└── web (Draft)
+`, out.String())
+}
+
+func TestRunE(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, porchapi.AddToScheme(scheme))
+
+	newPackageRevision := func(repo, pkg, ws string, revision int) *porchapi.PackageRevision {
+		lifecycle := porchapi.PackageRevisionLifecycleDraft
+		if revision > 0 {
+			lifecycle = porchapi.PackageRevisionLifecyclePublished
+		}
+		return &porchapi.PackageRevision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: repo + "." + pkg + "." + ws},
+			Spec: porchapi.PackageRevisionSpec{
+				RepositoryName: repo,
+				PackageName:    pkg,
+				WorkspaceName:  ws,
+				Revision:       revision,
+				Lifecycle:      lifecycle,
+			},
+		}
+	}
+	web := newPackageRevision("blueprints", "web", "v1", 1)
+	web.Status.Subpackages = []porchapi.Subpackage{{
+		Path: "db",
+		Name: "db",
+		UpstreamLock: &porchapi.Locator{
+			Type: "git",
+			Git:  &porchapi.GitLock{Repo: "https://github.com/example/catalog.git", Directory: "postgres", Ref: "v1"},
+		},
+	}}
+
+	// The subpackages are read from the listed package revisions; the client
+	// knows no PackageRevisionResources.
+	c := fake.NewClientBuilder().WithScheme(scheme).WithIndex(&porchapi.PackageRevision{}, "spec.repository",
+		func(o client.Object) []string {
+			return []string{o.(*porchapi.PackageRevision).Spec.RepositoryName}
+		}).WithObjects(
+		web,
+		newPackageRevision("blueprints", "web", "ws", 0),
+		newPackageRevision("deployments", "web", "v1", 1),
+	).Build()
+
+	ns := "ns"
+	r := newRunner(context.Background(), &genericclioptions.ConfigFlags{Namespace: &ns})
+	r.client = c
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, r.preRunE(cmd, []string{"blueprints"}))
+	require.NoError(t, r.runE(cmd, nil))
+	assert.Equal(t, `blueprints
+└── web (Published, revision 1)
+    └── db (subpackage) [upstream: https://github.com/example/catalog.git/postgres@v1]
+`, out.String())
+}
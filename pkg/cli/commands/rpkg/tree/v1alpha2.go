@@ -0,0 +1,99 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"context"
+
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	cliutils "github.com/kptdev/porch/internal/cliutils"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// v1alpha2Runner shows the package revisions of the v1alpha2 API.
+type v1alpha2Runner struct {
+	ctx    context.Context
+	cfg    *genericclioptions.ConfigFlags
+	client client.Client
+
+	repository string
+}
+
+func newV1Alpha2Runner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *v1alpha2Runner {
+	return &v1alpha2Runner{ctx: ctx, cfg: rcg}
+}
+
+func (r *v1alpha2Runner) preRunE(_ *cobra.Command, args []string) error {
+	const op errors.Op = command + ".preRunE"
+	if r.client == nil {
+		c, err := cliutils.CreateV1Alpha2ClientWithFlags(r.cfg)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		r.client = c
+	}
+
+	repository, err := validateArgs(args)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	r.repository = repository
+	return nil
+}
+
+func (r *v1alpha2Runner) runE(cmd *cobra.Command, _ []string) error {
+	const op errors.Op = command + ".runE"
+
+	var list porchv1alpha2.PackageRevisionList
+	if err := r.client.List(r.ctx, &list, listOptions(*r.cfg.Namespace, r.repository)...); err != nil {
+		return errors.E(op, err)
+	}
+
+	var candidates []treePackage
+	for i := range list.Items {
+		pr := &list.Items[i]
+		p := treePackage{
+			repository: pr.Spec.RepositoryName,
+			path:       pr.Spec.PackageName,
+			name:       pr.Name,
+			lifecycle:  string(pr.Spec.Lifecycle),
+			published:  pr.IsPublished(),
+			revision:   pr.Status.Revision,
+			created:    pr.CreationTimestamp,
+			upstream:   describeUpstream(v1alpha2LockOf(pr.Status.UpstreamLock)),
+		}
+		for _, s := range pr.Status.Subpackages {
+			p.subpackages = append(p.subpackages, treeSubpackage{
+				path:     s.Path,
+				parent:   s.Parent,
+				upstream: describeUpstream(v1alpha2LockOf(s.UpstreamLock)),
+			})
+		}
+		candidates = append(candidates, p)
+	}
+
+	printTree(cmd.OutOrStdout(), selectRevisions(candidates))
+	return nil
+}
+
+func v1alpha2LockOf(lock *porchv1alpha2.Locator) *gitLock {
+	if lock == nil || lock.Git == nil {
+		return nil
+	}
+	return &gitLock{repo: lock.Git.Repo, directory: lock.Git.Directory, ref: lock.Git.Ref}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newPackageRevision(repo, pkg, ws string, revision int) *porchv1alpha2.PackageRevision {
+	lifecycle := porchv1alpha2.PackageRevisionLifecycleDraft
+	if revision > 0 {
+		lifecycle = porchv1alpha2.PackageRevisionLifecyclePublished
+	}
+	return &porchv1alpha2.PackageRevision{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageRevision",
+			APIVersion: porchv1alpha2.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      repo + "." + pkg + "." + ws,
+		},
+		Spec: porchv1alpha2.PackageRevisionSpec{
+			RepositoryName: repo,
+			PackageName:    pkg,
+			WorkspaceName:  ws,
+			Lifecycle:      lifecycle,
+		},
+		Status: porchv1alpha2.PackageRevisionStatus{Revision: revision},
+	}
+}
+
+func TestV1Alpha2RunE(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, porchv1alpha2.AddToScheme(scheme))
+
+	web := newPackageRevision("blueprints", "web", "v1", 1)
+	web.Status.UpstreamLock = &porchv1alpha2.Locator{
+		Type: "git",
+		Git:  &porchv1alpha2.GitLock{Repo: "https://github.com/example/catalog.git", Directory: "web", Ref: "web/v2"},
+	}
+	web.Status.Subpackages = []porchv1alpha2.Subpackage{{Path: "db", Name: "db"}}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithIndex(&porchv1alpha2.PackageRevision{}, "spec.repository",
+		func(o client.Object) []string {
+			return []string{o.(*porchv1alpha2.PackageRevision).Spec.RepositoryName}
+		}).WithObjects(
+		web,
+		newPackageRevision("blueprints", "web", "ws", 0),
+		newPackageRevision("deployments", "web", "v1", 1),
+	).Build()
+
+	ns := "ns"
+	r := &v1alpha2Runner{
+		ctx:    context.Background(),
+		cfg:    &genericclioptions.ConfigFlags{Namespace: &ns},
+		client: c,
+	}
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, r.preRunE(cmd, []string{"blueprints"}))
+	require.NoError(t, r.runE(cmd, nil))
+	assert.Equal(t, `blueprints
+└── web (Published, revision 1) [upstream: https://github.com/example/catalog.git/web@web/v2]
+    └── db (subpackage)
+`, out.String())
+}
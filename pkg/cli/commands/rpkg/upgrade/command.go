@@ -70,12 +70,13 @@ func newRunner(ctx context.Context, rcg *genericclioptions.ConfigFlags) *runner
 	}
 	r.Command.Flags().IntVar(&r.revision, "revision", 0, "Revision of the upstream package to upgrade to.")
 	r.Command.Flags().StringVar(&r.workspace, "workspace", "", "Workspace name of the upgrade package revision.")
-	r.Command.Flags().StringVar(&r.strategy, "strategy", "resource-merge", "Strategy to use for the upgrade. Options: resource-merge (default), fast-forward, force-delete-replace, copy-merge.")
+	r.Command.Flags().StringVar(&r.strategy, "strategy", "resource-merge", "Strategy to use for the upgrade. Options: resource-merge (default), fast-forward, force-delete-replace, copy-merge, manual-merge.")
 	r.Command.Flags().StringVar(&r.discover, "discover", "",
 		`If set, search for available updates instead of performing an update.
 Setting this to 'upstream' will discover upstream updates of downstream packages.
 Setting this to 'downstream' will discover downstream package revisions of upstream packages that need to be updated.`)
 	r.Command.Flags().StringVar(&r.subpackageDir, "subpackage-dir", "", "Location of the subdirectory containing an independent subpackage to be upgraded.")
+	r.Command.Flags().BoolVar(&r.dryRun, "dry-run", false, "If set, report what the upgrade would do to each resource of the package without creating or changing a package revision.")
 	return r
 }
 
@@ -93,6 +94,8 @@ type runner struct {
 
 	subpackageDir string // If set, the subpackage directory containing an independent subpackage to be upgraded
 
+	dryRun bool // If set, preview the upgrade rather than do it
+
 	// there are multiple places where we need access to all package revisions, so
 	// we store it in the runner
 	prs []porchapi.PackageRevision
@@ -118,7 +121,7 @@ func (r *runner) preRunE(_ *cobra.Command, args []string) error {
 			return errors.E(op, fmt.Errorf("revision must be positive (and not main)"))
 		}
 		if r.subpackageDir == "" {
-			if r.workspace == "" {
+			if r.workspace == "" && !r.dryRun {
 				return errors.E(op, fmt.Errorf("workspace is required"))
 			}
 		} else {
@@ -131,7 +134,7 @@ func (r *runner) preRunE(_ *cobra.Command, args []string) error {
 			}
 		}
 		if r.strategy != "" {
-			validStrategies := []string{string(porchapi.ResourceMerge), string(porchapi.FastForward), string(porchapi.ForceDeleteReplace), string(porchapi.CopyMerge)}
+			validStrategies := []string{string(porchapi.ResourceMerge), string(porchapi.FastForward), string(porchapi.ForceDeleteReplace), string(porchapi.CopyMerge), string(porchapi.ManualMerge)}
 			valid := slices.Contains(validStrategies, r.strategy)
 			if !valid {
 				return errors.E(op, fmt.Errorf("invalid strategy %q; must be one of: %v", r.strategy, validStrategies))
@@ -168,6 +171,14 @@ func (r *runner) runE(cmd *cobra.Command, args []string) error {
 	if pr == nil {
 		return errors.E(op, pkgerrors.Errorf("could not find package revision %s", args[0]))
 	}
+
+	if r.dryRun {
+		if err := r.previewUpgrade(cmd, pr); err != nil {
+			return errors.E(op, err)
+		}
+		return nil
+	}
+
 	key := client.ObjectKeyFromObject(pr)
 	var upgradedPR *porchapi.PackageRevision
 	var lastErr error
@@ -211,6 +222,23 @@ func (r *runner) runE(cmd *cobra.Command, args []string) error {
 }
 
 func (r *runner) doUpgrade(pr *porchapi.PackageRevision) (*porchapi.PackageRevision, error) {
+	spec, err := r.upgradeSpec(pr)
+	if err != nil {
+		return nil, err
+	}
+
+	upgradeTask := &porchapi.Task{
+		Type:    porchapi.TaskTypeUpgrade,
+		Upgrade: spec,
+	}
+	newPr := makePackageRevision(pr, r.workspace, upgradeTask)
+
+	err = r.client.Create(r.ctx, newPr)
+	return newPr, pkgerrors.Wrapf(err, "failed to do create package revision %q", newPr.Name)
+}
+
+// upgradeSpec returns the spec of the upgrade of a package revision to the target upstream revision.
+func (r *runner) upgradeSpec(pr *porchapi.PackageRevision) (*porchapi.PackageUpgradeTaskSpec, error) {
 	if !pr.IsPublished() {
 		return nil, pkgerrors.Errorf("to upgrade a package, it must be in a published state, not %q", pr.Spec.Lifecycle)
 	}
@@ -247,28 +275,42 @@ func (r *runner) doUpgrade(pr *porchapi.PackageRevision) (*porchapi.PackageRevis
 		return nil, pkgerrors.Errorf("new upstream package revision %s is not published", newUpstreamPr.Name)
 	}
 
-	upgradeTask := &porchapi.Task{
-		Type: porchapi.TaskTypeUpgrade,
-		Upgrade: &porchapi.PackageUpgradeTaskSpec{
-			OldUpstream: porchapi.PackageRevisionRef{
-				Name: oldUpstreamPr.Name,
-			},
-			NewUpstream: porchapi.PackageRevisionRef{
-				Name: newUpstreamPr.Name,
-			},
-			LocalPackageRevisionRef: porchapi.PackageRevisionRef{
-				Name: pr.Name,
-			},
-			Strategy: porchapi.PackageMergeStrategy(r.strategy),
+	return &porchapi.PackageUpgradeTaskSpec{
+		OldUpstream: porchapi.PackageRevisionRef{
+			Name: oldUpstreamPr.Name,
 		},
+		NewUpstream: porchapi.PackageRevisionRef{
+			Name: newUpstreamPr.Name,
+		},
+		LocalPackageRevisionRef: porchapi.PackageRevisionRef{
+			Name: pr.Name,
+		},
+		Strategy: porchapi.PackageMergeStrategy(r.strategy),
+	}, nil
+}
+
+func (r *runner) doSubpackageUpgrade(parentPR *porchapi.PackageRevision) (*porchapi.PackageRevision, error) {
+	spec, err := r.subpackageUpgradeSpec(parentPR)
+	if err != nil {
+		return nil, err
 	}
-	newPr := makePackageRevision(pr, r.workspace, upgradeTask)
 
-	err := r.client.Create(r.ctx, newPr)
-	return newPr, pkgerrors.Wrapf(err, "failed to do create package revision %q", newPr.Name)
+	if len(parentPR.Spec.Tasks) != 1 {
+		return nil, pkgerrors.Errorf("to upgrade an independent subpackage, parent package revision %q must have exactly 1 existing task (found %d)", parentPR.Name, len(parentPR.Spec.Tasks))
+	}
+	parentPR.Spec.Tasks = append(parentPR.Spec.Tasks, porchapi.Task{
+		Type:    porchapi.TaskTypeUpgrade,
+		Upgrade: spec,
+	})
+
+	err = r.client.Update(r.ctx, parentPR)
+	return parentPR, pkgerrors.Wrapf(err, "could not upgrade independent subpackage at %q in package %q", r.subpackageDir, parentPR.Spec.PackageName)
+
 }
 
-func (r *runner) doSubpackageUpgrade(parentPR *porchapi.PackageRevision) (*porchapi.PackageRevision, error) {
+// subpackageUpgradeSpec returns the spec of the upgrade of the independent subpackage in
+// --subpackage-dir of a draft package revision to the target upstream revision.
+func (r *runner) subpackageUpgradeSpec(parentPR *porchapi.PackageRevision) (*porchapi.PackageUpgradeTaskSpec, error) {
 	if parentPR.Spec.Lifecycle != porchapi.PackageRevisionLifecycleDraft {
 		return nil, pkgerrors.Errorf("to upgrade an independent subpackage, its parent package must be in state draft, not %q", parentPR.Spec.Lifecycle)
 	}
@@ -323,31 +365,19 @@ func (r *runner) doSubpackageUpgrade(parentPR *porchapi.PackageRevision) (*porch
 		return nil, pkgerrors.Errorf("new upstream package revision %s is not published", newUpstreamPr.Name)
 	}
 
-	upgradeTask := porchapi.Task{
-		Type: porchapi.TaskTypeUpgrade,
-		Upgrade: &porchapi.PackageUpgradeTaskSpec{
-			OldUpstream: porchapi.PackageRevisionRef{
-				Name: oldUpstreamPr.Name,
-			},
-			NewUpstream: porchapi.PackageRevisionRef{
-				Name: newUpstreamPr.Name,
-			},
-			LocalPackageRevisionRef: porchapi.PackageRevisionRef{
-				Name: parentPR.Name,
-			},
-			Strategy:      porchapi.PackageMergeStrategy(r.strategy),
-			SubpackageDir: r.subpackageDir,
+	return &porchapi.PackageUpgradeTaskSpec{
+		OldUpstream: porchapi.PackageRevisionRef{
+			Name: oldUpstreamPr.Name,
 		},
-	}
-
-	if len(parentPR.Spec.Tasks) != 1 {
-		return nil, pkgerrors.Errorf("to upgrade an independent subpackage, parent package revision %q must have exactly 1 existing task (found %d)", parentPR.Name, len(parentPR.Spec.Tasks))
-	}
-	parentPR.Spec.Tasks = append(parentPR.Spec.Tasks, upgradeTask)
-
-	err = r.client.Update(r.ctx, parentPR)
-	return parentPR, pkgerrors.Wrapf(err, "could not upgrade independent subpackage at %q in package %q", r.subpackageDir, parentPR.Spec.PackageName)
-
+		NewUpstream: porchapi.PackageRevisionRef{
+			Name: newUpstreamPr.Name,
+		},
+		LocalPackageRevisionRef: porchapi.PackageRevisionRef{
+			Name: parentPR.Name,
+		},
+		Strategy:      porchapi.PackageMergeStrategy(r.strategy),
+		SubpackageDir: r.subpackageDir,
+	}, nil
 }
 
 func makePackageRevision(oldLocal *porchapi.PackageRevision, workspace string, task *porchapi.Task) *porchapi.PackageRevision {
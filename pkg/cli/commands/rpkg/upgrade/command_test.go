@@ -188,6 +188,11 @@ func TestPreRun(t *testing.T) {
 	r = createRunner(context.Background(), fake.NewClientBuilder().Build(), prs, ns, 2)
 	err = r.preRunE(r.Command, []string{"clone"})
 	assert.ErrorContains(t, err, "workspace")
+
+	r = createRunner(context.Background(), fake.NewClientBuilder().Build(), prs, ns, 2)
+	r.dryRun = true
+	err = r.preRunE(r.Command, []string{"clone"})
+	assert.NoError(t, err)
 }
 
 func TestPreRunSubpackageDir(t *testing.T) {
@@ -340,6 +345,68 @@ func TestUpgradeCommand(t *testing.T) {
 	}
 }
 
+func TestUpgradeDryRun(t *testing.T) {
+	ctx := context.Background()
+
+	origRevision := createOrigPackageRevision("ns", "repo", "orig", 1)
+	newUpstreamRevision := createEditPackageRevision(origRevision, 2)
+	localRevision := createClonePackageRevision(origRevision, "clone", 1)
+	prs := []porchapi.PackageRevision{*origRevision, *newUpstreamRevision, *localRevision}
+
+	scheme := runtime.NewScheme()
+	if err := porchapi.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add porch API to scheme: %v", err)
+	}
+	var previewed *porchapi.PackageUpgradePreview
+	interceptorFuncs := interceptor.Funcs{
+		Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			preview, ok := obj.(*porchapi.PackageUpgradePreview)
+			if !ok {
+				return fmt.Errorf("unexpected create of %T", obj)
+			}
+			previewed = preview.DeepCopy()
+			preview.Status = porchapi.PackageUpgradePreviewStatus{
+				Resources: []porchapi.UpgradePreviewResource{
+					{File: "Kptfile", Kind: "Kptfile", Name: "clone", Outcome: porchapi.UpgradeOutcomeMerged, Fields: []string{"upstream.git.ref", "upstreamLock.git.ref"}},
+					{File: "deploy.yaml", Kind: "Deployment", Namespace: "app", Name: "app", Outcome: porchapi.UpgradeOutcomeConflict, Fields: []string{"spec.replicas"}},
+				},
+				Conflicts: 1,
+			}
+			return nil
+		},
+		List: func(ctx context.Context, client client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if prList, ok := list.(*porchapi.PackageRevisionList); ok {
+				prList.Items = prs
+			}
+			return nil
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(origRevision, newUpstreamRevision, localRevision).
+		WithInterceptorFuncs(interceptorFuncs).
+		Build()
+
+	r := createRunner(ctx, c, prs, "ns", 2)
+	r.dryRun = true
+	output := &bytes.Buffer{}
+	r.Command.SetOut(output)
+
+	err := r.runE(r.Command, []string{localRevision.Name})
+	require.NoError(t, err)
+
+	require.NotNil(t, previewed)
+	assert.Equal(t, origRevision.Name, previewed.Spec.OldUpstream.Name)
+	assert.Equal(t, newUpstreamRevision.Name, previewed.Spec.NewUpstream.Name)
+	assert.Equal(t, localRevision.Name, previewed.Spec.LocalPackageRevisionRef.Name)
+
+	got := output.String()
+	assert.Contains(t, got, "FILE")
+	assert.Regexp(t, `deploy\.yaml\s+Deployment\s+app/app\s+Conflict\s+spec\.replicas`, got)
+	assert.Regexp(t, `Kptfile\s+Kptfile\s+clone\s+Merged\s+upstream\.git\.ref,upstreamLock\.git\.ref`, got)
+	assert.Contains(t, got, "2 resources, 1 conflicts")
+}
+
 func TestFindLatestPR(t *testing.T) {
 	origRevision := createOrigPackageRevision("ns", "repo", "orig", 1)
 	newUpstreamRevision := createEditPackageRevision(origRevision, 2)
@@ -551,6 +618,11 @@ func TestPreRunStrategyValidation(t *testing.T) {
 			strategy:             string(porchapi.CopyMerge),
 			validationShouldPass: true,
 		},
+		{
+			name:                 "Valid strategy: manual-merge",
+			strategy:             string(porchapi.ManualMerge),
+			validationShouldPass: true,
+		},
 		{
 			name:                 "Empty strategy is valid (uses default resource-merge)",
 			strategy:             "",
@@ -0,0 +1,82 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// previewUpgrade asks the server what upgrading the package revision would do, and prints the
+// outcome for each resource. No package revision is created or changed.
+func (r *runner) previewUpgrade(cmd *cobra.Command, pr *porchapi.PackageRevision) error {
+	var spec *porchapi.PackageUpgradeTaskSpec
+	var err error
+	if r.subpackageDir == "" {
+		spec, err = r.upgradeSpec(pr)
+	} else {
+		spec, err = r.subpackageUpgradeSpec(pr)
+	}
+	if err != nil {
+		return err
+	}
+
+	preview := &porchapi.PackageUpgradePreview{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageUpgradePreview",
+			APIVersion: porchapi.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pr.Namespace,
+		},
+		Spec: *spec,
+	}
+	if err := r.client.Create(r.ctx, preview); err != nil {
+		return pkgerrors.Wrapf(err, "failed to preview upgrade of package revision %q", pr.Name)
+	}
+
+	return printUpgradePreview(cmd.OutOrStdout(), pr.Name, preview)
+}
+
+func printUpgradePreview(w io.Writer, prName string, preview *porchapi.PackageUpgradePreview) error {
+	printer := printers.GetNewTabWriter(w)
+	if _, err := fmt.Fprintln(printer, "FILE\tKIND\tNAME\tOUTCOME\tFIELDS"); err != nil {
+		return err
+	}
+	for _, resource := range preview.Status.Resources {
+		name := resource.Name
+		if resource.Namespace != "" {
+			name = resource.Namespace + "/" + name
+		}
+		if _, err := fmt.Fprintf(printer, "%s\t%s\t%s\t%s\t%s\n", resource.File, resource.Kind, name,
+			resource.Outcome, strings.Join(resource.Fields, ",")); err != nil {
+			return err
+		}
+	}
+	if err := printer.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\nupgrade of %q to %q: %d resources, %d conflicts (dry run, nothing changed)\n",
+		prName, preview.Spec.NewUpstream.Name, len(preview.Status.Resources), preview.Status.Conflicts)
+	return err
+}
@@ -63,6 +63,10 @@ func (r *v1alpha2Runner) preRunE(cmd *cobra.Command, args []string) error {
 	r.workspace, _ = cmd.Flags().GetString("workspace")
 	r.strategy, _ = cmd.Flags().GetString("strategy")
 	r.discover, _ = cmd.Flags().GetString("discover")
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		// PackageUpgradePreview is only served for v1alpha1
+		return errors.E(op, fmt.Errorf("--dry-run is not supported for v1alpha2 package revisions"))
+	}
 
 	switch r.discover {
 	case "":
@@ -107,6 +111,7 @@ func (r *v1alpha2Runner) validateUpgradeArgs(args []string) error {
 			string(porchv1alpha2.FastForward),
 			string(porchv1alpha2.ForceDeleteReplace),
 			string(porchv1alpha2.CopyMerge),
+			string(porchv1alpha2.ManualMerge),
 		}
 		if !slices.Contains(validStrategies, r.strategy) {
 			return fmt.Errorf("invalid strategy %q; must be one of: %v", r.strategy, validStrategies)
@@ -297,6 +302,8 @@ func (r *v1alpha2Runner) findUpstreamName(pr *porchv1alpha2.PackageRevision) str
 		return ""
 	case pr.Spec.Source.Upgrade != nil:
 		return pr.Spec.Source.Upgrade.NewUpstream.Name
+	case pr.Spec.Source.PromoteFrom != nil:
+		return pr.Spec.Source.PromoteFrom.Name
 	default:
 		return ""
 	}
@@ -80,6 +80,29 @@ func TestV1Alpha2PreRunE(t *testing.T) {
 	}
 }
 
+func TestV1Alpha2PreRunEDryRun(t *testing.T) {
+	ns := "test-ns"
+	scheme, err := createV1Alpha2Scheme()
+	if err != nil {
+		t.Fatalf("error creating scheme: %v", err)
+	}
+
+	r := &v1alpha2Runner{
+		ctx:    context.Background(),
+		cfg:    &genericclioptions.ConfigFlags{Namespace: &ns},
+		client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Int("revision", 1, "")
+	cmd.Flags().String("workspace", "v1", "")
+	cmd.Flags().String("strategy", "resource-merge", "")
+	cmd.Flags().String("discover", "", "")
+	cmd.Flags().Bool("dry-run", true, "")
+	err = r.preRunE(cmd, []string{"test-pkg"})
+	assert.ErrorContains(t, err, "--dry-run is not supported for v1alpha2 package revisions")
+}
+
 func makeV2Pr(ns, repo, pkg string, revision int, lc porchv1alpha2.PackageRevisionLifecycle, source *porchv1alpha2.PackageSource) *porchv1alpha2.PackageRevision {
 	return &porchv1alpha2.PackageRevision{
 		TypeMeta: metav1.TypeMeta{
@@ -724,6 +747,13 @@ func TestV1Alpha2ValidateUpgradeArgs(t *testing.T) {
 			workspace: "ws",
 			strategy:  "resource-merge",
 		},
+		{
+			name:      "valid manual merge",
+			args:      []string{"pkg"},
+			revision:  1,
+			workspace: "ws",
+			strategy:  "manual-merge",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,100 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kptdev/kpt/pkg/lib/errors"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SelectorFlagUsage is the usage text of the --selector flag of the rpkg lifecycle commands.
+const SelectorFlagUsage = "Label selector of the package revisions to apply the operation to, in addition to any named ones. " +
+	"The package revisions are updated with a single PackageRevisionBatch, and all changes to a repository are applied together or not at all."
+
+// CheckNoSelectorV1Alpha2 returns an error if --selector is set on a command that operates on
+// v1alpha2 package revisions, because PackageRevisionBatch is only served for v1alpha1.
+func CheckNoSelectorV1Alpha2(cmd *cobra.Command) error {
+	if flag := cmd.Flags().Lookup("selector"); flag != nil && flag.Changed {
+		return fmt.Errorf("--selector is not supported for v1alpha2 package revisions")
+	}
+	return nil
+}
+
+// RunBatch applies operation to the package revisions named in args and to those matched by
+// selector with a single PackageRevisionBatch, and prints the outcome for each package revision.
+// done is printed after the name of each package revision the operation was applied to.
+func RunBatch(
+	ctx context.Context,
+	c client.Client,
+	cmd *cobra.Command,
+	namespace string,
+	args []string,
+	selector string,
+	operation porchapi.PackageRevisionBatchOperation,
+	cmdName string,
+	done string,
+) error {
+	op := errors.Op(cmdName + ".runE")
+
+	labelSelector, err := metav1.ParseToLabelSelector(selector)
+	if err != nil {
+		return errors.E(op, fmt.Errorf("invalid selector %q: %w", selector, err))
+	}
+
+	batch := &porchapi.PackageRevisionBatch{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageRevisionBatch",
+			APIVersion: porchapi.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: strings.ToLower(string(operation)) + "-",
+			Namespace:    namespace,
+		},
+		Spec: porchapi.PackageRevisionBatchSpec{
+			Operation:        operation,
+			PackageRevisions: args,
+			Selector:         labelSelector,
+		},
+	}
+	if err := c.Create(ctx, batch); err != nil {
+		return errors.E(op, err)
+	}
+
+	if len(batch.Status.Results) == 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "no package revisions matched selector %q\n", selector)
+		return nil
+	}
+
+	var messages []string
+	for _, result := range batch.Status.Results {
+		var err error
+		if result.Outcome != porchapi.BatchOutcomeSucceeded {
+			err = fmt.Errorf("%s: %s", strings.ToLower(string(result.Outcome)), result.Message)
+		}
+		reportResult(cmd, result.Name, fmt.Sprintf("%s %s", result.Name, done), err, &messages)
+	}
+
+	if len(messages) > 0 {
+		return errors.E(op, fmt.Errorf("errors:\n  %s", strings.Join(messages, "\n  ")))
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// setupBatchClient returns a fake client that answers PackageRevisionBatch creation with results,
+// and records the created batch in *created.
+func setupBatchClient(t *testing.T, created **porchapi.PackageRevisionBatch, createErr error,
+	results ...porchapi.PackageRevisionBatchResult) client.Client {
+	t.Helper()
+	scheme, err := CreateScheme()
+	require.NoError(t, err)
+	return fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			if createErr != nil {
+				return createErr
+			}
+			batch := obj.(*porchapi.PackageRevisionBatch)
+			*created = batch.DeepCopy()
+			batch.Status.Results = results
+			return nil
+		},
+	}).Build()
+}
+
+func TestRunBatch_AllSucceed(t *testing.T) {
+	cmd, stdout, stderr := setupCmdBuffers()
+	var created *porchapi.PackageRevisionBatch
+	fc := setupBatchClient(t, &created, nil,
+		porchapi.PackageRevisionBatchResult{Name: "repo.pkg-a.v1", Repository: "repo", Outcome: porchapi.BatchOutcomeSucceeded},
+		porchapi.PackageRevisionBatchResult{Name: "repo.pkg-b.v1", Repository: "repo", Outcome: porchapi.BatchOutcomeSucceeded},
+	)
+
+	err := RunBatch(context.Background(), fc, cmd, "ns", []string{"repo.pkg-a.v1"}, "team=blue",
+		porchapi.BatchOperationApprove, "cmdrpkgtest", "approved")
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "repo.pkg-a.v1 approved")
+	assert.Contains(t, stdout.String(), "repo.pkg-b.v1 approved")
+	assert.Empty(t, stderr.String())
+
+	require.NotNil(t, created)
+	assert.Equal(t, "ns", created.Namespace)
+	assert.Equal(t, "approve-", created.GenerateName)
+	assert.Equal(t, porchapi.BatchOperationApprove, created.Spec.Operation)
+	assert.Equal(t, []string{"repo.pkg-a.v1"}, created.Spec.PackageRevisions)
+	assert.Equal(t, map[string]string{"team": "blue"}, created.Spec.Selector.MatchLabels)
+}
+
+func TestRunBatch_ReportsFailedAndAborted(t *testing.T) {
+	cmd, stdout, stderr := setupCmdBuffers()
+	var created *porchapi.PackageRevisionBatch
+	fc := setupBatchClient(t, &created, nil,
+		porchapi.PackageRevisionBatchResult{Name: "repo.pkg-a.v1", Outcome: porchapi.BatchOutcomeFailed, Message: "readiness conditions not met"},
+		porchapi.PackageRevisionBatchResult{Name: "repo.pkg-b.v1", Outcome: porchapi.BatchOutcomeAborted, Message: "not applied"},
+		porchapi.PackageRevisionBatchResult{Name: "other.pkg-c.v1", Outcome: porchapi.BatchOutcomeSucceeded},
+	)
+
+	err := RunBatch(context.Background(), fc, cmd, "ns", nil, "team=blue",
+		porchapi.BatchOperationApprove, "cmdrpkgtest", "approved")
+	require.Error(t, err)
+	assert.Contains(t, stdout.String(), "other.pkg-c.v1 approved")
+	assert.Contains(t, stderr.String(), "repo.pkg-a.v1 failed (failed: readiness conditions not met)")
+	assert.Contains(t, stderr.String(), "repo.pkg-b.v1 failed (aborted: not applied)")
+}
+
+func TestRunBatch_NoMatches(t *testing.T) {
+	cmd, stdout, stderr := setupCmdBuffers()
+	var created *porchapi.PackageRevisionBatch
+	fc := setupBatchClient(t, &created, nil)
+
+	err := RunBatch(context.Background(), fc, cmd, "ns", nil, "team=blue",
+		porchapi.BatchOperationDelete, "cmdrpkgtest", "deleted")
+	require.NoError(t, err)
+	assert.Empty(t, stdout.String())
+	assert.Contains(t, stderr.String(), `no package revisions matched selector "team=blue"`)
+}
+
+func TestRunBatch_InvalidSelector(t *testing.T) {
+	cmd, _, _ := setupCmdBuffers()
+	var created *porchapi.PackageRevisionBatch
+	fc := setupBatchClient(t, &created, nil)
+
+	err := RunBatch(context.Background(), fc, cmd, "ns", nil, "team in (blue",
+		porchapi.BatchOperationPropose, "cmdrpkgtest", "proposed")
+	assert.ErrorContains(t, err, "invalid selector")
+	assert.Nil(t, created)
+}
+
+func TestRunBatch_CreateFails(t *testing.T) {
+	cmd, _, _ := setupCmdBuffers()
+	var created *porchapi.PackageRevisionBatch
+	fc := setupBatchClient(t, &created, fmt.Errorf("the server could not find the requested resource"))
+
+	err := RunBatch(context.Background(), fc, cmd, "ns", nil, "team=blue",
+		porchapi.BatchOperationReject, "cmdrpkgtest", "rejected")
+	assert.ErrorContains(t, err, "could not find the requested resource")
+}
+
+func TestCheckNoSelectorV1Alpha2(t *testing.T) {
+	cmd := &cobra.Command{}
+	assert.NoError(t, CheckNoSelectorV1Alpha2(cmd))
+
+	cmd.Flags().String("selector", "", "")
+	assert.NoError(t, CheckNoSelectorV1Alpha2(cmd))
+
+	require.NoError(t, cmd.Flags().Set("selector", "team=blue"))
+	assert.ErrorContains(t, CheckNoSelectorV1Alpha2(cmd), "not supported for v1alpha2")
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// ReadFromDir reads the files of a local package directory, keyed by their
+// slash-separated path relative to the directory. Files and directories for
+// which skip returns true are skipped; a nil skip reads every file.
+func ReadFromDir(dir string, skip func(path string) bool) (map[string]string, error) {
+	resources := map[string]string{}
+	if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel != "." && skip != nil && skip(filepath.ToSlash(rel)) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(contents) {
+			// Since PackageRevisionResources' spec.Resources is a map of strings, during JSON serialization all file contents
+			// will be converted to valid UTF-8 by changing invalid bytes to the Unicode replacement character.
+			// This of course corrupts the contents of binary files.
+			// Return an early error here to prevent pushing corrupt content.
+			return fmt.Errorf("file %s is not a valid UTF-8 text file: current porch API doesn't support binary files", path)
+		}
+		resources[filepath.ToSlash(rel)] = string(contents)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// IsIgnoredFile reports whether the file at the slash-separated path is one
+// that editors and tools leave in a package directory being developed: hidden
+// files and files in hidden directories, and the backup (*~) and swap (*.swp)
+// files of editors.
+func IsIgnoredFile(path string) bool {
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return true
+		}
+	}
+	return strings.HasSuffix(path, "~") || strings.HasSuffix(path, ".swp")
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFromDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Kptfile":              "kind: Kptfile\n",
+		"sub/cm.yaml":          "kind: ConfigMap\n",
+		".KptRevisionMetadata": "kind: KptRevisionMetadata\n",
+		"cm.yaml~":             "backup",
+		"sub/.cm.yaml.swp":     "swap",
+		"cm.yaml.swp":          "swap",
+		".git/HEAD":            "ref: refs/heads/main\n",
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	// Without a skip function every file is read
+	resources, err := ReadFromDir(dir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, files, resources)
+
+	resources, err = ReadFromDir(dir, IsIgnoredFile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"Kptfile":     "kind: Kptfile\n",
+		"sub/cm.yaml": "kind: ConfigMap\n",
+	}, resources)
+}
+
+func TestReadFromDirRejectsBinaryFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "image.png"), []byte{0xff, 0xfe}, 0600))
+
+	_, err := ReadFromDir(dir, nil)
+	assert.ErrorContains(t, err, "not a valid UTF-8 text file")
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kptdev/kpt/pkg/lib/runneroptions"
+	"github.com/kptdev/kpt/pkg/printer"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+)
+
+// PrintRenderResults prints the result of each function of a render. If withStderr
+// is set, the output of the functions on stderr is printed too.
+func PrintRenderResults(p printer.Printer, results []*porchapi.Result, withStderr bool) {
+	for _, result := range results {
+		p.Printf("[RUNNING] %q \n", result.Image)
+		printOpt := printer.NewOpt()
+		if result.ExitCode != 0 {
+			p.OptPrintf(printOpt, "[FAIL] %q\n", result.Image)
+		} else {
+			p.OptPrintf(printOpt, "[PASS] %q\n", result.Image)
+		}
+		PrintFnResult(p, result, printOpt)
+		if withStderr && result.Stderr != "" {
+			for _, line := range strings.Split(strings.TrimRight(result.Stderr, "\n"), "\n") {
+				p.OptPrintf(printOpt, "  %s\n", line)
+			}
+		}
+	}
+}
+
+// PrintFnResult prints given function result in a user friendly
+// format on kpt CLI.
+func PrintFnResult(p printer.Printer, fnResult *porchapi.Result, opt *printer.Options) {
+	if len(fnResult.Results) > 0 {
+		// function returned structured results
+		var lines []string
+		for _, item := range fnResult.Results {
+			lines = append(lines, resultItemString(item))
+		}
+
+		ri := &runneroptions.SingleLineFormatter{
+			Title:     "[Results]",
+			Lines:     lines,
+			UseQuote:  false,
+			Separator: ", ",
+		}
+		p.OptPrintf(opt, "%s", ri.String())
+	}
+}
+
+// resultItemString provides a human-readable message for the result item
+func resultItemString(i porchapi.ResultItem) string {
+	identifier := i.ResourceRef
+	var idStringList []string
+	if identifier != nil {
+		if identifier.APIVersion != "" {
+			idStringList = append(idStringList, identifier.APIVersion)
+		}
+		if identifier.Kind != "" {
+			idStringList = append(idStringList, identifier.Kind)
+		}
+		if identifier.Namespace != "" {
+			idStringList = append(idStringList, identifier.Namespace)
+		}
+		if identifier.Name != "" {
+			idStringList = append(idStringList, identifier.Name)
+		}
+	}
+	formatString := "[%s]"
+	severity := i.Severity
+	// We default Severity to Info when converting a result to a message.
+	if i.Severity == "" {
+		severity = "info"
+	}
+	list := []interface{}{severity}
+	if len(idStringList) > 0 {
+		formatString += " %s"
+		list = append(list, strings.Join(idStringList, "/"))
+	}
+	if i.Field != nil {
+		formatString += " %s"
+		list = append(list, i.Field.Path)
+	}
+	formatString += ": %s"
+	list = append(list, i.Message)
+	return fmt.Sprintf(formatString, list...)
+}
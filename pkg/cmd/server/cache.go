@@ -0,0 +1,146 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kptdev/porch/pkg/cache/dbcache"
+	cachetypes "github.com/kptdev/porch/pkg/cache/types"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+// NewCommandCache provides the "cache" command, which backs up and restores the draft and proposed
+// package revisions held only in the database cache. The database is configured with the same
+// environment variables as the Porch server.
+func NewCommandCache(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Back up and restore the database cache",
+		Long: "Back up and restore the draft and proposed package revisions that are held only in the database cache.\n" +
+			"Published package revisions are not backed up, repository sync rebuilds them from git.\n" +
+			"The database is configured with the DB_* environment variables used by the Porch server.",
+	}
+
+	var output string
+	backup := &cobra.Command{
+		Use:   "backup --output FILE",
+		Short: "Write a backup archive of the unpublished package revisions in the database cache",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			if output == "" {
+				return fmt.Errorf("--output is required")
+			}
+			return withCacheDB(ctx, func() error {
+				f, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				summary, err := dbcache.BackupUnpublished(ctx, f)
+				if closeErr := f.Close(); err == nil {
+					err = closeErr
+				}
+				if err != nil {
+					return err
+				}
+				printCacheBackupSummary(c.OutOrStdout(), summary)
+				return nil
+			})
+		},
+	}
+	backup.Flags().StringVarP(&output, "output", "o", "", "Path of the backup archive to write")
+
+	var dryRun bool
+	restore := &cobra.Command{
+		Use:   "restore FILE",
+		Short: "Restore a backup archive of unpublished package revisions into the database cache",
+		Long: "Restore a backup archive of unpublished package revisions into the database cache.\n" +
+			"Missing repositories and packages are recreated. Package revisions that are already in the database are skipped.\n" +
+			"Drafts that were published in git after the backup was taken are replaced by the published package revisions on the next repository sync.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			if dryRun {
+				summary, err := dbcache.ReadCacheBackup(f)
+				if err != nil {
+					return err
+				}
+				printCacheBackupSummary(c.OutOrStdout(), summary)
+				return nil
+			}
+
+			return withCacheDB(ctx, func() error {
+				summary, err := dbcache.RestoreUnpublished(ctx, f)
+				if err != nil {
+					return err
+				}
+				printCacheBackupSummary(c.OutOrStdout(), &summary.CacheBackupSummary)
+				for _, name := range summary.Restored {
+					fmt.Fprintf(c.OutOrStdout(), "restored %s\n", name)
+				}
+				for _, name := range summary.Skipped {
+					fmt.Fprintf(c.OutOrStdout(), "skipped %s, already in the database\n", name)
+				}
+				return nil
+			})
+		},
+	}
+	restore.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the backup archive and print its content without restoring it")
+
+	cmd.AddCommand(backup, restore)
+	return cmd
+}
+
+// withCacheDB opens the database cache for the duration of fn.
+func withCacheDB(ctx context.Context, fn func() error) error {
+	o := &PorchServerOptions{}
+	if err := o.setupDBCacheConn(); err != nil {
+		return err
+	}
+
+	if err := dbcache.OpenDB(ctx, cachetypes.CacheOptions{
+		DBCacheOptions: cachetypes.DBCacheOptions{
+			Driver:             o.DbCacheDriver,
+			DataSource:         o.DbCacheDataSource,
+			MaxConnections:     2,
+			MaxIdleConnections: 1,
+			MaxConnLifetime:    3 * time.Minute,
+		},
+	}); err != nil {
+		return fmt.Errorf("cannot open database cache: %w", err)
+	}
+	defer func() {
+		if err := dbcache.CloseDB(ctx); err != nil {
+			klog.Warningf("cannot close database cache: %v", err)
+		}
+	}()
+
+	return fn()
+}
+
+func printCacheBackupSummary(out io.Writer, summary *dbcache.CacheBackupSummary) {
+	fmt.Fprintf(out, "backup %s created %s: %d package revisions in %d packages of %d repositories\n",
+		summary.Version, summary.Created.Format(time.RFC3339), summary.PackageRevisions, summary.Packages, summary.Repositories)
+}
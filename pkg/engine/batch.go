@@ -0,0 +1,58 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/repository"
+	pctx "github.com/kptdev/porch/pkg/util/context"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+)
+
+// BatchRepositoryUpdates calls updates with a context on which the repository collects the changes
+// it would push, and then pushes them all in a single push. If updates returns an error, nothing
+// is pushed. Changes that the cache joined to the batch as transactions are only committed if the
+// push succeeds. Whatever the outcome, the repository is refreshed afterwards so that its cache
+// reflects what was actually pushed.
+func (cad *cadEngine) BatchRepositoryUpdates(ctx context.Context, repositoryObj *configapi.Repository, updates func(ctx context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "cadEngine::BatchRepositoryUpdates", trace.WithAttributes())
+	defer span.End()
+
+	repo, err := cad.cache.OpenRepository(ctx, repositoryObj)
+	if err != nil {
+		return err
+	}
+
+	batch := repository.NewPushBatch()
+	err = updates(repository.WithPushBatch(ctx, batch))
+	pending := batch.Len()
+	if err == nil {
+		klog.InfoS("[CaD Engine] Pushing batched changes to repository",
+			pctx.LogMetadataFromWithExtras(ctx, "repository", repositoryObj.Name, "pending", pending)...)
+		err = batch.Flush(ctx)
+	} else if discardErr := batch.Discard(ctx); discardErr != nil {
+		klog.Warningf("failed to discard batched changes to repository %s/%s: %v", repositoryObj.Namespace, repositoryObj.Name, discardErr)
+	}
+
+	if pending > 0 {
+		if refreshErr := repo.Refresh(ctx); refreshErr != nil {
+			klog.Warningf("failed to refresh repository %s/%s after batched update: %v", repositoryObj.Namespace, repositoryObj.Name, refreshErr)
+		}
+	}
+	return err
+}
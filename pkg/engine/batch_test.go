@@ -0,0 +1,132 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kptdev/porch/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPendingPush struct {
+	pushes int
+	err    error
+}
+
+func (p *recordingPendingPush) Push(context.Context) error {
+	p.pushes++
+	return p.err
+}
+
+// addPendingPush adds a pending push for the test repository to the batch on ctx, the way a
+// repository does when it would push
+func addPendingPush(ctx context.Context, push *recordingPendingPush) error {
+	batch, ok := repository.PushBatchFrom(ctx)
+	if !ok {
+		return errors.New("no push batch on context")
+	}
+	batch.Pending(repository.RepositoryKey{Namespace: "default", Name: "test-repo"}, func() repository.PendingPush { return push })
+	return nil
+}
+
+type recordingBatchTransaction struct {
+	calls []string
+}
+
+func (tx *recordingBatchTransaction) Commit(context.Context) error {
+	tx.calls = append(tx.calls, "commit")
+	return nil
+}
+
+func (tx *recordingBatchTransaction) Rollback(context.Context) error {
+	tx.calls = append(tx.calls, "rollback")
+	return nil
+}
+
+// addTransaction joins tx to the batch on ctx, the way a cache does when it writes under a batch
+func addTransaction(ctx context.Context, tx *recordingBatchTransaction) error {
+	batch, ok := repository.PushBatchFrom(ctx)
+	if !ok {
+		return errors.New("no push batch on context")
+	}
+	_, err := batch.Transaction("test-cache", func() (repository.BatchTransaction, error) { return tx, nil })
+	return err
+}
+
+func TestBatchRepositoryUpdates(t *testing.T) {
+	f := newTestFixture(t)
+	f.mockRepo.On("Refresh", mock.Anything).Return(nil).Once()
+
+	push := &recordingPendingPush{}
+	tx := &recordingBatchTransaction{}
+	err := f.engine.BatchRepositoryUpdates(context.TODO(), f.repositoryObj, func(ctx context.Context) error {
+		require.NoError(t, addPendingPush(ctx, push))
+		require.NoError(t, addTransaction(ctx, tx))
+		return addPendingPush(ctx, push)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, push.pushes)
+	assert.Equal(t, []string{"commit"}, tx.calls)
+	f.mockRepo.AssertExpectations(t)
+}
+
+func TestBatchRepositoryUpdatesFailedUpdate(t *testing.T) {
+	f := newTestFixture(t)
+	f.mockRepo.On("Refresh", mock.Anything).Return(nil).Once()
+
+	push := &recordingPendingPush{}
+	tx := &recordingBatchTransaction{}
+	updateErr := errors.New("cannot approve")
+	err := f.engine.BatchRepositoryUpdates(context.TODO(), f.repositoryObj, func(ctx context.Context) error {
+		require.NoError(t, addPendingPush(ctx, push))
+		require.NoError(t, addTransaction(ctx, tx))
+		return updateErr
+	})
+	assert.ErrorIs(t, err, updateErr)
+	assert.Equal(t, 0, push.pushes, "nothing must be pushed when an update fails")
+	assert.Equal(t, []string{"rollback"}, tx.calls)
+	f.mockRepo.AssertExpectations(t)
+}
+
+func TestBatchRepositoryUpdatesFailedPush(t *testing.T) {
+	f := newTestFixture(t)
+	f.mockRepo.On("Refresh", mock.Anything).Return(nil).Once()
+
+	pushErr := errors.New("push rejected")
+	push := &recordingPendingPush{err: pushErr}
+	tx := &recordingBatchTransaction{}
+	err := f.engine.BatchRepositoryUpdates(context.TODO(), f.repositoryObj, func(ctx context.Context) error {
+		require.NoError(t, addTransaction(ctx, tx))
+		return addPendingPush(ctx, push)
+	})
+	assert.ErrorIs(t, err, pushErr)
+	assert.Equal(t, []string{"rollback"}, tx.calls, "the changes of the cache must be rolled back when the push fails")
+	f.mockRepo.AssertExpectations(t)
+}
+
+func TestBatchRepositoryUpdatesNothingPending(t *testing.T) {
+	f := newTestFixture(t)
+
+	err := f.engine.BatchRepositoryUpdates(context.TODO(), f.repositoryObj, func(ctx context.Context) error {
+		return nil
+	})
+	require.NoError(t, err)
+	f.mockRepo.AssertNotCalled(t, "Refresh", mock.Anything)
+}
@@ -64,6 +64,10 @@ func (br *builtinRuntime) GetRunner(ctx context.Context, funct *kptfilev1.Functi
 				funct.Image = stripped
 			}
 		}
+		if processor, found := br.store.GetScriptFromCacheByConstraint(funct.Image, funct.Tag); found {
+			builtinRunner.processor = processor
+			return builtinRunner, nil
+		}
 		baseName := util.GetImageName(funct.Image)
 
 		builtinEntry := cache[baseName]
@@ -78,7 +82,10 @@ func (br *builtinRuntime) GetRunner(ctx context.Context, funct *kptfilev1.Functi
 		builtinRunner.processor = builtinEntry.Process
 	} else {
 		klog.Infof("Image tag is empty, using the image with explicit tag: %q", funct.Image)
-		processor, found := br.store.GetProcessorFromCache(funct.Image)
+		processor, found := br.store.GetScriptFromCache(funct.Image)
+		if !found {
+			processor, found = br.store.GetProcessorFromCache(funct.Image)
+		}
 		if !found {
 			return nil, &fn.NotFoundError{Function: *funct}
 		}
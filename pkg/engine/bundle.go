@@ -0,0 +1,352 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/repository"
+	"github.com/kptdev/porch/pkg/util"
+	pctx "github.com/kptdev/porch/pkg/util/context"
+	pkgerrors "github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+)
+
+// ExportRepository returns all package revisions of a repository with their resources, in
+// bundle order. Package revisions tracking the repository branch are left out, since the
+// repository derives them from the published package revisions.
+func (cad *cadEngine) ExportRepository(ctx context.Context, repositoryObj *configapi.Repository) ([]porchapi.BundledPackageRevision, error) {
+	ctx, span := tracer.Start(ctx, "cadEngine::ExportRepository", trace.WithAttributes())
+	defer span.End()
+
+	repo, err := cad.cache.OpenRepository(ctx, repositoryObj)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgRevs, err := repo.ListPackageRevisions(ctx, repository.ListPackageRevisionFilter{})
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "error listing package revisions of repository %q", repositoryObj.Name)
+	}
+
+	bundled := make([]porchapi.BundledPackageRevision, 0, len(pkgRevs))
+	for _, pkgRev := range pkgRevs {
+		if pkgRev.Key().Revision == -1 {
+			continue
+		}
+
+		apiPkgRev, err := pkgRev.GetPackageRevision(ctx)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "error reading package revision %q", pkgRev.KubeObjectName())
+		}
+		resources, err := pkgRev.GetResources(ctx)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "error reading resources of package revision %q", pkgRev.KubeObjectName())
+		}
+
+		bundled = append(bundled, porchapi.BundledPackageRevision{
+			PackageName:   apiPkgRev.Spec.PackageName,
+			WorkspaceName: apiPkgRev.Spec.WorkspaceName,
+			Revision:      apiPkgRev.Spec.Revision,
+			Lifecycle:     apiPkgRev.Spec.Lifecycle,
+			Tasks:         apiPkgRev.Spec.Tasks,
+			PublishedBy:   apiPkgRev.Status.PublishedBy,
+			PublishedAt:   apiPkgRev.Status.PublishedAt,
+			Resources:     resources.Spec.Resources,
+		})
+	}
+
+	SortBundledPackageRevisions(bundled)
+	return bundled, nil
+}
+
+// ImportRepository writes bundled package revisions into a repository, keeping their revision
+// numbers, lifecycles, tasks and publishing metadata. All package revisions are checked against
+// the repository and each other before anything is written; if any conflict is found, nothing is
+// written and the conflicts are returned. In dry-run mode, nothing is written either way. If
+// importing a package revision fails, the package revisions already imported are deleted again.
+// It returns the names of the package revisions that were, or in dry-run mode would be, created.
+func (cad *cadEngine) ImportRepository(ctx context.Context, repositoryObj *configapi.Repository, bundled []porchapi.BundledPackageRevision, dryRun bool) ([]string, []string, error) {
+	ctx, span := tracer.Start(ctx, "cadEngine::ImportRepository", trace.WithAttributes())
+	defer span.End()
+
+	repo, err := cad.cache.OpenRepository(ctx, repositoryObj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existing, err := repo.ListPackageRevisions(ctx, repository.ListPackageRevisionFilter{})
+	if err != nil {
+		return nil, nil, pkgerrors.Wrapf(err, "error listing package revisions of repository %q", repositoryObj.Name)
+	}
+
+	if conflicts := findBundleConflicts(repositoryObj.Name, bundled, existing); len(conflicts) > 0 {
+		return nil, conflicts, nil
+	}
+
+	bundled = append([]porchapi.BundledPackageRevision(nil), bundled...)
+	SortBundledPackageRevisions(bundled)
+
+	imported := make([]string, 0, len(bundled))
+	var created []repository.PackageRevision
+	for _, b := range bundled {
+		if dryRun {
+			pkgKey := repository.FromFullPathname(repo.Key(), b.PackageName)
+			imported = append(imported, repository.ComposePkgRevObjName(repository.PackageRevisionKey{
+				PkgKey:        pkgKey,
+				WorkspaceName: b.WorkspaceName,
+			}))
+			continue
+		}
+
+		pkgRev, err := cad.importPackageRevision(ctx, repo, repositoryObj, b)
+		if pkgRev != nil {
+			created = append(created, pkgRev)
+		}
+		if err != nil {
+			err = pkgerrors.Wrapf(err, "error importing package revision %s/%s", b.PackageName, b.WorkspaceName)
+			remaining := cad.rollbackImport(ctx, repo, created)
+			if len(remaining) > 0 {
+				return remaining, nil, pkgerrors.Wrapf(err, "could not delete imported package revisions %s", strings.Join(remaining, ", "))
+			}
+			return nil, nil, err
+		}
+		imported = append(imported, pkgRev.KubeObjectName())
+	}
+
+	klog.InfoS("[CaD Engine] Imported package revisions into repository",
+		pctx.LogMetadataFromWithExtras(ctx, "repository", repositoryObj.Name, "count", len(imported), "dryRun", dryRun)...)
+	return imported, nil, nil
+}
+
+// rollbackImport deletes the package revisions created by a failed import, newest first, and
+// returns the names of those that could not be deleted.
+func (cad *cadEngine) rollbackImport(ctx context.Context, repo repository.Repository, created []repository.PackageRevision) []string {
+	var remaining []string
+	for i := len(created) - 1; i >= 0; i-- {
+		pkgRev := created[i]
+		if err := repo.DeletePackageRevision(ctx, pkgRev); err != nil {
+			klog.Warningf("engine: could not delete imported PackageRevision %s/%s: %v", pkgRev.KubeObjectNamespace(), pkgRev.KubeObjectName(), err)
+			remaining = append(remaining, pkgRev.KubeObjectName())
+			continue
+		}
+		cad.watcherManager.NotifyPackageRevisionChange(watch.Deleted, pkgRev)
+	}
+	return remaining
+}
+
+// importPackageRevision creates a package revision from its bundled form. Published package
+// revisions are proposed first and then approved with their original publishing metadata, the
+// same way as package revisions created through the API. If it fails after the package revision
+// was created, it returns the package revision with the error.
+func (cad *cadEngine) importPackageRevision(ctx context.Context, repo repository.Repository, repositoryObj *configapi.Repository, b porchapi.BundledPackageRevision) (repository.PackageRevision, error) {
+	newPr := &porchapi.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: repositoryObj.Namespace,
+		},
+		Spec: porchapi.PackageRevisionSpec{
+			PackageName:    b.PackageName,
+			WorkspaceName:  b.WorkspaceName,
+			RepositoryName: repositoryObj.Name,
+			Lifecycle:      porchapi.PackageRevisionLifecycleDraft,
+			Tasks:          b.Tasks,
+		},
+	}
+
+	draft, err := repo.CreatePackageRevisionDraft(ctx, newPr)
+	if err != nil {
+		return nil, err
+	}
+
+	var task *porchapi.Task
+	if len(b.Tasks) > 0 {
+		task = &b.Tasks[0]
+	}
+	resources := &porchapi.PackageRevisionResources{
+		Spec: porchapi.PackageRevisionResourcesSpec{
+			PackageName:    b.PackageName,
+			WorkspaceName:  b.WorkspaceName,
+			RepositoryName: repositoryObj.Name,
+			Resources:      b.Resources,
+		},
+	}
+	if err := draft.UpdateResources(ctx, resources, task); err != nil {
+		return nil, err
+	}
+
+	lifecycle := b.Lifecycle
+	if porchapi.LifecycleIsPublished(lifecycle) {
+		lifecycle = porchapi.PackageRevisionLifecycleProposed
+	}
+	if err := draft.UpdateLifecycle(ctx, lifecycle); err != nil {
+		return nil, err
+	}
+	pkgRev, err := repo.ClosePackageRevisionDraft(ctx, draft, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !porchapi.LifecycleIsPublished(b.Lifecycle) {
+		return pkgRev, nil
+	}
+
+	publishCtx := repository.WithPublishInfo(ctx, repository.PublishInfo{
+		Revision:    b.Revision,
+		PublishedBy: b.PublishedBy,
+		PublishedAt: b.PublishedAt.Time,
+	})
+	draft, err = repo.UpdatePackageRevision(publishCtx, pkgRev)
+	if err != nil {
+		return pkgRev, err
+	}
+	if err := draft.UpdateLifecycle(publishCtx, porchapi.PackageRevisionLifecyclePublished); err != nil {
+		return pkgRev, err
+	}
+	published, err := repo.ClosePackageRevisionDraft(publishCtx, draft, b.Revision)
+	if err != nil {
+		return pkgRev, err
+	}
+	pkgRev = published
+
+	if b.Lifecycle == porchapi.PackageRevisionLifecycleDeletionProposed {
+		if err := pkgRev.UpdateLifecycle(ctx, b.Lifecycle); err != nil {
+			return pkgRev, err
+		}
+	}
+
+	sent := cad.watcherManager.NotifyPackageRevisionChange(watch.Modified, pkgRev)
+	klog.Infof("engine: sent %d for imported PackageRevision %s/%s", sent, pkgRev.KubeObjectNamespace(), pkgRev.KubeObjectName())
+	return pkgRev, nil
+}
+
+// findBundleConflicts returns a description of each bundled package revision that is invalid,
+// clashes with another bundled package revision or with a package revision in the repository.
+func findBundleConflicts(repoName string, bundled []porchapi.BundledPackageRevision, existing []repository.PackageRevision) []string {
+	var conflicts []string
+
+	type workspaceKey struct{ pkg, workspace string }
+	type revisionKey struct {
+		pkg      string
+		revision int
+	}
+	workspaces := map[workspaceKey]string{}
+	revisions := map[revisionKey]string{}
+	existingPkgs := map[string]bool{}
+	bundledPkgs := map[string]bool{}
+	for _, b := range bundled {
+		bundledPkgs[b.PackageName] = true
+	}
+	for _, pkgRev := range existing {
+		key := pkgRev.Key()
+		pkg := key.PkgKey.ToPkgPathname()
+		existingPkgs[pkg] = true
+		workspaces[workspaceKey{pkg, key.WorkspaceName}] = fmt.Sprintf("package revision %q in the repository", pkgRev.KubeObjectName())
+		if key.Revision > 0 {
+			revisions[revisionKey{pkg, key.Revision}] = fmt.Sprintf("package revision %q in the repository", pkgRev.KubeObjectName())
+		}
+	}
+
+	for i, b := range bundled {
+		name := fmt.Sprintf("%s/%s", b.PackageName, b.WorkspaceName)
+
+		switch b.Lifecycle {
+		case porchapi.PackageRevisionLifecycleDraft, porchapi.PackageRevisionLifecycleProposed:
+		case porchapi.PackageRevisionLifecyclePublished, porchapi.PackageRevisionLifecycleDeletionProposed:
+			if b.Revision <= 0 {
+				conflicts = append(conflicts, fmt.Sprintf("%s: published package revision has no revision number", name))
+				continue
+			}
+		default:
+			conflicts = append(conflicts, fmt.Sprintf("%s: unsupported lifecycle %q", name, b.Lifecycle))
+			continue
+		}
+
+		pkgPath, pkgName := repository.SplitPackagePathName(b.PackageName)
+		if err := util.ValidPkgRevObjName(repoName, pkgPath, pkgName, b.WorkspaceName); err != nil {
+			conflicts = append(conflicts, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+
+		if !existingPkgs[b.PackageName] {
+			newPr := &porchapi.PackageRevision{Spec: porchapi.PackageRevisionSpec{PackageName: b.PackageName, RepositoryName: repoName}}
+			if err := repository.ValidatePackagePathOverlap(newPr, existing); err != nil {
+				conflicts = append(conflicts, fmt.Sprintf("%s: %v", name, err))
+				continue
+			}
+		}
+
+		if outer := enclosingPackage(b.PackageName, bundledPkgs); outer != "" {
+			conflicts = append(conflicts, fmt.Sprintf("%s: package path %q is nested in bundled package %q: packages cannot be nested", name, b.PackageName, outer))
+			continue
+		}
+
+		self := fmt.Sprintf("bundled package revision %d", i)
+		if other, found := workspaces[workspaceKey{b.PackageName, b.WorkspaceName}]; found {
+			conflicts = append(conflicts, fmt.Sprintf("%s: workspace name %q is already used by %s", name, b.WorkspaceName, other))
+			continue
+		}
+		workspaces[workspaceKey{b.PackageName, b.WorkspaceName}] = self
+
+		if porchapi.LifecycleIsPublished(b.Lifecycle) {
+			if other, found := revisions[revisionKey{b.PackageName, b.Revision}]; found {
+				conflicts = append(conflicts, fmt.Sprintf("%s: revision %d is already used by %s", name, b.Revision, other))
+				continue
+			}
+			revisions[revisionKey{b.PackageName, b.Revision}] = self
+		}
+	}
+
+	return conflicts
+}
+
+// enclosingPackage returns the package of the given packages that the package is nested in, or
+// an empty string if it is not nested in any of them.
+func enclosingPackage(pkg string, pkgs map[string]bool) string {
+	for dir := path.Dir(pkg); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if pkgs[dir] {
+			return dir
+		}
+	}
+	return ""
+}
+
+// SortBundledPackageRevisions sorts bundled package revisions by package name and, within a
+// package, puts the published revisions first in ascending revision order, followed by the
+// unpublished revisions ordered by workspace name. Importing package revisions in this order
+// publishes the revisions of each package in the order they were originally published.
+func SortBundledPackageRevisions(bundled []porchapi.BundledPackageRevision) {
+	sort.SliceStable(bundled, func(i, j int) bool {
+		a, b := bundled[i], bundled[j]
+		if a.PackageName != b.PackageName {
+			return a.PackageName < b.PackageName
+		}
+		aPublished, bPublished := porchapi.LifecycleIsPublished(a.Lifecycle), porchapi.LifecycleIsPublished(b.Lifecycle)
+		if aPublished != bPublished {
+			return aPublished
+		}
+		if aPublished && a.Revision != b.Revision {
+			return a.Revision < b.Revision
+		}
+		return a.WorkspaceName < b.WorkspaceName
+	})
+}
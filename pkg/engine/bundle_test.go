@@ -0,0 +1,278 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/kptdev/porch/pkg/externalrepo/fake"
+	"github.com/kptdev/porch/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newBundlePkgRev(pkg, ws string, rev int, lifecycle porchapi.PackageRevisionLifecycle) *fake.FakePackageRevision {
+	return &fake.FakePackageRevision{
+		PrKey: repository.PackageRevisionKey{
+			PkgKey: repository.PackageKey{
+				RepoKey: repository.RepositoryKey{Namespace: "default", Name: "test-repo"},
+				Package: pkg,
+			},
+			Revision:      rev,
+			WorkspaceName: ws,
+		},
+		PackageLifecycle: lifecycle,
+		PackageRevision: &porchapi.PackageRevision{
+			Spec: porchapi.PackageRevisionSpec{
+				RepositoryName: "test-repo",
+				PackageName:    pkg,
+				WorkspaceName:  ws,
+				Revision:       rev,
+				Lifecycle:      lifecycle,
+			},
+		},
+		Resources: &porchapi.PackageRevisionResources{
+			Spec: porchapi.PackageRevisionResourcesSpec{
+				Resources: map[string]string{"Kptfile": "kind: Kptfile\nmetadata:\n  name: " + pkg + "\n"},
+			},
+		},
+	}
+}
+
+func TestExportRepository(t *testing.T) {
+	f := newTestFixture(t)
+
+	publishedAt := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	v2 := newBundlePkgRev("base", "v2", 2, porchapi.PackageRevisionLifecyclePublished)
+	v2.PackageRevision.Status.PublishedBy = "bob"
+	v2.PackageRevision.Status.PublishedAt = publishedAt
+	v1 := newBundlePkgRev("base", "v1", 1, porchapi.PackageRevisionLifecyclePublished)
+	branch := newBundlePkgRev("base", "main", -1, porchapi.PackageRevisionLifecyclePublished)
+	draft := newBundlePkgRev("base", "draft", 0, porchapi.PackageRevisionLifecycleDraft)
+	app := newBundlePkgRev("app", "ws", 0, porchapi.PackageRevisionLifecycleProposed)
+
+	f.mockRepo.On("ListPackageRevisions", mock.Anything, mock.Anything).Return([]repository.PackageRevision{draft, v2, branch, app, v1}, nil)
+
+	bundled, err := f.engine.ExportRepository(context.TODO(), f.repositoryObj)
+	require.NoError(t, err)
+
+	var names []string
+	for _, b := range bundled {
+		names = append(names, b.PackageName+"/"+b.WorkspaceName)
+	}
+	assert.Equal(t, []string{"app/ws", "base/v1", "base/v2", "base/draft"}, names)
+
+	assert.Equal(t, 2, bundled[2].Revision)
+	assert.Equal(t, "bob", bundled[2].PublishedBy)
+	assert.Equal(t, publishedAt, bundled[2].PublishedAt)
+	assert.Equal(t, v2.Resources.Spec.Resources, bundled[2].Resources)
+}
+
+func TestImportRepositoryConflicts(t *testing.T) {
+	existing := []repository.PackageRevision{
+		newBundlePkgRev("base", "v1", 1, porchapi.PackageRevisionLifecyclePublished),
+		newBundlePkgRev("apps/frontend", "ws", 0, porchapi.PackageRevisionLifecycleDraft),
+	}
+
+	tests := []struct {
+		name    string
+		bundled []porchapi.BundledPackageRevision
+		want    string
+	}{
+		{
+			name:    "workspace already used in repository",
+			bundled: []porchapi.BundledPackageRevision{{PackageName: "base", WorkspaceName: "v1", Revision: 3, Lifecycle: porchapi.PackageRevisionLifecyclePublished}},
+			want:    `workspace name "v1" is already used`,
+		},
+		{
+			name:    "revision already used in repository",
+			bundled: []porchapi.BundledPackageRevision{{PackageName: "base", WorkspaceName: "v1-copy", Revision: 1, Lifecycle: porchapi.PackageRevisionLifecyclePublished}},
+			want:    "revision 1 is already used",
+		},
+		{
+			name: "revision used twice in bundle",
+			bundled: []porchapi.BundledPackageRevision{
+				{PackageName: "other", WorkspaceName: "a", Revision: 1, Lifecycle: porchapi.PackageRevisionLifecyclePublished},
+				{PackageName: "other", WorkspaceName: "b", Revision: 1, Lifecycle: porchapi.PackageRevisionLifecyclePublished},
+			},
+			want: "revision 1 is already used by bundled package revision 0",
+		},
+		{
+			name:    "published without revision",
+			bundled: []porchapi.BundledPackageRevision{{PackageName: "other", WorkspaceName: "a", Lifecycle: porchapi.PackageRevisionLifecyclePublished}},
+			want:    "has no revision number",
+		},
+		{
+			name:    "unsupported lifecycle",
+			bundled: []porchapi.BundledPackageRevision{{PackageName: "other", WorkspaceName: "a", Lifecycle: "Unknown"}},
+			want:    `unsupported lifecycle "Unknown"`,
+		},
+		{
+			name:    "nested package",
+			bundled: []porchapi.BundledPackageRevision{{PackageName: "base/nested", WorkspaceName: "a", Lifecycle: porchapi.PackageRevisionLifecycleDraft}},
+			want:    "packages cannot be nested",
+		},
+		{
+			name: "package nested in bundled package",
+			bundled: []porchapi.BundledPackageRevision{
+				{PackageName: "other", WorkspaceName: "a", Lifecycle: porchapi.PackageRevisionLifecycleDraft},
+				{PackageName: "other/nested", WorkspaceName: "a", Lifecycle: porchapi.PackageRevisionLifecycleDraft},
+			},
+			want: `package path "other/nested" is nested in bundled package "other"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestFixture(t)
+			f.mockRepo.On("ListPackageRevisions", mock.Anything, mock.Anything).Return(existing, nil)
+
+			imported, conflicts, err := f.engine.ImportRepository(context.TODO(), f.repositoryObj, tt.bundled, false)
+			require.NoError(t, err)
+			assert.Empty(t, imported)
+			require.Len(t, conflicts, 1)
+			assert.Contains(t, conflicts[0], tt.want)
+			f.mockRepo.AssertNotCalled(t, "CreatePackageRevisionDraft", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestImportRepository(t *testing.T) {
+	publishedAt := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	bundled := []porchapi.BundledPackageRevision{
+		{
+			PackageName:   "base",
+			WorkspaceName: "draft",
+			Lifecycle:     porchapi.PackageRevisionLifecycleDraft,
+			Resources:     map[string]string{"Kptfile": "kind: Kptfile\n"},
+		},
+		{
+			PackageName:   "base",
+			WorkspaceName: "v3",
+			Revision:      3,
+			Lifecycle:     porchapi.PackageRevisionLifecyclePublished,
+			PublishedBy:   "alice",
+			PublishedAt:   publishedAt,
+			Resources:     map[string]string{"Kptfile": "kind: Kptfile\n"},
+		},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		f := newTestFixture(t)
+		f.mockRepo.On("ListPackageRevisions", mock.Anything, mock.Anything).Return([]repository.PackageRevision{}, nil)
+		f.mockRepo.On("Key").Return(repository.RepositoryKey{Namespace: "default", Name: "test-repo"})
+
+		imported, conflicts, err := f.engine.ImportRepository(context.TODO(), f.repositoryObj, bundled, true)
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+		assert.Equal(t, []string{"test-repo.base.v3", "test-repo.base.draft"}, imported)
+		f.mockRepo.AssertNotCalled(t, "CreatePackageRevisionDraft", mock.Anything, mock.Anything)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		f := newTestFixture(t)
+		f.engine.watcherManager = NewWatcherManager(DefaultWatchHistorySize)
+		f.mockRepo.On("ListPackageRevisions", mock.Anything, mock.Anything).Return([]repository.PackageRevision{}, nil)
+
+		drafts := map[string]*fake.FakePackageRevision{}
+		f.mockRepo.On("CreatePackageRevisionDraft", mock.Anything, mock.Anything).Return(
+			func(_ context.Context, pr *porchapi.PackageRevision) (repository.PackageRevisionDraft, error) {
+				assert.Equal(t, porchapi.PackageRevisionLifecycleDraft, pr.Spec.Lifecycle)
+				draft := newBundlePkgRev(pr.Spec.PackageName, pr.Spec.WorkspaceName, 0, pr.Spec.Lifecycle)
+				drafts[pr.Spec.WorkspaceName] = draft
+				return draft, nil
+			})
+		f.mockRepo.On("ClosePackageRevisionDraft", mock.Anything, mock.Anything, 0).Return(
+			func(_ context.Context, draft repository.PackageRevisionDraft, _ int) (repository.PackageRevision, error) {
+				return draft.(*fake.FakePackageRevision), nil
+			})
+		f.mockRepo.On("UpdatePackageRevision", mock.Anything, mock.Anything).Return(
+			func(_ context.Context, pr repository.PackageRevision) (repository.PackageRevisionDraft, error) {
+				return pr.(*fake.FakePackageRevision), nil
+			})
+		f.mockRepo.On("ClosePackageRevisionDraft", mock.MatchedBy(func(ctx context.Context) bool {
+			info, ok := repository.PublishInfoFrom(ctx)
+			return ok && info.Revision == 3 && info.PublishedBy == "alice" && info.PublishedAt.Equal(publishedAt.Time)
+		}), mock.Anything, 3).Return(
+			func(_ context.Context, draft repository.PackageRevisionDraft, _ int) (repository.PackageRevision, error) {
+				return draft.(*fake.FakePackageRevision), nil
+			})
+
+		imported, conflicts, err := f.engine.ImportRepository(context.TODO(), f.repositoryObj, bundled, false)
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+		assert.Equal(t, []string{"test-repo.base.v3", "test-repo.base.draft"}, imported)
+
+		assert.Equal(t, porchapi.PackageRevisionLifecyclePublished, drafts["v3"].PackageLifecycle)
+		assert.Equal(t, porchapi.PackageRevisionLifecycleDraft, drafts["draft"].PackageLifecycle)
+		assert.Equal(t, bundled[0].Resources, drafts["draft"].Resources.Spec.Resources)
+		f.mockRepo.AssertNumberOfCalls(t, "UpdatePackageRevision", 1)
+	})
+
+	t.Run("failure deletes imported package revisions", func(t *testing.T) {
+		f := newTestFixture(t)
+		f.engine.watcherManager = NewWatcherManager(DefaultWatchHistorySize)
+		f.mockRepo.On("ListPackageRevisions", mock.Anything, mock.Anything).Return([]repository.PackageRevision{}, nil)
+
+		var v3 *fake.FakePackageRevision
+		f.mockRepo.On("CreatePackageRevisionDraft", mock.Anything, mock.Anything).Return(
+			func(_ context.Context, pr *porchapi.PackageRevision) (repository.PackageRevisionDraft, error) {
+				if pr.Spec.WorkspaceName == "draft" {
+					return nil, errors.New("create failed")
+				}
+				v3 = newBundlePkgRev(pr.Spec.PackageName, pr.Spec.WorkspaceName, 0, pr.Spec.Lifecycle)
+				return v3, nil
+			})
+		f.mockRepo.On("ClosePackageRevisionDraft", mock.Anything, mock.Anything, mock.Anything).Return(
+			func(_ context.Context, draft repository.PackageRevisionDraft, _ int) (repository.PackageRevision, error) {
+				return draft.(*fake.FakePackageRevision), nil
+			})
+		f.mockRepo.On("UpdatePackageRevision", mock.Anything, mock.Anything).Return(
+			func(_ context.Context, pr repository.PackageRevision) (repository.PackageRevisionDraft, error) {
+				return pr.(*fake.FakePackageRevision), nil
+			})
+		f.mockRepo.On("DeletePackageRevision", mock.Anything, mock.Anything).Return(nil)
+
+		imported, conflicts, err := f.engine.ImportRepository(context.TODO(), f.repositoryObj, bundled, false)
+		require.ErrorContains(t, err, "create failed")
+		assert.Empty(t, conflicts)
+		assert.Empty(t, imported)
+
+		f.mockRepo.AssertNumberOfCalls(t, "DeletePackageRevision", 1)
+		f.mockRepo.AssertCalled(t, "DeletePackageRevision", mock.Anything, v3)
+	})
+}
+
+func TestSortBundledPackageRevisions(t *testing.T) {
+	bundled := []porchapi.BundledPackageRevision{
+		{PackageName: "b", WorkspaceName: "ws", Lifecycle: porchapi.PackageRevisionLifecycleDraft},
+		{PackageName: "b", WorkspaceName: "v10", Revision: 10, Lifecycle: porchapi.PackageRevisionLifecycleDeletionProposed},
+		{PackageName: "b", WorkspaceName: "v2", Revision: 2, Lifecycle: porchapi.PackageRevisionLifecyclePublished},
+		{PackageName: "a", WorkspaceName: "proposal", Lifecycle: porchapi.PackageRevisionLifecycleProposed},
+	}
+	SortBundledPackageRevisions(bundled)
+
+	var names []string
+	for _, b := range bundled {
+		names = append(names, b.PackageName+"/"+b.WorkspaceName)
+	}
+	assert.Equal(t, []string{"a/proposal", "b/v2", "b/v10", "b/ws"}, names)
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
 	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
@@ -48,6 +49,9 @@ type CaDEngine interface {
 
 	UpdatePackageResources(ctx context.Context, repositoryObj *configapi.Repository, oldPackage repository.PackageRevision, old, new *porchapi.PackageRevisionResources) (repository.PackageRevision, *porchapi.RenderStatus, error)
 	UpdatePackageResourcesWithoutRender(ctx context.Context, repositoryObj *configapi.Repository, oldPackage repository.PackageRevision, old, new *porchapi.PackageRevisionResources) (repository.PackageRevision, error)
+	UpdatePackageConditions(ctx context.Context, repositoryObj *configapi.Repository, oldPackage repository.PackageRevision, old, new *porchapi.PackageRevisionResources) (repository.PackageRevision, error)
+	RenderPackageResources(ctx context.Context, namespace string, resources map[string]string) (map[string]string, *porchapi.RenderStatus, error)
+	PreviewUpgrade(ctx context.Context, namespace string, spec *porchapi.PackageUpgradeTaskSpec) ([]porchapi.UpgradePreviewResource, error)
 
 	ListPackageRevisions(ctx context.Context, filter repository.ListPackageRevisionFilter) ([]repository.PackageRevision, error)
 	CreatePackageRevision(ctx context.Context, repositoryObj *configapi.Repository, obj *porchapi.PackageRevision, parent repository.PackageRevision) (repository.PackageRevision, error)
@@ -57,6 +61,12 @@ type CaDEngine interface {
 	ListPackages(ctx context.Context, repositorySpec *configapi.Repository, filter repository.ListPackageFilter) ([]repository.Package, error)
 
 	FindAllUpstreamReferencesInRepositories(ctx context.Context, namespace, prName string) (string, error)
+	FindAllDownstreamPackageRevisions(ctx context.Context, namespace, prName string) ([]string, error)
+
+	ExportRepository(ctx context.Context, repositoryObj *configapi.Repository) ([]porchapi.BundledPackageRevision, error)
+	ImportRepository(ctx context.Context, repositoryObj *configapi.Repository, bundled []porchapi.BundledPackageRevision, dryRun bool) ([]string, []string, error)
+
+	BatchRepositoryUpdates(ctx context.Context, repositoryObj *configapi.Repository, updates func(ctx context.Context) error) error
 }
 
 func NewCaDEngine(opts ...EngineOption) (CaDEngine, error) {
@@ -527,6 +537,10 @@ func (cad *cadEngine) FindAllUpstreamReferencesInRepositories(ctx context.Contex
 	return cad.cache.FindAllUpstreamReferencesInRepositories(ctx, namespace, prName)
 }
 
+func (cad *cadEngine) FindAllDownstreamPackageRevisions(ctx context.Context, namespace, prName string) ([]string, error) {
+	return cad.cache.FindAllDownstreamPackageRevisions(ctx, namespace, prName)
+}
+
 // UpdatePackageResourcesWithoutRender writes new resources without rendering.
 // Used by the PRR handler for v1alpha2 repos where the PR controller renders async.
 func (cad *cadEngine) UpdatePackageResourcesWithoutRender(ctx context.Context, repositoryObj *configapi.Repository, pr2Update repository.PackageRevision, oldRes, newRes *porchapi.PackageRevisionResources) (repository.PackageRevision, error) {
@@ -535,6 +549,23 @@ func (cad *cadEngine) UpdatePackageResourcesWithoutRender(ctx context.Context, r
 
 	klog.InfoS("[CaD Engine] Writing resources without render for v1alpha2", pctx.LogMetadataFrom(ctx)...)
 
+	return cad.writeResourcesWithoutRender(ctx, repositoryObj, pr2Update, oldRes, newRes, porchapi.PackageRevisionLifecycleDraft)
+}
+
+// UpdatePackageConditions writes new resources, which differ from the old ones only in the
+// conditions in the Kptfile status, to a Draft or Proposed package revision without rendering.
+func (cad *cadEngine) UpdatePackageConditions(ctx context.Context, repositoryObj *configapi.Repository, pr2Update repository.PackageRevision, oldRes, newRes *porchapi.PackageRevisionResources) (repository.PackageRevision, error) {
+	ctx, span := tracer.Start(ctx, "cadEngine::UpdatePackageConditions", trace.WithAttributes())
+	defer span.End()
+
+	klog.InfoS("[CaD Engine] Writing package conditions", pctx.LogMetadataFrom(ctx)...)
+
+	return cad.writeResourcesWithoutRender(ctx, repositoryObj, pr2Update, oldRes, newRes,
+		porchapi.PackageRevisionLifecycleDraft, porchapi.PackageRevisionLifecycleProposed)
+}
+
+func (cad *cadEngine) writeResourcesWithoutRender(ctx context.Context, repositoryObj *configapi.Repository, pr2Update repository.PackageRevision, oldRes, newRes *porchapi.PackageRevisionResources,
+	allowedLifecycles ...porchapi.PackageRevisionLifecycle) (repository.PackageRevision, error) {
 	newRV := newRes.GetResourceVersion()
 	if len(newRV) == 0 {
 		return nil, fmt.Errorf("resourceVersion must be specified for an update")
@@ -543,10 +574,12 @@ func (cad *cadEngine) UpdatePackageResourcesWithoutRender(ctx context.Context, r
 		return nil, apierrors.NewConflict(porchapi.Resource("packagerevisionresources"), oldRes.GetName(), errors.New(OptimisticLockErrorMsg))
 	}
 
-	switch lifecycle := pr2Update.Lifecycle(ctx); lifecycle {
-	case porchapi.PackageRevisionLifecycleDraft:
-	default:
-		return nil, fmt.Errorf("cannot update a package revision with lifecycle value %q; package must be Draft", lifecycle)
+	if lifecycle := pr2Update.Lifecycle(ctx); !slices.Contains(allowedLifecycles, lifecycle) {
+		allowed := make([]string, len(allowedLifecycles))
+		for i, l := range allowedLifecycles {
+			allowed[i] = string(l)
+		}
+		return nil, fmt.Errorf("cannot update a package revision with lifecycle value %q; package must be %s", lifecycle, strings.Join(allowed, " or "))
 	}
 
 	if err := util.ValidateResourcePaths(newRes.Spec.Resources); err != nil {
@@ -574,6 +607,29 @@ func (cad *cadEngine) UpdatePackageResourcesWithoutRender(ctx context.Context, r
 	return repo.ClosePackageRevisionDraft(ctx, draft, 0)
 }
 
+// RenderPackageResources renders the given package resources in the kpt function pipeline
+// without saving them, so that the outcome of a change can be previewed.
+func (cad *cadEngine) RenderPackageResources(ctx context.Context, namespace string, resources map[string]string) (map[string]string, *porchapi.RenderStatus, error) {
+	ctx, span := tracer.Start(ctx, "cadEngine::RenderPackageResources", trace.WithAttributes())
+	defer span.End()
+
+	if err := util.ValidateResourcePaths(resources); err != nil {
+		return nil, nil, err
+	}
+
+	rendered, renderStatus, err := cad.taskHandler.RenderResources(ctx, namespace, repository.PackageResources{Contents: resources})
+	return rendered.Contents, renderStatus, err
+}
+
+// PreviewUpgrade performs the given upgrade without creating a draft and reports the outcome for
+// each resource of the package.
+func (cad *cadEngine) PreviewUpgrade(ctx context.Context, namespace string, spec *porchapi.PackageUpgradeTaskSpec) ([]porchapi.UpgradePreviewResource, error) {
+	ctx, span := tracer.Start(ctx, "cadEngine::PreviewUpgrade", trace.WithAttributes())
+	defer span.End()
+
+	return cad.taskHandler.PreviewUpgrade(ctx, namespace, spec)
+}
+
 // handleMutationError decides whether to bail out or allow push-on-render-failure.
 // Returns a non-nil error to signal the caller should return immediately.
 // Returns a nil error to signal the caller should proceed to close the draft.
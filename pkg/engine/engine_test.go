@@ -222,6 +222,16 @@ func (m *mockTaskHandler) DoPRResourceMutations(ctx context.Context, pr2Update r
 	return args.Get(0).(*porchapi.RenderStatus), args.Error(1)
 }
 
+func (m *mockTaskHandler) RenderResources(ctx context.Context, namespace string, resources repository.PackageResources) (repository.PackageResources, *porchapi.RenderStatus, error) {
+	args := m.Called(ctx, namespace, resources)
+	return args.Get(0).(repository.PackageResources), args.Get(1).(*porchapi.RenderStatus), args.Error(2)
+}
+
+func (m *mockTaskHandler) PreviewUpgrade(ctx context.Context, namespace string, spec *porchapi.PackageUpgradeTaskSpec) ([]porchapi.UpgradePreviewResource, error) {
+	args := m.Called(ctx, namespace, spec)
+	return args.Get(0).([]porchapi.UpgradePreviewResource), args.Error(1)
+}
+
 func (m *mockTaskHandler) GetRuntime() fn.FunctionRuntime {
 	args := m.Called()
 	return args.Get(0).(fn.FunctionRuntime)
@@ -235,6 +245,10 @@ func (m *mockTaskHandler) SetReferenceResolver(resolver repository.ReferenceReso
 	m.Called(resolver)
 }
 
+func (m *mockTaskHandler) SetSourcePolicyChecker(checker repository.SourcePolicyChecker) {
+	m.Called(checker)
+}
+
 func (m *mockTaskHandler) SetRepoOpener(opener repository.RepositoryOpener) {
 	m.Called(opener)
 }
@@ -292,6 +306,11 @@ func (m *mockCache) FindAllUpstreamReferencesInRepositories(ctx context.Context,
 	return args.String(0), args.Error(1)
 }
 
+func (m *mockCache) FindAllDownstreamPackageRevisions(ctx context.Context, namespace, prName string) ([]string, error) {
+	args := m.Called(ctx, namespace, prName)
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *mockCache) ListPackageRevisions(ctx context.Context, filter repository.ListPackageRevisionFilter) ([]repository.PackageRevision, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).([]repository.PackageRevision), args.Error(1)
@@ -742,6 +761,23 @@ func TestFindUpstreamReference(t *testing.T) {
 	}
 }
 
+func TestFindAllDownstreamPackageRevisions(t *testing.T) {
+	mockCache := &mockCache{}
+	mockCache.On("FindAllDownstreamPackageRevisions", mock.Anything, "default", "upstream-pkg").Return([]string{"downstream-a", "downstream-b"}, nil).Once()
+	mockCache.On("FindAllDownstreamPackageRevisions", mock.Anything, "default", "broken-pkg").Return([]string(nil), fmt.Errorf("cache error")).Once()
+
+	engine := &cadEngine{cache: mockCache}
+
+	result, err := engine.FindAllDownstreamPackageRevisions(context.Background(), "default", "upstream-pkg")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"downstream-a", "downstream-b"}, result)
+
+	_, err = engine.FindAllDownstreamPackageRevisions(context.Background(), "default", "broken-pkg")
+	assert.ErrorContains(t, err, "cache error")
+
+	mockCache.AssertExpectations(t)
+}
+
 func TestUpdatePackageResourcesRenderFailure(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -892,6 +928,50 @@ func TestUpdatePackageResourcesRenderFailure(t *testing.T) {
 	}
 }
 
+func TestRenderPackageResources(t *testing.T) {
+	mockTaskHandler := &mockTaskHandler{}
+	engine := &cadEngine{
+		taskHandler: mockTaskHandler,
+	}
+
+	resources := map[string]string{"Kptfile": "kptfile", "cm.yaml": "cm"}
+	rendered := map[string]string{"Kptfile": "kptfile", "cm.yaml": "rendered cm"}
+	status := &porchapi.RenderStatus{}
+	mockTaskHandler.On("RenderResources", mock.Anything, "default", repository.PackageResources{Contents: resources}).
+		Return(repository.PackageResources{Contents: rendered}, status, nil).Once()
+
+	gotResources, gotStatus, err := engine.RenderPackageResources(context.Background(), "default", resources)
+	require.NoError(t, err)
+	assert.Equal(t, rendered, gotResources)
+	assert.Same(t, status, gotStatus)
+
+	_, _, err = engine.RenderPackageResources(context.Background(), "default", map[string]string{"../Kptfile": "kptfile"})
+	assert.ErrorContains(t, err, "invalid resource path")
+
+	mockTaskHandler.AssertExpectations(t)
+}
+
+func TestPreviewUpgrade(t *testing.T) {
+	mockTaskHandler := &mockTaskHandler{}
+	engine := &cadEngine{
+		taskHandler: mockTaskHandler,
+	}
+
+	spec := &porchapi.PackageUpgradeTaskSpec{
+		OldUpstream:             porchapi.PackageRevisionRef{Name: "repo.pkg.v1"},
+		NewUpstream:             porchapi.PackageRevisionRef{Name: "repo.pkg.v2"},
+		LocalPackageRevisionRef: porchapi.PackageRevisionRef{Name: "repo.local.v1"},
+	}
+	preview := []porchapi.UpgradePreviewResource{{File: "cm.yaml", Kind: "ConfigMap", Name: "cm", Outcome: porchapi.UpgradeOutcomeUpstream}}
+	mockTaskHandler.On("PreviewUpgrade", mock.Anything, "default", spec).Return(preview, nil).Once()
+
+	got, err := engine.PreviewUpgrade(context.Background(), "default", spec)
+	require.NoError(t, err)
+	assert.Equal(t, preview, got)
+
+	mockTaskHandler.AssertExpectations(t)
+}
+
 func TestUpdatePackageResourcesWithoutRender(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1027,3 +1107,52 @@ func TestUpdatePackageResourcesWithoutRender(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdatePackageConditions(t *testing.T) {
+	repositoryObj := &configapi.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-repo",
+			Namespace: "default",
+		},
+	}
+	oldRes := &porchapi.PackageRevisionResources{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pkg", ResourceVersion: "1"},
+	}
+	newRes := &porchapi.PackageRevisionResources{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pkg", ResourceVersion: "1"},
+		Spec: porchapi.PackageRevisionResourcesSpec{
+			Resources: map[string]string{"Kptfile": "test"},
+		},
+	}
+
+	t.Run("proposed package revision is updated", func(t *testing.T) {
+		mockRepo := &mockrepo.MockRepository{}
+		mockCache := &mockCache{}
+		mockPkgRev := &mockrepo.MockPackageRevision{}
+		mockDraft := &mockrepo.MockPackageRevisionDraft{}
+
+		mockPkgRev.On("Lifecycle", mock.Anything).Return(porchapi.PackageRevisionLifecycleProposed)
+		mockCache.On("OpenRepository", mock.Anything, repositoryObj).Return(mockRepo, nil)
+		mockRepo.On("UpdatePackageRevision", mock.Anything, mockPkgRev).Return(mockDraft, nil)
+		mockDraft.On("UpdateResources", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("ClosePackageRevisionDraft", mock.Anything, mockDraft, 0).Return(&mockrepo.MockPackageRevision{}, nil)
+
+		engine := &cadEngine{cache: mockCache}
+		result, err := engine.UpdatePackageConditions(context.Background(), repositoryObj, mockPkgRev, oldRes, newRes)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+
+		mockRepo.AssertExpectations(t)
+		mockCache.AssertExpectations(t)
+		mockDraft.AssertExpectations(t)
+	})
+
+	t.Run("published package revision is rejected", func(t *testing.T) {
+		mockPkgRev := &mockrepo.MockPackageRevision{}
+		mockPkgRev.On("Lifecycle", mock.Anything).Return(porchapi.PackageRevisionLifecyclePublished)
+
+		engine := &cadEngine{cache: &mockCache{}}
+		_, err := engine.UpdatePackageConditions(context.Background(), repositoryObj, mockPkgRev, oldRes, newRes)
+		assert.ErrorContains(t, err, `package must be Draft or Proposed`)
+	})
+}
@@ -24,9 +24,12 @@ import (
 	"github.com/kptdev/kpt/pkg/lib/kptops"
 	"github.com/kptdev/porch/controllers/functionconfigs/reconciler"
 	"github.com/kptdev/porch/func/evaluator"
+	pctx "github.com/kptdev/porch/pkg/util/context"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"k8s.io/klog/v2"
 )
 
@@ -108,8 +111,14 @@ func (gr *grpcRunner) Run(r io.Reader, w io.Writer) error {
 		ResourceList: in,
 		Image:        gr.image,
 		Tag:          gr.tag,
+		Namespace:    pctx.GetNamespace(gr.ctx),
+		Priority:     pctx.GetPriority(gr.ctx),
 	})
 	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.PermissionDenied {
+			// The function runner rejected the image, report its reason rather than the gRPC error
+			return fmt.Errorf("func eval %q failed: %s", gr.image, st.Message())
+		}
 		return fmt.Errorf("func eval %q failed: %w", gr.image, err)
 	}
 	if _, err := w.Write(res.ResourceList); err != nil {
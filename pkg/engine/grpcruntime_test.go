@@ -26,6 +26,7 @@ import (
 	v1 "github.com/kptdev/kpt/api/kptfile/v1"
 	"github.com/kptdev/porch/controllers/functionconfigs/reconciler"
 	"github.com/kptdev/porch/func/evaluator"
+	pctx "github.com/kptdev/porch/pkg/util/context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -214,6 +215,48 @@ items:
 	assert.Contains(t, err.Error(), "func eval")
 }
 
+func TestGRPCRunnerRunPassesNamespaceAndPriority(t *testing.T) {
+	var namespace string
+	var priority int32
+	client := &mockClient{
+		evaluateFunc: func(ctx context.Context, req *evaluator.EvaluateFunctionRequest) (*evaluator.EvaluateFunctionResponse, error) {
+			namespace = req.Namespace
+			priority = req.Priority
+			return &evaluator.EvaluateFunctionResponse{ResourceList: req.ResourceList}, nil
+		},
+	}
+	runner := &grpcRunner{
+		ctx:    pctx.WithPriority(pctx.WithNamespace(t.Context(), "test-ns"), evaluator.PriorityInteractive),
+		client: client,
+		image:  testImage,
+		tag:    testTag,
+	}
+
+	var writer bytes.Buffer
+	require.NoError(t, runner.Run(strings.NewReader("kind: ResourceList\n"), &writer))
+	assert.Equal(t, "test-ns", namespace)
+	assert.Equal(t, evaluator.PriorityInteractive, priority)
+}
+
+func TestGRPCRunnerRunImageRejected(t *testing.T) {
+	client := &mockClient{
+		evaluateFunc: func(ctx context.Context, req *evaluator.EvaluateFunctionRequest) (*evaluator.EvaluateFunctionResponse, error) {
+			return nil, status.Error(codes.PermissionDenied, `function image "test-image" rejected: not allowed by FunctionImagePolicy test-ns/restricted`)
+		},
+	}
+	runner := &grpcRunner{
+		ctx:    t.Context(),
+		client: client,
+		image:  testImage,
+		tag:    testTag,
+	}
+
+	var writer bytes.Buffer
+	err := runner.Run(strings.NewReader("kind: ResourceList\n"), &writer)
+	require.Error(t, err)
+	assert.Equal(t, `func eval "test-image" failed: function image "test-image" rejected: not allowed by FunctionImagePolicy test-ns/restricted`, err.Error())
+}
+
 func TestGRPCRunnerRunReadError(t *testing.T) {
 	runner := &grpcRunner{
 		ctx:    t.Context(),
@@ -107,6 +107,13 @@ func WithReferenceResolver(resolver repository.ReferenceResolver) EngineOption {
 	})
 }
 
+func WithSourcePolicyChecker(checker repository.SourcePolicyChecker) EngineOption {
+	return EngineOptionFunc(func(engine *cadEngine) error {
+		engine.taskHandler.SetSourcePolicyChecker(checker)
+		return nil
+	})
+}
+
 func WithUserInfoProvider(provider repository.UserInfoProvider) EngineOption {
 	return EngineOptionFunc(func(engine *cadEngine) error {
 		engine.userInfoProvider = provider
@@ -1,4 +1,4 @@
-// Copyright 2022, 2024 The kpt Authors
+// Copyright 2022, 2024, 2026 The kpt Authors
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,16 +16,39 @@ package engine
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
 	"sync"
+	"time"
 
+	cachetypes "github.com/kptdev/porch/pkg/cache/types"
 	"github.com/kptdev/porch/pkg/repository"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/klog/v2"
 )
 
+// DefaultWatchHistorySize is the default number of package revision events that are kept,
+// so that watches can resume from the resource version of one of them.
+const DefaultWatchHistorySize = 10000
+
+// ErrUnknownResourceVersion is returned when resuming a watch from a resource version that is neither one of
+// the watcher manager's event versions nor the resource version of a package revision in the event history.
+var ErrUnknownResourceVersion = errors.New("unknown resource version")
+
 // ObjectCache caches objects across repositories, and allows for watching.
 type WatcherManager interface {
 	WatchPackageRevisions(ctx context.Context, filter repository.ListPackageRevisionFilter, callback ObjectWatcher) error
+	// WatchPackageRevisionsFrom adds a change-listener like WatchPackageRevisions, after replaying the events
+	// that happened after the given resource version. The resource version is either one returned by
+	// ResourceVersion, or the resource version of a package revision that an event carried, in which case the
+	// events after the first event that carried it are replayed. It returns a ResourceExpired error (410 Gone)
+	// if the event history no longer goes back to an event version, and ErrUnknownResourceVersion if the
+	// resource version is not recognised.
+	WatchPackageRevisionsFrom(ctx context.Context, filter repository.ListPackageRevisionFilter, resourceVersion string, callback ObjectWatcher) error
+	// ResourceVersion returns the resource version of the latest event, which lists and bookmarks report.
+	ResourceVersion() string
 }
 
 // PackageRevisionWatcher is the callback interface for watchers.
@@ -33,6 +56,13 @@ type ObjectWatcher interface {
 	OnPackageRevisionChange(eventType watch.EventType, obj repository.PackageRevision) bool
 }
 
+// ExpirableWatcher is a watcher that can be ended with an error when it missed changes.
+type ExpirableWatcher interface {
+	ObjectWatcher
+	// OnWatchExpired ends the watch with the given error.
+	OnWatchExpired(err error)
+}
+
 func isContextError(err error) bool {
 	return err == context.Canceled || err == context.DeadlineExceeded
 }
@@ -46,10 +76,20 @@ func logWatcherAction(watcher *watcher, action string, err error) string {
 	return "info"
 }
 
-func NewWatcherManager() *watcherManager {
-	return &watcherManager{}
+// NewWatcherManager returns a watcher manager that keeps the last historySize events for resuming watches.
+func NewWatcherManager(historySize int) *watcherManager {
+	// Versions continue from the start time, so that the versions of a restarted server are newer
+	// than any version a client saw before the restart.
+	start := uint64(time.Now().UnixNano())
+	return &watcherManager{
+		history:              make([]historyEvent, 0, historySize),
+		firstResourceVersion: start,
+		resourceVersion:      start,
+	}
 }
 
+var _ cachetypes.WatchExpirer = &watcherManager{}
+
 // watcherManager implements WatcherManager
 type watcherManager struct {
 	mutex sync.Mutex
@@ -57,6 +97,25 @@ type watcherManager struct {
 	// watchers is a list of all the change-listeners.
 	// As an optimization, values in this slice can be nil; we use this when the watch ends.
 	watchers []*watcher
+
+	// history is a ring buffer of the latest events, oldest first from historyStart.
+	history      []historyEvent
+	historyStart int
+
+	// firstResourceVersion is the resource version the manager started with; the versions from it to
+	// resourceVersion are the manager's own.
+	firstResourceVersion uint64
+	// resourceVersion is the resource version of the latest event.
+	resourceVersion uint64
+}
+
+// historyEvent is a package revision event kept for resuming watches, stamped with the resource version the
+// manager assigned to it and the resource version of the package revision it carried.
+type historyEvent struct {
+	resourceVersion       uint64
+	objectResourceVersion string
+	eventType             watch.EventType
+	obj                   repository.PackageRevision
 }
 
 // watcher is a single change listener.
@@ -77,6 +136,154 @@ func (r *watcherManager) WatchPackageRevisions(ctx context.Context, filter repos
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	r.addWatcher(ctx, filter, callback)
+	return nil
+}
+
+// WatchPackageRevisionsFrom replays the events after resourceVersion to the change-listener, and then streams
+// new ones. The listener is added together with taking the events to replay, so that it neither misses nor
+// duplicates an event; the events that come in during the replay are buffered, so that the replay does not
+// hold up notifying the other listeners.
+func (r *watcherManager) WatchPackageRevisionsFrom(ctx context.Context, filter repository.ListPackageRevisionFilter, resourceVersion string, callback ObjectWatcher) error {
+	r.mutex.Lock()
+	replay, err := r.eventsAfter(resourceVersion)
+	if err != nil {
+		r.mutex.Unlock()
+		return err
+	}
+	replaying := &replayingWatcher{delegate: callback, replaying: true}
+	r.addWatcher(ctx, filter, replaying)
+	r.mutex.Unlock()
+
+	klog.V(3).Infof("replaying %d events after resource version %s to watcher", len(replay), resourceVersion)
+	replaying.replay(replay)
+	return nil
+}
+
+// replayingWatcher buffers the events of a resumed watch while the events before them are replayed.
+type replayingWatcher struct {
+	mutex     sync.Mutex
+	delegate  ObjectWatcher
+	replaying bool
+	stopped   bool
+	buffer    []historyEvent
+}
+
+func (w *replayingWatcher) OnPackageRevisionChange(eventType watch.EventType, obj repository.PackageRevision) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.stopped {
+		return false
+	}
+	if w.replaying {
+		w.buffer = append(w.buffer, historyEvent{eventType: eventType, obj: obj})
+		return true
+	}
+	if keepGoing := w.delegate.OnPackageRevisionChange(eventType, obj); !keepGoing {
+		w.stopped = true
+	}
+	return !w.stopped
+}
+
+func (w *replayingWatcher) OnWatchExpired(err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.stopped = true
+	w.buffer = nil
+	if expirable, ok := w.delegate.(ExpirableWatcher); ok {
+		expirable.OnWatchExpired(err)
+	}
+}
+
+// replay sends the given events and then the buffered ones to the delegate, before letting new events through.
+func (w *replayingWatcher) replay(events []historyEvent) {
+	for {
+		for _, ev := range events {
+			if keepGoing := w.delegate.OnPackageRevisionChange(ev.eventType, ev.obj); !keepGoing {
+				w.mutex.Lock()
+				w.stopped = true
+				w.buffer = nil
+				w.mutex.Unlock()
+				return
+			}
+		}
+
+		w.mutex.Lock()
+		events, w.buffer = w.buffer, nil
+		if len(events) == 0 {
+			w.replaying = false
+			w.mutex.Unlock()
+			return
+		}
+		w.mutex.Unlock()
+	}
+}
+
+// ResourceVersion returns the resource version of the latest event.
+func (r *watcherManager) ResourceVersion() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return strconv.FormatUint(r.resourceVersion, 10)
+}
+
+// eventsAfter returns the events in the history after the given resource version. For the resource version of
+// a package revision, these are the events after the first one that carried it; replaying an event a client
+// already saw is harmless, while skipping one is not. The caller must hold the lock.
+func (r *watcherManager) eventsAfter(resourceVersion string) ([]historyEvent, error) {
+	events := make([]historyEvent, 0, len(r.history))
+	events = append(events, r.history[r.historyStart:]...)
+	events = append(events, r.history[:r.historyStart]...)
+
+	if rv, err := strconv.ParseUint(resourceVersion, 10, 64); err == nil && rv >= r.firstResourceVersion && rv <= r.resourceVersion {
+		if rv == r.resourceVersion {
+			return nil, nil
+		}
+		// The history must hold the event right after the resource version.
+		if len(events) == 0 || rv+1 < events[0].resourceVersion {
+			return nil, apierrors.NewResourceExpired(fmt.Sprintf("too old resource version: %s (%d)", resourceVersion, r.resourceVersion))
+		}
+		for i, ev := range events {
+			if ev.resourceVersion > rv {
+				return events[i:], nil
+			}
+		}
+		return nil, nil
+	}
+
+	for i, ev := range events {
+		if ev.objectResourceVersion == resourceVersion {
+			return events[i+1:], nil
+		}
+	}
+	return nil, ErrUnknownResourceVersion
+}
+
+// addEvent assigns the next resource version to an event and adds it to the history.
+func (r *watcherManager) addEvent(eventType watch.EventType, obj repository.PackageRevision) {
+	r.resourceVersion++
+
+	if cap(r.history) == 0 {
+		return
+	}
+	ev := historyEvent{
+		resourceVersion:       r.resourceVersion,
+		objectResourceVersion: obj.ResourceVersion(),
+		eventType:             eventType,
+		obj:                   obj,
+	}
+	if len(r.history) < cap(r.history) {
+		r.history = append(r.history, ev)
+		return
+	}
+	r.history[r.historyStart] = ev
+	r.historyStart = (r.historyStart + 1) % len(r.history)
+}
+
+// addWatcher adds a change-listener, reusing the slot of a finished one. The caller must hold the lock.
+func (r *watcherManager) addWatcher(ctx context.Context, filter repository.ListPackageRevisionFilter, callback ObjectWatcher) {
 	w := &watcher{
 		isDoneFunction: ctx.Err,
 		callback:       callback,
@@ -116,7 +323,6 @@ func (r *watcherManager) WatchPackageRevisions(ctx context.Context, filter repos
 	}
 
 	klog.V(3).Infof("added watcher %p; there are now %d active watchers and %d slots", w, active, len(r.watchers))
-	return nil
 }
 
 // notifyPackageRevisionChange is called to send a change notification to all interested listeners.
@@ -124,6 +330,8 @@ func (r *watcherManager) NotifyPackageRevisionChange(eventType watch.EventType,
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	r.addEvent(eventType, obj)
+
 	sent := 0
 	for i, watcher := range r.watchers {
 		if watcher == nil {
@@ -143,3 +351,30 @@ func (r *watcherManager) NotifyPackageRevisionChange(eventType watch.EventType,
 
 	return sent
 }
+
+// ExpireWatches ends all watches with a ResourceExpired error, and forgets the event history, so that no watch
+// resumes from before the changes that were missed. Watchers that cannot be ended with an error are dropped.
+func (r *watcherManager) ExpireWatches(reason string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	err := apierrors.NewResourceExpired(reason)
+	expired := 0
+	for i, watcher := range r.watchers {
+		if watcher == nil {
+			continue
+		}
+		if expirable, ok := watcher.callback.(ExpirableWatcher); ok && watcher.isDoneFunction() == nil {
+			expirable.OnWatchExpired(err)
+			expired++
+		}
+		r.watchers[i] = nil
+	}
+
+	r.history = r.history[:0]
+	r.historyStart = 0
+	// The latest version is no longer one to resume from either
+	r.resourceVersion++
+
+	klog.Infof("expired %d watchers: %s", expired, reason)
+}
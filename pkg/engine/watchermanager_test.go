@@ -17,11 +17,17 @@ package engine
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
+	"time"
 
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
 	"github.com/kptdev/porch/pkg/externalrepo/fake"
 	"github.com/kptdev/porch/pkg/repository"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 )
 
@@ -34,7 +40,7 @@ func (m *fakeObjectWatcher) OnPackageRevisionChange(eventType watch.EventType, o
 }
 
 func TestWatchPackageRevisions(t *testing.T) {
-	manager := NewWatcherManager()
+	manager := NewWatcherManager(DefaultWatchHistorySize)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -67,7 +73,7 @@ func TestWatchPackageRevisions(t *testing.T) {
 }
 
 func TestNotifyPackageRevisionChange(t *testing.T) {
-	manager := NewWatcherManager()
+	manager := NewWatcherManager(DefaultWatchHistorySize)
 
 	activeCtx := context.Background()
 	inactiveCtx, cancel := context.WithCancel(context.Background())
@@ -86,7 +92,7 @@ func TestNotifyPackageRevisionChange(t *testing.T) {
 
 	cancel()
 
-	pkgRev := &fake.FakePackageRevision{}
+	pkgRev := fakePkgRev("1")
 	sent := manager.NotifyPackageRevisionChange(watch.Added, pkgRev)
 	assert.Equal(t, 2, sent)
 
@@ -94,6 +100,183 @@ func TestNotifyPackageRevisionChange(t *testing.T) {
 	assert.Equal(t, 1, watchersActive)
 }
 
+type recordingObjectWatcher struct {
+	received []string
+}
+
+func (m *recordingObjectWatcher) OnPackageRevisionChange(eventType watch.EventType, obj repository.PackageRevision) bool {
+	m.received = append(m.received, obj.ResourceVersion())
+	return true
+}
+
+func fakePkgRev(resourceVersion string) *fake.FakePackageRevision {
+	return &fake.FakePackageRevision{
+		PackageRevision: &porchapi.PackageRevision{
+			ObjectMeta: metav1.ObjectMeta{ResourceVersion: resourceVersion},
+		},
+	}
+}
+
+func TestWatchPackageRevisionsFrom(t *testing.T) {
+	manager := NewWatcherManager(3)
+	ctx := context.Background()
+
+	start := manager.ResourceVersion()
+	manager.NotifyPackageRevisionChange(watch.Added, fakePkgRev("a"))
+	afterFirst := manager.ResourceVersion()
+	manager.NotifyPackageRevisionChange(watch.Modified, fakePkgRev("b"))
+	manager.NotifyPackageRevisionChange(watch.Modified, fakePkgRev("c"))
+	assert.NotEqual(t, start, afterFirst)
+
+	t.Run("from list resource version", func(t *testing.T) {
+		w := &recordingObjectWatcher{}
+		require.NoError(t, manager.WatchPackageRevisionsFrom(ctx, repository.ListPackageRevisionFilter{}, afterFirst, w))
+		assert.Equal(t, []string{"b", "c"}, w.received)
+	})
+
+	t.Run("from event resource version", func(t *testing.T) {
+		// Informers resume from the resource version of the last object they received
+		w := &recordingObjectWatcher{}
+		require.NoError(t, manager.WatchPackageRevisionsFrom(ctx, repository.ListPackageRevisionFilter{}, "b", w))
+		assert.Equal(t, []string{"c"}, w.received)
+	})
+
+	t.Run("from latest resource version", func(t *testing.T) {
+		w := &recordingObjectWatcher{}
+		require.NoError(t, manager.WatchPackageRevisionsFrom(ctx, repository.ListPackageRevisionFilter{}, manager.ResourceVersion(), w))
+		assert.Empty(t, w.received)
+
+		manager.NotifyPackageRevisionChange(watch.Deleted, fakePkgRev("d"))
+		assert.Equal(t, []string{"d"}, w.received)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		// The history of 3 events no longer holds the event after the first one.
+		err := manager.WatchPackageRevisionsFrom(ctx, repository.ListPackageRevisionFilter{}, start, &recordingObjectWatcher{})
+		assert.True(t, apierrors.IsResourceExpired(err), "resource version %q: %v", start, err)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		// Versions from the future or from before the start were never issued, and the event that carried
+		// package revision "a" is no longer in the history.
+		future := strconv.FormatUint(manager.resourceVersion+1, 10)
+		past := strconv.FormatUint(manager.firstResourceVersion-1, 10)
+		for _, rv := range []string{"a", "unknown", future, past} {
+			err := manager.WatchPackageRevisionsFrom(ctx, repository.ListPackageRevisionFilter{}, rv, &recordingObjectWatcher{})
+			assert.ErrorIs(t, err, ErrUnknownResourceVersion, "resource version %q", rv)
+		}
+	})
+}
+
+// blockingObjectWatcher blocks on the first event until it is released.
+type blockingObjectWatcher struct {
+	recordingObjectWatcher
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m *blockingObjectWatcher) OnPackageRevisionChange(eventType watch.EventType, obj repository.PackageRevision) bool {
+	if len(m.received) == 0 {
+		close(m.started)
+		<-m.release
+	}
+	return m.recordingObjectWatcher.OnPackageRevisionChange(eventType, obj)
+}
+
+func TestWatchPackageRevisionsFromReplayDoesNotBlockNotifications(t *testing.T) {
+	manager := NewWatcherManager(10)
+	ctx := context.Background()
+
+	start := manager.ResourceVersion()
+	manager.NotifyPackageRevisionChange(watch.Added, fakePkgRev("a"))
+	manager.NotifyPackageRevisionChange(watch.Modified, fakePkgRev("b"))
+
+	w := &blockingObjectWatcher{started: make(chan struct{}), release: make(chan struct{})}
+	watching := make(chan error)
+	go func() {
+		watching <- manager.WatchPackageRevisionsFrom(ctx, repository.ListPackageRevisionFilter{}, start, w)
+	}()
+	<-w.started
+
+	// Other changes are notified while the replay is stuck, and reach the resumed watch after the replay
+	notified := make(chan int)
+	go func() {
+		notified <- manager.NotifyPackageRevisionChange(watch.Modified, fakePkgRev("c"))
+	}()
+	select {
+	case sent := <-notified:
+		assert.Equal(t, 1, sent)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "notification blocked by the replay")
+	}
+
+	close(w.release)
+	require.NoError(t, <-watching)
+	assert.Equal(t, []string{"a", "b", "c"}, w.received)
+
+	manager.NotifyPackageRevisionChange(watch.Deleted, fakePkgRev("d"))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, w.received)
+}
+
+func TestWatchPackageRevisionsFromNoHistory(t *testing.T) {
+	manager := NewWatcherManager(0)
+	ctx := context.Background()
+
+	rv := manager.ResourceVersion()
+	require.NoError(t, manager.WatchPackageRevisionsFrom(ctx, repository.ListPackageRevisionFilter{}, rv, &recordingObjectWatcher{}))
+
+	manager.NotifyPackageRevisionChange(watch.Added, fakePkgRev("a"))
+	err := manager.WatchPackageRevisionsFrom(ctx, repository.ListPackageRevisionFilter{}, rv, &recordingObjectWatcher{})
+	assert.True(t, apierrors.IsResourceExpired(err))
+}
+
+type expirableObjectWatcher struct {
+	recordingObjectWatcher
+	expired error
+}
+
+func (m *expirableObjectWatcher) OnWatchExpired(err error) {
+	m.expired = err
+}
+
+func TestExpireWatches(t *testing.T) {
+	manager := NewWatcherManager(10)
+	ctx := context.Background()
+
+	start := manager.ResourceVersion()
+	manager.NotifyPackageRevisionChange(watch.Added, fakePkgRev("a"))
+	latest := manager.ResourceVersion()
+
+	expirable := &expirableObjectWatcher{}
+	require.NoError(t, manager.WatchPackageRevisions(ctx, repository.ListPackageRevisionFilter{}, expirable))
+	resumed := &expirableObjectWatcher{}
+	require.NoError(t, manager.WatchPackageRevisionsFrom(ctx, repository.ListPackageRevisionFilter{}, start, resumed))
+	plain := &recordingObjectWatcher{}
+	require.NoError(t, manager.WatchPackageRevisions(ctx, repository.ListPackageRevisionFilter{}, plain))
+
+	manager.ExpireWatches("changes were missed")
+	assert.True(t, apierrors.IsResourceExpired(expirable.expired), "%v", expirable.expired)
+	assert.True(t, apierrors.IsResourceExpired(resumed.expired), "%v", resumed.expired)
+	active, _ := countActiveWatchers(manager)
+	assert.Equal(t, 0, active)
+
+	// No watcher gets changes after it was expired
+	assert.Equal(t, 0, manager.NotifyPackageRevisionChange(watch.Modified, fakePkgRev("b")))
+	assert.Empty(t, expirable.received)
+	assert.Equal(t, []string{"a"}, resumed.received)
+	assert.Empty(t, plain.received)
+
+	// Watches cannot resume from before the expiry
+	for _, rv := range []string{start, latest} {
+		err := manager.WatchPackageRevisionsFrom(ctx, repository.ListPackageRevisionFilter{}, rv, &recordingObjectWatcher{})
+		assert.True(t, apierrors.IsResourceExpired(err), "resource version %q: %v", rv, err)
+	}
+	w := &recordingObjectWatcher{}
+	require.NoError(t, manager.WatchPackageRevisionsFrom(ctx, repository.ListPackageRevisionFilter{}, manager.ResourceVersion(), w))
+	manager.NotifyPackageRevisionChange(watch.Modified, fakePkgRev("c"))
+	assert.Equal(t, []string{"c"}, w.received)
+}
+
 func countActiveWatchers(manager *watcherManager) (int, int) {
 	active := 0
 	for _, watcher := range manager.watchers {
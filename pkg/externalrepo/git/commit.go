@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"net/mail"
 	"path"
 	"sort"
 	"strings"
@@ -257,6 +258,16 @@ func (h *commitHelper) commit(ctx context.Context, message string, pkgPath strin
 	if h.userInfoProvider != nil {
 		ui = h.userInfoProvider.GetUserInfo(ctx)
 	}
+	authored := time.Now()
+	// Package revisions published elsewhere keep their original publisher and publish time
+	if info, ok := repository.PublishInfoFrom(ctx); ok {
+		if info.PublishedBy != "" {
+			ui = publisherUserInfo(info.PublishedBy)
+		}
+		if !info.PublishedAt.IsZero() {
+			authored = info.PublishedAt
+		}
+	}
 
 	var parentCommits []plumbing.Hash
 	if !h.parentCommitHash.IsZero() {
@@ -264,7 +275,7 @@ func (h *commitHelper) commit(ctx context.Context, message string, pkgPath strin
 	}
 	parentCommits = append(parentCommits, additionalParentCommits...)
 
-	commit, err = h.storeCommit(parentCommits, rootTreeHash, ui, message)
+	commit, err = h.storeCommit(parentCommits, rootTreeHash, ui, authored, message)
 	if err != nil {
 		return plumbing.ZeroHash, plumbing.ZeroHash, err
 	}
@@ -387,8 +398,19 @@ func entrySortKey(e *object.TreeEntry) string {
 	return e.Name
 }
 
+// publisherUserInfo returns the commit author for a package revision that was
+// published by the given identity. The identity is only used as the email if it
+// is an email address; otherwise the email is left empty.
+func publisherUserInfo(publishedBy string) *repository.UserInfo {
+	ui := &repository.UserInfo{Name: publishedBy}
+	if addr, err := mail.ParseAddress(publishedBy); err == nil {
+		ui.Email = addr.Address
+	}
+	return ui
+}
+
 // storeCommit creates and writes a commit object to git.
-func (h *commitHelper) storeCommit(parentCommits []plumbing.Hash, tree plumbing.Hash, userInfo *repository.UserInfo, message string) (plumbing.Hash, error) {
+func (h *commitHelper) storeCommit(parentCommits []plumbing.Hash, tree plumbing.Hash, userInfo *repository.UserInfo, authored time.Time, message string) (plumbing.Hash, error) {
 	now := time.Now()
 	var authorName, authorEmail string
 	if userInfo != nil {
@@ -404,7 +426,7 @@ func (h *commitHelper) storeCommit(parentCommits []plumbing.Hash, tree plumbing.
 		Author: object.Signature{
 			Name:  authorName,
 			Email: authorEmail,
-			When:  now,
+			When:  authored,
 		},
 		Committer: object.Signature{
 			Name:  porchSignatureName,
@@ -243,6 +243,55 @@ func TestCommitWithUser(t *testing.T) {
 	}
 }
 
+func TestCommitWithPublishInfo(t *testing.T) {
+	tempdir := t.TempDir()
+	gitRepo := OpenGitRepositoryFromArchive(t, filepath.Join("testdata", "trivial-repository.tar"), tempdir)
+	main := resolveReference(t, gitRepo, defaultMainReferenceName)
+	publishedAt := time.Date(2025, time.March, 4, 10, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		publishedBy string
+		wantName    string
+		wantEmail   string
+	}{
+		"email":    {publishedBy: "alice@example.com", wantName: "alice@example.com", wantEmail: "alice@example.com"},
+		"username": {publishedBy: "alice", wantName: "alice", wantEmail: ""},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			packagePath := "testpackage-" + tn
+			ch, err := newCommitHelper(gitRepo, nil, main.Hash(), packagePath, plumbing.ZeroHash)
+			if err != nil {
+				t.Fatalf("newCommitHelper(%q) failed: %v", packagePath, err)
+			}
+			if err := ch.storeFile(path.Join(packagePath, "hello.txt"), "Hello, World!"); err != nil {
+				t.Fatalf("storeFile failed: %v", err)
+			}
+
+			ctx := repository.WithPublishInfo(context.Background(), repository.PublishInfo{
+				PublishedBy: tc.publishedBy,
+				PublishedAt: publishedAt,
+			})
+			commitHash, _, err := ch.commit(ctx, "Publish "+packagePath, packagePath)
+			if err != nil {
+				t.Fatalf("commit failed: %v", err)
+			}
+
+			commit := getCommitObject(t, gitRepo, commitHash)
+			if got, want := commit.Author.Name, tc.wantName; got != want {
+				t.Errorf("Commit.Author.Name: got %q, want %q", got, want)
+			}
+			if got, want := commit.Author.Email, tc.wantEmail; got != want {
+				t.Errorf("Commit.Author.Email: got %q, want %q", got, want)
+			}
+			if got, want := commit.Author.When, publishedAt; !want.Equal(got) {
+				t.Errorf("Commit.Author.When: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func createPackageCommit(t *testing.T, repo *gogit.Repository, parentHash plumbing.Hash, packagePath string) plumbing.Hash {
 	t.Helper()
 	ch, err := newCommitHelper(repo, nil, parentHash, packagePath, plumbing.ZeroHash)
@@ -1260,16 +1260,21 @@ func (r *gitRepository) createBranch(repo *git.Repository, branch branchName) er
 	return repo.Storer.SetReference(ref)
 }
 
-func (r *gitRepository) commitPackageToMainInRepo(ctx context.Context, repo *git.Repository, d *gitPackageRevisionDraft) (commitHash, newPackageTreeHash plumbing.Hash, err error) {
+// commitPackageToMainInRepo commits the draft to the main branch on top of head, or on top of the
+// latest state of the main branch if head is zero.
+func (r *gitRepository) commitPackageToMainInRepo(ctx context.Context, repo *git.Repository, d *gitPackageRevisionDraft, head plumbing.Hash) (commitHash, newPackageTreeHash plumbing.Hash, err error) {
 	branch := r.branch
 	localRef := branch.refInLocal()
 
-	// Find localTarget branch - get latest state
-	localTarget, err := repo.Reference(localRef, true)
-	if err != nil {
-		return plumbing.ZeroHash, plumbing.ZeroHash, fmt.Errorf("failed to find 'main' branch: %w", err)
+	if head.IsZero() {
+		// Find localTarget branch - get latest state
+		localTarget, err := repo.Reference(localRef, true)
+		if err != nil {
+			return plumbing.ZeroHash, plumbing.ZeroHash, fmt.Errorf("failed to find 'main' branch: %w", err)
+		}
+		head = localTarget.Hash()
 	}
-	headCommit, err := repo.CommitObject(localTarget.Hash())
+	headCommit, err := repo.CommitObject(head)
 	if err != nil {
 		return plumbing.ZeroHash, plumbing.ZeroHash, fmt.Errorf("failed to resolve main branch to commit: %w", err)
 	}
@@ -1315,13 +1320,19 @@ func (r *gitRepository) commitPackageToMainInRepo(ctx context.Context, repo *git
 	return hash, treeHash, nil
 }
 
-func (r *gitRepository) createPackageDeleteCommitInRepo(ctx context.Context, repo *git.Repository, branch plumbing.ReferenceName, prKey repository.PackageRevisionKey) (plumbing.Hash, error) {
+// createPackageDeleteCommitInRepo commits the deletion of the package to the branch on top of head,
+// or on top of the latest state of the branch if head is zero.
+func (r *gitRepository) createPackageDeleteCommitInRepo(ctx context.Context, repo *git.Repository, branch plumbing.ReferenceName, prKey repository.PackageRevisionKey, head plumbing.Hash) (plumbing.Hash, error) {
 	var zero plumbing.Hash
 	// find the branch
-	ref, err := repo.Reference(branch, true)
-	if err != nil {
-		klog.Infof("Branch %q no longer exist, deleting a package from it is unnecessary", branch)
-		return plumbing.ZeroHash, nil
+	ref := plumbing.NewHashReference(branch, head)
+	if head.IsZero() {
+		var err error
+		ref, err = repo.Reference(branch, true)
+		if err != nil {
+			klog.Infof("Branch %q no longer exist, deleting a package from it is unnecessary", branch)
+			return plumbing.ZeroHash, nil
+		}
 	}
 	commit, err := repo.CommitObject(ref.Hash())
 	if err != nil {
@@ -1364,20 +1375,26 @@ func (r *gitRepository) createPackageDeleteCommitInRepo(ctx context.Context, rep
 	return hash, nil
 }
 
-func (r *gitRepository) executeCommitOperations(ctx context.Context, repo *git.Repository, ph *pushRefSpecBuilder, commitOps *commitOperationBuilder) error {
+// executeCommitOperations creates the commits of the approvals and deletions in commitOps. heads holds
+// the commits already created on each branch but not pushed yet; operations on such a branch are
+// committed on top of them rather than on top of the fetched state of the branch.
+func (r *gitRepository) executeCommitOperations(ctx context.Context, repo *git.Repository, ph *pushRefSpecBuilder, commitOps *commitOperationBuilder,
+	heads map[plumbing.ReferenceName]plumbing.Hash) error {
 	for _, op := range commitOps.getOperations() {
 		switch op.opType {
 		case "approval":
 			data := op.data.(map[string]interface{})
 			d := data["draft"].(*gitPackageRevisionDraft)
 			tag := data["tag"].(plumbing.ReferenceName)
+			branch := r.branch.refInLocal()
 
-			commitHash, newTreeHash, err := r.commitPackageToMainInRepo(ctx, repo, d)
+			commitHash, newTreeHash, err := r.commitPackageToMainInRepo(ctx, repo, d, heads[branch])
 			if err != nil {
 				return err
 			}
+			heads[branch] = commitHash
 
-			ph.addRefToPush(commitHash, r.branch.refInLocal())
+			ph.addRefToPush(commitHash, branch)
 			ph.addRefToPush(commitHash, tag)
 
 			d.commit = commitHash
@@ -1388,12 +1405,13 @@ func (r *gitRepository) executeCommitOperations(ctx context.Context, repo *git.R
 			branch := data["branch"].(plumbing.ReferenceName)
 			prKey := data["prKey"].(repository.PackageRevisionKey)
 
-			commitHash, err := r.createPackageDeleteCommitInRepo(ctx, repo, branch, prKey)
+			commitHash, err := r.createPackageDeleteCommitInRepo(ctx, repo, branch, prKey, heads[branch])
 			if err != nil {
 				return err
 			}
 
 			if !commitHash.IsZero() {
+				heads[branch] = commitHash
 				ph.addRefToPush(commitHash, branch)
 			}
 		}
@@ -1405,6 +1423,13 @@ func (r *gitRepository) pushAndCleanup(ctx context.Context, ph *pushRefSpecBuild
 	ctx, span := tracer.Start(ctx, "gitRepository::pushAndCleanup")
 	defer span.End()
 
+	if batch, ok := repository.PushBatchFrom(ctx); ok {
+		pending := batch.Pending(r.Key(), func() repository.PendingPush {
+			return newBatchedPush(r)
+		}).(*batchedPush)
+		return pending.add(ctx, ph, commitOps)
+	}
+
 	klog.InfoS("[Git] Pushing changes to remote Git repository started",
 		pctx.LogMetadataFromWithExtras(ctx, "repository", r.key.Name)...)
 	defer func() {
@@ -1427,7 +1452,7 @@ func (r *gitRepository) pushAndCleanup(ctx context.Context, ph *pushRefSpecBuild
 				}
 				// Execute commit operations with latest state - Approve and Delete
 				if commitOps != nil {
-					if err := r.executeCommitOperations(ctx, repo, ph, commitOps); err != nil {
+					if err := r.executeCommitOperations(ctx, repo, ph, commitOps, map[plumbing.ReferenceName]plumbing.Hash{}); err != nil {
 						return err
 					}
 				}
@@ -1451,6 +1476,7 @@ func (r *gitRepository) pushAndCleanup(ctx context.Context, ph *pushRefSpecBuild
 					RefSpecs:   specs,
 					Auth:       auth,
 					Force:      false,
+					Atomic:     ph.atomic,
 					CABundle:   r.caBundle,
 				})
 				if pushErr != nil {
@@ -1634,6 +1660,45 @@ func (r *gitRepository) getResources(hash plumbing.Hash) (map[string]string, err
 	return resources, nil
 }
 
+// getSubpackageKptfiles returns the Kptfiles of the subpackages in the tree, keyed by their path.
+// Only the Kptfiles are read, so it is cheaper than reading all the resources.
+func (r *gitRepository) getSubpackageKptfiles(hash plumbing.Hash) (map[string]string, error) {
+	kptfiles := map[string]string{}
+
+	err := r.sharedDir.withLock(func(repo *git.Repository) error {
+		tree, err := repo.TreeObject(hash)
+		if err != nil {
+			return err
+		}
+
+		fit := tree.Files()
+		defer fit.Close()
+		for {
+			file, err := fit.Next()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return fmt.Errorf("failed to list package files: %w", err)
+			}
+			if !strings.HasSuffix(file.Name, "/"+kptfilev1.KptFileName) {
+				continue
+			}
+
+			content, err := file.Contents()
+			if err != nil {
+				return fmt.Errorf("failed to read package file contents: %q, %w", file.Name, err)
+			}
+			kptfiles[file.Name] = content
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return kptfiles, nil
+}
+
 // findLatestPackageCommit returns the latest commit from the history that pertains
 // to the package given by the packagePath. If no commit is found, it will return nil and an error.
 func (r *gitRepository) findLatestPackageCommit(startCommit *object.Commit, key repository.PackageKey) (*object.Commit, error) {
@@ -1943,6 +2008,14 @@ func (r *gitRepository) ClosePackageRevisionDraft(ctx context.Context, prd repos
 				updatedBy = userInfo.Email
 			}
 		}
+		if info, ok := repository.PublishInfoFrom(ctx); ok {
+			if info.PublishedBy != "" {
+				updatedBy = info.PublishedBy
+			}
+			if !info.PublishedAt.IsZero() {
+				updatedTime = info.PublishedAt
+			}
+		}
 	}
 
 	return &gitPackageRevision{
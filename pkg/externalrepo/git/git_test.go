@@ -496,6 +496,61 @@ func (g GitSuite) TestListPackagesTrivial(t *testing.T) {
 	}
 }
 
+func (g GitSuite) TestPackageRevisionSubpackages(t *testing.T) {
+	tempdir := t.TempDir()
+	tarfile := filepath.Join("testdata", "trivial-repository.tar")
+	_, address := ServeGitRepositoryWithBranch(t, tarfile, tempdir, g.branch)
+
+	ctx := context.Background()
+	const (
+		repositoryName = "subpackages"
+		namespace      = "default"
+	)
+
+	git, err := OpenRepository(ctx, repositoryName, namespace, &configapi.GitRepository{
+		Repo:      address,
+		Branch:    g.branch,
+		Directory: "/",
+	}, false, tempdir, testGitRepositoryOptions())
+	if err != nil {
+		t.Fatalf("Failed to open Git repository loaded from %q: %v", tarfile, err)
+	}
+
+	draft, err := git.CreatePackageRevisionDraft(ctx, &porchapi.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+		Spec: porchapi.PackageRevisionSpec{
+			PackageName:    "app",
+			WorkspaceName:  "ws",
+			RepositoryName: repositoryName,
+			Lifecycle:      porchapi.PackageRevisionLifecycleDraft,
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreatePackageRevisionDraft() failed: %v", err)
+	}
+	if err := draft.UpdateResources(ctx, &porchapi.PackageRevisionResources{
+		Spec: porchapi.PackageRevisionResourcesSpec{
+			Resources: map[string]string{
+				"Kptfile":        Kptfile,
+				"db/Kptfile":     "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: db\n",
+				"db/config.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: db\n",
+			},
+		},
+	}, &porchapi.Task{Type: porchapi.TaskTypeInit, Init: &porchapi.PackageInitTaskSpec{}}); err != nil {
+		t.Fatalf("UpdateResources() failed: %v", err)
+	}
+	newRevision, err := git.ClosePackageRevisionDraft(ctx, draft, 0)
+	if err != nil {
+		t.Fatalf("ClosePackageRevisionDraft() failed: %v", err)
+	}
+
+	result, err := newRevision.GetPackageRevision(ctx)
+	if err != nil {
+		t.Fatalf("GetPackageRevision() failed: %v", err)
+	}
+	assert.Equal(t, []porchapi.Subpackage{{Path: "db", Name: "db"}}, result.Status.Subpackages)
+}
+
 // trivial-repository.tar has a repon with a `main` branch and a single empty commit.
 func (g GitSuite) TestCreatePackageInTrivialRepository(t *testing.T) {
 	tempdir := t.TempDir()
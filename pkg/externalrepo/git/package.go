@@ -31,6 +31,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 )
 
 type gitPackageRevision struct {
@@ -44,6 +45,10 @@ type gitPackageRevision struct {
 	tasks     []porchapi.Task
 	metadata  metav1.ObjectMeta
 	mutex     sync.Mutex
+
+	// subpackages caches the subpackages read from the Kptfiles in the tree of the package
+	subpackages     []porchapi.Subpackage
+	subpackagesRead bool
 }
 
 var _ repository.PackageRevision = &gitPackageRevision{}
@@ -83,6 +88,7 @@ func (p *gitPackageRevision) GetPackageRevision(ctx context.Context) (*porchapi.
 		SelfLock:     repository.KptUpstreamLock2APIUpstreamLock(selfLock),
 		Deployment:   p.repo.deployment,
 		Conditions:   repository.ToAPIConditions(kf),
+		Subpackages:  p.getSubpackages(),
 	}
 
 	lifecycle := p.Lifecycle(ctx)
@@ -128,6 +134,23 @@ func (p *gitPackageRevision) GetPackageRevision(ctx context.Context) (*porchapi.
 	return pr, nil
 }
 
+// getSubpackages returns the subpackages embedded in the package. The tree of a package
+// revision does not change, so they are read once.
+func (p *gitPackageRevision) getSubpackages() []porchapi.Subpackage {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.subpackagesRead {
+		kptfiles, err := p.repo.getSubpackageKptfiles(p.tree)
+		if err != nil {
+			klog.Warningf("failed to read the subpackages of package revision %s: %v", p.KubeObjectName(), err)
+			return nil
+		}
+		p.subpackages = repository.ToAPISubpackages(repository.FindSubpackages(kptfiles))
+		p.subpackagesRead = true
+	}
+	return p.subpackages
+}
+
 func (p *gitPackageRevision) GetResources(context.Context) (*porchapi.PackageRevisionResources, error) {
 	resources, err := p.repo.getResources(p.tree)
 	if err != nil {
@@ -25,6 +25,8 @@ import (
 type pushRefSpecBuilder struct {
 	pushRefs map[plumbing.ReferenceName]plumbing.Hash
 	require  map[plumbing.ReferenceName]plumbing.Hash
+	// atomic requests that the remote updates either all refs or none of them
+	atomic bool
 }
 
 func newPushRefSpecBuilder() *pushRefSpecBuilder {
@@ -49,6 +51,16 @@ func (b *pushRefSpecBuilder) requireRef(ref *plumbing.Reference) {
 	}
 }
 
+// merge adds the refs to push and the required refs of other to b.
+func (b *pushRefSpecBuilder) merge(other *pushRefSpecBuilder) {
+	for ref, hash := range other.pushRefs {
+		b.pushRefs[ref] = hash
+	}
+	for ref, hash := range other.require {
+		b.require[ref] = hash
+	}
+}
+
 func (b *pushRefSpecBuilder) updateRequiredRefs(repo *git.Repository) {
 	for refName := range b.require {
 		// Try local ref first, then remote ref
@@ -0,0 +1,80 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/kptdev/porch/pkg/repository"
+	pctx "github.com/kptdev/porch/pkg/util/context"
+	pkgerrors "github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// batchedPush holds the changes to a git repository that were made while a
+// repository.PushBatch was set on the context. Commits for approvals and deletions
+// are created when the changes are added, chained on top of each other, and all
+// refs are pushed together in a single atomic push when the batch is flushed.
+type batchedPush struct {
+	repo     *gitRepository
+	refSpecs *pushRefSpecBuilder
+	// heads holds the commits created on each branch that are not pushed yet
+	heads map[plumbing.ReferenceName]plumbing.Hash
+}
+
+var _ repository.PendingPush = &batchedPush{}
+
+func newBatchedPush(repo *gitRepository) *batchedPush {
+	refSpecs := newPushRefSpecBuilder()
+	refSpecs.atomic = true
+	return &batchedPush{
+		repo:     repo,
+		refSpecs: refSpecs,
+		heads:    map[plumbing.ReferenceName]plumbing.Hash{},
+	}
+}
+
+func (p *batchedPush) add(ctx context.Context, ph *pushRefSpecBuilder, commitOps *commitOperationBuilder) error {
+	if commitOps != nil && len(commitOps.getOperations()) > 0 {
+		if err := p.repo.fetchRemoteRepositoryWithRetry(ctx); err != nil {
+			return err
+		}
+		if err := p.repo.sharedDir.withLock(func(repo *git.Repository) error {
+			return p.repo.executeCommitOperations(ctx, repo, ph, commitOps, p.heads)
+		}); err != nil {
+			return err
+		}
+	}
+	p.refSpecs.merge(ph)
+
+	klog.V(3).InfoS("[Git] Changes added to push batch",
+		pctx.LogMetadataFromWithExtras(ctx, "repository", p.repo.key.Name)...)
+	return nil
+}
+
+// Push pushes all batched refs in a single atomic push. If the push fails, none of the
+// batched changes reach the remote repository.
+func (p *batchedPush) Push(ctx context.Context) error {
+	if err := p.repo.pushAndCleanup(ctx, p.refSpecs, nil); err != nil && !pkgerrors.Is(err, git.NoErrAlreadyUpToDate) {
+		// Lifecycle changes update the deletion proposed cache before they are pushed
+		if cacheErr := p.repo.UpdateDeletionProposedCache(ctx); cacheErr != nil {
+			klog.Warningf("failed to update deletion proposed cache of repository %s: %v", p.repo.key.Name, cacheErr)
+		}
+		return err
+	}
+	return nil
+}
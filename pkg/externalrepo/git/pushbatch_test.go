@@ -0,0 +1,99 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (g GitSuite) TestApproveDraftsInPushBatch(t *testing.T) {
+	tempdir := t.TempDir()
+	tarfile := filepath.Join("testdata", "drafts-repository.tar")
+	repo, address := ServeGitRepositoryWithBranch(t, tarfile, tempdir, g.branch)
+
+	const (
+		repositoryName = "batch"
+		namespace      = "default"
+		deployment     = true
+	)
+	ctx := context.Background()
+	git, err := OpenRepository(ctx, repositoryName, namespace, &configapi.GitRepository{
+		Repo:      address,
+		Branch:    g.branch,
+		Directory: "/",
+	}, deployment, tempdir, testGitRepositoryOptions())
+	require.NoError(t, err)
+
+	revisions, err := git.ListPackageRevisions(ctx, repository.ListPackageRevisionFilter{})
+	require.NoError(t, err)
+
+	mainBefore, err := repo.Reference(plumbing.NewBranchReferenceName(g.branch), true)
+	require.NoError(t, err)
+
+	batch := repository.NewPushBatch()
+	batchCtx := repository.WithPushBatch(ctx, batch)
+
+	for _, pkg := range []string{"bucket", "none"} {
+		draft := findPackageRevision(t, revisions, repository.ListPackageRevisionFilter{
+			Key: repository.PackageRevisionKey{
+				PkgKey:        repository.PackageKey{Package: pkg},
+				WorkspaceName: "v1",
+			},
+		})
+		update, err := git.UpdatePackageRevision(batchCtx, draft)
+		require.NoError(t, err)
+		require.NoError(t, update.UpdateLifecycle(batchCtx, porchapi.PackageRevisionLifecyclePublished))
+		approved, err := git.ClosePackageRevisionDraft(batchCtx, update, 1)
+		require.NoError(t, err)
+		assert.Equal(t, porchapi.PackageRevisionLifecyclePublished, approved.Lifecycle(ctx))
+	}
+
+	// Nothing has been pushed before the batch is flushed
+	assert.Equal(t, 1, batch.Len())
+	refMustExist(t, repo, branchName("drafts/bucket/v1").refInRemote())
+	refMustExist(t, repo, branchName("drafts/none/v1").refInRemote())
+	refMustNotExist(t, repo, "refs/tags/bucket/v1")
+	refMustNotExist(t, repo, "refs/tags/none/v1")
+	mainUnchanged, err := repo.Reference(plumbing.NewBranchReferenceName(g.branch), true)
+	require.NoError(t, err)
+	assert.Equal(t, mainBefore.Hash(), mainUnchanged.Hash())
+
+	require.NoError(t, batch.Flush(ctx))
+
+	refMustNotExist(t, repo, branchName("drafts/bucket/v1").refInRemote())
+	refMustNotExist(t, repo, branchName("drafts/none/v1").refInRemote())
+	refMustExist(t, repo, "refs/tags/bucket/v1")
+	refMustExist(t, repo, "refs/tags/none/v1")
+
+	// Both approvals are on the main branch, one after the other
+	mainAfter, err := repo.Reference(plumbing.NewBranchReferenceName(g.branch), true)
+	require.NoError(t, err)
+	head, err := repo.CommitObject(mainAfter.Hash())
+	require.NoError(t, err)
+	require.Equal(t, 1, head.NumParents())
+	parent, err := head.Parent(0)
+	require.NoError(t, err)
+	require.Equal(t, 1, parent.NumParents())
+	assert.Equal(t, mainBefore.Hash(), parent.ParentHashes[0])
+}
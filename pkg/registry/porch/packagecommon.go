@@ -172,14 +172,26 @@ func (v *v1alpha2FilteringWatcher) OnPackageRevisionChange(eventType watch.Event
 }
 
 func (r *packageCommon) watchPackages(ctx context.Context, filter repository.ListPackageRevisionFilter, callback engine.ObjectWatcher) error {
+	return r.cad.ObjectCache().WatchPackageRevisions(ctx, filter, r.filteringWatcher(ctx, callback))
+}
+
+// watchPackagesFrom is like watchPackages, but first replays the changes made after the resource version.
+func (r *packageCommon) watchPackagesFrom(ctx context.Context, filter repository.ListPackageRevisionFilter, resourceVersion string, callback engine.ObjectWatcher) error {
+	return r.cad.ObjectCache().WatchPackageRevisionsFrom(ctx, filter, resourceVersion, r.filteringWatcher(ctx, callback))
+}
+
+// latestResourceVersion returns the resource version that lists and watch bookmarks report.
+func (r *packageCommon) latestResourceVersion() string {
+	return r.cad.ObjectCache().ResourceVersion()
+}
+
+func (r *packageCommon) filteringWatcher(ctx context.Context, callback engine.ObjectWatcher) engine.ObjectWatcher {
 	var watcher = callback
 
 	if ns, namespaced := genericapirequest.NamespaceFrom(ctx); namespaced && ns != "" {
 		watcher = &namespaceFilteringWatcher{ns: ns, delegate: watcher}
 	}
-	watcher = &v1alpha2FilteringWatcher{coreClient: r.coreClient, delegate: watcher}
-
-	return r.cad.ObjectCache().WatchPackageRevisions(ctx, filter, watcher)
+	return &v1alpha2FilteringWatcher{coreClient: r.coreClient, delegate: watcher}
 }
 
 func (r *packageCommon) getRepositoryObj(ctx context.Context, repositoryID types.NamespacedName) (*configapi.Repository, error) {
@@ -380,10 +392,13 @@ func (r *packageCommon) updatePackageRevision(ctx context.Context, name string,
 	}
 
 	if isCreate {
+		if err := r.checkSourcePolicies(ctx, namespace, newApiPkgRev); err != nil {
+			return nil, false, internalOrSourceDeniedError(name, err)
+		}
 		rev, err := r.cad.CreatePackageRevision(ctx, &repositoryObj, newApiPkgRev, parentPackage)
 		if err != nil {
 			klog.Infof("error creating package: %v", err)
-			return nil, false, apierrors.NewInternalError(err)
+			return nil, false, internalOrSourceDeniedError(name, err)
 		}
 		createdApiPkgRev, err := rev.GetPackageRevision(ctx)
 		if err != nil {
@@ -395,7 +410,7 @@ func (r *packageCommon) updatePackageRevision(ctx context.Context, name string,
 
 	rev, err := r.cad.UpdatePackageRevision(ctx, 0, &repositoryObj, oldRepoPkgRev, oldApiPkgRev.(*porchapi.PackageRevision), newApiPkgRev, parentPackage)
 	if err != nil {
-		return nil, false, apierrors.NewInternalError(err)
+		return nil, false, internalOrSourceDeniedError(name, err)
 	}
 
 	updated, err := rev.GetPackageRevision(ctx)
@@ -153,6 +153,14 @@ func (f *fakeWatcherManager) WatchPackageRevisions(ctx context.Context, filter r
 	return nil
 }
 
+func (f *fakeWatcherManager) WatchPackageRevisionsFrom(ctx context.Context, filter repository.ListPackageRevisionFilter, resourceVersion string, callback engine.ObjectWatcher) error {
+	return f.WatchPackageRevisions(ctx, filter, callback)
+}
+
+func (f *fakeWatcherManager) ResourceVersion() string {
+	return "42"
+}
+
 func newMockCoreClientForWatcher() *mockclient.MockClient {
 	c := &mockclient.MockClient{}
 	c.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), mock.Anything).
@@ -215,6 +223,15 @@ type errorWatcherManager struct{}
 func (e *errorWatcherManager) WatchPackageRevisions(ctx context.Context, filter repository.ListPackageRevisionFilter, callback engine.ObjectWatcher) error {
 	return fmt.Errorf("simulated error")
 }
+
+func (e *errorWatcherManager) WatchPackageRevisionsFrom(ctx context.Context, filter repository.ListPackageRevisionFilter, resourceVersion string, callback engine.ObjectWatcher) error {
+	return fmt.Errorf("simulated error")
+}
+
+func (e *errorWatcherManager) ResourceVersion() string {
+	return ""
+}
+
 func TestWatchPackages_ErrorPath(t *testing.T) {
 	mockCad := &mockcad.MockCaDEngine{}
 	mockCad.On("ObjectCache").Return(&errorWatcherManager{})
@@ -92,6 +92,9 @@ func (r *packageRevisions) List(ctx context.Context, options *metainternalversio
 		return nil, err
 	}
 
+	// Take the resource version before listing, so that a watch from it replays changes made during the list.
+	result.ResourceVersion = r.latestResourceVersion()
+
 	if err := r.listPackageRevisions(ctx, *filter, func(ctx context.Context, p repository.PackageRevision) error {
 		item, err := p.GetPackageRevision(ctx)
 		if err != nil {
@@ -105,6 +108,12 @@ func (r *packageRevisions) List(ctx context.Context, options *metainternalversio
 		return nil, err
 	}
 
+	items := make([]*porchapi.PackageRevision, 0, len(result.Items))
+	for i := range result.Items {
+		items = append(items, &result.Items[i])
+	}
+	r.addDeploymentStatus(ctx, ns, items...)
+
 	klog.V(3).InfoS("[API] List operation completed for PackageRevisions",
 		pctx.LogMetadataFromWithExtras(ctx, "found", len(result.Items))...)
 
@@ -129,6 +138,7 @@ func (r *packageRevisions) Get(ctx context.Context, name string, _ *metav1.GetOp
 	if err != nil {
 		return nil, err
 	}
+	r.addDeploymentStatus(ctx, apiPkgRev.Namespace, apiPkgRev)
 
 	klog.V(3).InfoS("[API] Get operation completed for PackageRevision", pctx.LogMetadataFrom(ctx)...)
 
@@ -188,6 +198,10 @@ func (r *packageRevisions) Create(ctx context.Context, runtimeObject runtime.Obj
 		return nil, apierrors.NewInvalid(porchapi.SchemeGroupVersion.WithKind("PackageRevision").GroupKind(), newApiPkgRev.Name, fieldErrors)
 	}
 
+	if err := r.checkSourcePolicies(ctx, ns, newApiPkgRev); err != nil {
+		return nil, internalOrSourceDeniedError(prName, err)
+	}
+
 	klog.InfoS("[API] Operation started for PackageRevision",
 		pctx.LogMetadataFromWithExtras(ctx, "action", action)...)
 
@@ -216,7 +230,7 @@ func (r *packageRevisions) Create(ctx context.Context, runtimeObject runtime.Obj
 
 	createdRepoPkgRev, err := r.cad.CreatePackageRevision(ctx, repositoryObj, newApiPkgRev, parentPackage)
 	if err != nil {
-		return nil, apierrors.NewInternalError(err)
+		return nil, internalOrSourceDeniedError(prName, err)
 	}
 
 	createdApiPkgRev, err := createdRepoPkgRev.GetPackageRevision(ctx)
@@ -379,7 +393,7 @@ func creationConflictError(newApiPkgRev *porchapi.PackageRevision) error {
 	)
 }
 
-func (r *packageRevisions) checkIfUpstreamIsReferenced(ctx context.Context, apiPkgRev *porchapi.PackageRevision) error {
+func (r *packageCommon) checkIfUpstreamIsReferenced(ctx context.Context, apiPkgRev *porchapi.PackageRevision) error {
 	klog.Infof("[API] Checking if upstream PackageRevision is referenced: %s", apiPkgRev.Name)
 	ns, _ := genericapirequest.NamespaceFrom(ctx)
 	downstream, err := r.cad.FindAllUpstreamReferencesInRepositories(ctx, ns, apiPkgRev.Name)
@@ -107,7 +107,12 @@ func (s packageRevisionApprovalStrategy) ValidateUpdate(ctx context.Context, obj
 		}
 
 	case porchapi.PackageRevisionLifecycleProposed:
-		// valid
+		if newRevision.Spec.Lifecycle == porchapi.PackageRevisionLifecyclePublished {
+			if unmet := porchapi.UnmetReadinessGates(oldRevision.Spec.ReadinessGates, oldRevision.Status.Conditions); len(unmet) > 0 {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("status", "conditions"),
+					fmt.Sprintf("cannot approve package; readiness conditions not met: %s", strings.Join(unmet, ", "))))
+			}
+		}
 
 	default:
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "lifecycle"), lifecycle,
@@ -83,6 +83,54 @@ func TestApprovalUpdateStrategy(t *testing.T) {
 	}
 }
 
+func TestApprovalUpdateStrategyReadinessGates(t *testing.T) {
+	s := packageRevisionApprovalStrategy{}
+	ctx := context.Background()
+
+	proposed := func(conditions ...porchapi.Condition) *porchapi.PackageRevision {
+		return &porchapi.PackageRevision{
+			Spec: porchapi.PackageRevisionSpec{
+				Lifecycle: porchapi.PackageRevisionLifecycleProposed,
+				ReadinessGates: []porchapi.ReadinessGate{
+					{ConditionType: "scan.example.com/passed"},
+					{ConditionType: "review.example.com/approved"},
+				},
+			},
+			Status: porchapi.PackageRevisionStatus{
+				Conditions: conditions,
+			},
+		}
+	}
+	published := &porchapi.PackageRevision{
+		Spec: porchapi.PackageRevisionSpec{
+			Lifecycle: porchapi.PackageRevisionLifecyclePublished,
+		},
+	}
+
+	// One gate missing, one False
+	allErrs := s.ValidateUpdate(ctx, published, proposed(
+		porchapi.Condition{Type: "review.example.com/approved", Status: porchapi.ConditionFalse},
+	))
+	require.Len(t, allErrs, 1)
+	assert.Equal(t, "status.conditions", allErrs[0].Field)
+	assert.Contains(t, allErrs[0].Detail, "readiness conditions not met: scan.example.com/passed, review.example.com/approved")
+
+	// All gates True
+	allErrs = s.ValidateUpdate(ctx, published, proposed(
+		porchapi.Condition{Type: "scan.example.com/passed", Status: porchapi.ConditionTrue},
+		porchapi.Condition{Type: "review.example.com/approved", Status: porchapi.ConditionTrue},
+	))
+	assert.Empty(t, allErrs)
+
+	// Rejecting back to draft is not gated
+	draft := &porchapi.PackageRevision{
+		Spec: porchapi.PackageRevisionSpec{
+			Lifecycle: porchapi.PackageRevisionLifecycleDraft,
+		},
+	}
+	assert.Empty(t, s.ValidateUpdate(ctx, draft, proposed()))
+}
+
 func TestApprovalUpdate(t *testing.T) {
 	// Setup approval instance
 	approval := &packageRevisionApproval{
@@ -0,0 +1,381 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	kptfilev1 "github.com/kptdev/kpt/api/kptfile/v1"
+	"github.com/kptdev/kpt/pkg/kptfile/kptfileutil"
+	kptfn "github.com/kptdev/krm-functions-sdk/go/fn"
+	kptfileko "github.com/kptdev/krm-functions-sdk/go/fn/kptfileko"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/engine"
+	"github.com/kptdev/porch/pkg/repository"
+	pctx "github.com/kptdev/porch/pkg/util/context"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
+	"github.com/kptdev/porch/pkg/util/pipeline"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+)
+
+// conditionsResource is the virtual resource that is authorized for every condition type set
+// through the conditions subresource, with the condition type as the resource name.
+const conditionsResource = "packageconditions"
+
+type packageRevisionConditions struct {
+	packageCommon
+	authorizer authorizer.Authorizer
+}
+
+var _ rest.Storage = &packageRevisionConditions{}
+var _ rest.Scoper = &packageRevisionConditions{}
+var _ rest.Getter = &packageRevisionConditions{}
+var _ rest.Updater = &packageRevisionConditions{}
+
+// New returns an empty object that can be used with Create and Update after request data has been put into it.
+// This object must be a pointer type for use with Codec.DecodeInto([]byte, runtime.Object)
+func (c *packageRevisionConditions) New() runtime.Object {
+	return &porchapi.PackageRevisionConditions{}
+}
+
+func (c *packageRevisionConditions) Destroy() {}
+
+// NamespaceScoped returns true if the storage is namespaced
+func (c *packageRevisionConditions) NamespaceScoped() bool {
+	return true
+}
+
+// Get returns the conditions and readiness gates in the Kptfile of the named package revision.
+func (c *packageRevisionConditions) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	ctx, span := tracer.Start(ctx, "[START]::packageRevisionConditions::Get", trace.WithAttributes())
+	defer span.End()
+
+	ctx = pctx.WithNewRequestIDAndPackageRevision(ctx, name)
+
+	pkgRev, err := c.getRepoPkgRevForResources(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := pkgRev.GetResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newConditionsFromResources(name, resources)
+}
+
+// Update sets, changes and clears conditions in the Kptfile of a draft or proposed package
+// revision. The package is not rendered. The caller must be allowed to set every condition
+// type that is added, changed or removed.
+func (c *packageRevisionConditions) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, _ rest.ValidateObjectFunc,
+	updateValidation rest.ValidateObjectUpdateFunc, _ bool, _ *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	ctx, span := tracer.Start(ctx, "[START]::packageRevisionConditions::Update", trace.WithAttributes())
+	defer span.End()
+
+	ctx = pctx.WithNewRequestIDAndPackageRevision(ctx, name)
+
+	namespace, namespaced := genericapirequest.NamespaceFrom(ctx)
+	if !namespaced {
+		return nil, false, apierrors.NewBadRequest("namespace must be specified")
+	}
+
+	pkgMutexKey := getPackageMutexKey(namespace, name)
+	pkgMutex := getMutexForPackage(pkgMutexKey)
+	locked := pkgMutex.TryLock()
+	if !locked {
+		return nil, false,
+			apierrors.NewConflict(
+				porchapi.Resource("packagerevisions"),
+				name,
+				fmt.Errorf(GenericConflictErrorMsg, "package revision conditions", pkgMutexKey))
+	}
+	defer pkgMutex.Unlock()
+
+	pkgRev, err := c.getRepoPkgRevForResources(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	if lifecycle := pkgRev.Lifecycle(ctx); lifecycle != porchapi.PackageRevisionLifecycleDraft && lifecycle != porchapi.PackageRevisionLifecycleProposed {
+		return nil, false, apierrors.NewBadRequest(
+			fmt.Sprintf("cannot update the conditions of a package revision with lifecycle value %q; package must be Draft or Proposed", lifecycle))
+	}
+
+	oldResources, err := pkgRev.GetResources(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	oldObj, err := newConditionsFromResources(name, oldResources)
+	if err != nil {
+		return nil, false, err
+	}
+
+	newRuntimeObj, err := objInfo.UpdatedObject(ctx, oldObj)
+	if err != nil {
+		klog.Infof("update failed to construct UpdatedObject: %v", err)
+		return nil, false, err
+	}
+	newObj, ok := newRuntimeObj.(*porchapi.PackageRevisionConditions)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest(fmt.Sprintf("expected PackageRevisionConditions object, got %T", newRuntimeObj))
+	}
+
+	if updateValidation != nil {
+		if err := updateValidation(ctx, newObj, oldObj); err != nil {
+			klog.Infof("update failed validation: %v", err)
+			return nil, false, err
+		}
+	}
+	if err := validateConditions(newObj.Spec.Conditions); err != nil {
+		return nil, false, apierrors.NewBadRequest(err.Error())
+	}
+	if newObj.ResourceVersion != "" && newObj.ResourceVersion != oldObj.ResourceVersion {
+		return nil, false, apierrors.NewConflict(porchapi.Resource("packagerevisions"), name, fmt.Errorf("%s", engine.OptimisticLockErrorMsg))
+	}
+
+	changed := changedConditionTypes(oldObj.Spec.Conditions, newObj.Spec.Conditions)
+	if len(changed) == 0 {
+		return oldObj, false, nil
+	}
+	if slices.Contains(changed, mergeconflict.ConditionType) {
+		return nil, false, apierrors.NewBadRequest(fmt.Sprintf(
+			"the %s condition is managed by porch; resolve the merge conflicts in the package resources instead", mergeconflict.ConditionType))
+	}
+	if err := c.authorizeConditions(ctx, namespace, changed); err != nil {
+		return nil, false, err
+	}
+
+	kptfile, err := setKptfileConditions(oldResources.Spec.Resources[kptfilev1.KptFileName], newObj.Spec.Conditions)
+	if err != nil {
+		return nil, false, apierrors.NewInternalError(err)
+	}
+	newResources := oldResources.DeepCopy()
+	newResources.Spec.Resources[kptfilev1.KptFileName] = kptfile
+
+	klog.InfoS("[API] Update operation started for PackageRevision conditions",
+		pctx.LogMetadataFromWithExtras(ctx, "conditionTypes", changed)...)
+
+	prKey, err := repository.PkgRevK8sName2Key(namespace, name)
+	if err != nil {
+		return nil, false, err
+	}
+	var repositoryObj configapi.Repository
+	repositoryID := types.NamespacedName{Namespace: prKey.RKey().Namespace, Name: prKey.RKey().Name}
+	if err := c.coreClient.Get(ctx, repositoryID, &repositoryObj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, apierrors.NewNotFound(c.gr, repositoryID.Name)
+		}
+		return nil, false, apierrors.NewInternalError(fmt.Errorf("error getting repository %v: %w", repositoryID, err))
+	}
+
+	rev, err := c.cad.UpdatePackageConditions(ctx, &repositoryObj, pkgRev, oldResources, newResources)
+	if err != nil {
+		return nil, false, apierrors.NewInternalError(err)
+	}
+	if isV1Alpha2Repo(&repositoryObj) {
+		// The PR controller syncs the Kptfile conditions into the CRD and re-checks the readiness gates.
+		c.patchRenderRequestAnnotation(ctx, namespace, name, rev.ResourceVersion())
+	}
+
+	updatedResources, err := rev.GetResources(ctx)
+	if err != nil {
+		return nil, false, apierrors.NewInternalError(err)
+	}
+	updated, err := newConditionsFromResources(name, updatedResources)
+	if err != nil {
+		return nil, false, err
+	}
+
+	klog.InfoS("[API] Update operation completed for PackageRevision conditions", pctx.LogMetadataFrom(ctx)...)
+
+	return updated, false, nil
+}
+
+// authorizeConditions checks that the user may set each of the given condition types, so that
+// RBAC rules can grant actors the conditions they own and nothing else.
+func (c *packageRevisionConditions) authorizeConditions(ctx context.Context, namespace string, conditionTypes []string) error {
+	if c.authorizer == nil {
+		return nil
+	}
+	user, ok := genericapirequest.UserFrom(ctx)
+	if !ok {
+		return apierrors.NewForbidden(porchapi.Resource(conditionsResource), "", fmt.Errorf("no user in request"))
+	}
+	for _, conditionType := range conditionTypes {
+		decision, reason, err := c.authorizer.Authorize(ctx, authorizer.AttributesRecord{
+			User:            user,
+			Verb:            "set",
+			Namespace:       namespace,
+			APIGroup:        porchapi.GroupName,
+			APIVersion:      porchapi.SchemeGroupVersion.Version,
+			Resource:        conditionsResource,
+			Name:            conditionType,
+			ResourceRequest: true,
+		})
+		if err != nil {
+			return apierrors.NewInternalError(fmt.Errorf("error authorizing condition %q: %w", conditionType, err))
+		}
+		if decision != authorizer.DecisionAllow {
+			return apierrors.NewForbidden(porchapi.Resource(conditionsResource), conditionType,
+				fmt.Errorf("user %q cannot set condition %q: %s", user.GetName(), conditionType, reason))
+		}
+	}
+	return nil
+}
+
+// newConditionsFromResources reads the conditions and readiness gates from the Kptfile in the
+// resources of a package revision. The resource version of the resources is used as the
+// resource version of the conditions.
+func newConditionsFromResources(name string, resources *porchapi.PackageRevisionResources) (*porchapi.PackageRevisionConditions, error) {
+	kptfile, found := resources.Spec.Resources[kptfilev1.KptFileName]
+	if !found {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("package revision %s has no %s", name, kptfilev1.KptFileName))
+	}
+	kf, err := kptfileutil.DecodeKptfile(strings.NewReader(kptfile))
+	if err != nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("decode Kptfile: %w", err))
+	}
+	conditions := repository.ToAPIConditions(*kf)
+	gates := repository.ToAPIReadinessGates(*kf)
+
+	return &porchapi.PackageRevisionConditions{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageRevisionConditions",
+			APIVersion: porchapi.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       resources.Namespace,
+			ResourceVersion: resources.ResourceVersion,
+		},
+		Spec: porchapi.PackageRevisionConditionsSpec{
+			Conditions: conditions,
+		},
+		Status: porchapi.PackageRevisionConditionsStatus{
+			ReadinessGates: gates,
+			Ready:          porchapi.PackageRevisionIsReady(gates, conditions),
+		},
+	}, nil
+}
+
+// validateConditions checks that every condition has a type and a valid status, and that
+// condition types are unique.
+func validateConditions(conditions []porchapi.Condition) error {
+	seen := map[string]bool{}
+	for i, condition := range conditions {
+		if condition.Type == "" {
+			return fmt.Errorf("conditions[%d]: type is required", i)
+		}
+		switch condition.Status {
+		case porchapi.ConditionTrue, porchapi.ConditionFalse, porchapi.ConditionUnknown:
+		default:
+			return fmt.Errorf("conditions[%d]: status must be one of %s, %s or %s", i,
+				porchapi.ConditionTrue, porchapi.ConditionFalse, porchapi.ConditionUnknown)
+		}
+		if seen[condition.Type] {
+			return fmt.Errorf("conditions[%d]: duplicate condition type %q", i, condition.Type)
+		}
+		seen[condition.Type] = true
+	}
+	return nil
+}
+
+// changedConditionTypes returns the types of the conditions that are added, changed or removed,
+// in the order they appear in the old and then the new conditions.
+func changedConditionTypes(oldConditions, newConditions []porchapi.Condition) []string {
+	newByType := map[string]porchapi.Condition{}
+	for _, condition := range newConditions {
+		newByType[condition.Type] = condition
+	}
+	oldTypes := map[string]bool{}
+
+	var changed []string
+	for _, oldCondition := range oldConditions {
+		oldTypes[oldCondition.Type] = true
+		if newCondition, found := newByType[oldCondition.Type]; !found || newCondition != oldCondition {
+			changed = append(changed, oldCondition.Type)
+		}
+	}
+	for _, newCondition := range newConditions {
+		if !oldTypes[newCondition.Type] {
+			changed = append(changed, newCondition.Type)
+		}
+	}
+	return changed
+}
+
+// setKptfileConditions replaces the status conditions of the given Kptfile and returns the new
+// Kptfile. Existing conditions are updated in place, so the rest of the Kptfile keeps its formatting.
+func setKptfileConditions(kptfile string, conditions []porchapi.Condition) (string, error) {
+	kf, err := kptfileko.NewFromPackage(map[string]string{kptfilev1.KptFileName: kptfile})
+	if err != nil {
+		return "", fmt.Errorf("parse Kptfile: %w", err)
+	}
+
+	existing := map[string]*kptfn.SubObject{}
+	for _, so := range kf.Conditions() {
+		existing[so.GetString("type")] = so
+	}
+
+	objs := make(kptfn.SliceSubObjects, 0, len(conditions))
+	for _, condition := range conditions {
+		so, found := existing[condition.Type]
+		if !found {
+			ko, err := kptfn.NewFromTypedObject(kptfilev1.Condition{Type: condition.Type})
+			if err != nil {
+				return "", fmt.Errorf("convert condition %q: %w", condition.Type, err)
+			}
+			so = &ko.SubObject
+		}
+		if err := so.SetNestedString(string(condition.Status), "status"); err != nil {
+			return "", err
+		}
+		if err := pipeline.SetOrRemoveString(so, "reason", condition.Reason); err != nil {
+			return "", err
+		}
+		if err := pipeline.SetOrRemoveString(so, "message", condition.Message); err != nil {
+			return "", err
+		}
+		objs = append(objs, so)
+	}
+	if len(objs) == 0 {
+		if _, err := kf.RemoveNestedField("status", "conditions"); err != nil {
+			return "", err
+		}
+		if status := kf.GetMap("status"); status != nil && status.IsEmpty() {
+			if _, err := kf.RemoveNestedField("status"); err != nil {
+				return "", err
+			}
+		}
+	} else if err := kf.SetConditions(objs); err != nil {
+		return "", fmt.Errorf("set conditions: %w", err)
+	}
+
+	resources := map[string]string{}
+	if err := kf.WriteToPackage(resources); err != nil {
+		return "", fmt.Errorf("write Kptfile: %w", err)
+	}
+	return resources[kptfilev1.KptFileName], nil
+}
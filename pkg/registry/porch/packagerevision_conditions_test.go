@@ -0,0 +1,257 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"testing"
+
+	kptfilev1 "github.com/kptdev/kpt/api/kptfile/v1"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/externalrepo/fake"
+	"github.com/kptdev/porch/pkg/repository"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
+	mockclient "github.com/kptdev/porch/test/mockery/mocks/external/sigs.k8s.io/controller-runtime/pkg/client"
+	mockengine "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+const conditionsTestKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: app
+info:
+  readinessGates:
+    - conditionType: scan.example.com/passed
+    - conditionType: review.example.com/approved
+status:
+  conditions:
+    # set by the security scanner
+    - type: scan.example.com/passed
+      status: "False"
+      reason: Vulnerable
+`
+
+func newConditionsPkgRev(lifecycle porchapi.PackageRevisionLifecycle) *fake.FakePackageRevision {
+	pkgRev := newDependencyPkgRev("deployments", "app", "ws", 0, lifecycle)
+	pkgRev.Resources = &porchapi.PackageRevisionResources{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            pkgRev.KubeObjectName(),
+			Namespace:       "ns",
+			ResourceVersion: "7",
+		},
+		Spec: porchapi.PackageRevisionResourcesSpec{
+			Resources: map[string]string{
+				kptfilev1.KptFileName: conditionsTestKptfile,
+			},
+		},
+	}
+	return pkgRev
+}
+
+// allowConditions is an authorizer that only allows the given condition types to be set.
+func allowConditions(t *testing.T, conditionTypes ...string) authorizer.Authorizer {
+	return authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+		assert.Equal(t, "set", a.GetVerb())
+		assert.Equal(t, "ns", a.GetNamespace())
+		assert.Equal(t, porchapi.GroupName, a.GetAPIGroup())
+		assert.Equal(t, "packageconditions", a.GetResource())
+		assert.Equal(t, "scanner", a.GetUser().GetName())
+		for _, conditionType := range conditionTypes {
+			if a.GetName() == conditionType {
+				return authorizer.DecisionAllow, "", nil
+			}
+		}
+		return authorizer.DecisionNoOpinion, "not allowed", nil
+	})
+}
+
+func newTestConditions(t *testing.T, pkgRev repository.PackageRevision, authz authorizer.Authorizer) (*packageRevisionConditions, *mockengine.MockCaDEngine) {
+	mockClient := mockclient.NewMockClient(t)
+	mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), mock.Anything).Return(nil).Maybe()
+	mockEngine := mockengine.NewMockCaDEngine(t)
+	mockEngine.EXPECT().ListPackageRevisions(mock.Anything, mock.Anything).Return([]repository.PackageRevision{pkgRev}, nil).Maybe()
+
+	return &packageRevisionConditions{
+		packageCommon: packageCommon{
+			scheme:     runtime.NewScheme(),
+			gr:         porchapi.Resource("packagerevisions"),
+			coreClient: mockClient,
+			cad:        mockEngine,
+		},
+		authorizer: authz,
+	}, mockEngine
+}
+
+func TestConditionsGet(t *testing.T) {
+	pkgRev := newConditionsPkgRev(porchapi.PackageRevisionLifecycleProposed)
+	conditions, _ := newTestConditions(t, pkgRev, nil)
+
+	ctx := request.WithNamespace(context.TODO(), "ns")
+	result, err := conditions.Get(ctx, pkgRev.KubeObjectName(), &metav1.GetOptions{})
+	require.NoError(t, err)
+	require.IsType(t, &porchapi.PackageRevisionConditions{}, result)
+
+	obj := result.(*porchapi.PackageRevisionConditions)
+	assert.Equal(t, pkgRev.KubeObjectName(), obj.Name)
+	assert.Equal(t, "7", obj.ResourceVersion)
+	assert.Equal(t, []porchapi.Condition{{
+		Type:   "scan.example.com/passed",
+		Status: porchapi.ConditionFalse,
+		Reason: "Vulnerable",
+	}}, obj.Spec.Conditions)
+	assert.Equal(t, []porchapi.ReadinessGate{
+		{ConditionType: "scan.example.com/passed"},
+		{ConditionType: "review.example.com/approved"},
+	}, obj.Status.ReadinessGates)
+	assert.False(t, obj.Status.Ready)
+}
+
+func TestConditionsUpdate(t *testing.T) {
+	ctx := request.WithUser(request.WithNamespace(context.TODO(), "ns"), &user.DefaultInfo{Name: "scanner"})
+
+	setCondition := func(resourceVersion string, condition porchapi.Condition) rest.UpdatedObjectInfo {
+		return rest.DefaultUpdatedObjectInfo(nil, func(_ context.Context, _, oldObj runtime.Object) (runtime.Object, error) {
+			obj := oldObj.DeepCopyObject().(*porchapi.PackageRevisionConditions)
+			obj.ResourceVersion = resourceVersion
+			for i := range obj.Spec.Conditions {
+				if obj.Spec.Conditions[i].Type == condition.Type {
+					obj.Spec.Conditions[i] = condition
+					return obj, nil
+				}
+			}
+			obj.Spec.Conditions = append(obj.Spec.Conditions, condition)
+			return obj, nil
+		})
+	}
+	scanPassed := porchapi.Condition{Type: "scan.example.com/passed", Status: porchapi.ConditionTrue}
+
+	t.Run("update writes the Kptfile of a proposed package", func(t *testing.T) {
+		pkgRev := newConditionsPkgRev(porchapi.PackageRevisionLifecycleProposed)
+		conditions, mockEngine := newTestConditions(t, pkgRev, allowConditions(t, scanPassed.Type))
+
+		mockEngine.EXPECT().UpdatePackageConditions(mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), pkgRev, pkgRev.Resources, mock.Anything).RunAndReturn(
+			func(_ context.Context, _ *configapi.Repository, _ repository.PackageRevision, _, newRes *porchapi.PackageRevisionResources) (repository.PackageRevision, error) {
+				kptfile := newRes.Spec.Resources[kptfilev1.KptFileName]
+				assert.Contains(t, kptfile, "# set by the security scanner")
+				assert.Contains(t, kptfile, `status: "True"`)
+				assert.NotContains(t, kptfile, "Vulnerable")
+
+				updated := newConditionsPkgRev(porchapi.PackageRevisionLifecycleProposed)
+				updated.Resources = newRes.DeepCopy()
+				updated.Resources.ResourceVersion = "8"
+				return updated, nil
+			})
+
+		result, created, err := conditions.Update(ctx, pkgRev.KubeObjectName(), setCondition("7", scanPassed), nil, nil, false, &metav1.UpdateOptions{})
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		obj := result.(*porchapi.PackageRevisionConditions)
+		assert.Equal(t, "8", obj.ResourceVersion)
+		assert.Equal(t, []porchapi.Condition{scanPassed}, obj.Spec.Conditions)
+		assert.False(t, obj.Status.Ready)
+	})
+
+	t.Run("condition types the user may not set are forbidden", func(t *testing.T) {
+		pkgRev := newConditionsPkgRev(porchapi.PackageRevisionLifecycleDraft)
+		conditions, mockEngine := newTestConditions(t, pkgRev, allowConditions(t, scanPassed.Type))
+
+		approved := porchapi.Condition{Type: "review.example.com/approved", Status: porchapi.ConditionTrue}
+		_, _, err := conditions.Update(ctx, pkgRev.KubeObjectName(), setCondition("", approved), nil, nil, false, &metav1.UpdateOptions{})
+		require.Error(t, err)
+		assert.True(t, apierrors.IsForbidden(err))
+		assert.Contains(t, err.Error(), "review.example.com/approved")
+		mockEngine.AssertNotCalled(t, "UpdatePackageConditions", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("the MergeConflicts condition cannot be set", func(t *testing.T) {
+		pkgRev := newConditionsPkgRev(porchapi.PackageRevisionLifecycleDraft)
+		conditions, mockEngine := newTestConditions(t, pkgRev, allowConditions(t))
+
+		resolved := porchapi.Condition{Type: mergeconflict.ConditionType, Status: porchapi.ConditionFalse}
+		_, _, err := conditions.Update(ctx, pkgRev.KubeObjectName(), setCondition("7", resolved), nil, nil, false, &metav1.UpdateOptions{})
+		require.Error(t, err)
+		assert.True(t, apierrors.IsBadRequest(err))
+		assert.Contains(t, err.Error(), "managed by porch")
+		mockEngine.AssertNotCalled(t, "UpdatePackageConditions", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unchanged conditions are not written", func(t *testing.T) {
+		pkgRev := newConditionsPkgRev(porchapi.PackageRevisionLifecycleDraft)
+		conditions, mockEngine := newTestConditions(t, pkgRev, allowConditions(t))
+
+		unchanged := porchapi.Condition{Type: "scan.example.com/passed", Status: porchapi.ConditionFalse, Reason: "Vulnerable"}
+		result, _, err := conditions.Update(ctx, pkgRev.KubeObjectName(), setCondition("7", unchanged), nil, nil, false, &metav1.UpdateOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "7", result.(*porchapi.PackageRevisionConditions).ResourceVersion)
+		mockEngine.AssertNotCalled(t, "UpdatePackageConditions", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("only drafts and proposed packages can be updated", func(t *testing.T) {
+		pkgRev := newConditionsPkgRev(porchapi.PackageRevisionLifecyclePublished)
+		conditions, _ := newTestConditions(t, pkgRev, allowConditions(t, scanPassed.Type))
+
+		_, _, err := conditions.Update(ctx, pkgRev.KubeObjectName(), setCondition("7", scanPassed), nil, nil, false, &metav1.UpdateOptions{})
+		require.Error(t, err)
+		assert.True(t, apierrors.IsBadRequest(err))
+		assert.Contains(t, err.Error(), "package must be Draft or Proposed")
+	})
+
+	t.Run("stale resource version", func(t *testing.T) {
+		pkgRev := newConditionsPkgRev(porchapi.PackageRevisionLifecycleDraft)
+		conditions, _ := newTestConditions(t, pkgRev, allowConditions(t, scanPassed.Type))
+
+		_, _, err := conditions.Update(ctx, pkgRev.KubeObjectName(), setCondition("6", scanPassed), nil, nil, false, &metav1.UpdateOptions{})
+		require.Error(t, err)
+		assert.True(t, apierrors.IsConflict(err))
+	})
+
+	t.Run("invalid condition status", func(t *testing.T) {
+		pkgRev := newConditionsPkgRev(porchapi.PackageRevisionLifecycleDraft)
+		conditions, _ := newTestConditions(t, pkgRev, allowConditions(t, scanPassed.Type))
+
+		invalid := porchapi.Condition{Type: scanPassed.Type, Status: "Yes"}
+		_, _, err := conditions.Update(ctx, pkgRev.KubeObjectName(), setCondition("7", invalid), nil, nil, false, &metav1.UpdateOptions{})
+		require.Error(t, err)
+		assert.True(t, apierrors.IsBadRequest(err))
+		assert.Contains(t, err.Error(), "conditions[0]: status must be one of True, False or Unknown")
+	})
+}
+
+func TestChangedConditionTypes(t *testing.T) {
+	oldConditions := []porchapi.Condition{
+		{Type: "a", Status: porchapi.ConditionTrue},
+		{Type: "b", Status: porchapi.ConditionTrue},
+		{Type: "c", Status: porchapi.ConditionFalse},
+	}
+	newConditions := []porchapi.Condition{
+		{Type: "b", Status: porchapi.ConditionTrue},
+		{Type: "d", Status: porchapi.ConditionUnknown},
+		{Type: "c", Status: porchapi.ConditionFalse, Message: "failed"},
+	}
+	assert.Equal(t, []string{"a", "c", "d"}, changedConditionTypes(oldConditions, newConditions))
+	assert.Empty(t, changedConditionTypes(oldConditions, oldConditions))
+}
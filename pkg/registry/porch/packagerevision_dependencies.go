@@ -0,0 +1,220 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/kptdev/porch/pkg/repository"
+	pctx "github.com/kptdev/porch/pkg/util/context"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+)
+
+type packageRevisionDependencies struct {
+	packageCommon
+}
+
+var _ rest.Storage = &packageRevisionDependencies{}
+var _ rest.Scoper = &packageRevisionDependencies{}
+var _ rest.Getter = &packageRevisionDependencies{}
+
+// New returns an empty object that can be used with Create and Update after request data has been put into it.
+// This object must be a pointer type for use with Codec.DecodeInto([]byte, runtime.Object)
+func (d *packageRevisionDependencies) New() runtime.Object {
+	return &porchapi.PackageRevisionDependencies{}
+}
+
+func (d *packageRevisionDependencies) Destroy() {}
+
+// NamespaceScoped returns true if the storage is namespaced
+func (d *packageRevisionDependencies) NamespaceScoped() bool {
+	return true
+}
+
+// Get returns the upstream chain and all downstream package revisions of the named package revision.
+func (d *packageRevisionDependencies) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	ctx, span := tracer.Start(ctx, "[START]::packageRevisionDependencies::Get", trace.WithAttributes())
+	defer span.End()
+
+	ctx = pctx.WithNewRequestIDAndPackageRevision(ctx, name)
+
+	pkgRev, err := d.getRepoPkgRev(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := &dependencyResolver{
+		packageCommon: &d.packageCommon,
+		latest:        map[repository.PackageKey]repository.PackageRevision{},
+	}
+	status, err := resolver.resolve(ctx, pkgRev)
+	if err != nil {
+		klog.ErrorS(err, "[API] PackageRevision dependencies lookup failed", pctx.LogMetadataFrom(ctx)...)
+		return nil, err
+	}
+
+	ns, _ := genericapirequest.NamespaceFrom(ctx)
+	return &porchapi.PackageRevisionDependencies{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageRevisionDependencies",
+			APIVersion: porchapi.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Status: *status,
+	}, nil
+}
+
+// dependencyResolver walks the upstream and downstream references of a package revision.
+// The latest published revision of each upstream package is looked up once per request.
+type dependencyResolver struct {
+	*packageCommon
+	latest map[repository.PackageKey]repository.PackageRevision
+}
+
+func (r *dependencyResolver) resolve(ctx context.Context, pkgRev repository.PackageRevision) (*porchapi.PackageRevisionDependenciesStatus, error) {
+	status := &porchapi.PackageRevisionDependenciesStatus{}
+	visited := map[string]bool{pkgRev.KubeObjectName(): true}
+
+	// Walk up the chain of upstreams until a package revision without a registered upstream is reached.
+	current, err := r.describe(ctx, pkgRev)
+	if err != nil {
+		return nil, err
+	}
+	for upstreamName := current.UpstreamName; upstreamName != "" && !visited[upstreamName]; {
+		visited[upstreamName] = true
+		upstream, err := r.getRepoPkgRev(ctx, upstreamName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// The upstream was deleted, or is in another namespace; the chain ends here.
+				break
+			}
+			return nil, err
+		}
+		dependency, err := r.describe(ctx, upstream)
+		if err != nil {
+			return nil, err
+		}
+		status.Upstream = append(status.Upstream, *dependency)
+		upstreamName = dependency.UpstreamName
+	}
+
+	// Walk down breadth-first, so that each downstream follows its upstream.
+	ns, _ := genericapirequest.NamespaceFrom(ctx)
+	queue := []string{pkgRev.KubeObjectName()}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		downstreamNames, err := r.cad.FindAllDownstreamPackageRevisions(ctx, ns, name)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		for _, downstreamName := range downstreamNames {
+			if visited[downstreamName] {
+				continue
+			}
+			visited[downstreamName] = true
+
+			downstream, err := r.getRepoPkgRev(ctx, downstreamName)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			dependency, err := r.describe(ctx, downstream)
+			if err != nil {
+				return nil, err
+			}
+			status.Downstream = append(status.Downstream, *dependency)
+			queue = append(queue, downstreamName)
+		}
+	}
+
+	return status, nil
+}
+
+// describe returns the dependency entry of a package revision, including whether a newer
+// published revision of its upstream package exists.
+func (r *dependencyResolver) describe(ctx context.Context, pkgRev repository.PackageRevision) (*porchapi.PackageRevisionDependency, error) {
+	apiPkgRev, err := pkgRev.GetPackageRevision(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dependency := &porchapi.PackageRevisionDependency{
+		Name:           pkgRev.KubeObjectName(),
+		RepositoryName: apiPkgRev.Spec.RepositoryName,
+		PackageName:    apiPkgRev.Spec.PackageName,
+		Revision:       apiPkgRev.Spec.Revision,
+		Lifecycle:      apiPkgRev.Spec.Lifecycle,
+		UpstreamName:   repository.UpstreamPackageRevisionName(apiPkgRev.Spec.Tasks),
+	}
+	if dependency.UpstreamName == "" {
+		return dependency, nil
+	}
+
+	upstream, err := r.getRepoPkgRev(ctx, dependency.UpstreamName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return dependency, nil
+		}
+		return nil, err
+	}
+	latest, err := r.latestPublished(ctx, upstream.Key().PkgKey)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		dependency.LatestUpstreamName = latest.KubeObjectName()
+		dependency.BehindUpstream = latest.Key().Revision > upstream.Key().Revision
+	}
+	return dependency, nil
+}
+
+// latestPublished returns the published revision of the package with the highest revision number,
+// or nil if the package has no published revisions.
+func (r *dependencyResolver) latestPublished(ctx context.Context, pkgKey repository.PackageKey) (repository.PackageRevision, error) {
+	if latest, ok := r.latest[pkgKey]; ok {
+		return latest, nil
+	}
+
+	pkgRevs, err := r.cad.ListPackageRevisions(ctx, repository.ListPackageRevisionFilter{
+		Key:        repository.PackageRevisionKey{PkgKey: pkgKey},
+		Lifecycles: []porchapi.PackageRevisionLifecycle{porchapi.PackageRevisionLifecyclePublished},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var latest repository.PackageRevision
+	for _, pkgRev := range pkgRevs {
+		if latest == nil || pkgRev.Key().Revision > latest.Key().Revision {
+			latest = pkgRev
+		}
+	}
+	r.latest[pkgKey] = latest
+	return latest, nil
+}
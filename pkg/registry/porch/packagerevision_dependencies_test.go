@@ -0,0 +1,160 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/kptdev/porch/pkg/externalrepo/fake"
+	"github.com/kptdev/porch/pkg/repository"
+	mockclient "github.com/kptdev/porch/test/mockery/mocks/external/sigs.k8s.io/controller-runtime/pkg/client"
+	mockengine "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func newDependencyPkgRev(repo, pkg, ws string, rev int, lifecycle porchapi.PackageRevisionLifecycle, tasks ...porchapi.Task) *fake.FakePackageRevision {
+	return &fake.FakePackageRevision{
+		PrKey: repository.PackageRevisionKey{
+			PkgKey: repository.PackageKey{
+				RepoKey: repository.RepositoryKey{Namespace: "ns", Name: repo},
+				Package: pkg,
+			},
+			Revision:      rev,
+			WorkspaceName: ws,
+		},
+		PackageLifecycle: lifecycle,
+		PackageRevision: &porchapi.PackageRevision{
+			Spec: porchapi.PackageRevisionSpec{
+				RepositoryName: repo,
+				PackageName:    pkg,
+				WorkspaceName:  ws,
+				Revision:       rev,
+				Lifecycle:      lifecycle,
+				Tasks:          tasks,
+			},
+		},
+	}
+}
+
+func cloneTask(upstream string) porchapi.Task {
+	return porchapi.Task{
+		Type: porchapi.TaskTypeClone,
+		Clone: &porchapi.PackageCloneTaskSpec{
+			Upstream: porchapi.UpstreamPackage{
+				UpstreamRef: &porchapi.PackageRevisionRef{Name: upstream},
+			},
+		},
+	}
+}
+
+func TestDependenciesGet(t *testing.T) {
+	baseV1 := newDependencyPkgRev("blueprints", "base", "v1", 1, porchapi.PackageRevisionLifecyclePublished)
+	baseV2 := newDependencyPkgRev("blueprints", "base", "v2", 2, porchapi.PackageRevisionLifecyclePublished)
+	app := newDependencyPkgRev("deployments", "app", "v1", 1, porchapi.PackageRevisionLifecyclePublished, cloneTask(baseV1.KubeObjectName()))
+	appDraft := newDependencyPkgRev("edge", "app", "ws", 0, porchapi.PackageRevisionLifecycleDraft, cloneTask(app.KubeObjectName()))
+	all := []repository.PackageRevision{baseV1, baseV2, app, appDraft}
+
+	newDependencies := func(t *testing.T) (*packageRevisionDependencies, *mockengine.MockCaDEngine) {
+		mockClient := mockclient.NewMockClient(t)
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), mock.Anything).Return(nil).Maybe()
+		mockEngine := mockengine.NewMockCaDEngine(t)
+		mockEngine.EXPECT().ListPackageRevisions(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, filter repository.ListPackageRevisionFilter) ([]repository.PackageRevision, error) {
+				var result []repository.PackageRevision
+				for _, pr := range all {
+					if filter.Matches(ctx, pr) {
+						result = append(result, pr)
+					}
+				}
+				return result, nil
+			}).Maybe()
+
+		return &packageRevisionDependencies{
+			packageCommon: packageCommon{
+				scheme:     runtime.NewScheme(),
+				gr:         porchapi.Resource("packagerevisions"),
+				coreClient: mockClient,
+				cad:        mockEngine,
+			},
+		}, mockEngine
+	}
+
+	ctx := request.WithNamespace(context.TODO(), "ns")
+
+	t.Run("upstream chain and downstream tree", func(t *testing.T) {
+		deps, mockEngine := newDependencies(t)
+		mockEngine.EXPECT().FindAllDownstreamPackageRevisions(mock.Anything, "ns", app.KubeObjectName()).Return([]string{appDraft.KubeObjectName()}, nil)
+		mockEngine.EXPECT().FindAllDownstreamPackageRevisions(mock.Anything, "ns", appDraft.KubeObjectName()).Return(nil, nil)
+
+		result, err := deps.Get(ctx, app.KubeObjectName(), &metav1.GetOptions{})
+		require.NoError(t, err)
+		require.IsType(t, &porchapi.PackageRevisionDependencies{}, result)
+
+		status := result.(*porchapi.PackageRevisionDependencies).Status
+		require.Len(t, status.Upstream, 1)
+		assert.Equal(t, baseV1.KubeObjectName(), status.Upstream[0].Name)
+		assert.Empty(t, status.Upstream[0].UpstreamName)
+
+		require.Len(t, status.Downstream, 1)
+		assert.Equal(t, appDraft.KubeObjectName(), status.Downstream[0].Name)
+		assert.Equal(t, "edge", status.Downstream[0].RepositoryName)
+		assert.Equal(t, app.KubeObjectName(), status.Downstream[0].UpstreamName)
+		assert.Equal(t, app.KubeObjectName(), status.Downstream[0].LatestUpstreamName)
+		assert.False(t, status.Downstream[0].BehindUpstream)
+	})
+
+	t.Run("downstream behind its upstream", func(t *testing.T) {
+		deps, mockEngine := newDependencies(t)
+		mockEngine.EXPECT().FindAllDownstreamPackageRevisions(mock.Anything, "ns", baseV1.KubeObjectName()).Return([]string{app.KubeObjectName()}, nil)
+		mockEngine.EXPECT().FindAllDownstreamPackageRevisions(mock.Anything, "ns", app.KubeObjectName()).Return([]string{appDraft.KubeObjectName()}, nil)
+		mockEngine.EXPECT().FindAllDownstreamPackageRevisions(mock.Anything, "ns", appDraft.KubeObjectName()).Return(nil, nil)
+
+		result, err := deps.Get(ctx, baseV1.KubeObjectName(), &metav1.GetOptions{})
+		require.NoError(t, err)
+
+		status := result.(*porchapi.PackageRevisionDependencies).Status
+		assert.Empty(t, status.Upstream)
+		require.Len(t, status.Downstream, 2)
+		assert.Equal(t, app.KubeObjectName(), status.Downstream[0].Name)
+		assert.Equal(t, baseV2.KubeObjectName(), status.Downstream[0].LatestUpstreamName)
+		assert.True(t, status.Downstream[0].BehindUpstream)
+		assert.Equal(t, appDraft.KubeObjectName(), status.Downstream[1].Name)
+	})
+
+	t.Run("package revision not found", func(t *testing.T) {
+		deps, _ := newDependencies(t)
+
+		_, err := deps.Get(ctx, "blueprints.base.missing", &metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("downstream lookup fails", func(t *testing.T) {
+		deps, mockEngine := newDependencies(t)
+		mockEngine.EXPECT().FindAllDownstreamPackageRevisions(mock.Anything, "ns", baseV2.KubeObjectName()).Return(nil, errors.New("db down"))
+
+		_, err := deps.Get(ctx, baseV2.KubeObjectName(), &metav1.GetOptions{})
+		assert.True(t, apierrors.IsInternalError(err))
+		assert.ErrorContains(t, err, "db down")
+	})
+}
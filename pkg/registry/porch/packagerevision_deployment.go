@@ -0,0 +1,63 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/util/deploymentstatus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// addDeploymentStatus sets the deployment status of the deployment package revisions
+// from the PackageRevisionDeploymentStatus objects reported by GitOps agents in the
+// namespace (all namespaces if ns is empty). Package revisions of other repositories
+// have no reports, so they are not looked up. The reports are feedback only, so a
+// failure to list them is logged rather than failing the request.
+func (r *packageCommon) addDeploymentStatus(ctx context.Context, ns string, prs ...*porchapi.PackageRevision) {
+	var deployments []*porchapi.PackageRevision
+	for _, pr := range prs {
+		if pr.Status.Deployment {
+			deployments = append(deployments, pr)
+		}
+	}
+	if len(deployments) == 0 {
+		return
+	}
+
+	var opts []client.ListOption
+	if len(deployments) == 1 {
+		// A single package revision, such as for a get, only needs its own reports,
+		// which the API server selects by their package revision field.
+		opts = append(opts, client.InNamespace(deployments[0].Namespace),
+			client.MatchingFields{deploymentstatus.PackageRevisionField: deployments[0].Name})
+	} else if ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+	var reports configapi.PackageRevisionDeploymentStatusList
+	if err := r.coreClient.List(ctx, &reports, opts...); err != nil {
+		klog.Warningf("error listing deployment statuses in namespace %q: %v", ns, err)
+		return
+	}
+
+	grouped := deploymentstatus.GroupByPackageRevision(reports.Items)
+	for _, pr := range deployments {
+		pr.Status.DeploymentStatus = deploymentstatus.ForPackageRevision(grouped[types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name}])
+	}
+}
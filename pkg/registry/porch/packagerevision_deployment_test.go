@@ -0,0 +1,125 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	mockclient "github.com/kptdev/porch/test/mockery/mocks/external/sigs.k8s.io/controller-runtime/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func deploymentPkgRev(name string, deployment bool) *porchapi.PackageRevision {
+	return &porchapi.PackageRevision{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+		Status:     porchapi.PackageRevisionStatus{Deployment: deployment},
+	}
+}
+
+func TestAddDeploymentStatus(t *testing.T) {
+	mockClient := mockclient.NewMockClient(t)
+	mockClient.On("List", mock.Anything, mock.AnythingOfType("*v1alpha1.PackageRevisionDeploymentStatusList"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*configapi.PackageRevisionDeploymentStatusList)
+			list.Items = []configapi.PackageRevisionDeploymentStatus{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "edge-1"},
+					Spec: configapi.PackageRevisionDeploymentStatusSpec{
+						PackageRevision: "deployments.app.v1",
+						Target:          "edge-1",
+						State:           configapi.DeploymentStateApplied,
+						SyncedCommit:    "abc123",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "edge-2"},
+					Spec: configapi.PackageRevisionDeploymentStatusSpec{
+						PackageRevision: "deployments.app.v1",
+						Target:          "edge-2",
+						State:           configapi.DeploymentStateFailed,
+					},
+				},
+			}
+		}).Return(nil).Once()
+	r := &packageCommon{coreClient: mockClient}
+
+	deployed := deploymentPkgRev("deployments.app.v1", true)
+	unreported := deploymentPkgRev("deployments.other.v1", true)
+	blueprint := deploymentPkgRev("deployments.app.v1", false)
+	r.addDeploymentStatus(context.TODO(), "ns", deployed, unreported, blueprint)
+
+	if assert.NotNil(t, deployed.Status.DeploymentStatus) {
+		assert.Equal(t, "Failed", deployed.Status.DeploymentStatus.State)
+		assert.Len(t, deployed.Status.DeploymentStatus.Targets, 2)
+		assert.Equal(t, "abc123", deployed.Status.DeploymentStatus.Targets[0].SyncedCommit)
+	}
+	assert.Nil(t, unreported.Status.DeploymentStatus)
+	assert.Nil(t, blueprint.Status.DeploymentStatus)
+}
+
+func TestAddDeploymentStatusSkipsListing(t *testing.T) {
+	// No deployment package revisions, so the client is never called.
+	r := &packageCommon{coreClient: mockclient.NewMockClient(t)}
+	r.addDeploymentStatus(context.TODO(), "ns", deploymentPkgRev("blueprints.app.v1", false))
+}
+
+func TestAddDeploymentStatusSelectsPackageRevision(t *testing.T) {
+	mockClient := mockclient.NewMockClient(t)
+	mockClient.On("List", mock.Anything, mock.AnythingOfType("*v1alpha1.PackageRevisionDeploymentStatusList"), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			var opts client.ListOptions
+			for _, opt := range args[2:] {
+				opt.(client.ListOption).ApplyToList(&opts)
+			}
+			assert.Equal(t, "ns", opts.Namespace)
+			assert.Equal(t, "spec.packageRevision=deployments.app.v1", opts.FieldSelector.String())
+
+			list := args.Get(1).(*configapi.PackageRevisionDeploymentStatusList)
+			list.Items = []configapi.PackageRevisionDeploymentStatus{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "edge-1"},
+					Spec: configapi.PackageRevisionDeploymentStatusSpec{
+						PackageRevision: "deployments.app.v1",
+						Target:          "edge-1",
+						State:           configapi.DeploymentStateApplied,
+					},
+				},
+			}
+		}).Return(nil).Once()
+	r := &packageCommon{coreClient: mockClient}
+
+	pr := deploymentPkgRev("deployments.app.v1", true)
+	r.addDeploymentStatus(context.TODO(), "", pr)
+	if assert.NotNil(t, pr.Status.DeploymentStatus) {
+		assert.Equal(t, "Applied", pr.Status.DeploymentStatus.State)
+	}
+}
+
+func TestAddDeploymentStatusListError(t *testing.T) {
+	mockClient := mockclient.NewMockClient(t)
+	mockClient.On("List", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("forbidden")).Once()
+	r := &packageCommon{coreClient: mockClient}
+
+	pr := deploymentPkgRev("deployments.app.v1", true)
+	r.addDeploymentStatus(context.TODO(), "ns", pr)
+	assert.Nil(t, pr.Status.DeploymentStatus)
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	kptfilev1 "github.com/kptdev/kpt/api/kptfile/v1"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/engine"
+	"github.com/kptdev/porch/pkg/repository"
+	pctx "github.com/kptdev/porch/pkg/util/context"
+	"github.com/kptdev/porch/pkg/util/pipeline"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+)
+
+type packageRevisionPipeline struct {
+	packageCommon
+}
+
+var _ rest.Storage = &packageRevisionPipeline{}
+var _ rest.Scoper = &packageRevisionPipeline{}
+var _ rest.Getter = &packageRevisionPipeline{}
+var _ rest.Updater = &packageRevisionPipeline{}
+
+// New returns an empty object that can be used with Create and Update after request data has been put into it.
+// This object must be a pointer type for use with Codec.DecodeInto([]byte, runtime.Object)
+func (p *packageRevisionPipeline) New() runtime.Object {
+	return &porchapi.PackageRevisionPipeline{}
+}
+
+func (p *packageRevisionPipeline) Destroy() {}
+
+// NamespaceScoped returns true if the storage is namespaced
+func (p *packageRevisionPipeline) NamespaceScoped() bool {
+	return true
+}
+
+// Get returns the pipeline in the Kptfile of the named package revision.
+func (p *packageRevisionPipeline) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	ctx, span := tracer.Start(ctx, "[START]::packageRevisionPipeline::Get", trace.WithAttributes())
+	defer span.End()
+
+	ctx = pctx.WithNewRequestIDAndPackageRevision(ctx, name)
+
+	pkgRev, err := p.getRepoPkgRevForResources(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := pkgRev.GetResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newPipelineFromResources(name, resources)
+}
+
+// Update rewrites the pipeline in the Kptfile of a draft package revision and renders the package.
+// With dry run, the package is rendered with the new pipeline but nothing is saved. Packages in
+// v1alpha2 repositories are saved without rendering; the PackageRevision controller renders them.
+func (p *packageRevisionPipeline) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, _ rest.ValidateObjectFunc,
+	updateValidation rest.ValidateObjectUpdateFunc, _ bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	ctx, span := tracer.Start(ctx, "[START]::packageRevisionPipeline::Update", trace.WithAttributes())
+	defer span.End()
+
+	ctx = pctx.WithNewRequestIDAndPackageRevision(ctx, name)
+	// A user waits for the render of their update, so its functions are evaluated ahead of background renders
+	ctx = pctx.WithPriority(ctx, requestPriority(ctx))
+
+	namespace, namespaced := genericapirequest.NamespaceFrom(ctx)
+	if !namespaced {
+		return nil, false, apierrors.NewBadRequest("namespace must be specified")
+	}
+
+	pkgMutexKey := getPackageMutexKey(namespace, name)
+	pkgMutex := getMutexForPackage(pkgMutexKey)
+	locked := pkgMutex.TryLock()
+	if !locked {
+		return nil, false,
+			apierrors.NewConflict(
+				porchapi.Resource("packagerevisions"),
+				name,
+				fmt.Errorf(GenericConflictErrorMsg, "package revision pipeline", pkgMutexKey))
+	}
+	defer pkgMutex.Unlock()
+
+	pkgRev, err := p.getRepoPkgRevForResources(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	if lifecycle := pkgRev.Lifecycle(ctx); lifecycle != porchapi.PackageRevisionLifecycleDraft {
+		return nil, false, apierrors.NewBadRequest(
+			fmt.Sprintf("cannot update the pipeline of a package revision with lifecycle value %q; package must be Draft", lifecycle))
+	}
+
+	oldResources, err := pkgRev.GetResources(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	oldObj, err := newPipelineFromResources(name, oldResources)
+	if err != nil {
+		return nil, false, err
+	}
+
+	newRuntimeObj, err := objInfo.UpdatedObject(ctx, oldObj)
+	if err != nil {
+		klog.Infof("update failed to construct UpdatedObject: %v", err)
+		return nil, false, err
+	}
+	newObj, ok := newRuntimeObj.(*porchapi.PackageRevisionPipeline)
+	if !ok {
+		return nil, false, apierrors.NewBadRequest(fmt.Sprintf("expected PackageRevisionPipeline object, got %T", newRuntimeObj))
+	}
+
+	if updateValidation != nil {
+		if err := updateValidation(ctx, newObj, oldObj); err != nil {
+			klog.Infof("update failed validation: %v", err)
+			return nil, false, err
+		}
+	}
+	if err := pipeline.Validate(newObj.Spec); err != nil {
+		return nil, false, apierrors.NewBadRequest(err.Error())
+	}
+	if newObj.ResourceVersion != "" && newObj.ResourceVersion != oldObj.ResourceVersion {
+		return nil, false, apierrors.NewConflict(porchapi.Resource("packagerevisions"), name, fmt.Errorf("%s", engine.OptimisticLockErrorMsg))
+	}
+
+	kptfile, err := pipeline.Set(oldResources.Spec.Resources[kptfilev1.KptFileName], newObj.Spec)
+	if err != nil {
+		return nil, false, apierrors.NewBadRequest(err.Error())
+	}
+	newResources := oldResources.DeepCopy()
+	newResources.Spec.Resources[kptfilev1.KptFileName] = kptfile
+
+	dryRun := options != nil && slices.Contains(options.DryRun, metav1.DryRunAll)
+	klog.InfoS("[API] Update operation started for PackageRevision pipeline",
+		pctx.LogMetadataFromWithExtras(ctx, "dryRun", dryRun)...)
+
+	if dryRun {
+		_, renderStatus, err := p.cad.RenderPackageResources(ctx, namespace, maps.Clone(newResources.Spec.Resources))
+		if renderStatus != nil {
+			newObj.Status.RenderStatus = *renderStatus
+		} else if err != nil {
+			return nil, false, apierrors.NewInternalError(err)
+		}
+		return newObj, false, nil
+	}
+
+	prKey, err := repository.PkgRevK8sName2Key(namespace, name)
+	if err != nil {
+		return nil, false, err
+	}
+	var repositoryObj configapi.Repository
+	repositoryID := types.NamespacedName{Namespace: prKey.RKey().Namespace, Name: prKey.RKey().Name}
+	if err := p.coreClient.Get(ctx, repositoryID, &repositoryObj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, apierrors.NewNotFound(p.gr, repositoryID.Name)
+		}
+		return nil, false, apierrors.NewInternalError(fmt.Errorf("error getting repository %v: %w", repositoryID, err))
+	}
+
+	var rev repository.PackageRevision
+	var renderStatus *porchapi.RenderStatus
+	if isV1Alpha2Repo(&repositoryObj) {
+		// v1alpha2: write resources without render. PR controller renders async.
+		rev, err = p.cad.UpdatePackageResourcesWithoutRender(ctx, &repositoryObj, pkgRev, oldResources, newResources)
+		if err != nil {
+			return nil, false, apierrors.NewInternalError(err)
+		}
+		p.patchRenderRequestAnnotation(ctx, namespace, name, rev.ResourceVersion())
+	} else {
+		rev, renderStatus, err = p.cad.UpdatePackageResources(ctx, &repositoryObj, pkgRev, oldResources, newResources)
+		if err != nil {
+			return nil, false, apierrors.NewInternalError(err)
+		}
+	}
+
+	updatedResources, err := rev.GetResources(ctx)
+	if err != nil {
+		return nil, false, apierrors.NewInternalError(err)
+	}
+	updated, err := newPipelineFromResources(name, updatedResources)
+	if err != nil {
+		return nil, false, err
+	}
+	if renderStatus != nil {
+		updated.Status.RenderStatus = *renderStatus
+	}
+
+	klog.InfoS("[API] Update operation completed for PackageRevision pipeline", pctx.LogMetadataFrom(ctx)...)
+
+	return updated, false, nil
+}
+
+// newPipelineFromResources reads the pipeline from the Kptfile in the resources of a package revision.
+// The resource version of the resources is used as the resource version of the pipeline.
+func newPipelineFromResources(name string, resources *porchapi.PackageRevisionResources) (*porchapi.PackageRevisionPipeline, error) {
+	kptfile, found := resources.Spec.Resources[kptfilev1.KptFileName]
+	if !found {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("package revision %s has no %s", name, kptfilev1.KptFileName))
+	}
+	spec, err := pipeline.Get(kptfile)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	return &porchapi.PackageRevisionPipeline{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PackageRevisionPipeline",
+			APIVersion: porchapi.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       resources.Namespace,
+			ResourceVersion: resources.ResourceVersion,
+		},
+		Spec: spec,
+	}, nil
+}
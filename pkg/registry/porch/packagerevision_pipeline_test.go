@@ -0,0 +1,244 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"testing"
+
+	kptfilev1 "github.com/kptdev/kpt/api/kptfile/v1"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/externalrepo/fake"
+	"github.com/kptdev/porch/pkg/repository"
+	mockclient "github.com/kptdev/porch/test/mockery/mocks/external/sigs.k8s.io/controller-runtime/pkg/client"
+	mockengine "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const pipelineTestKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: app
+pipeline:
+  mutators:
+    # sets the namespace of all resources
+    - image: ghcr.io/kptdev/krm-functions-catalog/set-namespace:v0.4.1
+      configMap:
+        namespace: app
+`
+
+func newPipelinePkgRev(lifecycle porchapi.PackageRevisionLifecycle) *fake.FakePackageRevision {
+	pkgRev := newDependencyPkgRev("deployments", "app", "ws", 0, lifecycle)
+	pkgRev.Resources = &porchapi.PackageRevisionResources{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            pkgRev.KubeObjectName(),
+			Namespace:       "ns",
+			ResourceVersion: "7",
+		},
+		Spec: porchapi.PackageRevisionResourcesSpec{
+			Resources: map[string]string{
+				kptfilev1.KptFileName: pipelineTestKptfile,
+				"cm.yaml":             "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\n",
+			},
+		},
+	}
+	return pkgRev
+}
+
+func newTestPipeline(t *testing.T, pkgRev repository.PackageRevision) (*packageRevisionPipeline, *mockengine.MockCaDEngine) {
+	mockClient := mockclient.NewMockClient(t)
+	mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), mock.Anything).Return(nil).Maybe()
+	mockEngine := mockengine.NewMockCaDEngine(t)
+	mockEngine.EXPECT().ListPackageRevisions(mock.Anything, mock.Anything).Return([]repository.PackageRevision{pkgRev}, nil).Maybe()
+
+	return &packageRevisionPipeline{
+		packageCommon: packageCommon{
+			scheme:     runtime.NewScheme(),
+			gr:         porchapi.Resource("packagerevisions"),
+			coreClient: mockClient,
+			cad:        mockEngine,
+		},
+	}, mockEngine
+}
+
+func TestPipelineGet(t *testing.T) {
+	pkgRev := newPipelinePkgRev(porchapi.PackageRevisionLifecycleDraft)
+	pipeline, _ := newTestPipeline(t, pkgRev)
+
+	ctx := request.WithNamespace(context.TODO(), "ns")
+	result, err := pipeline.Get(ctx, pkgRev.KubeObjectName(), &metav1.GetOptions{})
+	require.NoError(t, err)
+	require.IsType(t, &porchapi.PackageRevisionPipeline{}, result)
+
+	obj := result.(*porchapi.PackageRevisionPipeline)
+	assert.Equal(t, pkgRev.KubeObjectName(), obj.Name)
+	assert.Equal(t, "7", obj.ResourceVersion)
+	assert.Equal(t, []porchapi.PipelineFunction{{
+		Image:     "ghcr.io/kptdev/krm-functions-catalog/set-namespace:v0.4.1",
+		ConfigMap: map[string]string{"namespace": "app"},
+	}}, obj.Spec.Mutators)
+	assert.Empty(t, obj.Spec.Validators)
+}
+
+func TestPipelineUpdate(t *testing.T) {
+	ctx := request.WithNamespace(context.TODO(), "ns")
+	validator := porchapi.PipelineFunction{Name: "kubeconform", Image: "ghcr.io/kptdev/krm-functions-catalog/kubeconform:v0.1.1"}
+
+	addValidator := func(resourceVersion string) rest.UpdatedObjectInfo {
+		return rest.DefaultUpdatedObjectInfo(nil, func(_ context.Context, newObj, oldObj runtime.Object) (runtime.Object, error) {
+			obj := oldObj.DeepCopyObject().(*porchapi.PackageRevisionPipeline)
+			obj.ResourceVersion = resourceVersion
+			obj.Spec.Validators = append(obj.Spec.Validators, validator)
+			return obj, nil
+		})
+	}
+
+	t.Run("update renders and saves the package", func(t *testing.T) {
+		pkgRev := newPipelinePkgRev(porchapi.PackageRevisionLifecycleDraft)
+		pipeline, mockEngine := newTestPipeline(t, pkgRev)
+
+		renderStatus := &porchapi.RenderStatus{}
+		mockEngine.EXPECT().UpdatePackageResources(mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), pkgRev, pkgRev.Resources, mock.Anything).RunAndReturn(
+			func(_ context.Context, _ *configapi.Repository, _ repository.PackageRevision, oldRes, newRes *porchapi.PackageRevisionResources) (repository.PackageRevision, *porchapi.RenderStatus, error) {
+				assert.Equal(t, oldRes.Spec.Resources["cm.yaml"], newRes.Spec.Resources["cm.yaml"])
+				kptfile := newRes.Spec.Resources[kptfilev1.KptFileName]
+				assert.Contains(t, kptfile, "# sets the namespace of all resources")
+				assert.Contains(t, kptfile, "kubeconform:v0.1.1")
+
+				updated := newPipelinePkgRev(porchapi.PackageRevisionLifecycleDraft)
+				updated.Resources = newRes.DeepCopy()
+				updated.Resources.ResourceVersion = "8"
+				return updated, renderStatus, nil
+			})
+
+		result, created, err := pipeline.Update(ctx, pkgRev.KubeObjectName(), addValidator("7"), nil, nil, false, &metav1.UpdateOptions{})
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		obj := result.(*porchapi.PackageRevisionPipeline)
+		assert.Equal(t, "8", obj.ResourceVersion)
+		assert.Equal(t, []porchapi.PipelineFunction{validator}, obj.Spec.Validators)
+		// the stored package revision is unchanged
+		assert.Equal(t, pipelineTestKptfile, pkgRev.Resources.Spec.Resources[kptfilev1.KptFileName])
+	})
+
+	t.Run("dry run renders without saving", func(t *testing.T) {
+		pkgRev := newPipelinePkgRev(porchapi.PackageRevisionLifecycleDraft)
+		pipeline, mockEngine := newTestPipeline(t, pkgRev)
+
+		mockEngine.EXPECT().RenderPackageResources(mock.Anything, "ns", mock.Anything).RunAndReturn(
+			func(_ context.Context, _ string, resources map[string]string) (map[string]string, *porchapi.RenderStatus, error) {
+				assert.Contains(t, resources[kptfilev1.KptFileName], "kubeconform")
+				return resources, &porchapi.RenderStatus{Err: "validation failed"}, assert.AnError
+			})
+
+		dryRun := &metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}}
+		result, _, err := pipeline.Update(ctx, pkgRev.KubeObjectName(), addValidator(""), nil, nil, false, dryRun)
+		require.NoError(t, err)
+
+		obj := result.(*porchapi.PackageRevisionPipeline)
+		assert.Equal(t, "validation failed", obj.Status.RenderStatus.Err)
+		assert.Equal(t, []porchapi.PipelineFunction{validator}, obj.Spec.Validators)
+		mockEngine.AssertNotCalled(t, "UpdatePackageResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("v1alpha2 repository saves without rendering", func(t *testing.T) {
+		pkgRev := newPipelinePkgRev(porchapi.PackageRevisionLifecycleDraft)
+		mockClient := mockclient.NewMockClient(t)
+		mockClient.EXPECT().Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), mock.Anything).
+			RunAndReturn(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				obj.SetAnnotations(map[string]string{configapi.AnnotationKeyV1Alpha2Migration: configapi.AnnotationValueMigrationEnabled})
+				return nil
+			})
+		mockClient.EXPECT().Get(mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha2.PackageRevision")).Return(nil)
+		var renderRequest string
+		mockClient.EXPECT().Patch(mock.Anything, mock.AnythingOfType("*v1alpha2.PackageRevision"), mock.Anything).
+			RunAndReturn(func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+				renderRequest = obj.GetAnnotations()[porchv1alpha2.AnnotationRenderRequest]
+				return nil
+			})
+		mockEngine := mockengine.NewMockCaDEngine(t)
+		mockEngine.EXPECT().ListPackageRevisions(mock.Anything, mock.Anything).Return([]repository.PackageRevision{pkgRev}, nil)
+		mockEngine.EXPECT().UpdatePackageResourcesWithoutRender(mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), pkgRev, pkgRev.Resources, mock.Anything).RunAndReturn(
+			func(_ context.Context, _ *configapi.Repository, _ repository.PackageRevision, _, newRes *porchapi.PackageRevisionResources) (repository.PackageRevision, error) {
+				assert.Contains(t, newRes.Spec.Resources[kptfilev1.KptFileName], "kubeconform:v0.1.1")
+				updated := newPipelinePkgRev(porchapi.PackageRevisionLifecycleDraft)
+				updated.Resources = newRes.DeepCopy()
+				updated.Resources.ResourceVersion = "8"
+				return updated, nil
+			})
+		pipeline := &packageRevisionPipeline{
+			packageCommon: packageCommon{
+				scheme:     runtime.NewScheme(),
+				gr:         porchapi.Resource("packagerevisions"),
+				coreClient: mockClient,
+				cad:        mockEngine,
+			},
+		}
+
+		result, _, err := pipeline.Update(ctx, pkgRev.KubeObjectName(), addValidator("7"), nil, nil, false, &metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		obj := result.(*porchapi.PackageRevisionPipeline)
+		assert.Equal(t, "8", obj.ResourceVersion)
+		assert.Empty(t, obj.Status.RenderStatus)
+		assert.NotEmpty(t, renderRequest, "the controller is asked to render the package")
+		mockEngine.AssertNotCalled(t, "UpdatePackageResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("only drafts can be updated", func(t *testing.T) {
+		pkgRev := newPipelinePkgRev(porchapi.PackageRevisionLifecyclePublished)
+		pipeline, _ := newTestPipeline(t, pkgRev)
+
+		_, _, err := pipeline.Update(ctx, pkgRev.KubeObjectName(), addValidator("7"), nil, nil, false, &metav1.UpdateOptions{})
+		require.Error(t, err)
+		assert.True(t, apierrors.IsBadRequest(err))
+		assert.Contains(t, err.Error(), "package must be Draft")
+	})
+
+	t.Run("stale resource version", func(t *testing.T) {
+		pkgRev := newPipelinePkgRev(porchapi.PackageRevisionLifecycleDraft)
+		pipeline, _ := newTestPipeline(t, pkgRev)
+
+		_, _, err := pipeline.Update(ctx, pkgRev.KubeObjectName(), addValidator("6"), nil, nil, false, &metav1.UpdateOptions{})
+		require.Error(t, err)
+		assert.True(t, apierrors.IsConflict(err))
+	})
+
+	t.Run("invalid pipeline", func(t *testing.T) {
+		pkgRev := newPipelinePkgRev(porchapi.PackageRevisionLifecycleDraft)
+		pipeline, _ := newTestPipeline(t, pkgRev)
+
+		missingImage := rest.DefaultUpdatedObjectInfo(nil, func(_ context.Context, _, oldObj runtime.Object) (runtime.Object, error) {
+			obj := oldObj.DeepCopyObject().(*porchapi.PackageRevisionPipeline)
+			obj.Spec.Mutators = append(obj.Spec.Mutators, porchapi.PipelineFunction{Name: "no-image"})
+			return obj, nil
+		})
+		_, _, err := pipeline.Update(ctx, pkgRev.KubeObjectName(), missingImage, nil, nil, false, &metav1.UpdateOptions{})
+		require.Error(t, err)
+		assert.True(t, apierrors.IsBadRequest(err))
+		assert.Contains(t, err.Error(), "mutators[1]: image is required")
+	})
+}
@@ -145,6 +145,9 @@ func filterCalls(calls []*mock.Call, method string) []*mock.Call {
 func TestList(t *testing.T) {
 	mockClient, mockEngine := setup(t)
 	mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), mock.Anything).Return(nil).Maybe()
+	mockWatcherManager := mockengine.NewMockWatcherManager(t)
+	mockEngine.On("ObjectCache").Return(mockWatcherManager)
+	mockWatcherManager.On("ResourceVersion").Return("1234")
 	mockEngine.On("ListPackageRevisions", mock.Anything, mock.Anything).Return([]repository.PackageRevision{
 		packageRevision,
 	}, nil).Once()
@@ -152,6 +155,7 @@ func TestList(t *testing.T) {
 	result, err := packagerevisions.List(context.TODO(), &internalversion.ListOptions{})
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(result.(*porchapi.PackageRevisionList).Items))
+	assert.Equal(t, "1234", result.(*porchapi.PackageRevisionList).ResourceVersion)
 
 	//=========================================================================================
 
@@ -0,0 +1,434 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/repository"
+	pctx "github.com/kptdev/porch/pkg/util/context"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+)
+
+// packageRevisionBatches applies a lifecycle operation to many package revisions. The package
+// revisions are grouped by repository, and the changes to each repository are validated up front
+// and pushed in a single push, so that either all or none of them are applied.
+type packageRevisionBatches struct {
+	packageCommon
+
+	// authorizer checks that the caller may apply the operation to each selected package revision
+	// through the packagerevisions API, so that a batch grants no more than the individual requests.
+	authorizer authorizer.Authorizer
+}
+
+var _ rest.Storage = &packageRevisionBatches{}
+var _ rest.Scoper = &packageRevisionBatches{}
+var _ rest.Creater = &packageRevisionBatches{}
+
+// New returns an empty object that can be used with Create and Update after request data has been put into it.
+// This object must be a pointer type for use with Codec.DecodeInto([]byte, runtime.Object)
+func (b *packageRevisionBatches) New() runtime.Object {
+	return &porchapi.PackageRevisionBatch{}
+}
+
+func (b *packageRevisionBatches) Destroy() {}
+
+// NamespaceScoped returns true if the storage is namespaced
+func (b *packageRevisionBatches) NamespaceScoped() bool {
+	return true
+}
+
+// batchItem is a package revision selected by a PackageRevisionBatch.
+type batchItem struct {
+	name       string
+	repository string
+	repoPkgRev repository.PackageRevision
+	apiPkgRev  *porchapi.PackageRevision
+	// newLifecycle is the lifecycle the operation moves the package revision to; empty for deletion
+	newLifecycle porchapi.PackageRevisionLifecycle
+}
+
+// Create applies the operation of the PackageRevisionBatch and returns the batch with the outcome
+// for each package revision in its status. Failures of individual package revisions are reported
+// in the status rather than as an error.
+func (b *packageRevisionBatches) Create(ctx context.Context, runtimeObject runtime.Object, createValidation rest.ValidateObjectFunc,
+	options *metav1.CreateOptions) (runtime.Object, error) {
+	ctx, span := tracer.Start(ctx, "[START]::packageRevisionBatches::Create", trace.WithAttributes())
+	defer span.End()
+
+	ctx = pctx.WithNewRequestID(ctx)
+
+	ns, namespaced := genericapirequest.NamespaceFrom(ctx)
+	if !namespaced {
+		return nil, apierrors.NewBadRequest("namespace must be specified")
+	}
+
+	batch, ok := runtimeObject.(*porchapi.PackageRevisionBatch)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected PackageRevisionBatch object, got %T", runtimeObject))
+	}
+	if err := validateBatchSpec(&batch.Spec); err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	if createValidation != nil {
+		if err := createValidation(ctx, batch); err != nil {
+			return nil, err
+		}
+	}
+
+	dryRun := options != nil && slices.Contains(options.DryRun, metav1.DryRunAll)
+
+	klog.InfoS("[API] Operation started for PackageRevisionBatch",
+		pctx.LogMetadataFromWithExtras(ctx, "operation", batch.Spec.Operation, "dryRun", dryRun)...)
+
+	names, err := b.selectBatchPackageRevisions(ctx, ns, &batch.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.authorizeBatch(ctx, ns, names, batch.Spec.Operation); err != nil {
+		return nil, err
+	}
+
+	byRepository := map[string][]string{}
+	for _, name := range names {
+		repoName := name
+		if prKey, err := repository.PkgRevK8sName2Key(ns, name); err == nil {
+			repoName = prKey.RKey().Name
+		}
+		byRepository[repoName] = append(byRepository[repoName], name)
+	}
+	repositories := make([]string, 0, len(byRepository))
+	for repoName := range byRepository {
+		repositories = append(repositories, repoName)
+	}
+	sort.Strings(repositories)
+
+	result := batch.DeepCopy()
+	result.Namespace = ns
+	result.Status.Results = nil
+	for _, repoName := range repositories {
+		result.Status.Results = append(result.Status.Results,
+			b.applyToRepository(ctx, ns, repoName, byRepository[repoName], batch.Spec.Operation, dryRun)...)
+	}
+
+	klog.InfoS("[API] Operation completed for PackageRevisionBatch",
+		pctx.LogMetadataFromWithExtras(ctx, "operation", batch.Spec.Operation, "packageRevisions", len(names))...)
+
+	return result, nil
+}
+
+func validateBatchSpec(spec *porchapi.PackageRevisionBatchSpec) error {
+	switch spec.Operation {
+	case porchapi.BatchOperationPropose, porchapi.BatchOperationApprove, porchapi.BatchOperationReject,
+		porchapi.BatchOperationProposeDelete, porchapi.BatchOperationDelete:
+	default:
+		return fmt.Errorf("spec.operation must be one of %s, %s, %s, %s, %s",
+			porchapi.BatchOperationPropose, porchapi.BatchOperationApprove, porchapi.BatchOperationReject,
+			porchapi.BatchOperationProposeDelete, porchapi.BatchOperationDelete)
+	}
+	if len(spec.PackageRevisions) == 0 && spec.Selector == nil {
+		return fmt.Errorf("spec.packageRevisions or spec.selector must be set")
+	}
+	return nil
+}
+
+// authorizeBatch checks that the user may apply the operation to each of the named package
+// revisions with the request that porchctl sends for a single package revision: an update of the
+// approval subresource to approve or reject, an update to propose or propose deletion, and a
+// delete. If the user may not apply it to one of them, the batch is rejected as a whole.
+func (b *packageRevisionBatches) authorizeBatch(ctx context.Context, ns string, names []string,
+	operation porchapi.PackageRevisionBatchOperation) error {
+	if b.authorizer == nil {
+		return nil
+	}
+	user, ok := genericapirequest.UserFrom(ctx)
+	if !ok {
+		return apierrors.NewForbidden(porchapi.Resource("packagerevisionbatches"), "", fmt.Errorf("no user in request"))
+	}
+
+	verb, subresource := "update", ""
+	switch operation {
+	case porchapi.BatchOperationApprove, porchapi.BatchOperationReject:
+		subresource = "approval"
+	case porchapi.BatchOperationDelete:
+		verb = "delete"
+	}
+
+	for _, name := range names {
+		decision, reason, err := b.authorizer.Authorize(ctx, authorizer.AttributesRecord{
+			User:            user,
+			Verb:            verb,
+			Namespace:       ns,
+			APIGroup:        porchapi.GroupName,
+			APIVersion:      porchapi.SchemeGroupVersion.Version,
+			Resource:        "packagerevisions",
+			Subresource:     subresource,
+			Name:            name,
+			ResourceRequest: true,
+		})
+		if err != nil {
+			return apierrors.NewInternalError(fmt.Errorf("error authorizing %s of package revision %q: %w", operation, name, err))
+		}
+		if decision != authorizer.DecisionAllow {
+			return apierrors.NewForbidden(porchapi.Resource("packagerevisions"), name,
+				fmt.Errorf("user %q may not apply %s to package revision %q: %s", user.GetName(), operation, name, reason))
+		}
+	}
+	return nil
+}
+
+// selectBatchPackageRevisions returns the sorted names of the package revisions listed in the spec
+// and of the package revisions matched by its selector.
+func (b *packageRevisionBatches) selectBatchPackageRevisions(ctx context.Context, ns string, spec *porchapi.PackageRevisionBatchSpec) ([]string, error) {
+	selected := map[string]bool{}
+	for _, name := range spec.PackageRevisions {
+		selected[name] = true
+	}
+
+	if spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.Selector)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid spec.selector: %v", err))
+		}
+		filter := repository.ListPackageRevisionFilter{
+			Key: repository.PackageRevisionKey{
+				PkgKey: repository.PackageKey{
+					RepoKey: repository.RepositoryKey{Namespace: ns},
+				},
+			},
+			Label: selector,
+		}
+		if err := b.listPackageRevisions(ctx, filter, func(ctx context.Context, p repository.PackageRevision) error {
+			selected[p.KubeObjectName()] = true
+			return nil
+		}); err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+	}
+
+	names := make([]string, 0, len(selected))
+	for name := range selected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// applyToRepository applies the operation to the named package revisions of a repository. If the
+// operation cannot be applied to one of them, it is applied to none of them.
+func (b *packageRevisionBatches) applyToRepository(ctx context.Context, ns, repoName string, names []string,
+	operation porchapi.PackageRevisionBatchOperation, dryRun bool) []porchapi.PackageRevisionBatchResult {
+	results := make([]porchapi.PackageRevisionBatchResult, len(names))
+	for i, name := range names {
+		results[i] = porchapi.PackageRevisionBatchResult{Name: name, Repository: repoName}
+	}
+	failAll := func(message string) []porchapi.PackageRevisionBatchResult {
+		for i := range results {
+			results[i].Outcome = porchapi.BatchOutcomeFailed
+			results[i].Message = message
+		}
+		return results
+	}
+
+	repositoryObj, err := b.getRepositoryObj(ctx, types.NamespacedName{Name: repoName, Namespace: ns})
+	if err != nil {
+		return failAll(err.Error())
+	}
+	if isV1Alpha2Repo(repositoryObj) {
+		return failAll(fmt.Sprintf("repository %q is managed by v1alpha2; use the v1alpha2 API", repoName))
+	}
+
+	mutexes := make([]*sync.Mutex, 0, len(names))
+	defer func() {
+		for _, mutex := range mutexes {
+			mutex.Unlock()
+		}
+	}()
+
+	items := make([]batchItem, len(names))
+	failures := map[int]string{}
+	for i, name := range names {
+		pkgMutexKey := getPackageMutexKey(ns, name)
+		pkgMutex := getMutexForPackage(pkgMutexKey)
+		if !pkgMutex.TryLock() {
+			failures[i] = fmt.Sprintf(GenericConflictErrorMsg, "package revision", pkgMutexKey)
+			continue
+		}
+		mutexes = append(mutexes, pkgMutex)
+
+		item, err := b.prepareBatchItem(ctx, name, repoName, operation)
+		if err != nil {
+			failures[i] = err.Error()
+			continue
+		}
+		items[i] = *item
+	}
+
+	if len(failures) > 0 {
+		for i := range results {
+			if message, failed := failures[i]; failed {
+				results[i].Outcome = porchapi.BatchOutcomeFailed
+				results[i].Message = message
+			} else {
+				results[i].Outcome = porchapi.BatchOutcomeAborted
+				results[i].Message = fmt.Sprintf("not applied because the operation failed for another package revision in repository %q", repoName)
+			}
+		}
+		return results
+	}
+
+	if !dryRun {
+		failed := -1
+		err := b.cad.BatchRepositoryUpdates(ctx, repositoryObj, func(ctx context.Context) error {
+			for i := range items {
+				if err := b.applyBatchItem(ctx, repositoryObj, &items[i]); err != nil {
+					failed = i
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			klog.ErrorS(err, "[API] PackageRevisionBatch failed for repository", pctx.LogMetadataFromWithExtras(ctx, "repository", repoName)...)
+			for i := range results {
+				if i == failed {
+					results[i].Outcome = porchapi.BatchOutcomeFailed
+					results[i].Message = err.Error()
+				} else {
+					results[i].Outcome = porchapi.BatchOutcomeAborted
+					results[i].Message = fmt.Sprintf("not applied because the changes to repository %q could not be pushed: %v", repoName, err)
+				}
+			}
+			return results
+		}
+	}
+
+	for i := range results {
+		results[i].Outcome = porchapi.BatchOutcomeSucceeded
+	}
+	return results
+}
+
+// prepareBatchItem looks up a package revision and checks that the operation can be applied to it.
+func (b *packageRevisionBatches) prepareBatchItem(ctx context.Context, name, repoName string,
+	operation porchapi.PackageRevisionBatchOperation) (*batchItem, error) {
+	repoPkgRev, err := b.getRepoPkgRev(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	apiPkgRev, err := repoPkgRev.GetPackageRevision(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &batchItem{
+		name:       name,
+		repository: repoName,
+		repoPkgRev: repoPkgRev,
+		apiPkgRev:  apiPkgRev,
+	}
+
+	lifecycle := apiPkgRev.Spec.Lifecycle
+	// porchctl checks the readiness gates before it proposes or approves a single package
+	// revision, so the batch does the same
+	ready := porchapi.PackageRevisionIsReady(apiPkgRev.Spec.ReadinessGates, apiPkgRev.Status.Conditions)
+
+	switch operation {
+	case porchapi.BatchOperationPropose:
+		if lifecycle != porchapi.PackageRevisionLifecycleDraft {
+			return nil, fmt.Errorf("cannot propose package revision with %s lifecycle; only Draft package revisions can be proposed", lifecycle)
+		}
+		if !ready {
+			return nil, fmt.Errorf("readiness conditions not met")
+		}
+		if c := mergeconflict.Unresolved(apiPkgRev.Status.Conditions); c != nil {
+			return nil, fmt.Errorf("%s", c.Message)
+		}
+		item.newLifecycle = porchapi.PackageRevisionLifecycleProposed
+
+	case porchapi.BatchOperationApprove:
+		if lifecycle != porchapi.PackageRevisionLifecycleProposed {
+			return nil, fmt.Errorf("cannot approve package revision with %s lifecycle; only Proposed package revisions can be approved", lifecycle)
+		}
+		if !ready {
+			return nil, fmt.Errorf("readiness conditions not met")
+		}
+		item.newLifecycle = porchapi.PackageRevisionLifecyclePublished
+
+	case porchapi.BatchOperationReject:
+		switch lifecycle {
+		case porchapi.PackageRevisionLifecycleProposed:
+			item.newLifecycle = porchapi.PackageRevisionLifecycleDraft
+		case porchapi.PackageRevisionLifecycleDeletionProposed:
+			item.newLifecycle = porchapi.PackageRevisionLifecyclePublished
+		default:
+			return nil, fmt.Errorf("cannot reject package revision with %s lifecycle; only Proposed and DeletionProposed package revisions can be rejected", lifecycle)
+		}
+
+	case porchapi.BatchOperationProposeDelete:
+		if lifecycle != porchapi.PackageRevisionLifecyclePublished {
+			return nil, fmt.Errorf("cannot propose deletion of package revision with %s lifecycle; only Published package revisions can be proposed for deletion", lifecycle)
+		}
+		item.newLifecycle = porchapi.PackageRevisionLifecycleDeletionProposed
+
+	case porchapi.BatchOperationDelete:
+		if lifecycle == porchapi.PackageRevisionLifecyclePublished {
+			return nil, fmt.Errorf("published package revisions must be proposed for deletion before they can be deleted")
+		}
+		if err := b.checkIfUpstreamIsReferenced(ctx, apiPkgRev); err != nil {
+			return nil, err
+		}
+	}
+	return item, nil
+}
+
+// applyBatchItem applies the operation to a package revision that prepareBatchItem accepted.
+func (b *packageRevisionBatches) applyBatchItem(ctx context.Context, repositoryObj *configapi.Repository, item *batchItem) error {
+	ctx = pctx.WithPackageRevision(ctx, item.name)
+
+	if item.newLifecycle == "" {
+		return b.cad.DeletePackageRevision(ctx, repositoryObj, item.repoPkgRev)
+	}
+
+	var parentPackage repository.PackageRevision
+	if parent := item.apiPkgRev.Spec.Parent; parent != nil && parent.Name != "" {
+		p, err := b.getRepoPkgRev(ctx, parent.Name)
+		if err != nil {
+			return fmt.Errorf("cannot get parent package %q: %w", parent.Name, err)
+		}
+		parentPackage = p
+	}
+
+	newApiPkgRev := item.apiPkgRev.DeepCopy()
+	newApiPkgRev.Spec.Lifecycle = item.newLifecycle
+	_, err := b.cad.UpdatePackageRevision(ctx, 0, repositoryObj, item.repoPkgRev, item.apiPkgRev, newApiPkgRev, parentPackage)
+	return err
+}
@@ -0,0 +1,345 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/externalrepo/fake"
+	"github.com/kptdev/porch/pkg/repository"
+	mockclient "github.com/kptdev/porch/test/mockery/mocks/external/sigs.k8s.io/controller-runtime/pkg/client"
+	mockengine "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func newBatchPkgRev(repo, pkg string, lifecycle porchapi.PackageRevisionLifecycle, labels map[string]string) *fake.FakePackageRevision {
+	pr := newDependencyPkgRev(repo, pkg, "v1", 0, lifecycle)
+	pr.Meta = &metav1.ObjectMeta{Labels: labels}
+	return pr
+}
+
+func TestPackageRevisionBatches(t *testing.T) {
+	blue := map[string]string{"team": "blue"}
+
+	type fixture struct {
+		batches *packageRevisionBatches
+		engine  *mockengine.MockCaDEngine
+
+		mutex   sync.Mutex
+		updated map[string]porchapi.PackageRevisionLifecycle
+		deleted []string
+	}
+
+	newFixture := func(t *testing.T, revisions ...repository.PackageRevision) *fixture {
+		mockClient := mockclient.NewMockClient(t)
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), mock.Anything).Return(nil).Maybe()
+		mockEngine := mockengine.NewMockCaDEngine(t)
+		mockEngine.EXPECT().ListPackageRevisions(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, filter repository.ListPackageRevisionFilter) ([]repository.PackageRevision, error) {
+				var matched []repository.PackageRevision
+				for _, rev := range revisions {
+					if filter.Matches(ctx, rev) {
+						matched = append(matched, rev)
+					}
+				}
+				return matched, nil
+			}).Maybe()
+
+		f := &fixture{
+			batches: &packageRevisionBatches{
+				packageCommon: packageCommon{
+					scheme:     runtime.NewScheme(),
+					gr:         porchapi.Resource("packagerevisionbatches"),
+					coreClient: mockClient,
+					cad:        mockEngine,
+				},
+			},
+			engine:  mockEngine,
+			updated: map[string]porchapi.PackageRevisionLifecycle{},
+		}
+		mockEngine.EXPECT().UpdatePackageRevision(mock.Anything, 0, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+			func(_ context.Context, _ int, _ *configapi.Repository, oldPackage repository.PackageRevision, _, newObj *porchapi.PackageRevision, _ repository.PackageRevision) (repository.PackageRevision, error) {
+				f.mutex.Lock()
+				defer f.mutex.Unlock()
+				f.updated[oldPackage.KubeObjectName()] = newObj.Spec.Lifecycle
+				return oldPackage, nil
+			}).Maybe()
+		mockEngine.EXPECT().DeletePackageRevision(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+			func(_ context.Context, _ *configapi.Repository, pr repository.PackageRevision) error {
+				f.mutex.Lock()
+				defer f.mutex.Unlock()
+				f.deleted = append(f.deleted, pr.KubeObjectName())
+				return nil
+			}).Maybe()
+		return f
+	}
+
+	runUpdates := func(ctx context.Context, _ *configapi.Repository, updates func(ctx context.Context) error) error {
+		return updates(ctx)
+	}
+
+	ctx := request.WithNamespace(context.TODO(), "ns")
+
+	outcomes := func(t *testing.T, result runtime.Object) map[string]porchapi.PackageRevisionBatchOutcome {
+		t.Helper()
+		require.IsType(t, &porchapi.PackageRevisionBatch{}, result)
+		got := map[string]porchapi.PackageRevisionBatchOutcome{}
+		for _, r := range result.(*porchapi.PackageRevisionBatch).Status.Results {
+			got[r.Name] = r.Outcome
+		}
+		return got
+	}
+
+	t.Run("approve named package revisions in two repositories", func(t *testing.T) {
+		a := newBatchPkgRev("blueprints", "a", porchapi.PackageRevisionLifecycleProposed, nil)
+		b := newBatchPkgRev("blueprints", "b", porchapi.PackageRevisionLifecycleProposed, nil)
+		c := newBatchPkgRev("deployments", "c", porchapi.PackageRevisionLifecycleProposed, nil)
+		f := newFixture(t, a, b, c)
+		f.engine.EXPECT().BatchRepositoryUpdates(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(runUpdates).Times(2)
+
+		result, err := f.batches.Create(ctx, &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{
+				Operation:        porchapi.BatchOperationApprove,
+				PackageRevisions: []string{c.KubeObjectName(), a.KubeObjectName(), b.KubeObjectName()},
+			},
+		}, nil, &metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]porchapi.PackageRevisionBatchOutcome{
+			a.KubeObjectName(): porchapi.BatchOutcomeSucceeded,
+			b.KubeObjectName(): porchapi.BatchOutcomeSucceeded,
+			c.KubeObjectName(): porchapi.BatchOutcomeSucceeded,
+		}, outcomes(t, result))
+		results := result.(*porchapi.PackageRevisionBatch).Status.Results
+		assert.Equal(t, "blueprints", results[0].Repository)
+		assert.Equal(t, "deployments", results[2].Repository)
+		assert.Equal(t, map[string]porchapi.PackageRevisionLifecycle{
+			a.KubeObjectName(): porchapi.PackageRevisionLifecyclePublished,
+			b.KubeObjectName(): porchapi.PackageRevisionLifecyclePublished,
+			c.KubeObjectName(): porchapi.PackageRevisionLifecyclePublished,
+		}, f.updated)
+	})
+
+	t.Run("propose package revisions matched by selector", func(t *testing.T) {
+		a := newBatchPkgRev("blueprints", "a", porchapi.PackageRevisionLifecycleDraft, blue)
+		b := newBatchPkgRev("blueprints", "b", porchapi.PackageRevisionLifecycleDraft, map[string]string{"team": "red"})
+		f := newFixture(t, a, b)
+		f.engine.EXPECT().BatchRepositoryUpdates(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(runUpdates).Once()
+
+		result, err := f.batches.Create(ctx, &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{
+				Operation: porchapi.BatchOperationPropose,
+				Selector:  &metav1.LabelSelector{MatchLabels: blue},
+			},
+		}, nil, &metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]porchapi.PackageRevisionBatchOutcome{
+			a.KubeObjectName(): porchapi.BatchOutcomeSucceeded,
+		}, outcomes(t, result))
+		assert.Equal(t, map[string]porchapi.PackageRevisionLifecycle{
+			a.KubeObjectName(): porchapi.PackageRevisionLifecycleProposed,
+		}, f.updated)
+	})
+
+	t.Run("invalid transition aborts the rest of the repository", func(t *testing.T) {
+		a := newBatchPkgRev("blueprints", "a", porchapi.PackageRevisionLifecycleProposed, blue)
+		b := newBatchPkgRev("blueprints", "b", porchapi.PackageRevisionLifecyclePublished, blue)
+		c := newBatchPkgRev("deployments", "c", porchapi.PackageRevisionLifecycleDeletionProposed, blue)
+		f := newFixture(t, a, b, c)
+		f.engine.EXPECT().BatchRepositoryUpdates(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(runUpdates).Once()
+
+		result, err := f.batches.Create(ctx, &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{
+				Operation: porchapi.BatchOperationReject,
+				Selector:  &metav1.LabelSelector{MatchLabels: blue},
+			},
+		}, nil, &metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]porchapi.PackageRevisionBatchOutcome{
+			a.KubeObjectName(): porchapi.BatchOutcomeAborted,
+			b.KubeObjectName(): porchapi.BatchOutcomeFailed,
+			c.KubeObjectName(): porchapi.BatchOutcomeSucceeded,
+		}, outcomes(t, result))
+		assert.Equal(t, map[string]porchapi.PackageRevisionLifecycle{
+			c.KubeObjectName(): porchapi.PackageRevisionLifecyclePublished,
+		}, f.updated)
+	})
+
+	t.Run("failed push fails the repository", func(t *testing.T) {
+		a := newBatchPkgRev("blueprints", "a", porchapi.PackageRevisionLifecycleDeletionProposed, nil)
+		b := newBatchPkgRev("blueprints", "b", porchapi.PackageRevisionLifecycleDraft, nil)
+		f := newFixture(t, a, b)
+		f.engine.EXPECT().FindAllUpstreamReferencesInRepositories(mock.Anything, "ns", mock.Anything).Return("", nil)
+		f.engine.EXPECT().BatchRepositoryUpdates(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, repositoryObj *configapi.Repository, updates func(ctx context.Context) error) error {
+				if err := updates(ctx); err != nil {
+					return err
+				}
+				return errors.New("push rejected")
+			}).Once()
+
+		result, err := f.batches.Create(ctx, &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{
+				Operation:        porchapi.BatchOperationDelete,
+				PackageRevisions: []string{a.KubeObjectName(), b.KubeObjectName()},
+			},
+		}, nil, &metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]porchapi.PackageRevisionBatchOutcome{
+			a.KubeObjectName(): porchapi.BatchOutcomeAborted,
+			b.KubeObjectName(): porchapi.BatchOutcomeAborted,
+		}, outcomes(t, result))
+		assert.Len(t, f.deleted, 2)
+	})
+
+	t.Run("dry run validates without applying", func(t *testing.T) {
+		a := newBatchPkgRev("blueprints", "a", porchapi.PackageRevisionLifecyclePublished, nil)
+		f := newFixture(t, a)
+
+		result, err := f.batches.Create(ctx, &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{
+				Operation:        porchapi.BatchOperationProposeDelete,
+				PackageRevisions: []string{a.KubeObjectName()},
+			},
+		}, nil, &metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]porchapi.PackageRevisionBatchOutcome{
+			a.KubeObjectName(): porchapi.BatchOutcomeSucceeded,
+		}, outcomes(t, result))
+		assert.Empty(t, f.updated)
+	})
+
+	t.Run("published package revisions cannot be deleted", func(t *testing.T) {
+		a := newBatchPkgRev("blueprints", "a", porchapi.PackageRevisionLifecyclePublished, nil)
+		f := newFixture(t, a)
+
+		result, err := f.batches.Create(ctx, &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{
+				Operation:        porchapi.BatchOperationDelete,
+				PackageRevisions: []string{a.KubeObjectName()},
+			},
+		}, nil, &metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		batch := result.(*porchapi.PackageRevisionBatch)
+		require.Len(t, batch.Status.Results, 1)
+		assert.Equal(t, porchapi.BatchOutcomeFailed, batch.Status.Results[0].Outcome)
+		assert.Contains(t, batch.Status.Results[0].Message, "must be proposed for deletion")
+	})
+
+	t.Run("missing package revision", func(t *testing.T) {
+		f := newFixture(t)
+
+		result, err := f.batches.Create(ctx, &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{
+				Operation:        porchapi.BatchOperationApprove,
+				PackageRevisions: []string{"blueprints.missing.v1"},
+			},
+		}, nil, &metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]porchapi.PackageRevisionBatchOutcome{
+			"blueprints.missing.v1": porchapi.BatchOutcomeFailed,
+		}, outcomes(t, result))
+	})
+
+	t.Run("user without approval rights cannot approve", func(t *testing.T) {
+		a := newBatchPkgRev("blueprints", "a", porchapi.PackageRevisionLifecycleProposed, nil)
+		b := newBatchPkgRev("blueprints", "b", porchapi.PackageRevisionLifecycleProposed, nil)
+		f := newFixture(t, a, b)
+		var checked []authorizer.Attributes
+		f.batches.authorizer = authorizer.AuthorizerFunc(func(_ context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+			checked = append(checked, attrs)
+			if attrs.GetSubresource() == "approval" && attrs.GetName() == b.KubeObjectName() {
+				return authorizer.DecisionNoOpinion, "no approval rights", nil
+			}
+			return authorizer.DecisionAllow, "", nil
+		})
+
+		userCtx := request.WithUser(ctx, &user.DefaultInfo{Name: "alice"})
+		_, err := f.batches.Create(userCtx, &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{
+				Operation:        porchapi.BatchOperationApprove,
+				PackageRevisions: []string{a.KubeObjectName(), b.KubeObjectName()},
+			},
+		}, nil, &metav1.CreateOptions{})
+		assert.True(t, apierrors.IsForbidden(err))
+		assert.ErrorContains(t, err, "no approval rights")
+		assert.Empty(t, f.updated)
+
+		require.Len(t, checked, 2)
+		assert.Equal(t, "update", checked[0].GetVerb())
+		assert.Equal(t, "packagerevisions", checked[0].GetResource())
+		assert.Equal(t, "approval", checked[0].GetSubresource())
+		assert.Equal(t, "alice", checked[0].GetUser().GetName())
+	})
+
+	t.Run("delete is authorized as delete of each package revision", func(t *testing.T) {
+		a := newBatchPkgRev("blueprints", "a", porchapi.PackageRevisionLifecycleDraft, nil)
+		f := newFixture(t, a)
+		f.batches.authorizer = authorizer.AuthorizerFunc(func(_ context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+			if attrs.GetVerb() == "delete" && attrs.GetResource() == "packagerevisions" && attrs.GetSubresource() == "" {
+				return authorizer.DecisionNoOpinion, "no delete rights", nil
+			}
+			return authorizer.DecisionAllow, "", nil
+		})
+
+		userCtx := request.WithUser(ctx, &user.DefaultInfo{Name: "alice"})
+		_, err := f.batches.Create(userCtx, &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{
+				Operation:        porchapi.BatchOperationDelete,
+				PackageRevisions: []string{a.KubeObjectName()},
+			},
+		}, nil, &metav1.CreateOptions{})
+		assert.True(t, apierrors.IsForbidden(err))
+		assert.Empty(t, f.deleted)
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		f := newFixture(t)
+
+		_, err := f.batches.Create(ctx, &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{Operation: "Publish", PackageRevisions: []string{"blueprints.a.v1"}},
+		}, nil, &metav1.CreateOptions{})
+		assert.True(t, apierrors.IsBadRequest(err))
+
+		_, err = f.batches.Create(ctx, &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{Operation: porchapi.BatchOperationApprove},
+		}, nil, &metav1.CreateOptions{})
+		assert.True(t, apierrors.IsBadRequest(err))
+
+		_, err = f.batches.Create(context.TODO(), &porchapi.PackageRevisionBatch{
+			Spec: porchapi.PackageRevisionBatchSpec{Operation: porchapi.BatchOperationApprove, PackageRevisions: []string{"blueprints.a.v1"}},
+		}, nil, &metav1.CreateOptions{})
+		assert.True(t, apierrors.IsBadRequest(err))
+	})
+}
@@ -94,7 +94,7 @@ func (r *packageRevisionResources) List(ctx context.Context, options *metaintern
 		if err != nil {
 			return err
 		}
-		result.Items = append(result.Items, *apiPkgResources)
+		result.Items = append(result.Items, *withSubpackages(apiPkgResources))
 		return nil
 	}); err != nil {
 		return nil, err
@@ -127,7 +127,16 @@ func (r *packageRevisionResources) Get(ctx context.Context, name string, _ *meta
 
 	klog.V(3).InfoS("Get PackageRevisionResources completed", pctx.LogMetadataFrom(ctx)...)
 
-	return apiPkgResources, nil
+	return withSubpackages(apiPkgResources), nil
+}
+
+// withSubpackages returns a copy of the package revision resources that lists the
+// independent subpackages embedded in the package revision in its status. The
+// resources returned by the repository may be shared, so they are not modified.
+func withSubpackages(apiPkgResources *porchapi.PackageRevisionResources) *porchapi.PackageRevisionResources {
+	result := *apiPkgResources
+	result.Status.Subpackages = repository.ToAPISubpackages(repository.FindSubpackages(apiPkgResources.Spec.Resources))
+	return &result
 }
 
 // Update finds a resource in the storage and updates it. Some implementations
@@ -139,6 +148,8 @@ func (r *packageRevisionResources) Update(ctx context.Context, name string, objI
 	defer span.End()
 
 	ctx = pctx.WithNewRequestIDAndPackageRevision(ctx, name)
+	// A user waits for the render of their update, so its functions are evaluated ahead of background renders
+	ctx = pctx.WithPriority(ctx, requestPriority(ctx))
 
 	namespace, namespaced := genericapirequest.NamespaceFrom(ctx)
 	if !namespaced {
@@ -222,6 +233,7 @@ func (r *packageRevisionResources) Update(ctx context.Context, name string, objI
 	if err != nil {
 		return nil, false, apierrors.NewInternalError(err)
 	}
+	created = withSubpackages(created)
 	if renderStatus != nil {
 		created.Status.RenderStatus = *renderStatus
 	}
@@ -233,7 +245,7 @@ func (r *packageRevisionResources) Update(ctx context.Context, name string, objI
 
 // patchRenderRequestAnnotation patches the render-request annotation on the
 // v1alpha2 PackageRevision CRD to trigger async rendering.
-func (r *packageRevisionResources) patchRenderRequestAnnotation(ctx context.Context, namespace, name, resourceVersion string) {
+func (r *packageCommon) patchRenderRequestAnnotation(ctx context.Context, namespace, name, resourceVersion string) {
 	pr := &porchv1alpha2.PackageRevision{}
 	key := client.ObjectKey{Namespace: namespace, Name: name}
 	if err := r.coreClient.Get(ctx, key, pr); err != nil {
@@ -253,7 +265,7 @@ func (r *packageRevisionResources) patchRenderRequestAnnotation(ctx context.Cont
 
 // getRepoPkgRevForResources looks up a package revision in the cache, including v1alpha2 repos.
 // TODO: Replace r.cad.ListPackageRevisions with direct cache access when engine is removed
-func (r *packageRevisionResources) getRepoPkgRevForResources(ctx context.Context, name string) (repository.PackageRevision, error) {
+func (r *packageCommon) getRepoPkgRevForResources(ctx context.Context, name string) (repository.PackageRevision, error) {
 	ctx, span := tracer.Start(ctx, "packageRevisionResources::getRepoPkgRevForResources", trace.WithAttributes())
 	defer span.End()
 
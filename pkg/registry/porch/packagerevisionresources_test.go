@@ -138,3 +138,31 @@ func TestGetResources(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, result)
 }
+
+func TestGetResourcesSubpackages(t *testing.T) {
+	mockClient, mockEngine := setupResourcesTest(t)
+	mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), mock.Anything).Return(nil).Maybe()
+	pkgRevName := "repo.1234567890.ws"
+
+	mockPkgRev := mockrepo.NewMockPackageRevision(t)
+	mockEngine.On("ListPackageRevisions", mock.Anything, mock.Anything).Return([]repository.PackageRevision{
+		mockPkgRev,
+	}, nil).Once()
+	mockPkgRev.On("KubeObjectName").Return(pkgRevName)
+	mockPkgRev.On("GetResources", mock.Anything).Return(&porchapi.PackageRevisionResources{
+		Spec: porchapi.PackageRevisionResourcesSpec{
+			Resources: map[string]string{
+				"Kptfile":        "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n",
+				"db/Kptfile":     "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: db\n",
+				"db/config.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: db\n",
+			},
+		},
+	}, nil)
+
+	ctx := genericapirequest.WithNamespace(context.TODO(), "someDummyNamespace")
+	result, err := packagerevisionresources.Get(ctx, pkgRevName, nil)
+	assert.NoError(t, err)
+	prr, ok := result.(*porchapi.PackageRevisionResources)
+	assert.True(t, ok)
+	assert.Equal(t, []porchapi.Subpackage{{Path: "db", Name: "db"}}, prr.Status.Subpackages)
+}
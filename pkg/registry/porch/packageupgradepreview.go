@@ -0,0 +1,129 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"fmt"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	pctx "github.com/kptdev/porch/pkg/util/context"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+)
+
+// packageUpgradePreviews performs an upgrade of a package revision without creating a draft, and
+// reports what the upgrade would do to each resource of the package.
+type packageUpgradePreviews struct {
+	packageCommon
+}
+
+var _ rest.Storage = &packageUpgradePreviews{}
+var _ rest.Scoper = &packageUpgradePreviews{}
+var _ rest.Creater = &packageUpgradePreviews{}
+
+// New returns an empty object that can be used with Create and Update after request data has been put into it.
+// This object must be a pointer type for use with Codec.DecodeInto([]byte, runtime.Object)
+func (p *packageUpgradePreviews) New() runtime.Object {
+	return &porchapi.PackageUpgradePreview{}
+}
+
+func (p *packageUpgradePreviews) Destroy() {}
+
+// NamespaceScoped returns true if the storage is namespaced
+func (p *packageUpgradePreviews) NamespaceScoped() bool {
+	return true
+}
+
+// Create performs the upgrade described by the PackageUpgradePreview and returns the preview with
+// the outcome for each resource in its status. Nothing is stored.
+func (p *packageUpgradePreviews) Create(ctx context.Context, runtimeObject runtime.Object, createValidation rest.ValidateObjectFunc,
+	options *metav1.CreateOptions) (runtime.Object, error) {
+	ctx, span := tracer.Start(ctx, "[START]::packageUpgradePreviews::Create", trace.WithAttributes())
+	defer span.End()
+
+	ctx = pctx.WithNewRequestID(ctx)
+
+	ns, namespaced := genericapirequest.NamespaceFrom(ctx)
+	if !namespaced {
+		return nil, apierrors.NewBadRequest("namespace must be specified")
+	}
+
+	preview, ok := runtimeObject.(*porchapi.PackageUpgradePreview)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected PackageUpgradePreview object, got %T", runtimeObject))
+	}
+	if err := validateUpgradePreviewSpec(&preview.Spec); err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	if createValidation != nil {
+		if err := createValidation(ctx, preview); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx = pctx.WithPackageRevision(ctx, preview.Spec.LocalPackageRevisionRef.Name)
+	klog.InfoS("[API] Operation started for PackageUpgradePreview", pctx.LogMetadataFrom(ctx)...)
+
+	resources, err := p.cad.PreviewUpgrade(ctx, ns, &preview.Spec)
+	if err != nil {
+		klog.ErrorS(err, "[API] PackageUpgradePreview failed", pctx.LogMetadataFrom(ctx)...)
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	result := preview.DeepCopy()
+	result.Namespace = ns
+	result.Status = porchapi.PackageUpgradePreviewStatus{Resources: resources}
+	for _, resource := range resources {
+		if resource.Outcome == porchapi.UpgradeOutcomeConflict {
+			result.Status.Conflicts++
+		}
+	}
+
+	klog.InfoS("[API] Operation completed for PackageUpgradePreview",
+		pctx.LogMetadataFromWithExtras(ctx, "resources", len(resources), "conflicts", result.Status.Conflicts)...)
+
+	return result, nil
+}
+
+func validateUpgradePreviewSpec(spec *porchapi.PackageUpgradeTaskSpec) error {
+	if spec.OldUpstream.Name == "" {
+		return fmt.Errorf("spec.oldUpstreamRef.name must be set")
+	}
+	if spec.NewUpstream.Name == "" {
+		return fmt.Errorf("spec.newUpstreamRef.name must be set")
+	}
+	if spec.LocalPackageRevisionRef.Name == "" {
+		return fmt.Errorf("spec.localPackageRevisionRef.name must be set")
+	}
+	switch spec.Strategy {
+	case "", porchapi.ResourceMerge, porchapi.FastForward, porchapi.ForceDeleteReplace, porchapi.CopyMerge, porchapi.ManualMerge:
+	default:
+		return fmt.Errorf("spec.strategy must be one of %s, %s, %s, %s, %s",
+			porchapi.ResourceMerge, porchapi.FastForward, porchapi.ForceDeleteReplace, porchapi.CopyMerge, porchapi.ManualMerge)
+	}
+	if spec.SubpackageDir != "" {
+		if err := porchapi.IsValidSubpackageDir(spec.SubpackageDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	mockengine "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestPackageUpgradePreviews(t *testing.T) {
+	newPreviews := func(t *testing.T) (*packageUpgradePreviews, *mockengine.MockCaDEngine) {
+		mockEngine := mockengine.NewMockCaDEngine(t)
+		return &packageUpgradePreviews{
+			packageCommon: packageCommon{
+				scheme: runtime.NewScheme(),
+				gr:     porchapi.Resource("packageupgradepreviews"),
+				cad:    mockEngine,
+			},
+		}, mockEngine
+	}
+
+	spec := porchapi.PackageUpgradeTaskSpec{
+		OldUpstream:             porchapi.PackageRevisionRef{Name: "blueprints.app.v1"},
+		NewUpstream:             porchapi.PackageRevisionRef{Name: "blueprints.app.v2"},
+		LocalPackageRevisionRef: porchapi.PackageRevisionRef{Name: "deployments.app.v1"},
+		Strategy:                porchapi.ResourceMerge,
+	}
+
+	ctx := request.WithNamespace(context.TODO(), "ns")
+
+	t.Run("reports outcomes and counts conflicts", func(t *testing.T) {
+		previews, mockEngine := newPreviews(t)
+		resources := []porchapi.UpgradePreviewResource{
+			{File: "cm.yaml", Kind: "ConfigMap", Name: "cm", Outcome: porchapi.UpgradeOutcomeUpstream, Fields: []string{"data.key"}},
+			{File: "deploy.yaml", Kind: "Deployment", Name: "app", Outcome: porchapi.UpgradeOutcomeConflict, Fields: []string{"spec.replicas"}},
+		}
+		mockEngine.EXPECT().PreviewUpgrade(mock.Anything, "ns", &spec).Return(resources, nil).Once()
+
+		result, err := previews.Create(ctx, &porchapi.PackageUpgradePreview{Spec: spec}, nil, nil)
+		require.NoError(t, err)
+		require.IsType(t, &porchapi.PackageUpgradePreview{}, result)
+		preview := result.(*porchapi.PackageUpgradePreview)
+		assert.Equal(t, "ns", preview.Namespace)
+		assert.Equal(t, resources, preview.Status.Resources)
+		assert.Equal(t, 1, preview.Status.Conflicts)
+	})
+
+	t.Run("manual merge", func(t *testing.T) {
+		previews, mockEngine := newPreviews(t)
+		manual := spec
+		manual.Strategy = porchapi.ManualMerge
+		resources := []porchapi.UpgradePreviewResource{
+			{File: "deploy.yaml", Kind: "Deployment", Name: "app", Outcome: porchapi.UpgradeOutcomeConflict, Fields: []string{"spec.replicas"}},
+		}
+		mockEngine.EXPECT().PreviewUpgrade(mock.Anything, "ns", &manual).Return(resources, nil).Once()
+
+		result, err := previews.Create(ctx, &porchapi.PackageUpgradePreview{Spec: manual}, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.(*porchapi.PackageUpgradePreview).Status.Conflicts)
+	})
+
+	t.Run("upgrade failure is a bad request", func(t *testing.T) {
+		previews, mockEngine := newPreviews(t)
+		mockEngine.EXPECT().PreviewUpgrade(mock.Anything, "ns", &spec).Return(nil, errors.New("upstream not found")).Once()
+
+		_, err := previews.Create(ctx, &porchapi.PackageUpgradePreview{Spec: spec}, nil, nil)
+		assert.True(t, apierrors.IsBadRequest(err))
+		assert.ErrorContains(t, err, "upstream not found")
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		previews, _ := newPreviews(t)
+
+		missingRef := spec
+		missingRef.NewUpstream.Name = ""
+		_, err := previews.Create(ctx, &porchapi.PackageUpgradePreview{Spec: missingRef}, nil, nil)
+		assert.True(t, apierrors.IsBadRequest(err))
+		assert.ErrorContains(t, err, "spec.newUpstreamRef.name")
+
+		badStrategy := spec
+		badStrategy.Strategy = "rebase"
+		_, err = previews.Create(ctx, &porchapi.PackageUpgradePreview{Spec: badStrategy}, nil, nil)
+		assert.ErrorContains(t, err, "spec.strategy")
+
+		badSubpackage := spec
+		badSubpackage.SubpackageDir = "/abs"
+		_, err = previews.Create(ctx, &porchapi.PackageUpgradePreview{Spec: badSubpackage}, nil, nil)
+		assert.True(t, apierrors.IsBadRequest(err))
+	})
+
+	t.Run("namespace is required", func(t *testing.T) {
+		previews, _ := newPreviews(t)
+		_, err := previews.Create(context.TODO(), &porchapi.PackageUpgradePreview{Spec: spec}, nil, nil)
+		assert.True(t, apierrors.IsBadRequest(err))
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	pctx "github.com/kptdev/porch/pkg/util/context"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+)
+
+// repositoryBundles exports the package revisions of a repository as a RepositoryBundle, and
+// imports a RepositoryBundle into a repository. The name of a RepositoryBundle is the name of
+// the repository it is exported from or imported into.
+type repositoryBundles struct {
+	packageCommon
+}
+
+var _ rest.Storage = &repositoryBundles{}
+var _ rest.Scoper = &repositoryBundles{}
+var _ rest.Getter = &repositoryBundles{}
+var _ rest.Creater = &repositoryBundles{}
+
+// New returns an empty object that can be used with Create and Update after request data has been put into it.
+// This object must be a pointer type for use with Codec.DecodeInto([]byte, runtime.Object)
+func (b *repositoryBundles) New() runtime.Object {
+	return &porchapi.RepositoryBundle{}
+}
+
+func (b *repositoryBundles) Destroy() {}
+
+// NamespaceScoped returns true if the storage is namespaced
+func (b *repositoryBundles) NamespaceScoped() bool {
+	return true
+}
+
+// Get exports all package revisions of the named repository.
+func (b *repositoryBundles) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	ctx, span := tracer.Start(ctx, "[START]::repositoryBundles::Get", trace.WithAttributes())
+	defer span.End()
+
+	ctx = pctx.WithNewRequestID(ctx)
+
+	ns, namespaced := genericapirequest.NamespaceFrom(ctx)
+	if !namespaced {
+		return nil, apierrors.NewBadRequest("namespace must be specified")
+	}
+
+	repositoryObj, err := b.getRepositoryObj(ctx, types.NamespacedName{Name: name, Namespace: ns})
+	if err != nil {
+		return nil, err
+	}
+	if isV1Alpha2Repo(repositoryObj) {
+		return nil, apierrors.NewNotFound(b.gr, name)
+	}
+
+	bundled, err := b.cad.ExportRepository(ctx, repositoryObj)
+	if err != nil {
+		klog.ErrorS(err, "[API] Repository export failed", pctx.LogMetadataFromWithExtras(ctx, "repository", name)...)
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	return &porchapi.RepositoryBundle{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RepositoryBundle",
+			APIVersion: porchapi.SchemeGroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: porchapi.RepositoryBundleSpec{
+			SourceRepository: name,
+			PackageRevisions: bundled,
+		},
+	}, nil
+}
+
+// Create imports a RepositoryBundle into the repository named by the bundle. If any bundled
+// package revision conflicts with the repository or with another bundled package revision,
+// nothing is imported and a Conflict error listing all conflicts is returned.
+func (b *repositoryBundles) Create(ctx context.Context, runtimeObject runtime.Object, _ rest.ValidateObjectFunc,
+	options *metav1.CreateOptions) (runtime.Object, error) {
+	ctx, span := tracer.Start(ctx, "[START]::repositoryBundles::Create", trace.WithAttributes())
+	defer span.End()
+
+	ctx = pctx.WithNewRequestID(ctx)
+
+	ns, namespaced := genericapirequest.NamespaceFrom(ctx)
+	if !namespaced {
+		return nil, apierrors.NewBadRequest("namespace must be specified")
+	}
+
+	bundle, ok := runtimeObject.(*porchapi.RepositoryBundle)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected RepositoryBundle object, got %T", runtimeObject))
+	}
+	if bundle.Name == "" {
+		return nil, apierrors.NewBadRequest("metadata.name must be the name of the repository to import into")
+	}
+
+	repositoryObj, err := b.getRepositoryObj(ctx, types.NamespacedName{Name: bundle.Name, Namespace: ns})
+	if err != nil {
+		return nil, err
+	}
+	if isV1Alpha2Repo(repositoryObj) {
+		return nil, apierrors.NewResourceExpired(fmt.Sprintf("repository %q is managed by v1alpha2; use the v1alpha2 API", bundle.Name))
+	}
+
+	dryRun := options != nil && slices.Contains(options.DryRun, metav1.DryRunAll)
+
+	klog.InfoS("[API] Operation started for RepositoryBundle",
+		pctx.LogMetadataFromWithExtras(ctx, "repository", bundle.Name, "source", bundle.Spec.SourceRepository, "dryRun", dryRun)...)
+
+	imported, conflicts, err := b.cad.ImportRepository(ctx, repositoryObj, bundle.Spec.PackageRevisions, dryRun)
+	if err != nil {
+		klog.ErrorS(err, "[API] Repository import failed", pctx.LogMetadataFromWithExtras(ctx, "repository", bundle.Name)...)
+		return nil, apierrors.NewInternalError(err)
+	}
+	if len(conflicts) > 0 {
+		return nil, apierrors.NewConflict(b.gr, bundle.Name, fmt.Errorf("bundle conflicts with repository:\n%s", strings.Join(conflicts, "\n")))
+	}
+
+	result := bundle.DeepCopy()
+	result.Namespace = ns
+	result.Spec.PackageRevisions = nil
+	result.Status.Imported = imported
+	return result, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	mockclient "github.com/kptdev/porch/test/mockery/mocks/external/sigs.k8s.io/controller-runtime/pkg/client"
+	mockengine "github.com/kptdev/porch/test/mockery/mocks/porch/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestRepositoryBundles(t *testing.T) {
+	bundled := []porchapi.BundledPackageRevision{
+		{
+			PackageName:   "base",
+			WorkspaceName: "v1",
+			Revision:      1,
+			Lifecycle:     porchapi.PackageRevisionLifecyclePublished,
+			PublishedBy:   "alice",
+			Resources:     map[string]string{"Kptfile": "kind: Kptfile"},
+		},
+	}
+
+	newBundles := func(t *testing.T, repoErr error) (*repositoryBundles, *mockengine.MockCaDEngine) {
+		mockClient := mockclient.NewMockClient(t)
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.Repository"), mock.Anything).Return(repoErr).Maybe()
+		mockEngine := mockengine.NewMockCaDEngine(t)
+
+		return &repositoryBundles{
+			packageCommon: packageCommon{
+				scheme:     runtime.NewScheme(),
+				gr:         porchapi.Resource("repositorybundles"),
+				coreClient: mockClient,
+				cad:        mockEngine,
+			},
+		}, mockEngine
+	}
+
+	ctx := request.WithNamespace(context.TODO(), "ns")
+
+	t.Run("export", func(t *testing.T) {
+		bundles, mockEngine := newBundles(t, nil)
+		mockEngine.EXPECT().ExportRepository(mock.Anything, mock.Anything).Return(bundled, nil)
+
+		result, err := bundles.Get(ctx, "blueprints", &metav1.GetOptions{})
+		require.NoError(t, err)
+		require.IsType(t, &porchapi.RepositoryBundle{}, result)
+
+		bundle := result.(*porchapi.RepositoryBundle)
+		assert.Equal(t, "blueprints", bundle.Name)
+		assert.Equal(t, "ns", bundle.Namespace)
+		assert.Equal(t, "blueprints", bundle.Spec.SourceRepository)
+		assert.Equal(t, bundled, bundle.Spec.PackageRevisions)
+	})
+
+	t.Run("export of missing repository", func(t *testing.T) {
+		bundles, _ := newBundles(t, apierrors.NewNotFound(porchapi.Resource("repositories"), "blueprints"))
+
+		_, err := bundles.Get(ctx, "blueprints", &metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("import", func(t *testing.T) {
+		bundles, mockEngine := newBundles(t, nil)
+		mockEngine.EXPECT().ImportRepository(mock.Anything, mock.Anything, bundled, false).Return([]string{"copy.base.v1"}, []string(nil), nil)
+
+		result, err := bundles.Create(ctx, &porchapi.RepositoryBundle{
+			ObjectMeta: metav1.ObjectMeta{Name: "copy"},
+			Spec: porchapi.RepositoryBundleSpec{
+				SourceRepository: "blueprints",
+				PackageRevisions: bundled,
+			},
+		}, nil, &metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		bundle := result.(*porchapi.RepositoryBundle)
+		assert.Equal(t, []string{"copy.base.v1"}, bundle.Status.Imported)
+		assert.Empty(t, bundle.Spec.PackageRevisions)
+	})
+
+	t.Run("dry-run import", func(t *testing.T) {
+		bundles, mockEngine := newBundles(t, nil)
+		mockEngine.EXPECT().ImportRepository(mock.Anything, mock.Anything, bundled, true).Return([]string{"copy.base.v1"}, []string(nil), nil)
+
+		_, err := bundles.Create(ctx, &porchapi.RepositoryBundle{
+			ObjectMeta: metav1.ObjectMeta{Name: "copy"},
+			Spec:       porchapi.RepositoryBundleSpec{PackageRevisions: bundled},
+		}, nil, &metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+		require.NoError(t, err)
+	})
+
+	t.Run("import conflicts", func(t *testing.T) {
+		bundles, mockEngine := newBundles(t, nil)
+		mockEngine.EXPECT().ImportRepository(mock.Anything, mock.Anything, bundled, false).Return([]string(nil), []string{"base/v1: revision 1 is already used"}, nil)
+
+		_, err := bundles.Create(ctx, &porchapi.RepositoryBundle{
+			ObjectMeta: metav1.ObjectMeta{Name: "copy"},
+			Spec:       porchapi.RepositoryBundleSpec{PackageRevisions: bundled},
+		}, nil, &metav1.CreateOptions{})
+		require.Error(t, err)
+		assert.True(t, apierrors.IsConflict(err))
+		assert.Contains(t, err.Error(), "revision 1 is already used")
+	})
+
+	t.Run("import failure", func(t *testing.T) {
+		bundles, mockEngine := newBundles(t, nil)
+		mockEngine.EXPECT().ImportRepository(mock.Anything, mock.Anything, bundled, false).Return([]string(nil), []string(nil), errors.New("push failed"))
+
+		_, err := bundles.Create(ctx, &porchapi.RepositoryBundle{
+			ObjectMeta: metav1.ObjectMeta{Name: "copy"},
+			Spec:       porchapi.RepositoryBundleSpec{PackageRevisions: bundled},
+		}, nil, &metav1.CreateOptions{})
+		assert.True(t, apierrors.IsInternalError(err))
+	})
+
+	t.Run("import without repository name", func(t *testing.T) {
+		bundles, _ := newBundles(t, nil)
+
+		_, err := bundles.Create(ctx, &porchapi.RepositoryBundle{}, nil, &metav1.CreateOptions{})
+		assert.True(t, apierrors.IsBadRequest(err))
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"fmt"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/repository"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewSourcePolicyChecker(coreClient client.Reader) repository.SourcePolicyChecker {
+	return &sourcePolicyChecker{
+		coreClient: coreClient,
+	}
+}
+
+type sourcePolicyChecker struct {
+	coreClient client.Reader
+}
+
+var _ repository.SourcePolicyChecker = &sourcePolicyChecker{}
+
+func (c *sourcePolicyChecker) CheckGitSource(ctx context.Context, namespace, url string) error {
+	return c.check(ctx, namespace, repository.SourceKindGit, url, (*configapi.SourcePolicy).AllowsGitURL)
+}
+
+func (c *sourcePolicyChecker) CheckRepositorySource(ctx context.Context, namespace, repositoryName string) error {
+	return c.check(ctx, namespace, repository.SourceKindRepository, repositoryName, (*configapi.SourcePolicy).AllowsRepository)
+}
+
+func (c *sourcePolicyChecker) CheckOCISource(ctx context.Context, namespace, image string) error {
+	return c.check(ctx, namespace, repository.SourceKindOCI, image, (*configapi.SourcePolicy).AllowsOCIImage)
+}
+
+// check allows the source if the namespace has no SourcePolicy, or if any of its SourcePolicies allows it.
+func (c *sourcePolicyChecker) check(ctx context.Context, namespace, kind, source string, allows func(*configapi.SourcePolicy, string) bool) error {
+	var policies configapi.SourcePolicyList
+	if err := c.coreClient.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			// The SourcePolicy CRD is not installed, so no policy restricts the source.
+			return nil
+		}
+		return fmt.Errorf("cannot list SourcePolicies in namespace %q: %w", namespace, err)
+	}
+
+	if len(policies.Items) == 0 {
+		return nil
+	}
+	for i := range policies.Items {
+		if allows(&policies.Items[i], source) {
+			return nil
+		}
+	}
+	return &repository.SourceDeniedError{
+		Namespace: namespace,
+		Kind:      kind,
+		Source:    source,
+	}
+}
+
+// checkSourcePolicies checks the upstream sources of the clone and upgrade tasks of a new package
+// revision against the SourcePolicies of its namespace, so that a denied source is rejected before
+// the package revision is created.
+func (r *packageCommon) checkSourcePolicies(ctx context.Context, namespace string, obj *porchapi.PackageRevision) error {
+	checker := NewSourcePolicyChecker(r.coreClient)
+
+	checkRef := func(ref *porchapi.PackageRevisionRef) error {
+		if ref == nil || ref.Name == "" {
+			return nil
+		}
+		prKey, err := repository.PkgRevK8sName2Key(namespace, ref.Name)
+		if err != nil {
+			// Invalid references are reported when the task is applied
+			return nil
+		}
+		return checker.CheckRepositorySource(ctx, namespace, prKey.RKey().Name)
+	}
+
+	for _, task := range obj.Spec.Tasks {
+		switch {
+		case task.Type == porchapi.TaskTypeClone && task.Clone != nil:
+			upstream := task.Clone.Upstream
+			switch {
+			case upstream.UpstreamRef != nil:
+				if err := checkRef(upstream.UpstreamRef); err != nil {
+					return err
+				}
+			case upstream.Git != nil:
+				if err := checker.CheckGitSource(ctx, namespace, upstream.Git.Repo); err != nil {
+					return err
+				}
+			case upstream.Oci != nil:
+				if err := checker.CheckOCISource(ctx, namespace, upstream.Oci.Image); err != nil {
+					return err
+				}
+			}
+		case task.Type == porchapi.TaskTypeUpgrade && task.Upgrade != nil:
+			if err := checkRef(&task.Upgrade.NewUpstream); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// internalOrSourceDeniedError returns a Forbidden error if err reports a source denied by a
+// SourcePolicy, and an InternalError otherwise.
+func internalOrSourceDeniedError(name string, err error) error {
+	if repository.IsSourceDenied(err) {
+		return apierrors.NewForbidden(porchapi.Resource("packagerevisions"), name, err)
+	}
+	return apierrors.NewInternalError(err)
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package porch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newSourcePolicyClient(t *testing.T, policies ...*configapi.SourcePolicy) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, configapi.AddToScheme(scheme))
+
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, policy := range policies {
+		builder = builder.WithObjects(policy)
+	}
+	return builder.Build()
+}
+
+func TestSourcePolicyChecker(t *testing.T) {
+	ctx := context.Background()
+	checker := NewSourcePolicyChecker(newSourcePolicyClient(t,
+		&configapi.SourcePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "git", Namespace: "restricted"},
+			Spec: configapi.SourcePolicySpec{
+				GitURLs: []string{"https://github.com/approved/*"},
+			},
+		},
+		&configapi.SourcePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "registered", Namespace: "restricted"},
+			Spec: configapi.SourcePolicySpec{
+				Repositories:  []string{"blueprints", "catalog-*"},
+				OCIRegistries: []string{"gcr.io/approved"},
+			},
+		},
+	))
+
+	assert.NoError(t, checker.CheckGitSource(ctx, "restricted", "https://github.com/approved/blueprints.git"))
+	assert.NoError(t, checker.CheckGitSource(ctx, "restricted", "https://github.com/approved/blueprints/"))
+	assert.NoError(t, checker.CheckRepositorySource(ctx, "restricted", "blueprints"))
+	assert.NoError(t, checker.CheckRepositorySource(ctx, "restricted", "catalog-networking"))
+	assert.NoError(t, checker.CheckOCISource(ctx, "restricted", "gcr.io/approved/packages/base:v1"))
+	assert.NoError(t, checker.CheckOCISource(ctx, "restricted", "gcr.io/approved/base@sha256:0123"))
+
+	err := checker.CheckGitSource(ctx, "restricted", "https://github.com/someone-else/blueprints.git")
+	var denied *repository.SourceDeniedError
+	require.ErrorAs(t, err, &denied)
+	assert.Equal(t, "restricted", denied.Namespace)
+	assert.Equal(t, repository.SourceKindGit, denied.Kind)
+	assert.Equal(t, "https://github.com/someone-else/blueprints.git", denied.Source)
+
+	assert.True(t, repository.IsSourceDenied(checker.CheckRepositorySource(ctx, "restricted", "scratch")))
+	assert.True(t, repository.IsSourceDenied(checker.CheckOCISource(ctx, "restricted", "gcr.io/approved-not/base:v1")))
+	assert.True(t, repository.IsSourceDenied(checker.CheckOCISource(ctx, "restricted", "docker.io/approved/base")))
+
+	// Namespaces without SourcePolicies allow any source
+	assert.NoError(t, checker.CheckGitSource(ctx, "open", "https://github.com/someone-else/blueprints.git"))
+	assert.NoError(t, checker.CheckRepositorySource(ctx, "open", "scratch"))
+	assert.NoError(t, checker.CheckOCISource(ctx, "open", "docker.io/someone-else/base"))
+}
+
+func TestCheckSourcePolicies(t *testing.T) {
+	ctx := context.Background()
+	r := &packageCommon{
+		coreClient: newSourcePolicyClient(t, &configapi.SourcePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "approved", Namespace: "restricted"},
+			Spec: configapi.SourcePolicySpec{
+				GitURLs:      []string{"https://github.com/approved/*"},
+				Repositories: []string{"blueprints"},
+			},
+		}),
+	}
+
+	newPR := func(tasks ...porchapi.Task) *porchapi.PackageRevision {
+		return &porchapi.PackageRevision{Spec: porchapi.PackageRevisionSpec{Tasks: tasks}}
+	}
+	gitClone := func(repo string) porchapi.Task {
+		return porchapi.Task{
+			Type: porchapi.TaskTypeClone,
+			Clone: &porchapi.PackageCloneTaskSpec{
+				Upstream: porchapi.UpstreamPackage{Git: &porchapi.GitPackage{Repo: repo}},
+			},
+		}
+	}
+
+	assert.NoError(t, r.checkSourcePolicies(ctx, "restricted", newPR(gitClone("https://github.com/approved/blueprints"))))
+	assert.True(t, repository.IsSourceDenied(r.checkSourcePolicies(ctx, "restricted", newPR(gitClone("https://github.com/other/blueprints")))))
+
+	assert.NoError(t, r.checkSourcePolicies(ctx, "restricted", newPR(porchapi.Task{
+		Type: porchapi.TaskTypeClone,
+		Clone: &porchapi.PackageCloneTaskSpec{
+			Upstream: porchapi.UpstreamPackage{UpstreamRef: &porchapi.PackageRevisionRef{Name: "blueprints.base.v1"}},
+		},
+	})))
+	assert.True(t, repository.IsSourceDenied(r.checkSourcePolicies(ctx, "restricted", newPR(porchapi.Task{
+		Type: porchapi.TaskTypeUpgrade,
+		Upgrade: &porchapi.PackageUpgradeTaskSpec{
+			NewUpstream: porchapi.PackageRevisionRef{Name: "scratch.base.v2"},
+		},
+	}))))
+
+	assert.NoError(t, r.checkSourcePolicies(ctx, "restricted", newPR(porchapi.Task{
+		Type: porchapi.TaskTypeInit,
+		Init: &porchapi.PackageInitTaskSpec{},
+	})))
+}
+
+func TestInternalOrSourceDeniedError(t *testing.T) {
+	err := internalOrSourceDeniedError("repo.pkg.ws", &repository.SourceDeniedError{
+		Namespace: "restricted",
+		Kind:      repository.SourceKindGit,
+		Source:    "https://github.com/other/blueprints",
+	})
+	assert.True(t, apierrors.IsForbidden(err))
+	assert.Contains(t, err.Error(), "https://github.com/other/blueprints")
+
+	assert.True(t, apierrors.IsInternalError(internalOrSourceDeniedError("repo.pkg.ws", errors.New("boom"))))
+}
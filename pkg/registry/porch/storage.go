@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/apiserver/pkg/registry/rest"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,6 +32,9 @@ type RESTStorageOptions struct {
 	Codecs     serializer.CodecFactory
 	CaD        engine.CaDEngine
 	CoreClient client.WithWatch
+	// Authorizer checks that callers of the conditions subresource may set each condition type,
+	// and that callers of packagerevisionbatches may apply the operation to each package revision.
+	Authorizer authorizer.Authorizer
 }
 
 func (r *RESTStorageOptions) NewRESTStorage() (genericapiserver.APIGroupInfo, error) {
@@ -69,6 +73,34 @@ func (r *RESTStorageOptions) NewRESTStorage() (genericapiserver.APIGroupInfo, er
 		},
 	}
 
+	packageRevisionDependencies := &packageRevisionDependencies{
+		packageCommon: packageCommon{
+			scheme:     r.Scheme,
+			cad:        r.CaD,
+			coreClient: r.CoreClient,
+			gr:         porchapi.Resource("packagerevisions"),
+		},
+	}
+
+	packageRevisionPipeline := &packageRevisionPipeline{
+		packageCommon: packageCommon{
+			scheme:     r.Scheme,
+			cad:        r.CaD,
+			coreClient: r.CoreClient,
+			gr:         porchapi.Resource("packagerevisions"),
+		},
+	}
+
+	packageRevisionConditions := &packageRevisionConditions{
+		packageCommon: packageCommon{
+			scheme:     r.Scheme,
+			cad:        r.CaD,
+			coreClient: r.CoreClient,
+			gr:         porchapi.Resource("packagerevisions"),
+		},
+		authorizer: r.Authorizer,
+	}
+
 	packageRevisionResources := &packageRevisionResources{
 		TableConvertor: packageRevisionResourcesTableConvertor,
 		packageCommon: packageCommon{
@@ -79,14 +111,48 @@ func (r *RESTStorageOptions) NewRESTStorage() (genericapiserver.APIGroupInfo, er
 		},
 	}
 
+	repositoryBundles := &repositoryBundles{
+		packageCommon: packageCommon{
+			scheme:     r.Scheme,
+			cad:        r.CaD,
+			coreClient: r.CoreClient,
+			gr:         porchapi.Resource("repositorybundles"),
+		},
+	}
+
+	packageRevisionBatches := &packageRevisionBatches{
+		packageCommon: packageCommon{
+			scheme:     r.Scheme,
+			cad:        r.CaD,
+			coreClient: r.CoreClient,
+			gr:         porchapi.Resource("packagerevisionbatches"),
+		},
+		authorizer: r.Authorizer,
+	}
+
+	packageUpgradePreviews := &packageUpgradePreviews{
+		packageCommon: packageCommon{
+			scheme:     r.Scheme,
+			cad:        r.CaD,
+			coreClient: r.CoreClient,
+			gr:         porchapi.Resource("packageupgradepreviews"),
+		},
+	}
+
 	group := genericapiserver.NewDefaultAPIGroupInfo(porchapi.GroupName, r.Scheme, metav1.ParameterCodec, r.Codecs)
 
 	group.VersionedResourcesStorageMap = map[string]map[string]rest.Storage{
 		porchapi.SchemeGroupVersion.Version: {
-			"packages":                  packages,
-			"packagerevisions":          packageRevisions,
-			"packagerevisions/approval": packageRevisionsApproval,
-			"packagerevisionresources":  packageRevisionResources,
+			"packages":                      packages,
+			"packagerevisions":              packageRevisions,
+			"packagerevisions/approval":     packageRevisionsApproval,
+			"packagerevisions/conditions":   packageRevisionConditions,
+			"packagerevisions/dependencies": packageRevisionDependencies,
+			"packagerevisions/pipeline":     packageRevisionPipeline,
+			"packagerevisionresources":      packageRevisionResources,
+			"repositorybundles":             repositoryBundles,
+			"packagerevisionbatches":        packageRevisionBatches,
+			"packageupgradepreviews":        packageUpgradePreviews,
 		},
 	}
 
@@ -21,6 +21,7 @@ import (
 
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
 	"github.com/kptdev/porch/pkg/util"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -94,6 +95,12 @@ func (s packageRevisionStrategy) ValidateUpdate(ctx context.Context, obj, old ru
 				}, ",")),
 			))
 		}
+		if newLifecycle == porchapi.PackageRevisionLifecycleProposed && lifecycle != porchapi.PackageRevisionLifecycleProposed {
+			if c := mergeconflict.Unresolved(oldRevision.Status.Conditions); c != nil {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("status", "conditions"),
+					fmt.Sprintf("cannot propose package; %s", c.Message)))
+			}
+		}
 	case porchapi.PackageRevisionLifecyclePublished, porchapi.PackageRevisionLifecycleDeletionProposed:
 		// We don't allow any updates to the spec for packagerevision that have been published. That includes updates of the lifecycle. But
 		// we allow updates to metadata and status. The only exception is that the lifecycle
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -78,3 +79,65 @@ func TestPackageRevisionStrategyValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestPackageRevisionStrategyValidateUpdateMergeConflicts(t *testing.T) {
+	s := packageRevisionStrategy{}
+
+	packageRevision := func(lifecycle porchapi.PackageRevisionLifecycle, conflicts porchapi.ConditionStatus) *porchapi.PackageRevision {
+		pr := &porchapi.PackageRevision{
+			Spec: porchapi.PackageRevisionSpec{
+				PackageName:    "test-package",
+				WorkspaceName:  "test-workspace",
+				RepositoryName: "test-repo",
+				Lifecycle:      lifecycle,
+			},
+		}
+		if conflicts != "" {
+			pr.Status.Conditions = []porchapi.Condition{{
+				Type:    mergeconflict.ConditionType,
+				Status:  conflicts,
+				Message: "1 unresolved merge conflicts: deploy.yaml Deployment/app spec.replicas",
+			}}
+		}
+		return pr
+	}
+
+	testCases := map[string]struct {
+		old, new *porchapi.PackageRevision
+		valid    bool
+	}{
+		"cannot propose with unresolved conflicts": {
+			old:   packageRevision(porchapi.PackageRevisionLifecycleDraft, porchapi.ConditionTrue),
+			new:   packageRevision(porchapi.PackageRevisionLifecycleProposed, porchapi.ConditionTrue),
+			valid: false,
+		},
+		"can propose with resolved conflicts": {
+			old:   packageRevision(porchapi.PackageRevisionLifecycleDraft, porchapi.ConditionFalse),
+			new:   packageRevision(porchapi.PackageRevisionLifecycleProposed, porchapi.ConditionFalse),
+			valid: true,
+		},
+		"can propose without the condition": {
+			old:   packageRevision(porchapi.PackageRevisionLifecycleDraft, ""),
+			new:   packageRevision(porchapi.PackageRevisionLifecycleProposed, ""),
+			valid: true,
+		},
+		"can update a draft with unresolved conflicts": {
+			old:   packageRevision(porchapi.PackageRevisionLifecycleDraft, porchapi.ConditionTrue),
+			new:   packageRevision(porchapi.PackageRevisionLifecycleDraft, porchapi.ConditionTrue),
+			valid: true,
+		},
+	}
+
+	for tn := range testCases {
+		tc := testCases[tn]
+		t.Run(tn, func(t *testing.T) {
+			allErrs := s.ValidateUpdate(context.Background(), tc.new, tc.old)
+
+			if tc.valid {
+				assert.Empty(t, allErrs, "Update validation failed unexpectedly")
+			} else {
+				assert.NotEmpty(t, allErrs, "Update validation should fail but didn't")
+			}
+		})
+	}
+}
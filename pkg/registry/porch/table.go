@@ -118,6 +118,7 @@ var (
 				isLatest(pr),
 				pr.Spec.Lifecycle,
 				pr.Spec.RepositoryName,
+				deploymentState(pr),
 			}
 		},
 		columns: []metav1.TableColumnDefinition{
@@ -128,6 +129,7 @@ var (
 			{Name: "Latest", Type: "boolean"},
 			{Name: "Lifecycle", Type: "string"},
 			{Name: "Repository", Type: "string"},
+			{Name: "Deployment", Type: "string", Priority: 1},
 		},
 	}
 
@@ -162,3 +164,10 @@ func isLatest(pr *porchapi.PackageRevision) bool {
 	val, ok := pr.Labels[porchapi.LatestPackageRevisionKey]
 	return ok && val == porchapi.LatestPackageRevisionValue
 }
+
+func deploymentState(pr *porchapi.PackageRevision) string {
+	if pr.Status.DeploymentStatus == nil {
+		return ""
+	}
+	return pr.Status.DeploymentStatus.State
+}
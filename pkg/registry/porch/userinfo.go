@@ -17,7 +17,9 @@ package porch
 import (
 	"context"
 
+	"github.com/kptdev/porch/func/evaluator"
 	"github.com/kptdev/porch/pkg/repository"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/endpoints/request"
 )
@@ -48,3 +50,17 @@ func (p *ApiserverUserInfoProvider) GetUserInfo(ctx context.Context) *repository
 
 	return nil
 }
+
+// requestPriority returns the priority of the function evaluations of a request. Requests
+// of service accounts, such as those of controllers, are evaluated in the background;
+// requests of other users are evaluated ahead of them, since a user is waiting for them.
+func requestPriority(ctx context.Context) int32 {
+	userinfo, ok := request.UserFrom(ctx)
+	if !ok {
+		return evaluator.PriorityBackground
+	}
+	if _, _, err := serviceaccount.SplitUsername(userinfo.GetName()); err == nil {
+		return evaluator.PriorityBackground
+	}
+	return evaluator.PriorityInteractive
+}
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/kptdev/porch/func/evaluator"
 	"github.com/kptdev/porch/pkg/repository"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/endpoints/request"
@@ -83,3 +84,36 @@ func TestEmptyUserInfo(t *testing.T) {
 		t.Errorf("GetUserInfo with empty context: got %v, want %v", got, want)
 	}
 }
+
+func TestRequestPriority(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		user user.Info
+
+		want int32
+	}{
+		{
+			name: "no user",
+			want: evaluator.PriorityBackground,
+		},
+		{
+			name: "service account",
+			user: &user.DefaultInfo{Name: "system:serviceaccount:porch-system:porch-controllers"},
+			want: evaluator.PriorityBackground,
+		},
+		{
+			name: "user",
+			user: &user.DefaultInfo{Name: "user1@domain.com", Groups: []string{user.AllAuthenticated}},
+			want: evaluator.PriorityInteractive,
+		},
+	} {
+		ctx := context.Background()
+		if tc.user != nil {
+			ctx = request.WithUser(ctx, tc.user)
+		}
+
+		if got := requestPriority(ctx); got != tc.want {
+			t.Errorf("%s: requestPriority: got %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
@@ -16,6 +16,7 @@ package porch
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/kptdev/porch/pkg/engine"
 	"github.com/kptdev/porch/pkg/repository"
 	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -47,6 +49,11 @@ func createGenericWatch(ctx context.Context, r packageReader, filter repository.
 		sendInitialEvents:   sendInitialEvents,
 		bookmarkInterval:    1 * time.Minute, // Default bookmark interval which aligns with Kubernetes standards. Enables code to be tested.
 	}
+	// A watch from a resource version continues where the client left off, unless it asks for the initial events.
+	// Resource version "0" means any version, which we serve with a list.
+	if options != nil && !sendInitialEvents && options.ResourceVersion != "" && options.ResourceVersion != "0" {
+		w.resourceVersion = options.ResourceVersion
+	}
 	go w.listAndWatch(ctx, r, filter)
 
 	return w, nil
@@ -56,7 +63,7 @@ func createGenericWatch(ctx context.Context, r packageReader, filter repository.
 func (r *packageRevisions) Watch(ctx context.Context, options *metainternalversion.ListOptions) (watch.Interface, error) {
 	// 'label' selects on labels; 'field' selects on the object's fields. Not all fields
 	// are supported; an error should be returned if 'field' tries to select on a field that
-	// isn't supported. 'resourceVersion' allows for continuing/starting a watch at a
+	// isn't supported. 'resourceVersion' allows for continuing a watch after a
 	// particular version.
 
 	ctx, span := tracer.Start(ctx, "[START]::packageRevisions::Watch", trace.WithAttributes())
@@ -84,18 +91,25 @@ type watcher struct {
 	mutex         sync.Mutex
 	eventCallback func(eventType watch.EventType, pr repository.PackageRevision) bool
 	done          bool
-	totalSent     int
+	// errorResult ends the watch with an error.
+	errorResult chan error
+	totalSent   int
+	// received counts the events the watcher was called with, including those it filtered out.
+	received int
 
 	// objectExtractor function to get the appropriate object from PackageRevision
 	extractor           objectExtractor
 	allowWatchBookmarks bool
 	sendInitialEvents   bool
+	// resourceVersion is the version to resume the watch after; if empty, the watch starts with a list.
+	resourceVersion     string
 	lastResourceVersion string
 	initialEventsSent   bool
 	bookmarkInterval    time.Duration
 }
 
 var _ watch.Interface = &watcher{}
+var _ engine.ExpirableWatcher = &watcher{}
 
 // Stop stops watching. Will close the channel returned by ResultChan(). Releases
 // any resources used by the watch.
@@ -112,6 +126,8 @@ func (w *watcher) ResultChan() <-chan watch.Event {
 
 type packageReader interface {
 	watchPackages(ctx context.Context, filter repository.ListPackageRevisionFilter, callback engine.ObjectWatcher) error
+	watchPackagesFrom(ctx context.Context, filter repository.ListPackageRevisionFilter, resourceVersion string, callback engine.ObjectWatcher) error
+	latestResourceVersion() string
 	listPackageRevisions(ctx context.Context, filter repository.ListPackageRevisionFilter, callback func(ctx context.Context, p repository.PackageRevision) error) error
 }
 
@@ -120,8 +136,9 @@ type packageReader interface {
 type objectExtractor func(ctx context.Context, pr repository.PackageRevision) (runtime.Object, error)
 
 // listAndWatch implements watch by doing a list, then sending any observed changes.
-// This is not a compliant implementation of watch, but it is a good-enough start for most controllers.
 // One trick is that we start the watch _before_ we perform the list, so we don't miss changes that happen immediately after the list.
+// A watch with a resource version skips the list, and instead replays the changes made after that version,
+// unless the version is not recognised.
 func (w *watcher) listAndWatch(ctx context.Context, r packageReader, filter repository.ListPackageRevisionFilter) {
 	if w.extractor == nil {
 		w.extractor = func(ctx context.Context, pr repository.PackageRevision) (runtime.Object, error) {
@@ -130,13 +147,21 @@ func (w *watcher) listAndWatch(ctx context.Context, r packageReader, filter repo
 	}
 
 	if err := w.listAndWatchInner(ctx, r, filter); err != nil {
-		// TODO: We need to populate the object on this error
 		if err == context.Canceled || err == context.DeadlineExceeded {
 			klog.V(3).Infof("sending error to watch stream: %v", err)
 		} else {
 			klog.Warningf("sending error to watch stream: %v", err)
 		}
-		// Don't send error events with nil objects
+		// Don't send error events with nil objects; API errors such as an expired resource version carry their
+		// status, which tells the client to list again.
+		var statusErr apierrors.APIStatus
+		if errors.As(err, &statusErr) {
+			status := statusErr.Status()
+			w.resultChan <- watch.Event{
+				Type:   watch.Error,
+				Object: &status,
+			}
+		}
 	}
 	w.cancel()
 	close(w.resultChan)
@@ -144,7 +169,49 @@ func (w *watcher) listAndWatch(ctx context.Context, r packageReader, filter repo
 
 func (w *watcher) listAndWatchInner(ctx context.Context, r packageReader, filter repository.ListPackageRevisionFilter) error {
 	errorResult := make(chan error, 4)
+	w.mutex.Lock()
+	w.errorResult = errorResult
+	w.mutex.Unlock()
+
+	if w.resourceVersion != "" {
+		err := w.resume(ctx, r, filter, errorResult)
+		if errors.Is(err, engine.ErrUnknownResourceVersion) {
+			// A resource version the server does not recognise, for instance one issued before a restart,
+			// starts the watch with a list as it did before watches could resume.
+			klog.V(3).Infof("watch %p: unknown resource version %s, listing instead", w, w.resourceVersion)
+			err = w.list(ctx, r, filter, errorResult)
+		}
+		if err != nil {
+			return err
+		}
+	} else if err := w.list(ctx, r, filter, errorResult); err != nil {
+		return err
+	}
+
+	return w.stream(ctx, r, errorResult)
+}
+
+// resume starts sending changes, beginning with those made after the watcher's resource version.
+func (w *watcher) resume(ctx context.Context, r packageReader, filter repository.ListPackageRevisionFilter, errorResult chan error) error {
+	w.mutex.Lock()
+	w.eventCallback = w.streamingCallback(ctx, errorResult)
+	w.mutex.Unlock()
 
+	if err := r.watchPackagesFrom(ctx, filter, w.resourceVersion, w); err != nil {
+		if !errors.Is(err, engine.ErrUnknownResourceVersion) {
+			w.mutex.Lock()
+			w.done = true
+			w.mutex.Unlock()
+		}
+		return err
+	}
+
+	klog.V(3).Infof("watch %p: resumed watch in streaming mode after resource version %s", w, w.resourceVersion)
+	return nil
+}
+
+// list sends all the matching package revisions as added, followed by the changes made in the meantime.
+func (w *watcher) list(ctx context.Context, r packageReader, filter repository.ListPackageRevisionFilter, errorResult chan error) error {
 	var backlog []watch.Event
 	// Make sure we hold the lock when setting the eventCallback, as it
 	// will be read by other goroutines when events happen.
@@ -223,29 +290,47 @@ func (w *watcher) listAndWatchInner(ctx context.Context, r packageReader, filter
 		}
 	}
 
-	w.mutex.Lock()
-	// Pick up anything that squeezed in
+	// Pick up anything that squeezed in. The initial bookmark needs a resource version that no event
+	// received so far is newer than, so read the latest one again until no event comes in meanwhile.
 	sentNewBacklog := 0
-	for _, ev := range backlog {
-		// TODO: Check resource version?
+	var bookmarkRV string
+	for {
+		latest, received := w.latestResourceVersion(r)
+		w.mutex.Lock()
+		for _, ev := range backlog {
+			sentNewBacklog += 1
+			w.sendWatchEvent(ev)
+		}
+		backlog = nil
 
-		sentNewBacklog += 1
-		w.sendWatchEvent(ev)
+		// For empty namespaces, use "0" as the resource version
+		if w.lastResourceVersion == "" {
+			w.lastResourceVersion = "0"
+		}
+		bookmarkRV = w.bookmarkResourceVersion(latest, received)
+		if bookmarkRV != "" || !w.allowWatchBookmarks {
+			break
+		}
+		w.mutex.Unlock()
 	}
 
 	klog.V(3).Infof("watch %p: moving watch into streaming mode after sentAdd %d, sentBacklog %d, sentNewBacklog %d", w, sentAdd, sentBacklog, sentNewBacklog)
 
 	// Send initial bookmark after list completes if requested
-	// For empty namespaces, use "0" as the resource version
 	if w.allowWatchBookmarks {
-		if w.lastResourceVersion == "" {
-			w.lastResourceVersion = "0"
-		}
-		w.sendBookmark(true)
+		w.sendBookmark(bookmarkRV, true)
 		w.initialEventsSent = true
 	}
 
-	w.eventCallback = func(eventType watch.EventType, pr repository.PackageRevision) bool {
+	w.eventCallback = w.streamingCallback(ctx, errorResult)
+	w.mutex.Unlock()
+
+	return nil
+}
+
+// streamingCallback returns the event callback that sends changes straight to the result channel.
+func (w *watcher) streamingCallback(ctx context.Context, errorResult chan error) func(eventType watch.EventType, pr repository.PackageRevision) bool {
+	return func(eventType watch.EventType, pr repository.PackageRevision) bool {
 		if w.done {
 			return false
 		}
@@ -258,7 +343,6 @@ func (w *watcher) listAndWatchInner(ctx context.Context, r packageReader, filter
 		if obj == nil {
 			return true
 		}
-		// TODO: Check resource version?
 		ev := watch.Event{
 			Type:   eventType,
 			Object: obj,
@@ -266,8 +350,10 @@ func (w *watcher) listAndWatchInner(ctx context.Context, r packageReader, filter
 		w.sendWatchEvent(ev)
 		return true
 	}
-	w.mutex.Unlock()
+}
 
+// stream waits until the watch ends, sending periodic bookmarks if requested.
+func (w *watcher) stream(ctx context.Context, r packageReader, errorResult chan error) error {
 	// Send periodic bookmarks if requested
 	var bookmarkTicker *time.Ticker
 	var bookmarkChan <-chan time.Time
@@ -296,8 +382,10 @@ func (w *watcher) listAndWatchInner(ctx context.Context, r packageReader, filter
 			return err
 
 		case <-bookmarkChan:
+			latest, received := w.latestResourceVersion(r)
 			w.mutex.Lock()
-			w.sendBookmark(false)
+			// An event came in after the latest resource version was read; bookmark at the next tick instead.
+			w.sendBookmark(w.bookmarkResourceVersion(latest, received), false)
 			w.mutex.Unlock()
 		}
 	}
@@ -313,13 +401,38 @@ func (w *watcher) sendWatchEvent(ev watch.Event) {
 	}
 }
 
-func (w *watcher) sendBookmark(markInitialEventsEnd bool) {
+// latestResourceVersion returns the resource version of the latest change, along with the number of events
+// received so far. It must be called without holding the lock, as the package reader takes its own lock to
+// notify the watcher.
+func (w *watcher) latestResourceVersion(r packageReader) (string, int) {
+	w.mutex.Lock()
+	received := w.received
+	w.mutex.Unlock()
+
+	return r.latestResourceVersion(), received
+}
+
+// bookmarkResourceVersion returns the resource version of the latest change if no event was received since it
+// was read, and an empty string otherwise, so that bookmarks never go back in time. Only the versions of the
+// package reader can resume a watch, so the version of the last event sent is used only if the reader has
+// none. The caller must hold the lock.
+func (w *watcher) bookmarkResourceVersion(latest string, received int) string {
+	if latest == "" {
+		return w.lastResourceVersion
+	}
+	if w.received == received {
+		return latest
+	}
+	return ""
+}
+
+func (w *watcher) sendBookmark(resourceVersion string, markInitialEventsEnd bool) {
 	if w.done {
 		return
 	}
 
-	// Create a bookmark event with the last known resource version
-	if w.lastResourceVersion != "" {
+	// Create a bookmark event with the given resource version
+	if resourceVersion != "" {
 		// Create a minimal PackageRevision object for the bookmark
 		obj := &porchapi.PackageRevision{
 			TypeMeta: metav1.TypeMeta{
@@ -327,7 +440,7 @@ func (w *watcher) sendBookmark(markInitialEventsEnd bool) {
 				APIVersion: porchapi.SchemeGroupVersion.String(),
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				ResourceVersion: w.lastResourceVersion,
+				ResourceVersion: resourceVersion,
 			},
 		}
 		// Add annotation to mark end of initial events only for WatchList requests
@@ -342,7 +455,7 @@ func (w *watcher) sendBookmark(markInitialEventsEnd bool) {
 		}
 		w.resultChan <- ev
 		didMarkInitialEventsEnd := markInitialEventsEnd && w.sendInitialEvents
-		klog.V(2).Infof("watch %p: sent bookmark with resourceVersion %s (markInitialEventsEnd=%v, sendInitialEvents=%v, annotationApplied=%v)", w, w.lastResourceVersion, markInitialEventsEnd, w.sendInitialEvents, didMarkInitialEventsEnd)
+		klog.V(2).Infof("watch %p: sent bookmark with resourceVersion %s (markInitialEventsEnd=%v, sendInitialEvents=%v, annotationApplied=%v)", w, resourceVersion, markInitialEventsEnd, w.sendInitialEvents, didMarkInitialEventsEnd)
 	}
 }
 
@@ -351,9 +464,23 @@ func (w *watcher) OnPackageRevisionChange(eventType watch.EventType, pr reposito
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
+	w.received++
 	return w.eventCallback(eventType, pr)
 }
 
+// OnWatchExpired is called when the watch missed changes. It ends the watch with the error, which tells the
+// client to list again.
+func (w *watcher) OnWatchExpired(err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.done || w.errorResult == nil {
+		return
+	}
+	w.done = true
+	w.errorResult <- err
+}
+
 // effectiveAllowWatchBookmarks returns true if bookmarks should be sent.
 // WatchList semantics require bookmarks to signal initial-events-end.
 func effectiveAllowWatchBookmarks(allowWatchBookmarks, sendInitialEvents bool) bool {
@@ -26,6 +26,8 @@ import (
 	"github.com/kptdev/porch/pkg/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
@@ -49,6 +51,9 @@ type fakePackageReader struct {
 	callback           engine.ObjectWatcher
 	packages           []repository.PackageRevision
 	sendEventInBacklog bool
+	latest             string
+	expiredAfter       string
+	unknown            bool
 }
 
 func (f *fakePackageReader) watchPackages(ctx context.Context, filter repository.ListPackageRevisionFilter, callback engine.ObjectWatcher) error {
@@ -61,6 +66,27 @@ func (f *fakePackageReader) watchPackages(ctx context.Context, filter repository
 	return nil
 }
 
+func (f *fakePackageReader) watchPackagesFrom(ctx context.Context, filter repository.ListPackageRevisionFilter, resourceVersion string, callback engine.ObjectWatcher) error {
+	defer f.Done()
+	if f.unknown {
+		return engine.ErrUnknownResourceVersion
+	}
+	f.callback = callback
+	if f.expiredAfter != "" && resourceVersion <= f.expiredAfter {
+		return apierrors.NewResourceExpired("too old resource version: " + resourceVersion)
+	}
+	for _, pkg := range f.packages {
+		if pkg.ResourceVersion() > resourceVersion {
+			callback.OnPackageRevisionChange(watch.Modified, pkg)
+		}
+	}
+	return nil
+}
+
+func (f *fakePackageReader) latestResourceVersion() string {
+	return f.latest
+}
+
 func (f *fakePackageReader) listPackageRevisions(ctx context.Context, filter repository.ListPackageRevisionFilter, callback func(ctx context.Context, p repository.PackageRevision) error) error {
 	for _, pkg := range f.packages {
 		if err := callback(ctx, pkg); err != nil {
@@ -368,3 +394,270 @@ done:
 		assert.Empty(t, obj.Annotations, "Periodic bookmark should not have annotations")
 	}
 }
+
+func TestWatcherResume(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	w := &watcher{
+		cancel:          cancelFunc,
+		resultChan:      make(chan watch.Event, 64),
+		resourceVersion: "2",
+	}
+
+	r := &fakePackageReader{
+		packages: []repository.PackageRevision{
+			createFakePackageRevision("1"),
+			createFakePackageRevision("2"),
+			createFakePackageRevision("3"),
+		},
+	}
+	r.Add(1)
+	var filter repository.ListPackageRevisionFilter
+
+	go w.listAndWatch(ctx, r, filter)
+	r.Wait()
+
+	// Only the change after the resource version is replayed, and nothing is listed
+	ev := <-w.resultChan
+	assert.Equal(t, watch.Modified, ev.Type)
+	assert.Equal(t, "3", ev.Object.(*porchapi.PackageRevision).ResourceVersion)
+
+	cont := r.callback.OnPackageRevisionChange(watch.Deleted, createFakePackageRevision("4"))
+	assert.True(t, cont)
+	ev = <-w.resultChan
+	assert.Equal(t, watch.Deleted, ev.Type)
+	assert.Equal(t, "4", ev.Object.(*porchapi.PackageRevision).ResourceVersion)
+}
+
+func TestWatcherResumeExpired(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	w := &watcher{
+		cancel:          cancelFunc,
+		resultChan:      make(chan watch.Event, 64),
+		resourceVersion: "2",
+	}
+
+	r := &fakePackageReader{expiredAfter: "5"}
+	r.Add(1)
+	var filter repository.ListPackageRevisionFilter
+
+	go w.listAndWatch(ctx, r, filter)
+	r.Wait()
+
+	ev, ok := <-w.resultChan
+	require.True(t, ok, "Expected an error event before the watch closes")
+	assert.Equal(t, watch.Error, ev.Type)
+	status, isStatus := ev.Object.(*metav1.Status)
+	require.True(t, isStatus)
+	assert.Equal(t, metav1.StatusReasonExpired, status.Reason)
+
+	_, ok = <-w.resultChan
+	assert.False(t, ok, "Expected the watch to close after the error")
+}
+
+func TestWatcherResumeUnknownResourceVersion(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	w := &watcher{
+		cancel:          cancelFunc,
+		resultChan:      make(chan watch.Event, 64),
+		resourceVersion: "unknown",
+	}
+
+	r := &fakePackageReader{
+		packages: []repository.PackageRevision{createFakePackageRevision("1")},
+		unknown:  true,
+	}
+	// The resume and the watch of the list that replaces it
+	r.Add(2)
+	var filter repository.ListPackageRevisionFilter
+
+	go w.listAndWatch(ctx, r, filter)
+	r.Wait()
+
+	// The watch starts with a list, as for a watch without a resource version
+	ev := <-w.resultChan
+	assert.Equal(t, watch.Added, ev.Type)
+	assert.Equal(t, "1", ev.Object.(*porchapi.PackageRevision).ResourceVersion)
+}
+
+// managerPackageReader serves watches from a watcher manager.
+type managerPackageReader struct {
+	fakePackageReader
+	manager engine.WatcherManager
+}
+
+func (m *managerPackageReader) watchPackages(ctx context.Context, filter repository.ListPackageRevisionFilter, callback engine.ObjectWatcher) error {
+	return m.manager.WatchPackageRevisions(ctx, filter, callback)
+}
+
+func (m *managerPackageReader) watchPackagesFrom(ctx context.Context, filter repository.ListPackageRevisionFilter, resourceVersion string, callback engine.ObjectWatcher) error {
+	return m.manager.WatchPackageRevisionsFrom(ctx, filter, resourceVersion, callback)
+}
+
+func (m *managerPackageReader) latestResourceVersion() string {
+	return m.manager.ResourceVersion()
+}
+
+func TestWatcherResumeFromEventResourceVersion(t *testing.T) {
+	manager := engine.NewWatcherManager(10)
+	r := &managerPackageReader{manager: manager}
+
+	first, err := createGenericWatch(context.Background(), r, repository.ListPackageRevisionFilter{}, nil, nil)
+	require.NoError(t, err)
+	// Wait until the list is done and the watch streams
+	require.Eventually(t, func() bool {
+		return manager.NotifyPackageRevisionChange(watch.Added, createFakePackageRevision("a")) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	ev := <-first.ResultChan()
+	delivered := ev.Object.(*porchapi.PackageRevision).ResourceVersion
+	first.Stop()
+
+	// Changes made while the client reconnects are replayed after the event it received
+	manager.NotifyPackageRevisionChange(watch.Modified, createFakePackageRevision("b"))
+	resumed, err := createGenericWatch(context.Background(), r, repository.ListPackageRevisionFilter{}, nil,
+		&metainternalversion.ListOptions{ResourceVersion: delivered})
+	require.NoError(t, err)
+	defer resumed.Stop()
+
+	// Events for "a" notified while the first watch was starting may be replayed again, which is harmless
+	for ev = range resumed.ResultChan() {
+		if ev.Object.(*porchapi.PackageRevision).ResourceVersion != delivered {
+			break
+		}
+	}
+	assert.Equal(t, watch.Modified, ev.Type)
+	assert.Equal(t, "b", ev.Object.(*porchapi.PackageRevision).ResourceVersion)
+}
+
+func TestWatcherExpired(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	w := &watcher{
+		cancel:     cancelFunc,
+		resultChan: make(chan watch.Event, 64),
+	}
+
+	r := &fakePackageReader{
+		packages: []repository.PackageRevision{createFakePackageRevision("1")},
+	}
+	r.Add(1)
+	var filter repository.ListPackageRevisionFilter
+
+	go w.listAndWatch(ctx, r, filter)
+	r.Wait()
+
+	ev := <-w.resultChan
+	assert.Equal(t, watch.Added, ev.Type)
+
+	r.callback.(engine.ExpirableWatcher).OnWatchExpired(apierrors.NewResourceExpired("changes were missed"))
+	assert.False(t, r.callback.OnPackageRevisionChange(watch.Modified, createFakePackageRevision("2")))
+
+	ev, ok := <-w.resultChan
+	require.True(t, ok, "Expected an error event before the watch closes")
+	assert.Equal(t, watch.Error, ev.Type)
+	status, isStatus := ev.Object.(*metav1.Status)
+	require.True(t, isStatus)
+	assert.Equal(t, metav1.StatusReasonExpired, status.Reason)
+
+	_, ok = <-w.resultChan
+	assert.False(t, ok, "Expected the watch to close after the error")
+}
+
+func TestWatcherBookmarkLatestResourceVersion(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	w := &watcher{
+		cancel:              cancelFunc,
+		resultChan:          make(chan watch.Event, 64),
+		allowWatchBookmarks: true,
+	}
+
+	r := &fakePackageReader{
+		packages: []repository.PackageRevision{
+			createFakePackageRevision("123"),
+		},
+		latest: "456",
+	}
+	r.Add(1)
+	var filter repository.ListPackageRevisionFilter
+
+	go w.listAndWatch(ctx, r, filter)
+	r.Wait()
+
+	timeout := time.After(1 * time.Second)
+	for {
+		select {
+		case ev := <-w.resultChan:
+			if ev.Type == watch.Bookmark {
+				assert.Equal(t, "456", ev.Object.(*porchapi.PackageRevision).ResourceVersion)
+				return
+			}
+		case <-timeout:
+			require.Fail(t, "Timeout waiting for bookmark")
+		}
+	}
+}
+
+func TestWatcherBookmarkResourceVersionAfterEvent(t *testing.T) {
+	w := &watcher{lastResourceVersion: "pr.123"}
+
+	assert.Equal(t, "456", w.bookmarkResourceVersion("456", 0))
+
+	// An event received after the latest version was read may be newer than it, and the version of the
+	// package revision sent in it cannot resume a watch
+	w.received = 1
+	assert.Equal(t, "", w.bookmarkResourceVersion("456", 0))
+
+	// A reader without versions bookmarks the last event sent
+	assert.Equal(t, "pr.123", w.bookmarkResourceVersion("", 0))
+}
+
+func TestCreateGenericWatchResourceVersion(t *testing.T) {
+	sendInitialEvents := true
+	tests := []struct {
+		name     string
+		options  *metainternalversion.ListOptions
+		expected string
+	}{
+		{
+			name:     "no options",
+			options:  nil,
+			expected: "",
+		},
+		{
+			name:     "any version",
+			options:  &metainternalversion.ListOptions{ResourceVersion: "0"},
+			expected: "",
+		},
+		{
+			name:     "resume",
+			options:  &metainternalversion.ListOptions{ResourceVersion: "42"},
+			expected: "42",
+		},
+		{
+			name:     "initial events",
+			options:  &metainternalversion.ListOptions{ResourceVersion: "42", SendInitialEvents: &sendInitialEvents},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &fakePackageReader{}
+			r.Add(1)
+			wi, err := createGenericWatch(context.Background(), r, repository.ListPackageRevisionFilter{}, nil, tt.options)
+			require.NoError(t, err)
+			defer wi.Stop()
+			r.Wait()
+
+			assert.Equal(t, tt.expected, wi.(*watcher).resourceVersion)
+		})
+	}
+}
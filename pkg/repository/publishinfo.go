@@ -0,0 +1,45 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// PublishInfo is the publishing metadata of a package revision that was published elsewhere,
+// for example in the repository a package revision is imported from.
+type PublishInfo struct {
+	// Revision is the revision number of the package revision.
+	Revision int
+	// PublishedBy is the identity of the user who approved the package revision.
+	PublishedBy string
+	// PublishedAt is the time when the package revision was approved.
+	PublishedAt time.Time
+}
+
+type publishInfoKey struct{}
+
+// WithPublishInfo returns a context that makes repositories publish package revisions with
+// the given revision number, publisher and publish time instead of assigning new ones.
+func WithPublishInfo(ctx context.Context, info PublishInfo) context.Context {
+	return context.WithValue(ctx, publishInfoKey{}, info)
+}
+
+// PublishInfoFrom returns the publishing metadata set on the context with WithPublishInfo, if any.
+func PublishInfoFrom(ctx context.Context) (PublishInfo, bool) {
+	info, ok := ctx.Value(publishInfoKey{}).(PublishInfo)
+	return info, ok
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// PendingPush is a change to a repository that has been prepared but not yet pushed.
+type PendingPush interface {
+	// Push pushes the change to the remote repository.
+	Push(ctx context.Context) error
+}
+
+// BatchTransaction is a change outside the repositories, such as the writes of a database cache,
+// that must only be kept if the pushes of the batch succeed.
+type BatchTransaction interface {
+	// Commit keeps the change.
+	Commit(ctx context.Context) error
+	// Rollback undoes the change.
+	Rollback(ctx context.Context) error
+}
+
+// PushBatch collects the changes that repositories would push while the batch is set on a
+// context, so that changes to several package revisions of a repository are pushed together.
+type PushBatch struct {
+	mutex        sync.Mutex
+	pending      map[RepositoryKey]PendingPush
+	order        []RepositoryKey
+	transactions map[any]BatchTransaction
+	txOrder      []any
+}
+
+// NewPushBatch returns an empty PushBatch.
+func NewPushBatch() *PushBatch {
+	return &PushBatch{
+		pending:      map[RepositoryKey]PendingPush{},
+		transactions: map[any]BatchTransaction{},
+	}
+}
+
+type pushBatchKey struct{}
+
+// WithPushBatch returns a context that makes repositories that support batching add their
+// pushes to the batch instead of pushing them immediately.
+func WithPushBatch(ctx context.Context, batch *PushBatch) context.Context {
+	return context.WithValue(ctx, pushBatchKey{}, batch)
+}
+
+// PushBatchFrom returns the PushBatch set on the context with WithPushBatch, if any.
+func PushBatchFrom(ctx context.Context) (*PushBatch, bool) {
+	batch, ok := ctx.Value(pushBatchKey{}).(*PushBatch)
+	return batch, ok && batch != nil
+}
+
+// Pending returns the pending push of the repository, calling newPending to create it if the
+// batch does not hold one for the repository yet.
+func (b *PushBatch) Pending(key RepositoryKey, newPending func() PendingPush) PendingPush {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if pending, ok := b.pending[key]; ok {
+		return pending
+	}
+	pending := newPending()
+	b.pending[key] = pending
+	b.order = append(b.order, key)
+	return pending
+}
+
+// Len returns the number of repositories with a pending push.
+func (b *PushBatch) Len() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return len(b.pending)
+}
+
+// Transaction returns the transaction of the batch registered under key, calling begin to start
+// it if the batch does not hold one for key yet. If begin is nil, Transaction returns nil when
+// there is no transaction for key.
+func (b *PushBatch) Transaction(key any, begin func() (BatchTransaction, error)) (BatchTransaction, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if tx, ok := b.transactions[key]; ok {
+		return tx, nil
+	}
+	if begin == nil {
+		return nil, nil
+	}
+	tx, err := begin()
+	if err != nil {
+		return nil, err
+	}
+	b.transactions[key] = tx
+	b.txOrder = append(b.txOrder, key)
+	return tx, nil
+}
+
+// Flush pushes the pending pushes in the order their repositories joined the batch, and
+// empties the batch. It stops at the first push that fails and rolls back the transactions
+// of the batch. If all pushes succeed, it commits the transactions.
+func (b *PushBatch) Flush(ctx context.Context) error {
+	order, pending, transactions := b.take()
+
+	// Push without the batch, so that the pushes are not added to it again
+	ctx = WithPushBatch(ctx, nil)
+	for _, key := range order {
+		if err := pending[key].Push(ctx); err != nil {
+			return errors.Join(err, rollback(ctx, transactions))
+		}
+	}
+
+	var errs []error
+	for _, tx := range transactions {
+		errs = append(errs, tx.Commit(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// Discard empties the batch without pushing, and rolls back the transactions of the batch.
+func (b *PushBatch) Discard(ctx context.Context) error {
+	_, _, transactions := b.take()
+	return rollback(WithPushBatch(ctx, nil), transactions)
+}
+
+func (b *PushBatch) take() ([]RepositoryKey, map[RepositoryKey]PendingPush, []BatchTransaction) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	transactions := make([]BatchTransaction, 0, len(b.txOrder))
+	for _, key := range b.txOrder {
+		transactions = append(transactions, b.transactions[key])
+	}
+	order, pending := b.order, b.pending
+	b.order, b.pending = nil, map[RepositoryKey]PendingPush{}
+	b.txOrder, b.transactions = nil, map[any]BatchTransaction{}
+	return order, pending, transactions
+}
+
+func rollback(ctx context.Context, transactions []BatchTransaction) error {
+	var errs []error
+	for _, tx := range transactions {
+		errs = append(errs, tx.Rollback(ctx))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePendingPush struct {
+	name    string
+	pushed  *[]string
+	err     error
+	batched bool
+}
+
+func (p *fakePendingPush) Push(ctx context.Context) error {
+	_, p.batched = PushBatchFrom(ctx)
+	*p.pushed = append(*p.pushed, p.name)
+	return p.err
+}
+
+func TestPushBatchFrom(t *testing.T) {
+	_, ok := PushBatchFrom(context.Background())
+	assert.False(t, ok)
+
+	_, ok = PushBatchFrom(WithPushBatch(context.Background(), nil))
+	assert.False(t, ok)
+
+	batch := NewPushBatch()
+	got, ok := PushBatchFrom(WithPushBatch(context.Background(), batch))
+	assert.True(t, ok)
+	assert.Same(t, batch, got)
+}
+
+func TestPushBatchPending(t *testing.T) {
+	var pushed []string
+	batch := NewPushBatch()
+	repoA := RepositoryKey{Namespace: "ns", Name: "a"}
+
+	created := 0
+	newPending := func() PendingPush {
+		created++
+		return &fakePendingPush{name: "a", pushed: &pushed}
+	}
+
+	first := batch.Pending(repoA, newPending)
+	second := batch.Pending(repoA, newPending)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 1, batch.Len())
+}
+
+func TestPushBatchFlush(t *testing.T) {
+	var pushed []string
+	batch := NewPushBatch()
+	pushes := map[string]*fakePendingPush{}
+	for _, name := range []string{"b", "a", "c"} {
+		push := &fakePendingPush{name: name, pushed: &pushed}
+		pushes[name] = push
+		batch.Pending(RepositoryKey{Namespace: "ns", Name: name}, func() PendingPush { return push })
+	}
+
+	ctx := WithPushBatch(context.Background(), batch)
+	require.NoError(t, batch.Flush(ctx))
+	assert.Equal(t, []string{"b", "a", "c"}, pushed)
+	assert.Equal(t, 0, batch.Len())
+	for name, push := range pushes {
+		assert.False(t, push.batched, "push of %s must not see the batch", name)
+	}
+
+	// Flushing an empty batch pushes nothing
+	require.NoError(t, batch.Flush(ctx))
+	assert.Len(t, pushed, 3)
+}
+
+func TestPushBatchFlushStopsAtFirstError(t *testing.T) {
+	var pushed []string
+	batch := NewPushBatch()
+	pushErr := errors.New("push rejected")
+	batch.Pending(RepositoryKey{Name: "a"}, func() PendingPush { return &fakePendingPush{name: "a", pushed: &pushed, err: pushErr} })
+	batch.Pending(RepositoryKey{Name: "b"}, func() PendingPush { return &fakePendingPush{name: "b", pushed: &pushed} })
+
+	err := batch.Flush(context.Background())
+	assert.ErrorIs(t, err, pushErr)
+	assert.Equal(t, []string{"a"}, pushed)
+	assert.Equal(t, 0, batch.Len())
+}
+
+type fakeBatchTransaction struct {
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeBatchTransaction) Commit(context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeBatchTransaction) Rollback(context.Context) error {
+	tx.rolledBack = true
+	return nil
+}
+
+func TestPushBatchTransaction(t *testing.T) {
+	batch := NewPushBatch()
+
+	got, err := batch.Transaction("db", nil)
+	require.NoError(t, err)
+	assert.Nil(t, got, "a transaction must not be started without begin")
+
+	tx := &fakeBatchTransaction{}
+	begun := 0
+	begin := func() (BatchTransaction, error) {
+		begun++
+		return tx, nil
+	}
+	got, err = batch.Transaction("db", begin)
+	require.NoError(t, err)
+	assert.Same(t, tx, got)
+
+	got, err = batch.Transaction("db", nil)
+	require.NoError(t, err)
+	assert.Same(t, tx, got)
+	_, err = batch.Transaction("db", begin)
+	require.NoError(t, err)
+	assert.Equal(t, 1, begun)
+
+	beginErr := errors.New("cannot begin")
+	_, err = batch.Transaction("other", func() (BatchTransaction, error) { return nil, beginErr })
+	assert.ErrorIs(t, err, beginErr)
+}
+
+func TestPushBatchFlushCommitsTransactions(t *testing.T) {
+	var pushed []string
+	batch := NewPushBatch()
+	tx := &fakeBatchTransaction{}
+	_, err := batch.Transaction("db", func() (BatchTransaction, error) { return tx, nil })
+	require.NoError(t, err)
+	batch.Pending(RepositoryKey{Name: "a"}, func() PendingPush { return &fakePendingPush{name: "a", pushed: &pushed} })
+
+	require.NoError(t, batch.Flush(context.Background()))
+	assert.True(t, tx.committed)
+	assert.False(t, tx.rolledBack)
+
+	got, err := batch.Transaction("db", nil)
+	require.NoError(t, err)
+	assert.Nil(t, got, "flush must empty the batch")
+}
+
+func TestPushBatchFailedFlushRollsBackTransactions(t *testing.T) {
+	var pushed []string
+	batch := NewPushBatch()
+	tx := &fakeBatchTransaction{}
+	_, err := batch.Transaction("db", func() (BatchTransaction, error) { return tx, nil })
+	require.NoError(t, err)
+	pushErr := errors.New("push rejected")
+	batch.Pending(RepositoryKey{Name: "a"}, func() PendingPush { return &fakePendingPush{name: "a", pushed: &pushed, err: pushErr} })
+
+	assert.ErrorIs(t, batch.Flush(context.Background()), pushErr)
+	assert.False(t, tx.committed)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestPushBatchDiscard(t *testing.T) {
+	var pushed []string
+	batch := NewPushBatch()
+	tx := &fakeBatchTransaction{}
+	_, err := batch.Transaction("db", func() (BatchTransaction, error) { return tx, nil })
+	require.NoError(t, err)
+	batch.Pending(RepositoryKey{Name: "a"}, func() PendingPush { return &fakePendingPush{name: "a", pushed: &pushed} })
+
+	require.NoError(t, batch.Discard(context.Background()))
+	assert.Empty(t, pushed)
+	assert.Equal(t, 0, batch.Len())
+	assert.False(t, tx.committed)
+	assert.True(t, tx.rolledBack)
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Kinds of upstream sources checked against SourcePolicies.
+const (
+	SourceKindGit        = "git repository"
+	SourceKindRepository = "registered repository"
+	SourceKindOCI        = "OCI image"
+)
+
+// SourcePolicyChecker checks the upstream sources that package revisions are cloned, upgraded
+// or promoted from against the SourcePolicies of their namespace. The methods return a
+// *SourceDeniedError if the source is not allowed.
+type SourcePolicyChecker interface {
+	CheckGitSource(ctx context.Context, namespace, url string) error
+	CheckRepositorySource(ctx context.Context, namespace, repositoryName string) error
+	CheckOCISource(ctx context.Context, namespace, image string) error
+}
+
+// SourceDeniedError reports an upstream source that the SourcePolicies of a namespace do not allow.
+type SourceDeniedError struct {
+	Namespace string
+	Kind      string
+	Source    string
+}
+
+func (e *SourceDeniedError) Error() string {
+	return fmt.Sprintf("%s %q is not allowed by the SourcePolicies in namespace %q", e.Kind, e.Source, e.Namespace)
+}
+
+// IsSourceDenied reports whether err is, or wraps, a *SourceDeniedError.
+func IsSourceDenied(err error) bool {
+	var denied *SourceDeniedError
+	return errors.As(err, &denied)
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	kptfilev1 "github.com/kptdev/kpt/api/kptfile/v1"
+	"github.com/kptdev/kpt/pkg/kptfile/kptfileutil"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+)
+
+// Subpackage is an independent subpackage embedded in the resources of a package
+// revision, that is a directory below the root of the package that has its own Kptfile.
+type Subpackage struct {
+	// Path is the directory of the subpackage relative to the root of the package.
+	Path string
+	// Parent is the path of the closest enclosing subpackage, or empty if the
+	// subpackage is embedded directly in the package.
+	Parent string
+	// Name is the name in the Kptfile of the subpackage.
+	Name string
+	// UpstreamLock is the upstream lock in the Kptfile of the subpackage, if any.
+	UpstreamLock *kptfilev1.Locator
+}
+
+// FindSubpackages returns the independent subpackages embedded in the resources of a
+// package revision, ordered by path. A subpackage whose Kptfile cannot be decoded is
+// still reported, without a name or upstream lock.
+func FindSubpackages(resources map[string]string) []Subpackage {
+	var paths []string
+	for name := range resources {
+		dir, file := path.Split(name)
+		if file == kptfilev1.KptFileName && dir != "" {
+			paths = append(paths, strings.TrimSuffix(dir, "/"))
+		}
+	}
+	slices.Sort(paths)
+
+	subpackages := make([]Subpackage, 0, len(paths))
+	for i, dir := range paths {
+		subpackage := Subpackage{Path: dir}
+		// Paths are sorted, so the closest enclosing subpackage is the last earlier path that is a prefix.
+		for j := i - 1; j >= 0; j-- {
+			if strings.HasPrefix(dir, paths[j]+"/") {
+				subpackage.Parent = paths[j]
+				break
+			}
+		}
+		if kf, err := kptfileutil.DecodeKptfile(strings.NewReader(resources[path.Join(dir, kptfilev1.KptFileName)])); err == nil {
+			subpackage.Name = kf.Name
+			if kf.UpstreamLock != nil && kf.UpstreamLock.Git != nil {
+				lock := *kf.UpstreamLock
+				subpackage.UpstreamLock = &lock
+			}
+		}
+		subpackages = append(subpackages, subpackage)
+	}
+	if len(subpackages) == 0 {
+		return nil
+	}
+	return subpackages
+}
+
+// ToAPISubpackages converts subpackages to their v1alpha1 API representation.
+func ToAPISubpackages(subpackages []Subpackage) []porchapi.Subpackage {
+	var result []porchapi.Subpackage
+	for _, subpackage := range subpackages {
+		apiSubpackage := porchapi.Subpackage{
+			Path:   subpackage.Path,
+			Parent: subpackage.Parent,
+			Name:   subpackage.Name,
+		}
+		if subpackage.UpstreamLock != nil {
+			apiSubpackage.UpstreamLock = KptUpstreamLock2APIUpstreamLock(*subpackage.UpstreamLock)
+		}
+		result = append(result, apiSubpackage)
+	}
+	return result
+}
+
+// ToV1Alpha2Subpackages converts subpackages to their v1alpha2 API representation.
+func ToV1Alpha2Subpackages(subpackages []Subpackage) []porchv1alpha2.Subpackage {
+	var result []porchv1alpha2.Subpackage
+	for _, subpackage := range subpackages {
+		v1alpha2Subpackage := porchv1alpha2.Subpackage{
+			Path:   subpackage.Path,
+			Parent: subpackage.Parent,
+			Name:   subpackage.Name,
+		}
+		if subpackage.UpstreamLock != nil {
+			v1alpha2Subpackage.UpstreamLock = porchv1alpha2.KptLocatorToLocator(*subpackage.UpstreamLock)
+		}
+		result = append(result, v1alpha2Subpackage)
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"testing"
+
+	kptfilev1 "github.com/kptdev/kpt/api/kptfile/v1"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+const clonedSubpackageKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: monitoring
+upstream:
+  type: git
+  git:
+    repo: https://example.com/blueprints.git
+    directory: /monitoring
+    ref: v1
+upstreamLock:
+  type: git
+  git:
+    repo: https://example.com/blueprints.git
+    directory: /monitoring
+    ref: v1
+    commit: abc123
+`
+
+func TestFindSubpackages(t *testing.T) {
+	resources := map[string]string{
+		"Kptfile":                       "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: app\n",
+		"deployment.yaml":               "apiVersion: apps/v1\nkind: Deployment\n",
+		"monitoring/Kptfile":            clonedSubpackageKptfile,
+		"monitoring/alerts/Kptfile":     "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: alerts\n",
+		"monitoring/alerts/rules.yaml":  "apiVersion: v1\nkind: ConfigMap\n",
+		"monitoring-extra/Kptfile":      "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: extra\n",
+		"broken/Kptfile":                "not: [valid",
+		"docs/README.md":                "# docs",
+		"monitoring/alerts/README.md":   "# alerts",
+		"monitoring/dashboards/a.yaml":  "apiVersion: v1\nkind: ConfigMap\n",
+		"monitoring/dashboards/b.yaml":  "apiVersion: v1\nkind: ConfigMap\n",
+		"monitoring/alerts/more/x.yaml": "apiVersion: v1\nkind: ConfigMap\n",
+	}
+
+	subpackages := FindSubpackages(resources)
+
+	lock := kptfilev1.Locator{
+		Type: kptfilev1.GitOrigin,
+		Git: &kptfilev1.GitLock{
+			Repo:      "https://example.com/blueprints.git",
+			Directory: "/monitoring",
+			Ref:       "v1",
+			Commit:    "abc123",
+		},
+	}
+	assert.Equal(t, []Subpackage{
+		{Path: "broken"},
+		{Path: "monitoring", Name: "monitoring", UpstreamLock: &lock},
+		{Path: "monitoring-extra", Name: "extra"},
+		{Path: "monitoring/alerts", Parent: "monitoring", Name: "alerts"},
+	}, subpackages)
+
+	assert.Nil(t, FindSubpackages(map[string]string{"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\n"}))
+}
+
+func TestToAPISubpackages(t *testing.T) {
+	subpackages := []Subpackage{
+		{Path: "monitoring", Name: "monitoring", UpstreamLock: &kptfilev1.Locator{
+			Type: kptfilev1.GitOrigin,
+			Git:  &kptfilev1.GitLock{Repo: "https://example.com/blueprints.git", Directory: "/monitoring", Ref: "v1", Commit: "abc123"},
+		}},
+		{Path: "monitoring/alerts", Parent: "monitoring", Name: "alerts"},
+	}
+
+	assert.Equal(t, []porchapi.Subpackage{
+		{Path: "monitoring", Name: "monitoring", UpstreamLock: &porchapi.Locator{
+			Type: "git",
+			Git:  &porchapi.GitLock{Repo: "https://example.com/blueprints.git", Directory: "/monitoring", Ref: "v1", Commit: "abc123"},
+		}},
+		{Path: "monitoring/alerts", Parent: "monitoring", Name: "alerts"},
+	}, ToAPISubpackages(subpackages))
+
+	assert.Equal(t, []porchv1alpha2.Subpackage{
+		{Path: "monitoring", Name: "monitoring", UpstreamLock: &porchv1alpha2.Locator{
+			Type: "git",
+			Git:  &porchv1alpha2.GitLock{Repo: "https://example.com/blueprints.git", Directory: "/monitoring", Ref: "v1", Commit: "abc123"},
+		}},
+		{Path: "monitoring/alerts", Parent: "monitoring", Name: "alerts"},
+	}, ToV1Alpha2Subpackages(subpackages))
+
+	assert.Nil(t, ToAPISubpackages(nil))
+}
@@ -158,6 +158,24 @@ func KptUpstreamLock2KptUpstream(kptLock kptfilev1.Locator) kptfilev1.Upstream {
 	return kptUpstream
 }
 
+// UpstreamPackageRevisionName returns the name of the package revision that a package revision
+// was cloned or upgraded from, as recorded in its clone or upgrade task, or "" if there is none.
+func UpstreamPackageRevisionName(tasks []porchapi.Task) string {
+	for _, task := range tasks {
+		switch task.Type {
+		case porchapi.TaskTypeClone:
+			if task.Clone != nil && task.Clone.Upstream.UpstreamRef != nil && task.Clone.Upstream.UpstreamRef.Name != "" {
+				return task.Clone.Upstream.UpstreamRef.Name
+			}
+		case porchapi.TaskTypeUpgrade:
+			if task.Upgrade != nil && task.Upgrade.NewUpstream.Name != "" {
+				return task.Upgrade.NewUpstream.Name
+			}
+		}
+	}
+	return ""
+}
+
 // ValidatePackagePathOverlap checks for path conflicts with existing packages
 func ValidatePackagePathOverlap(newPr *porchapi.PackageRevision, existingRevs []PackageRevision) error {
 	existingPaths := make(map[string]bool)
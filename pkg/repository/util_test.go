@@ -209,6 +209,25 @@ func TestKptUpstreamLock2KptUpstream(t *testing.T) {
 	assert.Equal(t, "my-repo", KptUpstreamLock2KptUpstream(kptLock).Git.Repo)
 }
 
+func TestUpstreamPackageRevisionName(t *testing.T) {
+	assert.Empty(t, UpstreamPackageRevisionName(nil))
+	assert.Empty(t, UpstreamPackageRevisionName([]porchapi.Task{{Type: porchapi.TaskTypeInit, Init: &porchapi.PackageInitTaskSpec{}}}))
+	assert.Empty(t, UpstreamPackageRevisionName([]porchapi.Task{{
+		Type:  porchapi.TaskTypeClone,
+		Clone: &porchapi.PackageCloneTaskSpec{Upstream: porchapi.UpstreamPackage{UpstreamRef: &porchapi.PackageRevisionRef{}}},
+	}}))
+	assert.Equal(t, "repo.pkg.v1", UpstreamPackageRevisionName([]porchapi.Task{{
+		Type:  porchapi.TaskTypeClone,
+		Clone: &porchapi.PackageCloneTaskSpec{Upstream: porchapi.UpstreamPackage{UpstreamRef: &porchapi.PackageRevisionRef{Name: "repo.pkg.v1"}}},
+	}}))
+	assert.Equal(t, "repo.pkg.v2", UpstreamPackageRevisionName([]porchapi.Task{{
+		Type: porchapi.TaskTypeUpgrade,
+		Upgrade: &porchapi.PackageUpgradeTaskSpec{
+			NewUpstream: porchapi.PackageRevisionRef{Name: "repo.pkg.v2"},
+		},
+	}}))
+}
+
 func TestPathsOverlap(t *testing.T) {
 	assert.False(t, PathsOverlap("pkg1", "pkg1"))
 	assert.True(t, PathsOverlap("pkg", "pkg/sub"))
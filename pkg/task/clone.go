@@ -46,6 +46,7 @@ type clonePackageMutation struct {
 	repoOpener                 repository.RepositoryOpener
 	credentialResolver         repository.CredentialResolver
 	referenceResolver          repository.ReferenceResolver
+	sourcePolicyChecker        repository.SourcePolicyChecker
 	repoOperationRetryAttempts int
 
 	// packageConfig contains the package configuration.
@@ -106,8 +107,18 @@ func (m *clonePackageMutation) apply(ctx context.Context, resources repository.P
 }
 
 func (m *clonePackageMutation) cloneFromRegisteredRepository(ctx context.Context, ref *porchapi.PackageRevisionRef) (repository.PackageResources, error) {
+	upstreamRevision, err := m.fetchRegisteredRevision(ctx, ref)
+	if err != nil {
+		return repository.PackageResources{}, err
+	}
+	return m.cloneRevision(ctx, ref, upstreamRevision)
+}
+
+// fetchRegisteredRevision fetches the upstream package revision from a registered repository,
+// checking that the source policy allows the repository and that the revision is not a placeholder.
+func (m *clonePackageMutation) fetchRegisteredRevision(ctx context.Context, ref *porchapi.PackageRevisionRef) (repository.PackageRevision, error) {
 	if ref.Name == "" {
-		return repository.PackageResources{}, fmt.Errorf("upstreamRef.name is required")
+		return nil, fmt.Errorf("upstreamRef.name is required")
 	}
 
 	upstreamRevision, err := (&repository.PackageFetcher{
@@ -115,18 +126,29 @@ func (m *clonePackageMutation) cloneFromRegisteredRepository(ctx context.Context
 		ReferenceResolver: m.referenceResolver,
 	}).FetchRevision(ctx, ref, m.namespace)
 	if err != nil {
-		return repository.PackageResources{}, fmt.Errorf("failed to fetch package revision %q: %w", ref.Name, err)
+		return nil, fmt.Errorf("failed to fetch package revision %q: %w", ref.Name, err)
+	}
+
+	if m.sourcePolicyChecker != nil {
+		if err := m.sourcePolicyChecker.CheckRepositorySource(ctx, m.namespace, upstreamRevision.Key().RKey().Name); err != nil {
+			return nil, err
+		}
 	}
 
 	upstreamIsPlaceholder, err := repository.PackageRevisionIsPlaceholder(ctx, m.namespace, m.referenceResolver, upstreamRevision)
 	if err != nil {
-		return repository.PackageResources{}, pkgerrors.Wrap(err, "error checking for placeholder package revision")
+		return nil, pkgerrors.Wrap(err, "error checking for placeholder package revision")
 	}
 	if upstreamIsPlaceholder {
 		// We only allow clone to create new revisions from non-placeholder package revisions
-		return repository.PackageResources{}, fmt.Errorf("upstream revision may not be the placeholder package revision %s/%s", upstreamRevision.Key().RKey().Name, upstreamRevision.KubeObjectName())
+		return nil, fmt.Errorf("upstream revision may not be the placeholder package revision %s/%s", upstreamRevision.Key().RKey().Name, upstreamRevision.KubeObjectName())
 	}
 
+	return upstreamRevision, nil
+}
+
+// cloneRevision reads the resources of the upstream package revision and points the Kptfile at it.
+func (m *clonePackageMutation) cloneRevision(ctx context.Context, ref *porchapi.PackageRevisionRef, upstreamRevision repository.PackageRevision) (repository.PackageResources, error) {
 	resources, err := upstreamRevision.GetResources(ctx)
 	if err != nil {
 		return repository.PackageResources{}, fmt.Errorf("cannot read contents of package %q: %w", ref.Name, err)
@@ -151,6 +173,12 @@ func (m *clonePackageMutation) cloneFromGit(ctx context.Context, gitPackage *por
 	// TODO: Cache unregistered repositories with appropriate cache eviction policy.
 	// TODO: Separate low-level repository access from Repository abstraction?
 
+	if m.sourcePolicyChecker != nil {
+		if err := m.sourcePolicyChecker.CheckGitSource(ctx, m.namespace, gitPackage.Repo); err != nil {
+			return repository.PackageResources{}, err
+		}
+	}
+
 	spec := configapi.GitRepository{
 		Repo:      gitPackage.Repo,
 		Directory: gitPackage.Directory,
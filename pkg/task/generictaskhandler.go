@@ -30,6 +30,7 @@ import (
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
 	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
 	"github.com/kptdev/porch/pkg/repository"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
 	pkgerrors "github.com/pkg/errors"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/klog/v2"
@@ -47,6 +48,7 @@ type genericTaskHandler struct {
 	repoOpener                 repository.RepositoryOpener
 	credentialResolver         repository.CredentialResolver
 	referenceResolver          repository.ReferenceResolver
+	sourcePolicyChecker        repository.SourcePolicyChecker
 	repoOperationRetryAttempts int
 }
 
@@ -74,6 +76,10 @@ func (th *genericTaskHandler) SetReferenceResolver(referenceResolver repository.
 	th.referenceResolver = referenceResolver
 }
 
+func (th *genericTaskHandler) SetSourcePolicyChecker(sourcePolicyChecker repository.SourcePolicyChecker) {
+	th.sourcePolicyChecker = sourcePolicyChecker
+}
+
 func (th *genericTaskHandler) SetRepoOperationRetryAttempts(retryAttempts int) {
 	th.repoOperationRetryAttempts = retryAttempts
 }
@@ -199,11 +205,17 @@ func (th *genericTaskHandler) DoPRResourceMutations(
 	resources := repository.PackageResources{
 		Contents: prevResources.Spec.Resources,
 	}
+	hadConflicts := mergeconflict.HasCondition(resources.Contents)
 
 	appliedResources, _, err := mut.apply(ctx, resources)
 	if err != nil {
 		return nil, err
 	}
+	// Keep the MergeConflicts condition of a package upgraded with the manual-merge strategy in
+	// step with the conflicts resolved by editing its resources.
+	if err := mergeconflict.Refresh(appliedResources.Contents, hadConflicts); err != nil {
+		return nil, err
+	}
 
 	// Render the package
 	// Render failure will fail the overall API operation.
@@ -240,6 +252,49 @@ func (th *genericTaskHandler) DoPRResourceMutations(
 	return renderStatus, draft.UpdateResources(ctx, prr, &porchapi.Task{Type: porchapi.TaskTypeRender})
 }
 
+// RenderResources renders the given package resources in the kpt function pipeline
+// without saving them anywhere.
+func (th *genericTaskHandler) RenderResources(
+	ctx context.Context,
+	namespace string,
+	resources repository.PackageResources) (repository.PackageResources, *porchapi.RenderStatus, error) {
+	ctx, span := tracer.Start(ctx, "genericTaskHandler::RenderResources", trace.WithAttributes())
+	defer span.End()
+
+	var renderStatus *porchapi.RenderStatus
+	renderedResources, renderResult, err := th.renderMutation(namespace).apply(ctx, resources)
+	if renderResult != nil {
+		renderStatus = renderResult.RenderStatus
+	}
+	if err != nil {
+		return renderedResources, renderStatus, &RenderError{Err: err}
+	}
+	return renderedResources, renderStatus, nil
+}
+
+// PreviewUpgrade performs the given upgrade without saving the result and reports the outcome for
+// each resource of the package.
+func (th *genericTaskHandler) PreviewUpgrade(
+	ctx context.Context,
+	namespace string,
+	spec *porchapi.PackageUpgradeTaskSpec) ([]porchapi.UpgradePreviewResource, error) {
+	ctx, span := tracer.Start(ctx, "genericTaskHandler::PreviewUpgrade", trace.WithAttributes())
+	defer span.End()
+
+	mut := &upgradePackageMutation{
+		upgradeTask: &porchapi.Task{
+			Type:    porchapi.TaskTypeUpgrade,
+			Upgrade: spec,
+		},
+		namespace:           namespace,
+		repoOpener:          th.repoOpener,
+		referenceResolver:   th.referenceResolver,
+		sourcePolicyChecker: th.sourcePolicyChecker,
+		pkgName:             spec.LocalPackageRevisionRef.Name,
+	}
+	return mut.preview(ctx)
+}
+
 func (th *genericTaskHandler) applySubpackageTask(
 	ctx context.Context,
 	draft repository.PackageRevisionDraft,
@@ -293,7 +348,13 @@ func (th *genericTaskHandler) applySubpackageTask(
 	case porchapi.TaskTypeClone:
 		return th.insertSubpackageResourcesInDraftResources(ctx, subpackageDir, resources, subpackageResources)
 	case porchapi.TaskTypeUpgrade:
-		return th.upgradeSubpackageResourcesInDraftResources(ctx, subpackageDir, resources, subpackageResources)
+		if err := th.upgradeSubpackageResourcesInDraftResources(ctx, subpackageDir, resources, subpackageResources); err != nil {
+			return err
+		}
+		if taskResult.Task.Upgrade != nil && taskResult.Task.Upgrade.Strategy == porchapi.ManualMerge {
+			return mergeconflict.Refresh(resources.Contents, true)
+		}
+		return nil
 	default:
 		return fmt.Errorf("task of type %q not supported for subpackages", taskResult.Task.Type)
 	}
@@ -303,6 +364,7 @@ func (th *genericTaskHandler) renderMutation(namespace string) mutation {
 	return &renderPackageMutation{
 		runnerOptions: th.runnerOptionsResolver(namespace),
 		runtime:       th.runtime,
+		namespace:     namespace,
 	}
 }
 
@@ -316,10 +378,22 @@ func (th *genericTaskHandler) mapTaskToMutation(obj *porchapi.PackageRevision, t
 		if task.Init == nil {
 			return nil, fmt.Errorf("init not set for task of type %q", task.Type)
 		}
-		return &initPackageMutation{
+		initMutation := &initPackageMutation{
 			name: obj.Spec.PackageName,
 			task: task,
-		}, nil
+		}
+		if task.Init.Template != nil {
+			initMutation.templateSource = &clonePackageMutation{
+				namespace:                  obj.Namespace,
+				name:                       obj.Spec.PackageName,
+				repoOpener:                 th.repoOpener,
+				credentialResolver:         th.credentialResolver,
+				referenceResolver:          th.referenceResolver,
+				sourcePolicyChecker:        th.sourcePolicyChecker,
+				repoOperationRetryAttempts: th.repoOperationRetryAttempts,
+			}
+		}
+		return initMutation, nil
 	case porchapi.TaskTypeClone:
 		if task.Clone == nil {
 			return nil, fmt.Errorf("clone not set for task of type %q", task.Type)
@@ -332,6 +406,7 @@ func (th *genericTaskHandler) mapTaskToMutation(obj *porchapi.PackageRevision, t
 			repoOpener:                 th.repoOpener,
 			credentialResolver:         th.credentialResolver,
 			referenceResolver:          th.referenceResolver,
+			sourcePolicyChecker:        th.sourcePolicyChecker,
 			repoOperationRetryAttempts: th.repoOperationRetryAttempts,
 			packageConfig:              packageConfig,
 		}, nil
@@ -341,11 +416,12 @@ func (th *genericTaskHandler) mapTaskToMutation(obj *porchapi.PackageRevision, t
 			return nil, fmt.Errorf("upgrade field not set for task of type %q", task.Type)
 		}
 		return &upgradePackageMutation{
-			upgradeTask:       task,
-			namespace:         obj.Namespace,
-			repoOpener:        th.repoOpener,
-			referenceResolver: th.referenceResolver,
-			pkgName:           obj.Spec.PackageName,
+			upgradeTask:         task,
+			namespace:           obj.Namespace,
+			repoOpener:          th.repoOpener,
+			referenceResolver:   th.referenceResolver,
+			sourcePolicyChecker: th.sourcePolicyChecker,
+			pkgName:             obj.Spec.PackageName,
 		}, nil
 
 	case porchapi.TaskTypeEdit:
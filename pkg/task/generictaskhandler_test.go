@@ -707,6 +707,53 @@ pipeline:
 	})
 }
 
+func TestRenderResources(t *testing.T) {
+	th := &genericTaskHandler{
+		runnerOptionsResolver: func(namespace string) runneroptions.RunnerOptions {
+			return runneroptions.RunnerOptions{
+				ImagePullPolicy: runneroptions.IfNotPresentPull,
+				ResolveToImage: func(image string) string {
+					return image
+				},
+			}
+		},
+		runtime: NewSimpleFunctionRuntime(),
+	}
+
+	t.Run("Resources without pipeline", func(t *testing.T) {
+		resources := repository.PackageResources{
+			Contents: map[string]string{
+				"foo.txt": "bar",
+			},
+		}
+		rendered, renderStatus, err := th.RenderResources(context.TODO(), "default", resources)
+		require.NoError(t, err)
+		assert.NotNil(t, renderStatus)
+		assert.Equal(t, resources.Contents, rendered.Contents)
+	})
+
+	t.Run("Render failure", func(t *testing.T) {
+		resources := repository.PackageResources{
+			Contents: map[string]string{
+				"Kptfile": `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: test-pkg
+pipeline:
+  mutators:
+    - image: quay.io/invalid/nonexistent-fn:v0.0.1
+`,
+			},
+		}
+		_, renderStatus, err := th.RenderResources(context.TODO(), "default", resources)
+		require.Error(t, err)
+		require.NotNil(t, renderStatus)
+		assert.NotEmpty(t, renderStatus.Err)
+		var renderError *RenderError
+		assert.True(t, errors.As(err, &renderError))
+	})
+}
+
 func TestRenderError(t *testing.T) {
 	baseErr := errors.New("some base error")
 	wrappedErr := renderError(baseErr)
@@ -1,4 +1,4 @@
-// Copyright 2022, 2024 The kpt Authors
+// Copyright 2022, 2024, 2026 The kpt Authors
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -23,6 +23,7 @@ import (
 	"github.com/kptdev/kpt/pkg/printer/fake"
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
 	"github.com/kptdev/porch/pkg/repository"
+	"github.com/kptdev/porch/pkg/util/template"
 	"go.opentelemetry.io/otel/trace"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 )
@@ -31,6 +32,9 @@ type initPackageMutation struct {
 	kptpkg.DefaultInitializer
 	name string
 	task *porchapi.Task
+
+	// templateSource fetches the starter template when the init task specifies one.
+	templateSource *clonePackageMutation
 }
 
 var _ mutation = &initPackageMutation{}
@@ -39,6 +43,10 @@ func (m *initPackageMutation) apply(ctx context.Context, resources repository.Pa
 	ctx, span := tracer.Start(ctx, "initPackageMutation::apply", trace.WithAttributes())
 	defer span.End()
 
+	if m.task.Init.Template != nil {
+		return m.initFromTemplate(ctx)
+	}
+
 	fs := filesys.MakeFsInMemory()
 	// virtual fs expected a rooted filesystem
 	pkgPath := "/"
@@ -64,3 +72,52 @@ func (m *initPackageMutation) apply(ctx context.Context, resources repository.Pa
 
 	return result, &porchapi.TaskResult{Task: m.task}, nil
 }
+
+// initFromTemplate instantiates the starter template of the init task with the
+// supplied parameter values. The new package does not track the template as
+// its upstream.
+func (m *initPackageMutation) initFromTemplate(ctx context.Context) (repository.PackageResources, *porchapi.TaskResult, error) {
+	spec := m.task.Init.Template
+	if m.templateSource == nil {
+		return repository.PackageResources{}, nil, fmt.Errorf("cannot fetch template for pkg %q", m.name)
+	}
+
+	var fetched repository.PackageResources
+	var err error
+	if spec.UpstreamRef != nil {
+		fetched, err = m.fetchPublishedTemplate(ctx, spec.UpstreamRef)
+	} else if spec.Git != nil {
+		fetched, err = m.templateSource.cloneFromGit(ctx, spec.Git)
+	} else {
+		err = fmt.Errorf("invalid template source (neither of git nor upstreamRef were specified)")
+	}
+	if err != nil {
+		return repository.PackageResources{}, nil, err
+	}
+
+	resources, err := template.Instantiate(fetched.Contents, template.Options{
+		PackageName: m.name,
+		Description: m.task.Init.Description,
+		Keywords:    m.task.Init.Keywords,
+		Site:        m.task.Init.Site,
+		Values:      spec.Parameters,
+	})
+	if err != nil {
+		return repository.PackageResources{}, nil, fmt.Errorf("failed to instantiate template for pkg %q: %w", m.name, err)
+	}
+
+	return repository.PackageResources{Contents: resources}, &porchapi.TaskResult{Task: m.task}, nil
+}
+
+// fetchPublishedTemplate fetches a template from a registered repository. Only published package
+// revisions can be used as templates.
+func (m *initPackageMutation) fetchPublishedTemplate(ctx context.Context, ref *porchapi.PackageRevisionRef) (repository.PackageResources, error) {
+	templateRevision, err := m.templateSource.fetchRegisteredRevision(ctx, ref)
+	if err != nil {
+		return repository.PackageResources{}, err
+	}
+	if !porchapi.LifecycleIsPublished(templateRevision.Lifecycle(ctx)) {
+		return repository.PackageResources{}, fmt.Errorf("template %q must be published", ref.Name)
+	}
+	return m.templateSource.cloneRevision(ctx, ref, templateRevision)
+}
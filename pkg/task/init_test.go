@@ -1,4 +1,4 @@
-// Copyright 2022, 2024 The kpt Authors
+// Copyright 2022, 2024, 2026 The kpt Authors
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -22,7 +22,9 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/kptdev/porch/pkg/externalrepo/fake"
 	"github.com/kptdev/porch/pkg/repository"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInit(t *testing.T) {
@@ -66,3 +68,51 @@ func TestInit(t *testing.T) {
 	}
 
 }
+
+func TestInitFromTemplate(t *testing.T) {
+	init := &initPackageMutation{
+		name: "testpkg",
+		task: &porchapi.Task{
+			Init: &porchapi.PackageInitTaskSpec{
+				Description: "created from a template",
+				Template: &porchapi.PackageTemplate{
+					UpstreamRef: &porchapi.PackageRevisionRef{Name: packageName},
+				},
+			},
+		},
+		templateSource: &clonePackageMutation{
+			namespace:         repositoryNamespace,
+			name:              "testpkg",
+			referenceResolver: &fakeReferenceResolver{},
+			repoOpener:        repoOpener,
+		},
+	}
+
+	initializedPkg, _, err := init.apply(context.Background(), repository.PackageResources{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	kptfile := initializedPkg.Contents["Kptfile"]
+	assert.Contains(t, kptfile, "name: testpkg")
+	assert.Contains(t, kptfile, "description: created from a template")
+	assert.NotContains(t, kptfile, "upstream")
+	assert.NotContains(t, initializedPkg.Contents, "template-values.yaml")
+
+	init.task.Init.Template.Parameters = map[string]string{"replicas": "3"}
+	_, _, err = init.apply(context.Background(), repository.PackageResources{})
+	assert.ErrorContains(t, err, `template declares no parameter "replicas"`)
+
+	// Only published package revisions can be used as templates
+	draft := *packageRevision
+	draft.PackageLifecycle = porchapi.PackageRevisionLifecycleDraft
+	init.task.Init.Template.Parameters = nil
+	init.templateSource.repoOpener = &fakeRepositoryOpener{
+		repository: &fake.Repository{PackageRevisions: []repository.PackageRevision{&draft}},
+	}
+	_, _, err = init.apply(context.Background(), repository.PackageResources{})
+	assert.ErrorContains(t, err, `template "repo.1234567890.ws" must be published`)
+
+	init.task.Init.Template = &porchapi.PackageTemplate{}
+	_, _, err = init.apply(context.Background(), repository.PackageResources{})
+	assert.ErrorContains(t, err, "invalid template source")
+}
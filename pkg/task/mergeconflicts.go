@@ -0,0 +1,169 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"fmt"
+	"path"
+	"slices"
+
+	kptfilev1 "github.com/kptdev/kpt/api/kptfile/v1"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// MarkConflicts records, for the manual-merge strategy, the conflicts between the local package
+// and the new upstream in the resources merged by the resource-merge strategy. A conflicting field
+// of a KRM resource is set to the local value and recorded in the merge-conflicts annotation of the
+// resource. A resource which one side deleted and the other changed is kept and recorded as a
+// conflict about the whole resource. Files which do not hold KRM resources and which both sides
+// changed are merged by line, with conflict markers around the overlapping changes.
+func MarkConflicts(local, original, upstream, merged map[string]string) error {
+	l, o, u := newPreviewResources(local), newPreviewResources(original), newPreviewResources(upstream)
+	recorder := conflictRecorder{merged: merged, files: map[string][]*yaml.RNode{}}
+
+	seen := map[previewID]bool{}
+	for _, id := range slices.Concat(l.ids, u.ids) {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		fields, conflict := conflictingFields(l, o, u, id)
+		if !conflict {
+			continue
+		}
+		ours, theirs := l.resources[id], u.resources[id]
+		if ours == nil && theirs == nil {
+			contents, _ := mergeconflict.MergeLines(o.contents[id], l.contents[id], u.contents[id],
+				mergeconflict.OursLabel, mergeconflict.TheirsLabel)
+			merged[id.file] = contents
+			continue
+		}
+
+		if ours == nil || theirs == nil {
+			// One side deleted the resource and the other changed it: keep the changed version.
+			present := ""
+			c := mergeconflict.FieldConflict{Ours: &present}
+			kept := ours
+			if ours == nil {
+				c = mergeconflict.FieldConflict{Theirs: &present}
+				kept = theirs
+			}
+			node, err := recorder.resource(id, kept)
+			if err != nil {
+				return err
+			}
+			if err := mergeconflict.SetConflicts(node, []mergeconflict.FieldConflict{c}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		node, err := recorder.resource(id, ours)
+		if err != nil {
+			return err
+		}
+		var conflicts []mergeconflict.FieldConflict
+		for _, field := range fields {
+			if path.Base(id.file) == kptfilev1.KptFileName &&
+				(mergeconflict.FieldsOverlap(field, "upstream") || mergeconflict.FieldsOverlap(field, "upstreamLock")) {
+				// The upgrade sets the upstream of the package.
+				continue
+			}
+			c, err := fieldConflict(ours, theirs, field)
+			if err != nil {
+				return fmt.Errorf("%s: %w", id.file, err)
+			}
+			ourValue, err := mergeconflict.DecodeValue(c.Ours)
+			if err != nil {
+				return err
+			}
+			if err := mergeconflict.SetField(node, field, ourValue); err != nil {
+				return fmt.Errorf("%s: %w", id.file, err)
+			}
+			conflicts = append(conflicts, c)
+		}
+		if err := mergeconflict.SetConflicts(node, conflicts); err != nil {
+			return err
+		}
+	}
+	return recorder.write()
+}
+
+func fieldConflict(ours, theirs *yaml.RNode, field string) (mergeconflict.FieldConflict, error) {
+	c := mergeconflict.FieldConflict{Field: field}
+	for _, side := range []struct {
+		node  *yaml.RNode
+		value **string
+	}{{ours, &c.Ours}, {theirs, &c.Theirs}} {
+		v, err := mergeconflict.LookupField(side.node, field)
+		if err != nil {
+			return c, err
+		}
+		if *side.value, err = mergeconflict.EncodeValue(v); err != nil {
+			return c, err
+		}
+	}
+	return c, nil
+}
+
+// conflictRecorder holds the merged resources of the files in which conflicts are recorded.
+type conflictRecorder struct {
+	merged map[string]string
+	files  map[string][]*yaml.RNode
+}
+
+// resource returns the merged resource with the given identity, adding a copy of fallback to the
+// merged file if the merge dropped it.
+func (r *conflictRecorder) resource(id previewID, fallback *yaml.RNode) (*yaml.RNode, error) {
+	nodes, found := r.files[id.file]
+	if !found {
+		if contents, ok := r.merged[id.file]; ok {
+			var isKRM bool
+			if nodes, isKRM = mergeconflict.ReadNodes(id.file, contents); !isKRM {
+				return nil, fmt.Errorf("cannot read the merged resources in %s", id.file)
+			}
+		}
+	}
+
+	key := id
+	key.n = 0
+	n := 0
+	for _, node := range nodes {
+		if resourceID(id.file, node) != key {
+			continue
+		}
+		if n == id.n {
+			r.files[id.file] = nodes
+			return node, nil
+		}
+		n++
+	}
+	node := fallback.Copy()
+	r.files[id.file] = append(nodes, node)
+	return node, nil
+}
+
+func (r *conflictRecorder) write() error {
+	for file, nodes := range r.files {
+		contents, err := mergeconflict.WriteNodes(nodes)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		r.merged[file] = contents
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"testing"
+
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+func TestMarkConflicts(t *testing.T) {
+	deployment := func(replicas, image string) string {
+		return `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: ` + replicas + `
+  template:
+    spec:
+      containers:
+      - name: app
+        image: ` + image + `
+`
+	}
+	configMap := func(value string) string {
+		return "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  key: " + value + "\n"
+	}
+
+	local := map[string]string{
+		"deploy.yaml": deployment("3", "app:v1"),
+		"README.md":   "intro\nlocal\n",
+	}
+	original := map[string]string{
+		"deploy.yaml": deployment("1", "app:v1"),
+		"cm.yaml":     configMap("a"),
+		"README.md":   "intro\nbase\n",
+	}
+	upstream := map[string]string{
+		"deploy.yaml": deployment("5", "app:v2"),
+		"cm.yaml":     configMap("b"),
+		"README.md":   "intro\nupstream\n",
+	}
+	// What resource-merge produces: upstream wins the conflicting field and the deleted resource
+	// stays deleted.
+	merged := map[string]string{
+		"deploy.yaml": deployment("5", "app:v2"),
+		"README.md":   "intro\nupstream\n",
+	}
+
+	require.NoError(t, MarkConflicts(local, original, upstream, merged))
+
+	items, err := mergeconflict.List(merged)
+	require.NoError(t, err)
+	assert.Equal(t, []mergeconflict.Item{
+		{File: "README.md"},
+		{File: "cm.yaml", Kind: "ConfigMap", Name: "cm"},
+		{File: "deploy.yaml", Kind: "Deployment", Name: "app", Field: "spec.replicas"},
+	}, items)
+
+	assert.Equal(t, "3", field(t, merged["deploy.yaml"], "spec.replicas"), "conflicting field keeps the local value")
+	assert.Equal(t, "app:v2", field(t, merged["deploy.yaml"], "spec.template.spec.containers[name=app].image"))
+	assert.Equal(t, "b", field(t, merged["cm.yaml"], "data.key"), "resource deleted locally keeps the upstream change")
+	assert.True(t, mergeconflict.HasMarkers(merged["README.md"]))
+
+	resolved, err := mergeconflict.Resolve(merged, mergeconflict.Selector{}, mergeconflict.Theirs)
+	require.NoError(t, err)
+	assert.Equal(t, 3, resolved)
+	assert.Equal(t, "5", field(t, merged["deploy.yaml"], "spec.replicas"))
+	assert.Equal(t, "intro\nupstream\n", merged["README.md"])
+	assert.Contains(t, merged, "cm.yaml")
+
+	items, err = mergeconflict.List(merged)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestMarkConflictsNone(t *testing.T) {
+	local := map[string]string{"cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  a: local\n  b: base\n"}
+	original := map[string]string{"cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  a: base\n  b: base\n"}
+	upstream := map[string]string{"cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  a: base\n  b: upstream\n"}
+	merged := map[string]string{"cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  a: local\n  b: upstream\n"}
+	want := merged["cm.yaml"]
+
+	require.NoError(t, MarkConflicts(local, original, upstream, merged))
+	assert.Equal(t, want, merged["cm.yaml"])
+}
+
+func field(t *testing.T, contents, path string) string {
+	t.Helper()
+	node, err := yaml.Parse(contents)
+	require.NoError(t, err)
+	value, err := mergeconflict.LookupField(node, path)
+	require.NoError(t, err)
+	require.NotNil(t, value, "field %s", path)
+	return value.Value
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/kptdev/kpt/pkg/lib/runneroptions"
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
 	"github.com/kptdev/porch/pkg/repository"
+	pctx "github.com/kptdev/porch/pkg/util/context"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
@@ -35,6 +36,8 @@ import (
 type renderPackageMutation struct {
 	runtime       fn.FunctionRuntime
 	runnerOptions runneroptions.RunnerOptions
+	// namespace of the package revision, passed to the function runtime
+	namespace string
 }
 
 var _ mutation = &renderPackageMutation{}
@@ -42,6 +45,9 @@ var _ mutation = &renderPackageMutation{}
 func (m *renderPackageMutation) apply(ctx context.Context, resources repository.PackageResources) (repository.PackageResources, *porchapi.TaskResult, error) {
 	ctx, span := tracer.Start(ctx, "renderPackageMutation::apply", trace.WithAttributes())
 	defer span.End()
+	if m.namespace != "" {
+		ctx = pctx.WithNamespace(ctx, m.namespace)
+	}
 
 	fs := filesys.MakeFsInMemory()
 	taskResult := &porchapi.TaskResult{
@@ -36,11 +36,14 @@ type TaskHandler interface {
 	SetRepoOpener(repository.RepositoryOpener)
 	SetCredentialResolver(repository.CredentialResolver)
 	SetReferenceResolver(repository.ReferenceResolver)
+	SetSourcePolicyChecker(repository.SourcePolicyChecker)
 	SetRepoOperationRetryAttempts(int)
 
 	ApplyTask(ctx context.Context, draft repository.PackageRevisionDraft, repositoryObj *configapi.Repository, obj *porchapi.PackageRevision, packageConfig *builtintypes.PackageConfig) error
 	DoPRMutations(ctx context.Context, repoPR repository.PackageRevision, oldObj *porchapi.PackageRevision, newObj *porchapi.PackageRevision, draft repository.PackageRevisionDraft) error
 	DoPRResourceMutations(ctx context.Context, pr2Update repository.PackageRevision, draft repository.PackageRevisionDraft, oldRes, newRes *porchapi.PackageRevisionResources) (*porchapi.RenderStatus, error)
+	RenderResources(ctx context.Context, namespace string, resources repository.PackageResources) (repository.PackageResources, *porchapi.RenderStatus, error)
+	PreviewUpgrade(ctx context.Context, namespace string, spec *porchapi.PackageUpgradeTaskSpec) ([]porchapi.UpgradePreviewResource, error)
 }
 
 type mutation interface {
@@ -22,6 +22,7 @@ import (
 	"github.com/kptdev/kpt/pkg/lib/kptops"
 	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
 	"github.com/kptdev/porch/pkg/repository"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
 	pkgerrors "github.com/pkg/errors"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/klog/v2"
@@ -30,17 +31,71 @@ import (
 var _ mutation = &upgradePackageMutation{}
 
 type upgradePackageMutation struct {
-	upgradeTask       *porchapi.Task
-	repoOpener        repository.RepositoryOpener
-	referenceResolver repository.ReferenceResolver
-	namespace         string
-	pkgName           string
+	upgradeTask         *porchapi.Task
+	repoOpener          repository.RepositoryOpener
+	referenceResolver   repository.ReferenceResolver
+	sourcePolicyChecker repository.SourcePolicyChecker
+	namespace           string
+	pkgName             string
+}
+
+// upgradeInputs holds the resources an upgrade merges: the local package, the upstream it was
+// cloned from and the upstream it is upgraded to.
+type upgradeInputs struct {
+	local                  map[string]string
+	original               map[string]string
+	upstream               map[string]string
+	targetUpstreamRevision repository.PackageRevision
 }
 
 func (m *upgradePackageMutation) apply(ctx context.Context, _ repository.PackageResources) (repository.PackageResources, *porchapi.TaskResult, error) {
 	ctx, span := tracer.Start(ctx, "upgradePackageMutation::apply", trace.WithAttributes())
 	defer span.End()
 
+	in, err := m.fetch(ctx)
+	if err != nil {
+		return repository.PackageResources{}, nil, err
+	}
+
+	updatedResources, err := m.merge(ctx, in)
+	if err != nil {
+		return repository.PackageResources{}, nil, err
+	}
+
+	// ensure merge-key comment is added to newly added resources.
+	result, err := ensureMergeKey(ctx, updatedResources)
+	if err != nil {
+		klog.Infof("failed to add merge key comments: %v", err)
+	}
+	return result, &porchapi.TaskResult{Task: m.upgradeTask}, nil
+}
+
+// preview performs the upgrade without storing the result and reports the outcome for each resource
+// of the package.
+func (m *upgradePackageMutation) preview(ctx context.Context) ([]porchapi.UpgradePreviewResource, error) {
+	ctx, span := tracer.Start(ctx, "upgradePackageMutation::preview", trace.WithAttributes())
+	defer span.End()
+
+	in, err := m.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedResources, err := m.merge(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := upgradePreview(in.local, in.original, in.upstream, updatedResources.Contents)
+	if subpackageDir := m.upgradeTask.Upgrade.SubpackageDir; subpackageDir != "" {
+		for i := range resources {
+			resources[i].File = subpackageDir + "/" + resources[i].File
+		}
+	}
+	return resources, nil
+}
+
+func (m *upgradePackageMutation) fetch(ctx context.Context) (*upgradeInputs, error) {
 	currUpstreamPkgRef := m.upgradeTask.Upgrade.OldUpstream
 	targetUpstreamRef := m.upgradeTask.Upgrade.NewUpstream
 	localRef := m.upgradeTask.Upgrade.LocalPackageRevisionRef
@@ -52,46 +107,52 @@ func (m *upgradePackageMutation) apply(ctx context.Context, _ repository.Package
 
 	currUpstreamResources, err := packageFetcher.FetchResources(ctx, &currUpstreamPkgRef, m.namespace)
 	if err != nil {
-		return repository.PackageResources{}, nil, pkgerrors.Wrapf(err, "error fetching the resources for package %q with ref %+v",
+		return nil, pkgerrors.Wrapf(err, "error fetching the resources for package %q with ref %+v",
 			m.pkgName, currUpstreamPkgRef)
 	}
 
 	targetUpstreamRevision, err := packageFetcher.FetchRevision(ctx, &targetUpstreamRef, m.namespace)
 	if err != nil {
-		return repository.PackageResources{}, nil, pkgerrors.Wrapf(err, "error fetching revision for target upstream %q", targetUpstreamRef.Name)
+		return nil, pkgerrors.Wrapf(err, "error fetching revision for target upstream %q", targetUpstreamRef.Name)
+	}
+
+	if m.sourcePolicyChecker != nil {
+		if err := m.sourcePolicyChecker.CheckRepositorySource(ctx, m.namespace, targetUpstreamRevision.Key().RKey().Name); err != nil {
+			return nil, err
+		}
 	}
 
 	targetUpstreamIsPlaceholder, err := repository.PackageRevisionIsPlaceholder(ctx, m.namespace, m.referenceResolver, targetUpstreamRevision)
 	if err != nil {
-		return repository.PackageResources{}, nil, pkgerrors.Wrap(err, "error checking for placeholder package revision")
+		return nil, pkgerrors.Wrap(err, "error checking for placeholder package revision")
 	}
 	if targetUpstreamIsPlaceholder {
 		// We only allow upgrade to create new revisions with non-placeholder package revisions as target upstream
-		return repository.PackageResources{}, nil, fmt.Errorf("target upstream revision may not be the placeholder package revision %s/%s", targetUpstreamRevision.Key().RKey().Name, targetUpstreamRevision.KubeObjectName())
+		return nil, fmt.Errorf("target upstream revision may not be the placeholder package revision %s/%s", targetUpstreamRevision.Key().RKey().Name, targetUpstreamRevision.KubeObjectName())
 	}
 
 	targetUpstreamResources, err := targetUpstreamRevision.GetResources(ctx)
 	if err != nil {
-		return repository.PackageResources{}, nil, pkgerrors.Wrapf(err, "error fetching resources for target upstream %q", targetUpstreamRef.Name)
+		return nil, pkgerrors.Wrapf(err, "error fetching resources for target upstream %q", targetUpstreamRef.Name)
 	}
 
 	localRevision, err := packageFetcher.FetchRevision(ctx, &localRef, m.namespace)
 	if err != nil {
-		return repository.PackageResources{}, nil, pkgerrors.Wrapf(err, "error fetching revision %q to be upgraded", localRef.Name)
+		return nil, pkgerrors.Wrapf(err, "error fetching revision %q to be upgraded", localRef.Name)
 	}
 
 	localIsPlaceholder, err := repository.PackageRevisionIsPlaceholder(ctx, m.namespace, m.referenceResolver, localRevision)
 	if err != nil {
-		return repository.PackageResources{}, nil, pkgerrors.Wrap(err, "error checking for placeholder package revision")
+		return nil, pkgerrors.Wrap(err, "error checking for placeholder package revision")
 	}
 	if localIsPlaceholder {
 		// We only allow upgrade to upgrade non-placeholder package revisions
-		return repository.PackageResources{}, nil, fmt.Errorf("source revision may not be the placeholder package revision %s/%s", localRevision.Key().RKey().Name, localRevision.KubeObjectName())
+		return nil, fmt.Errorf("source revision may not be the placeholder package revision %s/%s", localRevision.Key().RKey().Name, localRevision.KubeObjectName())
 	}
 
 	localResources, err := localRevision.GetResources(ctx)
 	if err != nil {
-		return repository.PackageResources{}, nil, pkgerrors.Wrapf(err, "error fetching resources for local revision %q", localRef.Name)
+		return nil, pkgerrors.Wrapf(err, "error fetching resources for local revision %q", localRef.Name)
 	}
 
 	if m.upgradeTask.Upgrade.SubpackageDir != "" {
@@ -104,47 +165,70 @@ func (m *upgradePackageMutation) apply(ctx context.Context, _ repository.Package
 		}
 
 		if len(subpackageLocalResources) == 0 {
-			return repository.PackageResources{}, nil, fmt.Errorf("subpackage %q not found in package %q", m.upgradeTask.Upgrade.SubpackageDir, localRef.Name)
+			return nil, fmt.Errorf("subpackage %q not found in package %q", m.upgradeTask.Upgrade.SubpackageDir, localRef.Name)
 		}
 
 		if _, ok := subpackageLocalResources["Kptfile"]; !ok {
-			return repository.PackageResources{}, nil, fmt.Errorf("subpackage %q in package %q is missing Kptfile", m.upgradeTask.Upgrade.SubpackageDir, localRef.Name)
+			return nil, fmt.Errorf("subpackage %q in package %q is missing Kptfile", m.upgradeTask.Upgrade.SubpackageDir, localRef.Name)
 		}
 
 		localResources.Spec.Resources = subpackageLocalResources
 	}
 
+	return &upgradeInputs{
+		local:                  localResources.Spec.Resources,
+		original:               currUpstreamResources.Spec.Resources,
+		upstream:               targetUpstreamResources.Spec.Resources,
+		targetUpstreamRevision: targetUpstreamRevision,
+	}, nil
+}
+
+func (m *upgradePackageMutation) merge(ctx context.Context, in *upgradeInputs) (repository.PackageResources, error) {
+	targetUpstreamRef := m.upgradeTask.Upgrade.NewUpstream
+
 	klog.Infof("performing pkg upgrade operation for pkg %s resource counts local[%d] original[%d] upstream[%d]",
-		m.pkgName, len(localResources.Spec.Resources), len(currUpstreamResources.Spec.Resources), len(targetUpstreamResources.Spec.Resources))
+		m.pkgName, len(in.local), len(in.original), len(in.upstream))
+
+	// The manual-merge strategy merges like resource-merge and then records the conflicts.
+	strategy := m.upgradeTask.Upgrade.Strategy
+	manual := strategy == porchapi.ManualMerge
+	if manual {
+		strategy = porchapi.ResourceMerge
+	}
 
 	//TODO: May be have packageUpdater part of the Porch core to make it easy for testing ?
 	updatedResources, err := (&repository.DefaultPackageUpdater{}).Update(ctx,
 		repository.PackageResources{
-			Contents: localResources.Spec.Resources,
+			Contents: in.local,
 		},
 		repository.PackageResources{
-			Contents: currUpstreamResources.Spec.Resources,
+			Contents: in.original,
 		},
 		repository.PackageResources{
-			Contents: targetUpstreamResources.Spec.Resources,
+			Contents: in.upstream,
 		},
-		string(m.upgradeTask.Upgrade.Strategy))
+		string(strategy))
 	if err != nil {
-		return repository.PackageResources{}, nil, pkgerrors.Wrapf(err, "error updating the package %q to revision %q", m.pkgName, targetUpstreamRef.Name)
+		return repository.PackageResources{}, pkgerrors.Wrapf(err, "error updating the package %q to revision %q", m.pkgName, targetUpstreamRef.Name)
+	}
+	if manual {
+		if err := MarkConflicts(in.local, in.original, in.upstream, updatedResources.Contents); err != nil {
+			return repository.PackageResources{}, pkgerrors.Wrapf(err, "failed to record merge conflicts in package %q", m.pkgName)
+		}
 	}
 
-	newUpstream, newUpstreamLock, err := targetUpstreamRevision.GetLock(ctx)
+	newUpstream, newUpstreamLock, err := in.targetUpstreamRevision.GetLock(ctx)
 	if err != nil {
-		return repository.PackageResources{}, nil, pkgerrors.Wrapf(err, "error fetching the resources for package revision %q", targetUpstreamRef.Name)
+		return repository.PackageResources{}, pkgerrors.Wrapf(err, "error fetching the resources for package revision %q", targetUpstreamRef.Name)
 	}
 	if err := kptops.UpdateKptfileUpstream("", updatedResources.Contents, newUpstream, newUpstreamLock); err != nil {
-		return repository.PackageResources{}, nil, pkgerrors.Wrapf(err, "failed to apply upstream lock to package %q", m.pkgName)
+		return repository.PackageResources{}, pkgerrors.Wrapf(err, "failed to apply upstream lock to package %q", m.pkgName)
 	}
-
-	// ensure merge-key comment is added to newly added resources.
-	result, err := ensureMergeKey(ctx, updatedResources)
-	if err != nil {
-		klog.Infof("failed to add merge key comments: %v", err)
+	if manual && m.upgradeTask.Upgrade.SubpackageDir == "" {
+		// The condition of a subpackage upgrade is set in the Kptfile of the parent package.
+		if err := mergeconflict.Refresh(updatedResources.Contents, true); err != nil {
+			return repository.PackageResources{}, pkgerrors.Wrapf(err, "failed to set the %s condition of package %q", mergeconflict.ConditionType, m.pkgName)
+		}
 	}
-	return result, &porchapi.TaskResult{Task: m.upgradeTask}, nil
+	return updatedResources, nil
 }
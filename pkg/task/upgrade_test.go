@@ -354,6 +354,17 @@ info:
 		assert.NotEmpty(t, result.Contents["subpkg-resource.yaml"])
 		assert.NotContains(t, result.Contents, "root-resource.yaml")
 		assert.NotContains(t, result.Contents, "my-subpkg/subpkg-resource.yaml")
+
+		// The preview reports the resources of the subpackage at their paths in the package
+		preview, err := mutation.preview(context.Background())
+		assert.NoError(t, err)
+		outcomes := map[string]porchapi.UpgradeOutcome{}
+		for _, r := range preview {
+			outcomes[r.File+":"+r.Name] = r.Outcome
+		}
+		assert.Equal(t, porchapi.UpgradeOutcomeUpstream, outcomes["my-subpkg/new-resource.yaml:new-cm"])
+		assert.Equal(t, porchapi.UpgradeOutcomeLocal, outcomes["my-subpkg/subpkg-resource.yaml:subpkg-cm"])
+		assert.NotContains(t, outcomes, "root-resource.yaml:root-cm")
 	})
 
 	t.Run("Error when SubpackageDir has no trailing content match", func(t *testing.T) {
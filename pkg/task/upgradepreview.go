@@ -0,0 +1,328 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/kptdev/porch/pkg/util/mergeconflict"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// previewID identifies a resource of a package in an upgrade preview. Files which do not hold KRM
+// resources are identified by the file alone.
+type previewID struct {
+	file      string
+	group     string
+	kind      string
+	namespace string
+	name      string
+	// n tells apart resources with the same identity in the same file.
+	n int
+}
+
+// previewResources holds the resources of a package for an upgrade preview. Resources are nil for
+// files which do not hold KRM resources, whose contents are compared as a whole.
+type previewResources struct {
+	ids        []previewID
+	resources  map[previewID]*yaml.RNode
+	contents   map[previewID]string
+	apiVersion map[previewID]string
+}
+
+func newPreviewResources(contents map[string]string) previewResources {
+	r := previewResources{
+		resources:  map[previewID]*yaml.RNode{},
+		contents:   map[previewID]string{},
+		apiVersion: map[previewID]string{},
+	}
+	files := make([]string, 0, len(contents))
+	for file := range contents {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		nodes, isKRM := mergeconflict.ReadNodes(file, contents[file])
+		if !isKRM || nodes == nil {
+			id := previewID{file: file}
+			r.ids = append(r.ids, id)
+			r.contents[id] = contents[file]
+			continue
+		}
+		seen := map[previewID]int{}
+		for _, node := range nodes {
+			key := resourceID(file, node)
+			id := key
+			id.n = seen[key]
+			seen[key]++
+			r.ids = append(r.ids, id)
+			r.resources[id] = node
+			r.apiVersion[id] = node.GetApiVersion()
+		}
+	}
+	return r
+}
+
+// resourceID returns the identity of a KRM resource in a file, without telling apart resources
+// with the same identity.
+func resourceID(file string, node *yaml.RNode) previewID {
+	group, _, found := strings.Cut(node.GetApiVersion(), "/")
+	if !found {
+		group = ""
+	}
+	return previewID{
+		file:      file,
+		group:     group,
+		kind:      node.GetKind(),
+		namespace: node.GetNamespace(),
+		name:      node.GetName(),
+	}
+}
+
+func (r previewResources) has(id previewID) bool {
+	if _, ok := r.resources[id]; ok {
+		return true
+	}
+	_, ok := r.contents[id]
+	return ok
+}
+
+// changedFields returns whether the resource differs between a and b and, if it is in both, the
+// paths of the fields that differ.
+func changedFields(a, b previewResources, id previewID) ([]string, bool) {
+	if a.has(id) != b.has(id) {
+		return nil, true
+	}
+	if !a.has(id) {
+		return nil, false
+	}
+	if _, ok := a.resources[id]; !ok {
+		return nil, a.contents[id] != b.contents[id]
+	}
+	var fields []string
+	diffNodes(a.resources[id].YNode(), b.resources[id].YNode(), "", &fields)
+	return fields, len(fields) > 0
+}
+
+// upgradePreview returns the outcome of an upgrade for each resource of the package, given the
+// resources of the local package, the old upstream, the new upstream and the upgraded package.
+func upgradePreview(local, original, upstream, upgraded map[string]string) []porchapi.UpgradePreviewResource {
+	l, o, u, r := newPreviewResources(local), newPreviewResources(original),
+		newPreviewResources(upstream), newPreviewResources(upgraded)
+
+	var ids []previewID
+	seen := map[previewID]bool{}
+	for _, id := range slices.Concat(l.ids, u.ids, r.ids) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		return ids[i].file < ids[j].file
+	})
+
+	results := make([]porchapi.UpgradePreviewResource, 0, len(ids))
+	for _, id := range ids {
+		result := porchapi.UpgradePreviewResource{
+			File:      id.file,
+			Kind:      id.kind,
+			Namespace: id.namespace,
+			Name:      id.name,
+		}
+		for _, res := range []previewResources{r, u, l} {
+			if v, ok := res.apiVersion[id]; ok {
+				result.APIVersion = v
+				break
+			}
+		}
+
+		if conflicts, ok := conflictingFields(l, o, u, id); ok {
+			result.Outcome = porchapi.UpgradeOutcomeConflict
+			result.Fields = conflicts
+			results = append(results, result)
+			continue
+		}
+
+		fields, localChanged := changedFields(l, r, id)
+		_, upstreamChanged := changedFields(u, r, id)
+		result.Fields = fields
+		switch {
+		case !localChanged && !upstreamChanged:
+			result.Outcome = porchapi.UpgradeOutcomeUnchanged
+		case !localChanged:
+			result.Outcome = porchapi.UpgradeOutcomeLocal
+		case !upstreamChanged:
+			result.Outcome = porchapi.UpgradeOutcomeUpstream
+		default:
+			result.Outcome = porchapi.UpgradeOutcomeMerged
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// conflictingFields reports whether the local package and the new upstream changed a resource of
+// the old upstream in conflicting ways, and returns the paths of the fields they changed
+// differently.
+func conflictingFields(l, o, u previewResources, id previewID) ([]string, bool) {
+	localFields, localChanged := changedFields(o, l, id)
+	upstreamFields, upstreamChanged := changedFields(o, u, id)
+	if !localChanged || !upstreamChanged {
+		return nil, false
+	}
+	if l.has(id) != u.has(id) {
+		// One side deleted the resource, the other changed it.
+		return nil, true
+	}
+	differentFields, different := changedFields(l, u, id)
+	if !different {
+		return nil, false
+	}
+	if _, ok := l.resources[id]; !ok {
+		// Both sides changed a file which does not hold KRM resources.
+		return nil, true
+	}
+	var conflicts []string
+	for _, f := range differentFields {
+		if overlapsAny(f, localFields) && overlapsAny(f, upstreamFields) {
+			conflicts = append(conflicts, f)
+		}
+	}
+	return conflicts, len(conflicts) > 0
+}
+
+func overlapsAny(field string, fields []string) bool {
+	for _, f := range fields {
+		if mergeconflict.FieldsOverlap(field, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// diffNodes appends the paths of the fields that differ between a and b to fields. Elements of
+// sequences of mappings with a name are matched by name, other sequences are compared as a whole.
+func diffNodes(a, b *yaml.Node, fieldPath string, fields *[]string) {
+	switch {
+	case a == nil && b == nil:
+		return
+	case a == nil || b == nil || a.Kind != b.Kind:
+		*fields = append(*fields, fieldPath)
+		return
+	}
+
+	switch a.Kind {
+	case yaml.DocumentNode:
+		if len(a.Content) != len(b.Content) {
+			*fields = append(*fields, fieldPath)
+			return
+		}
+		for i := range a.Content {
+			diffNodes(a.Content[i], b.Content[i], fieldPath, fields)
+		}
+	case yaml.MappingNode:
+		for _, key := range mappingKeys(a, b) {
+			diffNodes(mappingValue(a, key), mappingValue(b, key), mergeconflict.JoinField(fieldPath, key), fields)
+		}
+	case yaml.SequenceNode:
+		aNames, aOK := sequenceNames(a)
+		bNames, bOK := sequenceNames(b)
+		if !aOK || !bOK {
+			if !nodesEqual(a, b) {
+				*fields = append(*fields, fieldPath)
+			}
+			return
+		}
+		for _, name := range mergeNames(aNames, bNames) {
+			diffNodes(sequenceElement(a, name), sequenceElement(b, name),
+				mergeconflict.ElementField(fieldPath, name), fields)
+		}
+	case yaml.AliasNode:
+		diffNodes(a.Alias, b.Alias, fieldPath, fields)
+	default:
+		if a.Value != b.Value {
+			*fields = append(*fields, fieldPath)
+		}
+	}
+}
+
+// mappingKeys returns the keys of a followed by the keys only in b.
+func mappingKeys(a, b *yaml.Node) []string {
+	var keys []string
+	for _, n := range []*yaml.Node{a, b} {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if !slices.Contains(keys, n.Content[i].Value) {
+				keys = append(keys, n.Content[i].Value)
+			}
+		}
+	}
+	return keys
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sequenceNames returns the names of the elements of a sequence, and false if not all of them are
+// mappings with a unique name.
+func sequenceNames(n *yaml.Node) ([]string, bool) {
+	names := make([]string, 0, len(n.Content))
+	for _, e := range n.Content {
+		if e.Kind != yaml.MappingNode {
+			return nil, false
+		}
+		name := mappingValue(e, "name")
+		if name == nil || name.Kind != yaml.ScalarNode || slices.Contains(names, name.Value) {
+			return nil, false
+		}
+		names = append(names, name.Value)
+	}
+	return names, true
+}
+
+func sequenceElement(n *yaml.Node, name string) *yaml.Node {
+	for _, e := range n.Content {
+		if v := mappingValue(e, "name"); v != nil && v.Value == name {
+			return e
+		}
+	}
+	return nil
+}
+
+func mergeNames(a, b []string) []string {
+	names := slices.Clone(a)
+	for _, name := range b {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func nodesEqual(a, b *yaml.Node) bool {
+	var fields []string
+	diffNodes(a, b, "", &fields)
+	return len(fields) == 0
+}
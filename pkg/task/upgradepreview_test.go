@@ -0,0 +1,163 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgradePreview(t *testing.T) {
+	deployment := func(replicas, image string) string {
+		return `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: ` + replicas + `
+  template:
+    spec:
+      containers:
+      - name: app
+        image: ` + image + `
+`
+	}
+	configMap := func(name, value string) string {
+		return `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ` + name + `
+data:
+  key: ` + value + `
+`
+	}
+
+	testCases := map[string]struct {
+		local    map[string]string
+		original map[string]string
+		upstream map[string]string
+		upgraded map[string]string
+		want     []porchapi.UpgradePreviewResource
+	}{
+		"unchanged": {
+			local:    map[string]string{"cm.yaml": configMap("cm", "a")},
+			original: map[string]string{"cm.yaml": configMap("cm", "a")},
+			upstream: map[string]string{"cm.yaml": configMap("cm", "a")},
+			upgraded: map[string]string{"cm.yaml": configMap("cm", "a")},
+			want: []porchapi.UpgradePreviewResource{{
+				File: "cm.yaml", APIVersion: "v1", Kind: "ConfigMap", Name: "cm",
+				Outcome: porchapi.UpgradeOutcomeUnchanged,
+			}},
+		},
+		"taken from upstream": {
+			local:    map[string]string{"cm.yaml": configMap("cm", "a")},
+			original: map[string]string{"cm.yaml": configMap("cm", "a")},
+			upstream: map[string]string{"cm.yaml": configMap("cm", "b")},
+			upgraded: map[string]string{"cm.yaml": configMap("cm", "b")},
+			want: []porchapi.UpgradePreviewResource{{
+				File: "cm.yaml", APIVersion: "v1", Kind: "ConfigMap", Name: "cm",
+				Outcome: porchapi.UpgradeOutcomeUpstream,
+				Fields:  []string{"data.key"},
+			}},
+		},
+		"kept local": {
+			local:    map[string]string{"cm.yaml": configMap("cm", "b")},
+			original: map[string]string{"cm.yaml": configMap("cm", "a")},
+			upstream: map[string]string{"cm.yaml": configMap("cm", "a")},
+			upgraded: map[string]string{"cm.yaml": configMap("cm", "b")},
+			want: []porchapi.UpgradePreviewResource{{
+				File: "cm.yaml", APIVersion: "v1", Kind: "ConfigMap", Name: "cm",
+				Outcome: porchapi.UpgradeOutcomeLocal,
+			}},
+		},
+		"merged": {
+			local:    map[string]string{"deploy.yaml": deployment("3", "app:v1")},
+			original: map[string]string{"deploy.yaml": deployment("1", "app:v1")},
+			upstream: map[string]string{"deploy.yaml": deployment("1", "app:v2")},
+			upgraded: map[string]string{"deploy.yaml": deployment("3", "app:v2")},
+			want: []porchapi.UpgradePreviewResource{{
+				File: "deploy.yaml", APIVersion: "apps/v1", Kind: "Deployment", Name: "app",
+				Outcome: porchapi.UpgradeOutcomeMerged,
+				Fields:  []string{"spec.template.spec.containers[name=app].image"},
+			}},
+		},
+		"conflicting field": {
+			local:    map[string]string{"deploy.yaml": deployment("3", "app:v1")},
+			original: map[string]string{"deploy.yaml": deployment("1", "app:v1")},
+			upstream: map[string]string{"deploy.yaml": deployment("5", "app:v1")},
+			upgraded: map[string]string{"deploy.yaml": deployment("5", "app:v1")},
+			want: []porchapi.UpgradePreviewResource{{
+				File: "deploy.yaml", APIVersion: "apps/v1", Kind: "Deployment", Name: "app",
+				Outcome: porchapi.UpgradeOutcomeConflict,
+				Fields:  []string{"spec.replicas"},
+			}},
+		},
+		"same change on both sides": {
+			local:    map[string]string{"cm.yaml": configMap("cm", "b")},
+			original: map[string]string{"cm.yaml": configMap("cm", "a")},
+			upstream: map[string]string{"cm.yaml": configMap("cm", "b")},
+			upgraded: map[string]string{"cm.yaml": configMap("cm", "b")},
+			want: []porchapi.UpgradePreviewResource{{
+				File: "cm.yaml", APIVersion: "v1", Kind: "ConfigMap", Name: "cm",
+				Outcome: porchapi.UpgradeOutcomeUnchanged,
+			}},
+		},
+		"deleted locally and changed upstream": {
+			local:    map[string]string{},
+			original: map[string]string{"cm.yaml": configMap("cm", "a")},
+			upstream: map[string]string{"cm.yaml": configMap("cm", "b")},
+			upgraded: map[string]string{"cm.yaml": configMap("cm", "b")},
+			want: []porchapi.UpgradePreviewResource{{
+				File: "cm.yaml", APIVersion: "v1", Kind: "ConfigMap", Name: "cm",
+				Outcome: porchapi.UpgradeOutcomeConflict,
+			}},
+		},
+		"added upstream and deleted upstream": {
+			local:    map[string]string{"old.yaml": configMap("old", "a")},
+			original: map[string]string{"old.yaml": configMap("old", "a")},
+			upstream: map[string]string{"new.yaml": configMap("new", "a")},
+			upgraded: map[string]string{"new.yaml": configMap("new", "a")},
+			want: []porchapi.UpgradePreviewResource{
+				{
+					File: "new.yaml", APIVersion: "v1", Kind: "ConfigMap", Name: "new",
+					Outcome: porchapi.UpgradeOutcomeUpstream,
+				},
+				{
+					File: "old.yaml", APIVersion: "v1", Kind: "ConfigMap", Name: "old",
+					Outcome: porchapi.UpgradeOutcomeUpstream,
+				},
+			},
+		},
+		"non-KRM file changed on both sides": {
+			local:    map[string]string{"README.md": "local"},
+			original: map[string]string{"README.md": "original"},
+			upstream: map[string]string{"README.md": "upstream"},
+			upgraded: map[string]string{"README.md": "local"},
+			want: []porchapi.UpgradePreviewResource{{
+				File:    "README.md",
+				Outcome: porchapi.UpgradeOutcomeConflict,
+			}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := upgradePreview(tc.local, tc.original, tc.upstream, tc.upgraded)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -26,6 +26,8 @@ type porchContextKey string
 const (
 	requestIDKey       porchContextKey = "requestID"
 	packageRevisionKey porchContextKey = "packageRevision"
+	namespaceKey       porchContextKey = "namespace"
+	priorityKey        porchContextKey = "priority"
 
 	EmptyPRName = "<undefined>"
 )
@@ -61,6 +63,24 @@ func WithPackageRevision(ctx context.Context, prName string) context.Context {
 	return context.WithValue(ctx, packageRevisionKey, prName)
 }
 
+// GetNamespace returns the namespace of the package revision the request operates on, or "" if it is not known.
+func GetNamespace(ctx context.Context) string {
+	return getter(ctx, namespaceKey, "")
+}
+
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceKey, namespace)
+}
+
+// GetPriority returns the priority of the function evaluations of the request, 0 if it is not set.
+func GetPriority(ctx context.Context) int32 {
+	return getter(ctx, priorityKey, int32(0))
+}
+
+func WithPriority(ctx context.Context, priority int32) context.Context {
+	return context.WithValue(ctx, priorityKey, priority)
+}
+
 func WithNewRequestIDAndPackageRevision(ctx context.Context, prName string) context.Context {
 	return WithPackageRevision(WithNewRequestID(ctx), prName)
 }
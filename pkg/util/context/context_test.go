@@ -51,6 +51,26 @@ func TestGetPackageRevision(t *testing.T) {
 	}
 }
 
+func TestGetNamespace(t *testing.T) {
+	ctx := WithNamespace(context.Background(), "test-ns")
+	if got := GetNamespace(ctx); got != "test-ns" {
+		t.Errorf("got %v, want test-ns", got)
+	}
+	if got := GetNamespace(context.Background()); got != "" {
+		t.Errorf("got %v, want empty namespace", got)
+	}
+}
+
+func TestGetPriority(t *testing.T) {
+	ctx := WithPriority(context.Background(), 1)
+	if got := GetPriority(ctx); got != 1 {
+		t.Errorf("got %v, want 1", got)
+	}
+	if got := GetPriority(context.Background()); got != 0 {
+		t.Errorf("got %v, want 0", got)
+	}
+}
+
 func TestWithNewRequestIDAndPackageRevision(t *testing.T) {
 	ctx := WithNewRequestIDAndPackageRevision(context.Background(), "test-pr")
 	if got := GetRequestID(ctx); got == uuid.Nil {
@@ -0,0 +1,155 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deploymentstatus aggregates the PackageRevisionDeploymentStatus
+// reports of GitOps agents into the deployment status of package revisions
+// and repositories.
+package deploymentstatus
+
+import (
+	"sort"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	porchv1alpha2 "github.com/kptdev/porch/api/porch/v1alpha2"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/kptdev/porch/pkg/repository"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// PackageRevisionField is the field of a report that names its package revision.
+// Controllers index reports on it, and the API server can select reports by it.
+const PackageRevisionField = "spec.packageRevision"
+
+// RepositoryIndex is the index of reports by the repository of their package
+// revision, see RepositoryOf. It is not a field of the report, so it can only be
+// used with the indexed cache of a controller.
+const RepositoryIndex = "repository"
+
+// GroupByPackageRevision groups the reports by the namespaced name of their package revision.
+func GroupByPackageRevision(reports []configapi.PackageRevisionDeploymentStatus) map[types.NamespacedName][]configapi.PackageRevisionDeploymentStatus {
+	grouped := map[types.NamespacedName][]configapi.PackageRevisionDeploymentStatus{}
+	for _, report := range reports {
+		if report.Spec.PackageRevision == "" {
+			continue
+		}
+		key := types.NamespacedName{Namespace: report.Namespace, Name: report.Spec.PackageRevision}
+		grouped[key] = append(grouped[key], report)
+	}
+	return grouped
+}
+
+// RepositoryOf returns the name of the repository of the package revision the
+// report is for, or "" if the package revision name cannot be parsed.
+func RepositoryOf(report *configapi.PackageRevisionDeploymentStatus) string {
+	key, err := repository.PkgRevK8sName2Key(report.Namespace, report.Spec.PackageRevision)
+	if err != nil {
+		return ""
+	}
+	return key.RKey().Name
+}
+
+// ForPackageRevision returns the deployment status of a package revision from
+// its reports, or nil if there are none.
+func ForPackageRevision(reports []configapi.PackageRevisionDeploymentStatus) *porchapi.DeploymentStatus {
+	if len(reports) == 0 {
+		return nil
+	}
+
+	sorted := sortByTarget(reports)
+	status := &porchapi.DeploymentStatus{
+		State: string(aggregateState(sorted)),
+	}
+	for _, report := range sorted {
+		status.Targets = append(status.Targets, porchapi.DeploymentTargetStatus{
+			Target:           report.Spec.Target,
+			State:            string(report.Spec.State),
+			SyncedCommit:     report.Spec.SyncedCommit,
+			LastSyncTime:     report.Spec.LastSyncTime,
+			Message:          report.Spec.Message,
+			Resources:        len(report.Spec.Resources),
+			HealthyResources: report.Spec.HealthyResources(),
+		})
+	}
+	return status
+}
+
+// ForV1Alpha2PackageRevision is ForPackageRevision for v1alpha2 package revisions.
+func ForV1Alpha2PackageRevision(reports []configapi.PackageRevisionDeploymentStatus) *porchv1alpha2.DeploymentStatus {
+	v1alpha1Status := ForPackageRevision(reports)
+	if v1alpha1Status == nil {
+		return nil
+	}
+
+	status := &porchv1alpha2.DeploymentStatus{
+		State: v1alpha1Status.State,
+	}
+	for _, target := range v1alpha1Status.Targets {
+		status.Targets = append(status.Targets, porchv1alpha2.DeploymentTargetStatus{
+			Target:           target.Target,
+			State:            target.State,
+			SyncedCommit:     target.SyncedCommit,
+			LastSyncTime:     target.LastSyncTime,
+			Message:          target.Message,
+			Resources:        target.Resources,
+			HealthyResources: target.HealthyResources,
+		})
+	}
+	return status
+}
+
+// ForRepository summarises the reports for the package revisions of a
+// repository. It returns nil if there are none.
+func ForRepository(reports []configapi.PackageRevisionDeploymentStatus) *configapi.RepositoryDeploymentStatus {
+	grouped := GroupByPackageRevision(reports)
+	if len(grouped) == 0 {
+		return nil
+	}
+
+	status := &configapi.RepositoryDeploymentStatus{
+		PackageRevisions: len(grouped),
+	}
+	for _, prReports := range grouped {
+		switch aggregateState(prReports) {
+		case configapi.DeploymentStateApplied:
+			status.Applied++
+		case configapi.DeploymentStateFailed:
+			status.Failed++
+		case configapi.DeploymentStateDrifted:
+			status.Drifted++
+		case configapi.DeploymentStateProgressing:
+			status.Progressing++
+		}
+	}
+	return status
+}
+
+func aggregateState(reports []configapi.PackageRevisionDeploymentStatus) configapi.DeploymentState {
+	states := make([]configapi.DeploymentState, 0, len(reports))
+	for _, report := range reports {
+		states = append(states, report.Spec.State)
+	}
+	return configapi.AggregateDeploymentState(states...)
+}
+
+func sortByTarget(reports []configapi.PackageRevisionDeploymentStatus) []configapi.PackageRevisionDeploymentStatus {
+	sorted := make([]configapi.PackageRevisionDeploymentStatus, len(reports))
+	copy(sorted, reports)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Spec.Target != sorted[j].Spec.Target {
+			return sorted[i].Spec.Target < sorted[j].Spec.Target
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
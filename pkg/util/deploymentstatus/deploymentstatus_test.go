@@ -0,0 +1,101 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploymentstatus
+
+import (
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	configapi "github.com/kptdev/porch/api/porchconfig/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func report(name, prName, target string, state configapi.DeploymentState, health ...configapi.ResourceHealth) configapi.PackageRevisionDeploymentStatus {
+	r := configapi.PackageRevisionDeploymentStatus{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+		Spec: configapi.PackageRevisionDeploymentStatusSpec{
+			PackageRevision: prName,
+			Target:          target,
+			State:           state,
+			SyncedCommit:    "abc123",
+		},
+	}
+	for i, h := range health {
+		r.Spec.Resources = append(r.Spec.Resources, configapi.DeployedResourceStatus{
+			Kind:   "ConfigMap",
+			Name:   string(rune('a' + i)),
+			Health: h,
+		})
+	}
+	return r
+}
+
+func TestForPackageRevision(t *testing.T) {
+	assert.Nil(t, ForPackageRevision(nil))
+
+	status := ForPackageRevision([]configapi.PackageRevisionDeploymentStatus{
+		report("b", "deploy.pkg.v1", "cluster-b", configapi.DeploymentStateDrifted,
+			configapi.ResourceHealthHealthy, configapi.ResourceHealthDegraded),
+		report("a", "deploy.pkg.v1", "cluster-a", configapi.DeploymentStateApplied,
+			configapi.ResourceHealthHealthy),
+	})
+	assert.Equal(t, &porchapi.DeploymentStatus{
+		State: "Drifted",
+		Targets: []porchapi.DeploymentTargetStatus{
+			{Target: "cluster-a", State: "Applied", SyncedCommit: "abc123", Resources: 1, HealthyResources: 1},
+			{Target: "cluster-b", State: "Drifted", SyncedCommit: "abc123", Resources: 2, HealthyResources: 1},
+		},
+	}, status)
+
+	v1alpha2Status := ForV1Alpha2PackageRevision([]configapi.PackageRevisionDeploymentStatus{
+		report("a", "deploy.pkg.v1", "cluster-a", configapi.DeploymentStateFailed),
+	})
+	assert.Equal(t, "Failed", v1alpha2Status.State)
+	assert.Equal(t, "cluster-a", v1alpha2Status.Targets[0].Target)
+	assert.Nil(t, ForV1Alpha2PackageRevision(nil))
+}
+
+func TestForRepository(t *testing.T) {
+	assert.Nil(t, ForRepository(nil))
+
+	status := ForRepository([]configapi.PackageRevisionDeploymentStatus{
+		report("1", "deploy.a.v1", "cluster-a", configapi.DeploymentStateApplied),
+		report("2", "deploy.a.v1", "cluster-b", configapi.DeploymentStateApplied),
+		report("3", "deploy.b.v1", "cluster-a", configapi.DeploymentStateApplied),
+		report("4", "deploy.b.v1", "cluster-b", configapi.DeploymentStateFailed),
+		report("5", "deploy.c.v1", "cluster-a", configapi.DeploymentStateProgressing),
+		report("6", "deploy.d.v1", "cluster-a", configapi.DeploymentStateDrifted),
+		report("7", "", "cluster-a", configapi.DeploymentStateFailed),
+	})
+	assert.Equal(t, &configapi.RepositoryDeploymentStatus{
+		PackageRevisions: 4,
+		Applied:          1,
+		Failed:           1,
+		Drifted:          1,
+		Progressing:      1,
+	}, status)
+}
+
+func TestGroupByPackageRevisionAndRepositoryOf(t *testing.T) {
+	a := report("1", "deploy.pkg.main", "cluster-a", configapi.DeploymentStateApplied)
+	b := report("2", "deploy.pkg.main", "cluster-b", configapi.DeploymentStateApplied)
+	grouped := GroupByPackageRevision([]configapi.PackageRevisionDeploymentStatus{a, b})
+	assert.Len(t, grouped[types.NamespacedName{Namespace: "ns", Name: "deploy.pkg.main"}], 2)
+
+	assert.Equal(t, "deploy", RepositoryOf(&a))
+	assert.Equal(t, "", RepositoryOf(&configapi.PackageRevisionDeploymentStatus{}))
+}
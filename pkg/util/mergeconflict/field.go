@@ -0,0 +1,262 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergeconflict
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Field paths name a field of a KRM resource. Keys of mappings are separated by '.', and keys
+// which contain '.', '[', ']' or '"' are written quoted in brackets, as in
+// metadata.annotations["example.com/owner"]. Elements of sequences of mappings are selected by
+// their name, as in spec.containers[name=app].image.
+
+// JoinField returns the path of the field key of the mapping at fieldPath.
+func JoinField(fieldPath, key string) string {
+	if key == "" || strings.ContainsAny(key, `.[]"`) {
+		return fieldPath + "[" + strconv.Quote(key) + "]"
+	}
+	if fieldPath == "" {
+		return key
+	}
+	return fieldPath + "." + key
+}
+
+// ElementField returns the path of the element with the given name of the sequence at fieldPath.
+func ElementField(fieldPath, name string) string {
+	if strings.ContainsAny(name, `[]"`) {
+		name = strconv.Quote(name)
+	}
+	return fieldPath + "[name=" + name + "]"
+}
+
+// FieldsOverlap reports whether one of the fields is the other or contains it.
+func FieldsOverlap(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if !strings.HasPrefix(b, a) {
+		return false
+	}
+	return len(a) == 0 || len(a) == len(b) || b[len(a)] == '.' || b[len(a)] == '['
+}
+
+// segment is a step of a field path: either the key of a mapping or the named element of a
+// sequence.
+type segment struct {
+	key     string
+	name    string
+	element bool
+}
+
+func parseField(fieldPath string) ([]segment, error) {
+	var segments []segment
+	rest := fieldPath
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, `["`):
+			quoted, err := strconv.QuotedPrefix(rest[1:])
+			if err != nil || !strings.HasPrefix(rest[1+len(quoted):], "]") {
+				return nil, fmt.Errorf("invalid field path %q", fieldPath)
+			}
+			key, _ := strconv.Unquote(quoted)
+			segments = append(segments, segment{key: key})
+			rest = rest[len(quoted)+2:]
+		case strings.HasPrefix(rest, "[name="):
+			value := rest[len("[name="):]
+			var name string
+			if strings.HasPrefix(value, `"`) {
+				quoted, err := strconv.QuotedPrefix(value)
+				if err != nil || !strings.HasPrefix(value[len(quoted):], "]") {
+					return nil, fmt.Errorf("invalid field path %q", fieldPath)
+				}
+				name, _ = strconv.Unquote(quoted)
+				value = value[len(quoted):]
+			} else {
+				end := strings.Index(value, "]")
+				if end < 0 {
+					return nil, fmt.Errorf("invalid field path %q", fieldPath)
+				}
+				name, value = value[:end], value[end:]
+			}
+			segments = append(segments, segment{name: name, element: true})
+			rest = value[1:]
+		default:
+			rest = strings.TrimPrefix(rest, ".")
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid field path %q", fieldPath)
+			}
+			segments = append(segments, segment{key: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+	return segments, nil
+}
+
+// lookupField returns the node of the field at fieldPath in the resource, or nil if there is no
+// such field.
+func lookupField(resource *yaml.Node, fieldPath string) (*yaml.Node, error) {
+	segments, err := parseField(fieldPath)
+	if err != nil {
+		return nil, err
+	}
+	node := resource
+	for _, s := range segments {
+		if node == nil {
+			return nil, nil
+		}
+		node = child(node, s)
+	}
+	return node, nil
+}
+
+// setField sets the field at fieldPath in the resource to value, creating the mappings and
+// sequence elements on the way as needed, or removes the field if value is nil.
+func setField(resource *yaml.Node, fieldPath string, value *yaml.Node) error {
+	segments, err := parseField(fieldPath)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		return fmt.Errorf("field path is empty")
+	}
+	node := resource
+	for i, s := range segments[:len(segments)-1] {
+		next := child(node, s)
+		if next == nil {
+			if value == nil {
+				return nil
+			}
+			next = &yaml.Node{Kind: yaml.MappingNode}
+			if segments[i+1].element {
+				next.Kind = yaml.SequenceNode
+			}
+			if err := setChild(node, s, next); err != nil {
+				return err
+			}
+		}
+		node = next
+	}
+	last := segments[len(segments)-1]
+	if value == nil {
+		removeChild(node, last)
+		return nil
+	}
+	return setChild(node, last, value)
+}
+
+func child(node *yaml.Node, s segment) *yaml.Node {
+	switch {
+	case !s.element && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == s.key {
+				return node.Content[i+1]
+			}
+		}
+	case s.element && node.Kind == yaml.SequenceNode:
+		for _, e := range node.Content {
+			if elementName(e) == s.name {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+func setChild(node *yaml.Node, s segment, value *yaml.Node) error {
+	if s.element {
+		if node.Kind != yaml.SequenceNode {
+			return fmt.Errorf("cannot select element %q of a %s", s.name, kindName(node))
+		}
+		if value.Kind == yaml.MappingNode && elementName(value) != s.name {
+			return fmt.Errorf("element %q has name %q", s.name, elementName(value))
+		}
+		for i, e := range node.Content {
+			if elementName(e) == s.name {
+				node.Content[i] = value
+				return nil
+			}
+		}
+		if value.Kind == yaml.MappingNode && len(value.Content) == 0 {
+			value.Content = []*yaml.Node{
+				{Kind: yaml.ScalarNode, Value: "name"},
+				{Kind: yaml.ScalarNode, Value: s.name},
+			}
+		}
+		node.Content = append(node.Content, value)
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("cannot set field %q of a %s", s.key, kindName(node))
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == s.key {
+			node.Content[i+1] = value
+			return nil
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: s.key}, value)
+	return nil
+}
+
+func removeChild(node *yaml.Node, s segment) {
+	switch {
+	case !s.element && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == s.key {
+				node.Content = append(node.Content[:i], node.Content[i+2:]...)
+				return
+			}
+		}
+	case s.element && node.Kind == yaml.SequenceNode:
+		for i, e := range node.Content {
+			if elementName(e) == s.name {
+				node.Content = append(node.Content[:i], node.Content[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func elementName(e *yaml.Node) string {
+	if e.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(e.Content); i += 2 {
+		if e.Content[i].Value == "name" {
+			return e.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+func kindName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "mapping"
+	case yaml.SequenceNode:
+		return "sequence"
+	default:
+		return "scalar"
+	}
+}
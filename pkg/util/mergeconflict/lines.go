@@ -0,0 +1,231 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergeconflict
+
+import (
+	"strings"
+)
+
+const (
+	markerOurs   = "<<<<<<< "
+	markerBase   = "||||||| base"
+	markerSep    = "======="
+	markerTheirs = ">>>>>>> "
+)
+
+// MergeLines performs a diff3 merge of the lines of ours and theirs against base. Overlapping
+// changes are written with git style conflict markers labelled with oursLabel and theirsLabel. It
+// returns false if the merge has conflicts.
+func MergeLines(base, ours, theirs, oursLabel, theirsLabel string) (string, bool) {
+	o, a, b := splitLines(base), splitLines(ours), splitLines(theirs)
+	ma, mb := matchLines(o, a), matchLines(o, b)
+
+	m := lineMerger{oursLabel: oursLabel, theirsLabel: theirsLabel}
+	clean := true
+	io, ia, ib := 0, 0, 0
+	for io < len(o) || ia < len(a) || ib < len(b) {
+		// Copy the lines which are unchanged on both sides.
+		if io < len(o) && ma[io] == ia && mb[io] == ib {
+			m.out.WriteString(o[io])
+			io, ia, ib = io+1, ia+1, ib+1
+			continue
+		}
+		// Find the end of the changed chunk: the next base line kept by both sides.
+		jo, ja, jb := len(o), len(a), len(b)
+		for k := io + 1; k < len(o); k++ {
+			if ma[k] >= ia && mb[k] >= ib {
+				jo, ja, jb = k, ma[k], mb[k]
+				break
+			}
+		}
+		if !m.mergeChunk(o[io:jo], a[ia:ja], b[ib:jb]) {
+			clean = false
+		}
+		io, ia, ib = jo, ja, jb
+	}
+	return m.out.String(), clean
+}
+
+// HasMarkers reports whether the contents contain an unresolved conflict written by MergeLines.
+func HasMarkers(contents string) bool {
+	var inConflict bool
+	for _, line := range strings.Split(contents, "\n") {
+		switch {
+		case strings.HasPrefix(line, markerOurs):
+			inConflict = true
+		case inConflict && strings.HasPrefix(line, markerTheirs):
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveLines resolves every conflict written by MergeLines in the contents by taking the given
+// side, and returns the resolved contents.
+func ResolveLines(contents string, side Side) string {
+	var out strings.Builder
+	var section string // "", "ours", "base" or "theirs"
+	var ours, theirs []string
+	for _, line := range splitLines(contents) {
+		trimmed := strings.TrimSuffix(line, "\n")
+		switch {
+		case section == "" && strings.HasPrefix(trimmed, markerOurs):
+			section, ours, theirs = "ours", nil, nil
+		case section == "ours" && trimmed == markerBase:
+			section = "base"
+		case (section == "ours" || section == "base") && trimmed == markerSep:
+			section = "theirs"
+		case section == "theirs" && strings.HasPrefix(trimmed, markerTheirs):
+			taken := ours
+			if side == Theirs {
+				taken = theirs
+			}
+			for _, l := range taken {
+				out.WriteString(l)
+			}
+			section = ""
+		case section == "ours":
+			ours = append(ours, line)
+		case section == "base":
+		case section == "theirs":
+			theirs = append(theirs, line)
+		default:
+			out.WriteString(line)
+		}
+	}
+	if section != "" {
+		// An unterminated conflict is not a conflict; keep it as it is.
+		return contents
+	}
+	return out.String()
+}
+
+type lineMerger struct {
+	out         strings.Builder
+	oursLabel   string
+	theirsLabel string
+}
+
+// mergeChunk writes the merge of a chunk which changed on at least one side.
+func (m *lineMerger) mergeChunk(o, a, b []string) bool {
+	switch {
+	case equalLines(a, o):
+		m.writeLines(b)
+	case equalLines(b, o), equalLines(a, b):
+		m.writeLines(a)
+	default:
+		m.out.WriteString(markerOurs + m.oursLabel + "\n")
+		m.writeConflictLines(a)
+		m.out.WriteString(markerBase + "\n")
+		m.writeConflictLines(o)
+		m.out.WriteString(markerSep + "\n")
+		m.writeConflictLines(b)
+		m.out.WriteString(markerTheirs + m.theirsLabel + "\n")
+		return false
+	}
+	return true
+}
+
+func (m *lineMerger) writeLines(lines []string) {
+	for _, l := range lines {
+		m.out.WriteString(l)
+	}
+}
+
+// writeConflictLines writes lines inside a conflict, making sure the marker which
+// follows them starts on a new line.
+func (m *lineMerger) writeConflictLines(lines []string) {
+	m.writeLines(lines)
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		m.out.WriteString("\n")
+	}
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// splitLines splits s into lines, keeping the line endings.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// matchLines returns, for each line of base, the index of the matching line of
+// other in a longest common subsequence of the two, or -1 if the line is not
+// part of it.
+func matchLines(base, other []string) []int {
+	m := make([]int, len(base))
+	for i := range m {
+		m[i] = -1
+	}
+
+	// Lines shared at the start and the end are matched directly, which keeps the
+	// table below small for the typical edit.
+	prefix := 0
+	for prefix < len(base) && prefix < len(other) && base[prefix] == other[prefix] {
+		m[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(base)-prefix && suffix < len(other)-prefix &&
+		base[len(base)-1-suffix] == other[len(other)-1-suffix] {
+		m[len(base)-1-suffix] = len(other) - 1 - suffix
+		suffix++
+	}
+
+	o, a := base[prefix:len(base)-suffix], other[prefix:len(other)-suffix]
+	if len(o) == 0 || len(a) == 0 {
+		return m
+	}
+	// lcs[i][j] is the length of the longest common subsequence of o[i:] and a[j:].
+	lcs := make([][]int, len(o)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(a)+1)
+	}
+	for i := len(o) - 1; i >= 0; i-- {
+		for j := len(a) - 1; j >= 0; j-- {
+			if o[i] == a[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	for i, j := 0, 0; i < len(o) && j < len(a); {
+		switch {
+		case o[i] == a[j]:
+			m[prefix+i] = prefix + j
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return m
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergeconflict
+
+import (
+	"testing"
+)
+
+func TestMergeAndResolveLines(t *testing.T) {
+	base := "a\nb\nc\n"
+	ours := "a\nlocal\nc\n"
+	theirs := "a\nupstream\nc\nd\n"
+
+	merged, clean := MergeLines(base, ours, theirs, OursLabel, TheirsLabel)
+	if clean {
+		t.Fatalf("MergeLines reported no conflicts:\n%s", merged)
+	}
+	want := "a\n<<<<<<< local\nlocal\n||||||| base\nb\n=======\nupstream\n>>>>>>> upstream\nc\nd\n"
+	if merged != want {
+		t.Errorf("MergeLines() = %q, want %q", merged, want)
+	}
+	if !HasMarkers(merged) {
+		t.Errorf("HasMarkers(%q) = false, want true", merged)
+	}
+
+	if got, want := ResolveLines(merged, Ours), "a\nlocal\nc\nd\n"; got != want {
+		t.Errorf("ResolveLines(ours) = %q, want %q", got, want)
+	}
+	if got, want := ResolveLines(merged, Theirs), "a\nupstream\nc\nd\n"; got != want {
+		t.Errorf("ResolveLines(theirs) = %q, want %q", got, want)
+	}
+	if got := ResolveLines(merged, Theirs); HasMarkers(got) {
+		t.Errorf("ResolveLines left conflict markers in %q", got)
+	}
+}
+
+func TestResolveLinesUnterminated(t *testing.T) {
+	contents := "a\n<<<<<<< local\nb\n=======\n"
+	if got := ResolveLines(contents, Ours); got != contents {
+		t.Errorf("ResolveLines() = %q, want the contents unchanged", got)
+	}
+}
@@ -0,0 +1,473 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mergeconflict records, lists and resolves the merge conflicts that an upgrade with the
+// manual-merge strategy leaves in a package.
+//
+// A field of a KRM resource which the local package and the upstream changed differently keeps
+// the local value, and the conflict is recorded with both values in the
+// porch.kpt.dev/merge-conflicts annotation of the resource. Conflicts in other files are written
+// with git style conflict markers. The MergeConflicts condition in the Kptfile of the package
+// lists the unresolved conflicts, and a package revision cannot be proposed while it is True.
+package mergeconflict
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	// Annotation records the unresolved conflicts of a KRM resource as a JSON list of
+	// FieldConflicts.
+	Annotation = "porch.kpt.dev/merge-conflicts"
+	// ConditionType is the type of the Kptfile condition which lists the unresolved conflicts of
+	// the package.
+	ConditionType = "MergeConflicts"
+
+	// OursLabel and TheirsLabel label the sides of conflict markers in files which do not hold
+	// KRM resources.
+	OursLabel   = "local"
+	TheirsLabel = "upstream"
+
+	kptfileName = "Kptfile"
+)
+
+// Side is the side of a conflict to take when resolving it.
+type Side string
+
+const (
+	// Ours takes the local version.
+	Ours Side = "ours"
+	// Theirs takes the upstream version.
+	Theirs Side = "theirs"
+)
+
+// FieldConflict is a field of a KRM resource which the local package and the upstream changed
+// differently.
+type FieldConflict struct {
+	// Field is the path of the field, or empty if one side deleted the resource and the other
+	// changed it.
+	Field string `json:"field"`
+	// Ours is the local value of the field as YAML, or nil if the local package deleted it. For a
+	// conflict about the whole resource it is empty unless nil.
+	Ours *string `json:"ours,omitempty"`
+	// Theirs is the upstream value of the field as YAML, or nil if the upstream deleted it.
+	Theirs *string `json:"theirs,omitempty"`
+}
+
+// Item is an unresolved conflict in a package.
+type Item struct {
+	File      string
+	Kind      string
+	Namespace string
+	Name      string
+	// Field is the path of the conflicting field of the resource, or empty if the conflict is
+	// about the whole resource or file.
+	Field string
+}
+
+func (i Item) String() string {
+	s := i.File
+	if i.Kind != "" {
+		name := i.Name
+		if i.Namespace != "" {
+			name = i.Namespace + "/" + name
+		}
+		s += " " + i.Kind + "/" + name
+	}
+	if i.Field != "" {
+		s += " " + i.Field
+	}
+	return s
+}
+
+// Selector selects the conflicts to resolve. Empty fields match all conflicts.
+type Selector struct {
+	File string
+	Kind string
+	Name string
+	// Field selects the conflicts on the field and on the fields it contains.
+	Field string
+}
+
+// Matches reports whether the selector selects an unresolved conflict listed by List.
+func (s Selector) Matches(item Item) bool {
+	return (s.File == "" || s.File == item.File) &&
+		(s.Kind == "" || s.Kind == item.Kind) &&
+		(s.Name == "" || s.Name == item.Name) &&
+		s.matchesField(item.Field)
+}
+
+func (s Selector) matchesResource(file string, node *yaml.RNode) bool {
+	return (s.File == "" || s.File == file) &&
+		(s.Kind == "" || s.Kind == node.GetKind()) &&
+		(s.Name == "" || s.Name == node.GetName())
+}
+
+func (s Selector) matchesField(field string) bool {
+	if s.Field == "" {
+		return true
+	}
+	return strings.HasPrefix(field, s.Field) && FieldsOverlap(field, s.Field)
+}
+
+// ReadNodes reads the KRM resources in a file of a package. It returns false if the file does not
+// hold KRM resources.
+func ReadNodes(file, contents string) ([]*yaml.RNode, bool) {
+	base := path.Base(file)
+	ext := path.Ext(base)
+	if ext != ".yaml" && ext != ".yml" && base != kptfileName {
+		return nil, false
+	}
+	nodes, err := (&kio.ByteReader{
+		Reader:                strings.NewReader(contents),
+		OmitReaderAnnotations: true,
+		DisableUnwrapping:     true,
+	}).Read()
+	if err != nil {
+		return nil, false
+	}
+	return nodes, true
+}
+
+// WriteNodes writes KRM resources read by ReadNodes back to the contents of a file.
+func WriteNodes(nodes []*yaml.RNode) (string, error) {
+	var buf bytes.Buffer
+	if err := (kio.ByteWriter{Writer: &buf}).Write(nodes); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// GetConflicts returns the unresolved conflicts recorded on a KRM resource.
+func GetConflicts(node *yaml.RNode) ([]FieldConflict, error) {
+	value, found := node.GetAnnotations()[Annotation]
+	if !found {
+		return nil, nil
+	}
+	var conflicts []FieldConflict
+	if err := json.Unmarshal([]byte(value), &conflicts); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation on %s %s: %w", Annotation, node.GetKind(), node.GetName(), err)
+	}
+	return conflicts, nil
+}
+
+// SetConflicts records the unresolved conflicts of a KRM resource, or removes the record if there
+// are none.
+func SetConflicts(node *yaml.RNode, conflicts []FieldConflict) error {
+	if len(conflicts) == 0 {
+		_, err := node.Pipe(yaml.ClearAnnotation(Annotation))
+		return err
+	}
+	value, err := json.Marshal(conflicts)
+	if err != nil {
+		return err
+	}
+	return node.PipeE(yaml.SetAnnotation(Annotation, string(value)))
+}
+
+// EncodeValue returns the YAML of a field value for a FieldConflict, or nil if the field is absent.
+func EncodeValue(value *yaml.Node) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	s, err := yaml.NewRNode(value).String()
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// DecodeValue parses a field value encoded by EncodeValue.
+func DecodeValue(value *string) (*yaml.Node, error) {
+	if value == nil {
+		return nil, nil
+	}
+	node, err := yaml.Parse(*value)
+	if err != nil {
+		return nil, err
+	}
+	return node.YNode(), nil
+}
+
+// LookupField returns the field at fieldPath of a KRM resource, or nil if there is no such field.
+func LookupField(node *yaml.RNode, fieldPath string) (*yaml.Node, error) {
+	return lookupField(node.YNode(), fieldPath)
+}
+
+// SetField sets the field at fieldPath of a KRM resource to value, or removes it if value is nil.
+func SetField(node *yaml.RNode, fieldPath string, value *yaml.Node) error {
+	return setField(node.YNode(), fieldPath, value)
+}
+
+// List returns the unresolved conflicts in the resources of a package, ordered by file.
+func List(resources map[string]string) ([]Item, error) {
+	var items []Item
+	for _, file := range sortedFiles(resources) {
+		nodes, isKRM := ReadNodes(file, resources[file])
+		if !isKRM {
+			if HasMarkers(resources[file]) {
+				items = append(items, Item{File: file})
+			}
+			continue
+		}
+		for _, node := range nodes {
+			conflicts, err := GetConflicts(node)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+			for _, c := range conflicts {
+				items = append(items, Item{
+					File:      file,
+					Kind:      node.GetKind(),
+					Namespace: node.GetNamespace(),
+					Name:      node.GetName(),
+					Field:     c.Field,
+				})
+			}
+		}
+	}
+	return items, nil
+}
+
+// Resolve resolves the selected conflicts in the resources of a package by taking the given side,
+// and returns the number of conflicts it resolved. Conflicts in files which do not hold KRM
+// resources are resolved for the whole file, so they are only selected by file.
+func Resolve(resources map[string]string, selector Selector, side Side) (int, error) {
+	if side != Ours && side != Theirs {
+		return 0, fmt.Errorf("side must be %s or %s", Ours, Theirs)
+	}
+	resolved := 0
+	for _, file := range sortedFiles(resources) {
+		if selector.File != "" && selector.File != file {
+			continue
+		}
+		nodes, isKRM := ReadNodes(file, resources[file])
+		if !isKRM {
+			if selector.Kind == "" && selector.Name == "" && selector.Field == "" && HasMarkers(resources[file]) {
+				if contents := ResolveLines(resources[file], side); contents != "" {
+					resources[file] = contents
+				} else {
+					// The side taken had deleted the file.
+					delete(resources, file)
+				}
+				resolved++
+			}
+			continue
+		}
+
+		changed := false
+		kept := make([]*yaml.RNode, 0, len(nodes))
+		for _, node := range nodes {
+			if !selector.matchesResource(file, node) {
+				kept = append(kept, node)
+				continue
+			}
+			n, deleted, err := resolveResource(node, selector, side)
+			if err != nil {
+				return resolved, fmt.Errorf("%s: %w", file, err)
+			}
+			if n > 0 {
+				changed = true
+				resolved += n
+			}
+			if !deleted {
+				kept = append(kept, node)
+			}
+		}
+		if !changed {
+			continue
+		}
+		if len(kept) == 0 {
+			delete(resources, file)
+			continue
+		}
+		contents, err := WriteNodes(kept)
+		if err != nil {
+			return resolved, fmt.Errorf("%s: %w", file, err)
+		}
+		resources[file] = contents
+	}
+	return resolved, nil
+}
+
+// resolveResource resolves the selected conflicts of a KRM resource. It returns the number of
+// conflicts it resolved, and whether the resource is deleted because the side taken had deleted
+// it.
+func resolveResource(node *yaml.RNode, selector Selector, side Side) (int, bool, error) {
+	conflicts, err := GetConflicts(node)
+	if err != nil {
+		return 0, false, err
+	}
+	var remaining []FieldConflict
+	resolved := 0
+	for _, c := range conflicts {
+		if !selector.matchesField(c.Field) {
+			remaining = append(remaining, c)
+			continue
+		}
+		resolved++
+		value := c.Ours
+		if side == Theirs {
+			value = c.Theirs
+		}
+		if c.Field == "" {
+			if value == nil {
+				return resolved, true, nil
+			}
+			continue
+		}
+		v, err := DecodeValue(value)
+		if err != nil {
+			return resolved, false, fmt.Errorf("invalid value of field %s: %w", c.Field, err)
+		}
+		if err := SetField(node, c.Field, v); err != nil {
+			return resolved, false, err
+		}
+	}
+	if resolved == 0 {
+		return 0, false, nil
+	}
+	return resolved, false, SetConflicts(node, remaining)
+}
+
+// Refresh updates the MergeConflicts condition in the Kptfile of the package to list the
+// unresolved conflicts in its resources. Unless force is set, the condition is only updated if the
+// Kptfile already has it, so that packages which never had conflicts are left alone.
+func Refresh(resources map[string]string, force bool) error {
+	kptfile, found := resources[kptfileName]
+	if !found {
+		return nil
+	}
+	kf, err := yaml.Parse(kptfile)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", kptfileName, err)
+	}
+	condition, err := findCondition(kf)
+	if err != nil {
+		return err
+	}
+	if condition == nil && !force {
+		return nil
+	}
+
+	items, err := List(resources)
+	if err != nil {
+		return err
+	}
+	status, reason, message := porchapi.ConditionFalse, "Resolved", "all merge conflicts are resolved"
+	if len(items) > 0 {
+		listed := make([]string, len(items))
+		for i, item := range items {
+			listed[i] = item.String()
+		}
+		status, reason = porchapi.ConditionTrue, "Unresolved"
+		message = fmt.Sprintf("%d unresolved merge conflicts: %s", len(items), strings.Join(listed, "; "))
+	} else if condition == nil {
+		return nil
+	}
+
+	if condition == nil {
+		conditions, err := kf.Pipe(yaml.LookupCreate(yaml.SequenceNode, "status", "conditions"))
+		if err != nil {
+			return err
+		}
+		condition = yaml.NewMapRNode(&map[string]string{"type": ConditionType})
+		if err := conditions.PipeE(yaml.Append(condition.YNode())); err != nil {
+			return err
+		}
+	} else if fieldValue(condition, "status") == string(status) &&
+		fieldValue(condition, "reason") == reason &&
+		fieldValue(condition, "message") == message {
+		return nil
+	}
+	for field, value := range map[string]string{"status": string(status), "reason": reason, "message": message} {
+		if err := condition.PipeE(yaml.SetField(field, yaml.NewStringRNode(value))); err != nil {
+			return err
+		}
+	}
+
+	out, err := kf.String()
+	if err != nil {
+		return err
+	}
+	resources[kptfileName] = out
+	return nil
+}
+
+// HasCondition reports whether the Kptfile of the package has the MergeConflicts condition.
+func HasCondition(resources map[string]string) bool {
+	kptfile, found := resources[kptfileName]
+	if !found {
+		return false
+	}
+	kf, err := yaml.Parse(kptfile)
+	if err != nil {
+		return false
+	}
+	condition, err := findCondition(kf)
+	return err == nil && condition != nil
+}
+
+func findCondition(kf *yaml.RNode) (*yaml.RNode, error) {
+	conditions, err := kf.Pipe(yaml.Lookup("status", "conditions"))
+	if err != nil || conditions == nil {
+		return nil, err
+	}
+	elements, err := conditions.Elements()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range elements {
+		if fieldValue(e, "type") == ConditionType {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+func fieldValue(node *yaml.RNode, field string) string {
+	f := node.Field(field)
+	if f == nil {
+		return ""
+	}
+	return yaml.GetValue(f.Value)
+}
+
+// Unresolved returns the MergeConflicts condition if it reports unresolved conflicts.
+func Unresolved(conditions []porchapi.Condition) *porchapi.Condition {
+	for i := range conditions {
+		if conditions[i].Type == ConditionType && conditions[i].Status == porchapi.ConditionTrue {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func sortedFiles(resources map[string]string) []string {
+	files := make([]string, 0, len(resources))
+	for file := range resources {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
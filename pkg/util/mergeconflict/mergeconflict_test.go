@@ -0,0 +1,258 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergeconflict
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const kptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: pkg
+`
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  annotations:
+    porch.kpt.dev/merge-conflicts: '[{"field":"spec.replicas","ours":"3\n","theirs":"5\n"},{"field":"spec.template.spec.containers[name=app].image","ours":"app:local\n","theirs":"app:v2\n"}]'
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:local
+`
+
+const deletedConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: gone
+  annotations:
+    porch.kpt.dev/merge-conflicts: '[{"field":"","ours":""}]'
+data:
+  a: "1"
+`
+
+func testResources() map[string]string {
+	return map[string]string{
+		"Kptfile":     kptfile,
+		"deploy.yaml": deployment,
+		"cm.yaml":     deletedConfigMap,
+		"README.md":   "intro\n<<<<<<< local\nours\n||||||| base\nbase\n=======\ntheirs\n>>>>>>> upstream\n",
+		"other.yaml":  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: other\n",
+	}
+}
+
+func TestList(t *testing.T) {
+	items, err := List(testResources())
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	want := []Item{
+		{File: "README.md"},
+		{File: "cm.yaml", Kind: "ConfigMap", Name: "gone"},
+		{File: "deploy.yaml", Kind: "Deployment", Name: "app", Field: "spec.replicas"},
+		{File: "deploy.yaml", Kind: "Deployment", Name: "app", Field: "spec.template.spec.containers[name=app].image"},
+	}
+	if diff := cmp.Diff(want, items); diff != "" {
+		t.Errorf("List() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	testCases := map[string]struct {
+		selector  Selector
+		side      Side
+		resolved  int
+		remaining int
+		check     func(t *testing.T, resources map[string]string)
+	}{
+		"field theirs": {
+			selector:  Selector{Kind: "Deployment", Name: "app", Field: "spec.replicas"},
+			side:      Theirs,
+			resolved:  1,
+			remaining: 3,
+			check: func(t *testing.T, resources map[string]string) {
+				node := parse(t, resources["deploy.yaml"])
+				if got := fieldString(t, node, "spec.replicas"); got != "5" {
+					t.Errorf("spec.replicas = %q, want 5", got)
+				}
+			},
+		},
+		"containing field selects nested conflicts": {
+			selector:  Selector{File: "deploy.yaml", Field: "spec.template"},
+			side:      Theirs,
+			resolved:  1,
+			remaining: 3,
+			check: func(t *testing.T, resources map[string]string) {
+				node := parse(t, resources["deploy.yaml"])
+				if got := fieldString(t, node, "spec.template.spec.containers[name=app].image"); got != "app:v2" {
+					t.Errorf("image = %q, want app:v2", got)
+				}
+			},
+		},
+		"resource ours clears the annotation": {
+			selector:  Selector{Kind: "Deployment", Name: "app"},
+			side:      Ours,
+			resolved:  2,
+			remaining: 2,
+			check: func(t *testing.T, resources map[string]string) {
+				node := parse(t, resources["deploy.yaml"])
+				if _, found := node.GetAnnotations()[Annotation]; found {
+					t.Errorf("annotation %s not removed", Annotation)
+				}
+				if got := fieldString(t, node, "spec.replicas"); got != "3" {
+					t.Errorf("spec.replicas = %q, want 3", got)
+				}
+			},
+		},
+		"deleted resource theirs removes the file": {
+			selector:  Selector{File: "cm.yaml"},
+			side:      Theirs,
+			resolved:  1,
+			remaining: 3,
+			check: func(t *testing.T, resources map[string]string) {
+				if _, found := resources["cm.yaml"]; found {
+					t.Errorf("cm.yaml not deleted")
+				}
+			},
+		},
+		"non-KRM file": {
+			selector:  Selector{File: "README.md"},
+			side:      Theirs,
+			resolved:  1,
+			remaining: 3,
+			check: func(t *testing.T, resources map[string]string) {
+				if got, want := resources["README.md"], "intro\ntheirs\n"; got != want {
+					t.Errorf("README.md = %q, want %q", got, want)
+				}
+			},
+		},
+		"everything": {
+			side:      Ours,
+			resolved:  4,
+			remaining: 0,
+		},
+		"no match": {
+			selector:  Selector{Kind: "Service"},
+			side:      Ours,
+			resolved:  0,
+			remaining: 4,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resources := testResources()
+			resolved, err := Resolve(resources, tc.selector, tc.side)
+			if err != nil {
+				t.Fatalf("Resolve() failed: %v", err)
+			}
+			if resolved != tc.resolved {
+				t.Errorf("Resolve() resolved %d conflicts, want %d", resolved, tc.resolved)
+			}
+			items, err := List(resources)
+			if err != nil {
+				t.Fatalf("List() failed: %v", err)
+			}
+			if len(items) != tc.remaining {
+				t.Errorf("%d conflicts remain, want %d: %v", len(items), tc.remaining, items)
+			}
+			if tc.check != nil {
+				tc.check(t, resources)
+			}
+		})
+	}
+}
+
+func TestResolveInvalidSide(t *testing.T) {
+	if _, err := Resolve(testResources(), Selector{}, Side("both")); err == nil {
+		t.Errorf("Resolve() with an invalid side succeeded")
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	resources := testResources()
+
+	if err := Refresh(resources, false); err != nil {
+		t.Fatalf("Refresh() failed: %v", err)
+	}
+	if resources["Kptfile"] != kptfile {
+		t.Errorf("Refresh() without force changed a Kptfile without the condition:\n%s", resources["Kptfile"])
+	}
+
+	if HasCondition(resources) {
+		t.Errorf("HasCondition() = true before the condition is set")
+	}
+
+	if err := Refresh(resources, true); err != nil {
+		t.Fatalf("Refresh() failed: %v", err)
+	}
+	if !HasCondition(resources) {
+		t.Errorf("HasCondition() = false after the condition is set")
+	}
+	status, reason, message := condition(t, resources["Kptfile"])
+	if status != "True" || reason != "Unresolved" || !strings.HasPrefix(message, "4 unresolved merge conflicts: README.md; cm.yaml ConfigMap/gone;") {
+		t.Errorf("condition = %s %s %q, want True Unresolved listing 4 conflicts", status, reason, message)
+	}
+
+	if _, err := Resolve(resources, Selector{}, Theirs); err != nil {
+		t.Fatalf("Resolve() failed: %v", err)
+	}
+	if err := Refresh(resources, false); err != nil {
+		t.Fatalf("Refresh() failed: %v", err)
+	}
+	if status, reason, _ := condition(t, resources["Kptfile"]); status != "False" || reason != "Resolved" {
+		t.Errorf("condition = %s %s, want False Resolved", status, reason)
+	}
+}
+
+func parse(t *testing.T, contents string) *yaml.RNode {
+	t.Helper()
+	node, err := yaml.Parse(contents)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return node
+}
+
+func fieldString(t *testing.T, node *yaml.RNode, field string) string {
+	t.Helper()
+	value, err := LookupField(node, field)
+	if err != nil {
+		t.Fatalf("LookupField(%s): %v", field, err)
+	}
+	if value == nil {
+		return ""
+	}
+	return value.Value
+}
+
+func condition(t *testing.T, kptfile string) (string, string, string) {
+	t.Helper()
+	c, err := findCondition(parse(t, kptfile))
+	if err != nil || c == nil {
+		t.Fatalf("no %s condition in Kptfile (%v):\n%s", ConditionType, err, kptfile)
+	}
+	return fieldValue(c, "status"), fieldValue(c, "reason"), fieldValue(c, "message")
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pipeline reads and rewrites the function pipeline of a Kptfile.
+// The Kptfile is edited in place, so comments, formatting and the fields
+// of functions that are not exposed in the API (such as selectors) are kept.
+package pipeline
+
+import (
+	"fmt"
+	"maps"
+
+	kptfn "github.com/kptdev/krm-functions-sdk/go/fn"
+	kptfileapi "github.com/kptdev/krm-functions-sdk/go/fn/kptfileapi"
+	kptfileko "github.com/kptdev/krm-functions-sdk/go/fn/kptfileko"
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+)
+
+const (
+	mutatorsField   = "mutators"
+	validatorsField = "validators"
+)
+
+// Get returns the functions of the pipeline in the given Kptfile.
+func Get(kptfile string) (porchapi.PackageRevisionPipelineSpec, error) {
+	kf, err := parse(kptfile)
+	if err != nil {
+		return porchapi.PackageRevisionPipelineSpec{}, err
+	}
+
+	mutators, err := getFunctions(kf, mutatorsField)
+	if err != nil {
+		return porchapi.PackageRevisionPipelineSpec{}, err
+	}
+	validators, err := getFunctions(kf, validatorsField)
+	if err != nil {
+		return porchapi.PackageRevisionPipelineSpec{}, err
+	}
+	return porchapi.PackageRevisionPipelineSpec{
+		Mutators:   mutators,
+		Validators: validators,
+	}, nil
+}
+
+// Set replaces the pipeline of the given Kptfile with the given functions and returns the new
+// Kptfile. Existing functions are matched by name or, if they have no name, by image and config
+// path; they are updated in place and moved to their new position.
+func Set(kptfile string, spec porchapi.PackageRevisionPipelineSpec) (string, error) {
+	if err := Validate(spec); err != nil {
+		return "", err
+	}
+
+	kf, err := parse(kptfile)
+	if err != nil {
+		return "", err
+	}
+
+	if err := setFunctions(kf, mutatorsField, spec.Mutators); err != nil {
+		return "", err
+	}
+	if err := setFunctions(kf, validatorsField, spec.Validators); err != nil {
+		return "", err
+	}
+	if pipeline := kf.GetMap("pipeline"); pipeline != nil && pipeline.IsEmpty() {
+		if _, err := kf.RemoveNestedField("pipeline"); err != nil {
+			return "", err
+		}
+	}
+	resources := map[string]string{}
+	if err := kf.WriteToPackage(resources); err != nil {
+		return "", fmt.Errorf("write Kptfile: %w", err)
+	}
+	return resources[kptfileapi.KptFileName], nil
+}
+
+// parse reads the Kptfile the same way as the package reader does, so that the indentation of
+// sequences is kept when it is written back.
+func parse(kptfile string) (*kptfileko.KptfileKubeObject, error) {
+	kf, err := kptfileko.NewFromPackage(map[string]string{kptfileapi.KptFileName: kptfile})
+	if err != nil {
+		return nil, fmt.Errorf("parse Kptfile: %w", err)
+	}
+	return kf, nil
+}
+
+// Validate checks that every function has an image and that function names are unique in each list.
+func Validate(spec porchapi.PackageRevisionPipelineSpec) error {
+	if err := validateFunctions(mutatorsField, spec.Mutators); err != nil {
+		return err
+	}
+	return validateFunctions(validatorsField, spec.Validators)
+}
+
+func validateFunctions(field string, functions []porchapi.PipelineFunction) error {
+	names := map[string]bool{}
+	for i, function := range functions {
+		if function.Image == "" {
+			return fmt.Errorf("%s[%d]: image is required", field, i)
+		}
+		if function.ConfigPath != "" && len(function.ConfigMap) > 0 {
+			return fmt.Errorf("%s[%d]: configPath and configMap are mutually exclusive", field, i)
+		}
+		if function.Name == "" {
+			continue
+		}
+		if names[function.Name] {
+			return fmt.Errorf("%s[%d]: duplicate function name %q", field, i, function.Name)
+		}
+		names[function.Name] = true
+	}
+	return nil
+}
+
+func getFunctions(kf *kptfileko.KptfileKubeObject, field string) ([]porchapi.PipelineFunction, error) {
+	objs, _, err := kf.NestedSlice("pipeline", field)
+	if err != nil {
+		return nil, fmt.Errorf("read pipeline %s: %w", field, err)
+	}
+
+	var functions []porchapi.PipelineFunction
+	for i, obj := range objs {
+		configMap, _, err := obj.NestedStringMap("configMap")
+		if err != nil {
+			return nil, fmt.Errorf("read pipeline %s[%d] configMap: %w", field, i, err)
+		}
+		functions = append(functions, porchapi.PipelineFunction{
+			Name:       obj.GetString("name"),
+			Image:      obj.GetString("image"),
+			ConfigPath: obj.GetString("configPath"),
+			ConfigMap:  configMap,
+		})
+	}
+	return functions, nil
+}
+
+func setFunctions(kf *kptfileko.KptfileKubeObject, field string, functions []porchapi.PipelineFunction) error {
+	existing, _, err := kf.NestedSlice("pipeline", field)
+	if err != nil {
+		return fmt.Errorf("read pipeline %s: %w", field, err)
+	}
+
+	used := make([]bool, len(existing))
+	objs := make(kptfn.SliceSubObjects, 0, len(functions))
+	for _, function := range functions {
+		obj := takeMatching(existing, used, function)
+		if obj == nil {
+			newObj, err := kptfn.NewFromTypedObject(map[string]string{"image": function.Image})
+			if err != nil {
+				return err
+			}
+			obj = &newObj.SubObject
+		}
+		if err := updateFunction(obj, function); err != nil {
+			return fmt.Errorf("update pipeline %s: %w", field, err)
+		}
+		objs = append(objs, obj)
+	}
+
+	if len(objs) == 0 {
+		if pipeline := kf.GetMap("pipeline"); pipeline != nil {
+			_, err := pipeline.RemoveNestedField(field)
+			return err
+		}
+		return nil
+	}
+	return kf.UpsertMap("pipeline").SetSlice(objs, field)
+}
+
+// takeMatching returns the first unused function in existing that is the same function as the
+// given one, and marks it as used.
+func takeMatching(existing kptfn.SliceSubObjects, used []bool, function porchapi.PipelineFunction) *kptfn.SubObject {
+	for i, obj := range existing {
+		if used[i] {
+			continue
+		}
+		name := obj.GetString("name")
+		var match bool
+		if function.Name != "" || name != "" {
+			match = name == function.Name
+		} else {
+			match = obj.GetString("image") == function.Image && obj.GetString("configPath") == function.ConfigPath
+		}
+		if match {
+			used[i] = true
+			return obj
+		}
+	}
+	return nil
+}
+
+func updateFunction(obj *kptfn.SubObject, function porchapi.PipelineFunction) error {
+	if err := SetOrRemoveString(obj, "name", function.Name); err != nil {
+		return err
+	}
+	if err := SetOrRemoveString(obj, "image", function.Image); err != nil {
+		return err
+	}
+	if err := SetOrRemoveString(obj, "configPath", function.ConfigPath); err != nil {
+		return err
+	}
+
+	current, _, err := obj.NestedStringMap("configMap")
+	if err != nil {
+		return err
+	}
+	if len(function.ConfigMap) == 0 {
+		_, err := obj.RemoveNestedField("configMap")
+		return err
+	}
+	if maps.Equal(current, function.ConfigMap) {
+		// Leave the map untouched so that its comments and ordering are kept.
+		return nil
+	}
+	return obj.SetNestedStringMap(function.ConfigMap, "configMap")
+}
+
+// SetOrRemoveString sets the string field of the object to the value, or
+// removes the field if the value is empty. An unchanged field is left as it is.
+func SetOrRemoveString(obj *kptfn.SubObject, field, value string) error {
+	if value == "" {
+		_, err := obj.RemoveNestedField(field)
+		return err
+	}
+	if obj.GetString(field) == value {
+		return nil
+	}
+	return obj.SetNestedString(value, field)
+}
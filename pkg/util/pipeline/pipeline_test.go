@@ -0,0 +1,194 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"testing"
+
+	porchapi "github.com/kptdev/porch/api/porch/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: example # the package name
+info:
+  description: example package
+pipeline:
+  # mutators run in order
+  mutators:
+    - image: ghcr.io/kptdev/krm-functions-catalog/set-namespace:v0.4.1
+      configMap:
+        namespace: example # target namespace
+    - name: labels
+      image: ghcr.io/kptdev/krm-functions-catalog/set-labels:v0.2.0
+      configPath: labels.yaml
+      selectors:
+        - kind: Deployment
+  validators:
+    - image: ghcr.io/kptdev/krm-functions-catalog/kubeval:v0.3.0
+`
+
+func TestGet(t *testing.T) {
+	spec, err := Get(testKptfile)
+	require.NoError(t, err)
+
+	assert.Equal(t, porchapi.PackageRevisionPipelineSpec{
+		Mutators: []porchapi.PipelineFunction{
+			{
+				Image:     "ghcr.io/kptdev/krm-functions-catalog/set-namespace:v0.4.1",
+				ConfigMap: map[string]string{"namespace": "example"},
+			},
+			{
+				Name:       "labels",
+				Image:      "ghcr.io/kptdev/krm-functions-catalog/set-labels:v0.2.0",
+				ConfigPath: "labels.yaml",
+			},
+		},
+		Validators: []porchapi.PipelineFunction{
+			{Image: "ghcr.io/kptdev/krm-functions-catalog/kubeval:v0.3.0"},
+		},
+	}, spec)
+}
+
+func TestGetNoPipeline(t *testing.T) {
+	spec, err := Get("apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: example\n")
+	require.NoError(t, err)
+	assert.Empty(t, spec.Mutators)
+	assert.Empty(t, spec.Validators)
+
+	_, err = Get("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: example\n")
+	assert.Error(t, err)
+}
+
+func TestSetUnchanged(t *testing.T) {
+	spec, err := Get(testKptfile)
+	require.NoError(t, err)
+
+	kptfile, err := Set(testKptfile, spec)
+	require.NoError(t, err)
+	assert.Equal(t, testKptfile, kptfile)
+}
+
+func TestSetAddMoveAndRemove(t *testing.T) {
+	spec, err := Get(testKptfile)
+	require.NoError(t, err)
+
+	// Move the labels function to the front, change its config and add a new function at the end.
+	spec.Mutators = []porchapi.PipelineFunction{
+		{
+			Name:       "labels",
+			Image:      "ghcr.io/kptdev/krm-functions-catalog/set-labels:v0.2.0",
+			ConfigPath: "other-labels.yaml",
+		},
+		spec.Mutators[0],
+		{
+			Name:      "annotations",
+			Image:     "ghcr.io/kptdev/krm-functions-catalog/set-annotations:v0.1.4",
+			ConfigMap: map[string]string{"team": "a"},
+		},
+	}
+	spec.Validators = nil
+
+	kptfile, err := Set(testKptfile, spec)
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: example # the package name
+info:
+  description: example package
+pipeline:
+  # mutators run in order
+  mutators:
+    - name: labels
+      image: ghcr.io/kptdev/krm-functions-catalog/set-labels:v0.2.0
+      configPath: other-labels.yaml
+      selectors:
+        - kind: Deployment
+    - image: ghcr.io/kptdev/krm-functions-catalog/set-namespace:v0.4.1
+      configMap:
+        namespace: example # target namespace
+    - image: ghcr.io/kptdev/krm-functions-catalog/set-annotations:v0.1.4
+      name: annotations
+      configMap:
+        team: a
+`, kptfile)
+
+	roundTrip, err := Get(kptfile)
+	require.NoError(t, err)
+	assert.Equal(t, spec, roundTrip)
+}
+
+func TestSetRemovesEmptyPipeline(t *testing.T) {
+	kptfile, err := Set(testKptfile, porchapi.PackageRevisionPipelineSpec{})
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: example # the package name
+info:
+  description: example package
+`, kptfile)
+}
+
+func TestValidate(t *testing.T) {
+	testCases := map[string]struct {
+		spec    porchapi.PackageRevisionPipelineSpec
+		wantErr string
+	}{
+		"valid": {
+			spec: porchapi.PackageRevisionPipelineSpec{
+				Mutators:   []porchapi.PipelineFunction{{Name: "a", Image: "a:v1"}, {Image: "a:v1"}},
+				Validators: []porchapi.PipelineFunction{{Name: "a", Image: "b:v1"}},
+			},
+		},
+		"missing image": {
+			spec: porchapi.PackageRevisionPipelineSpec{
+				Validators: []porchapi.PipelineFunction{{Name: "a"}},
+			},
+			wantErr: "validators[0]: image is required",
+		},
+		"duplicate name": {
+			spec: porchapi.PackageRevisionPipelineSpec{
+				Mutators: []porchapi.PipelineFunction{{Name: "a", Image: "a:v1"}, {Name: "a", Image: "b:v1"}},
+			},
+			wantErr: `mutators[1]: duplicate function name "a"`,
+		},
+		"config path and config map": {
+			spec: porchapi.PackageRevisionPipelineSpec{
+				Mutators: []porchapi.PipelineFunction{{
+					Image:      "a:v1",
+					ConfigPath: "config.yaml",
+					ConfigMap:  map[string]string{"k": "v"},
+				}},
+			},
+			wantErr: "mutators[0]: configPath and configMap are mutually exclusive",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.spec)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package template instantiates starter template packages. A template is an
+// ordinary kpt package that declares its parameters in a TemplateParameters
+// resource. Instantiating it validates the supplied values against those
+// declarations, writes them to a ConfigMap that the pipeline of the template
+// can read (for example with apply-setters), and turns the Kptfile of the
+// template into the Kptfile of a new package that does not track the template
+// as its upstream.
+package template
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	kptfn "github.com/kptdev/krm-functions-sdk/go/fn"
+	kptfileapi "github.com/kptdev/krm-functions-sdk/go/fn/kptfileapi"
+	kptfileko "github.com/kptdev/krm-functions-sdk/go/fn/kptfileko"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ParametersAPIVersion is the apiVersion of the TemplateParameters resource
+	ParametersAPIVersion = "config.porch.kpt.dev/v1alpha1"
+	// ParametersKind is the kind of the resource declaring the parameters of a template
+	ParametersKind = "TemplateParameters"
+	// ValuesConfigMapName is the name of the ConfigMap the parameter values are written to
+	ValuesConfigMapName = "template-values"
+	// ValuesFileName is the file of the new package holding the parameter values
+	ValuesFileName = "template-values.yaml"
+
+	localConfigKey     = "config.kubernetes.io/local-config"
+	valuesConfigMapAPI = "v1"
+)
+
+// ParameterType is the type of a template parameter value
+type ParameterType string
+
+const (
+	ParameterTypeString  ParameterType = "string"
+	ParameterTypeInteger ParameterType = "integer"
+	ParameterTypeBoolean ParameterType = "boolean"
+)
+
+// Parameter declares a parameter of a template
+type Parameter struct {
+	// Name of the parameter, used as the key of its value
+	Name string `json:"name"`
+	// Description tells users what the parameter is for
+	Description string `json:"description,omitempty"`
+	// Type of the value, string if empty
+	Type ParameterType `json:"type,omitempty"`
+	// Required parameters must be given a value unless they have a default
+	Required bool `json:"required,omitempty"`
+	// Default is used when no value is given
+	Default *string `json:"default,omitempty"`
+	// Pattern is a regular expression the whole value must match
+	Pattern string `json:"pattern,omitempty"`
+	// Enum lists the allowed values
+	Enum []string `json:"enum,omitempty"`
+}
+
+// Parameters is the TemplateParameters resource of a template
+type Parameters struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Spec       struct {
+		Parameters []Parameter `json:"parameters,omitempty"`
+	} `json:"spec"`
+}
+
+// Options describe the package created from a template
+type Options struct {
+	// PackageName is the name of the new package
+	PackageName string
+	// Description, Keywords and Site replace those of the template when set
+	Description string
+	Keywords    []string
+	Site        string
+	// Values are the parameter values supplied by the user
+	Values map[string]string
+}
+
+// Instantiate returns the resources of a new package created from the
+// resources of a template. The TemplateParameters resource is dropped, the
+// resolved parameter values are written to ValuesFileName, and the Kptfile is
+// renamed and loses the upstream, upstream lock and status of the template.
+// Rendering the pipeline is left to the caller.
+func Instantiate(resources map[string]string, opts Options) (map[string]string, error) {
+	if _, found := resources[kptfileapi.KptFileName]; !found {
+		return nil, fmt.Errorf("template is not a kpt package: %s not found", kptfileapi.KptFileName)
+	}
+
+	result := maps.Clone(resources)
+	parameters, err := extractParameters(result)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := Validate(parameters, opts.Values)
+	if err != nil {
+		return nil, err
+	}
+	if parameters != nil {
+		valuesConfigMap, err := newValuesConfigMap(values)
+		if err != nil {
+			return nil, err
+		}
+		result[ValuesFileName] = valuesConfigMap
+	}
+
+	if err := instantiateKptfile(result, opts); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Validate checks the supplied values against the declared parameters and
+// returns the values with defaults filled in. All problems are reported
+// together. A template without a TemplateParameters resource (nil
+// parameters) accepts no values.
+func Validate(parameters *Parameters, values map[string]string) (map[string]string, error) {
+	var declared []Parameter
+	if parameters != nil {
+		declared = parameters.Spec.Parameters
+	}
+
+	var errs []error
+	resolved := map[string]string{}
+	known := map[string]bool{}
+	for _, parameter := range declared {
+		err := checkDeclaration(parameter, known)
+		known[parameter.Name] = true
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		value, found := values[parameter.Name]
+		if !found && parameter.Default != nil {
+			value, found = *parameter.Default, true
+		}
+		if !found {
+			if parameter.Required {
+				errs = append(errs, fmt.Errorf("parameter %q is required", parameter.Name))
+			}
+			continue
+		}
+		if err := checkValue(parameter, value); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		resolved[parameter.Name] = value
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if !known[name] {
+			errs = append(errs, fmt.Errorf("template declares no parameter %q", name))
+		}
+	}
+
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("invalid template parameters: %w", errors.Join(errs...))
+	}
+	return resolved, nil
+}
+
+// checkDeclaration checks a parameter declaration of the template itself
+func checkDeclaration(parameter Parameter, known map[string]bool) error {
+	switch {
+	case parameter.Name == "":
+		return fmt.Errorf("template declares a parameter without a name")
+	case known[parameter.Name]:
+		return fmt.Errorf("template declares parameter %q more than once", parameter.Name)
+	}
+	switch parameter.Type {
+	case "", ParameterTypeString, ParameterTypeInteger, ParameterTypeBoolean:
+	default:
+		return fmt.Errorf("template parameter %q has unsupported type %q", parameter.Name, parameter.Type)
+	}
+	if parameter.Pattern != "" {
+		if _, err := regexp.Compile(parameter.Pattern); err != nil {
+			return fmt.Errorf("template parameter %q has an invalid pattern: %w", parameter.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkValue checks a value against the declaration of its parameter
+func checkValue(parameter Parameter, value string) error {
+	switch parameter.Type {
+	case ParameterTypeInteger:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("parameter %q must be an integer, got %q", parameter.Name, value)
+		}
+	case ParameterTypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("parameter %q must be a boolean, got %q", parameter.Name, value)
+		}
+	}
+	if len(parameter.Enum) != 0 && !slices.Contains(parameter.Enum, value) {
+		return fmt.Errorf("parameter %q must be one of %s, got %q", parameter.Name, strings.Join(parameter.Enum, ", "), value)
+	}
+	if parameter.Pattern != "" {
+		if !regexp.MustCompile(`^(?:` + parameter.Pattern + `)$`).MatchString(value) {
+			return fmt.Errorf("parameter %q must match %q, got %q", parameter.Name, parameter.Pattern, value)
+		}
+	}
+	return nil
+}
+
+// extractParameters removes the TemplateParameters resource from the
+// resources and returns it, or nil if the template declares no parameters
+func extractParameters(resources map[string]string) (*Parameters, error) {
+	var parameters *Parameters
+	for _, name := range slices.Sorted(maps.Keys(resources)) {
+		if ext := path.Ext(name); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		if !strings.Contains(resources[name], ParametersKind) {
+			continue
+		}
+
+		objs, err := kptfn.ParseKubeObjects([]byte(resources[name]))
+		if err != nil {
+			// Not every YAML file of a package holds KRM resources
+			continue
+		}
+		var kept []string
+		for _, obj := range objs {
+			if obj.GetAPIVersion() != ParametersAPIVersion || obj.GetKind() != ParametersKind {
+				kept = append(kept, obj.String())
+				continue
+			}
+			if parameters != nil {
+				return nil, fmt.Errorf("template declares more than one %s resource", ParametersKind)
+			}
+			parameters = &Parameters{}
+			if err := yaml.Unmarshal([]byte(obj.String()), parameters); err != nil {
+				return nil, fmt.Errorf("failed to parse %s in %s: %w", ParametersKind, name, err)
+			}
+		}
+
+		switch {
+		case len(kept) == len(objs):
+		case len(kept) == 0:
+			delete(resources, name)
+		default:
+			resources[name] = strings.Join(kept, "---\n")
+		}
+	}
+	return parameters, nil
+}
+
+// newValuesConfigMap returns the ConfigMap holding the parameter values
+func newValuesConfigMap(values map[string]string) (string, error) {
+	configMap := kptfn.NewEmptyKubeObject()
+	if err := configMap.SetAPIVersion(valuesConfigMapAPI); err != nil {
+		return "", err
+	}
+	if err := configMap.SetKind("ConfigMap"); err != nil {
+		return "", err
+	}
+	if err := configMap.SetName(ValuesConfigMapName); err != nil {
+		return "", err
+	}
+	if err := configMap.SetAnnotation(localConfigKey, "true"); err != nil {
+		return "", err
+	}
+	if len(values) != 0 {
+		if err := configMap.SetNestedStringMap(values, "data"); err != nil {
+			return "", err
+		}
+	}
+	return configMap.String(), nil
+}
+
+// instantiateKptfile turns the Kptfile of the template into the Kptfile of
+// the new package
+func instantiateKptfile(resources map[string]string, opts Options) error {
+	kf, err := kptfileko.NewFromPackage(resources)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s of template: %w", kptfileapi.KptFileName, err)
+	}
+
+	if err := kf.SetName(opts.PackageName); err != nil {
+		return err
+	}
+	for _, field := range []string{"upstream", "upstreamLock", "status"} {
+		if _, err := kf.RemoveNestedField(field); err != nil {
+			return err
+		}
+	}
+	if opts.Description != "" {
+		if err := kf.SetNestedString(opts.Description, "info", "description"); err != nil {
+			return err
+		}
+	}
+	if len(opts.Keywords) != 0 {
+		if err := kf.SetNestedStringSlice(opts.Keywords, "info", "keywords"); err != nil {
+			return err
+		}
+	}
+	if opts.Site != "" {
+		if err := kf.SetNestedString(opts.Site, "info", "site"); err != nil {
+			return err
+		}
+	}
+	return kf.WriteToPackage(resources)
+}
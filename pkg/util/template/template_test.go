@@ -0,0 +1,267 @@
+// Copyright 2026 The kpt Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"testing"
+
+	kptfileko "github.com/kptdev/krm-functions-sdk/go/fn/kptfileko"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+const templateKptfile = `apiVersion: kpt.dev/v1
+kind: Kptfile
+metadata:
+  name: starter
+  annotations:
+    config.kubernetes.io/local-config: "true"
+upstream:
+  type: git
+  git:
+    repo: https://github.com/example/templates.git
+    directory: starter
+    ref: main
+upstreamLock:
+  type: git
+  git:
+    repo: https://github.com/example/templates.git
+    directory: starter
+    ref: main
+    commit: 0123456789abcdef
+info:
+  description: Starter template
+  site: https://example.com/starter
+pipeline:
+  mutators:
+  - image: ghcr.io/kptdev/krm-functions-catalog/apply-setters:v0.2
+    configPath: template-values.yaml
+status:
+  conditions:
+  - type: Rendered
+    status: "True"
+`
+
+const templateParameters = `apiVersion: config.porch.kpt.dev/v1alpha1
+kind: TemplateParameters
+metadata:
+  name: parameters
+  annotations:
+    config.kubernetes.io/local-config: "true"
+spec:
+  parameters:
+  - name: team
+    description: Team owning the package
+    required: true
+    pattern: "[a-z][a-z-]*"
+  - name: replicas
+    type: integer
+    default: "2"
+  - name: tier
+    enum: [gold, silver]
+  - name: monitored
+    type: boolean
+`
+
+func templateResources() map[string]string {
+	return map[string]string{
+		"Kptfile":         templateKptfile,
+		"parameters.yaml": templateParameters,
+		"template-values.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: template-values
+data:
+  team: example
+`,
+		"deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app # kpt-set: ${team}-app
+`,
+		"README.md": "TemplateParameters are documented elsewhere\n",
+	}
+}
+
+func TestInstantiate(t *testing.T) {
+	resources := templateResources()
+	result, err := Instantiate(resources, Options{
+		PackageName: "payments",
+		Description: "Payments service",
+		Keywords:    []string{"payments"},
+		Values:      map[string]string{"team": "payments", "tier": "gold"},
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, result, "parameters.yaml", "the parameter declarations belong to the template only")
+	assert.Equal(t, resources["deployment.yaml"], result["deployment.yaml"])
+	assert.Equal(t, resources["README.md"], result["README.md"])
+	assert.Contains(t, resources, "parameters.yaml", "the template resources must not be modified")
+
+	assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: template-values
+  annotations:
+    config.kubernetes.io/local-config: "true"
+data:
+  replicas: "2"
+  team: payments
+  tier: gold
+`, result[ValuesFileName])
+
+	kf, err := kptfileko.NewFromPackage(result)
+	require.NoError(t, err)
+	assert.Equal(t, "payments", kf.GetName())
+	for _, field := range []string{"upstream", "upstreamLock", "status"} {
+		_, found, err := kf.NestedSubObject(field)
+		require.NoError(t, err)
+		assert.False(t, found, "%s must be removed", field)
+	}
+	description, _, _ := kf.NestedString("info", "description")
+	assert.Equal(t, "Payments service", description)
+	keywords, _, _ := kf.NestedStringSlice("info", "keywords")
+	assert.Equal(t, []string{"payments"}, keywords)
+	site, _, _ := kf.NestedString("info", "site")
+	assert.Equal(t, "https://example.com/starter", site, "info is kept unless overridden")
+	pipeline, found, _ := kf.NestedSlice("pipeline", "mutators")
+	assert.True(t, found)
+	assert.Len(t, pipeline, 1)
+}
+
+func TestInstantiateSharedFile(t *testing.T) {
+	resources := map[string]string{
+		"Kptfile": templateKptfile,
+		"resources.yaml": `apiVersion: v1
+kind: Namespace
+metadata:
+  name: app
+---
+` + templateParameters,
+	}
+
+	result, err := Instantiate(resources, Options{PackageName: "app", Values: map[string]string{"team": "app"}})
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: Namespace
+metadata:
+  name: app
+`, result["resources.yaml"])
+}
+
+func TestInstantiateWithoutParameters(t *testing.T) {
+	resources := map[string]string{"Kptfile": templateKptfile}
+
+	result, err := Instantiate(resources, Options{PackageName: "app"})
+	require.NoError(t, err)
+	assert.NotContains(t, result, ValuesFileName)
+
+	_, err = Instantiate(resources, Options{PackageName: "app", Values: map[string]string{"team": "app"}})
+	assert.ErrorContains(t, err, `template declares no parameter "team"`)
+}
+
+func TestInstantiateErrors(t *testing.T) {
+	_, err := Instantiate(map[string]string{"parameters.yaml": templateParameters}, Options{PackageName: "app"})
+	assert.ErrorContains(t, err, "template is not a kpt package")
+
+	_, err = Instantiate(map[string]string{
+		"Kptfile": templateKptfile,
+		"a.yaml":  templateParameters,
+		"b.yaml":  templateParameters,
+	}, Options{PackageName: "app"})
+	assert.ErrorContains(t, err, "more than one TemplateParameters")
+}
+
+func TestValidate(t *testing.T) {
+	parameters := &Parameters{}
+	parameters.Spec.Parameters = []Parameter{
+		{Name: "team", Required: true, Pattern: "[a-z]+"},
+		{Name: "replicas", Type: ParameterTypeInteger, Default: ptr.To("1")},
+		{Name: "monitored", Type: ParameterTypeBoolean},
+		{Name: "tier", Enum: []string{"gold", "silver"}},
+	}
+
+	tests := []struct {
+		name     string
+		values   map[string]string
+		expected map[string]string
+		errors   []string
+	}{
+		{
+			name:     "defaults",
+			values:   map[string]string{"team": "web"},
+			expected: map[string]string{"team": "web", "replicas": "1"},
+		},
+		{
+			name:     "all values",
+			values:   map[string]string{"team": "web", "replicas": "3", "monitored": "true", "tier": "silver"},
+			expected: map[string]string{"team": "web", "replicas": "3", "monitored": "true", "tier": "silver"},
+		},
+		{
+			name:   "missing required value",
+			values: map[string]string{},
+			errors: []string{`parameter "team" is required`},
+		},
+		{
+			name:   "pattern must match the whole value",
+			values: map[string]string{"team": "web-1"},
+			errors: []string{`parameter "team" must match "[a-z]+", got "web-1"`},
+		},
+		{
+			name:   "all problems are reported",
+			values: map[string]string{"team": "web", "replicas": "many", "monitored": "maybe", "tier": "bronze", "owner": "me"},
+			errors: []string{
+				`parameter "replicas" must be an integer, got "many"`,
+				`parameter "monitored" must be a boolean, got "maybe"`,
+				`parameter "tier" must be one of gold, silver, got "bronze"`,
+				`template declares no parameter "owner"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := Validate(parameters, tt.values)
+			if len(tt.errors) == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, resolved)
+				return
+			}
+			require.Error(t, err)
+			for _, msg := range tt.errors {
+				assert.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}
+
+func TestValidateDeclarations(t *testing.T) {
+	parameters := &Parameters{}
+	parameters.Spec.Parameters = []Parameter{
+		{Name: ""},
+		{Name: "size", Type: "float"},
+		{Name: "team", Pattern: "["},
+		{Name: "team"},
+	}
+
+	_, err := Validate(parameters, map[string]string{"size": "1"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "parameter without a name")
+	assert.ErrorContains(t, err, `parameter "size" has unsupported type "float"`)
+	assert.ErrorContains(t, err, `parameter "team" has an invalid pattern`)
+	assert.ErrorContains(t, err, `parameter "team" more than once`)
+	assert.NotContains(t, err.Error(), `no parameter "size"`)
+}
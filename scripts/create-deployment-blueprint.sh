@@ -355,6 +355,15 @@ function main() {
   cp "${CRDS_DIR}/config.porch.kpt.dev_servicetemplates.yaml" \
      "${DESTINATION}/0-servicetemplates.yaml"
 
+  cp "${CRDS_DIR}/config.porch.kpt.dev_sourcepolicies.yaml" \
+     "${DESTINATION}/0-sourcepolicies.yaml"
+
+  cp "${CRDS_DIR}/config.porch.kpt.dev_functionimagepolicies.yaml" \
+     "${DESTINATION}/0-functionimagepolicies.yaml"
+
+  cp "${CRDS_DIR}/config.porch.kpt.dev_packagerevisiondeploymentstatuses.yaml" \
+     "${DESTINATION}/0-packagerevisiondeploymentstatuses.yaml"
+
   # Porch Deployment Config
   cp ${PORCH_DIR}/deployments/porch/*.yaml "${PORCH_DIR}/deployments/porch/Kptfile" "${DESTINATION}"
 
@@ -395,6 +404,12 @@ function main() {
          "${DESTINATION}/0-${i}.yaml"
     fi
 
+    if [[ "${i}" == "packagevariants" ]]; then
+      # PackageVariants read InjectionGrants for cross-namespace injection
+      cp "${CRDS_DIR}/config.porch.kpt.dev_injectiongrants.yaml" \
+         "${DESTINATION}/0-injectiongrants.yaml"
+    fi
+
     # Copy over the rbac rules for the reconciler
     cp "${PORCH_DIR}/controllers/${i}/config/rbac/role.yaml" \
     "${DESTINATION}/9-porch-controller-${i}-clusterrole.yaml"
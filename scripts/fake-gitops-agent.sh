@@ -0,0 +1,90 @@
+#!/usr/bin/env bash
+# Copyright 2026 The kpt Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Fake GitOps agent for local testing of deployment feedback. It reports a
+# PackageRevisionDeploymentStatus for a package revision on a target, as a real
+# agent such as Flux or Argo CD would after syncing the package.
+#
+# Usage:
+#   fake-gitops-agent.sh [-n NAMESPACE] [-s STATE] [-m MESSAGE] PACKAGE_REVISION TARGET [KIND/NAME[=HEALTH] ...]
+#
+# STATE is one of Applied (default), Failed, Drifted or Progressing. Each resource
+# is reported as Healthy unless a HEALTH is given (Progressing, Degraded, Missing
+# or Unknown). Run it again to update the report, or delete the object with
+#   kubectl delete packagerevisiondeploymentstatuses -n NAMESPACE PACKAGE_REVISION.TARGET
+
+set -e
+
+function error() {
+  echo "$@" >&2
+  exit 1
+}
+
+NAMESPACE=default
+STATE=Applied
+MESSAGE=""
+
+while getopts "n:s:m:" opt; do
+  case "${opt}" in
+    n) NAMESPACE="${OPTARG}" ;;
+    s) STATE="${OPTARG}" ;;
+    m) MESSAGE="${OPTARG}" ;;
+    *) error "usage: ${0} [-n NAMESPACE] [-s STATE] [-m MESSAGE] PACKAGE_REVISION TARGET [KIND/NAME[=HEALTH] ...]" ;;
+  esac
+done
+shift $((OPTIND - 1))
+
+if [[ $# -lt 2 ]]; then
+  error "${0} requires a package revision and a target"
+fi
+PACKAGE_REVISION="${1}"
+TARGET="${2}"
+shift 2
+
+# The agent syncs the commit of the package revision.
+COMMIT=$(kubectl get packagerevisions.porch.kpt.dev -n "${NAMESPACE}" "${PACKAGE_REVISION}" \
+  -o jsonpath='{.status.selfLock.git.commit}')
+
+RESOURCES=""
+for resource in "$@"; do
+  kind="${resource%%/*}"
+  name="${resource#*/}"
+  health=Healthy
+  if [[ "${name}" == *=* ]]; then
+    health="${name#*=}"
+    name="${name%%=*}"
+  fi
+  RESOURCES+="
+    - kind: ${kind}
+      namespace: ${NAMESPACE}
+      name: ${name}
+      health: ${health}"
+done
+
+kubectl apply -f - <<YAML
+apiVersion: config.porch.kpt.dev/v1alpha1
+kind: PackageRevisionDeploymentStatus
+metadata:
+  name: ${PACKAGE_REVISION}.${TARGET}
+  namespace: ${NAMESPACE}
+spec:
+  packageRevision: ${PACKAGE_REVISION}
+  target: ${TARGET}
+  syncedCommit: "${COMMIT}"
+  state: ${STATE}
+  message: "${MESSAGE}"
+  lastSyncTime: $(date -u +%Y-%m-%dT%H:%M:%SZ)
+  resources:${RESOURCES:- []}
+YAML
@@ -159,6 +159,80 @@ func (_c *MockCache_CloseRepository_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// FindAllDownstreamPackageRevisions provides a mock function for the type MockCache
+func (_mock *MockCache) FindAllDownstreamPackageRevisions(ctx context.Context, namespace string, prName string) ([]string, error) {
+	ret := _mock.Called(ctx, namespace, prName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllDownstreamPackageRevisions")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return returnFunc(ctx, namespace, prName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = returnFunc(ctx, namespace, prName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, namespace, prName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCache_FindAllDownstreamPackageRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAllDownstreamPackageRevisions'
+type MockCache_FindAllDownstreamPackageRevisions_Call struct {
+	*mock.Call
+}
+
+// FindAllDownstreamPackageRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - prName string
+func (_e *MockCache_Expecter) FindAllDownstreamPackageRevisions(ctx interface{}, namespace interface{}, prName interface{}) *MockCache_FindAllDownstreamPackageRevisions_Call {
+	return &MockCache_FindAllDownstreamPackageRevisions_Call{Call: _e.mock.On("FindAllDownstreamPackageRevisions", ctx, namespace, prName)}
+}
+
+func (_c *MockCache_FindAllDownstreamPackageRevisions_Call) Run(run func(ctx context.Context, namespace string, prName string)) *MockCache_FindAllDownstreamPackageRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCache_FindAllDownstreamPackageRevisions_Call) Return(strings []string, err error) *MockCache_FindAllDownstreamPackageRevisions_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockCache_FindAllDownstreamPackageRevisions_Call) RunAndReturn(run func(ctx context.Context, namespace string, prName string) ([]string, error)) *MockCache_FindAllDownstreamPackageRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindAllUpstreamReferencesInRepositories provides a mock function for the type MockCache
 func (_mock *MockCache) FindAllUpstreamReferencesInRepositories(ctx context.Context, namespace string, prName string) (string, error) {
 	ret := _mock.Called(ctx, namespace, prName)
@@ -41,6 +41,69 @@ func (_m *MockCaDEngine) EXPECT() *MockCaDEngine_Expecter {
 	return &MockCaDEngine_Expecter{mock: &_m.Mock}
 }
 
+// BatchRepositoryUpdates provides a mock function for the type MockCaDEngine
+func (_mock *MockCaDEngine) BatchRepositoryUpdates(ctx context.Context, repositoryObj *v1alpha1.Repository, updates func(ctx context.Context) error) error {
+	ret := _mock.Called(ctx, repositoryObj, updates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchRepositoryUpdates")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.Repository, func(ctx context.Context) error) error); ok {
+		r0 = returnFunc(ctx, repositoryObj, updates)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCaDEngine_BatchRepositoryUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchRepositoryUpdates'
+type MockCaDEngine_BatchRepositoryUpdates_Call struct {
+	*mock.Call
+}
+
+// BatchRepositoryUpdates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryObj *v1alpha1.Repository
+//   - updates func(ctx context.Context) error
+func (_e *MockCaDEngine_Expecter) BatchRepositoryUpdates(ctx interface{}, repositoryObj interface{}, updates interface{}) *MockCaDEngine_BatchRepositoryUpdates_Call {
+	return &MockCaDEngine_BatchRepositoryUpdates_Call{Call: _e.mock.On("BatchRepositoryUpdates", ctx, repositoryObj, updates)}
+}
+
+func (_c *MockCaDEngine_BatchRepositoryUpdates_Call) Run(run func(ctx context.Context, repositoryObj *v1alpha1.Repository, updates func(ctx context.Context) error)) *MockCaDEngine_BatchRepositoryUpdates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *v1alpha1.Repository
+		if args[1] != nil {
+			arg1 = args[1].(*v1alpha1.Repository)
+		}
+		var arg2 func(ctx context.Context) error
+		if args[2] != nil {
+			arg2 = args[2].(func(ctx context.Context) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCaDEngine_BatchRepositoryUpdates_Call) Return(err error) *MockCaDEngine_BatchRepositoryUpdates_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCaDEngine_BatchRepositoryUpdates_Call) RunAndReturn(run func(ctx context.Context, repositoryObj *v1alpha1.Repository, updates func(ctx context.Context) error) error) *MockCaDEngine_BatchRepositoryUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreatePackageRevision provides a mock function for the type MockCaDEngine
 func (_mock *MockCaDEngine) CreatePackageRevision(ctx context.Context, repositoryObj *v1alpha1.Repository, obj *v1alpha10.PackageRevision, parent repository.PackageRevision) (repository.PackageRevision, error) {
 	ret := _mock.Called(ctx, repositoryObj, obj, parent)
@@ -184,6 +247,148 @@ func (_c *MockCaDEngine_DeletePackageRevision_Call) RunAndReturn(run func(ctx co
 	return _c
 }
 
+// ExportRepository provides a mock function for the type MockCaDEngine
+func (_mock *MockCaDEngine) ExportRepository(ctx context.Context, repositoryObj *v1alpha1.Repository) ([]v1alpha10.BundledPackageRevision, error) {
+	ret := _mock.Called(ctx, repositoryObj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportRepository")
+	}
+
+	var r0 []v1alpha10.BundledPackageRevision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.Repository) ([]v1alpha10.BundledPackageRevision, error)); ok {
+		return returnFunc(ctx, repositoryObj)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.Repository) []v1alpha10.BundledPackageRevision); ok {
+		r0 = returnFunc(ctx, repositoryObj)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1alpha10.BundledPackageRevision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *v1alpha1.Repository) error); ok {
+		r1 = returnFunc(ctx, repositoryObj)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCaDEngine_ExportRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportRepository'
+type MockCaDEngine_ExportRepository_Call struct {
+	*mock.Call
+}
+
+// ExportRepository is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryObj *v1alpha1.Repository
+func (_e *MockCaDEngine_Expecter) ExportRepository(ctx interface{}, repositoryObj interface{}) *MockCaDEngine_ExportRepository_Call {
+	return &MockCaDEngine_ExportRepository_Call{Call: _e.mock.On("ExportRepository", ctx, repositoryObj)}
+}
+
+func (_c *MockCaDEngine_ExportRepository_Call) Run(run func(ctx context.Context, repositoryObj *v1alpha1.Repository)) *MockCaDEngine_ExportRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *v1alpha1.Repository
+		if args[1] != nil {
+			arg1 = args[1].(*v1alpha1.Repository)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCaDEngine_ExportRepository_Call) Return(bundledPackageRevisions []v1alpha10.BundledPackageRevision, err error) *MockCaDEngine_ExportRepository_Call {
+	_c.Call.Return(bundledPackageRevisions, err)
+	return _c
+}
+
+func (_c *MockCaDEngine_ExportRepository_Call) RunAndReturn(run func(ctx context.Context, repositoryObj *v1alpha1.Repository) ([]v1alpha10.BundledPackageRevision, error)) *MockCaDEngine_ExportRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAllDownstreamPackageRevisions provides a mock function for the type MockCaDEngine
+func (_mock *MockCaDEngine) FindAllDownstreamPackageRevisions(ctx context.Context, namespace string, prName string) ([]string, error) {
+	ret := _mock.Called(ctx, namespace, prName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllDownstreamPackageRevisions")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return returnFunc(ctx, namespace, prName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = returnFunc(ctx, namespace, prName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, namespace, prName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCaDEngine_FindAllDownstreamPackageRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAllDownstreamPackageRevisions'
+type MockCaDEngine_FindAllDownstreamPackageRevisions_Call struct {
+	*mock.Call
+}
+
+// FindAllDownstreamPackageRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - prName string
+func (_e *MockCaDEngine_Expecter) FindAllDownstreamPackageRevisions(ctx interface{}, namespace interface{}, prName interface{}) *MockCaDEngine_FindAllDownstreamPackageRevisions_Call {
+	return &MockCaDEngine_FindAllDownstreamPackageRevisions_Call{Call: _e.mock.On("FindAllDownstreamPackageRevisions", ctx, namespace, prName)}
+}
+
+func (_c *MockCaDEngine_FindAllDownstreamPackageRevisions_Call) Run(run func(ctx context.Context, namespace string, prName string)) *MockCaDEngine_FindAllDownstreamPackageRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCaDEngine_FindAllDownstreamPackageRevisions_Call) Return(strings []string, err error) *MockCaDEngine_FindAllDownstreamPackageRevisions_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockCaDEngine_FindAllDownstreamPackageRevisions_Call) RunAndReturn(run func(ctx context.Context, namespace string, prName string) ([]string, error)) *MockCaDEngine_FindAllDownstreamPackageRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindAllUpstreamReferencesInRepositories provides a mock function for the type MockCaDEngine
 func (_mock *MockCaDEngine) FindAllUpstreamReferencesInRepositories(ctx context.Context, namespace string, prName string) (string, error) {
 	ret := _mock.Called(ctx, namespace, prName)
@@ -256,6 +461,94 @@ func (_c *MockCaDEngine_FindAllUpstreamReferencesInRepositories_Call) RunAndRetu
 	return _c
 }
 
+// ImportRepository provides a mock function for the type MockCaDEngine
+func (_mock *MockCaDEngine) ImportRepository(ctx context.Context, repositoryObj *v1alpha1.Repository, bundled []v1alpha10.BundledPackageRevision, dryRun bool) ([]string, []string, error) {
+	ret := _mock.Called(ctx, repositoryObj, bundled, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportRepository")
+	}
+
+	var r0 []string
+	var r1 []string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.Repository, []v1alpha10.BundledPackageRevision, bool) ([]string, []string, error)); ok {
+		return returnFunc(ctx, repositoryObj, bundled, dryRun)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.Repository, []v1alpha10.BundledPackageRevision, bool) []string); ok {
+		r0 = returnFunc(ctx, repositoryObj, bundled, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *v1alpha1.Repository, []v1alpha10.BundledPackageRevision, bool) []string); ok {
+		r1 = returnFunc(ctx, repositoryObj, bundled, dryRun)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *v1alpha1.Repository, []v1alpha10.BundledPackageRevision, bool) error); ok {
+		r2 = returnFunc(ctx, repositoryObj, bundled, dryRun)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockCaDEngine_ImportRepository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportRepository'
+type MockCaDEngine_ImportRepository_Call struct {
+	*mock.Call
+}
+
+// ImportRepository is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryObj *v1alpha1.Repository
+//   - bundled []v1alpha10.BundledPackageRevision
+//   - dryRun bool
+func (_e *MockCaDEngine_Expecter) ImportRepository(ctx interface{}, repositoryObj interface{}, bundled interface{}, dryRun interface{}) *MockCaDEngine_ImportRepository_Call {
+	return &MockCaDEngine_ImportRepository_Call{Call: _e.mock.On("ImportRepository", ctx, repositoryObj, bundled, dryRun)}
+}
+
+func (_c *MockCaDEngine_ImportRepository_Call) Run(run func(ctx context.Context, repositoryObj *v1alpha1.Repository, bundled []v1alpha10.BundledPackageRevision, dryRun bool)) *MockCaDEngine_ImportRepository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *v1alpha1.Repository
+		if args[1] != nil {
+			arg1 = args[1].(*v1alpha1.Repository)
+		}
+		var arg2 []v1alpha10.BundledPackageRevision
+		if args[2] != nil {
+			arg2 = args[2].([]v1alpha10.BundledPackageRevision)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCaDEngine_ImportRepository_Call) Return(imported []string, conflicts []string, err error) *MockCaDEngine_ImportRepository_Call {
+	_c.Call.Return(imported, conflicts, err)
+	return _c
+}
+
+func (_c *MockCaDEngine_ImportRepository_Call) RunAndReturn(run func(ctx context.Context, repositoryObj *v1alpha1.Repository, bundled []v1alpha10.BundledPackageRevision, dryRun bool) ([]string, []string, error)) *MockCaDEngine_ImportRepository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListPackageRevisions provides a mock function for the type MockCaDEngine
 func (_mock *MockCaDEngine) ListPackageRevisions(ctx context.Context, filter repository.ListPackageRevisionFilter) ([]repository.PackageRevision, error) {
 	ret := _mock.Called(ctx, filter)
@@ -444,6 +737,248 @@ func (_c *MockCaDEngine_ObjectCache_Call) RunAndReturn(run func() engine.Watcher
 	return _c
 }
 
+// PreviewUpgrade provides a mock function for the type MockCaDEngine
+func (_mock *MockCaDEngine) PreviewUpgrade(ctx context.Context, namespace string, spec *v1alpha10.PackageUpgradeTaskSpec) ([]v1alpha10.UpgradePreviewResource, error) {
+	ret := _mock.Called(ctx, namespace, spec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewUpgrade")
+	}
+
+	var r0 []v1alpha10.UpgradePreviewResource
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *v1alpha10.PackageUpgradeTaskSpec) ([]v1alpha10.UpgradePreviewResource, error)); ok {
+		return returnFunc(ctx, namespace, spec)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *v1alpha10.PackageUpgradeTaskSpec) []v1alpha10.UpgradePreviewResource); ok {
+		r0 = returnFunc(ctx, namespace, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1alpha10.UpgradePreviewResource)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *v1alpha10.PackageUpgradeTaskSpec) error); ok {
+		r1 = returnFunc(ctx, namespace, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCaDEngine_PreviewUpgrade_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewUpgrade'
+type MockCaDEngine_PreviewUpgrade_Call struct {
+	*mock.Call
+}
+
+// PreviewUpgrade is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - spec *v1alpha10.PackageUpgradeTaskSpec
+func (_e *MockCaDEngine_Expecter) PreviewUpgrade(ctx interface{}, namespace interface{}, spec interface{}) *MockCaDEngine_PreviewUpgrade_Call {
+	return &MockCaDEngine_PreviewUpgrade_Call{Call: _e.mock.On("PreviewUpgrade", ctx, namespace, spec)}
+}
+
+func (_c *MockCaDEngine_PreviewUpgrade_Call) Run(run func(ctx context.Context, namespace string, spec *v1alpha10.PackageUpgradeTaskSpec)) *MockCaDEngine_PreviewUpgrade_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *v1alpha10.PackageUpgradeTaskSpec
+		if args[2] != nil {
+			arg2 = args[2].(*v1alpha10.PackageUpgradeTaskSpec)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCaDEngine_PreviewUpgrade_Call) Return(upgradePreviewResources []v1alpha10.UpgradePreviewResource, err error) *MockCaDEngine_PreviewUpgrade_Call {
+	_c.Call.Return(upgradePreviewResources, err)
+	return _c
+}
+
+func (_c *MockCaDEngine_PreviewUpgrade_Call) RunAndReturn(run func(ctx context.Context, namespace string, spec *v1alpha10.PackageUpgradeTaskSpec) ([]v1alpha10.UpgradePreviewResource, error)) *MockCaDEngine_PreviewUpgrade_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenderPackageResources provides a mock function for the type MockCaDEngine
+func (_mock *MockCaDEngine) RenderPackageResources(ctx context.Context, namespace string, resources map[string]string) (map[string]string, *v1alpha10.RenderStatus, error) {
+	ret := _mock.Called(ctx, namespace, resources)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderPackageResources")
+	}
+
+	var r0 map[string]string
+	var r1 *v1alpha10.RenderStatus
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, map[string]string) (map[string]string, *v1alpha10.RenderStatus, error)); ok {
+		return returnFunc(ctx, namespace, resources)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, map[string]string) map[string]string); ok {
+		r0 = returnFunc(ctx, namespace, resources)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, map[string]string) *v1alpha10.RenderStatus); ok {
+		r1 = returnFunc(ctx, namespace, resources)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*v1alpha10.RenderStatus)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, map[string]string) error); ok {
+		r2 = returnFunc(ctx, namespace, resources)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockCaDEngine_RenderPackageResources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenderPackageResources'
+type MockCaDEngine_RenderPackageResources_Call struct {
+	*mock.Call
+}
+
+// RenderPackageResources is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - resources map[string]string
+func (_e *MockCaDEngine_Expecter) RenderPackageResources(ctx interface{}, namespace interface{}, resources interface{}) *MockCaDEngine_RenderPackageResources_Call {
+	return &MockCaDEngine_RenderPackageResources_Call{Call: _e.mock.On("RenderPackageResources", ctx, namespace, resources)}
+}
+
+func (_c *MockCaDEngine_RenderPackageResources_Call) Run(run func(ctx context.Context, namespace string, resources map[string]string)) *MockCaDEngine_RenderPackageResources_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 map[string]string
+		if args[2] != nil {
+			arg2 = args[2].(map[string]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCaDEngine_RenderPackageResources_Call) Return(stringToString map[string]string, renderStatus *v1alpha10.RenderStatus, err error) *MockCaDEngine_RenderPackageResources_Call {
+	_c.Call.Return(stringToString, renderStatus, err)
+	return _c
+}
+
+func (_c *MockCaDEngine_RenderPackageResources_Call) RunAndReturn(run func(ctx context.Context, namespace string, resources map[string]string) (map[string]string, *v1alpha10.RenderStatus, error)) *MockCaDEngine_RenderPackageResources_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePackageConditions provides a mock function for the type MockCaDEngine
+func (_mock *MockCaDEngine) UpdatePackageConditions(ctx context.Context, repositoryObj *v1alpha1.Repository, oldPackage repository.PackageRevision, old *v1alpha10.PackageRevisionResources, new *v1alpha10.PackageRevisionResources) (repository.PackageRevision, error) {
+	ret := _mock.Called(ctx, repositoryObj, oldPackage, old, new)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePackageConditions")
+	}
+
+	var r0 repository.PackageRevision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.Repository, repository.PackageRevision, *v1alpha10.PackageRevisionResources, *v1alpha10.PackageRevisionResources) (repository.PackageRevision, error)); ok {
+		return returnFunc(ctx, repositoryObj, oldPackage, old, new)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1alpha1.Repository, repository.PackageRevision, *v1alpha10.PackageRevisionResources, *v1alpha10.PackageRevisionResources) repository.PackageRevision); ok {
+		r0 = returnFunc(ctx, repositoryObj, oldPackage, old, new)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.PackageRevision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *v1alpha1.Repository, repository.PackageRevision, *v1alpha10.PackageRevisionResources, *v1alpha10.PackageRevisionResources) error); ok {
+		r1 = returnFunc(ctx, repositoryObj, oldPackage, old, new)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCaDEngine_UpdatePackageConditions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePackageConditions'
+type MockCaDEngine_UpdatePackageConditions_Call struct {
+	*mock.Call
+}
+
+// UpdatePackageConditions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryObj *v1alpha1.Repository
+//   - oldPackage repository.PackageRevision
+//   - old *v1alpha10.PackageRevisionResources
+//   - new *v1alpha10.PackageRevisionResources
+func (_e *MockCaDEngine_Expecter) UpdatePackageConditions(ctx interface{}, repositoryObj interface{}, oldPackage interface{}, old interface{}, new interface{}) *MockCaDEngine_UpdatePackageConditions_Call {
+	return &MockCaDEngine_UpdatePackageConditions_Call{Call: _e.mock.On("UpdatePackageConditions", ctx, repositoryObj, oldPackage, old, new)}
+}
+
+func (_c *MockCaDEngine_UpdatePackageConditions_Call) Run(run func(ctx context.Context, repositoryObj *v1alpha1.Repository, oldPackage repository.PackageRevision, old *v1alpha10.PackageRevisionResources, new *v1alpha10.PackageRevisionResources)) *MockCaDEngine_UpdatePackageConditions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *v1alpha1.Repository
+		if args[1] != nil {
+			arg1 = args[1].(*v1alpha1.Repository)
+		}
+		var arg2 repository.PackageRevision
+		if args[2] != nil {
+			arg2 = args[2].(repository.PackageRevision)
+		}
+		var arg3 *v1alpha10.PackageRevisionResources
+		if args[3] != nil {
+			arg3 = args[3].(*v1alpha10.PackageRevisionResources)
+		}
+		var arg4 *v1alpha10.PackageRevisionResources
+		if args[4] != nil {
+			arg4 = args[4].(*v1alpha10.PackageRevisionResources)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCaDEngine_UpdatePackageConditions_Call) Return(packageRevision repository.PackageRevision, err error) *MockCaDEngine_UpdatePackageConditions_Call {
+	_c.Call.Return(packageRevision, err)
+	return _c
+}
+
+func (_c *MockCaDEngine_UpdatePackageConditions_Call) RunAndReturn(run func(ctx context.Context, repositoryObj *v1alpha1.Repository, oldPackage repository.PackageRevision, old *v1alpha10.PackageRevisionResources, new *v1alpha10.PackageRevisionResources) (repository.PackageRevision, error)) *MockCaDEngine_UpdatePackageConditions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdatePackageResources provides a mock function for the type MockCaDEngine
 func (_mock *MockCaDEngine) UpdatePackageResources(ctx context.Context, repositoryObj *v1alpha1.Repository, oldPackage repository.PackageRevision, old *v1alpha10.PackageRevisionResources, new *v1alpha10.PackageRevisionResources) (repository.PackageRevision, *v1alpha10.RenderStatus, error) {
 	ret := _mock.Called(ctx, repositoryObj, oldPackage, old, new)
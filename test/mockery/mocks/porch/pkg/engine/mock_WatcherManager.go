@@ -39,6 +39,50 @@ func (_m *MockWatcherManager) EXPECT() *MockWatcherManager_Expecter {
 	return &MockWatcherManager_Expecter{mock: &_m.Mock}
 }
 
+// ResourceVersion provides a mock function for the type MockWatcherManager
+func (_mock *MockWatcherManager) ResourceVersion() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResourceVersion")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockWatcherManager_ResourceVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResourceVersion'
+type MockWatcherManager_ResourceVersion_Call struct {
+	*mock.Call
+}
+
+// ResourceVersion is a helper method to define mock.On call
+func (_e *MockWatcherManager_Expecter) ResourceVersion() *MockWatcherManager_ResourceVersion_Call {
+	return &MockWatcherManager_ResourceVersion_Call{Call: _e.mock.On("ResourceVersion")}
+}
+
+func (_c *MockWatcherManager_ResourceVersion_Call) Run(run func()) *MockWatcherManager_ResourceVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockWatcherManager_ResourceVersion_Call) Return(s string) *MockWatcherManager_ResourceVersion_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockWatcherManager_ResourceVersion_Call) RunAndReturn(run func() string) *MockWatcherManager_ResourceVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WatchPackageRevisions provides a mock function for the type MockWatcherManager
 func (_mock *MockWatcherManager) WatchPackageRevisions(ctx context.Context, filter repository.ListPackageRevisionFilter, callback engine.ObjectWatcher) error {
 	ret := _mock.Called(ctx, filter, callback)
@@ -101,3 +145,72 @@ func (_c *MockWatcherManager_WatchPackageRevisions_Call) RunAndReturn(run func(c
 	_c.Call.Return(run)
 	return _c
 }
+
+// WatchPackageRevisionsFrom provides a mock function for the type MockWatcherManager
+func (_mock *MockWatcherManager) WatchPackageRevisionsFrom(ctx context.Context, filter repository.ListPackageRevisionFilter, resourceVersion string, callback engine.ObjectWatcher) error {
+	ret := _mock.Called(ctx, filter, resourceVersion, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchPackageRevisionsFrom")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListPackageRevisionFilter, string, engine.ObjectWatcher) error); ok {
+		r0 = returnFunc(ctx, filter, resourceVersion, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWatcherManager_WatchPackageRevisionsFrom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchPackageRevisionsFrom'
+type MockWatcherManager_WatchPackageRevisionsFrom_Call struct {
+	*mock.Call
+}
+
+// WatchPackageRevisionsFrom is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter repository.ListPackageRevisionFilter
+//   - resourceVersion string
+//   - callback engine.ObjectWatcher
+func (_e *MockWatcherManager_Expecter) WatchPackageRevisionsFrom(ctx interface{}, filter interface{}, resourceVersion interface{}, callback interface{}) *MockWatcherManager_WatchPackageRevisionsFrom_Call {
+	return &MockWatcherManager_WatchPackageRevisionsFrom_Call{Call: _e.mock.On("WatchPackageRevisionsFrom", ctx, filter, resourceVersion, callback)}
+}
+
+func (_c *MockWatcherManager_WatchPackageRevisionsFrom_Call) Run(run func(ctx context.Context, filter repository.ListPackageRevisionFilter, resourceVersion string, callback engine.ObjectWatcher)) *MockWatcherManager_WatchPackageRevisionsFrom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repository.ListPackageRevisionFilter
+		if args[1] != nil {
+			arg1 = args[1].(repository.ListPackageRevisionFilter)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 engine.ObjectWatcher
+		if args[3] != nil {
+			arg3 = args[3].(engine.ObjectWatcher)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWatcherManager_WatchPackageRevisionsFrom_Call) Return(err error) *MockWatcherManager_WatchPackageRevisionsFrom_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWatcherManager_WatchPackageRevisionsFrom_Call) RunAndReturn(run func(ctx context.Context, filter repository.ListPackageRevisionFilter, resourceVersion string, callback engine.ObjectWatcher) error) *MockWatcherManager_WatchPackageRevisionsFrom_Call {
+	_c.Call.Return(run)
+	return _c
+}